@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
@@ -50,17 +50,59 @@ type ProjectTemplate struct {
 	// The list of application namespaces to create for this project.
 	Namespaces []NamespaceTemplate `json:"namespaces"`
 
+	// The limit range applied to each namespace of the project, on every cluster of the placement.
+	// +optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+
 	// Network policies applied to namespaces in the project.
 	// +optional
 	NetworkPolicies []NetworkPolicyTemplate `json:"networkPolicies,omitempty"`
 
+	// The resource quota applied to each namespace of the project, on every cluster of the placement.
+	// +optional
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+
 	// The project security configuration.
 	// +optional
 	Security SecuritySpec `json:"security,omitempty"`
 }
 
+// GitOpsSyncPolicy specifies how Argo CD syncs the project applications from Git.
+type GitOpsSyncPolicy struct {
+	// If true, then Argo CD automatically syncs the applications when the Git revision changes.
+	// +optional
+	Automated bool `json:"automated,omitempty"`
+	// If true, then Argo CD deletes the resources that are no longer in Git. Applies only to automated syncs.
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+	// If true, then Argo CD reverts the changes made to the resources outside of Git. Applies only to automated syncs.
+	// +optional
+	SelfHeal bool `json:"selfHeal,omitempty"`
+}
+
+// GitOpsSpec specifies the Git repository from which Argo CD delivers the project applications. Argo CD must be
+// enabled on the admin cluster.
+type GitOpsSpec struct {
+	// The path of the application manifests in the Git repository. The manifests of each project namespace are in the
+	// subdirectory of the path named after the namespace.
+	Path string `json:"path"`
+	// The URL of the Git repository.
+	RepoURL string `json:"repoURL"`
+	// The Git revision to deliver, a branch, tag or commit. The default is `HEAD`.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// The sync policy of the project applications.
+	// +optional
+	SyncPolicy GitOpsSyncPolicy `json:"syncPolicy,omitempty"`
+}
+
 // VerrazzanoProjectSpec defines the desired state of a Verrazzano Project.
 type VerrazzanoProjectSpec struct {
+	// The Git repository from which Argo CD delivers the applications of the project to its namespaces on the
+	// placement clusters.
+	// +optional
+	GitOps *GitOpsSpec `json:"gitOps,omitempty"`
+
 	// Clusters on which the namespaces are to be created.
 	Placement Placement `json:"placement"`
 
@@ -68,6 +110,52 @@ type VerrazzanoProjectSpec struct {
 	Template ProjectTemplate `json:"template"`
 }
 
+// GitOpsApplicationStatus is the status of the Argo CD application of a project namespace on a cluster.
+type GitOpsApplicationStatus struct {
+	// The name of the cluster.
+	Cluster string `json:"cluster"`
+	// The Argo CD health status of the application, such as `Healthy`, `Progressing` or `Degraded`.
+	Health string `json:"health,omitempty"`
+	// The name of the project namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The Git revision last synced.
+	Revision string `json:"revision,omitempty"`
+	// The Argo CD sync status of the application, such as `Synced` or `OutOfSync`.
+	Sync string `json:"sync,omitempty"`
+}
+
+// GitOpsStatus is the status of the Argo CD delivery of a project.
+type GitOpsStatus struct {
+	// The status of the project application of each namespace on each cluster.
+	Applications []GitOpsApplicationStatus `json:"applications,omitempty"`
+	// The health status of the project, the worst health status of its applications.
+	Health string `json:"health,omitempty"`
+	// The sync status of the project, `Synced` when all its applications are synced.
+	Sync string `json:"sync,omitempty"`
+}
+
+// ProjectQuotaStatus is the resource quota usage of a project on a cluster, aggregated across the project namespaces.
+type ProjectQuotaStatus struct {
+	// The name of the cluster.
+	Cluster string `json:"cluster"`
+	// The total of the hard limits of the project resource quotas.
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+	// The total of the resources used in the project namespaces.
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+// VerrazzanoProjectStatus defines the observed state of a Verrazzano Project.
+type VerrazzanoProjectStatus struct {
+	MultiClusterResourceStatus `json:",inline"`
+
+	// The status of the Argo CD delivery of the project.
+	GitOps *GitOpsStatus `json:"gitOps,omitempty"`
+
+	// The resource quota usage of the project on each cluster.
+	Quotas []ProjectQuotaStatus `json:"quotas,omitempty"`
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=vp;vps
@@ -81,7 +169,7 @@ type VerrazzanoProject struct {
 	// The desired state of a Verrazzano Project resource.
 	Spec VerrazzanoProjectSpec `json:"spec"`
 	// The observed state of a Verrazzano Project resource.
-	Status MultiClusterResourceStatus `json:"status,omitempty"`
+	Status VerrazzanoProjectStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -99,7 +187,7 @@ func init() {
 
 // GetStatus returns the MultiClusterResourceStatus of this resource.
 func (in *VerrazzanoProject) GetStatus() MultiClusterResourceStatus {
-	return in.Status
+	return in.Status.MultiClusterResourceStatus
 }
 
 // GetPlacement returns the Placement of this resource.
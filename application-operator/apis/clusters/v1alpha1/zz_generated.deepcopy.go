@@ -9,6 +9,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/rbac/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -164,6 +165,72 @@ func (in *EmbeddedObjectMeta) DeepCopy() *EmbeddedObjectMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsApplicationStatus) DeepCopyInto(out *GitOpsApplicationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsApplicationStatus.
+func (in *GitOpsApplicationStatus) DeepCopy() *GitOpsApplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsApplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsSpec) DeepCopyInto(out *GitOpsSpec) {
+	*out = *in
+	out.SyncPolicy = in.SyncPolicy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsSpec.
+func (in *GitOpsSpec) DeepCopy() *GitOpsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsStatus) DeepCopyInto(out *GitOpsStatus) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]GitOpsApplicationStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsStatus.
+func (in *GitOpsStatus) DeepCopy() *GitOpsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsSyncPolicy) DeepCopyInto(out *GitOpsSyncPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsSyncPolicy.
+func (in *GitOpsSyncPolicy) DeepCopy() *GitOpsSyncPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsSyncPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiClusterApplicationConfiguration) DeepCopyInto(out *MultiClusterApplicationConfiguration) {
 	*out = *in
@@ -552,6 +619,35 @@ func (in *Placement) DeepCopy() *Placement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectQuotaStatus) DeepCopyInto(out *ProjectQuotaStatus) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectQuotaStatus.
+func (in *ProjectQuotaStatus) DeepCopy() *ProjectQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 	*out = *in
@@ -562,6 +658,11 @@ func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(corev1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NetworkPolicies != nil {
 		in, out := &in.NetworkPolicies, &out.NetworkPolicies
 		*out = make([]NetworkPolicyTemplate, len(*in))
@@ -569,6 +670,11 @@ func (in *ProjectTemplate) DeepCopyInto(out *ProjectTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(corev1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Security.DeepCopyInto(&out.Security)
 }
 
@@ -707,6 +813,11 @@ func (in *VerrazzanoProjectList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerrazzanoProjectSpec) DeepCopyInto(out *VerrazzanoProjectSpec) {
 	*out = *in
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsSpec)
+		**out = **in
+	}
 	in.Placement.DeepCopyInto(&out.Placement)
 	in.Template.DeepCopyInto(&out.Template)
 }
@@ -720,3 +831,31 @@ func (in *VerrazzanoProjectSpec) DeepCopy() *VerrazzanoProjectSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoProjectStatus) DeepCopyInto(out *VerrazzanoProjectStatus) {
+	*out = *in
+	in.MultiClusterResourceStatus.DeepCopyInto(&out.MultiClusterResourceStatus)
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]ProjectQuotaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoProjectStatus.
+func (in *VerrazzanoProjectStatus) DeepCopy() *VerrazzanoProjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoProjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalerTraitKind identifies the Kind for the autoscaler trait.
+const AutoscalerTraitKind string = "AutoscalerTrait"
+
+func init() {
+	SchemeBuilder.Register(&AutoscalerTrait{}, &AutoscalerTraitList{})
+}
+
+// AutoscalerTraitList contains a list of AutoscalerTrait.
+// +kubebuilder:object:root=true
+type AutoscalerTraitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoscalerTrait `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// AutoscalerTrait specifies the autoscaler trait API.
+type AutoscalerTrait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AutoscalerTraitSpec `json:"spec,omitempty"`
+	// The observed state of an autoscaler trait and related resources.
+	Status AutoscalerTraitStatus `json:"status,omitempty"`
+}
+
+// AutoscalerTraitSpec specifies the desired state of an autoscaler trait.
+type AutoscalerTraitSpec struct {
+	// The lower limit for the number of replicas to which the autoscaler can scale down. Defaults to `1`.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The upper limit for the number of replicas to which the autoscaler can scale up.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The target CPU usage, averaged across all pods of the workload. If no CPU, memory or custom
+	// metric targets are specified, the target defaults to an average CPU utilization of `80` percent.
+	// +optional
+	CPU *ResourceTarget `json:"cpu,omitempty"`
+
+	// The target memory usage, averaged across all pods of the workload.
+	// +optional
+	Memory *ResourceTarget `json:"memory,omitempty"`
+
+	// Custom metric targets, averaged across all pods of the workload. The metrics are served to the
+	// autoscaler by the Verrazzano-supplied Prometheus adapter.
+	// +optional
+	Metrics []CustomMetricTarget `json:"metrics,omitempty"`
+
+	// The scaling behavior of the autoscaler in both the up and down directions.
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+
+	// The name of the WebLogic cluster to scale. Required only for VerrazzanoWebLogicWorkload
+	// workloads that contain more than one cluster.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// The WorkloadReference of the workload to which this trait applies.
+	// This value is populated by the OAM runtime when an ApplicationConfiguration
+	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
+	// a workload resource are created from the content of the ApplicationConfiguration.
+	// The WorkloadReference is provided in the trait by OAM to ensure that the trait controller
+	// can find the workload associated with the component containing the trait within the
+	// original ApplicationConfiguration.
+	WorkloadReference oamrt.TypedReference `json:"workloadRef"`
+}
+
+// ResourceTarget defines the target usage of a container resource. Only one of the fields may be specified.
+type ResourceTarget struct {
+	// The target average utilization, as a percentage of the requested resource.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AverageUtilization *int32 `json:"averageUtilization,omitempty"`
+
+	// The target average value of the resource, for example, `500m` or `512Mi`.
+	// +optional
+	AverageValue *resource.Quantity `json:"averageValue,omitempty"`
+}
+
+// CustomMetricTarget defines the target value of a custom per-pod metric.
+type CustomMetricTarget struct {
+	// The name of the metric, as exposed by the Prometheus adapter, for example, `http_requests_per_second`.
+	Name string `json:"name"`
+
+	// The label selector used to select the metric series.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// The target average value of the metric across all pods.
+	AverageValue resource.Quantity `json:"averageValue"`
+}
+
+// AutoscalerTraitStatus defines the observed state of an autoscaler trait and related resources.
+type AutoscalerTraitStatus struct {
+	// Reconcile status of this autoscaler trait.
+	oamrt.ConditionedStatus `json:",inline"`
+
+	// The current number of replicas of the scaled resource, as last seen by the autoscaler.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// The desired number of replicas of the scaled resource, as last calculated by the autoscaler.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// Related resources affected by this autoscaler trait.
+	Resources []QualifiedResourceRelation `json:"resources,omitempty"`
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupTraitKind identifies the Kind for the backup trait.
+const BackupTraitKind string = "BackupTrait"
+
+func init() {
+	SchemeBuilder.Register(&BackupTrait{}, &BackupTraitList{})
+}
+
+// BackupTraitList contains a list of BackupTrait.
+// +kubebuilder:object:root=true
+type BackupTraitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupTrait `json:"items"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// BackupTrait specifies the backup trait API.
+type BackupTrait struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupTraitSpec `json:"spec,omitempty"`
+	// The observed state of a backup trait and related resources.
+	Status BackupTraitStatus `json:"status,omitempty"`
+}
+
+// BackupTraitSpec specifies the desired state of a backup trait.
+type BackupTraitSpec struct {
+	// A cron expression with five fields, for example, `0 2 * * *`, at which the component is backed up.
+	// If no schedule is specified, the component is backed up once, and again each time the trait is changed.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// The length of time the backups are kept, for example, `720h`. Defaults to the Velero default of 30 days.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// The name of the Velero BackupStorageLocation where the backups are stored. Defaults to the Velero
+	// default storage location.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Specifies whether to take snapshots of the persistent volumes of the component. Defaults to `true`.
+	// +optional
+	SnapshotVolumes *bool `json:"snapshotVolumes,omitempty"`
+
+	// The hooks run in the pods of the component before and after they are backed up. If not specified,
+	// hooks appropriate for the workload are used: the WebLogic managed servers are suspended, the Coherence
+	// services are suspended to flush their persistence and the MySQL tables are flushed.
+	// +optional
+	Hooks *BackupHooks `json:"hooks,omitempty"`
+
+	// The WorkloadReference of the workload to which this trait applies.
+	// This value is populated by the OAM runtime when an ApplicationConfiguration
+	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
+	// a workload resource are created from the content of the ApplicationConfiguration.
+	// The WorkloadReference is provided in the trait by OAM to ensure that the trait controller
+	// can find the workload associated with the component containing the trait within the
+	// original ApplicationConfiguration.
+	WorkloadReference oamrt.TypedReference `json:"workloadRef"`
+}
+
+// BackupHooks defines the hooks run in the pods of a component around a backup.
+type BackupHooks struct {
+	// If true, no hooks are run, including the hooks appropriate for the workload.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// The commands run in the pods before they are backed up. If specified, they replace the pre-backup
+	// hooks appropriate for the workload.
+	// +optional
+	Pre []BackupHookCommand `json:"pre,omitempty"`
+
+	// The commands run in the pods after they are backed up. If specified, they replace the post-backup
+	// hooks appropriate for the workload.
+	// +optional
+	Post []BackupHookCommand `json:"post,omitempty"`
+}
+
+// BackupHookCommand defines a command run in a container of the pods of a component.
+type BackupHookCommand struct {
+	// The name of the container in which the command is run. Defaults to the first container of the pod.
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// The command and its arguments.
+	Command []string `json:"command"`
+
+	// The maximum length of time to wait for the command to complete. Defaults to `30s`.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// BackupTraitStatus defines the observed state of a backup trait and related resources.
+type BackupTraitStatus struct {
+	// Reconcile status of this backup trait.
+	oamrt.ConditionedStatus `json:",inline"`
+
+	// The state of the last backup of the component.
+	// +optional
+	LastBackup *LastBackupStatus `json:"lastBackup,omitempty"`
+
+	// Related resources affected by this backup trait.
+	Resources []QualifiedResourceRelation `json:"resources,omitempty"`
+}
+
+// LastBackupStatus defines the state of the last Velero backup of a component.
+type LastBackupStatus struct {
+	// The name of the Velero Backup.
+	Name string `json:"name"`
+
+	// The phase of the Velero Backup, for example, `InProgress`, `Completed` or `PartiallyFailed`.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// The time at which the backup started.
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The time at which the backup completed.
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// The number of errors encountered during the backup.
+	// +optional
+	Errors int64 `json:"errors,omitempty"`
+
+	// The number of warnings encountered during the backup.
+	// +optional
+	Warnings int64 `json:"warnings,omitempty"`
+}
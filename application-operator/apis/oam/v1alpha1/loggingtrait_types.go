@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
@@ -27,6 +27,12 @@ type LoggingTraitSpec struct {
 	// +optional
 	LoggingImage string `json:"loggingImage,omitempty"`
 
+	// The Fluent Bit log processing configuration. When specified, namespaced Fluent Bit resources are generated
+	// for the workload instead of a Fluentd sidecar, and the `imagePullPolicy`, `loggingConfig` and
+	// `loggingImage` fields are ignored.
+	// +optional
+	FluentBit *FluentBitLogging `json:"fluentBit,omitempty"`
+
 	// The WorkloadReference of the workload to which this trait applies.
 	// This value is populated by the OAM runtime when an ApplicationConfiguration
 	// resource is processed.  When the ApplicationConfiguration is processed, a trait and
@@ -37,11 +43,79 @@ type LoggingTraitSpec struct {
 	WorkloadReference oamrt.TypedReference `json:"workloadRef"`
 }
 
+// FluentBitLogging specifies how Fluent Bit processes and ships the logs of a workload.
+type FluentBitLogging struct {
+	// The container log files to process, relative to `/var/log/containers`, for example,
+	// `hello-helidon-*_*_hello-helidon-container-*.log`. The star (*) character can be used as a wildcard.
+	// Defaults to the logs of all containers in the namespace of the workload.
+	// +optional
+	LogPaths []string `json:"logPaths,omitempty"`
+
+	// The parsers applied to each log record, in order. The first parser that matches a record is used.
+	// +optional
+	Parsers []FluentBitParser `json:"parsers,omitempty"`
+
+	// The multiline configuration used to concatenate log records that span multiple lines, for example,
+	// stack traces. Multiline processing is applied before the parsers.
+	// +optional
+	Multiline *FluentBitMultiline `json:"multiline,omitempty"`
+
+	// The destination of the logs. Defaults to the Verrazzano OpenSearch application log output.
+	// +optional
+	Destination *LogDestination `json:"destination,omitempty"`
+}
+
+// FluentBitParser specifies a Fluent Bit parser for log records.
+type FluentBitParser struct {
+	// The name of the parser, unique within the trait.
+	Name string `json:"name"`
+
+	// The format of the log records.
+	// +kubebuilder:validation:Enum=json;regex;logfmt
+	Format string `json:"format"`
+
+	// The regular expression, with named capture groups, used to parse the log records. Required for the
+	// `regex` format.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// The name of the parsed field that holds the time of the log record.
+	// +optional
+	TimeKey string `json:"timeKey,omitempty"`
+
+	// The format of the time field, for example, `%Y-%m-%dT%H:%M:%S.%L%z`.
+	// +optional
+	TimeFormat string `json:"timeFormat,omitempty"`
+
+	// The field of the log record to parse. Defaults to `log`.
+	// +optional
+	KeyName string `json:"keyName,omitempty"`
+}
+
+// FluentBitMultiline specifies the multiline processing of log records.
+type FluentBitMultiline struct {
+	// The names of the built-in Fluent Bit multiline parsers to apply, for example, `java`, `go` or `python`.
+	Parsers []string `json:"parsers"`
+
+	// The field of the log record that holds the content to concatenate. Defaults to `log`.
+	// +optional
+	KeyContent string `json:"keyContent,omitempty"`
+}
+
+// LogDestination specifies where the logs of a workload are sent.
+type LogDestination struct {
+	// The name of an existing Fluent Bit ClusterOutput. A namespaced Output with the configuration of the
+	// ClusterOutput is generated to send the logs of the workload to the destination of the ClusterOutput.
+	// +optional
+	ClusterOutput string `json:"clusterOutput,omitempty"`
+}
+
 // LoggingTraitStatus specifies the observed state of a logging trait and related resources.
 type LoggingTraitStatus struct {
 	// Reconcile status of this logging trait.
 	oamrt.ConditionedStatus `json:",inline"`
-	// The resources managed by this logging trait.
+	// The resources managed by this logging trait. When Fluent Bit log processing is configured, these are the
+	// generated Fluent Bit resources.
 	Resources []oamrt.TypedReference `json:"resources,omitempty"`
 }
 
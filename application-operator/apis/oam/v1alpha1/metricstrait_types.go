@@ -1,16 +1,24 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1alpha1
 
 import (
 	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MetricsTraitKind identifies the Kind for the metrics trait.
 const MetricsTraitKind string = "MetricsTrait"
 
+const (
+	// ServiceMonitorType identifies a Prometheus Operator ServiceMonitor
+	ServiceMonitorType = "ServiceMonitor"
+	// PodMonitorType identifies a Prometheus Operator PodMonitor
+	PodMonitorType = "PodMonitor"
+)
+
 func init() {
 	SchemeBuilder.Register(&MetricsTrait{}, &MetricsTraitList{})
 }
@@ -55,6 +63,51 @@ type MetricsTraitSpec struct {
 	// +optional
 	Ports []PortSpec `json:"ports,omitempty"`
 
+	// The interval at which the metrics endpoint is scraped, for example, `30s`. Defaults to the
+	// scrape interval of the scraper.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// The timeout of a scrape of the metrics endpoint, for example, `10s`. Defaults to the scrape
+	// timeout of the scraper.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	ScrapeTimeout *string `json:"scrapeTimeout,omitempty"`
+
+	// The kind of Prometheus Operator monitor created for the metrics endpoints, either `ServiceMonitor` or
+	// `PodMonitor`. A PodMonitor scrapes the pods of the workload directly, the metrics ports must be declared
+	// as container ports. PodMonitors are not supported for workloads in Istio-enabled namespaces.
+	// Defaults to `ServiceMonitor`.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	// +optional
+	MonitorType *string `json:"monitorType,omitempty"`
+
+	// The relabeling rules applied to the scraped metrics before they are ingested.
+	// +optional
+	MetricRelabelings []MetricRelabeling `json:"metricRelabelings,omitempty"`
+
+	// Regular expressions matching the names of the metrics to drop before they are ingested,
+	// for example, `jvm_threads_.*`.
+	// +optional
+	DropMetrics []string `json:"dropMetrics,omitempty"`
+
+	// The TLS configuration used to scrape the metrics endpoints. Ignored for workloads in Istio-enabled
+	// namespaces, which are scraped using Istio mutual TLS.
+	// +optional
+	TLS *MetricsTLSConfig `json:"tls,omitempty"`
+
+	// The key of a secret within the workload’s namespace that holds the bearer token for metrics
+	// endpoint access.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+
+	// The name of an OpenTelemetry Collector that collects the metrics instead of Prometheus. The monitor is
+	// labeled with `verrazzano.io/otel-collector: <name>` instead of the label selected by the Verrazzano
+	// Prometheus, and must be selected by the target allocator of the collector.
+	// +optional
+	OpenTelemetryCollector *string `json:"openTelemetryCollector,omitempty"`
+
 	// The Prometheus deployment used to scrape the related metrics endpoints. By default, the Verrazzano-supplied
 	// Prometheus component is used to scrape the endpoint.
 	// +optional
@@ -84,6 +137,68 @@ type PortSpec struct {
 	// The HTTP port for the related metrics trait. Defaults to `8080`.
 	// +optional
 	Port *int `json:"port,omitempty"`
+
+	// The interval at which the metrics endpoint is scraped. Overrides the interval of the trait.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// The timeout of a scrape of the metrics endpoint. Overrides the scrape timeout of the trait.
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	// +optional
+	ScrapeTimeout *string `json:"scrapeTimeout,omitempty"`
+}
+
+// MetricRelabeling defines a Prometheus relabeling rule applied to scraped metrics.
+type MetricRelabeling struct {
+	// The source labels whose values are concatenated and matched against the regular expression.
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// The separator placed between concatenated source label values. Defaults to `;`.
+	// +optional
+	Separator string `json:"separator,omitempty"`
+
+	// The label to which the resulting value is written for the `replace` action.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+
+	// The regular expression matched against the concatenated source label values. Defaults to `(.*)`.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// The replacement value for the `replace` action. Defaults to `$1`.
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// The action to perform. Defaults to `replace`.
+	// +kubebuilder:validation:Enum=replace;keep;drop;labelmap;labeldrop;labelkeep;hashmod
+	// +optional
+	Action string `json:"action,omitempty"`
+}
+
+// MetricsTLSConfig defines the TLS configuration used to scrape metrics endpoints. The secrets must be in the
+// workload’s namespace.
+type MetricsTLSConfig struct {
+	// The secret key that holds the certificate authority used to verify the endpoint certificate.
+	// +optional
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+
+	// The secret key that holds the client certificate presented to the endpoint.
+	// +optional
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+
+	// The secret key that holds the private key of the client certificate.
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+
+	// The server name used to verify the endpoint certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Disables the verification of the endpoint certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 // MetricsTraitStatus defines the observed state of a metrics trait and related resources.
@@ -0,0 +1,182 @@
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	scheme "github.com/verrazzano/verrazzano/application-operator/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// AutoscalerTraitsGetter has a method to return a AutoscalerTraitInterface.
+// A group's client should implement this interface.
+type AutoscalerTraitsGetter interface {
+	AutoscalerTraits(namespace string) AutoscalerTraitInterface
+}
+
+// AutoscalerTraitInterface has methods to work with AutoscalerTrait resources.
+type AutoscalerTraitInterface interface {
+	Create(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.CreateOptions) (*v1alpha1.AutoscalerTrait, error)
+	Update(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (*v1alpha1.AutoscalerTrait, error)
+	UpdateStatus(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (*v1alpha1.AutoscalerTrait, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.AutoscalerTrait, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.AutoscalerTraitList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AutoscalerTrait, err error)
+	AutoscalerTraitExpansion
+}
+
+// autoscalerTraits implements AutoscalerTraitInterface
+type autoscalerTraits struct {
+	client rest.Interface
+	ns     string
+}
+
+// newAutoscalerTraits returns a AutoscalerTraits
+func newAutoscalerTraits(c *OamV1alpha1Client, namespace string) *autoscalerTraits {
+	return &autoscalerTraits{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the autoscalerTrait, and returns the corresponding autoscalerTrait object, and an error if there is any.
+func (c *autoscalerTraits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	result = &v1alpha1.AutoscalerTrait{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of AutoscalerTraits that match those selectors.
+func (c *autoscalerTraits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.AutoscalerTraitList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.AutoscalerTraitList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested autoscalerTraits.
+func (c *autoscalerTraits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a autoscalerTrait and creates it.  Returns the server's representation of the autoscalerTrait, and an error, if there is any.
+func (c *autoscalerTraits) Create(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.CreateOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	result = &v1alpha1.AutoscalerTrait{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(autoscalerTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a autoscalerTrait and updates it. Returns the server's representation of the autoscalerTrait, and an error, if there is any.
+func (c *autoscalerTraits) Update(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	result = &v1alpha1.AutoscalerTrait{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		Name(autoscalerTrait.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(autoscalerTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *autoscalerTraits) UpdateStatus(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	result = &v1alpha1.AutoscalerTrait{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		Name(autoscalerTrait.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(autoscalerTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the autoscalerTrait and deletes it. Returns an error if one occurs.
+func (c *autoscalerTraits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *autoscalerTraits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched autoscalerTrait.
+func (c *autoscalerTraits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AutoscalerTrait, err error) {
+	result = &v1alpha1.AutoscalerTrait{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("autoscalertraits").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
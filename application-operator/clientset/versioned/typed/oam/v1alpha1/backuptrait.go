@@ -0,0 +1,182 @@
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	scheme "github.com/verrazzano/verrazzano/application-operator/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// BackupTraitsGetter has a method to return a BackupTraitInterface.
+// A group's client should implement this interface.
+type BackupTraitsGetter interface {
+	BackupTraits(namespace string) BackupTraitInterface
+}
+
+// BackupTraitInterface has methods to work with BackupTrait resources.
+type BackupTraitInterface interface {
+	Create(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.CreateOptions) (*v1alpha1.BackupTrait, error)
+	Update(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (*v1alpha1.BackupTrait, error)
+	UpdateStatus(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (*v1alpha1.BackupTrait, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.BackupTrait, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.BackupTraitList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BackupTrait, err error)
+	BackupTraitExpansion
+}
+
+// backupTraits implements BackupTraitInterface
+type backupTraits struct {
+	client rest.Interface
+	ns     string
+}
+
+// newBackupTraits returns a BackupTraits
+func newBackupTraits(c *OamV1alpha1Client, namespace string) *backupTraits {
+	return &backupTraits{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the backupTrait, and returns the corresponding backupTrait object, and an error if there is any.
+func (c *backupTraits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.BackupTrait, err error) {
+	result = &v1alpha1.BackupTrait{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BackupTraits that match those selectors.
+func (c *backupTraits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.BackupTraitList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.BackupTraitList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested backupTraits.
+func (c *backupTraits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a backupTrait and creates it.  Returns the server's representation of the backupTrait, and an error, if there is any.
+func (c *backupTraits) Create(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.CreateOptions) (result *v1alpha1.BackupTrait, err error) {
+	result = &v1alpha1.BackupTrait{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(backupTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a backupTrait and updates it. Returns the server's representation of the backupTrait, and an error, if there is any.
+func (c *backupTraits) Update(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (result *v1alpha1.BackupTrait, err error) {
+	result = &v1alpha1.BackupTrait{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		Name(backupTrait.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(backupTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *backupTraits) UpdateStatus(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (result *v1alpha1.BackupTrait, err error) {
+	result = &v1alpha1.BackupTrait{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		Name(backupTrait.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(backupTrait).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the backupTrait and deletes it. Returns an error if one occurs.
+func (c *backupTraits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *backupTraits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("backuptraits").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched backupTrait.
+func (c *backupTraits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BackupTrait, err error) {
+	result = &v1alpha1.BackupTrait{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("backuptraits").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAutoscalerTraits implements AutoscalerTraitInterface
+type FakeAutoscalerTraits struct {
+	Fake *FakeOamV1alpha1
+	ns   string
+}
+
+var autoscalertraitsResource = schema.GroupVersionResource{Group: "oam.verrazzano.io", Version: "v1alpha1", Resource: "autoscalertraits"}
+
+var autoscalertraitsKind = schema.GroupVersionKind{Group: "oam.verrazzano.io", Version: "v1alpha1", Kind: "AutoscalerTrait"}
+
+// Get takes name of the autoscalerTrait, and returns the corresponding autoscalerTrait object, and an error if there is any.
+func (c *FakeAutoscalerTraits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(autoscalertraitsResource, c.ns, name), &v1alpha1.AutoscalerTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AutoscalerTrait), err
+}
+
+// List takes label and field selectors, and returns the list of AutoscalerTraits that match those selectors.
+func (c *FakeAutoscalerTraits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.AutoscalerTraitList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(autoscalertraitsResource, autoscalertraitsKind, c.ns, opts), &v1alpha1.AutoscalerTraitList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.AutoscalerTraitList{ListMeta: obj.(*v1alpha1.AutoscalerTraitList).ListMeta}
+	for _, item := range obj.(*v1alpha1.AutoscalerTraitList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested autoscalerTraits.
+func (c *FakeAutoscalerTraits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(autoscalertraitsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a autoscalerTrait and creates it.  Returns the server's representation of the autoscalerTrait, and an error, if there is any.
+func (c *FakeAutoscalerTraits) Create(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.CreateOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(autoscalertraitsResource, c.ns, autoscalerTrait), &v1alpha1.AutoscalerTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AutoscalerTrait), err
+}
+
+// Update takes the representation of a autoscalerTrait and updates it. Returns the server's representation of the autoscalerTrait, and an error, if there is any.
+func (c *FakeAutoscalerTraits) Update(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (result *v1alpha1.AutoscalerTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(autoscalertraitsResource, c.ns, autoscalerTrait), &v1alpha1.AutoscalerTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AutoscalerTrait), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAutoscalerTraits) UpdateStatus(ctx context.Context, autoscalerTrait *v1alpha1.AutoscalerTrait, opts v1.UpdateOptions) (*v1alpha1.AutoscalerTrait, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(autoscalertraitsResource, "status", c.ns, autoscalerTrait), &v1alpha1.AutoscalerTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AutoscalerTrait), err
+}
+
+// Delete takes name of the autoscalerTrait and deletes it. Returns an error if one occurs.
+func (c *FakeAutoscalerTraits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(autoscalertraitsResource, c.ns, name, opts), &v1alpha1.AutoscalerTrait{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAutoscalerTraits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(autoscalertraitsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.AutoscalerTraitList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched autoscalerTrait.
+func (c *FakeAutoscalerTraits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AutoscalerTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(autoscalertraitsResource, c.ns, name, pt, data, subresources...), &v1alpha1.AutoscalerTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.AutoscalerTrait), err
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeBackupTraits implements BackupTraitInterface
+type FakeBackupTraits struct {
+	Fake *FakeOamV1alpha1
+	ns   string
+}
+
+var backuptraitsResource = schema.GroupVersionResource{Group: "oam.verrazzano.io", Version: "v1alpha1", Resource: "backuptraits"}
+
+var backuptraitsKind = schema.GroupVersionKind{Group: "oam.verrazzano.io", Version: "v1alpha1", Kind: "BackupTrait"}
+
+// Get takes name of the backupTrait, and returns the corresponding backupTrait object, and an error if there is any.
+func (c *FakeBackupTraits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.BackupTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(backuptraitsResource, c.ns, name), &v1alpha1.BackupTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BackupTrait), err
+}
+
+// List takes label and field selectors, and returns the list of BackupTraits that match those selectors.
+func (c *FakeBackupTraits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.BackupTraitList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(backuptraitsResource, backuptraitsKind, c.ns, opts), &v1alpha1.BackupTraitList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.BackupTraitList{ListMeta: obj.(*v1alpha1.BackupTraitList).ListMeta}
+	for _, item := range obj.(*v1alpha1.BackupTraitList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested backupTraits.
+func (c *FakeBackupTraits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(backuptraitsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a backupTrait and creates it.  Returns the server's representation of the backupTrait, and an error, if there is any.
+func (c *FakeBackupTraits) Create(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.CreateOptions) (result *v1alpha1.BackupTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(backuptraitsResource, c.ns, backupTrait), &v1alpha1.BackupTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BackupTrait), err
+}
+
+// Update takes the representation of a backupTrait and updates it. Returns the server's representation of the backupTrait, and an error, if there is any.
+func (c *FakeBackupTraits) Update(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (result *v1alpha1.BackupTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(backuptraitsResource, c.ns, backupTrait), &v1alpha1.BackupTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BackupTrait), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBackupTraits) UpdateStatus(ctx context.Context, backupTrait *v1alpha1.BackupTrait, opts v1.UpdateOptions) (*v1alpha1.BackupTrait, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(backuptraitsResource, "status", c.ns, backupTrait), &v1alpha1.BackupTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BackupTrait), err
+}
+
+// Delete takes name of the backupTrait and deletes it. Returns an error if one occurs.
+func (c *FakeBackupTraits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(backuptraitsResource, c.ns, name, opts), &v1alpha1.BackupTrait{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeBackupTraits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(backuptraitsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.BackupTraitList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched backupTrait.
+func (c *FakeBackupTraits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BackupTrait, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(backuptraitsResource, c.ns, name, pt, data, subresources...), &v1alpha1.BackupTrait{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BackupTrait), err
+}
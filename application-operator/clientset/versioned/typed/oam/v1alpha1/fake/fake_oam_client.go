@@ -15,6 +15,14 @@ type FakeOamV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeOamV1alpha1) AutoscalerTraits(namespace string) v1alpha1.AutoscalerTraitInterface {
+	return &FakeAutoscalerTraits{c, namespace}
+}
+
+func (c *FakeOamV1alpha1) BackupTraits(namespace string) v1alpha1.BackupTraitInterface {
+	return &FakeBackupTraits{c, namespace}
+}
+
 func (c *FakeOamV1alpha1) IngressTraits(namespace string) v1alpha1.IngressTraitInterface {
 	return &FakeIngressTraits{c, namespace}
 }
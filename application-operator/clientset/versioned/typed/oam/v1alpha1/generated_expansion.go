@@ -5,6 +5,10 @@
 
 package v1alpha1
 
+type AutoscalerTraitExpansion interface{}
+
+type BackupTraitExpansion interface{}
+
 type IngressTraitExpansion interface{}
 
 type LoggingTraitExpansion interface{}
@@ -15,6 +15,8 @@ import (
 
 type OamV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AutoscalerTraitsGetter
+	BackupTraitsGetter
 	IngressTraitsGetter
 	LoggingTraitsGetter
 	MetricsTraitsGetter
@@ -28,6 +30,14 @@ type OamV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *OamV1alpha1Client) AutoscalerTraits(namespace string) AutoscalerTraitInterface {
+	return newAutoscalerTraits(c, namespace)
+}
+
+func (c *OamV1alpha1Client) BackupTraits(namespace string) BackupTraitInterface {
+	return newBackupTraits(c, namespace)
+}
+
 func (c *OamV1alpha1Client) IngressTraits(namespace string) IngressTraitInterface {
 	return newIngressTraits(c, namespace)
 }
@@ -129,3 +129,29 @@ const LegacyDefaultMetricsTemplateNamespace = VerrazzanoSystemNamespace
 // DefaultScraperName is the default Prometheus deployment name used to scrape metrics. If a metrics trait does not specify a scraper, this
 // is the scraper that will be used.
 const DefaultScraperName = "verrazzano-system/vmi-system-prometheus-0"
+
+// AutoscalerTraitAnnotation is the annotation placed on a resource scaled by an autoscaler trait. The value is the
+// namespaced name of the trait. The Helidon and Coherence workload controllers preserve the replica count of the
+// annotated Deployments and Coherence resources, the other scale targets are not rewritten by Verrazzano.
+const AutoscalerTraitAnnotation = "verrazzano.io/autoscaler-trait"
+
+// VeleroNamespace is the namespace of the Velero backup resources generated by backup traits
+const VeleroNamespace = "verrazzano-backup"
+
+// BackupAppLabel is the label placed by backup traits on the ApplicationConfiguration, Component and PersistentVolumeClaim
+// resources of an application, so that they are selected by the Velero backups. The value is the name of the application.
+const BackupAppLabel = "verrazzano.io/backup-app"
+
+// BackupComponentLabel is the label placed by backup traits on the Component and PersistentVolumeClaim resources of
+// a backed up component. The value is the name of the component.
+const BackupComponentLabel = "verrazzano.io/backup-component"
+
+// NetworkPolicyModeAnnotation is the annotation on an ApplicationConfiguration, or its namespace, that selects how the
+// generated network policies of the application are applied. The value is one of `audit` (the default), `enforce`
+// or `disabled`. In audit mode the policies are only rendered into the `<app>-network-policies` ConfigMap; review them
+// there before setting the annotation to `enforce`.
+const NetworkPolicyModeAnnotation = "verrazzano.io/network-policy-mode"
+
+// NetworkPolicyAppLabel is the label placed on the NetworkPolicy, AuthorizationPolicy and ConfigMap resources generated
+// for the components of an application. The value is the name of the application.
+const NetworkPolicyAppLabel = "verrazzano.io/network-policy-app"
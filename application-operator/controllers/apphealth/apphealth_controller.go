@@ -0,0 +1,153 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlogInit "github.com/verrazzano/verrazzano/pkg/log"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "apphealth"
+
+	// HealthyConditionType is the type of the application configuration status condition that rolls up
+	// the health of the application
+	HealthyConditionType oamrt.ConditionType = "verrazzano.io/Healthy"
+
+	// Reasons of the health condition
+	ReasonHealthy   oamrt.ConditionReason = "Healthy"
+	ReasonUnhealthy oamrt.ConditionReason = "Unhealthy"
+
+	// The health of the resources of an application is not watched, so it is checked periodically
+	healthCheckInterval = time.Minute
+)
+
+// Reconciler computes the health of an ApplicationConfiguration
+type Reconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager registers our controller with the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			RateLimiter: controllers.NewDefaultRateLimiter(),
+		}).
+		For(&oamv1.ApplicationConfiguration{}).
+		Complete(r)
+}
+
+// Reconcile builds the dependency graph of an ApplicationConfiguration and rolls up the health of the
+// resources in the graph into a status condition of the ApplicationConfiguration.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlogInit.FieldResourceNamespace, req.Namespace, vzlogInit.FieldResourceName, req.Name, vzlogInit.FieldController, controllerName)
+		log.Infof("Application configuration resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	var appConfig oamv1.ApplicationConfiguration
+	if err := r.Get(ctx, req.NamespacedName, &appConfig); err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	if !appConfig.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	log, err := clusters.GetResourceLogger(controllerName, req.NamespacedName, &appConfig)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for application configuration resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling health of application configuration resource %v, generation %v", req.NamespacedName, appConfig.Generation)
+
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err := r.updateHealthStatus(ctx, &appConfig, log); err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	return reconcile.Result{RequeueAfter: healthCheckInterval}, nil
+}
+
+// updateHealthStatus updates the health condition of the application configuration if it has changed
+func (r *Reconciler) updateHealthStatus(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, log vzlog.VerrazzanoLogger) error {
+	root, err := BuildGraph(ctx, r.Client, log, appConfig)
+	if err != nil {
+		log.Errorf("Failed to build the dependency graph of application configuration %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		return err
+	}
+	condition := NewHealthCondition(root)
+	if appConfig.Status.GetCondition(HealthyConditionType).Equal(condition) {
+		return nil
+	}
+	log.Debugf("Setting the health of application configuration %s/%s to %s", appConfig.Namespace, appConfig.Name, condition.Status)
+	appConfig.Status.SetConditions(condition)
+	if err := r.Status().Update(ctx, appConfig); err != nil {
+		return vzlogInit.ConflictWithLog(fmt.Sprintf("Failed to update the health of application configuration %s/%s", appConfig.Namespace, appConfig.Name), err, zap.S())
+	}
+	return nil
+}
+
+// NewHealthCondition creates the health condition of an application from its dependency graph.  The message
+// of an unhealthy condition lists the reason of each unhealthy component.
+func NewHealthCondition(root *Node) oamrt.Condition {
+	condition := oamrt.Condition{
+		Type:               HealthyConditionType,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+	if root.Healthy {
+		return condition
+	}
+	condition.Status = corev1.ConditionFalse
+	condition.Reason = ReasonUnhealthy
+
+	reasons := ComponentReasons(root)
+	if len(reasons) == 0 {
+		condition.Message = root.Reason
+		return condition
+	}
+	var names []string
+	for name := range reasons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var messages []string
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("component %s: %s", name, reasons[name]))
+	}
+	condition.Message = strings.Join(messages, "; ")
+	return condition
+}
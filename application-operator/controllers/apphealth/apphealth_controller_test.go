@@ -0,0 +1,72 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	asserts "github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// reconcileTestAppConfig reconciles the test application configuration and returns the updated resource
+func reconcileTestAppConfig(t *testing.T, objs ...client.Object) (ctrl.Result, *oamv1.ApplicationConfiguration) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(append(objs, newTestAppConfig())...).Build()
+	reconciler := Reconciler{Client: cli, Log: zap.S(), Scheme: newScheme()}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testAppName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(t, err)
+
+	appConfig := &oamv1.ApplicationConfiguration{}
+	asserts.NoError(t, cli.Get(context.TODO(), request.NamespacedName, appConfig))
+	return result, appConfig
+}
+
+// TestReconcileHealthy tests the reconcile of a healthy application
+// GIVEN an application whose resources are all healthy
+// WHEN the application configuration is reconciled
+// THEN the health condition is true and the reconcile is requeued to check the health again
+func TestReconcileHealthy(t *testing.T) {
+	assert := asserts.New(t)
+	result, appConfig := reconcileTestAppConfig(t, newTestDeployment(1), newTestIngressTrait())
+
+	condition := appConfig.Status.GetCondition(HealthyConditionType)
+	assert.Equal(corev1.ConditionTrue, condition.Status)
+	assert.Equal(ReasonHealthy, condition.Reason)
+	assert.Equal(healthCheckInterval, result.RequeueAfter)
+}
+
+// TestReconcileUnhealthy tests the reconcile of an unhealthy application
+// GIVEN an application with a Deployment that is not ready
+// WHEN the application configuration is reconciled
+// THEN the health condition is false with the reason of the unhealthy component
+func TestReconcileUnhealthy(t *testing.T) {
+	assert := asserts.New(t)
+	_, appConfig := reconcileTestAppConfig(t, newTestDeployment(0), newTestIngressTrait())
+
+	condition := appConfig.Status.GetCondition(HealthyConditionType)
+	assert.Equal(corev1.ConditionFalse, condition.Status)
+	assert.Equal(ReasonUnhealthy, condition.Reason)
+	assert.Equal("component hello-component: Deployment hello-deployment has 0/1 ready replicas", condition.Message)
+}
+
+// TestReconcileKubeSystem tests that application configurations in the kube-system namespace are ignored
+// GIVEN an application configuration in the kube-system namespace
+// WHEN the application configuration is reconciled
+// THEN nothing is done
+func TestReconcileKubeSystem(t *testing.T) {
+	reconciler := Reconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).Build(), Log: zap.S()}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: vzconst.KubeSystem, Name: testAppName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(t, err)
+	asserts.True(t, result.IsZero())
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"context"
+	"fmt"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const componentKind = "Component"
+
+// Node is a resource in the dependency graph of an application.  The health of a node is rolled up from
+// the health of the resource and the health of its children.
+type Node struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	// Healthy is true if the resource and all of its children are healthy
+	Healthy bool
+	// Reason describes why the node is not healthy
+	Reason   string
+	Children []*Node
+}
+
+// BuildGraph builds the dependency graph of an application configuration.  The application configuration
+// is the root of the graph, followed by the components, their workloads and traits, and the
+// Kubernetes and Istio resources generated for the workloads and traits.
+func BuildGraph(ctx context.Context, cli client.Reader, log vzlog.VerrazzanoLogger, appConfig *oamv1.ApplicationConfiguration) (*Node, error) {
+	root := &Node{
+		APIVersion: oamv1.SchemeGroupVersion.String(),
+		Kind:       oamv1.ApplicationConfigurationKind,
+		Namespace:  appConfig.Namespace,
+		Name:       appConfig.Name,
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appConfig)
+	if err != nil {
+		return nil, err
+	}
+	root.Healthy, root.Reason = evaluateHealth(&unstructured.Unstructured{Object: u})
+
+	for _, wlStatus := range appConfig.Status.Workloads {
+		component := &Node{
+			APIVersion: oamv1.SchemeGroupVersion.String(),
+			Kind:       componentKind,
+			Namespace:  appConfig.Namespace,
+			Name:       wlStatus.ComponentName,
+			Healthy:    true,
+		}
+		workload, err := buildReferenceNode(ctx, cli, log, appConfig.Namespace, wlStatus.Reference, true)
+		if err != nil {
+			return nil, err
+		}
+		component.Children = append(component.Children, workload)
+		for _, trait := range wlStatus.Traits {
+			traitNode, err := buildReferenceNode(ctx, cli, log, appConfig.Namespace, trait.Reference, false)
+			if err != nil {
+				return nil, err
+			}
+			component.Children = append(component.Children, traitNode)
+		}
+		rollUp(component)
+		root.Children = append(root.Children, component)
+	}
+	rollUp(root)
+	return root, nil
+}
+
+// ComponentReasons returns the reasons that the components of an application are not healthy, keyed by
+// component name
+func ComponentReasons(root *Node) map[string]string {
+	reasons := map[string]string{}
+	for _, child := range root.Children {
+		if child.Kind == componentKind && !child.Healthy {
+			reasons[child.Name] = child.Reason
+		}
+	}
+	return reasons
+}
+
+// buildReferenceNode builds the node of a workload or trait referenced by the application configuration
+// along with the nodes of the resources generated for it
+func buildReferenceNode(ctx context.Context, cli client.Reader, log vzlog.VerrazzanoLogger, namespace string, ref oamrt.TypedReference, isWorkload bool) (*Node, error) {
+	node := &Node{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: namespace, Name: ref.Name}
+	u, err := fetchUnstructured(ctx, cli, ref.APIVersion, ref.Kind, namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		node.Reason = notFoundReason(node)
+		return node, nil
+	}
+	node.Healthy, node.Reason = evaluateHealth(u)
+
+	var children []*Node
+	if isWorkload {
+		children, err = fetchWorkloadResources(ctx, cli, log, u)
+	} else {
+		children, err = fetchTraitResources(ctx, cli, u)
+	}
+	if err != nil {
+		return nil, err
+	}
+	node.Children = children
+	rollUp(node)
+	return node, nil
+}
+
+// fetchWorkloadResources returns the resources generated for a workload.  For Verrazzano workloads this
+// includes the contained workload, for example, the Domain of a VerrazzanoWebLogicWorkload.
+func fetchWorkloadResources(ctx context.Context, cli client.Reader, log vzlog.VerrazzanoLogger, workload *unstructured.Unstructured) ([]*Node, error) {
+	var resources []*Node
+	seen := map[types.UID]bool{workload.GetUID(): true}
+	if vznav.IsVerrazzanoWorkloadKind(workload) {
+		contained, err := vznav.FetchContainedWorkload(ctx, cli, workload)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		if err == nil {
+			seen[contained.GetUID()] = true
+			resources = append(resources, newResourceNode(contained))
+		}
+	}
+
+	// Workloads without a workload definition have no generated resources
+	workloadDef := oamv1.WorkloadDefinition{}
+	err := cli.Get(ctx, vznav.GetDefinitionOfResource(workload.GetAPIVersion(), workload.GetKind()), &workloadDef)
+	if k8serrors.IsNotFound(err) {
+		return resources, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	children, err := vznav.FetchUnstructuredChildResourcesByAPIVersionKinds(ctx, cli, log, workload.GetNamespace(), workload.GetUID(), workloadDef.Spec.ChildResourceKinds)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		if !seen[child.GetUID()] {
+			seen[child.GetUID()] = true
+			resources = append(resources, newResourceNode(child))
+		}
+	}
+	return resources, nil
+}
+
+// fetchTraitResources returns the resources listed in the status of a trait
+func fetchTraitResources(ctx context.Context, cli client.Reader, trait *unstructured.Unstructured) ([]*Node, error) {
+	refs, _, err := unstructured.NestedSlice(trait.Object, "status", "resources")
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Node
+	for _, ref := range refs {
+		refMap, ok := ref.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		apiVersion, _, _ := unstructured.NestedString(refMap, "apiVersion")
+		kind, _, _ := unstructured.NestedString(refMap, "kind")
+		name, _, _ := unstructured.NestedString(refMap, "name")
+		namespace, _, _ := unstructured.NestedString(refMap, "namespace")
+		if len(namespace) == 0 {
+			namespace = trait.GetNamespace()
+		}
+		u, err := fetchUnstructured(ctx, cli, apiVersion, kind, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			node := &Node{APIVersion: apiVersion, Kind: kind, Namespace: namespace, Name: name}
+			node.Reason = notFoundReason(node)
+			resources = append(resources, node)
+			continue
+		}
+		resources = append(resources, newResourceNode(u))
+	}
+	return resources, nil
+}
+
+// fetchUnstructured fetches a resource as unstructured.  Returns nil for the resource and no error if the
+// resource or its kind does not exist.
+func fetchUnstructured(ctx context.Context, cli client.Reader, apiVersion string, kind string, namespace string, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, u)
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// newResourceNode creates the node of an existing resource
+func newResourceNode(u *unstructured.Unstructured) *Node {
+	node := &Node{APIVersion: u.GetAPIVersion(), Kind: u.GetKind(), Namespace: u.GetNamespace(), Name: u.GetName()}
+	node.Healthy, node.Reason = evaluateHealth(u)
+	return node
+}
+
+// rollUp marks a node as unhealthy if any of its children are unhealthy
+func rollUp(node *Node) {
+	if !node.Healthy && len(node.Reason) > 0 {
+		return
+	}
+	for _, child := range node.Children {
+		if !child.Healthy {
+			node.Healthy = false
+			node.Reason = child.Reason
+			return
+		}
+	}
+	node.Healthy = true
+}
+
+// notFoundReason returns the reason for a node whose resource does not exist
+func notFoundReason(node *Node) string {
+	return fmt.Sprintf("%s %s not found", node.Kind, node.Name)
+}
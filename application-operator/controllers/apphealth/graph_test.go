@@ -0,0 +1,143 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace  = "test-ns"
+	testAppName    = "hello-app"
+	testComponent  = "hello-component"
+	testDeployment = "hello-deployment"
+	testTrait      = "hello-ingress"
+)
+
+// newScheme creates a scheme that includes the Kubernetes and OAM types
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	return scheme
+}
+
+// newTestAppConfig creates an application configuration with a Deployment workload and an ingress trait
+func newTestAppConfig() *oamv1.ApplicationConfiguration {
+	return &oamv1.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName},
+		Status: oamv1.ApplicationConfigurationStatus{
+			Workloads: []oamv1.WorkloadStatus{{
+				ComponentName: testComponent,
+				Reference:     oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: testDeployment},
+				Traits: []oamv1.WorkloadTrait{{
+					Reference: oamrt.TypedReference{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: "IngressTrait", Name: testTrait},
+				}},
+			}},
+		},
+	}
+}
+
+// newTestDeployment creates a Deployment with the given number of ready replicas
+func newTestDeployment(ready int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testDeployment},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+// newTestIngressTrait creates an ingress trait whose status lists the given resources
+func newTestIngressTrait(resources ...oamrt.TypedReference) *vzapi.IngressTrait {
+	return &vzapi.IngressTrait{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testTrait},
+		Status:     vzapi.IngressTraitStatus{Resources: resources},
+	}
+}
+
+// newSecret creates a secret in the test namespace
+func newSecret(name string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name}}
+}
+
+// buildTestGraph builds the graph of the test application configuration
+func buildTestGraph(t *testing.T, objs ...client.Object) *Node {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build()
+	root, err := BuildGraph(context.TODO(), cli, vzlog.DefaultLogger(), newTestAppConfig())
+	asserts.NoError(t, err)
+	return root
+}
+
+// TestBuildGraphHealthy tests building the graph of a healthy application
+// GIVEN an application with a ready Deployment and an ingress trait whose resources exist
+// WHEN the graph is built
+// THEN the graph contains the component, workload, trait and trait resources, and is healthy
+func TestBuildGraphHealthy(t *testing.T) {
+	assert := asserts.New(t)
+	secret := oamrt.TypedReference{APIVersion: "v1", Kind: "Secret", Name: "hello-secret"}
+	root := buildTestGraph(t, newTestDeployment(1), newTestIngressTrait(secret), newSecret("hello-secret"))
+
+	assert.True(root.Healthy)
+	assert.Len(root.Children, 1)
+	component := root.Children[0]
+	assert.Equal(componentKind, component.Kind)
+	assert.Equal(testComponent, component.Name)
+	assert.Len(component.Children, 2)
+	assert.Equal("Deployment", component.Children[0].Kind)
+	assert.Equal("IngressTrait", component.Children[1].Kind)
+	assert.Len(component.Children[1].Children, 1)
+	assert.Equal("hello-secret", component.Children[1].Children[0].Name)
+	assert.Empty(ComponentReasons(root))
+}
+
+// TestBuildGraphUnhealthy tests building the graph of an unhealthy application
+// GIVEN an application with a Deployment that is not ready
+// WHEN the graph is built
+// THEN the component and the application are unhealthy with the reason of the Deployment
+func TestBuildGraphUnhealthy(t *testing.T) {
+	assert := asserts.New(t)
+	root := buildTestGraph(t, newTestDeployment(0), newTestIngressTrait())
+
+	assert.False(root.Healthy)
+	assert.Equal(map[string]string{testComponent: "Deployment hello-deployment has 0/1 ready replicas"}, ComponentReasons(root))
+}
+
+// TestBuildGraphMissingResources tests building the graph of an application with missing resources
+// GIVEN an application whose trait lists a resource that does not exist
+// WHEN the graph is built
+// THEN the trait and the application are unhealthy because the resource was not found
+func TestBuildGraphMissingResources(t *testing.T) {
+	assert := asserts.New(t)
+	service := oamrt.TypedReference{APIVersion: "v1", Kind: "Service", Name: "hello-service"}
+	root := buildTestGraph(t, newTestDeployment(1), newTestIngressTrait(service))
+
+	assert.False(root.Healthy)
+	trait := root.Children[0].Children[1]
+	assert.False(trait.Healthy)
+	assert.Equal("Service hello-service not found", trait.Reason)
+
+	// GIVEN an application whose workload does not exist
+	// WHEN the graph is built
+	// THEN the workload is unhealthy because it was not found
+	root = buildTestGraph(t, newTestIngressTrait())
+	assert.False(root.Healthy)
+	assert.Equal("Deployment hello-deployment not found", root.Children[0].Reason)
+}
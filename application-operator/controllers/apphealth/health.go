@@ -0,0 +1,105 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Condition types that report the readiness of a resource, in order of precedence
+var readinessConditionTypes = []string{"Ready", "Available", "Synced"}
+
+// evaluateHealth determines whether a resource is healthy.  Resources with replicas are healthy when all
+// replicas are ready, pods are healthy when they are ready, and other resources are healthy unless one of
+// their readiness conditions is not true.
+func evaluateHealth(u *unstructured.Unstructured) (bool, string) {
+	switch u.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		return evaluateReplicas(u)
+	case "DaemonSet":
+		return evaluateDaemonSet(u)
+	case "Pod":
+		return evaluatePod(u)
+	}
+	return evaluateConditions(u)
+}
+
+// evaluateReplicas determines whether all the desired replicas of a resource are ready
+func evaluateReplicas(u *unstructured.Unstructured) (bool, string) {
+	desired, found, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if !found {
+		desired = 1
+	}
+	ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+	if ready < desired {
+		return false, fmt.Sprintf("%s %s has %d/%d ready replicas", u.GetKind(), u.GetName(), ready, desired)
+	}
+	return true, ""
+}
+
+// evaluateDaemonSet determines whether a daemon set is ready on all the nodes it is scheduled on
+func evaluateDaemonSet(u *unstructured.Unstructured) (bool, string) {
+	desired, _, _ := unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled")
+	ready, _, _ := unstructured.NestedInt64(u.Object, "status", "numberReady")
+	if ready < desired {
+		return false, fmt.Sprintf("%s %s has %d/%d ready pods", u.GetKind(), u.GetName(), ready, desired)
+	}
+	return true, ""
+}
+
+// evaluatePod determines whether a pod is ready
+func evaluatePod(u *unstructured.Unstructured) (bool, string) {
+	status, reason, found := findCondition(u, "Ready")
+	if !found || status != "True" {
+		return false, formatReason(u, "is not ready", reason)
+	}
+	return true, ""
+}
+
+// evaluateConditions determines whether a resource is healthy from the first readiness condition found
+// in its status.  Resources without a readiness condition are considered healthy.
+func evaluateConditions(u *unstructured.Unstructured) (bool, string) {
+	for _, conditionType := range readinessConditionTypes {
+		status, reason, found := findCondition(u, conditionType)
+		if !found {
+			continue
+		}
+		if status != "True" {
+			return false, formatReason(u, fmt.Sprintf("condition %s is %s", conditionType, status), reason)
+		}
+		return true, ""
+	}
+	return true, ""
+}
+
+// findCondition returns the status and the message, or reason if there is no message, of a status condition
+func findCondition(u *unstructured.Unstructured, conditionType string) (string, string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(conditionMap, "type"); t != conditionType {
+			continue
+		}
+		status, _, _ := unstructured.NestedString(conditionMap, "status")
+		reason, _, _ := unstructured.NestedString(conditionMap, "message")
+		if len(reason) == 0 {
+			reason, _, _ = unstructured.NestedString(conditionMap, "reason")
+		}
+		return status, reason, true
+	}
+	return "", "", false
+}
+
+// formatReason formats the reason that a resource is not healthy
+func formatReason(u *unstructured.Unstructured, problem string, detail string) string {
+	if len(detail) == 0 {
+		return fmt.Sprintf("%s %s %s", u.GetKind(), u.GetName(), problem)
+	}
+	return fmt.Sprintf("%s %s %s: %s", u.GetKind(), u.GetName(), problem, detail)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package apphealth
+
+import (
+	"testing"
+
+	asserts "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newUnstructured creates an unstructured resource with the given kind and status
+func newUnstructured(kind string, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	u.SetKind(kind)
+	u.SetName("test")
+	return u
+}
+
+// TestEvaluateHealth tests the evaluation of the health of resources
+func TestEvaluateHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       *unstructured.Unstructured
+		healthy bool
+		reason  string
+	}{
+		// GIVEN a StatefulSet without a replica count
+		// WHEN the health is evaluated
+		// THEN one ready replica is expected
+		{
+			name:    "StatefulSet not ready",
+			u:       newUnstructured("StatefulSet", map[string]interface{}{"readyReplicas": int64(0)}),
+			healthy: false,
+			reason:  "StatefulSet test has 0/1 ready replicas",
+		},
+		// GIVEN a DaemonSet that is ready on all nodes
+		// WHEN the health is evaluated
+		// THEN the DaemonSet is healthy
+		{
+			name:    "DaemonSet ready",
+			u:       newUnstructured("DaemonSet", map[string]interface{}{"desiredNumberScheduled": int64(2), "numberReady": int64(2)}),
+			healthy: true,
+		},
+		// GIVEN a pod without a Ready condition
+		// WHEN the health is evaluated
+		// THEN the pod is not healthy
+		{
+			name:    "Pod not ready",
+			u:       newUnstructured("Pod", map[string]interface{}{}),
+			healthy: false,
+			reason:  "Pod test is not ready",
+		},
+		// GIVEN a resource with a false Synced condition
+		// WHEN the health is evaluated
+		// THEN the resource is not healthy and the condition message is the reason
+		{
+			name: "Trait not synced",
+			u: newUnstructured("IngressTrait", map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Synced", "status": "False", "reason": "ReconcileError", "message": "failed to create gateway"},
+			}}),
+			healthy: false,
+			reason:  "IngressTrait test condition Synced is False: failed to create gateway",
+		},
+		// GIVEN a resource with a true Available condition
+		// WHEN the health is evaluated
+		// THEN the resource is healthy
+		{
+			name: "Domain available",
+			u: newUnstructured("Domain", map[string]interface{}{"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			}}),
+			healthy: true,
+		},
+		// GIVEN a resource without readiness conditions
+		// WHEN the health is evaluated
+		// THEN the resource is healthy
+		{
+			name:    "Service",
+			u:       newUnstructured("Service", map[string]interface{}{}),
+			healthy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy, reason := evaluateHealth(tt.u)
+			asserts.Equal(t, tt.healthy, healthy)
+			asserts.Equal(t, tt.reason, reason)
+		})
+	}
+}
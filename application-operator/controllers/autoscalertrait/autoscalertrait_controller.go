@@ -0,0 +1,267 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlogInit "github.com/verrazzano/verrazzano/pkg/log"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "autoscalertrait"
+	finalizerName  = "autoscalertrait.finalizers.verrazzano.io"
+
+	// Roles for use in qualified resource relations
+	autoscalerRole = "autoscaler"
+	targetRole     = "target"
+)
+
+// Reconciler reconciles an AutoscalerTrait object
+type Reconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager creates a controller and adds it to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			RateLimiter: controllers.NewDefaultRateLimiter(),
+		}).
+		For(&vzapi.AutoscalerTrait{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Complete(r)
+}
+
+// Reconcile reconciles an autoscaler trait with the HorizontalPodAutoscaler that scales the trait's workload.
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=autoscalertraits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=autoscalertraits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlogInit.FieldResourceNamespace, req.Namespace, vzlogInit.FieldResourceName, req.Name, vzlogInit.FieldController, controllerName)
+		log.Infof("Autoscaler trait resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	trait, err := r.fetchTrait(ctx, req.NamespacedName)
+	if err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	if trait == nil {
+		return reconcile.Result{}, nil
+	}
+
+	log, err := clusters.GetResourceLogger(controllerName, req.NamespacedName, trait)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for autoscaler trait resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling autoscaler trait resource %v, generation %v", req.NamespacedName, trait.Generation)
+
+	res, err := r.doReconcile(ctx, trait, log)
+	if clusters.ShouldRequeue(res) {
+		return res, nil
+	}
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	log.Oncef("Finished reconciling autoscaler trait %v", req.NamespacedName)
+	return ctrl.Result{}, nil
+}
+
+// fetchTrait attempts to get a trait given a namespaced name.
+// Will return nil for the trait and no error if the trait does not exist.
+func (r *Reconciler) fetchTrait(ctx context.Context, name types.NamespacedName) (*vzapi.AutoscalerTrait, error) {
+	var trait vzapi.AutoscalerTrait
+	if err := r.Get(ctx, name, &trait); err != nil {
+		if k8serrors.IsNotFound(err) {
+			zap.S().Debugf("Autoscaler trait %s has been deleted", name)
+			return nil, nil
+		}
+		zap.S().Errorf("Failed to fetch autoscaler trait %s: %v", name, err)
+		return nil, err
+	}
+	return &trait, nil
+}
+
+// doReconcile performs the reconciliation operations for the autoscaler trait
+func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	if !trait.DeletionTimestamp.IsZero() {
+		return r.reconcileTraitDelete(ctx, trait, log)
+	}
+	if err := r.addFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.reconcileTraitCreateOrUpdate(ctx, trait, log)
+}
+
+// reconcileTraitCreateOrUpdate reconciles an autoscaler trait that is being created or updated.
+func (r *Reconciler) reconcileTraitCreateOrUpdate(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	status := &reconcileresults.ReconcileResults{}
+	hpaRel := vzapi.QualifiedResourceRelation{APIVersion: autoscalingv2.SchemeGroupVersion.String(), Kind: "HorizontalPodAutoscaler", Namespace: trait.Namespace, Name: trait.Name, Role: autoscalerRole}
+
+	if err := validateTrait(trait); err != nil {
+		status.RecordOutcome(hpaRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	target, err := r.resolveScaleTarget(ctx, trait, log)
+	if err != nil {
+		status.RecordOutcome(hpaRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+	targetRel := target.relation(trait.Namespace)
+
+	// Refuse to create a second autoscaler for a resource that is already scaled by another HorizontalPodAutoscaler,
+	// the two autoscalers would fight over the replica count.
+	if err = r.checkForConflicts(ctx, trait, target); err != nil {
+		log.Progressf("Autoscaler trait %s conflicts with an existing autoscaler: %v", trait.Name, err)
+		status.RecordOutcome(hpaRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	res, err := r.annotateScaleTarget(ctx, trait, targetRel, true)
+	status.RecordOutcome(targetRel, res, err)
+	if status.ContainsErrors() {
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	hpa, res, err := r.createOrUpdateHPA(ctx, trait, target)
+	status.RecordOutcome(hpaRel, res, err)
+
+	// Clean up a previous scale target if the workload or WebLogic cluster of the trait has changed.
+	for _, rel := range trait.Status.Resources {
+		if rel.Role == targetRole && !status.ContainsRelation(rel) {
+			res, err = r.annotateScaleTarget(ctx, trait, rel, false)
+			status.RecordOutcomeIfError(rel, res, err)
+		}
+	}
+	return r.updateTraitStatus(ctx, trait, status, hpa, log)
+}
+
+// reconcileTraitDelete reconciles an autoscaler trait that is being deleted. The HorizontalPodAutoscaler is
+// garbage collected through its owner reference, only the annotation on the scale target needs to be removed.
+func (r *Reconciler) reconcileTraitDelete(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	for _, rel := range trait.Status.Resources {
+		if rel.Role != targetRole {
+			continue
+		}
+		if _, err := r.annotateScaleTarget(ctx, trait, rel, false); err != nil && !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, log.ErrorfNewErr("Failed to remove the autoscaler annotation from %s %s: %v", rel.Kind, rel.Name, err)
+		}
+	}
+	if err := r.removeFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// addFinalizerIfRequired adds the finalizer to the trait if required
+// The finalizer is only added if the trait is not being deleted and the finalizer has not previously been added
+func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) error {
+	if trait.GetDeletionTimestamp().IsZero() && !vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		log.Debugf("Adding finalizer for autoscaler trait %s", trait.Name)
+		trait.Finalizers = append(trait.Finalizers, finalizerName)
+		if err := r.Update(ctx, trait); err != nil {
+			return log.ErrorfNewErr("Failed to add finalizer to autoscaler trait %s: %v", trait.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeFinalizerIfRequired removes the finalizer from the trait if required
+// The finalizer is only removed if the trait is being deleted and the finalizer had been added
+func (r *Reconciler) removeFinalizerIfRequired(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) error {
+	if !trait.DeletionTimestamp.IsZero() && vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		log.Debugf("Removing finalizer from autoscaler trait %s", trait.Name)
+		trait.Finalizers = vzstring.RemoveStringFromSlice(trait.Finalizers, finalizerName)
+		if err := r.Update(ctx, trait); err != nil {
+			return vzlogInit.ConflictWithLog(fmt.Sprintf("Failed to remove finalizer from autoscaler trait %s", trait.Name), err, zap.S())
+		}
+	}
+	return nil
+}
+
+// updateTraitStatus updates the trait's status conditions, resources and replica counts if they have changed.
+// The return value can be used as the result of the Reconcile method.
+func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.AutoscalerTrait, results *reconcileresults.ReconcileResults, hpa *autoscalingv2.HorizontalPodAutoscaler, log vzlog.VerrazzanoLogger) (reconcile.Result, error) {
+	if updateStatusIfRequired(&trait.Status, results, hpa) {
+		if err := r.Status().Update(ctx, trait); err != nil {
+			return vzlogInit.IgnoreConflictWithLog(fmt.Sprintf("Failed to update autoscaler trait %s status", trait.Name), err, zap.S())
+		}
+		log.Debugf("Updated autoscaler trait %s status", trait.Name)
+	}
+
+	// If the results contained errors then requeue with a delay, most errors need user intervention.
+	if results.ContainsErrors() {
+		vzlogInit.ResultErrorsWithLog(fmt.Sprintf("Failed to reconcile autoscaler trait %s", trait.Name), results.Errors, zap.S())
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	// Requeue with a jittered delay to refresh the replica counts reported in the trait status
+	var seconds = rand.IntnRange(45, 90)
+	return reconcile.Result{Requeue: true, RequeueAfter: time.Duration(seconds) * time.Second}, nil
+}
+
+// updateStatusIfRequired updates the trait status (i.e. resources, conditions and replicas) if they have changed.
+// Returns a boolean indicating if the status has been updated.
+func updateStatusIfRequired(status *vzapi.AutoscalerTraitStatus, results *reconcileresults.ReconcileResults, hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	updated := false
+	relations := results.CreateRelations()
+	if !vzapi.QualifiedResourceRelationSlicesEquivalent(status.Resources, relations) {
+		status.Resources = relations
+		updated = true
+	}
+	conditionedStatus := results.CreateConditionedStatus()
+	if !reconcileresults.ConditionedStatusEquivalent(&status.ConditionedStatus, &conditionedStatus) {
+		status.ConditionedStatus = conditionedStatus
+		updated = true
+	}
+	if hpa != nil && (status.CurrentReplicas != hpa.Status.CurrentReplicas || status.DesiredReplicas != hpa.Status.DesiredReplicas) {
+		status.CurrentReplicas = hpa.Status.CurrentReplicas
+		status.DesiredReplicas = hpa.Status.DesiredReplicas
+		updated = true
+	}
+	return updated
+}
+
+// getTraitNamespacedName returns the namespaced name of a trait as a string, used as the annotation value on scale targets
+func getTraitNamespacedName(trait *vzapi.AutoscalerTrait) string {
+	return vznav.GetNamespacedNameFromObjectMeta(trait.ObjectMeta).String()
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace      = "unit-test-namespace"
+	testTraitName      = "unit-test-trait"
+	testWorkloadName   = "unit-test-workload"
+	testDeploymentName = "unit-test-deployment"
+)
+
+// newScheme creates a new scheme that includes this package's object for use by client
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	return scheme
+}
+
+// newReconciler creates a new reconciler for testing
+func newReconciler(c client.Client) Reconciler {
+	return Reconciler{
+		Client: c,
+		Log:    zap.S(),
+		Scheme: newScheme(),
+	}
+}
+
+// newTrait creates an autoscaler trait for the given workload
+func newTrait(apiVersion, kind string, spec vzapi.AutoscalerTraitSpec) *vzapi.AutoscalerTrait {
+	spec.WorkloadReference = oamrt.TypedReference{APIVersion: apiVersion, Kind: kind, Name: testWorkloadName}
+	return &vzapi.AutoscalerTrait{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testTraitName,
+			Labels:    map[string]string{oam.LabelAppName: "unit-test-app", oam.LabelAppComponent: "unit-test-component"},
+		},
+		Spec: spec,
+	}
+}
+
+// newHelidonWorkload creates a Helidon workload with a deployment template
+func newHelidonWorkload() *vzapi.VerrazzanoHelidonWorkload {
+	return &vzapi.VerrazzanoHelidonWorkload{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testWorkloadName},
+		Spec: vzapi.VerrazzanoHelidonWorkloadSpec{
+			DeploymentTemplate: vzapi.DeploymentTemplate{
+				Metadata: metav1.ObjectMeta{Name: testDeploymentName},
+			},
+		},
+	}
+}
+
+// newDeployment creates the Deployment of a Helidon workload
+func newDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testDeploymentName}}
+}
+
+// reconcileTrait runs a reconcile for the test trait
+func reconcileTrait(t *testing.T, cli client.Client) ctrl.Result {
+	reconciler := newReconciler(cli)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testTraitName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	return result
+}
+
+// getTrait fetches the test trait
+func getTrait(t *testing.T, cli client.Client) *vzapi.AutoscalerTrait {
+	trait := &vzapi.AutoscalerTrait{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, trait))
+	return trait
+}
+
+// TestReconcileHelidonWorkloadDefaultTarget tests reconciling an autoscaler trait for a Helidon workload
+// GIVEN an autoscaler trait without any metric targets for a Helidon workload
+// WHEN the trait is reconciled
+// THEN an HPA is created for the workload's Deployment with the default CPU target, the Deployment is annotated
+// and the trait status is updated
+func TestReconcileHelidonWorkloadDefaultTarget(t *testing.T) {
+	asserts := assert.New(t)
+	minReplicas := int32(2)
+	trait := newTrait("oam.verrazzano.io/v1alpha1", helidonWorkloadKind, vzapi.AutoscalerTraitSpec{MinReplicas: &minReplicas, MaxReplicas: 5})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, newHelidonWorkload(), newDeployment()).Build()
+
+	result := reconcileTrait(t, cli)
+	asserts.True(result.Requeue)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, hpa))
+	asserts.Equal(autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: testDeploymentName}, hpa.Spec.ScaleTargetRef)
+	asserts.Equal(int32(2), *hpa.Spec.MinReplicas)
+	asserts.Equal(int32(5), hpa.Spec.MaxReplicas)
+	asserts.Len(hpa.Spec.Metrics, 1)
+	asserts.Equal(corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	asserts.Equal(defaultCPUUtilization, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	asserts.Equal("unit-test-app", hpa.Labels[oam.LabelAppName])
+	asserts.Len(hpa.OwnerReferences, 1)
+	asserts.Equal(vzapi.AutoscalerTraitKind, hpa.OwnerReferences[0].Kind)
+
+	deployment := &appsv1.Deployment{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testDeploymentName}, deployment))
+	asserts.Equal(testNamespace+"/"+testTraitName, deployment.Annotations[constants.AutoscalerTraitAnnotation])
+
+	trait = getTrait(t, cli)
+	asserts.Contains(trait.Finalizers, finalizerName)
+	asserts.Equal(corev1.ConditionTrue, trait.Status.Conditions[0].Status)
+	asserts.Len(trait.Status.Resources, 2)
+}
+
+// TestReconcileMetricTargets tests the conversion of the trait metric targets to HPA metrics
+// GIVEN an autoscaler trait with CPU, memory and custom metric targets
+// WHEN the trait is reconciled
+// THEN the HPA contains a metric for each target
+func TestReconcileMetricTargets(t *testing.T) {
+	asserts := assert.New(t)
+	cpu := int32(60)
+	memory := resource.MustParse("512Mi")
+	trait := newTrait("oam.verrazzano.io/v1alpha1", helidonWorkloadKind, vzapi.AutoscalerTraitSpec{
+		MaxReplicas: 3,
+		CPU:         &vzapi.ResourceTarget{AverageUtilization: &cpu},
+		Memory:      &vzapi.ResourceTarget{AverageValue: &memory},
+		Metrics:     []vzapi.CustomMetricTarget{{Name: "http_requests_per_second", AverageValue: resource.MustParse("10")}},
+	})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, newHelidonWorkload(), newDeployment()).Build()
+
+	reconcileTrait(t, cli)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, hpa))
+	asserts.Len(hpa.Spec.Metrics, 3)
+	asserts.Equal(autoscalingv2.UtilizationMetricType, hpa.Spec.Metrics[0].Resource.Target.Type)
+	asserts.Equal(cpu, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	asserts.Equal(corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+	asserts.Equal(autoscalingv2.AverageValueMetricType, hpa.Spec.Metrics[1].Resource.Target.Type)
+	asserts.Equal(autoscalingv2.PodsMetricSourceType, hpa.Spec.Metrics[2].Type)
+	asserts.Equal("http_requests_per_second", hpa.Spec.Metrics[2].Pods.Metric.Name)
+}
+
+// TestReconcileCoherenceWorkload tests reconciling an autoscaler trait for a Coherence workload
+// GIVEN an autoscaler trait for a Coherence workload
+// WHEN the trait is reconciled
+// THEN an HPA is created for the Coherence resource and the Coherence resource is annotated
+func TestReconcileCoherenceWorkload(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait("oam.verrazzano.io/v1alpha1", coherenceWorkloadKind, vzapi.AutoscalerTraitSpec{MaxReplicas: 4})
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "oam.verrazzano.io/v1alpha1",
+		"kind":       coherenceWorkloadKind,
+		"metadata":   map[string]interface{}{"namespace": testNamespace, "name": testWorkloadName},
+		"spec":       map[string]interface{}{"template": map[string]interface{}{"metadata": map[string]interface{}{"name": "unit-test-coherence"}}},
+	}}
+	coherence := &unstructured.Unstructured{}
+	coherence.SetAPIVersion("coherence.oracle.com/v1")
+	coherence.SetKind("Coherence")
+	coherence.SetNamespace(testNamespace)
+	coherence.SetName("unit-test-coherence")
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, workload, coherence).Build()
+
+	reconcileTrait(t, cli)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, hpa))
+	asserts.Equal(autoscalingv2.CrossVersionObjectReference{APIVersion: "coherence.oracle.com/v1", Kind: "Coherence", Name: "unit-test-coherence"}, hpa.Spec.ScaleTargetRef)
+
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "unit-test-coherence"}, coherence))
+	asserts.Equal(testNamespace+"/"+testTraitName, coherence.GetAnnotations()[constants.AutoscalerTraitAnnotation])
+}
+
+// TestReconcileWebLogicWorkloadRequiresClusterName tests reconciling an autoscaler trait for a WebLogic workload
+// GIVEN an autoscaler trait without a cluster name for a WebLogic workload with two clusters
+// WHEN the trait is reconciled
+// THEN no HPA is created and the trait status reports the error
+func TestReconcileWebLogicWorkloadRequiresClusterName(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait("oam.verrazzano.io/v1alpha1", weblogicWorkloadKind, vzapi.AutoscalerTraitSpec{MaxReplicas: 4})
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "oam.verrazzano.io/v1alpha1",
+		"kind":       weblogicWorkloadKind,
+		"metadata":   map[string]interface{}{"namespace": testNamespace, "name": testWorkloadName},
+		"spec": map[string]interface{}{"clusters": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "cluster-1"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "cluster-2"}},
+		}},
+	}}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, workload).Build()
+
+	reconcileTrait(t, cli)
+
+	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
+	asserts.NoError(cli.List(context.TODO(), hpaList))
+	asserts.Empty(hpaList.Items)
+	trait = getTrait(t, cli)
+	asserts.Equal(corev1.ConditionFalse, trait.Status.Conditions[0].Status)
+	asserts.Contains(trait.Status.Conditions[0].Message, "clusterName must be specified")
+}
+
+// TestWLSClusterScaleTarget tests selecting the WebLogic cluster to scale
+// GIVEN a WebLogic workload with two clusters
+// WHEN the scale target is resolved with a cluster name
+// THEN the named cluster is returned, and an unknown cluster name results in an error
+func TestWLSClusterScaleTarget(t *testing.T) {
+	asserts := assert.New(t)
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"clusters": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "cluster-1"}},
+			map[string]interface{}{"apiVersion": "weblogic.oracle/v9", "metadata": map[string]interface{}{"name": "cluster-2"}},
+		}},
+	}}
+
+	target, err := wlsClusterScaleTarget(workload, "cluster-2")
+	asserts.NoError(err)
+	asserts.Equal(scaleTarget{APIVersion: "weblogic.oracle/v9", Kind: wlsClusterKind, Name: "cluster-2"}, *target)
+
+	_, err = wlsClusterScaleTarget(workload, "cluster-3")
+	asserts.Error(err)
+}
+
+// TestReconcileConflictingHPA tests reconciling an autoscaler trait when another HPA already scales the workload
+// GIVEN an autoscaler trait for a Helidon workload whose Deployment is scaled by a user-defined HPA
+// WHEN the trait is reconciled
+// THEN the trait's HPA is not created and the trait status reports the conflict
+func TestReconcileConflictingHPA(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait("oam.verrazzano.io/v1alpha1", helidonWorkloadKind, vzapi.AutoscalerTraitSpec{MaxReplicas: 5})
+	existing := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "user-hpa"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: testDeploymentName},
+			MaxReplicas:    3,
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, newHelidonWorkload(), newDeployment(), existing).Build()
+
+	reconcileTrait(t, cli)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, hpa)
+	asserts.Error(err)
+	trait = getTrait(t, cli)
+	asserts.Equal(corev1.ConditionFalse, trait.Status.Conditions[0].Status)
+	asserts.Contains(trait.Status.Conditions[0].Message, "user-hpa")
+}
+
+// TestValidateTrait tests the validation of the trait spec
+func TestValidateTrait(t *testing.T) {
+	asserts := assert.New(t)
+	minReplicas := int32(4)
+	utilization := int32(50)
+	value := resource.MustParse("100m")
+
+	// GIVEN a trait with minReplicas greater than maxReplicas
+	// WHEN the trait is validated
+	// THEN an error is returned
+	asserts.Error(validateTrait(newTrait("", "", vzapi.AutoscalerTraitSpec{MinReplicas: &minReplicas, MaxReplicas: 2})))
+
+	// GIVEN a trait with both a utilization and a value CPU target
+	// WHEN the trait is validated
+	// THEN an error is returned
+	asserts.Error(validateTrait(newTrait("", "", vzapi.AutoscalerTraitSpec{MaxReplicas: 2, CPU: &vzapi.ResourceTarget{AverageUtilization: &utilization, AverageValue: &value}})))
+
+	// GIVEN a valid trait
+	// WHEN the trait is validated
+	// THEN no error is returned
+	asserts.NoError(validateTrait(newTrait("", "", vzapi.AutoscalerTraitSpec{MinReplicas: &minReplicas, MaxReplicas: 4, CPU: &vzapi.ResourceTarget{AverageValue: &value}})))
+}
+
+// TestReconcileDeletedTrait tests reconciling a trait that is being deleted
+// GIVEN an autoscaler trait that is being deleted and an annotated Deployment
+// WHEN the trait is reconciled
+// THEN the annotation is removed from the Deployment and the finalizer is removed from the trait
+func TestReconcileDeletedTrait(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait("oam.verrazzano.io/v1alpha1", helidonWorkloadKind, vzapi.AutoscalerTraitSpec{MaxReplicas: 5})
+	trait.Finalizers = []string{finalizerName}
+	now := metav1.Now()
+	trait.DeletionTimestamp = &now
+	trait.Status.Resources = []vzapi.QualifiedResourceRelation{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: testNamespace, Name: testDeploymentName, Role: targetRole}}
+	deployment := newDeployment()
+	deployment.Annotations = map[string]string{constants.AutoscalerTraitAnnotation: testNamespace + "/" + testTraitName}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, newHelidonWorkload(), deployment).Build()
+
+	result := reconcileTrait(t, cli)
+	asserts.False(result.Requeue)
+
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testDeploymentName}, deployment))
+	asserts.NotContains(deployment.Annotations, constants.AutoscalerTraitAnnotation)
+	// the trait is deleted once the finalizer has been removed
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, trait)
+	asserts.True(k8serrors.IsNotFound(err))
+}
+
+// TestReconcileKubeSystem tests to make sure we do not reconcile
+// Any resource that belong to the kube-system namespace
+func TestReconcileKubeSystem(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newReconciler(cli)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: testTraitName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(err)
+	asserts.False(result.Requeue)
+}
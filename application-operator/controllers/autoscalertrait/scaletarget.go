@@ -0,0 +1,304 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package autoscalertrait
+
+import (
+	"context"
+	"fmt"
+
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// defaultCPUUtilization is the target CPU utilization used when a trait does not specify any targets
+	defaultCPUUtilization int32 = 80
+
+	deploymentAPIVersion    = "apps/v1"
+	deploymentKind          = "Deployment"
+	wlsClusterAPIVersion    = "weblogic.oracle/v1"
+	wlsClusterKind          = "Cluster"
+	helidonWorkloadKind     = "VerrazzanoHelidonWorkload"
+	coherenceWorkloadKind   = "VerrazzanoCoherenceWorkload"
+	weblogicWorkloadKind    = "VerrazzanoWebLogicWorkload"
+	containerizedWorkloadGV = "core.oam.dev"
+)
+
+// scaleTarget identifies the resource scaled by the HorizontalPodAutoscaler of a trait
+type scaleTarget struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// relation returns the qualified resource relation of the scale target for use in the trait status
+func (t scaleTarget) relation(namespace string) vzapi.QualifiedResourceRelation {
+	return vzapi.QualifiedResourceRelation{APIVersion: t.APIVersion, Kind: t.Kind, Name: t.Name, Namespace: namespace, Role: targetRole}
+}
+
+// matches returns true if the cross version object reference of an HPA refers to this scale target.
+// The version of the API is ignored since the same resource may be scaled through any served version.
+func (t scaleTarget) matches(ref autoscalingv2.CrossVersionObjectReference) bool {
+	refGV, _ := schema.ParseGroupVersion(ref.APIVersion)
+	targetGV, _ := schema.ParseGroupVersion(t.APIVersion)
+	return refGV.Group == targetGV.Group && ref.Kind == t.Kind && ref.Name == t.Name
+}
+
+// validateTrait validates the replica limits and the metric targets of a trait
+func validateTrait(trait *vzapi.AutoscalerTrait) error {
+	if trait.Spec.MaxReplicas < 1 {
+		return fmt.Errorf("maxReplicas must be at least 1")
+	}
+	if trait.Spec.MinReplicas != nil && *trait.Spec.MinReplicas > trait.Spec.MaxReplicas {
+		return fmt.Errorf("minReplicas %d must not be greater than maxReplicas %d", *trait.Spec.MinReplicas, trait.Spec.MaxReplicas)
+	}
+	for name, target := range map[string]*vzapi.ResourceTarget{"cpu": trait.Spec.CPU, "memory": trait.Spec.Memory} {
+		if target == nil {
+			continue
+		}
+		if (target.AverageUtilization == nil) == (target.AverageValue == nil) {
+			return fmt.Errorf("exactly one of averageUtilization or averageValue must be specified for the %s target", name)
+		}
+	}
+	for _, metric := range trait.Spec.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("custom metric targets must specify a metric name")
+		}
+	}
+	return nil
+}
+
+// resolveScaleTarget determines the resource to scale for the workload of a trait.
+// Helidon and containerized workloads are scaled through their Deployment, Coherence workloads through the
+// Coherence resource and WebLogic workloads through the WebLogic Cluster resource.
+func (r *Reconciler) resolveScaleTarget(ctx context.Context, trait *vzapi.AutoscalerTrait, log vzlog.VerrazzanoLogger) (*scaleTarget, error) {
+	ref := trait.Spec.WorkloadReference
+	workload := &unstructured.Unstructured{}
+	workload.SetAPIVersion(ref.APIVersion)
+	workload.SetKind(ref.Kind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: trait.Namespace, Name: ref.Name}, workload); err != nil {
+		return nil, fmt.Errorf("failed to fetch workload %s %s: %v", ref.Kind, ref.Name, err)
+	}
+
+	gv, _ := schema.ParseGroupVersion(ref.APIVersion)
+	switch {
+	case ref.Kind == helidonWorkloadKind:
+		name, _, _ := unstructured.NestedString(workload.Object, "spec", "deploymentTemplate", "metadata", "name")
+		if name == "" {
+			return nil, fmt.Errorf("workload %s is missing spec.deploymentTemplate.metadata.name", ref.Name)
+		}
+		return &scaleTarget{APIVersion: deploymentAPIVersion, Kind: deploymentKind, Name: name}, nil
+
+	case ref.Kind == coherenceWorkloadKind:
+		apiVersion, kind, name, err := vznav.GetContainedWorkloadVersionKindName(workload)
+		if err != nil {
+			return nil, err
+		}
+		return &scaleTarget{APIVersion: apiVersion, Kind: kind, Name: name}, nil
+
+	case ref.Kind == weblogicWorkloadKind:
+		return wlsClusterScaleTarget(workload, trait.Spec.ClusterName)
+
+	case ref.Kind == oamcore.ContainerizedWorkloadKind && gv.Group == containerizedWorkloadGV:
+		children, err := vznav.FetchWorkloadChildren(ctx, r, log, workload)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if child.GetKind() == deploymentKind {
+				return &scaleTarget{APIVersion: child.GetAPIVersion(), Kind: deploymentKind, Name: child.GetName()}, nil
+			}
+		}
+		return nil, fmt.Errorf("the Deployment of workload %s has not been created yet", ref.Name)
+	}
+	return nil, fmt.Errorf("workload kind %s is not supported by the autoscaler trait", ref.Kind)
+}
+
+// wlsClusterScaleTarget returns the WebLogic Cluster resource to scale. If the trait does not name a cluster the
+// workload must contain exactly one cluster.
+func wlsClusterScaleTarget(workload *unstructured.Unstructured, clusterName string) (*scaleTarget, error) {
+	clusters, _, err := unstructured.NestedSlice(workload.Object, "spec", "clusters")
+	if err != nil {
+		return nil, err
+	}
+	var targets []scaleTarget
+	for _, c := range clusters {
+		cluster, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(cluster, "metadata", "name")
+		apiVersion, _, _ := unstructured.NestedString(cluster, "apiVersion")
+		if apiVersion == "" {
+			apiVersion = wlsClusterAPIVersion
+		}
+		if name != "" && (clusterName == "" || clusterName == name) {
+			targets = append(targets, scaleTarget{APIVersion: apiVersion, Kind: wlsClusterKind, Name: name})
+		}
+	}
+	switch {
+	case len(targets) == 1:
+		return &targets[0], nil
+	case clusterName != "":
+		return nil, fmt.Errorf("WebLogic cluster %s not found in workload %s", clusterName, workload.GetName())
+	case len(targets) == 0:
+		return nil, fmt.Errorf("workload %s does not contain any WebLogic clusters to scale", workload.GetName())
+	}
+	return nil, fmt.Errorf("workload %s contains %d WebLogic clusters, clusterName must be specified", workload.GetName(), len(targets))
+}
+
+// checkForConflicts returns an error if a HorizontalPodAutoscaler that is not owned by the trait already scales
+// the scale target.
+func (r *Reconciler) checkForConflicts(ctx context.Context, trait *vzapi.AutoscalerTrait, target *scaleTarget) error {
+	hpaList := autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := r.List(ctx, &hpaList, client.InNamespace(trait.Namespace)); err != nil {
+		return err
+	}
+	for i := range hpaList.Items {
+		hpa := &hpaList.Items[i]
+		if hpa.Name == trait.Name && isOwnedByTrait(hpa, trait) {
+			continue
+		}
+		if target.matches(hpa.Spec.ScaleTargetRef) {
+			return fmt.Errorf("%s %s is already scaled by HorizontalPodAutoscaler %s", target.Kind, target.Name, hpa.Name)
+		}
+	}
+	return nil
+}
+
+// isOwnedByTrait returns true if the HPA has a controller owner reference to the trait
+func isOwnedByTrait(hpa *autoscalingv2.HorizontalPodAutoscaler, trait *vzapi.AutoscalerTrait) bool {
+	for _, owner := range hpa.OwnerReferences {
+		if owner.Kind == vzapi.AutoscalerTraitKind && owner.Name == trait.Name && (trait.UID == "" || owner.UID == trait.UID) {
+			return true
+		}
+	}
+	return false
+}
+
+// annotateScaleTarget adds or removes the autoscaler annotation on a scale target. The annotation tells the
+// workload controllers to leave the replica count of the resource to the autoscaler.
+func (r *Reconciler) annotateScaleTarget(ctx context.Context, trait *vzapi.AutoscalerTrait, rel vzapi.QualifiedResourceRelation, add bool) (controllerutil.OperationResult, error) {
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion(rel.APIVersion)
+	target.SetKind(rel.Kind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: rel.Namespace, Name: rel.Name}, target); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	annotations := target.GetAnnotations()
+	value, found := annotations[constants.AutoscalerTraitAnnotation]
+	traitName := getTraitNamespacedName(trait)
+	switch {
+	case add && found && value == traitName:
+		return controllerutil.OperationResultNone, nil
+	case add && found:
+		return controllerutil.OperationResultNone, fmt.Errorf("%s %s is already scaled by autoscaler trait %s", rel.Kind, rel.Name, value)
+	case add:
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constants.AutoscalerTraitAnnotation] = traitName
+	case !found || value != traitName:
+		return controllerutil.OperationResultNone, nil
+	default:
+		delete(annotations, constants.AutoscalerTraitAnnotation)
+	}
+	patch := client.MergeFrom(target.DeepCopy())
+	target.SetAnnotations(annotations)
+	if err := r.Patch(ctx, target, patch); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// createOrUpdateHPA creates or updates the HorizontalPodAutoscaler of a trait
+func (r *Reconciler) createOrUpdateHPA(ctx context.Context, trait *vzapi.AutoscalerTrait, target *scaleTarget) (*autoscalingv2.HorizontalPodAutoscaler, controllerutil.OperationResult, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	hpa.Namespace = trait.Namespace
+	hpa.Name = trait.Name
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, hpa, func() error {
+		mutateHPA(trait, target, hpa)
+		return controllerutil.SetControllerReference(trait, hpa, r.Scheme)
+	})
+	if err != nil {
+		return nil, res, err
+	}
+	return hpa, res, nil
+}
+
+// mutateHPA sets the labels and the spec of a HorizontalPodAutoscaler from the trait and the scale target
+func mutateHPA(trait *vzapi.AutoscalerTrait, target *scaleTarget, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	labels := hpa.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, key := range []string{oam.LabelAppName, oam.LabelAppComponent} {
+		if value, ok := trait.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	hpa.SetLabels(labels)
+
+	hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{APIVersion: target.APIVersion, Kind: target.Kind, Name: target.Name}
+	hpa.Spec.MinReplicas = trait.Spec.MinReplicas
+	hpa.Spec.MaxReplicas = trait.Spec.MaxReplicas
+	hpa.Spec.Behavior = trait.Spec.Behavior
+	hpa.Spec.Metrics = buildMetricSpecs(trait)
+}
+
+// buildMetricSpecs converts the resource and custom metric targets of a trait to HPA metric specs
+func buildMetricSpecs(trait *vzapi.AutoscalerTrait) []autoscalingv2.MetricSpec {
+	var metrics []autoscalingv2.MetricSpec
+	if spec := buildResourceMetricSpec(corev1.ResourceCPU, trait.Spec.CPU); spec != nil {
+		metrics = append(metrics, *spec)
+	}
+	if spec := buildResourceMetricSpec(corev1.ResourceMemory, trait.Spec.Memory); spec != nil {
+		metrics = append(metrics, *spec)
+	}
+	for i := range trait.Spec.Metrics {
+		metric := trait.Spec.Metrics[i]
+		averageValue := metric.AverageValue.DeepCopy()
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: metric.Name, Selector: metric.Selector},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &averageValue},
+			},
+		})
+	}
+	if len(metrics) == 0 {
+		utilization := defaultCPUUtilization
+		metrics = append(metrics, *buildResourceMetricSpec(corev1.ResourceCPU, &vzapi.ResourceTarget{AverageUtilization: &utilization}))
+	}
+	return metrics
+}
+
+// buildResourceMetricSpec converts a resource target to an HPA resource metric spec
+func buildResourceMetricSpec(name corev1.ResourceName, target *vzapi.ResourceTarget) *autoscalingv2.MetricSpec {
+	if target == nil {
+		return nil
+	}
+	metricTarget := autoscalingv2.MetricTarget{}
+	if target.AverageUtilization != nil {
+		metricTarget.Type = autoscalingv2.UtilizationMetricType
+		metricTarget.AverageUtilization = target.AverageUtilization
+	} else {
+		metricTarget.Type = autoscalingv2.AverageValueMetricType
+		metricTarget.AverageValue = target.AverageValue
+	}
+	return &autoscalingv2.MetricSpec{
+		Type:     autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{Name: name, Target: metricTarget},
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backuptrait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlogInit "github.com/verrazzano/verrazzano/pkg/log"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "backuptrait"
+	finalizerName  = "backuptrait.finalizers.verrazzano.io"
+
+	// Roles for use in qualified resource relations
+	backupRole   = "backup"
+	scheduleRole = "schedule"
+	sourceRole   = "source"
+)
+
+// Reconciler reconciles a BackupTrait object
+type Reconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager creates a controller and adds it to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			RateLimiter: controllers.NewDefaultRateLimiter(),
+		}).
+		For(&vzapi.BackupTrait{}).
+		Complete(r)
+}
+
+// Reconcile reconciles a backup trait with the Velero Backup or Schedule that backs up the trait's component.
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=backuptraits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=oam.verrazzano.io,resources=backuptraits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=velero.io,resources=backups;schedules,verbs=get;list;watch;create;update;patch;delete
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlogInit.FieldResourceNamespace, req.Namespace, vzlogInit.FieldResourceName, req.Name, vzlogInit.FieldController, controllerName)
+		log.Infof("Backup trait resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	trait, err := r.fetchTrait(ctx, req.NamespacedName)
+	if err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	if trait == nil {
+		return reconcile.Result{}, nil
+	}
+
+	log, err := clusters.GetResourceLogger(controllerName, req.NamespacedName, trait)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for backup trait resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling backup trait resource %v, generation %v", req.NamespacedName, trait.Generation)
+
+	res, err := r.doReconcile(ctx, trait, log)
+	if clusters.ShouldRequeue(res) {
+		return res, nil
+	}
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	log.Oncef("Finished reconciling backup trait %v", req.NamespacedName)
+	return ctrl.Result{}, nil
+}
+
+// fetchTrait attempts to get a trait given a namespaced name.
+// Will return nil for the trait and no error if the trait does not exist.
+func (r *Reconciler) fetchTrait(ctx context.Context, name types.NamespacedName) (*vzapi.BackupTrait, error) {
+	var trait vzapi.BackupTrait
+	if err := r.Get(ctx, name, &trait); err != nil {
+		if k8serrors.IsNotFound(err) {
+			zap.S().Debugf("Backup trait %s has been deleted", name)
+			return nil, nil
+		}
+		zap.S().Errorf("Failed to fetch backup trait %s: %v", name, err)
+		return nil, err
+	}
+	return &trait, nil
+}
+
+// doReconcile performs the reconciliation operations for the backup trait
+func (r *Reconciler) doReconcile(ctx context.Context, trait *vzapi.BackupTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	if !trait.DeletionTimestamp.IsZero() {
+		return r.reconcileTraitDelete(ctx, trait, log)
+	}
+	if err := r.addFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.reconcileTraitCreateOrUpdate(ctx, trait, log)
+}
+
+// reconcileTraitCreateOrUpdate reconciles a backup trait that is being created or updated.
+func (r *Reconciler) reconcileTraitCreateOrUpdate(ctx context.Context, trait *vzapi.BackupTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	status := &reconcileresults.ReconcileResults{}
+	veleroRel := getVeleroRelation(trait)
+
+	if err := validateTrait(trait); err != nil {
+		status.RecordOutcome(veleroRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	// Label the application resources that are not selected by the OAM labels of the component
+	sources, err := r.labelBackupSources(ctx, trait)
+	for _, rel := range sources {
+		status.RecordOutcome(rel, controllerutil.OperationResultNone, nil)
+	}
+	if err != nil {
+		status.RecordOutcome(veleroRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	hooks, err := r.resolveHooks(ctx, trait)
+	if err != nil {
+		status.RecordOutcome(veleroRel, controllerutil.OperationResultNone, err)
+		return r.updateTraitStatus(ctx, trait, status, nil, log)
+	}
+
+	res, err := r.createOrUpdateVeleroResource(ctx, trait, veleroRel, buildBackupSpec(trait, hooks))
+	status.RecordOutcome(veleroRel, res, err)
+
+	// Delete the previous Velero Schedule if the trait no longer has a schedule, or no longer the same one.
+	// The backups are kept until they expire.
+	for _, rel := range trait.Status.Resources {
+		if rel.Role == scheduleRole && !status.ContainsRelation(rel) {
+			err = r.deleteSchedule(ctx, rel)
+			status.RecordOutcomeIfError(rel, controllerutil.OperationResultNone, err)
+		}
+	}
+
+	lastBackup, err := r.fetchLastBackup(ctx, trait, veleroRel)
+	status.RecordOutcomeIfError(veleroRel, controllerutil.OperationResultNone, err)
+	return r.updateTraitStatus(ctx, trait, status, lastBackup, log)
+}
+
+// reconcileTraitDelete reconciles a backup trait that is being deleted. The Velero Schedule is in the Velero
+// namespace and cannot be garbage collected through an owner reference, so it is deleted explicitly.
+// The backups that have been taken are kept until they expire.
+func (r *Reconciler) reconcileTraitDelete(ctx context.Context, trait *vzapi.BackupTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	for _, rel := range trait.Status.Resources {
+		if rel.Role != scheduleRole {
+			continue
+		}
+		if err := r.deleteSchedule(ctx, rel); err != nil {
+			return reconcile.Result{}, log.ErrorfNewErr("Failed to delete the Velero Schedule %s: %v", rel.Name, err)
+		}
+	}
+	if err := r.removeFinalizerIfRequired(ctx, trait, log); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// addFinalizerIfRequired adds the finalizer to the trait if required
+// The finalizer is only added if the trait is not being deleted and the finalizer has not previously been added
+func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.BackupTrait, log vzlog.VerrazzanoLogger) error {
+	if trait.GetDeletionTimestamp().IsZero() && !vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		log.Debugf("Adding finalizer for backup trait %s", trait.Name)
+		trait.Finalizers = append(trait.Finalizers, finalizerName)
+		if err := r.Update(ctx, trait); err != nil {
+			return log.ErrorfNewErr("Failed to add finalizer to backup trait %s: %v", trait.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeFinalizerIfRequired removes the finalizer from the trait if required
+// The finalizer is only removed if the trait is being deleted and the finalizer had been added
+func (r *Reconciler) removeFinalizerIfRequired(ctx context.Context, trait *vzapi.BackupTrait, log vzlog.VerrazzanoLogger) error {
+	if !trait.DeletionTimestamp.IsZero() && vzstring.SliceContainsString(trait.Finalizers, finalizerName) {
+		log.Debugf("Removing finalizer from backup trait %s", trait.Name)
+		trait.Finalizers = vzstring.RemoveStringFromSlice(trait.Finalizers, finalizerName)
+		if err := r.Update(ctx, trait); err != nil {
+			return vzlogInit.ConflictWithLog(fmt.Sprintf("Failed to remove finalizer from backup trait %s", trait.Name), err, zap.S())
+		}
+	}
+	return nil
+}
+
+// updateTraitStatus updates the trait's status conditions, resources and last backup if they have changed.
+// The return value can be used as the result of the Reconcile method.
+func (r *Reconciler) updateTraitStatus(ctx context.Context, trait *vzapi.BackupTrait, results *reconcileresults.ReconcileResults, lastBackup *vzapi.LastBackupStatus, log vzlog.VerrazzanoLogger) (reconcile.Result, error) {
+	if updateStatusIfRequired(&trait.Status, results, lastBackup) {
+		if err := r.Status().Update(ctx, trait); err != nil {
+			return vzlogInit.IgnoreConflictWithLog(fmt.Sprintf("Failed to update backup trait %s status", trait.Name), err, zap.S())
+		}
+		log.Debugf("Updated backup trait %s status", trait.Name)
+	}
+
+	// If the results contained errors then requeue with a delay, most errors need user intervention.
+	if results.ContainsErrors() {
+		vzlogInit.ResultErrorsWithLog(fmt.Sprintf("Failed to reconcile backup trait %s", trait.Name), results.Errors, zap.S())
+		return clusters.NewRequeueWithDelay(), nil
+	}
+
+	// Requeue with a jittered delay to refresh the last backup reported in the trait status
+	var seconds = rand.IntnRange(45, 90)
+	return reconcile.Result{Requeue: true, RequeueAfter: time.Duration(seconds) * time.Second}, nil
+}
+
+// updateStatusIfRequired updates the trait status (i.e. resources, conditions and last backup) if they have changed.
+// Returns a boolean indicating if the status has been updated.
+func updateStatusIfRequired(status *vzapi.BackupTraitStatus, results *reconcileresults.ReconcileResults, lastBackup *vzapi.LastBackupStatus) bool {
+	updated := false
+	relations := results.CreateRelations()
+	if !vzapi.QualifiedResourceRelationSlicesEquivalent(status.Resources, relations) {
+		status.Resources = relations
+		updated = true
+	}
+	conditionedStatus := results.CreateConditionedStatus()
+	if !reconcileresults.ConditionedStatusEquivalent(&status.ConditionedStatus, &conditionedStatus) {
+		status.ConditionedStatus = conditionedStatus
+		updated = true
+	}
+	if lastBackup != nil && (status.LastBackup == nil || !lastBackupEquivalent(status.LastBackup, lastBackup)) {
+		status.LastBackup = lastBackup
+		updated = true
+	}
+	return updated
+}
+
+// lastBackupEquivalent returns true if the two last backup states are the same, ignoring the precision of the times
+func lastBackupEquivalent(a *vzapi.LastBackupStatus, b *vzapi.LastBackupStatus) bool {
+	return a.Name == b.Name && a.Phase == b.Phase && a.Errors == b.Errors && a.Warnings == b.Warnings &&
+		a.StartTimestamp.Equal(b.StartTimestamp) && a.CompletionTimestamp.Equal(b.CompletionTimestamp)
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backuptrait
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace     = "unit-test-namespace"
+	testTraitName     = "unit-test-trait"
+	testWorkloadName  = "unit-test-workload"
+	testAppName       = "unit-test-app"
+	testComponentName = "unit-test-component"
+	testClaimName     = "unit-test-claim"
+	testScheduleName  = testNamespace + "-" + testTraitName
+)
+
+// newScheme creates a new scheme that includes this package's object for use by client
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	return scheme
+}
+
+// newReconciler creates a new reconciler for testing
+func newReconciler(c client.Client) Reconciler {
+	return Reconciler{
+		Client: c,
+		Log:    zap.S(),
+		Scheme: newScheme(),
+	}
+}
+
+// newTrait creates a backup trait for the given workload
+func newTrait(kind string, spec vzapi.BackupTraitSpec) *vzapi.BackupTrait {
+	spec.WorkloadReference = oamrt.TypedReference{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: kind, Name: testWorkloadName}
+	return &vzapi.BackupTrait{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  testNamespace,
+			Name:       testTraitName,
+			Generation: 1,
+			Labels:     map[string]string{oam.LabelAppName: testAppName, oam.LabelAppComponent: testComponentName},
+		},
+		Spec: spec,
+	}
+}
+
+// newWorkload creates a workload of the given kind with the given spec
+func newWorkload(kind string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "oam.verrazzano.io/v1alpha1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"namespace": testNamespace, "name": testWorkloadName},
+		"spec":       spec,
+	}}
+}
+
+// newHelidonMySQLWorkload creates a Helidon workload running a MySQL container
+func newHelidonMySQLWorkload() *unstructured.Unstructured {
+	return newWorkload(helidonWorkloadKind, map[string]interface{}{
+		"deploymentTemplate": map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "unit-test-deployment"},
+			"podSpec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "db", "image": "ghcr.io/oracle/mysql-server:8.0.32"},
+			}},
+		},
+	})
+}
+
+// newAppObjects creates the ApplicationConfiguration and Component of the trait, and a pod of the component
+// mounting a PersistentVolumeClaim
+func newAppObjects() []client.Object {
+	return []client.Object{
+		&oamv1.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName}},
+		&oamv1.Component{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testComponentName}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "unit-test-pod", Labels: map[string]string{oam.LabelAppName: testAppName, oam.LabelAppComponent: testComponentName}},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: testClaimName}},
+			}}},
+		},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testClaimName}},
+	}
+}
+
+// newVeleroBackup creates a Velero Backup of the schedule of the test trait
+func newVeleroBackup(name string, created time.Time, phase string) *unstructured.Unstructured {
+	backup := newVeleroObject(vzapi.QualifiedResourceRelation{APIVersion: veleroAPIVersion, Kind: backupKind, Namespace: constants.VeleroNamespace, Name: name})
+	backup.SetLabels(map[string]string{scheduleNameLabel: testScheduleName})
+	backup.SetCreationTimestamp(metav1.NewTime(created))
+	backup.Object["status"] = map[string]interface{}{"phase": phase, "startTimestamp": created.Format(time.RFC3339)}
+	return backup
+}
+
+// reconcileTrait runs a reconcile for the test trait
+func reconcileTrait(t *testing.T, cli client.Client) ctrl.Result {
+	reconciler := newReconciler(cli)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testTraitName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	return result
+}
+
+// getTrait fetches the test trait
+func getTrait(t *testing.T, cli client.Client) *vzapi.BackupTrait {
+	trait := &vzapi.BackupTrait{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, trait))
+	return trait
+}
+
+// getVeleroObject fetches a Velero resource of the test trait
+func getVeleroObject(cli client.Client, kind string, name string) (*unstructured.Unstructured, error) {
+	obj := newVeleroObject(vzapi.QualifiedResourceRelation{APIVersion: veleroAPIVersion, Kind: kind, Namespace: constants.VeleroNamespace, Name: name})
+	return obj, cli.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+}
+
+// TestReconcileOneOffBackup tests reconciling a backup trait without a schedule
+// GIVEN a backup trait without a schedule for a Helidon workload running MySQL
+// WHEN the trait is reconciled
+// THEN a Velero Backup of the component is created with a hook flushing the MySQL tables, the application resources
+// are labeled for the backup, and the state of the backup is reported in the trait status once it completes
+func TestReconcileOneOffBackup(t *testing.T) {
+	asserts := assert.New(t)
+	ttl := metav1.Duration{Duration: 72 * time.Hour}
+	trait := newTrait(helidonWorkloadKind, vzapi.BackupTraitSpec{TTL: &ttl, StorageLocation: "unit-test-location"})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(append(newAppObjects(), trait, newHelidonMySQLWorkload())...).Build()
+
+	result := reconcileTrait(t, cli)
+	asserts.True(result.Requeue)
+
+	backupName := getVeleroRelation(getTrait(t, cli)).Name
+	backup, err := getVeleroObject(cli, backupKind, backupName)
+	asserts.NoError(err)
+	asserts.Equal(testAppName, backup.GetLabels()[oam.LabelAppName])
+	namespaces, _, _ := unstructured.NestedStringSlice(backup.Object, "spec", "includedNamespaces")
+	asserts.Equal([]string{testNamespace}, namespaces)
+	selectors, _, _ := unstructured.NestedSlice(backup.Object, "spec", "orLabelSelectors")
+	asserts.Len(selectors, 3)
+	asserts.Equal(map[string]interface{}{"matchLabels": map[string]interface{}{oam.LabelAppName: testAppName, oam.LabelAppComponent: testComponentName}}, selectors[2])
+	ttlValue, _, _ := unstructured.NestedString(backup.Object, "spec", "ttl")
+	asserts.Equal("72h0m0s", ttlValue)
+	location, _, _ := unstructured.NestedString(backup.Object, "spec", "storageLocation")
+	asserts.Equal("unit-test-location", location)
+	snapshotVolumes, _, _ := unstructured.NestedBool(backup.Object, "spec", "snapshotVolumes")
+	asserts.True(snapshotVolumes)
+	hooks, _, _ := unstructured.NestedSlice(backup.Object, "spec", "hooks", "resources")
+	asserts.Len(hooks, 1)
+	pre, _, _ := unstructured.NestedSlice(hooks[0].(map[string]interface{}), "pre")
+	asserts.Len(pre, 1)
+	container, _, _ := unstructured.NestedString(pre[0].(map[string]interface{}), "exec", "container")
+	asserts.Equal("db", container)
+
+	appConfig := &oamv1.ApplicationConfiguration{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testAppName}, appConfig))
+	asserts.Equal(map[string]string{constants.BackupAppLabel: testAppName}, appConfig.Labels)
+	pvc := &corev1.PersistentVolumeClaim{}
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testClaimName}, pvc))
+	asserts.Equal(testComponentName, pvc.Labels[constants.BackupComponentLabel])
+
+	trait = getTrait(t, cli)
+	asserts.Contains(trait.Finalizers, finalizerName)
+	asserts.Equal(corev1.ConditionTrue, trait.Status.Conditions[0].Status)
+	asserts.Len(trait.Status.Resources, 4)
+	asserts.Equal(&vzapi.LastBackupStatus{Name: backupName}, trait.Status.LastBackup)
+
+	// GIVEN the Velero Backup has completed
+	// WHEN the trait is reconciled again
+	// THEN the trait status reports the completed backup, and no other backup is created
+	asserts.NoError(unstructured.SetNestedField(backup.Object, "Completed", "status", "phase"))
+	asserts.NoError(unstructured.SetNestedField(backup.Object, "2023-03-01T02:05:00Z", "status", "completionTimestamp"))
+	asserts.NoError(cli.Update(context.TODO(), backup))
+	reconcileTrait(t, cli)
+
+	trait = getTrait(t, cli)
+	asserts.Equal(backupName, trait.Status.LastBackup.Name)
+	asserts.Equal("Completed", trait.Status.LastBackup.Phase)
+	asserts.Equal(time.Date(2023, 3, 1, 2, 5, 0, 0, time.UTC), trait.Status.LastBackup.CompletionTimestamp.UTC())
+	backups := &unstructured.UnstructuredList{}
+	backups.SetGroupVersionKind(backupListGVK)
+	asserts.NoError(cli.List(context.TODO(), backups))
+	asserts.Len(backups.Items, 1)
+}
+
+// TestReconcileScheduledWebLogicBackup tests reconciling a backup trait with a schedule for a WebLogic workload
+// GIVEN a backup trait with a schedule for a WebLogic workload, and two backups taken by its schedule
+// WHEN the trait is reconciled
+// THEN a Velero Schedule is created with hooks suspending and resuming the WebLogic managed servers, and the trait
+// status reports the most recent backup
+func TestReconcileScheduledWebLogicBackup(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait(weblogicWorkloadKind, vzapi.BackupTraitSpec{Schedule: "0 2 * * *"})
+	workload := newWorkload(weblogicWorkloadKind, map[string]interface{}{
+		"template": map[string]interface{}{"metadata": map[string]interface{}{"name": "tododomain"}},
+	})
+	now := time.Now().UTC().Truncate(time.Second)
+	objects := append(newAppObjects(), trait, workload,
+		newVeleroBackup(testScheduleName+"-1", now.Add(-24*time.Hour), "Completed"),
+		newVeleroBackup(testScheduleName+"-2", now, "InProgress"))
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+
+	reconcileTrait(t, cli)
+
+	schedule, err := getVeleroObject(cli, scheduleKind, testScheduleName)
+	asserts.NoError(err)
+	cron, _, _ := unstructured.NestedString(schedule.Object, "spec", "schedule")
+	asserts.Equal("0 2 * * *", cron)
+	hooks, _, _ := unstructured.NestedSlice(schedule.Object, "spec", "template", "hooks", "resources")
+	asserts.Len(hooks, 1)
+	hook := hooks[0].(map[string]interface{})
+	domainUID, _, _ := unstructured.NestedString(hook, "labelSelector", "matchLabels", weblogicDomainUIDLabel)
+	asserts.Equal("tododomain", domainUID)
+	pre, _, _ := unstructured.NestedSlice(hook, "pre")
+	command, _, _ := unstructured.NestedStringSlice(pre[0].(map[string]interface{}), "exec", "command")
+	asserts.True(strings.HasSuffix(command[2], `/suspend"`))
+	post, _, _ := unstructured.NestedSlice(hook, "post")
+	onError, _, _ := unstructured.NestedString(post[0].(map[string]interface{}), "exec", "onError")
+	asserts.Equal(hookOnErrorContinue, onError)
+
+	trait = getTrait(t, cli)
+	asserts.Equal(testScheduleName+"-2", trait.Status.LastBackup.Name)
+	asserts.Equal("InProgress", trait.Status.LastBackup.Phase)
+	asserts.Equal(now, trait.Status.LastBackup.StartTimestamp.UTC())
+}
+
+// TestReconcileScheduleRemoved tests removing the schedule of a backup trait
+// GIVEN a backup trait whose schedule has been removed
+// WHEN the trait is reconciled
+// THEN the previous Velero Schedule is deleted and a Velero Backup is created instead
+func TestReconcileScheduleRemoved(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait(helidonWorkloadKind, vzapi.BackupTraitSpec{})
+	scheduleRel := vzapi.QualifiedResourceRelation{APIVersion: veleroAPIVersion, Kind: scheduleKind, Namespace: constants.VeleroNamespace, Name: testScheduleName, Role: scheduleRole}
+	trait.Status.Resources = []vzapi.QualifiedResourceRelation{scheduleRel}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(append(newAppObjects(), trait, newHelidonMySQLWorkload(), newVeleroObject(scheduleRel))...).Build()
+
+	reconcileTrait(t, cli)
+
+	_, err := getVeleroObject(cli, scheduleKind, testScheduleName)
+	asserts.True(k8serrors.IsNotFound(err))
+	_, err = getVeleroObject(cli, backupKind, getVeleroRelation(getTrait(t, cli)).Name)
+	asserts.NoError(err)
+}
+
+// TestReconcileInvalidTrait tests reconciling a trait with an invalid schedule
+// GIVEN a backup trait with a schedule that is not a cron expression
+// WHEN the trait is reconciled
+// THEN no Velero resource is created and the trait status reports the error
+func TestReconcileInvalidTrait(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait(helidonWorkloadKind, vzapi.BackupTraitSpec{Schedule: "every night"})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(append(newAppObjects(), trait, newHelidonMySQLWorkload())...).Build()
+
+	reconcileTrait(t, cli)
+
+	_, err := getVeleroObject(cli, scheduleKind, testScheduleName)
+	asserts.True(k8serrors.IsNotFound(err))
+	trait = getTrait(t, cli)
+	asserts.Equal(corev1.ConditionFalse, trait.Status.Conditions[0].Status)
+	asserts.Contains(trait.Status.Conditions[0].Message, "every night")
+}
+
+// TestResolveHooks tests the hooks run in the pods of a component
+func TestResolveHooks(t *testing.T) {
+	asserts := assert.New(t)
+	coherence := newWorkload(coherenceWorkloadKind, map[string]interface{}{
+		"template": map[string]interface{}{"metadata": map[string]interface{}{"name": "unit-test-coherence"}},
+	})
+
+	// GIVEN a trait for a Coherence workload
+	// WHEN the hooks are resolved
+	// THEN the Coherence services of the Coherence deployment are suspended and resumed
+	r := newReconciler(fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(coherence).Build())
+	hooks, err := r.resolveHooks(context.TODO(), newTrait(coherenceWorkloadKind, vzapi.BackupTraitSpec{}))
+	asserts.NoError(err)
+	asserts.Equal(map[string]string{coherenceDeployLabel: "unit-test-coherence"}, hooks.selector.MatchLabels)
+	asserts.Equal(coherenceContainer, hooks.pre[0].container)
+	asserts.Contains(hooks.pre[0].command[2], "/suspend")
+	asserts.Contains(hooks.post[0].command[2], "/resume")
+
+	// GIVEN a trait replacing the pre-backup hooks of a Coherence workload
+	// WHEN the hooks are resolved
+	// THEN the pre-backup hooks of the trait are used along with the post-backup hooks of the workload
+	hooks, err = r.resolveHooks(context.TODO(), newTrait(coherenceWorkloadKind, vzapi.BackupTraitSpec{Hooks: &vzapi.BackupHooks{
+		Pre: []vzapi.BackupHookCommand{{Command: []string{"/bin/true"}, Timeout: &metav1.Duration{Duration: time.Minute}}},
+	}}))
+	asserts.NoError(err)
+	asserts.Equal([]hookExec{{command: []string{"/bin/true"}, onError: hookOnErrorFail, timeout: time.Minute}}, hooks.pre)
+	asserts.Contains(hooks.post[0].command[2], "/resume")
+
+	// GIVEN a trait disabling the hooks
+	// WHEN the hooks are resolved
+	// THEN no hooks are run
+	hooks, err = r.resolveHooks(context.TODO(), newTrait(coherenceWorkloadKind, vzapi.BackupTraitSpec{Hooks: &vzapi.BackupHooks{Disabled: true}}))
+	asserts.NoError(err)
+	asserts.Nil(hooks)
+
+	// GIVEN a trait for a Helidon workload without a MySQL container
+	// WHEN the hooks are resolved
+	// THEN no hooks are run
+	helidon := newWorkload(helidonWorkloadKind, map[string]interface{}{
+		"deploymentTemplate": map[string]interface{}{"podSpec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "ghcr.io/oracle/helidon-mysql-client:1.0"},
+		}}},
+	})
+	r = newReconciler(fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(helidon).Build())
+	hooks, err = r.resolveHooks(context.TODO(), newTrait(helidonWorkloadKind, vzapi.BackupTraitSpec{}))
+	asserts.NoError(err)
+	asserts.Nil(hooks)
+}
+
+// TestValidateTrait tests the validation of the trait spec
+func TestValidateTrait(t *testing.T) {
+	asserts := assert.New(t)
+
+	// GIVEN a trait with a schedule that does not have five fields
+	// WHEN the trait is validated
+	// THEN an error is returned
+	asserts.Error(validateTrait(newTrait("", vzapi.BackupTraitSpec{Schedule: "0 2 * *"})))
+
+	// GIVEN a trait with a hook without a command
+	// WHEN the trait is validated
+	// THEN an error is returned
+	asserts.Error(validateTrait(newTrait("", vzapi.BackupTraitSpec{Hooks: &vzapi.BackupHooks{Post: []vzapi.BackupHookCommand{{Container: "app"}}}})))
+
+	// GIVEN a trait without the labels of its component
+	// WHEN the trait is validated
+	// THEN an error is returned
+	trait := newTrait("", vzapi.BackupTraitSpec{})
+	trait.Labels = nil
+	asserts.Error(validateTrait(trait))
+
+	// GIVEN valid traits
+	// WHEN the traits are validated
+	// THEN no error is returned
+	asserts.NoError(validateTrait(newTrait("", vzapi.BackupTraitSpec{Schedule: "@daily"})))
+	asserts.NoError(validateTrait(newTrait("", vzapi.BackupTraitSpec{Schedule: "30 1 * * 0"})))
+}
+
+// TestIsMySQLImage tests detecting MySQL server images
+func TestIsMySQLImage(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.True(isMySQLImage("mysql:8.0"))
+	asserts.True(isMySQLImage("container-registry.oracle.com/mysql/mysql-server:8.0.32"))
+	asserts.True(isMySQLImage("ghcr.io/verrazzano/mysql@sha256:0123"))
+	asserts.False(isMySQLImage("ghcr.io/oracle/helidon-mysql-client:1.0"))
+	asserts.False(isMySQLImage("mysql/app"))
+}
+
+// TestGetValidLabelValue tests the label value of a long Velero Schedule name
+func TestGetValidLabelValue(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.Equal(testScheduleName, getValidLabelValue(testScheduleName))
+	long := getValidLabelValue(strings.Repeat("a", 80))
+	asserts.Len(long, 63)
+	asserts.True(strings.HasPrefix(long, strings.Repeat("a", 57)))
+}
+
+// TestReconcileDeletedTrait tests reconciling a trait that is being deleted
+// GIVEN a backup trait with a schedule that is being deleted
+// WHEN the trait is reconciled
+// THEN the Velero Schedule is deleted, the backups are kept and the finalizer is removed from the trait
+func TestReconcileDeletedTrait(t *testing.T) {
+	asserts := assert.New(t)
+	trait := newTrait(helidonWorkloadKind, vzapi.BackupTraitSpec{Schedule: "0 2 * * *"})
+	trait.Finalizers = []string{finalizerName}
+	now := metav1.Now()
+	trait.DeletionTimestamp = &now
+	scheduleRel := getVeleroRelation(trait)
+	trait.Status.Resources = []vzapi.QualifiedResourceRelation{scheduleRel}
+	backup := newVeleroBackup(testScheduleName+"-1", now.Time, "Completed")
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(trait, newVeleroObject(scheduleRel), backup).Build()
+
+	result := reconcileTrait(t, cli)
+	asserts.False(result.Requeue)
+
+	_, err := getVeleroObject(cli, scheduleKind, testScheduleName)
+	asserts.True(k8serrors.IsNotFound(err))
+	_, err = getVeleroObject(cli, backupKind, backup.GetName())
+	asserts.NoError(err)
+	// the trait is deleted once the finalizer has been removed
+	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testTraitName}, trait)
+	asserts.True(k8serrors.IsNotFound(err))
+}
+
+// TestReconcileKubeSystem tests to make sure we do not reconcile
+// Any resource that belong to the kube-system namespace
+func TestReconcileKubeSystem(t *testing.T) {
+	asserts := assert.New(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	reconciler := newReconciler(cli)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "kube-system", Name: testTraitName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(err)
+	asserts.False(result.Requeue)
+}
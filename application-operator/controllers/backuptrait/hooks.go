@@ -0,0 +1,258 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backuptrait
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	helidonWorkloadKind   = "VerrazzanoHelidonWorkload"
+	coherenceWorkloadKind = "VerrazzanoCoherenceWorkload"
+	weblogicWorkloadKind  = "VerrazzanoWebLogicWorkload"
+
+	weblogicServerContainer = "weblogic-server"
+	weblogicDomainUIDLabel  = "weblogic.domainUID"
+	weblogicClusterLabel    = "weblogic.clusterName"
+	coherenceContainer      = "coherence"
+	coherenceDeployLabel    = "coherenceDeployment"
+	mysqlImageName          = "mysql"
+
+	hookOnErrorFail     = "Fail"
+	hookOnErrorContinue = "Continue"
+)
+
+var (
+	// The WebLogic managed servers are suspended through the WebLogic REST API of the admin server, with the
+	// credentials mounted into the server pods by the WebLogic operator. A suspended server completes its in-flight
+	// work and stops accepting new requests.
+	weblogicLifecycleCommand = `curl --silent --fail --user "$(cat /weblogic-operator/secrets/username):$(cat /weblogic-operator/secrets/password)" ` +
+		`-H "X-Requested-By: verrazzano" -H "Content-Type: application/json" -X POST -d "{}" ` +
+		`"http://$(echo "${DOMAIN_UID}-${ADMIN_NAME}" | tr "[:upper:]_" "[:lower:]-"):${ADMIN_PORT}/management/weblogic/latest/domainRuntime/serverLifeCycleRuntimes/${SERVER_NAME}/%s"`
+
+	// The Coherence services are suspended through the health endpoint of the Coherence operator, a suspended service
+	// writes its persistent state to disk
+	coherenceLifecycleCommand = "curl --silent --fail -X POST http://127.0.0.1:6676/%s"
+
+	// The MySQL tables are closed and their data written to disk
+	mysqlFlushCommand = `mysql --user=root --password="${MYSQL_ROOT_PASSWORD}" --execute="FLUSH TABLES"`
+)
+
+// hookResource defines the hooks run in the pods of a component selected by a label selector
+type hookResource struct {
+	name     string
+	selector *metav1.LabelSelector
+	pre      []hookExec
+	post     []hookExec
+}
+
+// hookExec defines a command run in a container of the pods selected by a hook resource
+type hookExec struct {
+	container string
+	command   []string
+	onError   string
+	timeout   time.Duration
+}
+
+// resolveHooks determines the hooks run in the pods of the trait's component. The hooks appropriate for the workload
+// are used unless the trait replaces or disables them.
+func (r *Reconciler) resolveHooks(ctx context.Context, trait *vzapi.BackupTrait) (*hookResource, error) {
+	overrides := trait.Spec.Hooks
+	if overrides != nil && overrides.Disabled {
+		return nil, nil
+	}
+	ref := trait.Spec.WorkloadReference
+	workload := &unstructured.Unstructured{}
+	workload.SetAPIVersion(ref.APIVersion)
+	workload.SetKind(ref.Kind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: trait.Namespace, Name: ref.Name}, workload); err != nil {
+		return nil, fmt.Errorf("failed to fetch workload %s %s: %v", ref.Kind, ref.Name, err)
+	}
+	hooks, err := getWorkloadHooks(trait, workload)
+	if err != nil {
+		return nil, err
+	}
+	if overrides == nil || (len(overrides.Pre) == 0 && len(overrides.Post) == 0) {
+		return hooks, nil
+	}
+	if hooks == nil {
+		hooks = &hookResource{
+			name:     trait.Labels[oam.LabelAppComponent],
+			selector: componentSelector(trait),
+		}
+	}
+	if len(overrides.Pre) > 0 {
+		hooks.pre = toHookExecs(overrides.Pre, hookOnErrorFail)
+	}
+	if len(overrides.Post) > 0 {
+		hooks.post = toHookExecs(overrides.Post, hookOnErrorContinue)
+	}
+	return hooks, nil
+}
+
+// getWorkloadHooks returns the hooks appropriate for a workload, or nil if the workload does not need any.
+// The WebLogic managed servers are suspended during the backup, as are the Coherence services. The tables of
+// MySQL containers are flushed before the backup.
+func getWorkloadHooks(trait *vzapi.BackupTrait, workload *unstructured.Unstructured) (*hookResource, error) {
+	switch workload.GetKind() {
+	case weblogicWorkloadKind:
+		domainUID, _, _ := unstructured.NestedString(workload.Object, "spec", "template", "spec", "domainUID")
+		if domainUID == "" {
+			domainUID, _, _ = unstructured.NestedString(workload.Object, "spec", "template", "metadata", "name")
+		}
+		if domainUID == "" {
+			return nil, fmt.Errorf("workload %s is missing spec.template.metadata.name", workload.GetName())
+		}
+		return &hookResource{
+			name: domainUID,
+			selector: &metav1.LabelSelector{
+				MatchLabels:      map[string]string{weblogicDomainUIDLabel: domainUID},
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: weblogicClusterLabel, Operator: metav1.LabelSelectorOpExists}},
+			},
+			pre:  []hookExec{newShellHook(weblogicServerContainer, fmt.Sprintf(weblogicLifecycleCommand, "suspend"), hookOnErrorFail, 5*time.Minute)},
+			post: []hookExec{newShellHook(weblogicServerContainer, fmt.Sprintf(weblogicLifecycleCommand, "resume"), hookOnErrorContinue, 2*time.Minute)},
+		}, nil
+
+	case coherenceWorkloadKind:
+		name, _, _ := unstructured.NestedString(workload.Object, "spec", "template", "metadata", "name")
+		if name == "" {
+			return nil, fmt.Errorf("workload %s is missing spec.template.metadata.name", workload.GetName())
+		}
+		return &hookResource{
+			name:     name,
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{coherenceDeployLabel: name}},
+			pre:      []hookExec{newShellHook(coherenceContainer, fmt.Sprintf(coherenceLifecycleCommand, "suspend"), hookOnErrorFail, 2*time.Minute)},
+			post:     []hookExec{newShellHook(coherenceContainer, fmt.Sprintf(coherenceLifecycleCommand, "resume"), hookOnErrorContinue, 2*time.Minute)},
+		}, nil
+	}
+
+	containers, err := getWorkloadContainers(workload)
+	if err != nil {
+		return nil, err
+	}
+	var pre []hookExec
+	for _, c := range containers {
+		name, _, _ := unstructured.NestedString(c, "name")
+		image, _, _ := unstructured.NestedString(c, "image")
+		if isMySQLImage(image) {
+			pre = append(pre, newShellHook(name, mysqlFlushCommand, hookOnErrorFail, time.Minute))
+		}
+	}
+	if len(pre) == 0 {
+		return nil, nil
+	}
+	return &hookResource{
+		name:     trait.Labels[oam.LabelAppComponent],
+		selector: componentSelector(trait),
+		pre:      pre,
+	}, nil
+}
+
+// getWorkloadContainers returns the containers of the pods of a Helidon or containerized workload
+func getWorkloadContainers(workload *unstructured.Unstructured) ([]map[string]interface{}, error) {
+	var fields []string
+	switch workload.GetKind() {
+	case helidonWorkloadKind:
+		fields = []string{"spec", "deploymentTemplate", "podSpec", "containers"}
+	case oamcore.ContainerizedWorkloadKind:
+		fields = []string{"spec", "containers"}
+	default:
+		return nil, nil
+	}
+	containers, _, err := unstructured.NestedSlice(workload.Object, fields...)
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]interface{}
+	for _, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok {
+			result = append(result, container)
+		}
+	}
+	return result, nil
+}
+
+// isMySQLImage returns true if the repository of an image is a MySQL server, for example, mysql/mysql-server:8.0.32
+func isMySQLImage(image string) bool {
+	repository := path.Base(image)
+	if i := strings.IndexAny(repository, ":@"); i >= 0 {
+		repository = repository[:i]
+	}
+	return repository == mysqlImageName || strings.HasPrefix(repository, mysqlImageName+"-")
+}
+
+// componentSelector returns the label selector of the pods of the trait's component
+func componentSelector(trait *vzapi.BackupTrait) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{oam.LabelAppName: trait.Labels[oam.LabelAppName], oam.LabelAppComponent: trait.Labels[oam.LabelAppComponent]}}
+}
+
+// newShellHook returns a hook running a shell command in a container
+func newShellHook(container string, command string, onError string, timeout time.Duration) hookExec {
+	return hookExec{container: container, command: []string{"/bin/sh", "-c", command}, onError: onError, timeout: timeout}
+}
+
+// toHookExecs converts the hook commands of a trait to hooks
+func toHookExecs(commands []vzapi.BackupHookCommand, onError string) []hookExec {
+	var execs []hookExec
+	for _, c := range commands {
+		exec := hookExec{container: c.Container, command: c.Command, onError: onError}
+		if c.Timeout != nil {
+			exec.timeout = c.Timeout.Duration
+		}
+		execs = append(execs, exec)
+	}
+	return execs
+}
+
+// toVelero converts the hooks to a Velero backup hook resource
+func (h *hookResource) toVelero(namespace string) map[string]interface{} {
+	selector, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(h.selector)
+	resource := map[string]interface{}{
+		"name":               h.name,
+		"includedNamespaces": []interface{}{namespace},
+		"labelSelector":      selector,
+	}
+	if len(h.pre) > 0 {
+		resource["pre"] = toVeleroExecs(h.pre)
+	}
+	if len(h.post) > 0 {
+		resource["post"] = toVeleroExecs(h.post)
+	}
+	return resource
+}
+
+// toVeleroExecs converts hooks to Velero exec hooks
+func toVeleroExecs(execs []hookExec) []interface{} {
+	var hooks []interface{}
+	for _, e := range execs {
+		command := make([]interface{}, 0, len(e.command))
+		for _, arg := range e.command {
+			command = append(command, arg)
+		}
+		exec := map[string]interface{}{
+			"command": command,
+			"onError": e.onError,
+		}
+		if e.container != "" {
+			exec["container"] = e.container
+		}
+		if e.timeout > 0 {
+			exec["timeout"] = e.timeout.String()
+		}
+		hooks = append(hooks, map[string]interface{}{"exec": exec})
+	}
+	return hooks
+}
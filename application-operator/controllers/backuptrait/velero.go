@@ -0,0 +1,316 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package backuptrait
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	veleroAPIVersion   = "velero.io/v1"
+	backupKind         = "Backup"
+	scheduleKind       = "Schedule"
+	scheduleNameLabel  = "velero.io/schedule-name"
+	cronFieldCount     = 5
+	labelNameHashChars = 6
+)
+
+var backupListGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "BackupList"}
+
+// validateTrait validates the schedule, the retention and the hooks of a trait
+func validateTrait(trait *vzapi.BackupTrait) error {
+	if trait.Labels[oam.LabelAppName] == "" || trait.Labels[oam.LabelAppComponent] == "" {
+		return fmt.Errorf("the backup trait must be labeled with the %s and %s labels of its component", oam.LabelAppName, oam.LabelAppComponent)
+	}
+	schedule := trait.Spec.Schedule
+	if schedule != "" && !strings.HasPrefix(schedule, "@") && len(strings.Fields(schedule)) != cronFieldCount {
+		return fmt.Errorf("schedule %s is not a cron expression with %d fields", schedule, cronFieldCount)
+	}
+	if trait.Spec.TTL != nil && trait.Spec.TTL.Duration < 0 {
+		return fmt.Errorf("ttl %v must not be negative", trait.Spec.TTL.Duration)
+	}
+	if hooks := trait.Spec.Hooks; hooks != nil {
+		for _, hook := range append(append([]vzapi.BackupHookCommand{}, hooks.Pre...), hooks.Post...) {
+			if len(hook.Command) == 0 {
+				return fmt.Errorf("backup hooks must specify a command")
+			}
+		}
+	}
+	return nil
+}
+
+// getVeleroRelation returns the Velero resource generated for a trait. A trait with a schedule generates a Schedule,
+// otherwise it generates a Backup for each generation of the trait, so that the component is backed up again each
+// time the trait is changed.
+func getVeleroRelation(trait *vzapi.BackupTrait) vzapi.QualifiedResourceRelation {
+	name := fmt.Sprintf("%s-%s", trait.Namespace, trait.Name)
+	if trait.Spec.Schedule != "" {
+		return vzapi.QualifiedResourceRelation{APIVersion: veleroAPIVersion, Kind: scheduleKind, Namespace: constants.VeleroNamespace, Name: name, Role: scheduleRole}
+	}
+	return vzapi.QualifiedResourceRelation{APIVersion: veleroAPIVersion, Kind: backupKind, Namespace: constants.VeleroNamespace, Name: fmt.Sprintf("%s-%d", name, trait.Generation), Role: backupRole}
+}
+
+// labelBackupSources labels the ApplicationConfiguration, the Component and the PersistentVolumeClaims of the
+// trait's component so that they are selected by the Velero backups. The resources generated for the component
+// are selected by their OAM labels. Returns the relations of the labeled resources.
+func (r *Reconciler) labelBackupSources(ctx context.Context, trait *vzapi.BackupTrait) ([]vzapi.QualifiedResourceRelation, error) {
+	appName := trait.Labels[oam.LabelAppName]
+	componentName := trait.Labels[oam.LabelAppComponent]
+	appLabels := map[string]string{constants.BackupAppLabel: appName}
+	componentLabels := map[string]string{constants.BackupAppLabel: appName, constants.BackupComponentLabel: componentName}
+
+	var relations []vzapi.QualifiedResourceRelation
+	appConfig := &oamcore.ApplicationConfiguration{}
+	if err := r.addLabels(ctx, client.ObjectKey{Namespace: trait.Namespace, Name: appName}, appConfig, appLabels); err != nil {
+		return relations, fmt.Errorf("failed to label ApplicationConfiguration %s: %v", appName, err)
+	}
+	relations = append(relations, sourceRelation(oamcore.ApplicationConfigurationGroupVersionKind, appConfig))
+
+	component := &oamcore.Component{}
+	if err := r.addLabels(ctx, client.ObjectKey{Namespace: trait.Namespace, Name: componentName}, component, componentLabels); err != nil {
+		return relations, fmt.Errorf("failed to label Component %s: %v", componentName, err)
+	}
+	relations = append(relations, sourceRelation(oamcore.ComponentGroupVersionKind, component))
+
+	claims, err := r.fetchComponentClaims(ctx, trait.Namespace, appName, componentName)
+	if err != nil {
+		return relations, fmt.Errorf("failed to list the PersistentVolumeClaims of component %s: %v", componentName, err)
+	}
+	for _, claim := range claims {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.addLabels(ctx, client.ObjectKey{Namespace: trait.Namespace, Name: claim}, pvc, componentLabels); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return relations, fmt.Errorf("failed to label PersistentVolumeClaim %s: %v", claim, err)
+		}
+		relations = append(relations, sourceRelation(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), pvc))
+	}
+	return relations, nil
+}
+
+// fetchComponentClaims returns the names of the PersistentVolumeClaims mounted by the pods of a component
+func (r *Reconciler) fetchComponentClaims(ctx context.Context, namespace string, appName string, componentName string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels{oam.LabelAppName: appName, oam.LabelAppComponent: componentName}); err != nil {
+		return nil, err
+	}
+	var claims []string
+	found := map[string]bool{}
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && !found[volume.PersistentVolumeClaim.ClaimName] {
+				found[volume.PersistentVolumeClaim.ClaimName] = true
+				claims = append(claims, volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+	return claims, nil
+}
+
+// addLabels adds labels to a resource, the resource is only patched if the labels are missing
+func (r *Reconciler) addLabels(ctx context.Context, key client.ObjectKey, obj client.Object, labels map[string]string) error {
+	if err := r.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	existing := obj.GetLabels()
+	missing := false
+	for k, v := range labels {
+		if existing[k] != v {
+			missing = true
+		}
+	}
+	if !missing {
+		return nil
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	for k, v := range labels {
+		existing[k] = v
+	}
+	obj.SetLabels(existing)
+	return r.Patch(ctx, obj, patch)
+}
+
+// sourceRelation returns the relation of a resource selected by the backups of a trait
+func sourceRelation(gvk schema.GroupVersionKind, obj client.Object) vzapi.QualifiedResourceRelation {
+	return vzapi.QualifiedResourceRelation{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Role: sourceRole}
+}
+
+// buildBackupSpec builds the spec of the Velero backups of a trait. The backups select, in the namespace of the
+// trait, the ApplicationConfiguration, the Component and PersistentVolumeClaims labeled by the trait and the
+// resources generated for the component, which carry its OAM labels.
+func buildBackupSpec(trait *vzapi.BackupTrait, hooks *hookResource) map[string]interface{} {
+	appName := trait.Labels[oam.LabelAppName]
+	componentName := trait.Labels[oam.LabelAppComponent]
+	spec := map[string]interface{}{
+		"includedNamespaces": []interface{}{trait.Namespace},
+		"orLabelSelectors": []interface{}{
+			map[string]interface{}{
+				"matchLabels": map[string]interface{}{constants.BackupAppLabel: appName},
+				"matchExpressions": []interface{}{
+					map[string]interface{}{"key": constants.BackupComponentLabel, "operator": string(metav1.LabelSelectorOpDoesNotExist)},
+				},
+			},
+			map[string]interface{}{
+				"matchLabels": map[string]interface{}{constants.BackupAppLabel: appName, constants.BackupComponentLabel: componentName},
+			},
+			map[string]interface{}{
+				"matchLabels": map[string]interface{}{oam.LabelAppName: appName, oam.LabelAppComponent: componentName},
+			},
+		},
+		"snapshotVolumes": trait.Spec.SnapshotVolumes == nil || *trait.Spec.SnapshotVolumes,
+	}
+	if trait.Spec.TTL != nil {
+		spec["ttl"] = trait.Spec.TTL.Duration.String()
+	}
+	if trait.Spec.StorageLocation != "" {
+		spec["storageLocation"] = trait.Spec.StorageLocation
+	}
+	if hooks != nil {
+		spec["hooks"] = map[string]interface{}{
+			"resources": []interface{}{hooks.toVelero(trait.Namespace)},
+		}
+	}
+	return spec
+}
+
+// createOrUpdateVeleroResource creates or updates the Velero Schedule of a trait, or creates its Velero Backup.
+// A Backup is never updated since Velero processes it only once.
+func (r *Reconciler) createOrUpdateVeleroResource(ctx context.Context, trait *vzapi.BackupTrait, rel vzapi.QualifiedResourceRelation, spec map[string]interface{}) (controllerutil.OperationResult, error) {
+	obj := newVeleroObject(rel)
+	if rel.Kind == backupKind {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err == nil || !k8serrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		setVeleroLabels(trait, obj)
+		obj.Object["spec"] = spec
+		if err := r.Create(ctx, obj); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultCreated, nil
+	}
+	return controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		setVeleroLabels(trait, obj)
+		return unstructured.SetNestedField(obj.Object, map[string]interface{}{
+			"schedule": trait.Spec.Schedule,
+			"template": spec,
+		}, "spec")
+	})
+}
+
+// deleteSchedule deletes a Velero Schedule previously generated for a trait
+func (r *Reconciler) deleteSchedule(ctx context.Context, rel vzapi.QualifiedResourceRelation) error {
+	if err := r.Delete(ctx, newVeleroObject(rel)); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// fetchLastBackup returns the state of the last Velero backup of a trait, or nil if the component has not been
+// backed up yet. The backups of a Schedule are labeled with the name of the Schedule.
+func (r *Reconciler) fetchLastBackup(ctx context.Context, trait *vzapi.BackupTrait, rel vzapi.QualifiedResourceRelation) (*vzapi.LastBackupStatus, error) {
+	if rel.Kind == backupKind {
+		backup := newVeleroObject(rel)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(backup), backup); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return newLastBackupStatus(backup), nil
+	}
+
+	backups := &unstructured.UnstructuredList{}
+	backups.SetGroupVersionKind(backupListGVK)
+	if err := r.List(ctx, backups, client.InNamespace(constants.VeleroNamespace), client.MatchingLabels{scheduleNameLabel: getValidLabelValue(rel.Name)}); err != nil {
+		return nil, err
+	}
+	var last *unstructured.Unstructured
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if last == nil || last.GetCreationTimestamp().Time.Before(backup.GetCreationTimestamp().Time) {
+			last = backup
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return newLastBackupStatus(last), nil
+}
+
+// newLastBackupStatus returns the state of a Velero Backup for use in the trait status
+func newLastBackupStatus(backup *unstructured.Unstructured) *vzapi.LastBackupStatus {
+	status := &vzapi.LastBackupStatus{Name: backup.GetName()}
+	status.Phase, _, _ = unstructured.NestedString(backup.Object, "status", "phase")
+	status.Errors, _, _ = unstructured.NestedInt64(backup.Object, "status", "errors")
+	status.Warnings, _, _ = unstructured.NestedInt64(backup.Object, "status", "warnings")
+	status.StartTimestamp = getNestedTime(backup, "status", "startTimestamp")
+	status.CompletionTimestamp = getNestedTime(backup, "status", "completionTimestamp")
+	return status
+}
+
+// getNestedTime returns a timestamp of a Velero Backup, or nil if it is not set
+func getNestedTime(obj *unstructured.Unstructured, fields ...string) *metav1.Time {
+	value, found, err := unstructured.NestedString(obj.Object, fields...)
+	if err != nil || !found {
+		return nil
+	}
+	t := &metav1.Time{}
+	if err := t.UnmarshalQueryParameter(value); err != nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// setVeleroLabels sets the OAM labels of the trait's component on a Velero resource
+func setVeleroLabels(trait *vzapi.BackupTrait, obj *unstructured.Unstructured) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, key := range []string{oam.LabelAppName, oam.LabelAppComponent} {
+		labels[key] = trait.Labels[key]
+	}
+	obj.SetLabels(labels)
+}
+
+// newVeleroObject returns the Velero resource of a relation
+func newVeleroObject(rel vzapi.QualifiedResourceRelation) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(rel.APIVersion)
+	obj.SetKind(rel.Kind)
+	obj.SetNamespace(rel.Namespace)
+	obj.SetName(rel.Name)
+	return obj
+}
+
+// getValidLabelValue returns the label value Velero uses for a resource name. Names longer than a label value are
+// truncated and suffixed with a hash of the name.
+func getValidLabelValue(name string) string {
+	if len(name) <= validation.DNS1035LabelMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	return name[:validation.DNS1035LabelMaxLength-labelNameHashChars] + hex.EncodeToString(hash[:])[:labelNameHashChars]
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -191,6 +192,40 @@ func SetClusterLevelStatus(status *clustersv1alpha1.MultiClusterResourceStatus,
 	}
 }
 
+// GetProjectQuotaStatus returns the quota status of a cluster in the project status, or nil if there is none
+func GetProjectQuotaStatus(status clustersv1alpha1.VerrazzanoProjectStatus, clusterName string) *clustersv1alpha1.ProjectQuotaStatus {
+	for i := range status.Quotas {
+		if status.Quotas[i].Cluster == clusterName {
+			return &status.Quotas[i]
+		}
+	}
+	return nil
+}
+
+// SetProjectQuotaStatus sets the quota status of a cluster in the project status, a nil quota status removes the
+// status of the cluster. Returns true if the project status changed.
+func SetProjectQuotaStatus(status *clustersv1alpha1.VerrazzanoProjectStatus, clusterName string, quotaStatus *clustersv1alpha1.ProjectQuotaStatus) bool {
+	for i, existing := range status.Quotas {
+		if existing.Cluster != clusterName {
+			continue
+		}
+		if quotaStatus == nil {
+			status.Quotas = append(status.Quotas[:i], status.Quotas[i+1:]...)
+			return true
+		}
+		if equality.Semantic.DeepEqual(existing, *quotaStatus) {
+			return false
+		}
+		status.Quotas[i] = *quotaStatus
+		return true
+	}
+	if quotaStatus == nil {
+		return false
+	}
+	status.Quotas = append(status.Quotas, *quotaStatus)
+	return true
+}
+
 // NewScheme creates a new scheme that includes this package's object to use for testing
 func NewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -336,6 +337,35 @@ func TestUpdateClusterLevelStatus(t *testing.T) {
 
 }
 
+// TestSetProjectQuotaStatus tests setting the quota status of a cluster in the project status
+// GIVEN a project status with the quota status of a cluster
+// WHEN SetProjectQuotaStatus is called
+// THEN the quota status of the cluster is added, updated or removed, and the result tells whether the status changed
+func TestSetProjectQuotaStatus(t *testing.T) {
+	status := clustersv1alpha1.VerrazzanoProjectStatus{
+		Quotas: []clustersv1alpha1.ProjectQuotaStatus{
+			{Cluster: "cluster1", Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1")}},
+		},
+	}
+
+	// the same usage in another format does not change the status
+	unchanged := clustersv1alpha1.ProjectQuotaStatus{Cluster: "cluster1", Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1000m")}}
+	asserts.False(t, SetProjectQuotaStatus(&status, "cluster1", &unchanged))
+
+	updated := clustersv1alpha1.ProjectQuotaStatus{Cluster: "cluster1", Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")}}
+	asserts.True(t, SetProjectQuotaStatus(&status, "cluster1", &updated))
+	asserts.Equal(t, updated, *GetProjectQuotaStatus(status, "cluster1"))
+
+	added := clustersv1alpha1.ProjectQuotaStatus{Cluster: "cluster2"}
+	asserts.True(t, SetProjectQuotaStatus(&status, "cluster2", &added))
+	asserts.Len(t, status.Quotas, 2)
+
+	asserts.True(t, SetProjectQuotaStatus(&status, "cluster1", nil))
+	asserts.False(t, SetProjectQuotaStatus(&status, "cluster1", nil))
+	asserts.Nil(t, GetProjectQuotaStatus(status, "cluster1"))
+	asserts.Equal(t, []clustersv1alpha1.ProjectQuotaStatus{added}, status.Quotas)
+}
+
 func expectMCRegistrationSecret(cli *mocks.MockClient, clusterName string, secreteNameFullName types.NamespacedName, times int) {
 	regSecretData := map[string][]byte{constants.ClusterNameData: []byte(clusterName)}
 	cli.EXPECT().
@@ -0,0 +1,279 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	projectLabel        = "verrazzano.io/project"
+	clusterLabel        = "verrazzano.io/cluster"
+	namespaceLabel      = "verrazzano.io/namespace"
+	inClusterName       = "in-cluster"
+	defaultGitRevision  = "HEAD"
+	gitOpsStatusPeriod  = 1 * time.Minute
+	syncStatusSynced    = "Synced"
+	syncStatusOutOfSync = "OutOfSync"
+	syncStatusUnknown   = "Unknown"
+	healthStatusMissing = "Missing"
+	healthStatusUnknown = "Unknown"
+)
+
+var (
+	appProjectGVK      = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "AppProject"}
+	applicationSetGVK  = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ApplicationSet"}
+	applicationListGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ApplicationList"}
+
+	// healthOrder orders the Argo CD health statuses from the best to the worst
+	healthOrder = []string{"Healthy", "Suspended", "Progressing", healthStatusMissing, "Degraded", healthStatusUnknown}
+)
+
+// syncGitOps creates or updates the Argo CD AppProject and ApplicationSet delivering the project applications from
+// Git to the project namespaces on the placement clusters. The Argo CD resources are deleted when the project no
+// longer uses GitOps.
+func (r *Reconciler) syncGitOps(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace {
+		return nil
+	}
+	if vp.Spec.GitOps == nil {
+		if vp.Status.GitOps == nil {
+			return nil
+		}
+		return r.deleteGitOps(ctx, vp, log)
+	}
+	if admin, err := r.isAdminCluster(ctx); err != nil || !admin {
+		return err
+	}
+
+	appProject := newArgoCDObject(appProjectGVK, vp.Name)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, appProject, func() error {
+		return mutateAppProject(vp, appProject)
+	}); err != nil {
+		return log.ErrorfNewErr("Failed to create or update Argo CD AppProject %s: %v", vp.Name, err)
+	}
+	appSet := newArgoCDObject(applicationSetGVK, vp.Name)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, appSet, func() error {
+		return mutateApplicationSet(vp, appSet)
+	}); err != nil {
+		return log.ErrorfNewErr("Failed to create or update Argo CD ApplicationSet %s: %v", vp.Name, err)
+	}
+	return nil
+}
+
+// deleteGitOps deletes the Argo CD ApplicationSet and AppProject of the project, Argo CD then deletes the project
+// applications
+func (r *Reconciler) deleteGitOps(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	if admin, err := r.isAdminCluster(ctx); err != nil || !admin {
+		return err
+	}
+	for _, gvk := range []schema.GroupVersionKind{applicationSetGVK, appProjectGVK} {
+		obj := newArgoCDObject(gvk, vp.Name)
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return log.ErrorfNewErr("Failed to delete Argo CD %s %s: %v", gvk.Kind, vp.Name, err)
+		}
+	}
+	log.Oncef("Deleted the Argo CD resources of project %s", vp.Name)
+	return nil
+}
+
+// updateGitOpsStatus rolls up the sync and health status of the project applications into the project status
+func (r *Reconciler) updateGitOpsStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject) error {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace {
+		return nil
+	}
+	var status *clustersv1alpha1.GitOpsStatus
+	if vp.Spec.GitOps != nil {
+		// The Argo CD applications only exist on the admin cluster, the status is left alone on the managed clusters
+		if admin, err := r.isAdminCluster(ctx); err != nil || !admin {
+			return err
+		}
+		apps := &unstructured.UnstructuredList{}
+		apps.SetGroupVersionKind(applicationListGVK)
+		if err := r.List(ctx, apps, client.InNamespace(vzconst.ArgoCDNamespace), client.MatchingLabels{projectLabel: vp.Name}); err != nil {
+			return err
+		}
+		status = newGitOpsStatus(vp, apps.Items)
+	}
+	if reflect.DeepEqual(status, vp.Status.GitOps) {
+		return nil
+	}
+	vp.Status.GitOps = status
+	return r.Status().Update(ctx, vp)
+}
+
+// isAdminCluster returns true unless the cluster is a registered managed cluster. The cluster agent runs the project
+// reconciler on every cluster, but the projects are only delivered from Git by the Argo CD of the admin cluster.
+func (r *Reconciler) isAdminCluster(ctx context.Context) (bool, error) {
+	err := r.Get(ctx, clusters.MCRegistrationSecretFullName, &corev1.Secret{})
+	if err == nil {
+		return false, nil
+	}
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// newGitOpsStatus returns the GitOps status of the project from its applications. Each project namespace on each
+// placement cluster without an application yet is reported as missing.
+func newGitOpsStatus(vp *clustersv1alpha1.VerrazzanoProject, apps []unstructured.Unstructured) *clustersv1alpha1.GitOpsStatus {
+	appsByDestination := map[string]unstructured.Unstructured{}
+	for _, app := range apps {
+		appsByDestination[app.GetLabels()[clusterLabel]+"/"+app.GetLabels()[namespaceLabel]] = app
+	}
+	status := &clustersv1alpha1.GitOpsStatus{Sync: syncStatusSynced, Health: healthOrder[0]}
+	for _, cluster := range vp.Spec.Placement.Clusters {
+		for _, ns := range vp.Spec.Template.Namespaces {
+			appStatus := clustersv1alpha1.GitOpsApplicationStatus{Cluster: cluster.Name, Namespace: ns.Metadata.Name, Sync: syncStatusUnknown, Health: healthStatusMissing}
+			if app, ok := appsByDestination[cluster.Name+"/"+ns.Metadata.Name]; ok {
+				appStatus.Sync = getNestedString(app, syncStatusUnknown, "status", "sync", "status")
+				appStatus.Health = getNestedString(app, healthStatusUnknown, "status", "health", "status")
+				appStatus.Revision = getNestedString(app, "", "status", "sync", "revision")
+			}
+			if appStatus.Sync != syncStatusSynced {
+				status.Sync = syncStatusOutOfSync
+			}
+			if healthRank(appStatus.Health) > healthRank(status.Health) {
+				status.Health = appStatus.Health
+			}
+			status.Applications = append(status.Applications, appStatus)
+		}
+	}
+	return status
+}
+
+// healthRank returns the rank of an Argo CD health status, the higher the worse
+func healthRank(health string) int {
+	for i, h := range healthOrder {
+		if h == health {
+			return i
+		}
+	}
+	return len(healthOrder) - 1
+}
+
+// getNestedString returns a string field of an Argo CD resource, or the default when it is missing
+func getNestedString(obj unstructured.Unstructured, defaultValue string, fields ...string) string {
+	value, found, err := unstructured.NestedString(obj.Object, fields...)
+	if err != nil || !found || value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// mutateAppProject scopes the AppProject to the Git repository of the project and to its namespaces on its placement
+// clusters, without any cluster scoped resource
+func mutateAppProject(vp *clustersv1alpha1.VerrazzanoProject, appProject *unstructured.Unstructured) error {
+	var destinations []interface{}
+	for _, cluster := range vp.Spec.Placement.Clusters {
+		for _, ns := range vp.Spec.Template.Namespaces {
+			destinations = append(destinations, map[string]interface{}{
+				"name":      getArgoCDClusterName(cluster.Name),
+				"namespace": ns.Metadata.Name,
+			})
+		}
+	}
+	appProject.SetLabels(map[string]string{projectLabel: vp.Name})
+	return unstructured.SetNestedField(appProject.Object, map[string]interface{}{
+		"description":              fmt.Sprintf("Verrazzano project %s", vp.Name),
+		"sourceRepos":              []interface{}{vp.Spec.GitOps.RepoURL},
+		"destinations":             destinations,
+		"clusterResourceWhitelist": []interface{}{},
+		"namespaceResourceWhitelist": []interface{}{
+			map[string]interface{}{"group": "*", "kind": "*"},
+		},
+	}, "spec")
+}
+
+// mutateApplicationSet generates an application per project namespace on each placement cluster, syncing the
+// subdirectory of the Git path named after the namespace to the namespace
+func mutateApplicationSet(vp *clustersv1alpha1.VerrazzanoProject, appSet *unstructured.Unstructured) error {
+	gitOps := vp.Spec.GitOps
+	revision := gitOps.Revision
+	if revision == "" {
+		revision = defaultGitRevision
+	}
+	var elements []interface{}
+	for _, cluster := range vp.Spec.Placement.Clusters {
+		for _, ns := range vp.Spec.Template.Namespaces {
+			elements = append(elements, map[string]interface{}{
+				"cluster":       cluster.Name,
+				"argoCDCluster": getArgoCDClusterName(cluster.Name),
+				"namespace":     ns.Metadata.Name,
+			})
+		}
+	}
+	appSpec := map[string]interface{}{
+		"project": vp.Name,
+		"source": map[string]interface{}{
+			"repoURL":        gitOps.RepoURL,
+			"path":           path.Join(gitOps.Path, "{{namespace}}"),
+			"targetRevision": revision,
+		},
+		"destination": map[string]interface{}{
+			"name":      "{{argoCDCluster}}",
+			"namespace": "{{namespace}}",
+		},
+	}
+	if gitOps.SyncPolicy.Automated {
+		appSpec["syncPolicy"] = map[string]interface{}{
+			"automated": map[string]interface{}{
+				"prune":    gitOps.SyncPolicy.Prune,
+				"selfHeal": gitOps.SyncPolicy.SelfHeal,
+			},
+		}
+	}
+	appSet.SetLabels(map[string]string{projectLabel: vp.Name})
+	return unstructured.SetNestedField(appSet.Object, map[string]interface{}{
+		"generators": []interface{}{
+			map[string]interface{}{"list": map[string]interface{}{"elements": elements}},
+		},
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": fmt.Sprintf("%s-{{cluster}}-{{namespace}}", vp.Name),
+				"labels": map[string]interface{}{
+					projectLabel:   vp.Name,
+					clusterLabel:   "{{cluster}}",
+					namespaceLabel: "{{namespace}}",
+				},
+			},
+			"spec": appSpec,
+		},
+	}, "spec")
+}
+
+// getArgoCDClusterName returns the name of a Verrazzano cluster in Argo CD, the managed clusters are registered in
+// Argo CD with their name and the admin cluster is the Argo CD in-cluster
+func getArgoCDClusterName(cluster string) string {
+	if cluster == constants.DefaultClusterName {
+		return inClusterName
+	}
+	return cluster
+}
+
+// newArgoCDObject returns an Argo CD resource of the project in the Argo CD namespace
+func newArgoCDObject(gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(vzconst.ArgoCDNamespace)
+	obj.SetName(name)
+	return obj
+}
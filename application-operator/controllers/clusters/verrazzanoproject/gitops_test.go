@@ -0,0 +1,224 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// gitDaemonRepo is the repository of a local git daemon standing in for the project Git repository
+const gitDaemonRepo = "git://127.0.0.1:9418/apps.git"
+
+// newGitOpsProject returns a project delivered from Git to two namespaces on the admin and a managed cluster
+func newGitOpsProject() *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "hello", Finalizers: []string{finalizerName}},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			GitOps: &clustersv1alpha1.GitOpsSpec{
+				RepoURL:    gitDaemonRepo,
+				Path:       "hello",
+				SyncPolicy: clustersv1alpha1.GitOpsSyncPolicy{Automated: true, Prune: true},
+			},
+			Placement: clustersv1alpha1.Placement{
+				Clusters: []clustersv1alpha1.Cluster{{Name: constants.DefaultClusterName}, {Name: "managed1"}},
+			},
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{
+					{Metadata: metav1.ObjectMeta{Name: "hello"}},
+					{Metadata: metav1.ObjectMeta{Name: "hello-db"}},
+				},
+			},
+		},
+	}
+}
+
+// newArgoCDApplication returns an Argo CD application generated for a project namespace on a cluster, as the fake
+// Argo CD API
+func newArgoCDApplication(project string, cluster string, namespace string, sync string, health string) *unstructured.Unstructured {
+	app := newArgoCDObject(applicationListGVK.GroupVersion().WithKind("Application"), project+"-"+cluster+"-"+namespace)
+	app.SetLabels(map[string]string{projectLabel: project, clusterLabel: cluster, namespaceLabel: namespace})
+	app.Object["status"] = map[string]interface{}{
+		"sync":   map[string]interface{}{"status": sync, "revision": "4f1d3c2"},
+		"health": map[string]interface{}{"status": health},
+	}
+	return app
+}
+
+// newGitOpsReconciler returns a project reconciler with a fake client holding the given objects
+func newGitOpsReconciler(objs ...client.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = k8scheme.AddToScheme(scheme)
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Log:    zap.S(),
+		Scheme: scheme,
+	}
+}
+
+// getArgoCDObject gets an Argo CD resource of the project
+func getArgoCDObject(r *Reconciler, obj *unstructured.Unstructured) error {
+	return r.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+}
+
+// TestReconcileGitOps tests delivering a project from Git with Argo CD
+// GIVEN a project with a GitOps spec, placed on the admin and a managed cluster
+// WHEN the project is reconciled
+// THEN an AppProject scoped to the project namespaces on the placement clusters and an ApplicationSet generating an
+// application per project namespace on each cluster are created, and the project status reports the applications as missing
+func TestReconcileGitOps(t *testing.T) {
+	vp := newGitOpsProject()
+	r := newGitOpsReconciler(vp, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.VerrazzanoSystemNamespace}})
+
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vp)})
+	assert.NoError(t, err)
+	assert.Equal(t, gitOpsStatusPeriod, res.RequeueAfter)
+
+	appProject := newArgoCDObject(appProjectGVK, "hello")
+	assert.NoError(t, getArgoCDObject(r, appProject))
+	repos, _, _ := unstructured.NestedStringSlice(appProject.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{gitDaemonRepo}, repos)
+	destinations, _, _ := unstructured.NestedSlice(appProject.Object, "spec", "destinations")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "in-cluster", "namespace": "hello"},
+		map[string]interface{}{"name": "in-cluster", "namespace": "hello-db"},
+		map[string]interface{}{"name": "managed1", "namespace": "hello"},
+		map[string]interface{}{"name": "managed1", "namespace": "hello-db"},
+	}, destinations)
+	clusterResources, _, _ := unstructured.NestedSlice(appProject.Object, "spec", "clusterResourceWhitelist")
+	assert.Empty(t, clusterResources)
+
+	appSet := newArgoCDObject(applicationSetGVK, "hello")
+	assert.NoError(t, getArgoCDObject(r, appSet))
+	elements, _, _ := unstructured.NestedSlice(appSet.Object, "spec", "generators")
+	assert.Equal(t, []interface{}{map[string]interface{}{"list": map[string]interface{}{"elements": []interface{}{
+		map[string]interface{}{"cluster": "local", "argoCDCluster": "in-cluster", "namespace": "hello"},
+		map[string]interface{}{"cluster": "local", "argoCDCluster": "in-cluster", "namespace": "hello-db"},
+		map[string]interface{}{"cluster": "managed1", "argoCDCluster": "managed1", "namespace": "hello"},
+		map[string]interface{}{"cluster": "managed1", "argoCDCluster": "managed1", "namespace": "hello-db"},
+	}}}}, elements)
+	source, _, _ := unstructured.NestedStringMap(appSet.Object, "spec", "template", "spec", "source")
+	assert.Equal(t, map[string]string{"repoURL": gitDaemonRepo, "path": "hello/{{namespace}}", "targetRevision": "HEAD"}, source)
+	destination, _, _ := unstructured.NestedStringMap(appSet.Object, "spec", "template", "spec", "destination")
+	assert.Equal(t, map[string]string{"name": "{{argoCDCluster}}", "namespace": "{{namespace}}"}, destination)
+	automated, _, _ := unstructured.NestedMap(appSet.Object, "spec", "template", "spec", "syncPolicy", "automated")
+	assert.Equal(t, map[string]interface{}{"prune": true, "selfHeal": false}, automated)
+
+	assert.NoError(t, r.Get(context.TODO(), client.ObjectKeyFromObject(vp), vp))
+	assert.Equal(t, &clustersv1alpha1.GitOpsStatus{
+		Sync:   syncStatusOutOfSync,
+		Health: healthStatusMissing,
+		Applications: []clustersv1alpha1.GitOpsApplicationStatus{
+			{Cluster: "local", Namespace: "hello", Sync: syncStatusUnknown, Health: healthStatusMissing},
+			{Cluster: "local", Namespace: "hello-db", Sync: syncStatusUnknown, Health: healthStatusMissing},
+			{Cluster: "managed1", Namespace: "hello", Sync: syncStatusUnknown, Health: healthStatusMissing},
+			{Cluster: "managed1", Namespace: "hello-db", Sync: syncStatusUnknown, Health: healthStatusMissing},
+		},
+	}, vp.Status.GitOps)
+}
+
+// TestUpdateGitOpsStatus tests rolling up the sync status of the project applications
+// GIVEN a project whose applications are reported by Argo CD
+// WHEN the GitOps status of the project is updated
+// THEN the project reports the worst health of its applications, and is synced only when all of them are synced
+func TestUpdateGitOpsStatus(t *testing.T) {
+	vp := newGitOpsProject()
+	r := newGitOpsReconciler(vp,
+		newArgoCDApplication("hello", "local", "hello", "Synced", "Healthy"),
+		newArgoCDApplication("hello", "local", "hello-db", "Synced", "Healthy"),
+		newArgoCDApplication("hello", "managed1", "hello", "Synced", "Healthy"),
+		newArgoCDApplication("hello", "managed1", "hello-db", "OutOfSync", "Progressing"),
+		newArgoCDApplication("other", "managed1", "hello", "OutOfSync", "Degraded"),
+	)
+	assert.NoError(t, r.updateGitOpsStatus(context.TODO(), vp))
+	assert.Equal(t, syncStatusOutOfSync, vp.Status.GitOps.Sync)
+	assert.Equal(t, "Progressing", vp.Status.GitOps.Health)
+	assert.Equal(t, clustersv1alpha1.GitOpsApplicationStatus{Cluster: "managed1", Namespace: "hello-db", Sync: "OutOfSync", Health: "Progressing", Revision: "4f1d3c2"}, vp.Status.GitOps.Applications[3])
+
+	app := newArgoCDApplication("hello", "managed1", "hello-db", "", "")
+	assert.NoError(t, getArgoCDObject(r, app))
+	assert.NoError(t, unstructured.SetNestedField(app.Object, "Synced", "status", "sync", "status"))
+	assert.NoError(t, unstructured.SetNestedField(app.Object, "Healthy", "status", "health", "status"))
+	assert.NoError(t, r.Update(context.TODO(), app))
+	assert.NoError(t, r.updateGitOpsStatus(context.TODO(), vp))
+	assert.Equal(t, syncStatusSynced, vp.Status.GitOps.Sync)
+	assert.Equal(t, "Healthy", vp.Status.GitOps.Health)
+
+	fetched := &clustersv1alpha1.VerrazzanoProject{}
+	assert.NoError(t, r.Get(context.TODO(), client.ObjectKeyFromObject(vp), fetched))
+	assert.Equal(t, vp.Status.GitOps, fetched.Status.GitOps)
+}
+
+// TestGitOpsOnManagedCluster tests that a project is only delivered from Git by the admin cluster
+// GIVEN a project with a GitOps spec on a managed cluster, which has no Argo CD
+// WHEN the project is reconciled by the cluster agent
+// THEN no Argo CD resources are created and the GitOps status synced from the admin cluster is kept
+func TestGitOpsOnManagedCluster(t *testing.T) {
+	vp := newGitOpsProject()
+	vp.Status.GitOps = &clustersv1alpha1.GitOpsStatus{Sync: syncStatusSynced, Health: "Healthy"}
+	r := newGitOpsReconciler(vp, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace: clusters.MCRegistrationSecretFullName.Namespace,
+		Name:      clusters.MCRegistrationSecretFullName.Name,
+	}})
+	assert.NoError(t, r.syncGitOps(context.TODO(), vp, vzlog.DefaultLogger()))
+	assert.Error(t, getArgoCDObject(r, newArgoCDObject(appProjectGVK, "hello")))
+	assert.Error(t, getArgoCDObject(r, newArgoCDObject(applicationSetGVK, "hello")))
+	assert.NoError(t, r.updateGitOpsStatus(context.TODO(), vp))
+	assert.Equal(t, syncStatusSynced, vp.Status.GitOps.Sync)
+}
+
+// TestRemoveGitOps tests removing the GitOps spec of a project, and deleting a project delivered from Git
+// GIVEN a project delivered from Git whose GitOps spec is removed, or which is deleted
+// WHEN the project is reconciled
+// THEN the Argo CD AppProject and ApplicationSet of the project are deleted, and the GitOps status is cleared
+func TestRemoveGitOps(t *testing.T) {
+	log := vzlog.DefaultLogger()
+	vp := newGitOpsProject()
+	r := newGitOpsReconciler(vp, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.VerrazzanoSystemNamespace}})
+	assert.NoError(t, r.syncGitOps(context.TODO(), vp, log))
+	assert.NoError(t, r.updateGitOpsStatus(context.TODO(), vp))
+
+	vp.Spec.GitOps = nil
+	assert.NoError(t, r.Update(context.TODO(), vp))
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vp)})
+	assert.NoError(t, err)
+	assert.Zero(t, res.RequeueAfter)
+	for _, obj := range []*unstructured.Unstructured{newArgoCDObject(appProjectGVK, "hello"), newArgoCDObject(applicationSetGVK, "hello")} {
+		assert.Error(t, getArgoCDObject(r, obj))
+	}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: vp.Namespace, Name: vp.Name}, vp))
+	assert.Nil(t, vp.Status.GitOps)
+
+	// GIVEN a project delivered from Git being deleted
+	vp = newGitOpsProject()
+	vp.Name = "deleted"
+	r = newGitOpsReconciler(vp)
+	assert.NoError(t, r.syncGitOps(context.TODO(), vp, log))
+	vp.Status.GitOps = &clustersv1alpha1.GitOpsStatus{}
+	now := metav1.Now()
+	vp.DeletionTimestamp = &now
+	_, err = r.doReconcile(context.TODO(), *vp, log)
+	assert.NoError(t, err)
+	assert.Error(t, getArgoCDObject(r, newArgoCDObject(applicationSetGVK, "deleted")))
+	assert.Error(t, getArgoCDObject(r, newArgoCDObject(appProjectGVK, "deleted")))
+}
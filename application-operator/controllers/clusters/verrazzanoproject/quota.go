@@ -0,0 +1,154 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vzlog2 "github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ProjectResourceQuotaName is the name of the ResourceQuota created from the project template in each project namespace
+	ProjectResourceQuotaName = "verrazzano-project-quota"
+	// ProjectLimitRangeName is the name of the LimitRange created from the project template in each project namespace
+	ProjectLimitRangeName = "verrazzano-project-limits"
+	quotaStatusPeriod     = 1 * time.Minute
+)
+
+// syncQuotas creates or updates the ResourceQuota and LimitRange of the project template in each project namespace.
+// They are deleted when they are removed from the project template.
+func (r *Reconciler) syncQuotas(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace {
+		return nil
+	}
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: ProjectResourceQuotaName, Namespace: nsTemplate.Metadata.Name}}
+		if vp.Spec.Template.ResourceQuota != nil {
+			if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, quota, func() error {
+				setProjectLabel(&quota.ObjectMeta, vp.Name)
+				vp.Spec.Template.ResourceQuota.DeepCopyInto(&quota.Spec)
+				return nil
+			}); err != nil {
+				log.Errorf("Failed to create or update ResourceQuota %s/%s: %v", quota.Namespace, quota.Name, err)
+				return err
+			}
+		} else if err := r.deleteProjectObject(ctx, quota, vp.Name, log); err != nil {
+			return err
+		}
+
+		limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: ProjectLimitRangeName, Namespace: nsTemplate.Metadata.Name}}
+		if vp.Spec.Template.LimitRange != nil {
+			if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, limitRange, func() error {
+				setProjectLabel(&limitRange.ObjectMeta, vp.Name)
+				vp.Spec.Template.LimitRange.DeepCopyInto(&limitRange.Spec)
+				return nil
+			}); err != nil {
+				log.Errorf("Failed to create or update LimitRange %s/%s: %v", limitRange.Namespace, limitRange.Name, err)
+				return err
+			}
+		} else if err := r.deleteProjectObject(ctx, limitRange, vp.Name, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteQuotas deletes the ResourceQuota and LimitRange of the project in each project namespace
+func (r *Reconciler) deleteQuotas(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: ProjectResourceQuotaName, Namespace: nsTemplate.Metadata.Name}}
+		if err := r.deleteProjectObject(ctx, quota, vp.Name, log); err != nil {
+			return err
+		}
+		limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: ProjectLimitRangeName, Namespace: nsTemplate.Metadata.Name}}
+		if err := r.deleteProjectObject(ctx, limitRange, vp.Name, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteProjectObject deletes an object created for the project, objects not labeled with the project are left alone
+func (r *Reconciler) deleteProjectObject(ctx context.Context, obj client.Object, projectName string, log vzlog2.VerrazzanoLogger) error {
+	if err := r.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if obj.GetLabels()[projectLabel] != projectName {
+		return nil
+	}
+	log.Oncef("Deleting %T %s/%s of project %s", obj, obj.GetNamespace(), obj.GetName(), projectName)
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// updateQuotaStatus updates the resource quota usage of the project on this cluster, aggregated across the ResourceQuota
+// of each project namespace. On a managed cluster the cluster agent reports it to the project on the admin cluster.
+func (r *Reconciler) updateQuotaStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject) error {
+	if vp.Namespace != constants.VerrazzanoMultiClusterNamespace || (vp.Spec.Template.ResourceQuota == nil && len(vp.Status.Quotas) == 0) {
+		return nil
+	}
+	clusterName := clusters.GetClusterName(ctx, r.Client)
+	if clusterName == "" {
+		clusterName = constants.DefaultClusterName
+	}
+	var quotaStatus *clustersv1alpha1.ProjectQuotaStatus
+	if vp.Spec.Template.ResourceQuota != nil {
+		var err error
+		if quotaStatus, err = r.getQuotaStatus(ctx, vp, clusterName); err != nil {
+			return err
+		}
+	}
+	if !clusters.SetProjectQuotaStatus(&vp.Status, clusterName, quotaStatus) {
+		return nil
+	}
+	return r.Status().Update(ctx, vp)
+}
+
+// getQuotaStatus returns the totals of the hard limits and of the usage of the ResourceQuota in the project namespaces
+func (r *Reconciler) getQuotaStatus(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, clusterName string) (*clustersv1alpha1.ProjectQuotaStatus, error) {
+	quotaStatus := &clustersv1alpha1.ProjectQuotaStatus{
+		Cluster: clusterName,
+		Hard:    corev1.ResourceList{},
+		Used:    corev1.ResourceList{},
+	}
+	for _, nsTemplate := range vp.Spec.Template.Namespaces {
+		quota := corev1.ResourceQuota{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: nsTemplate.Metadata.Name, Name: ProjectResourceQuotaName}, &quota); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		addResources(quotaStatus.Hard, quota.Status.Hard)
+		addResources(quotaStatus.Used, quota.Status.Used)
+	}
+	return quotaStatus, nil
+}
+
+// addResources adds the quantities of the resources to the total
+func addResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// setProjectLabel labels an object created for the project
+func setProjectLabel(meta *metav1.ObjectMeta, projectName string) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	meta.Labels[projectLabel] = projectName
+}
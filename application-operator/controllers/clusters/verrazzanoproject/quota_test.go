@@ -0,0 +1,128 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package verrazzanoproject
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newQuotaProject returns a project with a resource quota and a limit range for two namespaces
+func newQuotaProject() *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "hello", Finalizers: []string{finalizerName}},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Placement: clustersv1alpha1.Placement{
+				Clusters: []clustersv1alpha1.Cluster{{Name: constants.DefaultClusterName}},
+			},
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{
+					{Metadata: metav1.ObjectMeta{Name: "hello"}},
+					{Metadata: metav1.ObjectMeta{Name: "hello-db"}},
+				},
+				ResourceQuota: &corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+				},
+				LimitRange: &corev1.LimitRangeSpec{
+					Limits: []corev1.LimitRangeItem{{
+						Type: corev1.LimitTypeContainer,
+						Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// TestSyncQuotas tests the syncQuotas function
+// GIVEN a project with a resource quota and a limit range
+// WHEN syncQuotas is called
+// THEN the ResourceQuota and LimitRange are created in each project namespace, and they are deleted when they are
+// removed from the project
+func TestSyncQuotas(t *testing.T) {
+	vp := newQuotaProject()
+	r := newGitOpsReconciler(vp)
+	log := vzlog.DefaultLogger()
+
+	assert.NoError(t, r.syncQuotas(context.TODO(), vp, log))
+	for _, ns := range []string{"hello", "hello-db"} {
+		quota := corev1.ResourceQuota{}
+		assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: ProjectResourceQuotaName}, &quota))
+		assert.Equal(t, "hello", quota.Labels[projectLabel])
+		assert.Equal(t, *vp.Spec.Template.ResourceQuota, quota.Spec)
+		limitRange := corev1.LimitRange{}
+		assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: ProjectLimitRangeName}, &limitRange))
+		assert.Equal(t, *vp.Spec.Template.LimitRange, limitRange.Spec)
+	}
+
+	vp.Spec.Template.ResourceQuota = nil
+	assert.NoError(t, r.syncQuotas(context.TODO(), vp, log))
+	err := r.Get(context.TODO(), types.NamespacedName{Namespace: "hello", Name: ProjectResourceQuotaName}, &corev1.ResourceQuota{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: "hello", Name: ProjectLimitRangeName}, &corev1.LimitRange{}))
+
+	assert.NoError(t, r.deleteQuotas(context.TODO(), vp, log))
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: "hello", Name: ProjectLimitRangeName}, &corev1.LimitRange{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestSyncQuotasNotOwned tests the syncQuotas function
+// GIVEN a project without resource quota, and a ResourceQuota of the same name not created for the project
+// WHEN syncQuotas is called
+// THEN the ResourceQuota is not deleted
+func TestSyncQuotasNotOwned(t *testing.T) {
+	vp := newQuotaProject()
+	vp.Spec.Template.ResourceQuota = nil
+	quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: ProjectResourceQuotaName}}
+	r := newGitOpsReconciler(vp, quota)
+
+	assert.NoError(t, r.syncQuotas(context.TODO(), vp, vzlog.DefaultLogger()))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: "hello", Name: ProjectResourceQuotaName}, &corev1.ResourceQuota{}))
+}
+
+// TestUpdateQuotaStatus tests the updateQuotaStatus function
+// GIVEN a project with a resource quota in two namespaces
+// WHEN updateQuotaStatus is called
+// THEN the quota status of the cluster holds the totals of the hard limits and the usage of the namespaces, and it is
+// removed when the project no longer has a resource quota
+func TestUpdateQuotaStatus(t *testing.T) {
+	vp := newQuotaProject()
+	vp.Status.Quotas = []clustersv1alpha1.ProjectQuotaStatus{{Cluster: "managed1"}}
+	quota1 := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: ProjectResourceQuotaName},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("500m")},
+		},
+	}
+	quota2 := quota1.DeepCopy()
+	quota2.Namespace = "hello-db"
+	quota2.Status.Used[corev1.ResourceRequestsCPU] = resource.MustParse("1")
+	r := newGitOpsReconciler(vp, quota1, quota2)
+
+	assert.NoError(t, r.updateQuotaStatus(context.TODO(), vp))
+	updated := clustersv1alpha1.VerrazzanoProject{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: vp.Namespace, Name: vp.Name}, &updated))
+	assert.Len(t, updated.Status.Quotas, 2)
+	assert.Equal(t, "managed1", updated.Status.Quotas[0].Cluster)
+	quotaStatus := updated.Status.Quotas[1]
+	assert.Equal(t, constants.DefaultClusterName, quotaStatus.Cluster)
+	assert.True(t, resource.MustParse("4").Equal(quotaStatus.Hard[corev1.ResourceRequestsCPU]))
+	assert.True(t, resource.MustParse("1500m").Equal(quotaStatus.Used[corev1.ResourceRequestsCPU]))
+
+	updated.Spec.Template.ResourceQuota = nil
+	assert.NoError(t, r.updateQuotaStatus(context.TODO(), &updated))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: vp.Namespace, Name: vp.Name}, &updated))
+	assert.Equal(t, []clustersv1alpha1.ProjectQuotaStatus{{Cluster: "managed1"}}, updated.Status.Quotas)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package verrazzanoproject
@@ -115,6 +115,14 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 			if err := r.deleteRoleBindings(ctx, &vp, log); err != nil {
 				return reconcile.Result{}, err
 			}
+			if err := r.deleteQuotas(ctx, &vp, log); err != nil {
+				return reconcile.Result{}, err
+			}
+			if vp.Status.GitOps != nil {
+				if err := r.deleteGitOps(ctx, &vp, log); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 			// Remove the finalizer and update the Verrazzano resource if the deletion has finished.
 			vp.ObjectMeta.Finalizers = vzstring.RemoveStringFromSlice(vp.ObjectMeta.Finalizers, finalizerName)
 			err := r.Update(ctx, &vp)
@@ -147,7 +155,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 	}
 
 	// Update the VerrazzanoProject state
-	oldState := clusters.SetEffectiveStateIfChanged(vp.Spec.Placement, &vp.Status)
+	oldState := clusters.SetEffectiveStateIfChanged(vp.Spec.Placement, &vp.Status.MultiClusterResourceStatus)
 	if oldState != vp.Status.State {
 		stateErr := r.Status().Update(ctx, &vp)
 		if stateErr != nil {
@@ -155,11 +163,29 @@ func (r *Reconciler) doReconcile(ctx context.Context, vp clustersv1alpha1.Verraz
 		}
 	}
 
+	// Roll up the sync status of the project applications delivered from Git
+	if gitOpsErr := r.updateGitOpsStatus(ctx, &vp); gitOpsErr != nil {
+		log.Errorf("Failed to update the GitOps status of project %s: %v", vp.Name, gitOpsErr)
+	}
+
+	// Report the resource quota usage of the project on this cluster
+	if quotaErr := r.updateQuotaStatus(ctx, &vp); quotaErr != nil {
+		log.Errorf("Failed to update the quota status of project %s: %v", vp.Name, quotaErr)
+	}
+
 	// if an error occurred in createOrUpdate, return that error with a requeue
 	// even if update status succeeded
 	if err != nil {
 		return ctrl.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelay()}, err
 	}
+	// The applications are synced by Argo CD, refresh their status periodically
+	if vp.Spec.GitOps != nil {
+		return ctrl.Result{RequeueAfter: gitOpsStatusPeriod}, nil
+	}
+	// The quota usage changes with the workloads in the project namespaces, refresh it periodically
+	if vp.Spec.Template.ResourceQuota != nil {
+		return ctrl.Result{RequeueAfter: quotaStatusPeriod}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -175,7 +201,15 @@ func (r *Reconciler) syncAll(ctx context.Context, vp clustersv1alpha1.Verrazzano
 	if err != nil {
 		return err
 	}
-	return nil
+
+	// Sync the resource quotas and limit ranges
+	err = r.syncQuotas(ctx, &vp, log)
+	if err != nil {
+		return err
+	}
+
+	// Sync the Argo CD resources delivering the project applications from Git
+	return r.syncGitOps(ctx, &vp, log)
 }
 
 func (r *Reconciler) createOrUpdateNamespaces(ctx context.Context, vp clustersv1alpha1.VerrazzanoProject, log vzlog2.VerrazzanoLogger) error {
@@ -311,7 +345,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, vp *clustersv1alpha1.Verr
 	clusterName := clusters.GetClusterName(ctx, r.Client)
 	newCondition := clusters.GetConditionFromResult(err, opResult, "VerrazzanoProject")
 	updateFunc := func() error { return r.Status().Update(ctx, vp) }
-	return clusters.UpdateStatus(vp, &vp.Status, vp.Spec.Placement, newCondition, clusterName,
+	return clusters.UpdateStatus(vp, &vp.Status.MultiClusterResourceStatus, vp.Spec.Placement, newCondition, clusterName,
 		r.AgentChannel, updateFunc)
 }
 
@@ -464,6 +498,10 @@ func (r *Reconciler) deleteNetworkPolicies(ctx context.Context, project *cluster
 		}
 		// Loop through the policies found in the namespace
 		for pi, policy := range policies.Items {
+			// Policies generated for the components of applications are managed by the application operator
+			if _, ok := policy.Labels[constants.NetworkPolicyAppLabel]; ok {
+				continue
+			}
 			if desiredPolicySet != nil {
 				// Don't delete policy if it should be in the namespace
 				if _, ok := desiredPolicySet[policy.Namespace+policy.Name]; ok {
@@ -264,6 +264,8 @@ func TestReconcileVerrazzanoProject(t *testing.T) {
 					})
 
 				if tt.fields.vpNamespace == constants.VerrazzanoMultiClusterNamespace {
+					mockProjectQuotasNotFound(mockClient, tt.fields.nsList[0].Metadata.Name)
+
 					if tt.fields.nsList[0].Metadata.Name == existingNS.Metadata.Name {
 						// expect call to get vz system namespace
 						mockClient.EXPECT().
@@ -374,7 +376,8 @@ func TestReconcileVerrazzanoProject(t *testing.T) {
 // TestNetworkPolicies tests the creation of network policies
 // GIVEN a VerrazzanoProject resource is create with specified network policies
 // WHEN the controller Reconcile function is called
-// THEN the network policies are created
+// THEN the network policies are created, and the policies not defined in the project are deleted unless they
+// were generated for application components
 func TestNetworkPolicies(t *testing.T) {
 	const vpName = "testNetwokPolicies"
 
@@ -435,6 +438,8 @@ func TestNetworkPolicies(t *testing.T) {
 
 	mockClusterRoleBindingNoDelete(assert, mockClient, ns1.Metadata.Name)
 
+	mockProjectQuotasNotFound(mockClient, ns1.Metadata.Name)
+
 	// expect call to get a network policy
 	mockClient.EXPECT().
 		Get(gomock.Any(), types.NamespacedName{Namespace: ns1Netpol.Metadata.Namespace, Name: ns1Netpol.Metadata.Name}, gomock.Not(gomock.Nil()), gomock.Any()).
@@ -458,11 +463,16 @@ func TestNetworkPolicies(t *testing.T) {
 				{
 					ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "ns2Netpol"},
 					Spec:       netv1.NetworkPolicySpec{},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "app-component", Labels: map[string]string{constants.NetworkPolicyAppLabel: "app"}},
+					Spec:       netv1.NetworkPolicySpec{},
 				}}
 			return nil
 		})
 
-	// Expect call to delete network policy ns2 since it is not defined in the project
+	// Expect call to delete network policy ns2 since it is not defined in the project, the policy generated
+	// for the application component is not deleted
 	mockClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(ctx context.Context, policy *netv1.NetworkPolicy, opts ...client.DeleteOption) error {
 			assert.Equal("ns2Netpol", policy.Name, "Incorrect NetworkPolicy being deleted")
@@ -552,6 +562,9 @@ func TestDeleteVerrazzanoProjectFinalizer(t *testing.T) {
 	// Expect call to delete network policies in the namespace
 	mockClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
+	// Expect calls to get the project resource quota and limit range in the namespace
+	mockProjectQuotasNotFound(mockClient, "existingNS")
+
 	// Expect call to get list of VerrazzanoProjects
 	mockClient.EXPECT().
 		List(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -630,6 +643,16 @@ func newVerrazzanoProjectReconciler(c client.Client) Reconciler {
 	}
 }
 
+// mockProjectQuotasNotFound mocks the expectations for getting the resource quota and limit range of a project without them
+func mockProjectQuotasNotFound(mockClient *mocks.MockClient, namespace string) {
+	mockClient.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: ProjectResourceQuotaName}, gomock.AssignableToTypeOf(&corev1.ResourceQuota{}), gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{Resource: "resourcequotas"}, ProjectResourceQuotaName))
+	mockClient.EXPECT().
+		Get(gomock.Any(), types.NamespacedName{Namespace: namespace, Name: ProjectLimitRangeName}, gomock.AssignableToTypeOf(&corev1.LimitRange{}), gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{Resource: "limitranges"}, ProjectLimitRangeName))
+}
+
 // mockClusterRoleBindingNoDelete mocks the expectations for deleting the managed cluster rolebinding
 func mockClusterRoleBindingNoDelete(assert *asserts.Assertions, mockClient *mocks.MockClient, name string) {
 	// Expect call to get list of VerrazzanoProjects
@@ -786,7 +809,7 @@ func doExpectStatusUpdateSucceeded(cli *mocks.MockClient, mockStatusWriter *mock
 	mockStatusWriter.EXPECT().
 		Update(gomock.Any(), gomock.AssignableToTypeOf(&clustersv1alpha1.VerrazzanoProject{}), gomock.Any()).
 		DoAndReturn(func(ctx context.Context, vp *clustersv1alpha1.VerrazzanoProject, opts ...client.UpdateOption) error {
-			clusterstest.AssertMultiClusterResourceStatus(assert, vp.Status, clustersv1alpha1.Succeeded, clustersv1alpha1.DeployComplete, corev1.ConditionTrue)
+			clusterstest.AssertMultiClusterResourceStatus(assert, vp.Status.MultiClusterResourceStatus, clustersv1alpha1.Succeeded, clustersv1alpha1.DeployComplete, corev1.ConditionTrue)
 			return nil
 		})
 }
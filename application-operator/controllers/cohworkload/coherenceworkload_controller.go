@@ -15,6 +15,7 @@ import (
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/logging"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
@@ -298,6 +299,8 @@ func (r *Reconciler) doReconcile(ctx context.Context, workload *vzapi.Verrazzano
 
 	// write out the Coherence resource
 	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, u, func() error {
+		// keep the replica count chosen by the autoscaler if the Coherence CR is scaled by an autoscaler trait
+		controllers.PreserveAutoscaledReplicas(u, specCopy)
 		return unstructured.SetNestedField(u.Object, specCopy, specField)
 	})
 	if err != nil {
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package controllers
@@ -6,6 +6,8 @@ package controllers
 import (
 	"context"
 	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,6 +55,26 @@ func NewDefaultRateLimiter() workqueue.RateLimiter {
 		secsToDuration(MaxDelay))
 }
 
+// PreserveAutoscaledReplicas copies the replica count of an existing resource into the desired spec of the
+// resource when the resource is scaled by an autoscaler trait. Without this, every workload reconcile would
+// reset the replica count chosen by the HorizontalPodAutoscaler.
+// existing - The resource as currently stored in the cluster
+// spec - The desired spec of the resource, which is updated in place
+func PreserveAutoscaledReplicas(existing *unstructured.Unstructured, spec interface{}) {
+	if _, ok := existing.GetAnnotations()[constants.AutoscalerTraitAnnotation]; !ok {
+		return
+	}
+	desired, ok := spec.(map[string]interface{})
+	if !ok {
+		return
+	}
+	replicas, found, err := unstructured.NestedFieldCopy(existing.Object, "spec", "replicas")
+	if err != nil || !found {
+		return
+	}
+	desired["replicas"] = replicas
+}
+
 func secsToDuration(secs int) time.Duration {
 	return time.Duration(float64(secs) * float64(time.Second))
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2021, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package controllers
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	asserts "github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // TestConvertAPIVersionToGroupAndVersion tests multiple use cases for parsing APIVersion
@@ -37,3 +39,33 @@ func TestConvertAPIVersionToGroupAndVersion(t *testing.T) {
 	assert.Equal("", g)
 	assert.Equal("version", v)
 }
+
+// TestPreserveAutoscaledReplicas tests that the replica count of an autoscaled resource is preserved
+func TestPreserveAutoscaledReplicas(t *testing.T) {
+	assert := asserts.New(t)
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": int64(5)},
+	}}
+
+	// GIVEN an existing resource that is not annotated by an autoscaler trait
+	// WHEN the desired spec is updated
+	// THEN ensure the desired replica count is kept
+	spec := map[string]interface{}{"replicas": int64(2)}
+	PreserveAutoscaledReplicas(existing, spec)
+	assert.Equal(int64(2), spec["replicas"])
+
+	// GIVEN an existing resource that is annotated by an autoscaler trait
+	// WHEN the desired spec is updated
+	// THEN ensure the replica count of the existing resource is copied to the desired spec
+	existing.SetAnnotations(map[string]string{constants.AutoscalerTraitAnnotation: "unit-test-namespace/unit-test-trait"})
+	PreserveAutoscaledReplicas(existing, spec)
+	assert.Equal(int64(5), spec["replicas"])
+
+	// GIVEN an annotated existing resource without a replica count
+	// WHEN the desired spec is updated
+	// THEN ensure the desired replica count is kept
+	existing.Object["spec"] = map[string]interface{}{}
+	spec = map[string]interface{}{"replicas": int64(2)}
+	PreserveAutoscaledReplicas(existing, spec)
+	assert.Equal(int64(2), spec["replicas"])
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
 	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/appconfig"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -154,6 +156,11 @@ func (r *Reconciler) doReconcile(ctx context.Context, workload vzapi.VerrazzanoH
 		return reconcile.Result{}, err
 	}
 
+	// keep the replica count chosen by the autoscaler if the deployment is scaled by an autoscaler trait
+	if err = preserveAutoscaledReplicas(&existingDeployment, deploy); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// set the controller reference so that we can watch this deployment and it will be deleted automatically
 	if err := ctrl.SetControllerReference(&workload, deploy, r.Scheme); err != nil {
 		return reconcile.Result{}, err
@@ -332,6 +339,23 @@ func (r *Reconciler) createServiceFromDeployment(workload *vzapi.VerrazzanoHelid
 	return s, nil
 }
 
+// preserveAutoscaledReplicas sets the replica count of the existing deployment on the deployment to apply when the
+// existing deployment is scaled by an autoscaler trait. The deployment is applied with force ownership, the replica
+// count of the workload would otherwise override the one of the HorizontalPodAutoscaler.
+func preserveAutoscaledReplicas(existing *appsv1.Deployment, deploy *appsv1.Deployment) error {
+	existingObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return err
+	}
+	spec := map[string]interface{}{}
+	controllers.PreserveAutoscaledReplicas(&unstructured.Unstructured{Object: existingObject}, spec)
+	if replicas, ok := spec["replicas"].(int64); ok {
+		count := int32(replicas)
+		deploy.Spec.Replicas = &count
+	}
+	return nil
+}
+
 // passLabelAndAnnotation passes through labels and annotation objectMeta from the workload to the deployment object
 func passLabelAndAnnotation(workload *vzapi.VerrazzanoHelidonWorkload, deploy *appsv1.Deployment) {
 	// set app-config labels on deployment metadata
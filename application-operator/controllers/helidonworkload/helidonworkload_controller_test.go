@@ -21,6 +21,7 @@ import (
 	"github.com/golang/mock/gomock"
 	asserts "github.com/stretchr/testify/assert"
 	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	appconst "github.com/verrazzano/verrazzano/application-operator/constants"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/logging"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
@@ -1224,3 +1225,22 @@ func TestAddMetrics(t *testing.T) {
 	assert.NoError(reconciler.addMetrics(context.TODO(), log, workload.Namespace, &workload, deploy))
 	mocker.Finish()
 }
+
+// TestPreserveAutoscaledReplicas tests keeping the replica count of a Helidon deployment scaled by an autoscaler
+// GIVEN an existing deployment scaled to 5 replicas, and a workload with 2 replicas
+// WHEN preserveAutoscaledReplicas is called
+// THEN the deployment to apply keeps 5 replicas if the existing deployment is annotated by an autoscaler trait,
+// and the 2 replicas of the workload otherwise
+func TestPreserveAutoscaledReplicas(t *testing.T) {
+	assert := asserts.New(t)
+	existingReplicas, workloadReplicas := int32(5), int32(2)
+	existing := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &existingReplicas}}
+	deploy := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &workloadReplicas}}
+
+	assert.NoError(preserveAutoscaledReplicas(existing, deploy))
+	assert.Equal(int32(2), *deploy.Spec.Replicas)
+
+	existing.Annotations = map[string]string{appconst.AutoscalerTraitAnnotation: "unit-test-namespace/hello"}
+	assert.NoError(preserveAutoscaledReplicas(existing, deploy))
+	assert.Equal(int32(5), *deploy.Spec.Replicas)
+}
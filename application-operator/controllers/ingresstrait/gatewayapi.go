@@ -0,0 +1,375 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/reconcileresults"
+	vznav "github.com/verrazzano/verrazzano/application-operator/controllers/navigation"
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	gatewayAPIEnabledEnvVar = "GATEWAY_API_ENABLED"
+	gatewayClassNameEnvVar  = "GATEWAY_CLASS_NAME"
+	k8sGatewayAPIVersion    = gatewayapi.Group + "/" + gatewayapi.Version
+	httpRouteKind           = "HTTPRoute"
+	referenceGrantKind      = "ReferenceGrant"
+)
+
+// isGatewayAPIEnabled returns true if the ingress traits are rendered as Gateway API resources instead of Istio
+// Gateways and VirtualServices
+func isGatewayAPIEnabled() bool {
+	return os.Getenv(gatewayAPIEnabledEnvVar) == "true"
+}
+
+// createOrUpdateGatewayAPIChildResources creates or updates the Gateway API resources that should be used to setup
+// ingress to the service. The cardinality matches the Istio resources:
+//
+//	1 Gateway per Application
+//	1 Gateway listener per IngressTrait host
+//	1 HTTPRoute per IngressTrait rule
+//
+// The DestinationRules and AuthorizationPolicies of the rules are the same for both ingress backends.
+func (r *Reconciler) createOrUpdateGatewayAPIChildResources(ctx context.Context, trait *vzapi.IngressTrait, rules []vzapi.IngressRule,
+	allHostsForTrait []string, gwName string, secretName string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	if err := r.createOrUpdateK8sGateway(ctx, trait, allHostsForTrait, gwName, secretName, status, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	// The secret of the trait is in the Istio namespace, the Gateway in the namespace of the trait
+	r.createOrUpdateReferenceGrant(ctx, trait, buildSecretReferenceGrantName(trait), constants.IstioSystemNamespace,
+		gatewayapi.GatewayGVK.Kind, "Secret", secretName, status, log)
+
+	for index, rule := range rules {
+		// Find the services associated with the trait in the application configuration.
+		services, err := r.fetchServicesFromTrait(ctx, trait, log)
+		if err != nil {
+			return reconcile.Result{}, err
+		} else if len(services) == 0 {
+			// This will be the case if the service has not started yet so we requeue and try again.
+			return reconcile.Result{Requeue: true, RequeueAfter: clusters.GetRandomRequeueDelay()}, err
+		}
+
+		routeHosts, err := createHostsFromIngressTraitRule(r, rule, trait)
+		if err != nil {
+			status.Errors = append(status.Errors, err)
+		}
+
+		routeName := fmt.Sprintf("%s-rule-%d-route", trait.Name, index)
+		drName := fmt.Sprintf("%s-rule-%d-dr", trait.Name, index)
+		authzPolicyName := fmt.Sprintf("%s-rule-%d-authz", trait.Name, index)
+		r.createOrUpdateHTTPRoute(ctx, trait, rule, routeHosts, routeName, gwName, services, status, log)
+		r.createOrUpdateDestinationRule(ctx, trait, rule, drName, status, log, services)
+		r.createOrUpdateAuthorizationPolicies(ctx, trait, rule, authzPolicyName, allHostsForTrait, status, log)
+	}
+
+	// Remove the Istio resources of a trait that was previously rendered by the Istio backend
+	if hasStatusRelation(trait, virtualServiceKind) {
+		if err := cleanupIstioIngress(trait, r.Client, log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// createOrUpdateK8sGateway creates or updates the Gateway API Gateway of the application, with a listener per host
+// of the trait. Results are added to the status object.
+func (r *Reconciler) createOrUpdateK8sGateway(ctx context.Context, trait *vzapi.IngressTrait, hostsForTrait []string, gwName string,
+	secretName string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) error {
+	gateway := gatewayapi.NewGateway(gwName, trait.Namespace)
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, gateway, func() error {
+		return r.mutateK8sGateway(ctx, gateway, trait, hostsForTrait, secretName)
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: k8sGatewayAPIVersion, Kind: gatewayapi.GatewayGVK.Kind, Name: gwName, Role: "gateway"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update Gateway API gateway: %v", err)
+	}
+	return err
+}
+
+// mutateK8sGateway replaces the listeners of the trait in the Gateway. A host that is already served by the listener
+// of another trait is not added again, the routes of the trait attach to that listener.
+func (r *Reconciler) mutateK8sGateway(ctx context.Context, gateway *unstructured.Unstructured, trait *vzapi.IngressTrait, hostsForTrait []string, secretName string) error {
+	listeners := removeTraitListeners(gatewayapi.GetGatewayListeners(gateway), trait)
+	for i, host := range hostsForTrait {
+		if _, found := findListenerHost(listeners, host); found {
+			continue
+		}
+		listeners = append(listeners, gatewayapi.Listener{
+			Name:            formatGatewayListenerName(trait.Name, i),
+			Hostname:        host,
+			SecretName:      secretName,
+			SecretNamespace: constants.IstioSystemNamespace,
+		})
+	}
+	if err := gatewayapi.SetGatewaySpec(gateway, os.Getenv(gatewayClassNameEnvVar), listeners); err != nil {
+		return err
+	}
+
+	// Set the owner reference.
+	appName, ok := trait.Labels[oam.LabelAppName]
+	if ok {
+		appConfig := &v1alpha2.ApplicationConfiguration{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: trait.Namespace, Name: appName}, appConfig)
+		if err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(appConfig, gateway, r.Scheme)
+	}
+	return nil
+}
+
+// createOrUpdateHTTPRoute creates or updates the HTTPRoute of a trait rule, and the ReferenceGrant allowing the
+// route to forward to a destination in another namespace. Results are added to the status object.
+func (r *Reconciler) createOrUpdateHTTPRoute(ctx context.Context, trait *vzapi.IngressTrait, rule vzapi.IngressRule, hosts []string,
+	name string, gwName string, services []*corev1.Service, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	dest, err := createDestinationFromRuleOrService(rule, services)
+	if err != nil {
+		status.Relations = append(status.Relations, vzapi.QualifiedResourceRelation{APIVersion: k8sGatewayAPIVersion, Kind: httpRouteKind, Name: name, Role: "httproute"})
+		status.Results = append(status.Results, controllerutil.OperationResultNone)
+		status.Errors = append(status.Errors, err)
+		log.Errorf("Failed to create or update HTTP route: %v", err)
+		return
+	}
+	backend := createBackendFromDestination(dest, trait.Namespace)
+
+	route := gatewayapi.NewHTTPRoute(name, trait.Namespace)
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		route.SetLabels(traitLabels(trait, route.GetLabels()))
+		routeRule := gatewayapi.RouteRule{Backend: backend}
+		for _, path := range getPathsFromRule(rule) {
+			routeRule.Paths = append(routeRule.Paths, createHTTPRoutePathMatchFromIngressTraitPath(path))
+		}
+		if vznav.IsWeblogicWorkloadKind(trait) {
+			routeRule.RequestHeaders = map[string]string{wlProxySSLHeader: wlProxySSLHeaderVal}
+		}
+		if err := gatewayapi.SetHTTPRouteSpec(route, gwName, trait.Namespace, hosts, []gatewayapi.RouteRule{routeRule}); err != nil {
+			return err
+		}
+		// Set the owner reference.
+		return controllerutil.SetControllerReference(trait, route, r.Scheme)
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: k8sGatewayAPIVersion, Kind: httpRouteKind, Name: name, Role: "httproute"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update HTTP route: %v", err)
+		return
+	}
+
+	if backend.Namespace != trait.Namespace {
+		r.createOrUpdateReferenceGrant(ctx, trait, fmt.Sprintf("%s-%s", trait.Namespace, name), backend.Namespace,
+			httpRouteKind, "Service", backend.Name, status, log)
+	}
+}
+
+// createOrUpdateReferenceGrant creates or updates a ReferenceGrant allowing the objects of the from kind in the
+// namespace of the trait to refer to the named object in another namespace. Results are added to the status object.
+func (r *Reconciler) createOrUpdateReferenceGrant(ctx context.Context, trait *vzapi.IngressTrait, name string, namespace string,
+	fromKind string, toKind string, toName string, status *reconcileresults.ReconcileResults, log vzlog.VerrazzanoLogger) {
+	grant := gatewayapi.NewReferenceGrant(name, namespace)
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, grant, func() error {
+		grant.SetLabels(traitLabels(trait, grant.GetLabels()))
+		return gatewayapi.SetReferenceGrantSpec(grant, fromKind, trait.Namespace, toKind, []string{toName})
+	})
+
+	ref := vzapi.QualifiedResourceRelation{APIVersion: k8sGatewayAPIVersion, Kind: referenceGrantKind, Namespace: namespace, Name: name, Role: "referencegrant"}
+	status.Relations = append(status.Relations, ref)
+	status.Results = append(status.Results, res)
+	status.Errors = append(status.Errors, err)
+
+	if err != nil {
+		log.Errorf("Failed to create or update reference grant: %v", err)
+	}
+}
+
+// createBackendFromDestination converts an Istio route destination to an HTTPRoute backend. The destination host is
+// a service name, optionally qualified with the namespace of the service.
+func createBackendFromDestination(dest *istionet.HTTPRouteDestination, namespace string) gatewayapi.Backend {
+	parts := strings.Split(dest.Destination.Host, ".")
+	backend := gatewayapi.Backend{Name: parts[0], Namespace: namespace}
+	if len(parts) > 1 {
+		backend.Namespace = parts[1]
+	}
+	if dest.Destination.Port != nil {
+		backend.Port = int32(dest.Destination.Port.Number)
+	}
+	return backend
+}
+
+// createHTTPRoutePathMatchFromIngressTraitPath creates an HTTPRoute path match from an ingress trait path, with the
+// same defaults as the VirtualService match
+func createHTTPRoutePathMatchFromIngressTraitPath(path vzapi.IngressPath) gatewayapi.PathMatch {
+	uri := createVirtualServiceMatchURIFromIngressTraitPath(path)
+	switch match := uri.MatchType.(type) {
+	case *istionet.StringMatch_Regex:
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchRegularExpression, Value: match.Regex}
+	case *istionet.StringMatch_Prefix:
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: match.Prefix}
+	default:
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchExact, Value: uri.GetExact()}
+	}
+}
+
+// buildSecretReferenceGrantName will construct the name of the ReferenceGrant allowing the Gateway to use the
+// secret of the trait
+func buildSecretReferenceGrantName(trait *vzapi.IngressTrait) string {
+	return fmt.Sprintf("%s-%s-secret", trait.Namespace, trait.Name)
+}
+
+// formatGatewayListenerName returns the name of the Gateway listener for a host of the trait
+func formatGatewayListenerName(traitName string, index int) string {
+	return fmt.Sprintf("%s-%d", formatGatewaySeverPortName(traitName), index)
+}
+
+// isTraitListener returns true if the listener was created for the trait
+func isTraitListener(listener gatewayapi.Listener, traitName string) bool {
+	suffix := strings.TrimPrefix(listener.Name, formatGatewaySeverPortName(traitName)+"-")
+	if suffix == listener.Name {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// removeTraitListeners returns the listeners that were not created for the trait
+func removeTraitListeners(listeners []gatewayapi.Listener, trait *vzapi.IngressTrait) []gatewayapi.Listener {
+	var result []gatewayapi.Listener
+	for _, listener := range listeners {
+		if !isTraitListener(listener, trait.Name) {
+			result = append(result, listener)
+		}
+	}
+	return result
+}
+
+// findListenerHost searches for a host in the provided listeners
+func findListenerHost(listeners []gatewayapi.Listener, host string) (int, bool) {
+	for i, listener := range listeners {
+		if strings.EqualFold(listener.Hostname, host) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// traitLabels adds the label identifying the trait to the given labels
+func traitLabels(trait *vzapi.IngressTrait, labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[constants.LabelIngressTraitNsn] = getIngressTraitNsn(trait.Namespace, trait.Name)
+	return labels
+}
+
+// hasStatusRelation returns true if the trait status has a resource of the given kind
+func hasStatusRelation(trait *vzapi.IngressTrait, kind string) bool {
+	for _, res := range trait.Status.Resources {
+		if res.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanupGatewayAPIResources deletes the HTTPRoutes and ReferenceGrants of the trait and removes the listeners of
+// the trait from the Gateway. The Gateway is deleted once it has no listeners left. Nothing needs to be done if the
+// Gateway API CRDs are not installed.
+func cleanupGatewayAPIResources(trait *vzapi.IngressTrait, c client.Client, log vzlog.VerrazzanoLogger) error {
+	selector := client.MatchingLabels{constants.LabelIngressTraitNsn: getIngressTraitNsn(trait.Namespace, trait.Name)}
+	for _, gvk := range []schema.GroupVersionKind{gatewayapi.HTTPRouteGVK, gatewayapi.ReferenceGrantGVK} {
+		list := gatewayapi.NewList(gvk)
+		if err := c.List(context.TODO(), list, selector); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			return log.ErrorfNewErr("Failed listing the %s resources of ingress trait %s: %v", gvk.Kind, trait.Name, err)
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			log.Debugf("Deleting %s %s/%s", gvk.Kind, item.GetNamespace(), item.GetName())
+			if err := c.Delete(context.TODO(), item); err != nil && !k8serrors.IsNotFound(err) {
+				return log.ErrorfNewErr("Failed deleting %s %s/%s: %v", gvk.Kind, item.GetNamespace(), item.GetName(), err)
+			}
+		}
+	}
+
+	gwName, err := buildGatewayName(trait)
+	if err != nil {
+		return err
+	}
+	gateway := gatewayapi.NewGateway(gwName, trait.Namespace)
+	err = c.Get(context.TODO(), client.ObjectKeyFromObject(gateway), gateway)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return log.ErrorfThrottledNewErr(fmt.Sprintf("Failed to fetch Gateway API gateway: %v", err))
+	}
+	listeners := gatewayapi.GetGatewayListeners(gateway)
+	remaining := removeTraitListeners(listeners, trait)
+	if len(remaining) == len(listeners) {
+		return nil
+	}
+	if len(remaining) == 0 {
+		// A Gateway must have at least one listener
+		if err := c.Delete(context.TODO(), gateway); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	className, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+	if err := gatewayapi.SetGatewaySpec(gateway, className, remaining); err != nil {
+		return err
+	}
+	return c.Update(context.TODO(), gateway)
+}
+
+// cleanupIstioIngress deletes the VirtualServices of the trait and removes the server of the trait from the Istio
+// Gateway, after the trait has been rendered by the Gateway API backend
+func cleanupIstioIngress(trait *vzapi.IngressTrait, c client.Client, log vzlog.VerrazzanoLogger) error {
+	for _, res := range trait.Status.Resources {
+		if res.Kind != virtualServiceKind {
+			continue
+		}
+		vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Namespace: trait.Namespace, Name: res.Name}}
+		log.Debugf("Deleting virtual service %s", res.Name)
+		if err := c.Delete(context.TODO(), vs); err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return log.ErrorfNewErr("Failed deleting the virtual service %s: %v", res.Name, err)
+		}
+	}
+	return cleanupGateway(trait, c, log)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package ingresstrait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclient "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testAppName = "hello"
+
+// TestGatewayAPIChildResources tests rendering an ingress trait as Gateway API resources
+// GIVEN an ingress trait with two rules and the Gateway API enabled
+// WHEN the child resources of the trait are created
+// THEN the application Gateway has a listener per host, the Gateway is granted access to the trait secret, an
+// HTTPRoute is created per rule and the status records the Gateway API resources
+func TestGatewayAPIChildResources(t *testing.T) {
+	assert := asserts.New(t)
+	t.Setenv(gatewayAPIEnabledEnvVar, "true")
+	t.Setenv(gatewayClassNameEnvVar, "test-class")
+
+	trait := newGatewayAPITestTrait("hello", "host1", "host2")
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(getAppFakeResources(setupAppFakes(testAppName))...).WithObjects(trait).Build()
+	r := newIngressTraitReconciler(cli)
+
+	status, result, err := r.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+	assert.False(result.Requeue)
+	assert.False(status.ContainsErrors())
+
+	gwName, _ := buildGatewayName(trait)
+	gateway := getGatewayAPIObject(t, cli, gatewayapi.GatewayGVK, testNamespace, gwName)
+	className, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+	assert.Equal("test-class", className)
+	assert.Equal([]gatewayapi.Listener{
+		{Name: "https-hello-0", Hostname: "host1", SecretName: buildCertificateSecretName(trait), SecretNamespace: constants.IstioSystemNamespace},
+		{Name: "https-hello-1", Hostname: "host2", SecretName: buildCertificateSecretName(trait), SecretNamespace: constants.IstioSystemNamespace},
+	}, gatewayapi.GetGatewayListeners(gateway))
+	assert.Len(gateway.GetOwnerReferences(), 1)
+
+	grant := getGatewayAPIObject(t, cli, gatewayapi.ReferenceGrantGVK, constants.IstioSystemNamespace, buildSecretReferenceGrantName(trait))
+	assert.Equal(getIngressTraitNsn(trait.Namespace, trait.Name), grant.GetLabels()[constants.LabelIngressTraitNsn])
+
+	for i, host := range []string{"host1", "host2"} {
+		route := getGatewayAPIObject(t, cli, gatewayapi.HTTPRouteGVK, testNamespace, formatTestRouteName(trait.Name, i))
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		assert.Equal([]string{host}, hostnames)
+		parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		assert.Equal(gwName, parents[0].(map[string]interface{})["name"])
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		backend := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})
+		assert.Equal("testService", backend["name"])
+		assert.Equal(int64(42), backend["port"])
+		assert.Equal(getIngressTraitNsn(trait.Namespace, trait.Name), route.GetLabels()[constants.LabelIngressTraitNsn])
+	}
+
+	var kinds []string
+	for _, rel := range status.Relations {
+		kinds = append(kinds, rel.Kind)
+	}
+	assert.Contains(kinds, httpRouteKind)
+	assert.Contains(kinds, referenceGrantKind)
+	assert.NotContains(kinds, virtualServiceKind)
+}
+
+// TestGatewayAPISwitchFromIstio tests switching an ingress trait from the Istio backend to the Gateway API
+// GIVEN an ingress trait that was rendered as an Istio Gateway server and VirtualService
+// WHEN the child resources of the trait are created with the Gateway API enabled
+// THEN the VirtualService is deleted and the trait server is removed from the Istio Gateway
+func TestGatewayAPISwitchFromIstio(t *testing.T) {
+	assert := asserts.New(t)
+	t.Setenv(gatewayAPIEnabledEnvVar, "true")
+
+	trait := newGatewayAPITestTrait("hello", "host1")
+	trait.Status.Resources = []oamrt.TypedReference{
+		{APIVersion: virtualServiceAPIVersion, Kind: virtualServiceKind, Name: "hello-rule-0-vs"},
+	}
+	gwName, _ := buildGatewayName(trait)
+	istioGateway := &istioclient.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: gwName, Namespace: testNamespace},
+		Spec:       istionet.Gateway{Servers: []*istionet.Server{{Name: trait.Name}, {Name: "other"}}},
+	}
+	vs := &istioclient.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "hello-rule-0-vs", Namespace: testNamespace}}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(getAppFakeResources(setupAppFakes(testAppName))...).WithObjects(trait, istioGateway, vs).Build()
+	r := newIngressTraitReconciler(cli)
+
+	_, _, err := r.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+	assert.NoError(err)
+
+	err = cli.Get(context.TODO(), client.ObjectKeyFromObject(vs), &istioclient.VirtualService{})
+	assert.True(k8serrors.IsNotFound(err))
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(istioGateway), istioGateway))
+	assert.Len(istioGateway.Spec.Servers, 1)
+	assert.Equal("other", istioGateway.Spec.Servers[0].Name)
+	getGatewayAPIObject(t, cli, gatewayapi.HTTPRouteGVK, testNamespace, formatTestRouteName(trait.Name, 0))
+}
+
+// TestCleanupGatewayAPIResources tests cleaning up the Gateway API resources of deleted ingress traits
+// GIVEN two ingress traits of an application rendered as Gateway API resources
+// WHEN the traits are cleaned up one after the other
+// THEN the routes and grants of a trait are deleted and its listeners are removed from the Gateway, and the Gateway
+// is deleted with the last listener
+func TestCleanupGatewayAPIResources(t *testing.T) {
+	assert := asserts.New(t)
+	t.Setenv(gatewayAPIEnabledEnvVar, "true")
+
+	trait1 := newGatewayAPITestTrait("hello", "host1")
+	trait2 := newGatewayAPITestTrait("hello-2", "host2")
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(getAppFakeResources(setupAppFakes(testAppName))...).WithObjects(trait1, trait2).Build()
+	r := newIngressTraitReconciler(cli)
+	for _, trait := range []*vzapi.IngressTrait{trait1, trait2} {
+		_, _, err := r.createOrUpdateChildResources(context.TODO(), trait, vzlog.DefaultLogger())
+		assert.NoError(err)
+	}
+	gwName, _ := buildGatewayName(trait1)
+	assert.Len(gatewayapi.GetGatewayListeners(getGatewayAPIObject(t, cli, gatewayapi.GatewayGVK, testNamespace, gwName)), 2)
+
+	assert.NoError(cleanup(trait1, cli, vzlog.DefaultLogger()))
+	assertGatewayAPIObjectNotFound(t, cli, gatewayapi.HTTPRouteGVK, testNamespace, formatTestRouteName(trait1.Name, 0))
+	assertGatewayAPIObjectNotFound(t, cli, gatewayapi.ReferenceGrantGVK, constants.IstioSystemNamespace, buildSecretReferenceGrantName(trait1))
+	getGatewayAPIObject(t, cli, gatewayapi.HTTPRouteGVK, testNamespace, formatTestRouteName(trait2.Name, 0))
+	listeners := gatewayapi.GetGatewayListeners(getGatewayAPIObject(t, cli, gatewayapi.GatewayGVK, testNamespace, gwName))
+	assert.Len(listeners, 1)
+	assert.Equal("host2", listeners[0].Hostname)
+
+	assert.NoError(cleanup(trait2, cli, vzlog.DefaultLogger()))
+	assertGatewayAPIObjectNotFound(t, cli, gatewayapi.GatewayGVK, testNamespace, gwName)
+}
+
+// TestCreateBackendFromDestination tests converting route destinations to HTTPRoute backends
+// GIVEN route destinations with unqualified and namespace qualified hosts
+// WHEN the destinations are converted
+// THEN the backend is in the namespace of the host, or the namespace of the trait by default
+func TestCreateBackendFromDestination(t *testing.T) {
+	assert := asserts.New(t)
+	assert.Equal(gatewayapi.Backend{Name: "svc", Namespace: testNamespace, Port: 8080},
+		createBackendFromDestination(&istionet.HTTPRouteDestination{Destination: &istionet.Destination{Host: "svc", Port: &istionet.PortSelector{Number: 8080}}}, testNamespace))
+	assert.Equal(gatewayapi.Backend{Name: "svc", Namespace: "other"},
+		createBackendFromDestination(&istionet.HTTPRouteDestination{Destination: &istionet.Destination{Host: "svc.other.svc.cluster.local"}}, testNamespace))
+}
+
+// TestIsTraitListener tests matching the Gateway listeners of a trait
+// GIVEN listeners of traits with similar names
+// WHEN the listeners are matched with a trait
+// THEN only the listeners of the trait match
+func TestIsTraitListener(t *testing.T) {
+	assert := asserts.New(t)
+	assert.True(isTraitListener(gatewayapi.Listener{Name: "https-hello-0"}, "hello"))
+	assert.False(isTraitListener(gatewayapi.Listener{Name: "https-hello-2-0"}, "hello"))
+	assert.False(isTraitListener(gatewayapi.Listener{Name: "https-0"}, "hello"))
+}
+
+func newGatewayAPITestTrait(name string, hosts ...string) *vzapi.IngressTrait {
+	var rules []vzapi.IngressRule
+	for _, host := range hosts {
+		rules = append(rules, vzapi.IngressRule{Hosts: []string{host}})
+	}
+	return &vzapi.IngressTrait{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiVersion, Kind: traitKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace,
+			Labels: map[string]string{oam.LabelAppName: testAppName},
+		},
+		Spec: vzapi.IngressTraitSpec{
+			Rules: rules,
+			WorkloadReference: oamrt.TypedReference{
+				APIVersion: "core.oam.dev/v1alpha2",
+				Kind:       "ContainerizedWorkload",
+				Name:       testWorkloadName,
+			},
+		},
+	}
+}
+
+func formatTestRouteName(traitName string, index int) string {
+	return fmt.Sprintf("%s-rule-%d-route", traitName, index)
+}
+
+func getGatewayAPIObject(t *testing.T, cli client.Client, gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	asserts.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, obj))
+	return obj
+}
+
+func assertGatewayAPIObjectNotFound(t *testing.T, cli client.Client, gvk schema.GroupVersionKind, namespace, name string) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := cli.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, obj)
+	asserts.True(t, k8serrors.IsNotFound(err), "expected %s %s/%s to be deleted", gvk.Kind, namespace, name)
+}
@@ -229,7 +229,7 @@ func (r *Reconciler) addFinalizerIfRequired(ctx context.Context, trait *vzapi.In
 }
 
 // createOrUpdateChildResources creates or updates the Gateway and VirtualService resources that
-// should be used to setup ingress to the service.
+// should be used to setup ingress to the service, or the Gateway API resources if the Gateway API is enabled.
 // This is the cardinality:
 //
 //	1 Gateway per Application
@@ -251,6 +251,9 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 		gwName, err := buildGatewayName(trait)
 		if err != nil {
 			status.Errors = append(status.Errors, err)
+		} else if isGatewayAPIEnabled() {
+			result, err := r.createOrUpdateGatewayAPIChildResources(ctx, trait, rules, allHostsForTrait, gwName, secretName, &status, log)
+			return &status, result, err
 		} else {
 			// The Gateway is shared across all ingress traits for the app, update it with all known hosts for the trait
 			// - Must create GW before service so that external DNS sees the GW once the service is created
@@ -284,6 +287,13 @@ func (r *Reconciler) createOrUpdateChildResources(ctx context.Context, trait *vz
 				r.createOrUpdateDestinationRule(ctx, trait, rule, drName, &status, log, services)
 				r.createOrUpdateAuthorizationPolicies(ctx, trait, rule, authzPolicyName, allHostsForTrait, &status, log)
 			}
+
+			// Remove the Gateway API resources of a trait that was previously rendered by the Gateway API backend
+			if hasStatusRelation(trait, httpRouteKind) {
+				if err := cleanupGatewayAPIResources(trait, r.Client, log); err != nil {
+					return &status, ctrl.Result{}, err
+				}
+			}
 		}
 	}
 	return &status, ctrl.Result{}, nil
@@ -25,7 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// cleanup cleans up the generated certificates and secrets associated with the given app config
+// cleanup cleans up the generated certificates, secrets and ingress resources associated with the given app config
 func cleanup(trait *vzapi.IngressTrait, client client.Client, log vzlog.VerrazzanoLogger) (err error) {
 	err = cleanupCert(buildCertificateName(trait), client, log)
 	if err != nil {
@@ -43,6 +43,10 @@ func cleanup(trait *vzapi.IngressTrait, client client.Client, log vzlog.Verrazza
 	if err != nil {
 		return
 	}
+	err = cleanupGatewayAPIResources(trait, client, log)
+	if err != nil {
+		return
+	}
 	return
 }
 
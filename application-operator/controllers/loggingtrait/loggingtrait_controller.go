@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package loggingtrait
@@ -57,6 +57,8 @@ type LoggingTraitReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=pods,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=fluentbit.fluent.io,resources=fluentbitconfigs;filters;parsers;outputs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=fluentbit.fluent.io,resources=clusteroutputs,verbs=get;list;watch
 
 func (r *LoggingTraitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	if ctx == nil {
@@ -101,7 +103,16 @@ func (r *LoggingTraitReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 // doReconcile performs the reconciliation operations for the logging trait
 func (r *LoggingTraitReconciler) doReconcile(ctx context.Context, trait *oamv1alpha1.LoggingTrait, log vzlog.VerrazzanoLogger) (ctrl.Result, error) {
+	if trait.DeletionTimestamp.IsZero() && trait.Spec.FluentBit != nil {
+		// The generated Fluent Bit resources are owned by the trait and garbage collected when the trait is deleted
+		return reconcile.Result{}, r.reconcileFluentBit(ctx, log, trait)
+	}
 	if trait.DeletionTimestamp.IsZero() {
+		// Remove the Fluent Bit resources generated for a previous configuration of the trait
+		if err := r.deleteAllFluentBitResources(ctx, trait); err != nil {
+			log.Errorf("Failed to delete the Fluent Bit resources of logging trait %s: %v", trait.Name, err)
+			return reconcile.Result{}, err
+		}
 		result, supported, err := r.reconcileTraitCreateOrUpdate(ctx, log, trait)
 		if err != nil {
 			return result, err
@@ -0,0 +1,315 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/filter"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/parser"
+	oamv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// fluentBitTraitLabel is placed on the generated Fluent Bit resources, the namespaced FluentBitConfig selects
+	// the filters, parsers and outputs of a trait by this label
+	fluentBitTraitLabel = "logging.verrazzano.io/trait"
+
+	// fluentBitNamespaceConfigLabel selects the namespaced FluentBitConfig resources that are merged into the
+	// Verrazzano Fluent Bit configuration
+	fluentBitNamespaceConfigLabel = "fluentbit.verrazzano.io/namespace-config"
+	fluentBitNamespaceConfigValue = "verrazzano"
+
+	containerLogTagPrefix = "kube.var.log.containers."
+	defaultLogKey         = "log"
+	parserFormatJSON      = "json"
+	parserFormatRegex     = "regex"
+	parserFormatLogfmt    = "logfmt"
+)
+
+// reconcileFluentBit generates the namespaced Fluent Bit FluentBitConfig, Filter, Parser and Output resources of a
+// logging trait and deletes the resources the trait no longer needs. The generated resources are recorded in
+// the trait status.
+func (r *LoggingTraitReconciler) reconcileFluentBit(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait) error {
+	// Remove a Fluentd sidecar left over from a previous configuration of the trait
+	if _, err := r.reconcileTraitDelete(ctx, log, trait); err != nil {
+		return err
+	}
+
+	var resources []oamrt.TypedReference
+	err := validateFluentBitLogging(trait.Spec.FluentBit)
+	if err == nil {
+		resources, err = r.createOrUpdateFluentBitResources(ctx, log, trait)
+	}
+	if err == nil {
+		err = r.deleteStaleFluentBitResources(ctx, trait, resources)
+	}
+	if err != nil {
+		log.Errorf("Failed to reconcile Fluent Bit resources for logging trait %s: %v", trait.Name, err)
+		trait.SetConditions(oamrt.ReconcileError(err))
+	} else {
+		trait.SetConditions(oamrt.ReconcileSuccess())
+		trait.Status.Resources = resources
+	}
+	if updateErr := r.Status().Update(ctx, trait); updateErr != nil {
+		log.Errorf("Failed to update the status of logging trait %s: %v", trait.Name, updateErr)
+		return updateErr
+	}
+	return err
+}
+
+// validateFluentBitLogging validates the Fluent Bit configuration of a logging trait
+func validateFluentBitLogging(config *oamv1alpha1.FluentBitLogging) error {
+	names := map[string]bool{}
+	for _, p := range config.Parsers {
+		if names[p.Name] {
+			return fmt.Errorf("parser name %s is not unique", p.Name)
+		}
+		names[p.Name] = true
+		if p.Format == parserFormatRegex && p.Regex == "" {
+			return fmt.Errorf("parser %s must specify a regex", p.Name)
+		}
+	}
+	if config.Multiline != nil && len(config.Multiline.Parsers) == 0 {
+		return fmt.Errorf("multiline configuration must specify at least one parser")
+	}
+	return nil
+}
+
+// createOrUpdateFluentBitResources creates or updates the Fluent Bit resources of a trait and returns references
+// to the resources
+func (r *LoggingTraitReconciler) createOrUpdateFluentBitResources(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait) ([]oamrt.TypedReference, error) {
+	config := trait.Spec.FluentBit
+	match, matchRegex := buildLogMatch(config.LogPaths)
+	var resources []oamrt.TypedReference
+
+	for _, p := range config.Parsers {
+		fbParser := &fluentbitv1alpha2.Parser{ObjectMeta: fluentBitObjectMeta(trait, fluentBitParserName(trait, p.Name))}
+		if err := r.createOrUpdateFluentBitResource(ctx, log, trait, fbParser, func() error {
+			fbParser.Spec = buildParserSpec(p)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		resources = append(resources, fluentBitReference(fbParser.Name, "Parser"))
+	}
+
+	if filterItems := buildFilterItems(trait); len(filterItems) > 0 {
+		fbFilter := &fluentbitv1alpha2.Filter{ObjectMeta: fluentBitObjectMeta(trait, trait.Name+"-filter")}
+		if err := r.createOrUpdateFluentBitResource(ctx, log, trait, fbFilter, func() error {
+			fbFilter.Spec = fluentbitv1alpha2.FilterSpec{Match: match, MatchRegex: matchRegex, FilterItems: filterItems}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		resources = append(resources, fluentBitReference(fbFilter.Name, "Filter"))
+	}
+
+	if config.Destination != nil && config.Destination.ClusterOutput != "" {
+		clusterOutput := &fluentbitv1alpha2.ClusterOutput{}
+		if err := r.Get(ctx, types.NamespacedName{Name: config.Destination.ClusterOutput}, clusterOutput); err != nil {
+			return nil, fmt.Errorf("failed to get ClusterOutput %s: %v", config.Destination.ClusterOutput, err)
+		}
+		fbOutput := &fluentbitv1alpha2.Output{ObjectMeta: fluentBitObjectMeta(trait, trait.Name+"-output")}
+		if err := r.createOrUpdateFluentBitResource(ctx, log, trait, fbOutput, func() error {
+			fbOutput.Spec = *clusterOutput.Spec.DeepCopy()
+			fbOutput.Spec.Match = match
+			fbOutput.Spec.MatchRegex = matchRegex
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		resources = append(resources, fluentBitReference(fbOutput.Name, "Output"))
+	}
+
+	// The FluentBitConfig adds the filters, parsers and outputs of the trait to the Fluent Bit configuration
+	fbConfig := &fluentbitv1alpha2.FluentBitConfig{ObjectMeta: fluentBitObjectMeta(trait, trait.Name+"-fbc")}
+	if err := r.createOrUpdateFluentBitResource(ctx, log, trait, fbConfig, func() error {
+		fbConfig.Labels[fluentBitNamespaceConfigLabel] = fluentBitNamespaceConfigValue
+		selector := metav1.LabelSelector{MatchLabels: map[string]string{fluentBitTraitLabel: trait.Name}}
+		fbConfig.Spec = fluentbitv1alpha2.NamespacedFluentBitCfgSpec{FilterSelector: selector, ParserSelector: selector, OutputSelector: selector}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	resources = append(resources, fluentBitReference(fbConfig.Name, "FluentBitConfig"))
+	return resources, nil
+}
+
+// createOrUpdateFluentBitResource creates or updates a generated Fluent Bit resource, setting the trait label and the
+// trait as the owner of the resource
+func (r *LoggingTraitReconciler) createOrUpdateFluentBitResource(ctx context.Context, log vzlog.VerrazzanoLogger, trait *oamv1alpha1.LoggingTrait, obj client.Object, mutate func() error) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[fluentBitTraitLabel] = trait.Name
+		obj.SetLabels(labels)
+		if err := mutate(); err != nil {
+			return err
+		}
+		return controllerutil.SetControllerReference(trait, obj, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update Fluent Bit resource %s: %v", obj.GetName(), err)
+	}
+	log.Debugf("Reconciled Fluent Bit resource %s for logging trait %s", obj.GetName(), trait.Name)
+	return nil
+}
+
+// deleteStaleFluentBitResources deletes the Fluent Bit resources recorded in the trait status that are no longer
+// generated for the trait, for example, after a parser has been removed from the trait
+func (r *LoggingTraitReconciler) deleteStaleFluentBitResources(ctx context.Context, trait *oamv1alpha1.LoggingTrait, resources []oamrt.TypedReference) error {
+	for _, ref := range trait.Status.Resources {
+		if ref.APIVersion != fluentbitv1alpha2.SchemeGroupVersion.String() || containsReference(resources, ref) {
+			continue
+		}
+		if err := r.deleteFluentBitResource(ctx, trait.Namespace, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAllFluentBitResources deletes all Fluent Bit resources recorded in the trait status. This is used when
+// the Fluent Bit configuration is removed from a trait.
+func (r *LoggingTraitReconciler) deleteAllFluentBitResources(ctx context.Context, trait *oamv1alpha1.LoggingTrait) error {
+	if err := r.deleteStaleFluentBitResources(ctx, trait, nil); err != nil {
+		return err
+	}
+	if len(trait.Status.Resources) == 0 {
+		return nil
+	}
+	trait.Status.Resources = nil
+	return r.Status().Update(ctx, trait)
+}
+
+// deleteFluentBitResource deletes a generated Fluent Bit resource, ignoring resources that no longer exist
+func (r *LoggingTraitReconciler) deleteFluentBitResource(ctx context.Context, namespace string, ref oamrt.TypedReference) error {
+	var obj client.Object
+	switch ref.Kind {
+	case "Parser":
+		obj = &fluentbitv1alpha2.Parser{}
+	case "Filter":
+		obj = &fluentbitv1alpha2.Filter{}
+	case "Output":
+		obj = &fluentbitv1alpha2.Output{}
+	case "FluentBitConfig":
+		obj = &fluentbitv1alpha2.FluentBitConfig{}
+	default:
+		return nil
+	}
+	obj.SetNamespace(namespace)
+	obj.SetName(ref.Name)
+	if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Fluent Bit resource %s %s: %v", ref.Kind, ref.Name, err)
+	}
+	return nil
+}
+
+// buildLogMatch converts the log paths of a trait to a Fluent Bit tag match pattern. A single log path is matched
+// with a wildcard pattern, multiple log paths are matched with a regular expression.
+func buildLogMatch(logPaths []string) (string, string) {
+	switch len(logPaths) {
+	case 0:
+		return "kube.*", ""
+	case 1:
+		return containerLogTag(logPaths[0]), ""
+	}
+	var patterns []string
+	for _, path := range logPaths {
+		patterns = append(patterns, strings.ReplaceAll(regexp.QuoteMeta(containerLogTag(path)), `\*`, ".*"))
+	}
+	return "", fmt.Sprintf("^(%s)$", strings.Join(patterns, "|"))
+}
+
+// containerLogTag returns the Fluent Bit tag of a container log file. The Fluent Bit tail input tags records with
+// the path of the log file, with the slashes replaced by dots.
+func containerLogTag(path string) string {
+	path = strings.TrimPrefix(path, "/var/log/containers/")
+	return containerLogTagPrefix + strings.ReplaceAll(strings.TrimPrefix(path, "/"), "/", ".")
+}
+
+// buildParserSpec converts a trait parser to a Fluent Bit parser spec
+func buildParserSpec(p oamv1alpha1.FluentBitParser) fluentbitv1alpha2.ParserSpec {
+	timeKeep := true
+	switch p.Format {
+	case parserFormatRegex:
+		return fluentbitv1alpha2.ParserSpec{Regex: &parser.Regex{Regex: p.Regex, TimeKey: p.TimeKey, TimeFormat: p.TimeFormat, TimeKeep: &timeKeep}}
+	case parserFormatLogfmt:
+		return fluentbitv1alpha2.ParserSpec{Logfmt: &parser.Logfmt{}}
+	}
+	return fluentbitv1alpha2.ParserSpec{JSON: &parser.JSON{TimeKey: p.TimeKey, TimeFormat: p.TimeFormat, TimeKeep: &timeKeep}}
+}
+
+// buildFilterItems returns the filter plugins of a trait. The multiline filter comes first so that the parsers
+// see complete records. Consecutive parsers of the same field are combined into one parser filter, which applies
+// the first parser that matches a record.
+func buildFilterItems(trait *oamv1alpha1.LoggingTrait) []fluentbitv1alpha2.FilterItem {
+	config := trait.Spec.FluentBit
+	var items []fluentbitv1alpha2.FilterItem
+	if config.Multiline != nil {
+		keyContent := config.Multiline.KeyContent
+		if keyContent == "" {
+			keyContent = defaultLogKey
+		}
+		items = append(items, fluentbitv1alpha2.FilterItem{Multiline: &filter.Multiline{
+			Multi: &filter.Multi{Parser: strings.Join(config.Multiline.Parsers, ","), KeyContent: keyContent},
+		}})
+	}
+
+	reserveData := true
+	preserveKey := true
+	var parserFilter *filter.Parser
+	for _, p := range config.Parsers {
+		keyName := p.KeyName
+		if keyName == "" {
+			keyName = defaultLogKey
+		}
+		if parserFilter != nil && parserFilter.KeyName == keyName {
+			parserFilter.Parser += "," + fluentBitParserName(trait, p.Name)
+			continue
+		}
+		parserFilter = &filter.Parser{KeyName: keyName, Parser: fluentBitParserName(trait, p.Name), ReserveData: &reserveData, PreserveKey: &preserveKey}
+		items = append(items, fluentbitv1alpha2.FilterItem{Parser: parserFilter})
+	}
+	return items
+}
+
+// fluentBitParserName returns the name of the Fluent Bit parser generated for a trait parser
+func fluentBitParserName(trait *oamv1alpha1.LoggingTrait, name string) string {
+	return trait.Name + "-" + name
+}
+
+// fluentBitObjectMeta returns the object metadata of a generated Fluent Bit resource
+func fluentBitObjectMeta(trait *oamv1alpha1.LoggingTrait, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: trait.Namespace, Name: name}
+}
+
+// fluentBitReference returns a typed reference to a generated Fluent Bit resource
+func fluentBitReference(name string, kind string) oamrt.TypedReference {
+	return oamrt.TypedReference{APIVersion: fluentbitv1alpha2.SchemeGroupVersion.String(), Kind: kind, Name: name}
+}
+
+// containsReference returns true if the references contain a reference of the same kind and name
+func containsReference(refs []oamrt.TypedReference, ref oamrt.TypedReference) bool {
+	for _, r := range refs {
+		if r.APIVersion == ref.APIVersion && r.Kind == ref.Kind && r.Name == ref.Name {
+			return true
+		}
+	}
+	return false
+}
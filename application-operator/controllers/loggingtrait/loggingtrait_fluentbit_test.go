@@ -0,0 +1,241 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package loggingtrait
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oamv1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
+	"github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2/plugins/output"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFluentBitScheme creates a scheme that includes the Fluent Bit types
+func newFluentBitScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
+	return scheme
+}
+
+// newFluentBitTrait creates a logging trait with a Fluent Bit configuration for a Helidon workload
+func newFluentBitTrait(config *vzapi.FluentBitLogging) *vzapi.LoggingTrait {
+	return &vzapi.LoggingTrait{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: vzapi.LoggingTraitKind},
+		ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName},
+		Spec: vzapi.LoggingTraitSpec{
+			FluentBit:         config,
+			WorkloadReference: oamrt.TypedReference{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: "VerrazzanoHelidonWorkload", Name: workloadName},
+		},
+	}
+}
+
+// newFluentBitClient creates a fake client with the trait and its Helidon workload
+func newFluentBitClient(objs ...client.Object) client.Client {
+	workload := &vzapi.VerrazzanoHelidonWorkload{ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: workloadName}}
+	return fake.NewClientBuilder().WithScheme(newFluentBitScheme()).WithObjects(append(objs, workload)...).Build()
+}
+
+// reconcileFluentBitTrait runs a reconcile of the test trait and returns the updated trait
+func reconcileFluentBitTrait(t *testing.T, cli client.Client) *vzapi.LoggingTrait {
+	reconciler := LoggingTraitReconciler{Client: cli, Log: zap.S(), Scheme: newFluentBitScheme()}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespaceName, Name: traitName}}
+	_, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(t, err)
+
+	trait := &vzapi.LoggingTrait{}
+	asserts.NoError(t, cli.Get(context.TODO(), request.NamespacedName, trait))
+	return trait
+}
+
+// TestFluentBitResourcesGenerated tests the generation of Fluent Bit resources for a logging trait
+// GIVEN a logging trait with log paths, parsers and multiline configuration
+// WHEN the logging trait is reconciled
+// THEN namespaced Fluent Bit Parser, Filter and FluentBitConfig resources are generated
+// AND the generated resources are listed in the trait status
+func TestFluentBitResourcesGenerated(t *testing.T) {
+	assert := asserts.New(t)
+	trait := newFluentBitTrait(&vzapi.FluentBitLogging{
+		LogPaths: []string{"hello-*_*_hello-container-*.log"},
+		Parsers: []vzapi.FluentBitParser{
+			{Name: "json", Format: "json", TimeKey: "time"},
+			{Name: "plain", Format: "regex", Regex: "^(?<level>\\S+) (?<message>.*)$"},
+		},
+		Multiline: &vzapi.FluentBitMultiline{Parsers: []string{"java", "go"}},
+	})
+	cli := newFluentBitClient(trait)
+
+	trait = reconcileFluentBitTrait(t, cli)
+
+	parser := &fluentbitv1alpha2.Parser{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-plain"}, parser))
+	assert.Equal("^(?<level>\\S+) (?<message>.*)$", parser.Spec.Regex.Regex)
+	assert.Equal(traitName, parser.Labels[fluentBitTraitLabel])
+	assert.Len(parser.OwnerReferences, 1)
+
+	filter := &fluentbitv1alpha2.Filter{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-filter"}, filter))
+	assert.Equal("kube.var.log.containers.hello-*_*_hello-container-*.log", filter.Spec.Match)
+	assert.Len(filter.Spec.FilterItems, 2)
+	assert.Equal("java,go", filter.Spec.FilterItems[0].Multiline.Parser)
+	assert.Equal(traitName+"-json,"+traitName+"-plain", filter.Spec.FilterItems[1].Parser.Parser)
+	assert.Equal(defaultLogKey, filter.Spec.FilterItems[1].Parser.KeyName)
+
+	fbConfig := &fluentbitv1alpha2.FluentBitConfig{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-fbc"}, fbConfig))
+	assert.Equal(fluentBitNamespaceConfigValue, fbConfig.Labels[fluentBitNamespaceConfigLabel])
+	assert.Equal(traitName, fbConfig.Spec.FilterSelector.MatchLabels[fluentBitTraitLabel])
+
+	assert.Len(trait.Status.Resources, 4)
+	assert.Equal(corev1.ConditionTrue, trait.Status.GetCondition(oamrt.TypeSynced).Status)
+}
+
+// TestFluentBitOutputFromClusterOutput tests the generation of an Output for a per-application destination
+// GIVEN a logging trait with a ClusterOutput destination
+// WHEN the logging trait is reconciled
+// THEN a namespaced Output with the configuration of the ClusterOutput is generated
+func TestFluentBitOutputFromClusterOutput(t *testing.T) {
+	assert := asserts.New(t)
+	clusterOutput := &fluentbitv1alpha2.ClusterOutput{
+		ObjectMeta: k8smeta.ObjectMeta{Name: "app-loki"},
+		Spec:       fluentbitv1alpha2.OutputSpec{MatchRegex: ".*", Loki: &output.Loki{Host: "loki.example.com"}},
+	}
+	trait := newFluentBitTrait(&vzapi.FluentBitLogging{Destination: &vzapi.LogDestination{ClusterOutput: "app-loki"}})
+	cli := newFluentBitClient(trait, clusterOutput)
+
+	trait = reconcileFluentBitTrait(t, cli)
+
+	out := &fluentbitv1alpha2.Output{}
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-output"}, out))
+	assert.Equal("loki.example.com", out.Spec.Loki.Host)
+	assert.Equal("kube.*", out.Spec.Match)
+	assert.Empty(out.Spec.MatchRegex)
+	assert.Len(trait.Status.Resources, 2)
+}
+
+// TestFluentBitMissingClusterOutput tests a destination that refers to a missing ClusterOutput
+// GIVEN a logging trait with a ClusterOutput destination that does not exist
+// WHEN the logging trait is reconciled
+// THEN the trait status reports the error
+func TestFluentBitMissingClusterOutput(t *testing.T) {
+	assert := asserts.New(t)
+	trait := newFluentBitTrait(&vzapi.FluentBitLogging{Destination: &vzapi.LogDestination{ClusterOutput: "missing"}})
+	cli := newFluentBitClient(trait)
+
+	trait = reconcileFluentBitTrait(t, cli)
+
+	assert.Equal(corev1.ConditionFalse, trait.Status.GetCondition(oamrt.TypeSynced).Status)
+	assert.Contains(trait.Status.GetCondition(oamrt.TypeSynced).Message, "missing")
+}
+
+// TestFluentBitStaleResourcesDeleted tests the deletion of Fluent Bit resources that are no longer needed
+// GIVEN a logging trait whose status lists a parser that has been removed from the trait
+// WHEN the logging trait is reconciled
+// THEN the generated parser is deleted
+func TestFluentBitStaleResourcesDeleted(t *testing.T) {
+	assert := asserts.New(t)
+	trait := newFluentBitTrait(&vzapi.FluentBitLogging{})
+	trait.Status.Resources = []oamrt.TypedReference{fluentBitReference(traitName+"-old", "Parser")}
+	stale := &fluentbitv1alpha2.Parser{ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: traitName + "-old"}}
+	cli := newFluentBitClient(trait, stale)
+
+	trait = reconcileFluentBitTrait(t, cli)
+
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: traitName + "-old"}, stale)
+	assert.True(k8serrors.IsNotFound(err))
+	assert.Equal([]oamrt.TypedReference{fluentBitReference(traitName+"-fbc", "FluentBitConfig")}, trait.Status.Resources)
+}
+
+// TestHelidonWorkloadWithoutFluentBit tests a logging trait without a Fluent Bit configuration for a Helidon workload
+// GIVEN a logging trait without the fluentBit configuration for a Helidon workload
+// WHEN the logging trait is reconciled
+// THEN the logging sidecar is injected in the Helidon Deployment and no Fluent Bit resources are generated
+func TestHelidonWorkloadWithoutFluentBit(t *testing.T) {
+	assert := asserts.New(t)
+	trait := newFluentBitTrait(nil)
+	trait.Spec.LoggingImage = "fluentd:test"
+	definition := &oamv1alpha2.WorkloadDefinition{
+		ObjectMeta: k8smeta.ObjectMeta{Name: "verrazzanohelidonworkloads.oam.verrazzano.io"},
+		Spec: oamv1alpha2.WorkloadDefinitionSpec{
+			ChildResourceKinds: []oamv1alpha2.ChildResourceKind{{APIVersion: "apps/v1", Kind: "Deployment"}},
+		},
+	}
+	workload := &vzapi.VerrazzanoHelidonWorkload{ObjectMeta: k8smeta.ObjectMeta{Namespace: namespaceName, Name: workloadName, UID: types.UID(workloadUID)}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: k8smeta.ObjectMeta{
+			Namespace:       namespaceName,
+			Name:            workloadName,
+			OwnerReferences: []k8smeta.OwnerReference{{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: "VerrazzanoHelidonWorkload", Name: workloadName, UID: types.UID(workloadUID)}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "hello", Image: "hello:test"}}}},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newFluentBitScheme()).WithObjects(trait, definition, workload, deployment).Build()
+
+	trait = reconcileFluentBitTrait(t, cli)
+
+	assert.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: workloadName}, deployment))
+	assert.Len(deployment.Spec.Template.Spec.Containers, 2)
+	assert.Equal(loggingNamePart, deployment.Spec.Template.Spec.Containers[1].Name)
+	assert.Equal("fluentd:test", deployment.Spec.Template.Spec.Containers[1].Image)
+	assert.Empty(trait.Status.Resources)
+}
+
+// TestBuildLogMatch tests the conversion of log paths to Fluent Bit tag matches
+func TestBuildLogMatch(t *testing.T) {
+	assert := asserts.New(t)
+
+	// GIVEN no log paths
+	// WHEN the match is built
+	// THEN all logs of the namespace are matched
+	match, matchRegex := buildLogMatch(nil)
+	assert.Equal("kube.*", match)
+	assert.Empty(matchRegex)
+
+	// GIVEN multiple log paths
+	// WHEN the match is built
+	// THEN a regular expression matching any of the paths is returned
+	match, matchRegex = buildLogMatch([]string{"/var/log/containers/a-*.log", "b.log"})
+	assert.Empty(match)
+	assert.Equal(`^(kube\.var\.log\.containers\.a-.*\.log|kube\.var\.log\.containers\.b\.log)$`, matchRegex)
+}
+
+// TestValidateFluentBitLogging tests the validation of the Fluent Bit configuration
+func TestValidateFluentBitLogging(t *testing.T) {
+	assert := asserts.New(t)
+
+	// GIVEN a regex parser without a regex
+	// WHEN the configuration is validated
+	// THEN an error is returned
+	assert.Error(validateFluentBitLogging(&vzapi.FluentBitLogging{Parsers: []vzapi.FluentBitParser{{Name: "p", Format: "regex"}}}))
+
+	// GIVEN parsers with duplicate names
+	// WHEN the configuration is validated
+	// THEN an error is returned
+	assert.Error(validateFluentBitLogging(&vzapi.FluentBitLogging{Parsers: []vzapi.FluentBitParser{{Name: "p", Format: "json"}, {Name: "p", Format: "logfmt"}}}))
+
+	// GIVEN a valid configuration
+	// WHEN the configuration is validated
+	// THEN no error is returned
+	assert.NoError(validateFluentBitLogging(&vzapi.FluentBitLogging{Parsers: []vzapi.FluentBitParser{{Name: "p", Format: "json"}}}))
+}
@@ -413,8 +413,8 @@ func (r *Reconciler) deleteOrUpdateObsoleteResources(ctx context.Context, trait
 		if !status.ContainsRelation(rel) {
 			switch rel.Role {
 			case scraperRole:
-				if rel.Kind == promoperapi.ServiceMonitorsKind {
-					result, err := r.deleteServiceMonitor(ctx, rel.Namespace, rel.Name, trait, log)
+				if rel.Kind == promoperapi.ServiceMonitorsKind || rel.Kind == promoperapi.PodMonitorsKind {
+					result, err := r.deleteMonitor(ctx, rel, trait, log)
 					update.RecordOutcome(rel, result, err)
 				} else {
 					update.RecordOutcomeIfError(r.deleteOrUpdateScraperConfigMap(ctx, trait, rel, log)) // Need to pass down traitDefaults, current scraper or current scraper deployment
@@ -18,6 +18,7 @@ import (
 
 	promoperapi "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/internal/metrics"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -1185,6 +1186,132 @@ func TestDeleteServiceMonitor(t *testing.T) {
 	}
 }
 
+// TestDeleteMonitor tests the deleteMonitor func call
+func TestDeleteMonitor(t *testing.T) {
+	scheme := k8scheme.Scheme
+	_ = vzapi.AddToScheme(scheme)
+	_ = promoperapi.AddToScheme(scheme)
+	podMonitorType := vzapi.PodMonitorType
+	tests := []struct {
+		name   string
+		kind   string
+		trait  vzapi.MetricsTrait
+		result controllerutil.OperationResult
+	}{
+		// GIVEN an enabled metricstrait with the PodMonitor type
+		// WHEN the related PodMonitor is deleted
+		// THEN the PodMonitor resource is not deleted
+		{
+			"PodMonitor of an enabled trait",
+			promoperapi.PodMonitorsKind,
+			vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Enabled: getBoolPtr(true), MonitorType: &podMonitorType}},
+			controllerutil.OperationResultNone,
+		},
+		// GIVEN an enabled metricstrait with the PodMonitor type
+		// WHEN the related ServiceMonitor is deleted
+		// THEN the ServiceMonitor is deleted because the monitor type changed
+		{
+			"ServiceMonitor after the monitor type changed",
+			promoperapi.ServiceMonitorsKind,
+			vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Enabled: getBoolPtr(true), MonitorType: &podMonitorType}},
+			controllerutil.OperationResultUpdated,
+		},
+		// GIVEN an enabled metricstrait without a monitor type
+		// WHEN the related PodMonitor is deleted
+		// THEN the PodMonitor is deleted because the monitor type changed
+		{
+			"PodMonitor after the monitor type changed",
+			promoperapi.PodMonitorsKind,
+			vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Enabled: getBoolPtr(true)}},
+			controllerutil.OperationResultUpdated,
+		},
+		// GIVEN a disabled metricstrait with the PodMonitor type
+		// WHEN the related PodMonitor is deleted
+		// THEN the PodMonitor is deleted
+		{
+			"PodMonitor of a disabled trait",
+			promoperapi.PodMonitorsKind,
+			vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Enabled: getBoolPtr(false), MonitorType: &podMonitorType}},
+			controllerutil.OperationResultUpdated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := k8smeta.ObjectMeta{Name: foo, Namespace: bar}
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&promoperapi.ServiceMonitor{ObjectMeta: meta},
+				&promoperapi.PodMonitor{ObjectMeta: meta},
+			).Build()
+			reconciler := newMetricsTraitReconciler(cli)
+			rel := vzapi.QualifiedResourceRelation{APIVersion: promoperapi.SchemeGroupVersion.String(), Kind: tt.kind, Namespace: bar, Name: foo}
+			res, err := reconciler.deleteMonitor(context.TODO(), rel, &tt.trait, vzlog.DefaultLogger())
+			asserts.NoError(t, err)
+			asserts.Equal(t, tt.result, res)
+		})
+	}
+}
+
+// TestMonitorSettings tests the conversion of the trait scrape settings to Prometheus Operator settings
+func TestMonitorSettings(t *testing.T) {
+	port := 8080
+	defaultPort := 9090
+	interval := "30s"
+	portInterval := "10s"
+	timeout := "5s"
+	caKey := k8score.SecretKeySelector{LocalObjectReference: k8score.LocalObjectReference{Name: "ca"}, Key: "ca.crt"}
+	trait := &vzapi.MetricsTrait{
+		Spec: vzapi.MetricsTraitSpec{
+			Interval:      &interval,
+			ScrapeTimeout: &timeout,
+			Ports: []vzapi.PortSpec{
+				{Port: &port, Interval: &portInterval},
+				{},
+			},
+			MetricRelabelings: []vzapi.MetricRelabeling{
+				{SourceLabels: []string{"pod", "container"}, TargetLabel: "instance", Action: "replace"},
+			},
+			DropMetrics: []string{"go_gc_.*"},
+			TLS:         &vzapi.MetricsTLSConfig{CASecret: &caKey, ServerName: "hello.example.com"},
+		},
+	}
+	traitDefaults := &vzapi.MetricsTraitSpec{Ports: []vzapi.PortSpec{{Port: &defaultPort}}}
+
+	// GIVEN a trait with two ports
+	// WHEN the port scrape settings are created
+	// THEN the port settings override the trait settings and the default port is used for a port without a port number
+	settings := getPortScrapeSettings(trait, traitDefaults, getPortSpecs(trait, traitDefaults))
+	asserts.Len(t, settings, 2)
+	asserts.Equal(t, metrics.PortScrapeSettings{Port: &port, Interval: portInterval, ScrapeTimeout: timeout}, settings[0])
+	asserts.Equal(t, metrics.PortScrapeSettings{Port: &defaultPort, Interval: interval, ScrapeTimeout: timeout}, settings[1])
+
+	// GIVEN a trait with relabelings and metrics to drop
+	// WHEN the metric relabel configs are created
+	// THEN the drop rules follow the relabelings
+	configs := getMetricRelabelConfigs(trait)
+	asserts.Len(t, configs, 2)
+	asserts.Equal(t, []promoperapi.LabelName{"pod", "container"}, configs[0].SourceLabels)
+	asserts.Equal(t, "instance", configs[0].TargetLabel)
+	asserts.Equal(t, "drop", configs[1].Action)
+	asserts.Equal(t, "go_gc_.*", configs[1].Regex)
+	asserts.Equal(t, []promoperapi.LabelName{"__name__"}, configs[1].SourceLabels)
+
+	// GIVEN a trait with a TLS configuration
+	// WHEN the TLS config is created
+	// THEN the CA secret and server name are set
+	tls := getSafeTLSConfig(trait)
+	asserts.Equal(t, &caKey, tls.CA.Secret)
+	asserts.Nil(t, tls.Cert.Secret)
+	asserts.Equal(t, "hello.example.com", tls.ServerName)
+	asserts.Nil(t, getSafeTLSConfig(&vzapi.MetricsTrait{}))
+
+	// GIVEN traits with and without the PodMonitor type
+	// WHEN the monitor kind is determined
+	// THEN the kind matches the monitor type
+	podMonitorType := vzapi.PodMonitorType
+	asserts.Equal(t, promoperapi.ServiceMonitorsKind, getMonitorKind(trait))
+	asserts.Equal(t, promoperapi.PodMonitorsKind, getMonitorKind(&vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{MonitorType: &podMonitorType}}))
+}
+
 // TestUpdateRelatedStatefulSet tests the updateRelatedStatefulSet func call
 // GIVEN metricstrait, workload and child resources
 // WHEN updateRelatedPod func call is made by the reconciler
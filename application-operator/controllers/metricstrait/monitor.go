@@ -13,12 +13,13 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/internal/metrics"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// updateServiceMonitor creates or updates a service monitor given the trait and workload parameters
-// A service monitor emulates a scrape config for Prometheus with the Prometheus Operator
-func (r *Reconciler) updateServiceMonitor(ctx context.Context, trait *vzapi.MetricsTrait, workload *unstructured.Unstructured, traitDefaults *vzapi.MetricsTraitSpec, log vzlog.VerrazzanoLogger) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
+// updateMonitor creates or updates a Service Monitor or Pod Monitor given the trait and workload parameters
+// A monitor emulates a scrape config for Prometheus with the Prometheus Operator
+func (r *Reconciler) updateMonitor(ctx context.Context, trait *vzapi.MetricsTrait, workload *unstructured.Unstructured, traitDefaults *vzapi.MetricsTraitSpec, log vzlog.VerrazzanoLogger) (vzapi.QualifiedResourceRelation, controllerutil.OperationResult, error) {
 	var rel vzapi.QualifiedResourceRelation
 
 	// If the metricsTrait is being disabled then return nil for the config
@@ -26,7 +27,7 @@ func (r *Reconciler) updateServiceMonitor(ctx context.Context, trait *vzapi.Metr
 		return rel, controllerutil.OperationResultNone, nil
 	}
 
-	// Creating a service monitor with name and namespace
+	// Creating a monitor with name and namespace
 	pmName, err := createServiceMonitorName(trait, 0)
 	if err != nil {
 		return rel, controllerutil.OperationResultNone, log.ErrorfNewErr("Failed to create Service Monitor name: %v", err)
@@ -50,13 +51,21 @@ func (r *Reconciler) updateServiceMonitor(ctx context.Context, trait *vzapi.Metr
 	}
 	vzPromLabels := !wlsWorkload
 
-	log.Debugf("Creating or updating the Service Monitor for workload %s/%s", workload.GetNamespace(), workload.GetName())
+	log.Debugf("Creating or updating the monitor for workload %s/%s", workload.GetNamespace(), workload.GetName())
+	portSpecs := getPortSpecs(trait, traitDefaults)
 	scrapeInfo := metrics.ScrapeInfo{
-		Ports:              len(getPortSpecs(trait, traitDefaults)),
-		BasicAuthSecret:    secret,
-		IstioEnabled:       &useHTTPS,
-		VZPrometheusLabels: &vzPromLabels,
-		ClusterName:        clusters.GetClusterName(ctx, r.Client),
+		Ports:                len(portSpecs),
+		BasicAuthSecret:      secret,
+		IstioEnabled:         &useHTTPS,
+		VZPrometheusLabels:   &vzPromLabels,
+		ClusterName:          clusters.GetClusterName(ctx, r.Client),
+		PortSettings:         getPortScrapeSettings(trait, traitDefaults, portSpecs),
+		MetricRelabelConfigs: getMetricRelabelConfigs(trait),
+		TLSConfig:            getSafeTLSConfig(trait),
+		BearerTokenSecret:    trait.Spec.BearerTokenSecret,
+	}
+	if trait.Spec.OpenTelemetryCollector != nil {
+		scrapeInfo.OpenTelemetryCollector = *trait.Spec.OpenTelemetryCollector
 	}
 
 	// Fill in the scrape info if it is populated in the trait
@@ -70,20 +79,133 @@ func (r *Reconciler) updateServiceMonitor(ctx context.Context, trait *vzapi.Metr
 		"__meta_kubernetes_pod_label_app_oam_dev_component": trait.Labels[oam.LabelAppComponent],
 	}
 
-	serviceMonitor := promoperapi.ServiceMonitor{}
-	serviceMonitor.SetName(pmName)
-	serviceMonitor.SetNamespace(workload.GetNamespace())
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, &serviceMonitor, func() error {
-		return metrics.PopulateServiceMonitor(scrapeInfo, &serviceMonitor, log)
-	})
+	var monitor client.Object
+	var mutate func() error
+	if getMonitorKind(trait) == promoperapi.PodMonitorsKind {
+		podMonitor := &promoperapi.PodMonitor{}
+		monitor = podMonitor
+		mutate = func() error { return metrics.PopulatePodMonitor(scrapeInfo, podMonitor, log) }
+	} else {
+		serviceMonitor := &promoperapi.ServiceMonitor{}
+		monitor = serviceMonitor
+		mutate = func() error { return metrics.PopulateServiceMonitor(scrapeInfo, serviceMonitor, log) }
+	}
+	monitor.SetName(pmName)
+	monitor.SetNamespace(workload.GetNamespace())
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, monitor, mutate)
 	if err != nil {
-		return rel, controllerutil.OperationResultNone, log.ErrorfNewErr("Failed to create or update the service monitor for workload %s/%s: %v", workload.GetNamespace(), workload.GetName(), err)
+		return rel, controllerutil.OperationResultNone, log.ErrorfNewErr("Failed to create or update the monitor for workload %s/%s: %v", workload.GetNamespace(), workload.GetName(), err)
 	}
 
-	rel = vzapi.QualifiedResourceRelation{APIVersion: promoperapi.SchemeGroupVersion.String(), Kind: promoperapi.ServiceMonitorsKind, Namespace: serviceMonitor.Namespace, Name: serviceMonitor.Name, Role: scraperRole}
+	rel = vzapi.QualifiedResourceRelation{APIVersion: promoperapi.SchemeGroupVersion.String(), Kind: getMonitorKind(trait), Namespace: monitor.GetNamespace(), Name: monitor.GetName(), Role: scraperRole}
 	return rel, result, nil
 }
 
+// deleteMonitor deletes a Service Monitor or Pod Monitor that is no longer related to the trait, for example,
+// after the monitor type of the trait has changed
+func (r *Reconciler) deleteMonitor(ctx context.Context, rel vzapi.QualifiedResourceRelation, trait *vzapi.MetricsTrait, log vzlog.VerrazzanoLogger) (controllerutil.OperationResult, error) {
+	if rel.Kind == getMonitorKind(trait) {
+		if rel.Kind == promoperapi.ServiceMonitorsKind {
+			return r.deleteServiceMonitor(ctx, rel.Namespace, rel.Name, trait, log)
+		}
+		if trait.DeletionTimestamp.IsZero() && isEnabled(trait) {
+			log.Debugf("Maintaining Pod Monitor name: %s namespace: %s because the trait is enabled and not in the deletion process", rel.Name, rel.Namespace)
+			return controllerutil.OperationResultNone, nil
+		}
+	}
+
+	log.Debugf("Deleting %s name: %s namespace: %s from resource relation", rel.Kind, rel.Name, rel.Namespace)
+	var monitor client.Object = &promoperapi.ServiceMonitor{}
+	if rel.Kind == promoperapi.PodMonitorsKind {
+		monitor = &promoperapi.PodMonitor{}
+	}
+	monitor.SetName(rel.Name)
+	monitor.SetNamespace(rel.Namespace)
+	if err := r.Delete(ctx, monitor); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// getMonitorKind returns the kind of Prometheus Operator monitor created for the trait
+func getMonitorKind(trait *vzapi.MetricsTrait) string {
+	if trait.Spec.MonitorType != nil && *trait.Spec.MonitorType == vzapi.PodMonitorType {
+		return promoperapi.PodMonitorsKind
+	}
+	return promoperapi.ServiceMonitorsKind
+}
+
+// getPortScrapeSettings returns the scrape settings of each port. The settings of a port override the settings
+// of the trait.
+func getPortScrapeSettings(trait *vzapi.MetricsTrait, traitDefaults *vzapi.MetricsTraitSpec, portSpecs []vzapi.PortSpec) []metrics.PortScrapeSettings {
+	var settings []metrics.PortScrapeSettings
+	for _, portSpec := range portSpecs {
+		port := portSpec.Port
+		if port == nil && len(traitDefaults.Ports) > 0 {
+			port = traitDefaults.Ports[0].Port
+		}
+		settings = append(settings, metrics.PortScrapeSettings{
+			Port:          port,
+			Interval:      stringValueOrDefault(portSpec.Interval, trait.Spec.Interval),
+			ScrapeTimeout: stringValueOrDefault(portSpec.ScrapeTimeout, trait.Spec.ScrapeTimeout),
+		})
+	}
+	return settings
+}
+
+// getMetricRelabelConfigs converts the metric relabelings and drop rules of the trait to Prometheus Operator
+// relabel configs. The drop rules are applied after the relabelings.
+func getMetricRelabelConfigs(trait *vzapi.MetricsTrait) []*promoperapi.RelabelConfig {
+	var configs []*promoperapi.RelabelConfig
+	for _, relabeling := range trait.Spec.MetricRelabelings {
+		config := &promoperapi.RelabelConfig{
+			Separator:   relabeling.Separator,
+			TargetLabel: relabeling.TargetLabel,
+			Regex:       relabeling.Regex,
+			Replacement: relabeling.Replacement,
+			Action:      relabeling.Action,
+		}
+		for _, label := range relabeling.SourceLabels {
+			config.SourceLabels = append(config.SourceLabels, promoperapi.LabelName(label))
+		}
+		configs = append(configs, config)
+	}
+	for _, metric := range trait.Spec.DropMetrics {
+		configs = append(configs, &promoperapi.RelabelConfig{
+			Action:       "drop",
+			Regex:        metric,
+			SourceLabels: []promoperapi.LabelName{"__name__"},
+		})
+	}
+	return configs
+}
+
+// getSafeTLSConfig converts the TLS configuration of the trait to a Prometheus Operator TLS configuration
+func getSafeTLSConfig(trait *vzapi.MetricsTrait) *promoperapi.SafeTLSConfig {
+	tls := trait.Spec.TLS
+	if tls == nil {
+		return nil
+	}
+	return &promoperapi.SafeTLSConfig{
+		CA:                 promoperapi.SecretOrConfigMap{Secret: tls.CASecret},
+		Cert:               promoperapi.SecretOrConfigMap{Secret: tls.CertSecret},
+		KeySecret:          tls.KeySecret,
+		ServerName:         tls.ServerName,
+		InsecureSkipVerify: tls.InsecureSkipVerify,
+	}
+}
+
+// stringValueOrDefault returns the value if it is set, otherwise the default value if it is set
+func stringValueOrDefault(value *string, defaultValue *string) string {
+	if value != nil {
+		return *value
+	}
+	if defaultValue != nil {
+		return *defaultValue
+	}
+	return ""
+}
+
 // deleteServiceMonitor deletes the object responsible for transporting metrics from the source to Prometheus
 func (r *Reconciler) deleteServiceMonitor(ctx context.Context, namespace string, name string, trait *vzapi.MetricsTrait, log vzlog.VerrazzanoLogger) (controllerutil.OperationResult, error) {
 	if trait.DeletionTimestamp.IsZero() && isEnabled(trait) {
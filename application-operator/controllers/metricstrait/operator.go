@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package metricstrait
@@ -70,19 +70,19 @@ func (r *Reconciler) reconcileOperatorTraitCreateOrUpdate(ctx context.Context, t
 
 	var opResult controllerutil.OperationResult
 	var rel vzapi.QualifiedResourceRelation
-	// update the ServiceMonitor if trait is enabled, delete it if trait is disabled
+	// update the monitor if trait is enabled, delete it if trait is disabled
 	if isEnabled(trait) {
-		rel, opResult, err = r.updateServiceMonitor(ctx, trait, workload, traitDefaults, log)
+		rel, opResult, err = r.updateMonitor(ctx, trait, workload, traitDefaults, log)
 	} else {
 		serviceMonitorName, err := createServiceMonitorName(trait, 0)
 		if err != nil {
 			return reconcile.Result{}, log.ErrorfNewErr("Failed to create Service Monitor name: %v", err)
 		}
-		opResult, err = r.deleteServiceMonitor(ctx, trait.Namespace, serviceMonitorName, trait, log)
+		rel = vzapi.QualifiedResourceRelation{APIVersion: promoperapi.SchemeGroupVersion.String(), Kind: getMonitorKind(trait), Namespace: trait.Namespace, Name: serviceMonitorName, Role: scraperRole}
+		opResult, err = r.deleteMonitor(ctx, rel, trait, log)
 		if client.IgnoreNotFound(err) != nil {
-			return reconcile.Result{}, log.ErrorfNewErr("Failed to delete Service Monitor %s for disabled metrics trait: %v", serviceMonitorName, err)
+			return reconcile.Result{}, log.ErrorfNewErr("Failed to delete %s %s for disabled metrics trait: %v", rel.Kind, serviceMonitorName, err)
 		}
-		rel = vzapi.QualifiedResourceRelation{APIVersion: promoperapi.SchemeGroupVersion.String(), Kind: promoperapi.ServiceMonitorsKind, Namespace: trait.Namespace, Name: serviceMonitorName, Role: scraperRole}
 	}
 	status.RecordOutcome(rel, opResult, err)
 
@@ -0,0 +1,354 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package netpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	"github.com/verrazzano/verrazzano/application-operator/controllers"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzlogInit "github.com/verrazzano/verrazzano/pkg/log"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"go.uber.org/zap"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	controllerName = "netpolicy"
+
+	// Modes of the generated network policies of an application
+	ModeEnforce  = "enforce"
+	ModeAudit    = "audit"
+	ModeDisabled = "disabled"
+
+	// The services and target ports of an application are not watched, so the policies are reconciled periodically
+	reconcileInterval = 2 * time.Minute
+
+	auditConfigMapSuffix = "-network-policies"
+)
+
+// errNotGenerated is returned when a resource with the name of a generated policy was not created by this controller
+var errNotGenerated = errors.New("resource was not generated for the application")
+
+// Reconciler generates the NetworkPolicies and Istio AuthorizationPolicies of the components of an ApplicationConfiguration
+type Reconciler struct {
+	client.Client
+	Log    *zap.SugaredLogger
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager registers our controller with the manager.  The application is reconciled when the ingress
+// or metrics traits of its components change.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(controller.Options{
+			RateLimiter: controllers.NewDefaultRateLimiter(),
+		}).
+		For(&oamv1.ApplicationConfiguration{}).
+		Owns(&netv1.NetworkPolicy{}).
+		Watches(&source.Kind{Type: &vzapi.IngressTrait{}}, handler.EnqueueRequestsFromMapFunc(appConfigRequestForTrait)).
+		Watches(&source.Kind{Type: &vzapi.MetricsTrait{}}, handler.EnqueueRequestsFromMapFunc(appConfigRequestForTrait)).
+		Complete(r)
+}
+
+// appConfigRequestForTrait maps a trait to a request for the application configuration of the trait
+func appConfigRequestForTrait(trait client.Object) []reconcile.Request {
+	appName := trait.GetLabels()[oam.LabelAppName]
+	if appName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: trait.GetNamespace(), Name: appName}}}
+}
+
+// Reconcile generates least-privilege NetworkPolicies and Istio AuthorizationPolicies for the components of an
+// ApplicationConfiguration from its ingress and metrics traits.  Depending on the mode of the application, the
+// policies are applied, rendered into a ConfigMap for review, or removed.
+// +kubebuilder:rbac:groups=core.oam.dev,resources=applicationconfigurations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, errors.New("context cannot be nil")
+	}
+
+	// We do not want any resource to get reconciled if it is in namespace kube-system
+	// This is due to a bug found in OKE, it should not affect functionality of any vz operators
+	// If this is the case then return success
+	if req.Namespace == vzconst.KubeSystem {
+		log := zap.S().With(vzlogInit.FieldResourceNamespace, req.Namespace, vzlogInit.FieldResourceName, req.Name, vzlogInit.FieldController, controllerName)
+		log.Infof("Application configuration resource %v should not be reconciled in kube-system namespace, ignoring", req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	var appConfig oamv1.ApplicationConfiguration
+	if err := r.Get(ctx, req.NamespacedName, &appConfig); err != nil {
+		return clusters.IgnoreNotFoundWithLog(err, zap.S())
+	}
+	// The generated resources are owned by the application configuration and garbage collected with it
+	if !appConfig.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	log, err := clusters.GetResourceLogger(controllerName, req.NamespacedName, &appConfig)
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for application configuration resource: %v", err)
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	log.Oncef("Reconciling network policies of application configuration resource %v, generation %v", req.NamespacedName, appConfig.Generation)
+
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err := r.doReconcile(ctx, &appConfig, log); err != nil {
+		return clusters.NewRequeueWithDelay(), nil
+	}
+	return reconcile.Result{RequeueAfter: reconcileInterval}, nil
+}
+
+// doReconcile applies the policies of the application in its mode
+func (r *Reconciler) doReconcile(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, log vzlog.VerrazzanoLogger) error {
+	mode, err := r.getMode(ctx, appConfig, log)
+	if err != nil {
+		return err
+	}
+
+	if mode == ModeDisabled {
+		if err := r.deleteStalePolicies(ctx, appConfig, nil, log); err != nil {
+			return err
+		}
+		return r.deleteAuditConfigMap(ctx, appConfig, log)
+	}
+
+	components, err := r.collectComponentPorts(ctx, appConfig)
+	if err != nil {
+		log.Errorf("Failed to collect the ports of the components of application configuration %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		return err
+	}
+
+	if mode == ModeAudit {
+		if err := r.deleteStalePolicies(ctx, appConfig, nil, log); err != nil {
+			return err
+		}
+		return r.createOrUpdateAuditConfigMap(ctx, appConfig, components, log)
+	}
+
+	desired := map[string]bool{}
+	for _, c := range components {
+		netPolicy := newNetworkPolicy(appConfig, c)
+		desired[policyKey(netPolicy)] = true
+		if err := r.createOrUpdateNetworkPolicy(ctx, appConfig, netPolicy, log); err != nil {
+			return err
+		}
+		authzPolicy := newAuthorizationPolicy(appConfig, c)
+		if authzPolicy == nil {
+			continue
+		}
+		desired[policyKey(authzPolicy)] = true
+		if err := r.createOrUpdateAuthorizationPolicy(ctx, appConfig, authzPolicy, log); err != nil {
+			return err
+		}
+	}
+	if err := r.deleteStalePolicies(ctx, appConfig, desired, log); err != nil {
+		return err
+	}
+	return r.deleteAuditConfigMap(ctx, appConfig, log)
+}
+
+// getMode returns the mode of the generated policies of an application from the annotation of the application
+// configuration, or else of its namespace.  The policies are audited by default so that upgrading the operator does not
+// cut the traffic of running applications, they are only enforced when the application or its namespace opts in.
+func (r *Reconciler) getMode(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, log vzlog.VerrazzanoLogger) (string, error) {
+	mode, ok := appConfig.Annotations[constants.NetworkPolicyModeAnnotation]
+	if !ok {
+		ns := corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: appConfig.Namespace}, &ns); err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorf("Failed to fetch namespace %s: %v", appConfig.Namespace, err)
+			return "", err
+		}
+		mode = ns.Annotations[constants.NetworkPolicyModeAnnotation]
+	}
+	switch mode {
+	case ModeEnforce, ModeAudit, ModeDisabled:
+		return mode, nil
+	case "":
+		return ModeAudit, nil
+	}
+	log.Progressf("Invalid value %s of annotation %s of application configuration %s/%s, the network policies are audited",
+		mode, constants.NetworkPolicyModeAnnotation, appConfig.Namespace, appConfig.Name)
+	return ModeAudit, nil
+}
+
+// createOrUpdateNetworkPolicy creates or updates the NetworkPolicy of a component.  A NetworkPolicy with the same
+// name that was not generated for the application is left unchanged.
+func (r *Reconciler) createOrUpdateNetworkPolicy(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, desired *netv1.NetworkPolicy, log vzlog.VerrazzanoLogger) error {
+	policy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: desired.Namespace, Name: desired.Name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if err := checkGenerated(policy, appConfig); err != nil {
+			return err
+		}
+		policy.Labels = desired.Labels
+		policy.Spec = desired.Spec
+		return controllerutil.SetControllerReference(appConfig, policy, r.Scheme)
+	})
+	return r.handleCreateOrUpdateError(err, "NetworkPolicy", policy, log)
+}
+
+// createOrUpdateAuthorizationPolicy creates or updates the AuthorizationPolicy of a component.  An
+// AuthorizationPolicy with the same name that was not generated for the application is left unchanged.
+func (r *Reconciler) createOrUpdateAuthorizationPolicy(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, desired *clisecurity.AuthorizationPolicy, log vzlog.VerrazzanoLogger) error {
+	policy := &clisecurity.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: desired.Namespace, Name: desired.Name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if err := checkGenerated(policy, appConfig); err != nil {
+			return err
+		}
+		policy.Labels = desired.Labels
+		setAuthorizationPolicySpec(policy, appConfig.Name, desired.Labels[oam.LabelAppComponent], desired.Spec.Rules)
+		return controllerutil.SetControllerReference(appConfig, policy, r.Scheme)
+	})
+	return r.handleCreateOrUpdateError(err, "AuthorizationPolicy", policy, log)
+}
+
+// checkGenerated returns an error if an existing resource was not generated for the application
+func checkGenerated(obj client.Object, appConfig *oamv1.ApplicationConfiguration) error {
+	if obj.GetResourceVersion() == "" {
+		return nil
+	}
+	if obj.GetLabels()[constants.NetworkPolicyAppLabel] != appConfig.Name {
+		return errNotGenerated
+	}
+	return nil
+}
+
+// handleCreateOrUpdateError logs the error of the create or update of a generated resource
+func (r *Reconciler) handleCreateOrUpdateError(err error, kind string, obj client.Object, log vzlog.VerrazzanoLogger) error {
+	if errors.Is(err, errNotGenerated) {
+		log.Progressf("%s %s/%s already exists and was not generated for the application, leaving it unchanged", kind, obj.GetNamespace(), obj.GetName())
+		return nil
+	}
+	if err != nil {
+		log.Errorf("Failed to create or update %s %s/%s: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+		return err
+	}
+	return nil
+}
+
+// deleteStalePolicies deletes the policies generated for the application that are not desired, all of them if
+// desired is nil
+func (r *Reconciler) deleteStalePolicies(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, desired map[string]bool, log vzlog.VerrazzanoLogger) error {
+	selector := []client.ListOption{client.InNamespace(appConfig.Namespace), client.MatchingLabels{constants.NetworkPolicyAppLabel: appConfig.Name}}
+
+	netPolicies := netv1.NetworkPolicyList{}
+	if err := r.List(ctx, &netPolicies, selector...); err != nil {
+		log.Errorf("Failed to list the network policies of application configuration %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		return err
+	}
+	for i := range netPolicies.Items {
+		if err := r.deleteIfStale(ctx, &netPolicies.Items[i], desired, log); err != nil {
+			return err
+		}
+	}
+
+	authzPolicies := clisecurity.AuthorizationPolicyList{}
+	if err := r.List(ctx, &authzPolicies, selector...); err != nil {
+		log.Errorf("Failed to list the authorization policies of application configuration %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		return err
+	}
+	for i := range authzPolicies.Items {
+		if err := r.deleteIfStale(ctx, authzPolicies.Items[i], desired, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyKey returns the key of a generated policy in the set of desired policies
+func policyKey(obj client.Object) string {
+	return fmt.Sprintf("%T/%s", obj, obj.GetName())
+}
+
+// deleteIfStale deletes a generated policy if it is not desired
+func (r *Reconciler) deleteIfStale(ctx context.Context, obj client.Object, desired map[string]bool, log vzlog.VerrazzanoLogger) error {
+	if desired[policyKey(obj)] {
+		return nil
+	}
+	log.Debugf("Deleting generated policy %s/%s", obj.GetNamespace(), obj.GetName())
+	if err := r.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		log.Errorf("Failed to delete generated policy %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		return err
+	}
+	return nil
+}
+
+// createOrUpdateAuditConfigMap renders the policies that would be applied to the application into a ConfigMap, so
+// that they can be reviewed before they are enforced
+func (r *Reconciler) createOrUpdateAuditConfigMap(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, components []*componentPorts, log vzlog.VerrazzanoLogger) error {
+	data := map[string]string{}
+	for _, c := range components {
+		netPolicy, err := yaml.Marshal(newNetworkPolicy(appConfig, c))
+		if err != nil {
+			return err
+		}
+		data[c.name+"-networkpolicy.yaml"] = string(netPolicy)
+		if authzPolicy := newAuthorizationPolicy(appConfig, c); authzPolicy != nil {
+			rendered, err := yaml.Marshal(authzPolicy)
+			if err != nil {
+				return err
+			}
+			data[c.name+"-authorizationpolicy.yaml"] = string(rendered)
+		}
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: appConfig.Namespace, Name: appConfig.Name + auditConfigMapSuffix}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if err := checkGenerated(cm, appConfig); err != nil {
+			return err
+		}
+		cm.Labels = map[string]string{constants.NetworkPolicyAppLabel: appConfig.Name, oam.LabelAppName: appConfig.Name}
+		cm.Data = data
+		return controllerutil.SetControllerReference(appConfig, cm, r.Scheme)
+	})
+	return r.handleCreateOrUpdateError(err, "ConfigMap", cm, log)
+}
+
+// deleteAuditConfigMap deletes the ConfigMap of the rendered policies of an application
+func (r *Reconciler) deleteAuditConfigMap(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, log vzlog.VerrazzanoLogger) error {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: appConfig.Namespace, Name: appConfig.Name + auditConfigMapSuffix}, cm)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		log.Errorf("Failed to fetch the network policies ConfigMap of application configuration %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		return err
+	}
+	if cm.Labels[constants.NetworkPolicyAppLabel] != appConfig.Name {
+		return nil
+	}
+	if err := r.Delete(ctx, cm); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package netpolicy
+
+import (
+	"context"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	asserts "github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"go.uber.org/zap"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace      = "test-ns"
+	testAppName        = "hello-app"
+	testFrontend       = "frontend"
+	testBackend        = "backend"
+	testIngressTrait   = "frontend-ingress"
+	testMetricsTrait   = "backend-metrics"
+	testServiceName    = "frontend-svc"
+	frontendPolicy     = testAppName + "-" + testFrontend
+	backendPolicy      = testAppName + "-" + testBackend
+	testAuditConfigMap = testAppName + auditConfigMapSuffix
+)
+
+// newScheme creates a scheme with the types used by the controller
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = oamcore.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = clisecurity.AddToScheme(scheme)
+	return scheme
+}
+
+// newTestAppConfig creates an application configuration with a frontend component exposed by an ingress trait
+// and a backend component scraped for a metrics trait
+func newTestAppConfig(annotations map[string]string) *oamv1.ApplicationConfiguration {
+	return &oamv1.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName, Annotations: annotations},
+		Status: oamv1.ApplicationConfigurationStatus{
+			Workloads: []oamv1.WorkloadStatus{
+				{
+					ComponentName: testFrontend,
+					Reference:     oamrt.TypedReference{APIVersion: "oam.verrazzano.io/v1alpha1", Kind: "VerrazzanoHelidonWorkload", Name: testFrontend},
+					Traits: []oamv1.WorkloadTrait{{
+						Reference: oamrt.TypedReference{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: ingressTraitKind, Name: testIngressTrait},
+					}},
+				},
+				{
+					ComponentName: testBackend,
+					Reference:     oamrt.TypedReference{APIVersion: "core.oam.dev/v1alpha2", Kind: "ContainerizedWorkload", Name: testBackend},
+					Traits: []oamv1.WorkloadTrait{{
+						Reference: oamrt.TypedReference{APIVersion: vzapi.SchemeGroupVersion.String(), Kind: vzapi.MetricsTraitKind, Name: testMetricsTrait},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// newTestIngressTrait creates an ingress trait routing to port 80 of the frontend service
+func newTestIngressTrait() *vzapi.IngressTrait {
+	return &vzapi.IngressTrait{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testIngressTrait, Labels: map[string]string{oam.LabelAppName: testAppName}},
+		Spec: vzapi.IngressTraitSpec{
+			Rules: []vzapi.IngressRule{{Destination: vzapi.IngressDestination{Host: testServiceName, Port: 80}}},
+		},
+	}
+}
+
+// newTestService creates the frontend service with port 80 targeting port 8080 of the pods
+func newTestService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testServiceName},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+}
+
+// newTestMetricsTrait creates a metrics trait scraping port 9090
+func newTestMetricsTrait() *vzapi.MetricsTrait {
+	port := 9090
+	return &vzapi.MetricsTrait{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testMetricsTrait, Labels: map[string]string{oam.LabelAppName: testAppName}},
+		Spec:       vzapi.MetricsTraitSpec{Port: &port},
+	}
+}
+
+// reconcileTestAppConfig reconciles the test application configuration and returns the client
+func reconcileTestAppConfig(t *testing.T, objs ...client.Object) (ctrl.Result, client.Client) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build()
+	reconciler := Reconciler{Client: cli, Log: zap.S(), Scheme: newScheme()}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testAppName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(t, err)
+	return result, cli
+}
+
+// TestReconcileEnforce tests the generation of the policies of an application
+// GIVEN an application with a component exposed by an ingress trait and a component with a metrics trait
+// WHEN the application configuration is reconciled
+// THEN a NetworkPolicy and a DENY AuthorizationPolicy are created for each component, restricting the ingress
+// gateway to the target port of the ingress trait and Prometheus to the metrics ports
+func TestReconcileEnforce(t *testing.T) {
+	assert := asserts.New(t)
+	result, cli := reconcileTestAppConfig(t, newTestAppConfig(map[string]string{constants.NetworkPolicyModeAnnotation: ModeEnforce}), newTestIngressTrait(), newTestService(), newTestMetricsTrait())
+	assert.Equal(reconcileInterval, result.RequeueAfter)
+
+	// The frontend accepts the gateway on the target port 8080 and Prometheus on the Envoy port only
+	policy := netv1.NetworkPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: frontendPolicy}, &policy))
+	assert.Equal(testAppName, policy.Labels[constants.NetworkPolicyAppLabel])
+	assert.Equal(map[string]string{oam.LabelAppName: testAppName, oam.LabelAppComponent: testFrontend}, policy.Spec.PodSelector.MatchLabels)
+	assert.Len(policy.OwnerReferences, 1)
+	assert.Len(policy.Spec.Ingress, 3)
+	assert.Equal(map[string]string{oam.LabelAppName: testAppName}, policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+	assert.Empty(policy.Spec.Ingress[0].Ports)
+	assert.Equal(constants.IstioSystemNamespace, policy.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels[constants.LabelVerrazzanoNamespace])
+	assert.Len(policy.Spec.Ingress[1].Ports, 1)
+	assert.Equal(intstr.FromInt(8080), *policy.Spec.Ingress[1].Ports[0].Port)
+	assert.Equal(vzconst.VerrazzanoMonitoringNamespace, policy.Spec.Ingress[2].From[0].NamespaceSelector.MatchLabels[constants.LabelVerrazzanoNamespace])
+	assert.Len(policy.Spec.Ingress[2].Ports, 1)
+	assert.Equal(intstr.FromInt(envoyStatsPort), *policy.Spec.Ingress[2].Ports[0].Port)
+
+	authzPolicy := clisecurity.AuthorizationPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: frontendPolicy}, &authzPolicy))
+	assert.Equal(securityv1beta1.AuthorizationPolicy_DENY, authzPolicy.Spec.Action)
+	assert.Len(authzPolicy.Spec.Rules, 2)
+	assert.Equal([]string{gatewayPrincipal}, authzPolicy.Spec.Rules[0].From[0].Source.Principals)
+	assert.Equal([]string{"8080"}, authzPolicy.Spec.Rules[0].To[0].Operation.NotPorts)
+	assert.Equal([]string{"15090"}, authzPolicy.Spec.Rules[1].To[0].Operation.NotPorts)
+
+	// The backend is not exposed, so the gateway is denied on all ports, Prometheus may scrape port 9090
+	policy = netv1.NetworkPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: backendPolicy}, &policy))
+	assert.Len(policy.Spec.Ingress, 2)
+	assert.Len(policy.Spec.Ingress[1].Ports, 2)
+	assert.Equal(intstr.FromInt(9090), *policy.Spec.Ingress[1].Ports[0].Port)
+
+	authzPolicy = clisecurity.AuthorizationPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: backendPolicy}, &authzPolicy))
+	assert.Len(authzPolicy.Spec.Rules, 2)
+	assert.Empty(authzPolicy.Spec.Rules[0].To)
+	assert.Equal([]string{promPrincipal, promOperatorPrincipal}, authzPolicy.Spec.Rules[1].From[0].Source.Principals)
+	assert.Equal([]string{"9090", "15090"}, authzPolicy.Spec.Rules[1].To[0].Operation.NotPorts)
+}
+
+// TestReconcileStalePolicies tests the cleanup of the policies of removed components
+// GIVEN generated policies of a component that is no longer in the application, and a NetworkPolicy that was
+// not generated for the application
+// WHEN the application configuration is reconciled
+// THEN the generated policies of the removed component are deleted and the other NetworkPolicy is left unchanged
+func TestReconcileStalePolicies(t *testing.T) {
+	assert := asserts.New(t)
+	stale := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName + "-removed", Labels: policyLabels(testAppName, "removed")}}
+	staleAuthz := &clisecurity.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName + "-removed", Labels: policyLabels(testAppName, "removed")}}
+	userPolicy := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: frontendPolicy}}
+	_, cli := reconcileTestAppConfig(t, newTestAppConfig(map[string]string{constants.NetworkPolicyModeAnnotation: ModeEnforce}), newTestIngressTrait(), newTestService(), newTestMetricsTrait(), stale, staleAuthz, userPolicy)
+
+	err := cli.Get(context.TODO(), client.ObjectKeyFromObject(stale), &netv1.NetworkPolicy{})
+	assert.True(k8serrors.IsNotFound(err))
+	err = cli.Get(context.TODO(), client.ObjectKeyFromObject(staleAuthz), &clisecurity.AuthorizationPolicy{})
+	assert.True(k8serrors.IsNotFound(err))
+
+	policy := netv1.NetworkPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(userPolicy), &policy))
+	assert.Empty(policy.Labels)
+	assert.Empty(policy.Spec.Ingress)
+}
+
+// TestReconcileAudit tests the audit mode
+// GIVEN an application annotated with the audit mode that has generated policies
+// WHEN the application configuration is reconciled
+// THEN the policies are deleted and rendered into a ConfigMap
+func TestReconcileAudit(t *testing.T) {
+	assert := asserts.New(t)
+	generated := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: frontendPolicy, Labels: policyLabels(testAppName, testFrontend)}}
+	appConfig := newTestAppConfig(map[string]string{constants.NetworkPolicyModeAnnotation: ModeAudit})
+	_, cli := reconcileTestAppConfig(t, appConfig, newTestIngressTrait(), newTestService(), newTestMetricsTrait(), generated)
+
+	err := cli.Get(context.TODO(), client.ObjectKeyFromObject(generated), &netv1.NetworkPolicy{})
+	assert.True(k8serrors.IsNotFound(err))
+
+	cm := corev1.ConfigMap{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testAuditConfigMap}, &cm))
+	assert.Len(cm.Data, 4)
+	assert.Contains(cm.Data[testFrontend+"-networkpolicy.yaml"], "kind: NetworkPolicy")
+	assert.Contains(cm.Data[testFrontend+"-authorizationpolicy.yaml"], "action: DENY")
+	assert.Contains(cm.Data[testBackend+"-authorizationpolicy.yaml"], "\"9090\"")
+}
+
+// TestReconcileDefaultAudit tests the default mode
+// GIVEN an application without the mode annotation in a namespace without the mode annotation
+// WHEN the application configuration is reconciled
+// THEN no policies are created and the policies are rendered into a ConfigMap
+func TestReconcileDefaultAudit(t *testing.T) {
+	assert := asserts.New(t)
+	_, cli := reconcileTestAppConfig(t, newTestAppConfig(nil), newTestIngressTrait(), newTestService(), newTestMetricsTrait())
+
+	policies := netv1.NetworkPolicyList{}
+	assert.NoError(cli.List(context.TODO(), &policies))
+	assert.Empty(policies.Items)
+	authzPolicies := clisecurity.AuthorizationPolicyList{}
+	assert.NoError(cli.List(context.TODO(), &authzPolicies))
+	assert.Empty(authzPolicies.Items)
+
+	cm := corev1.ConfigMap{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testAuditConfigMap}, &cm))
+	assert.Len(cm.Data, 4)
+}
+
+// TestReconcileDisabled tests the disabled mode set on the namespace
+// GIVEN an application in a namespace annotated with the disabled mode that has generated resources
+// WHEN the application configuration is reconciled
+// THEN the generated resources are deleted and no policies are created
+func TestReconcileDisabled(t *testing.T) {
+	assert := asserts.New(t)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Annotations: map[string]string{constants.NetworkPolicyModeAnnotation: ModeDisabled}}}
+	generated := &clisecurity.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: frontendPolicy, Labels: policyLabels(testAppName, testFrontend)}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAuditConfigMap, Labels: map[string]string{constants.NetworkPolicyAppLabel: testAppName}}}
+	_, cli := reconcileTestAppConfig(t, ns, newTestAppConfig(nil), newTestIngressTrait(), newTestService(), newTestMetricsTrait(), generated, cm)
+
+	err := cli.Get(context.TODO(), client.ObjectKeyFromObject(generated), &clisecurity.AuthorizationPolicy{})
+	assert.True(k8serrors.IsNotFound(err))
+	err = cli.Get(context.TODO(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+	assert.True(k8serrors.IsNotFound(err))
+
+	policies := netv1.NetworkPolicyList{}
+	assert.NoError(cli.List(context.TODO(), &policies))
+	assert.Empty(policies.Items)
+}
+
+// TestReconcileKubeSystem tests that application configurations in the kube-system namespace are ignored
+// GIVEN an application configuration in the kube-system namespace
+// WHEN the application configuration is reconciled
+// THEN nothing is done
+func TestReconcileKubeSystem(t *testing.T) {
+	reconciler := Reconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).Build(), Log: zap.S()}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: vzconst.KubeSystem, Name: testAppName}}
+	result, err := reconciler.Reconcile(context.TODO(), request)
+	asserts.NoError(t, err)
+	asserts.True(t, result.IsZero())
+}
+
+// TestAddMetricsPorts tests the ports scraped for metrics traits
+// GIVEN metrics traits of different workloads
+// WHEN the metrics ports are added to a component
+// THEN the ports of the trait or the default ports of the workload are used
+func TestAddMetricsPorts(t *testing.T) {
+	assert := asserts.New(t)
+	port := 7070
+	disabled := false
+
+	c := &componentPorts{workloadKind: "ContainerizedWorkload"}
+	addMetricsPorts(c, &vzapi.MetricsTrait{})
+	assert.Equal([]int32{defaultScrapePort}, c.metricsPorts)
+
+	c = &componentPorts{workloadKind: coherenceWorkloadKind}
+	addMetricsPorts(c, &vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Port: &port, Ports: []vzapi.PortSpec{{}}}})
+	assert.Equal([]int32{7070, defaultCohScrapePort}, c.metricsPorts)
+
+	c = &componentPorts{workloadKind: weblogicWorkloadKind}
+	addMetricsPorts(c, &vzapi.MetricsTrait{})
+	assert.True(c.allMetricsPorts)
+	assert.Nil(newAuthorizationPolicy(newTestAppConfig(nil), &componentPorts{exposed: true, allIngressPorts: true, allMetricsPorts: true}))
+
+	c = &componentPorts{}
+	addMetricsPorts(c, &vzapi.MetricsTrait{Spec: vzapi.MetricsTraitSpec{Enabled: &disabled}})
+	assert.Empty(c.metricsPorts)
+}
+
+// TestNamedTargetPort tests an ingress destination targeting a named port
+// GIVEN an ingress trait whose service targets a named port of the pods
+// WHEN the policies of the component are created
+// THEN the NetworkPolicy allows the named port and the AuthorizationPolicy does not restrict the ports of the gateway
+func TestNamedTargetPort(t *testing.T) {
+	assert := asserts.New(t)
+	service := newTestService()
+	service.Spec.Ports[0].TargetPort = intstr.FromString("http")
+	_, cli := reconcileTestAppConfig(t, newTestAppConfig(map[string]string{constants.NetworkPolicyModeAnnotation: ModeEnforce}), newTestIngressTrait(), service, newTestMetricsTrait())
+
+	policy := netv1.NetworkPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: frontendPolicy}, &policy))
+	assert.Equal(intstr.FromString("http"), *policy.Spec.Ingress[1].Ports[0].Port)
+
+	authzPolicy := clisecurity.AuthorizationPolicy{}
+	assert.NoError(cli.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: frontendPolicy}, &authzPolicy))
+	assert.Len(authzPolicy.Spec.Rules, 1)
+	assert.Equal([]string{promPrincipal, promOperatorPrincipal}, authzPolicy.Spec.Rules[0].From[0].Source.Principals)
+}
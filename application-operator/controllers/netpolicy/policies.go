@@ -0,0 +1,390 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package netpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/crossplane/oam-kubernetes-runtime/pkg/oam"
+	vzapi "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
+	"github.com/verrazzano/verrazzano/application-operator/constants"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	clisecurity "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ingressTraitKind = "IngressTrait"
+
+	weblogicWorkloadKind  = "VerrazzanoWebLogicWorkload"
+	weblogicDomainKind    = "Domain"
+	coherenceWorkloadKind = "VerrazzanoCoherenceWorkload"
+	coherenceKind         = "Coherence"
+
+	// Default scrape ports of the metrics traits, see the metricstrait controller
+	defaultCohScrapePort = 9612
+	defaultScrapePort    = 8080
+
+	// The port on which Prometheus scrapes the Envoy metrics of the Istio sidecars
+	envoyStatsPort = 15090
+
+	gatewayPrincipal          = "cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account"
+	promPrincipal             = "cluster.local/ns/verrazzano-system/sa/verrazzano-monitoring-operator"
+	promOperatorPrincipal     = "cluster.local/ns/verrazzano-monitoring/sa/prometheus-operator-kube-p-prometheus"
+	istioIngressGatewayApp    = "istio-ingressgateway"
+	prometheusNameLabelValue  = "prometheus"
+	weblogicOperatorApp       = "weblogic-operator"
+	coherenceOperatorLabel    = "control-plane"
+	coherenceOperatorLabelVal = "coherence"
+)
+
+// componentPorts holds the ports of a component that are reached from outside of the application
+type componentPorts struct {
+	name         string
+	workloadKind string
+
+	// exposed is true if the component has an ingress trait, ingressPorts are the ports of the component pods to
+	// which the ingress gateway routes, all ports are allowed if allIngressPorts is true
+	exposed         bool
+	allIngressPorts bool
+	ingressPorts    []intstr.IntOrString
+
+	// metricsPorts are the ports of the component pods scraped by Prometheus for an enabled metrics trait, all
+	// ports are allowed if allMetricsPorts is true
+	allMetricsPorts bool
+	metricsPorts    []int32
+}
+
+// collectComponentPorts derives the ports of each component of an application from the ingress and metrics traits
+// of the component
+func (r *Reconciler) collectComponentPorts(ctx context.Context, appConfig *oamv1.ApplicationConfiguration) ([]*componentPorts, error) {
+	var components []*componentPorts
+	byName := map[string]*componentPorts{}
+	for _, workload := range appConfig.Status.Workloads {
+		if workload.ComponentName == "" {
+			continue
+		}
+		c := &componentPorts{name: workload.ComponentName, workloadKind: workload.Reference.Kind}
+		components = append(components, c)
+		byName[c.name] = c
+	}
+	for _, workload := range appConfig.Status.Workloads {
+		c, ok := byName[workload.ComponentName]
+		if !ok {
+			continue
+		}
+		for _, wt := range workload.Traits {
+			ref := wt.Reference
+			if ref.APIVersion != vzapi.SchemeGroupVersion.String() {
+				continue
+			}
+			nsn := client.ObjectKey{Namespace: appConfig.Namespace, Name: ref.Name}
+			switch ref.Kind {
+			case ingressTraitKind:
+				trait := &vzapi.IngressTrait{}
+				if err := r.Get(ctx, nsn, trait); err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+				if err := r.addIngressPorts(ctx, byName, c, appConfig.Name, trait); err != nil {
+					return nil, err
+				}
+			case vzapi.MetricsTraitKind:
+				trait := &vzapi.MetricsTrait{}
+				if err := r.Get(ctx, nsn, trait); err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+				addMetricsPorts(c, trait)
+			}
+		}
+	}
+	return components, nil
+}
+
+// addIngressPorts adds the ports of the component pods to which the ingress gateway routes the rules of an
+// ingress trait.  The destination port of a rule is a service port, which is translated to the target port
+// of the service.  The destination host may be the service of another component of the application, in which
+// case the port is added to that component.  If a rule has no destination port, the gateway may route to any
+// port of the component.
+func (r *Reconciler) addIngressPorts(ctx context.Context, components map[string]*componentPorts, c *componentPorts, appName string, trait *vzapi.IngressTrait) error {
+	c.exposed = true
+	for _, rule := range trait.Spec.Rules {
+		if rule.Destination.Port == 0 {
+			c.allIngressPorts = true
+			continue
+		}
+		port, componentName, err := r.resolveTargetPort(ctx, trait.Namespace, appName, c.name, rule.Destination)
+		if err != nil {
+			return err
+		}
+		target := c
+		if other, ok := components[componentName]; ok {
+			target = other
+			target.exposed = true
+		}
+		target.ingressPorts = appendIntOrString(target.ingressPorts, port)
+	}
+	return nil
+}
+
+// resolveTargetPort returns the target port of the service port of an ingress destination, and the component of
+// the service.  The service is the destination host, or else the services of the component.  The service port
+// itself is returned if no service defines it.
+func (r *Reconciler) resolveTargetPort(ctx context.Context, namespace string, appName string, componentName string, dest vzapi.IngressDestination) (intstr.IntOrString, string, error) {
+	var services []corev1.Service
+	if dest.Host != "" {
+		service := corev1.Service{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: strings.Split(dest.Host, ".")[0]}, &service)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return intstr.IntOrString{}, "", err
+		}
+		if err == nil {
+			services = append(services, service)
+		}
+	} else {
+		list := corev1.ServiceList{}
+		err := r.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{oam.LabelAppName: appName, oam.LabelAppComponent: componentName})
+		if err != nil {
+			return intstr.IntOrString{}, "", err
+		}
+		services = list.Items
+	}
+	for _, service := range services {
+		for _, port := range service.Spec.Ports {
+			if port.Port != int32(dest.Port) {
+				continue
+			}
+			serviceComponent := componentName
+			if service.Labels[oam.LabelAppName] == appName && service.Labels[oam.LabelAppComponent] != "" {
+				serviceComponent = service.Labels[oam.LabelAppComponent]
+			}
+			if port.TargetPort.Type == intstr.String || port.TargetPort.IntVal != 0 {
+				return port.TargetPort, serviceComponent, nil
+			}
+			return intstr.FromInt(int(dest.Port)), serviceComponent, nil
+		}
+	}
+	return intstr.FromInt(int(dest.Port)), componentName, nil
+}
+
+// addMetricsPorts adds the ports of the component pods scraped for a metrics trait.  Ports that are not specified
+// default to the scrape port of the workload, the WebLogic servers are scraped on their listen ports, which are not
+// known, so any port is allowed.
+func addMetricsPorts(c *componentPorts, trait *vzapi.MetricsTrait) {
+	if trait.Spec.Enabled != nil && !*trait.Spec.Enabled {
+		return
+	}
+	specs := trait.Spec.Ports
+	if trait.Spec.Port != nil || len(specs) == 0 {
+		specs = append(specs, vzapi.PortSpec{Port: trait.Spec.Port})
+	}
+	for _, spec := range specs {
+		if spec.Port != nil {
+			c.metricsPorts = appendInt32(c.metricsPorts, int32(*spec.Port))
+			continue
+		}
+		switch c.workloadKind {
+		case weblogicWorkloadKind, weblogicDomainKind:
+			c.allMetricsPorts = true
+		case coherenceWorkloadKind, coherenceKind:
+			c.metricsPorts = appendInt32(c.metricsPorts, defaultCohScrapePort)
+		default:
+			c.metricsPorts = appendInt32(c.metricsPorts, defaultScrapePort)
+		}
+	}
+}
+
+// policyName returns the name of the policies generated for a component
+func policyName(appName string, componentName string) string {
+	return fmt.Sprintf("%s-%s", appName, componentName)
+}
+
+// policyLabels returns the labels of the resources generated for a component
+func policyLabels(appName string, componentName string) map[string]string {
+	return map[string]string{
+		constants.NetworkPolicyAppLabel: appName,
+		oam.LabelAppName:                appName,
+		oam.LabelAppComponent:           componentName,
+	}
+}
+
+// componentPodLabels returns the labels that select the pods of a component
+func componentPodLabels(appName string, componentName string) map[string]string {
+	return map[string]string{oam.LabelAppName: appName, oam.LabelAppComponent: componentName}
+}
+
+// newNetworkPolicy creates the NetworkPolicy of a component.  The component pods accept traffic from the other
+// pods of the application, from the ingress gateway on the ingress ports, and from Prometheus on the metrics ports
+// and the Envoy metrics port.  The pods of WebLogic and Coherence workloads also accept traffic from their operators.
+func newNetworkPolicy(appConfig *oamv1.ApplicationConfiguration, c *componentPorts) *netv1.NetworkPolicy {
+	tcp := corev1.ProtocolTCP
+	policy := &netv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: netv1.SchemeGroupVersion.String(), Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: appConfig.Namespace,
+			Name:      policyName(appConfig.Name, c.name),
+			Labels:    policyLabels(appConfig.Name, c.name),
+		},
+	}
+	spec := &policy.Spec
+	spec.PodSelector = metav1.LabelSelector{MatchLabels: componentPodLabels(appConfig.Name, c.name)}
+	spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeIngress}
+
+	// Traffic between the components of the application
+	spec.Ingress = append(spec.Ingress, netv1.NetworkPolicyIngressRule{
+		From: []netv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{oam.LabelAppName: appConfig.Name}},
+		}},
+	})
+
+	if c.exposed {
+		rule := netv1.NetworkPolicyIngressRule{
+			From: []netv1.NetworkPolicyPeer{newPeer(constants.IstioSystemNamespace, map[string]string{"app": istioIngressGatewayApp})},
+		}
+		if !c.allIngressPorts {
+			for i := range c.ingressPorts {
+				rule.Ports = append(rule.Ports, netv1.NetworkPolicyPort{Protocol: &tcp, Port: &c.ingressPorts[i]})
+			}
+		}
+		spec.Ingress = append(spec.Ingress, rule)
+	}
+
+	rule := netv1.NetworkPolicyIngressRule{
+		From: []netv1.NetworkPolicyPeer{newPeer(vzconst.VerrazzanoMonitoringNamespace, map[string]string{"app.kubernetes.io/name": prometheusNameLabelValue})},
+	}
+	if !c.allMetricsPorts {
+		for _, port := range appendInt32(c.metricsPorts, envoyStatsPort) {
+			p := intstr.FromInt(int(port))
+			rule.Ports = append(rule.Ports, netv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+		}
+	}
+	spec.Ingress = append(spec.Ingress, rule)
+
+	switch c.workloadKind {
+	case weblogicWorkloadKind, weblogicDomainKind:
+		spec.Ingress = append(spec.Ingress, netv1.NetworkPolicyIngressRule{
+			From: []netv1.NetworkPolicyPeer{newPeer(constants.VerrazzanoSystemNamespace, map[string]string{"app": weblogicOperatorApp})},
+		})
+	case coherenceWorkloadKind, coherenceKind:
+		spec.Ingress = append(spec.Ingress, netv1.NetworkPolicyIngressRule{
+			From: []netv1.NetworkPolicyPeer{newPeer(constants.VerrazzanoSystemNamespace, map[string]string{coherenceOperatorLabel: coherenceOperatorLabelVal})},
+		})
+	}
+	return policy
+}
+
+// newPeer creates a network policy peer selecting pods in a Verrazzano namespace
+func newPeer(namespace string, podLabels map[string]string) netv1.NetworkPolicyPeer {
+	return netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{constants.LabelVerrazzanoNamespace: namespace}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: podLabels},
+	}
+}
+
+// newAuthorizationPolicy creates the Istio AuthorizationPolicy of a component.  The AuthorizationPolicy created
+// by the Istio webhook for the application allows the ingress gateway and Prometheus to reach any port of the
+// application pods.  Since Istio evaluates DENY policies before ALLOW policies, the requests of the ingress
+// gateway and Prometheus to the other ports of the component are denied here.  Nil is returned if nothing
+// needs to be denied.
+func newAuthorizationPolicy(appConfig *oamv1.ApplicationConfiguration, c *componentPorts) *clisecurity.AuthorizationPolicy {
+	var rules []*securityv1beta1.Rule
+	if !c.exposed {
+		rules = append(rules, newDenyRule([]string{gatewayPrincipal}, nil))
+	} else if !c.allIngressPorts {
+		if ports, ok := numericPorts(c.ingressPorts); ok {
+			rules = append(rules, newDenyRule([]string{gatewayPrincipal}, ports))
+		}
+	}
+	if !c.allMetricsPorts {
+		var ports []string
+		for _, port := range appendInt32(c.metricsPorts, envoyStatsPort) {
+			ports = append(ports, strconv.Itoa(int(port)))
+		}
+		rules = append(rules, newDenyRule([]string{promPrincipal, promOperatorPrincipal}, ports))
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	policy := &clisecurity.AuthorizationPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: clisecurity.SchemeGroupVersion.String(), Kind: "AuthorizationPolicy"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: appConfig.Namespace,
+			Name:      policyName(appConfig.Name, c.name),
+			Labels:    policyLabels(appConfig.Name, c.name),
+		},
+	}
+	setAuthorizationPolicySpec(policy, appConfig.Name, c.name, rules)
+	return policy
+}
+
+// setAuthorizationPolicySpec sets the spec of a component AuthorizationPolicy
+func setAuthorizationPolicySpec(policy *clisecurity.AuthorizationPolicy, appName string, componentName string, rules []*securityv1beta1.Rule) {
+	policy.Spec.Selector = &v1beta1.WorkloadSelector{MatchLabels: componentPodLabels(appName, componentName)}
+	policy.Spec.Action = securityv1beta1.AuthorizationPolicy_DENY
+	policy.Spec.Rules = rules
+}
+
+// newDenyRule creates a rule matching the requests of principals to any port other than the given ports, or to any
+// port if no ports are given
+func newDenyRule(principals []string, notPorts []string) *securityv1beta1.Rule {
+	rule := &securityv1beta1.Rule{
+		From: []*securityv1beta1.Rule_From{{Source: &securityv1beta1.Source{Principals: principals}}},
+	}
+	if len(notPorts) > 0 {
+		rule.To = []*securityv1beta1.Rule_To{{Operation: &securityv1beta1.Operation{NotPorts: notPorts}}}
+	}
+	return rule
+}
+
+// numericPorts converts ports to strings, returns false if a port is a named port, which Istio does not support
+func numericPorts(ports []intstr.IntOrString) ([]string, bool) {
+	var result []string
+	for _, port := range ports {
+		if port.Type == intstr.String {
+			return nil, false
+		}
+		result = append(result, strconv.Itoa(int(port.IntVal)))
+	}
+	return result, true
+}
+
+// appendInt32 appends a port to a sorted list of ports, unless the port is already in the list
+func appendInt32(ports []int32, port int32) []int32 {
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+	result := append(append([]int32{}, ports...), port)
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// appendIntOrString appends a port to a list of ports, unless the port is already in the list
+func appendIntOrString(ports []intstr.IntOrString, port intstr.IntOrString) []intstr.IntOrString {
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+	return append(ports, port)
+}
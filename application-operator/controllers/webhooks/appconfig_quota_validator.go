@@ -0,0 +1,356 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
+	k8sadmission "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AppConfigQuotaValidatorPath specifies the path of AppConfigQuotaValidator
+const AppConfigQuotaValidatorPath = "/validate-core-oam-dev-v1alpha2-applicationconfiguration"
+
+const (
+	helidonWorkloadKind    = "VerrazzanoHelidonWorkload"
+	coherenceWorkloadKind  = "VerrazzanoCoherenceWorkload"
+	manualScalerTraitKind  = "ManualScalerTrait"
+	defaultCoherenceSize   = 3
+	defaultWorkloadReplica = 1
+)
+
+// AppConfigQuotaValidator validates that the workloads of the components of created or updated ApplicationConfiguration
+// resources fit in the LimitRange and the remaining ResourceQuota of their namespace, so that a quota violation is
+// reported with the component exceeding it instead of failing later when the pods are created.
+type AppConfigQuotaValidator struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+// componentResources holds the resources of the pods of a component workload
+type componentResources struct {
+	containers []corev1.Container
+	replicas   int64
+}
+
+// InjectClient injects the client.
+func (v *AppConfigQuotaValidator) InjectClient(c client.Client) error {
+	v.client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder.
+func (v *AppConfigQuotaValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle performs quota validation of created or updated ApplicationConfiguration resources.
+func (v *AppConfigQuotaValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	counterMetricObject, errorCounterMetricObject, handleDurationMetricObject, zapLogForMetrics, err := metricsexporter.ExposeControllerMetrics("AppConfigQuotaValidator", metricsexporter.AppconfigQuotaHandleCounter, metricsexporter.AppconfigQuotaHandleError, metricsexporter.AppconfigQuotaHandleDuration)
+	if err != nil {
+		return admission.Response{}
+	}
+	handleDurationMetricObject.TimerStart()
+	defer handleDurationMetricObject.TimerStop()
+
+	appConfig := &oamv1.ApplicationConfiguration{}
+	err = v.decoder.Decode(req, appConfig)
+	if err != nil {
+		errorCounterMetricObject.Inc(zapLogForMetrics, err)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if appConfig.ObjectMeta.DeletionTimestamp.IsZero() {
+		switch req.Operation {
+		case k8sadmission.Create, k8sadmission.Update:
+			var oldAppConfig *oamv1.ApplicationConfiguration
+			if req.Operation == k8sadmission.Update {
+				oldAppConfig = &oamv1.ApplicationConfiguration{}
+				if err = v.decoder.DecodeRaw(req.OldObject, oldAppConfig); err != nil {
+					errorCounterMetricObject.Inc(zapLogForMetrics, err)
+					return admission.Errored(http.StatusBadRequest, err)
+				}
+			}
+			denial, err := v.validateQuotas(ctx, appConfig, oldAppConfig)
+			if err != nil {
+				errorCounterMetricObject.Inc(zapLogForMetrics, err)
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if denial != "" {
+				errorCounterMetricObject.Inc(zapLogForMetrics, fmt.Errorf("%s", denial))
+				return admission.Denied(denial)
+			}
+		}
+	}
+	counterMetricObject.Inc(zapLogForMetrics, err)
+	return admission.Allowed("")
+}
+
+// validateQuotas returns the reason for denying the ApplicationConfiguration if the containers of a component added or
+// changed in the ApplicationConfiguration exceed the LimitRange of the namespace, or if the components added to the
+// ApplicationConfiguration exceed the remaining ResourceQuota of the namespace. The components already in the
+// ApplicationConfiguration are counted in the quota usage, and are not denied by a LimitRange created after them.
+func (v *AppConfigQuotaValidator) validateQuotas(ctx context.Context, appConfig *oamv1.ApplicationConfiguration, oldAppConfig *oamv1.ApplicationConfiguration) (string, error) {
+	quotas := corev1.ResourceQuotaList{}
+	if err := v.client.List(ctx, &quotas, client.InNamespace(appConfig.Namespace)); err != nil {
+		return "", err
+	}
+	limitRanges := corev1.LimitRangeList{}
+	if err := v.client.List(ctx, &limitRanges, client.InNamespace(appConfig.Namespace)); err != nil {
+		return "", err
+	}
+	if len(quotas.Items) == 0 && len(limitRanges.Items) == 0 {
+		return "", nil
+	}
+
+	deployed := map[string]oamv1.ApplicationConfigurationComponent{}
+	if oldAppConfig != nil {
+		for _, comp := range oldAppConfig.Spec.Components {
+			deployed[comp.ComponentName] = comp
+		}
+	}
+	// requested holds the resources requested by the added components, for each ResourceQuota
+	requested := map[string]corev1.ResourceList{}
+	for _, comp := range appConfig.Spec.Components {
+		resources, err := v.getComponentResources(ctx, appConfig.Namespace, comp)
+		if err != nil {
+			return "", err
+		}
+		if resources == nil {
+			continue
+		}
+		oldComp, isDeployed := deployed[comp.ComponentName]
+		if isDeployed && reflect.DeepEqual(oldComp, comp) {
+			continue
+		}
+		for i := range resources.containers {
+			container := &resources.containers[i]
+			for _, limitRange := range limitRanges.Items {
+				if denial := checkLimitRange(limitRange, container); denial != "" {
+					return fmt.Sprintf("Component %q of ApplicationConfiguration %q: %s", comp.ComponentName, appConfig.Name, denial), nil
+				}
+			}
+		}
+		if isDeployed {
+			continue
+		}
+		usage := resources.quotaUsage()
+		for _, quota := range quotas.Items {
+			if denial := checkResourceQuota(quota, usage, requested); denial != "" {
+				return fmt.Sprintf("Component %q of ApplicationConfiguration %q %s", comp.ComponentName, appConfig.Name, denial), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// getComponentResources returns the resources of the pods of the workload of a component, or nil if the component does
+// not exist yet or its workload type is not supported
+func (v *AppConfigQuotaValidator) getComponentResources(ctx context.Context, namespace string, comp oamv1.ApplicationConfigurationComponent) (*componentResources, error) {
+	component := oamv1.Component{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: comp.ComponentName}, &component); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	workload := &unstructured.Unstructured{}
+	if err := workload.UnmarshalJSON(component.Spec.Workload.Raw); err != nil {
+		return nil, err
+	}
+	resources, err := getWorkloadResources(workload)
+	if err != nil || resources == nil {
+		return nil, err
+	}
+	// The replicas of the workload are set by the manual scaler trait of the component
+	for _, trait := range comp.Traits {
+		scaler := &unstructured.Unstructured{}
+		if err := scaler.UnmarshalJSON(trait.Trait.Raw); err != nil {
+			return nil, err
+		}
+		if scaler.GetKind() == manualScalerTraitKind {
+			if replicas, found, _ := unstructured.NestedInt64(scaler.Object, "spec", "replicaCount"); found {
+				resources.replicas = replicas
+			}
+		}
+	}
+	return resources, nil
+}
+
+// getWorkloadResources returns the containers and the replicas of the pods of a workload
+func getWorkloadResources(workload *unstructured.Unstructured) (*componentResources, error) {
+	resources := &componentResources{replicas: defaultWorkloadReplica}
+	var podSpecFields []string
+	switch workload.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		podSpecFields = []string{"spec", "template", "spec"}
+	case helidonWorkloadKind:
+		podSpecFields = []string{"spec", "deploymentTemplate", "podSpec"}
+	case oamv1.ContainerizedWorkloadKind:
+		return getContainerizedWorkloadResources(workload)
+	case coherenceWorkloadKind:
+		// The Coherence members run a single Coherence container
+		resources.replicas = defaultCoherenceSize
+		if replicas, found, _ := unstructured.NestedInt64(workload.Object, "spec", "template", "replicas"); found {
+			resources.replicas = replicas
+		}
+		container := corev1.Container{Name: "coherence"}
+		if requirements, found, _ := unstructured.NestedMap(workload.Object, "spec", "template", "resources"); found {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(requirements, &container.Resources); err != nil {
+				return nil, err
+			}
+		}
+		resources.containers = []corev1.Container{container}
+		return resources, nil
+	default:
+		return nil, nil
+	}
+	if replicas, found, _ := unstructured.NestedInt64(workload.Object, "spec", "replicas"); found {
+		resources.replicas = replicas
+	}
+	podSpecObject, found, err := unstructured.NestedMap(workload.Object, podSpecFields...)
+	if err != nil || !found {
+		return nil, err
+	}
+	podSpec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(podSpecObject, &podSpec); err != nil {
+		return nil, err
+	}
+	resources.containers = podSpec.Containers
+	return resources, nil
+}
+
+// getContainerizedWorkloadResources returns the containers of an OAM containerized workload, the required CPU and
+// memory of its containers are their limits
+func getContainerizedWorkloadResources(workload *unstructured.Unstructured) (*componentResources, error) {
+	cw := oamv1.ContainerizedWorkload{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, &cw); err != nil {
+		return nil, err
+	}
+	resources := &componentResources{replicas: defaultWorkloadReplica}
+	for _, c := range cw.Spec.Containers {
+		container := corev1.Container{Name: c.Name, Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{}}}
+		if c.Resources != nil {
+			container.Resources.Limits[corev1.ResourceCPU] = c.Resources.CPU.Required
+			container.Resources.Limits[corev1.ResourceMemory] = c.Resources.Memory.Required
+		}
+		resources.containers = append(resources.containers, container)
+	}
+	return resources, nil
+}
+
+// checkLimitRange applies the defaults of the LimitRange to the container and returns the reason for denying the
+// container if its resources exceed the maximum of the LimitRange
+func checkLimitRange(limitRange corev1.LimitRange, container *corev1.Container) string {
+	for _, item := range limitRange.Spec.Limits {
+		if item.Type != corev1.LimitTypeContainer {
+			continue
+		}
+		for name, quantity := range item.Default {
+			if _, ok := container.Resources.Limits[name]; !ok {
+				if container.Resources.Limits == nil {
+					container.Resources.Limits = corev1.ResourceList{}
+				}
+				container.Resources.Limits[name] = quantity
+			}
+		}
+		for name, quantity := range item.DefaultRequest {
+			if _, ok := container.Resources.Requests[name]; !ok {
+				if container.Resources.Requests == nil {
+					container.Resources.Requests = corev1.ResourceList{}
+				}
+				container.Resources.Requests[name] = quantity
+			}
+		}
+		for name, max := range item.Max {
+			if limit, ok := container.Resources.Limits[name]; ok && limit.Cmp(max) > 0 {
+				return fmt.Sprintf("the %s limit %s of container %q exceeds the maximum %s of LimitRange %q",
+					name, limit.String(), container.Name, max.String(), limitRange.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// quotaUsage returns the quota usage of the pods of a component, the requests of a container default to its limits
+func (r *componentResources) quotaUsage() corev1.ResourceList {
+	usage := corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(r.replicas, resource.DecimalSI)}
+	for _, container := range r.containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, ok := container.Resources.Requests[name]
+			if !ok {
+				request = container.Resources.Limits[name]
+			}
+			addQuantity(usage, corev1.ResourceName("requests."+name), request, r.replicas)
+			if limit, ok := container.Resources.Limits[name]; ok {
+				addQuantity(usage, corev1.ResourceName("limits."+name), limit, r.replicas)
+			}
+		}
+	}
+	// The CPU and memory quotas are the requests quotas
+	usage[corev1.ResourceCPU] = usage[corev1.ResourceRequestsCPU]
+	usage[corev1.ResourceMemory] = usage[corev1.ResourceRequestsMemory]
+	return usage
+}
+
+// checkResourceQuota adds the usage to the resources requested from the ResourceQuota, and returns the reason for
+// denying the component if the requested resources exceed the remaining quota. Scoped quotas are not checked since
+// they apply only to some of the pods.
+func checkResourceQuota(quota corev1.ResourceQuota, usage corev1.ResourceList, requested map[string]corev1.ResourceList) string {
+	if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+		return ""
+	}
+	hard := quota.Status.Hard
+	if hard == nil {
+		hard = quota.Spec.Hard
+	}
+	if requested[quota.Name] == nil {
+		requested[quota.Name] = corev1.ResourceList{}
+	}
+	for name, limit := range hard {
+		quantity, ok := usage[name]
+		if !ok || quantity.IsZero() {
+			continue
+		}
+		total := requested[quota.Name][name]
+		total.Add(quantity)
+		requested[quota.Name][name] = total
+
+		remaining := limit.DeepCopy()
+		used := quota.Status.Used[name]
+		remaining.Sub(used)
+		if total.Cmp(remaining) > 0 {
+			if remaining.Sign() < 0 {
+				remaining = resource.Quantity{}
+			}
+			return fmt.Sprintf("requests %s of %s, exceeding the %s remaining in ResourceQuota %q (hard %s, used %s)",
+				quantity.String(), name, remaining.String(), quota.Name, limit.String(), used.String())
+		}
+	}
+	return ""
+}
+
+// addQuantity adds the quantity times the replicas to the resource of the list
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity, replicas int64) {
+	if quantity.IsZero() {
+		return
+	}
+	total := list[name]
+	total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*replicas, quantity.Format))
+	list[name] = total
+}
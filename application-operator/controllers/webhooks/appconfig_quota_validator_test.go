@@ -0,0 +1,263 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const quotaTestNamespace = "hello"
+
+// TestAppConfigQuotaValidatorLimitRange tests the validation of the LimitRange of the namespace
+// GIVEN an ApplicationConfiguration with a component whose container limit exceeds the maximum of the LimitRange
+// WHEN Handle is called
+// THEN the request is denied with the component exceeding the LimitRange
+func TestAppConfigQuotaValidatorLimitRange(t *testing.T) {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "limits"},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type: corev1.LimitTypeContainer,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+		},
+	}
+	validator := newQuotaValidator(t, limitRange, newDeploymentComponent(t, "hello-web", "2", 1))
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Create, newQuotaAppConfig("hello-web"), nil))
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Result.Reason, `Component "hello-web" of ApplicationConfiguration "hello-app"`)
+	assert.Contains(t, res.Result.Reason, `exceeds the maximum 1 of LimitRange "limits"`)
+}
+
+// TestAppConfigQuotaValidatorLimitRangeDefault tests the validation of the LimitRange of the namespace
+// GIVEN an ApplicationConfiguration with a component without limits, and a LimitRange with a default limit within
+// its maximum
+// WHEN Handle is called
+// THEN the request is allowed
+func TestAppConfigQuotaValidatorLimitRangeDefault(t *testing.T) {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "limits"},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type:    corev1.LimitTypeContainer,
+				Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Max:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+		},
+	}
+	validator := newQuotaValidator(t, limitRange, newDeploymentComponent(t, "hello-web", "", 1))
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Create, newQuotaAppConfig("hello-web"), nil))
+	assert.True(t, res.Allowed)
+}
+
+// TestAppConfigQuotaValidatorLimitRangeUpdate tests the validation of the LimitRange of the namespace
+// GIVEN an update of an ApplicationConfiguration whose deployed component exceeds a LimitRange created after it
+// WHEN Handle is called
+// THEN the deployed component is not denied, and the request is denied only if an added component exceeds the
+// LimitRange
+func TestAppConfigQuotaValidatorLimitRangeUpdate(t *testing.T) {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "limits"},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type: corev1.LimitTypeContainer,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+		},
+	}
+	validator := newQuotaValidator(t, limitRange,
+		newDeploymentComponent(t, "hello-web", "2", 1),
+		newDeploymentComponent(t, "hello-api", "2", 1))
+	oldAppConfig := newQuotaAppConfig("hello-web")
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Update, newQuotaAppConfig("hello-web"), oldAppConfig))
+	assert.True(t, res.Allowed)
+
+	res = validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Update, newQuotaAppConfig("hello-web", "hello-api"), oldAppConfig))
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Result.Reason, `Component "hello-api"`)
+}
+
+// TestAppConfigQuotaValidatorResourceQuota tests the validation of the ResourceQuota of the namespace
+// GIVEN an ApplicationConfiguration with two components whose replicas together exceed the remaining CPU quota
+// WHEN Handle is called
+// THEN the request is denied with the component exceeding the ResourceQuota
+func TestAppConfigQuotaValidatorResourceQuota(t *testing.T) {
+	validator := newQuotaValidator(t, newResourceQuota("4", "1"),
+		newDeploymentComponent(t, "hello-web", "1", 2),
+		newDeploymentComponent(t, "hello-api", "1", 2))
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Create, newQuotaAppConfig("hello-web", "hello-api"), nil))
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Result.Reason, `Component "hello-api" of ApplicationConfiguration "hello-app" requests 2 of requests.cpu`)
+	assert.Contains(t, res.Result.Reason, `exceeding the 3 remaining in ResourceQuota "quota" (hard 4, used 1)`)
+}
+
+// TestAppConfigQuotaValidatorUpdate tests the validation of the ResourceQuota of the namespace
+// GIVEN an update of an ApplicationConfiguration whose deployed component is counted in the quota usage
+// WHEN Handle is called
+// THEN the deployed component is not counted again, and the request is denied only if an added component exceeds
+// the remaining quota
+func TestAppConfigQuotaValidatorUpdate(t *testing.T) {
+	validator := newQuotaValidator(t, newResourceQuota("4", "2"),
+		newDeploymentComponent(t, "hello-web", "1", 2),
+		newDeploymentComponent(t, "hello-api", "1", 2),
+		newDeploymentComponent(t, "hello-db", "1", 1))
+	oldAppConfig := newQuotaAppConfig("hello-web")
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Update, newQuotaAppConfig("hello-web", "hello-api"), oldAppConfig))
+	assert.True(t, res.Allowed)
+
+	res = validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Update, newQuotaAppConfig("hello-web", "hello-api", "hello-db"), oldAppConfig))
+	assert.False(t, res.Allowed)
+	assert.Contains(t, res.Result.Reason, `Component "hello-db"`)
+}
+
+// TestAppConfigQuotaValidatorContainerizedWorkload tests the validation of the ResourceQuota of the namespace
+// GIVEN an ApplicationConfiguration with a containerized workload component scaled by a manual scaler trait
+// WHEN validateQuotas is called
+// THEN the required CPU of the containers times the replicas of the trait is counted in the quota usage
+func TestAppConfigQuotaValidatorContainerizedWorkload(t *testing.T) {
+	workload := oamv1.ContainerizedWorkload{
+		TypeMeta: metav1.TypeMeta{APIVersion: oamv1.SchemeGroupVersion.String(), Kind: oamv1.ContainerizedWorkloadKind},
+		Spec: oamv1.ContainerizedWorkloadSpec{
+			Containers: []oamv1.Container{{
+				Name: "hello",
+				Resources: &oamv1.ContainerResources{
+					CPU:    oamv1.CPUResources{Required: resource.MustParse("500m")},
+					Memory: oamv1.MemoryResources{Required: resource.MustParse("64Mi")},
+				},
+			}},
+		},
+	}
+	component := newQuotaComponent(t, "hello-cw", &workload)
+	appConfig := newQuotaAppConfig("hello-cw")
+	appConfig.Spec.Components[0].Traits = []oamv1.ComponentTrait{{
+		Trait: runtime.RawExtension{Raw: []byte(`{"apiVersion":"core.oam.dev/v1alpha2","kind":"ManualScalerTrait","spec":{"replicaCount":3}}`)},
+	}}
+
+	validator := newQuotaValidator(t, newResourceQuota("2", "0"), component)
+	denial, err := validator.validateQuotas(context.TODO(), appConfig, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, denial)
+
+	validator = newQuotaValidator(t, newResourceQuota("2", "1"), component)
+	denial, err = validator.validateQuotas(context.TODO(), appConfig, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, denial, "requests 1500m of requests.cpu")
+}
+
+// TestAppConfigQuotaValidatorNoQuota tests the validation of an ApplicationConfiguration
+// GIVEN a namespace without ResourceQuota and LimitRange
+// WHEN Handle is called
+// THEN the request is allowed without reading the components
+func TestAppConfigQuotaValidatorNoQuota(t *testing.T) {
+	validator := newQuotaValidator(t)
+
+	res := validator.Handle(context.TODO(), newAppConfigRequest(t, admissionv1.Create, newQuotaAppConfig("hello-web"), nil))
+	assert.True(t, res.Allowed)
+}
+
+// TestAppConfigQuotaValidatorHandleError tests handling an invalid admission.Request
+// GIVEN an AppConfigQuotaValidator
+// WHEN Handle is called with an admission.Request containing no content
+// THEN Handle should return an error with http.StatusBadRequest
+func TestAppConfigQuotaValidatorHandleError(t *testing.T) {
+	validator := newQuotaValidator(t)
+
+	res := validator.Handle(context.TODO(), admission.Request{})
+	assert.False(t, res.Allowed)
+	assert.Equal(t, int32(http.StatusBadRequest), res.Result.Code)
+}
+
+func newQuotaValidator(t *testing.T, objs ...client.Object) *AppConfigQuotaValidator {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, core.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	validator := &AppConfigQuotaValidator{}
+	assert.NoError(t, validator.InjectClient(ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()))
+	assert.NoError(t, validator.InjectDecoder(decoder()))
+	return validator
+}
+
+func newQuotaAppConfig(componentNames ...string) *oamv1.ApplicationConfiguration {
+	appConfig := &oamv1.ApplicationConfiguration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: oamv1.SchemeGroupVersion.String(), Kind: oamv1.ApplicationConfigurationKind},
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "hello-app"},
+	}
+	for _, name := range componentNames {
+		appConfig.Spec.Components = append(appConfig.Spec.Components, oamv1.ApplicationConfigurationComponent{ComponentName: name})
+	}
+	return appConfig
+}
+
+func newAppConfigRequest(t *testing.T, operation admissionv1.Operation, appConfig *oamv1.ApplicationConfiguration, oldAppConfig *oamv1.ApplicationConfiguration) admission.Request {
+	req := admission.Request{}
+	req.Operation = operation
+	raw, err := json.Marshal(appConfig)
+	assert.NoError(t, err)
+	req.Object = runtime.RawExtension{Raw: raw}
+	if oldAppConfig != nil {
+		raw, err = json.Marshal(oldAppConfig)
+		assert.NoError(t, err)
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+
+// newDeploymentComponent returns a component with a Deployment workload, the container has the CPU limit if not empty
+func newDeploymentComponent(t *testing.T, name string, cpuLimit string, replicas int32) *oamv1.Component {
+	container := corev1.Container{Name: "hello", Image: "hello"}
+	if cpuLimit != "" {
+		container.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuLimit)}
+	}
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container}}},
+		},
+	}
+	return newQuotaComponent(t, name, &deployment)
+}
+
+func newQuotaComponent(t *testing.T, name string, workload interface{}) *oamv1.Component {
+	raw, err := json.Marshal(workload)
+	assert.NoError(t, err)
+	return &oamv1.Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: name},
+		Spec:       oamv1.ComponentSpec{Workload: runtime.RawExtension{Raw: raw}},
+	}
+}
+
+func newResourceQuota(hardCPU string, usedCPU string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: quotaTestNamespace, Name: "quota"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(hardCPU)},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(hardCPU)},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(usedCPU)},
+		},
+	}
+}
@@ -1,15 +1,17 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	clusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	clusterutil "github.com/verrazzano/verrazzano/application-operator/controllers/clusters"
+	k8sadmission "k8s.io/api/admission/v1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,19 +19,36 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-func validateMultiClusterResource(c client.Client, r clusterutil.MultiClusterResource) error {
+// validateMultiClusterResource validates the placement of a multicluster resource, the old placement of the resource
+// is empty when it is created
+func validateMultiClusterResource(c client.Client, r clusterutil.MultiClusterResource, oldPlacement clusters.Placement) error {
 	p := r.GetPlacement()
 	if len(p.Clusters) == 0 {
 		return fmt.Errorf("One or more target clusters must be provided")
 	}
 	if !isLocalClusterManagedCluster(c) {
-		if err := validateTargetClustersExist(c, p); err != nil {
+		if err := validateTargetClustersExist(c, p, oldPlacement); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// getOldPlacement returns the placement of a multicluster resource before an update, or an empty placement if the
+// resource is created
+func getOldPlacement(req admission.Request) clusters.Placement {
+	old := struct {
+		Spec struct {
+			Placement clusters.Placement `json:"placement"`
+		} `json:"spec"`
+	}{}
+	if req.Operation == k8sadmission.Update && len(req.OldObject.Raw) > 0 {
+		// The old object was valid, an undecodable one is handled like a created resource
+		_ = json.Unmarshal(req.OldObject.Raw, &old)
+	}
+	return old.Spec.Placement
+}
+
 // isLocalClusterManagedCluster determines if the local cluster is a registered managed cluster.
 func isLocalClusterManagedCluster(c client.Client) bool {
 	s := core.Secret{}
@@ -40,8 +59,13 @@ func isLocalClusterManagedCluster(c client.Client) bool {
 
 // validateTargetClustersExist determines if all of the target clusters of the project have
 // corresponding managed cluster resources.  The results are only valid when this
-// is executed against an admin cluster.
-func validateTargetClustersExist(c client.Client, p clusters.Placement) error {
+// is executed against an admin cluster.  Target clusters that were not in the old placement
+// must accept new placements, which the cluster operator disables for unhealthy clusters.
+func validateTargetClustersExist(c client.Client, p clusters.Placement, oldPlacement clusters.Placement) error {
+	placed := make(map[string]bool)
+	for _, cluster := range oldPlacement.Clusters {
+		placed[cluster.Name] = true
+	}
 	for _, cluster := range p.Clusters {
 		targetClusterName := cluster.Name
 		// If the target cluster name is local then assume it is valid.
@@ -60,6 +84,14 @@ func validateTargetClustersExist(c client.Client, p clusters.Placement) error {
 			if err != nil {
 				return fmt.Errorf("target managed cluster %s is not registered: %v", cluster.Name, err)
 			}
+			if placed[targetClusterName] {
+				continue
+			}
+			disabled, _, _ := unstructured.NestedBool(vmc.Object, "status", "health", "placementDisabled")
+			if disabled {
+				state, _, _ := unstructured.NestedString(vmc.Object, "status", "health", "state")
+				return fmt.Errorf("target managed cluster %s does not accept new multicluster resources, its health state is %s", cluster.Name, state)
+			}
 		}
 	}
 	return nil
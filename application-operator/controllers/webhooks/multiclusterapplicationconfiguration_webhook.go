@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -54,7 +54,7 @@ func (v *MultiClusterApplicationConfigurationValidator) Handle(ctx context.Conte
 	if mcac.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create, k8sadmission.Update:
-			err = validateMultiClusterResource(v.client, mcac)
+			err = validateMultiClusterResource(v.client, mcac, getOldPlacement(req))
 			if err != nil {
 				errorCounterMetricObject.Inc(zapLogForMetrics, err)
 				return admission.Denied(err.Error())
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -51,7 +51,7 @@ func (v *MultiClusterComponentValidator) Handle(ctx context.Context, req admissi
 	if mcc.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create, k8sadmission.Update:
-			err = validateMultiClusterResource(v.client, mcc)
+			err = validateMultiClusterResource(v.client, mcc, getOldPlacement(req))
 			if err != nil {
 				errorCounterMetricObject.Inc(zapLogForMetrics, err)
 				return admission.Denied(err.Error())
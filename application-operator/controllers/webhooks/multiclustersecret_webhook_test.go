@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -147,6 +147,48 @@ func TestValidationSuccessForMultiClusterSecretCreationTargetingExistingManagedC
 	asrt.True(res.Allowed, "Expected multi-cluster secret update validation to succeed.")
 }
 
+// TestValidationForMultiClusterSecretTargetingUnhealthyManagedCluster tests the placement of a MultiClusterSecret
+// resource on a managed cluster that does not accept new multicluster resources.
+// GIVEN a call to validate a MultiClusterSecret resource
+// WHEN the MultiClusterSecret resource references an unreachable VerrazzanoManagedCluster
+// THEN the creation should fail, and the update of a resource already placed on the cluster should succeed.
+func TestValidationForMultiClusterSecretTargetingUnhealthyManagedCluster(t *testing.T) {
+	asrt := assert.New(t)
+	v := newMultiClusterSecretValidator()
+	c := v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unreachable-cluster-name",
+			Namespace: constants.VerrazzanoMultiClusterNamespace,
+		},
+		Status: v1alpha1.VerrazzanoManagedClusterStatus{
+			Health: &v1alpha1.HealthStatus{State: v1alpha1.HealthUnreachable, PlacementDisabled: true},
+		},
+	}
+	p := v1alpha12.MultiClusterSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-mcsecret-name",
+			Namespace: "application-ns",
+		},
+		Spec: v1alpha12.MultiClusterSecretSpec{
+			Placement: v1alpha12.Placement{
+				Clusters: []v1alpha12.Cluster{{Name: "unreachable-cluster-name"}},
+			},
+		},
+	}
+	asrt.NoError(v.client.Create(context.TODO(), &c))
+
+	req := newAdmissionRequest(admissionv1.Create, p)
+	res := v.Handle(context.TODO(), req)
+	asrt.False(res.Allowed, "Expected multi-cluster secret validation to fail due to the unreachable target cluster.")
+	asrt.Contains(res.Result.Reason, "unreachable-cluster-name does not accept new multicluster resources, its health state is Unreachable")
+
+	// The resource was already placed on the cluster before the update
+	req = newAdmissionRequest(admissionv1.Update, p)
+	req.OldObject = req.Object
+	res = v.Handle(context.TODO(), req)
+	asrt.True(res.Allowed, "Expected multi-cluster secret update validation to succeed.")
+}
+
 // TestValidationSuccessForMultiClusterSecretCreationWithoutTargetClustersOnManagedCluster tests allowing the creation
 // of a MultiClusterSecret resources that is missing target cluster information when on managed cluster.
 // GIVEN a call to validate a MultiClusterSecret resource
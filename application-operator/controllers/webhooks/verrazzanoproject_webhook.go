@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -7,6 +7,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
 
 	"github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
@@ -54,7 +58,7 @@ func (v *VerrazzanoProjectValidator) Handle(ctx context.Context, req admission.R
 	if prj.ObjectMeta.DeletionTimestamp.IsZero() {
 		switch req.Operation {
 		case k8sadmission.Create, k8sadmission.Update:
-			return translateErrorToResponse(validateVerrazzanoProject(v.client, prj))
+			return translateErrorToResponse(validateVerrazzanoProject(v.client, prj, getOldPlacement(req)))
 		}
 	}
 	counterMetricObject.Inc(zapLogForMetrics, err)
@@ -62,8 +66,9 @@ func (v *VerrazzanoProjectValidator) Handle(ctx context.Context, req admission.R
 	return admission.Allowed("")
 }
 
-// validateVerrazzanoProject performs validation checks on the resource
-func validateVerrazzanoProject(c client.Client, vp *v1alpha1.VerrazzanoProject) error {
+// validateVerrazzanoProject performs validation checks on the resource, the old placement of the resource is empty
+// when it is created
+func validateVerrazzanoProject(c client.Client, vp *v1alpha1.VerrazzanoProject, oldPlacement v1alpha1.Placement) error {
 	if vp.ObjectMeta.Namespace != constants.VerrazzanoMultiClusterNamespace {
 		return fmt.Errorf("Namespace for the resource must be %q", constants.VerrazzanoMultiClusterNamespace)
 	}
@@ -80,7 +85,11 @@ func validateVerrazzanoProject(c client.Client, vp *v1alpha1.VerrazzanoProject)
 		return err
 	}
 
-	if err := validateMultiClusterResource(c, vp); err != nil {
+	if err := validateGitOps(vp); err != nil {
+		return err
+	}
+
+	if err := validateMultiClusterResource(c, vp, oldPlacement); err != nil {
 		return err
 	}
 
@@ -104,6 +113,39 @@ func validateNetworkPolicies(vp *v1alpha1.VerrazzanoProject) error {
 	return nil
 }
 
+// scpLikeGitURL matches the Git URLs in the scp-like syntax, such as git@github.com:org/repo.git
+var scpLikeGitURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// validateGitOps validates the Git repository from which the project applications are delivered
+func validateGitOps(vp *v1alpha1.VerrazzanoProject) error {
+	gitOps := vp.Spec.GitOps
+	if gitOps == nil {
+		return nil
+	}
+	if !scpLikeGitURL.MatchString(gitOps.RepoURL) {
+		repoURL, err := url.Parse(gitOps.RepoURL)
+		if err != nil || repoURL.Path == "" {
+			return fmt.Errorf("GitOps repository URL %q is not a valid Git URL", gitOps.RepoURL)
+		}
+		switch repoURL.Scheme {
+		case "https", "http", "ssh", "git":
+			if repoURL.Host == "" {
+				return fmt.Errorf("GitOps repository URL %q has no host", gitOps.RepoURL)
+			}
+		case "file":
+		default:
+			return fmt.Errorf("GitOps repository URL %q must use the https, http, ssh, git or file scheme", gitOps.RepoURL)
+		}
+	}
+	if gitOps.Path == "" || path.IsAbs(gitOps.Path) || strings.HasPrefix(path.Clean(gitOps.Path), "..") {
+		return fmt.Errorf("GitOps path %q must be a relative path in the Git repository", gitOps.Path)
+	}
+	if strings.ContainsAny(gitOps.Revision, " \t\n") {
+		return fmt.Errorf("GitOps revision %q is not a valid Git revision", gitOps.Revision)
+	}
+	return nil
+}
+
 func validateNamespaceCanBeUsed(c client.Client, vp *v1alpha1.VerrazzanoProject) error {
 	projectsList := &v1alpha1.VerrazzanoProjectList{}
 	listOptions := &client.ListOptions{Namespace: constants.VerrazzanoMultiClusterNamespace}
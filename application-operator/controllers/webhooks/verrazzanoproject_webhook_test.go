@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -265,7 +265,7 @@ func TestNamespaceUniquenessForProjects(t *testing.T) {
 	err = validateNamespaceCanBeUsed(v.client, &currentVP)
 	assert.NotNil(t, err)
 	// This test will fail same as above but this time coming in through parent validator
-	err = validateVerrazzanoProject(v.client, &currentVP)
+	err = validateVerrazzanoProject(v.client, &currentVP, v1alpha12.Placement{})
 	assert.NotNil(t, err)
 
 	currentVP = v1alpha12.VerrazzanoProject{
@@ -584,3 +584,42 @@ func TestVzProjHandleFailed(t *testing.T) {
 	reconcileFailedCounterAfter := testutil.ToFloat64(reconcileerrorCounterObject.Get())
 	assert.Equal(reconcileFailedCounterBefore, reconcileFailedCounterAfter-1)
 }
+
+// TestGitOpsValidation tests the validation of the VerrazzanoProject GitOps spec
+// GIVEN a call to validate a VerrazzanoProject on create
+// WHEN the VerrazzanoProject delivers its applications from valid and invalid Git repositories
+// THEN the validation succeeds only for the valid Git repositories
+func TestGitOpsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		gitOps  v1alpha12.GitOpsSpec
+		allowed bool
+		reason  string
+	}{
+		{name: "https", gitOps: v1alpha12.GitOpsSpec{RepoURL: "https://github.com/example/apps.git", Path: "hello", Revision: "v1.0"}, allowed: true},
+		{name: "git daemon", gitOps: v1alpha12.GitOpsSpec{RepoURL: "git://127.0.0.1:9418/apps.git", Path: "./envs/dev"}, allowed: true},
+		{name: "scp-like", gitOps: v1alpha12.GitOpsSpec{RepoURL: "git@github.com:example/apps.git", Path: "hello"}, allowed: true},
+		{name: "file", gitOps: v1alpha12.GitOpsSpec{RepoURL: "file:///srv/git/apps.git", Path: "hello"}, allowed: true},
+		{name: "no scheme", gitOps: v1alpha12.GitOpsSpec{RepoURL: "github.com/example/apps", Path: "hello"}, reason: "must use the https, http, ssh, git or file scheme"},
+		{name: "no host", gitOps: v1alpha12.GitOpsSpec{RepoURL: "https:///apps.git", Path: "hello"}, reason: "has no host"},
+		{name: "absolute path", gitOps: v1alpha12.GitOpsSpec{RepoURL: "https://github.com/example/apps.git", Path: "/hello"}, reason: "must be a relative path"},
+		{name: "path out of repository", gitOps: v1alpha12.GitOpsSpec{RepoURL: "https://github.com/example/apps.git", Path: "hello/../.."}, reason: "must be a relative path"},
+		{name: "invalid revision", gitOps: v1alpha12.GitOpsSpec{RepoURL: "https://github.com/example/apps.git", Path: "hello", Revision: "main branch"}, reason: "is not a valid Git revision"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asrt := assert.New(t)
+			v := newVerrazzanoProjectValidator()
+			testMC := testManagedCluster
+			asrt.NoError(v.client.Create(context.TODO(), &testMC))
+
+			testVP := testProject
+			testVP.Spec.GitOps = tt.gitOps.DeepCopy()
+			res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Create, testVP))
+			asrt.Equal(tt.allowed, res.Allowed)
+			if !tt.allowed {
+				asrt.Contains(res.Result.Reason, tt.reason)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package certificates
@@ -41,6 +41,8 @@ const (
 	MetricsBindingWebhookName = "verrazzano-application-metrics-binding"
 	// VerrazzanoProjectValidatingWebhookName is the resource name for the Verrazzano ValidatingWebhook
 	VerrazzanoProjectValidatingWebhookName = "verrazzano-application-verrazzanoproject"
+	// AppConfigQuotaValidatingWebhookName is the resource name for the ApplicationConfiguration quota ValidatingWebhook
+	AppConfigQuotaValidatingWebhookName = "verrazzano-application-appconfig-quota-validator"
 	// MultiClusterApplicationConfigurationName is the resource name for the MultiClusterApplicationConfiguration ValidatingWebhook
 	MultiClusterApplicationConfigurationName = "verrazzano-application-multiclusterapplicationconfiguration"
 	// MultiClusterComponentName is the resource name for the MultiClusterComponent ValidatingWebhook
@@ -11,12 +11,31 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/constants"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
 	prometheusClusterNameLabel = "verrazzano_cluster"
+
+	// prometheusReleaseLabel is the label selected by the Verrazzano Prometheus for monitors
+	prometheusReleaseLabel = "release"
+	prometheusReleaseValue = "prometheus-operator"
+
+	// OpenTelemetryCollectorLabel is placed on the monitors of metrics collected by an OpenTelemetry Collector
+	// instead of the Verrazzano Prometheus. The value is the name of the collector.
+	OpenTelemetryCollectorLabel = "verrazzano.io/otel-collector"
 )
 
+// PortScrapeSettings captures the scrape settings of a single metrics port
+type PortScrapeSettings struct {
+	// The port number, used as the target port of PodMonitor endpoints
+	Port *int
+	// The scrape interval of the port
+	Interval string
+	// The scrape timeout of the port
+	ScrapeTimeout string
+}
+
 // ScrapeInfo captures the information needed to construct the service monitor for a generic workload
 type ScrapeInfo struct {
 	// The path by which Prometheus should scrape metrics
@@ -34,16 +53,23 @@ type ScrapeInfo struct {
 	KeepLabels map[string]string
 	// The name of the cluster for the selected workload
 	ClusterName string
+	// The scrape settings of each port, indexed by port increment
+	PortSettings []PortScrapeSettings
+	// The relabeling rules applied to the scraped metrics
+	MetricRelabelConfigs []*promoperapi.RelabelConfig
+	// The TLS configuration of the endpoints when Istio is not enabled
+	TLSConfig *promoperapi.SafeTLSConfig
+	// The secret key that holds the bearer token for the endpoints
+	BearerTokenSecret *corev1.SecretKeySelector
+	// The name of the OpenTelemetry Collector that collects the metrics instead of Prometheus
+	OpenTelemetryCollector string
 }
 
 // PopulateServiceMonitor populates the Service Monitor to prepare for a create or update
 // the Service Monitor reflects the specifications defined in the ScrapeInfo object
 func PopulateServiceMonitor(info ScrapeInfo, serviceMonitor *promoperapi.ServiceMonitor, log vzlog.VerrazzanoLogger) error {
 	// Create the Service Monitor selector from the info label if it exists
-	if serviceMonitor.ObjectMeta.Labels == nil {
-		serviceMonitor.ObjectMeta.Labels = make(map[string]string)
-	}
-	serviceMonitor.Labels["release"] = "prometheus-operator"
+	serviceMonitor.Labels = populateMonitorLabels(info, serviceMonitor.Labels)
 	serviceMonitor.Spec.NamespaceSelector = promoperapi.NamespaceSelector{
 		MatchNames: []string{serviceMonitor.Namespace},
 	}
@@ -62,6 +88,66 @@ func PopulateServiceMonitor(info ScrapeInfo, serviceMonitor *promoperapi.Service
 	return nil
 }
 
+// PopulatePodMonitor populates the Pod Monitor to prepare for a create or update
+// the Pod Monitor reflects the specifications defined in the ScrapeInfo object
+func PopulatePodMonitor(info ScrapeInfo, podMonitor *promoperapi.PodMonitor, log vzlog.VerrazzanoLogger) error {
+	// Pod Monitors can only reference TLS files from secrets, the Istio certificates mounted in the Prometheus
+	// pod can not be used
+	if info.IstioEnabled != nil && *info.IstioEnabled {
+		return log.ErrorfNewErr("Failed to create the Pod Monitor %s: Pod Monitors are not supported in Istio-enabled namespaces", podMonitor.Name)
+	}
+	podMonitor.Labels = populateMonitorLabels(info, podMonitor.Labels)
+	podMonitor.Spec.NamespaceSelector = promoperapi.NamespaceSelector{
+		MatchNames: []string{podMonitor.Namespace},
+	}
+
+	// Clear the existing endpoints to avoid duplications
+	podMonitor.Spec.PodMetricsEndpoints = nil
+
+	for i := 0; i < info.Ports; i++ {
+		endpoint, err := createServiceMonitorEndpoint(info, i)
+		if err != nil {
+			return log.ErrorfNewErr("Failed to create an endpoint for the Pod Monitor: %v", err)
+		}
+		podEndpoint := promoperapi.PodMetricsEndpoint{
+			Path:                 endpoint.Path,
+			Scheme:               endpoint.Scheme,
+			Interval:             endpoint.Interval,
+			ScrapeTimeout:        endpoint.ScrapeTimeout,
+			BearerTokenSecret:    endpoint.BearerTokenSecret,
+			BasicAuth:            endpoint.BasicAuth,
+			MetricRelabelConfigs: endpoint.MetricRelabelConfigs,
+			RelabelConfigs:       endpoint.RelabelConfigs,
+		}
+		if endpoint.TLSConfig != nil {
+			podEndpoint.TLSConfig = &promoperapi.PodMetricsEndpointTLSConfig{SafeTLSConfig: endpoint.TLSConfig.SafeTLSConfig}
+		}
+		// Only scrape the container port of the endpoint, otherwise every port of the pod becomes a target
+		if i < len(info.PortSettings) && info.PortSettings[i].Port != nil {
+			targetPort := intstr.FromInt(*info.PortSettings[i].Port)
+			podEndpoint.TargetPort = &targetPort
+		}
+		podMonitor.Spec.PodMetricsEndpoints = append(podMonitor.Spec.PodMetricsEndpoints, podEndpoint)
+	}
+	return nil
+}
+
+// populateMonitorLabels sets the label that selects the collector of a monitor, either the Verrazzano Prometheus
+// or an OpenTelemetry Collector
+func populateMonitorLabels(info ScrapeInfo, labels map[string]string) map[string]string {
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	if info.OpenTelemetryCollector != "" {
+		delete(labels, prometheusReleaseLabel)
+		labels[OpenTelemetryCollectorLabel] = info.OpenTelemetryCollector
+		return labels
+	}
+	delete(labels, OpenTelemetryCollectorLabel)
+	labels[prometheusReleaseLabel] = prometheusReleaseValue
+	return labels
+}
+
 // createServiceMonitorEndpoint creates an endpoint for a given port increment and info
 // this function effectively creates a scrape config for the workload target through the Service Monitor API
 func createServiceMonitorEndpoint(info ScrapeInfo, portIncrement int) (promoperapi.Endpoint, error) {
@@ -104,7 +190,19 @@ func createServiceMonitorEndpoint(info ScrapeInfo, portIncrement int) (promopera
 			KeyFile:  fmt.Sprintf("%s/key.pem", certPath),
 		}
 		endpoint.TLSConfig.InsecureSkipVerify = true
+	} else if info.TLSConfig != nil {
+		endpoint.Scheme = "https"
+		endpoint.TLSConfig = &promoperapi.TLSConfig{SafeTLSConfig: *info.TLSConfig}
+	}
+
+	if info.BearerTokenSecret != nil {
+		endpoint.BearerTokenSecret = *info.BearerTokenSecret
+	}
+	if portIncrement < len(info.PortSettings) {
+		endpoint.Interval = promoperapi.Duration(info.PortSettings[portIncrement].Interval)
+		endpoint.ScrapeTimeout = promoperapi.Duration(info.PortSettings[portIncrement].ScrapeTimeout)
 	}
+	endpoint.MetricRelabelConfigs = info.MetricRelabelConfigs
 
 	// Change the expected labels based on the workload type
 	enabledLabel := "__meta_kubernetes_pod_annotation_prometheus_io_scrape"
@@ -92,3 +92,57 @@ func TestPopulateServiceMonitor(t *testing.T) {
 		})
 	}
 }
+
+// TestPopulatePodMonitor tests the population of a Pod Monitor
+func TestPopulatePodMonitor(t *testing.T) {
+	trueVal := true
+	falseVal := false
+	port := 8080
+	tokenKey := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"}
+
+	// GIVEN scrape info with TLS, a bearer token and port scrape settings
+	// WHEN the Pod Monitor is populated
+	// THEN the Pod Monitor endpoints use the settings and target the container port
+	info := ScrapeInfo{
+		Ports:                1,
+		IstioEnabled:         &falseVal,
+		VZPrometheusLabels:   &trueVal,
+		PortSettings:         []PortScrapeSettings{{Port: &port, Interval: "30s", ScrapeTimeout: "5s"}},
+		MetricRelabelConfigs: []*promoperapi.RelabelConfig{{Action: "drop", Regex: "go_.*"}},
+		TLSConfig:            &promoperapi.SafeTLSConfig{ServerName: "hello.example.com"},
+		BearerTokenSecret:    &tokenKey,
+	}
+	podMonitor := &promoperapi.PodMonitor{}
+	asserts.NoError(t, PopulatePodMonitor(info, podMonitor, vzlog.DefaultLogger()))
+	asserts.Len(t, podMonitor.Spec.PodMetricsEndpoints, 1)
+	endpoint := podMonitor.Spec.PodMetricsEndpoints[0]
+	asserts.Equal(t, "https", endpoint.Scheme)
+	asserts.Equal(t, "hello.example.com", endpoint.TLSConfig.ServerName)
+	asserts.Equal(t, tokenKey, endpoint.BearerTokenSecret)
+	asserts.Equal(t, promoperapi.Duration("30s"), endpoint.Interval)
+	asserts.Equal(t, promoperapi.Duration("5s"), endpoint.ScrapeTimeout)
+	asserts.Equal(t, 8080, endpoint.TargetPort.IntValue())
+	asserts.Len(t, endpoint.MetricRelabelConfigs, 1)
+	asserts.Equal(t, prometheusReleaseValue, podMonitor.Labels[prometheusReleaseLabel])
+
+	// GIVEN scrape info for an Istio-enabled namespace
+	// WHEN the Pod Monitor is populated
+	// THEN an error is returned
+	info.IstioEnabled = &trueVal
+	asserts.Error(t, PopulatePodMonitor(info, &promoperapi.PodMonitor{}, vzlog.DefaultLogger()))
+}
+
+// TestPopulateMonitorLabels tests the selection of the collector of a monitor
+func TestPopulateMonitorLabels(t *testing.T) {
+	// GIVEN scrape info with an OpenTelemetry Collector
+	// WHEN the monitor labels are populated
+	// THEN the collector label replaces the Prometheus release label
+	labels := populateMonitorLabels(ScrapeInfo{OpenTelemetryCollector: "otel"}, map[string]string{prometheusReleaseLabel: prometheusReleaseValue})
+	asserts.Equal(t, map[string]string{OpenTelemetryCollectorLabel: "otel"}, labels)
+
+	// GIVEN scrape info without an OpenTelemetry Collector
+	// WHEN the monitor labels are populated
+	// THEN the Prometheus release label replaces the collector label
+	labels = populateMonitorLabels(ScrapeInfo{}, labels)
+	asserts.Equal(t, map[string]string{prometheusReleaseLabel: prometheusReleaseValue}, labels)
+}
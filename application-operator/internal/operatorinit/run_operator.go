@@ -5,6 +5,9 @@ package operatorinit
 
 import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/appconfig"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/apphealth"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/autoscalertrait"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/backuptrait"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/cohworkload"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/containerizedworkload"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/helidonworkload"
@@ -13,6 +16,7 @@ import (
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricsbinding"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/metricstrait"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/namespace"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/netpolicy"
 	"github.com/verrazzano/verrazzano/application-operator/controllers/wlsworkload"
 	"github.com/verrazzano/verrazzano/application-operator/metricsexporter"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
@@ -146,6 +150,14 @@ func setupAppReconcilers(mgr manager.Manager, defaultMetricsScraper string, log
 		log.Errorf("Failed to create ApplicationConfiguration controller: %v", err)
 		return err
 	}
+	if err = (&apphealth.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    logger,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create ApplicationConfiguration health controller: %v", err)
+		return err
+	}
 	if err = (&containerizedworkload.Reconciler{
 		Client: mgr.GetClient(),
 		Log:    logger,
@@ -163,5 +175,29 @@ func setupAppReconcilers(mgr manager.Manager, defaultMetricsScraper string, log
 		log.Errorf("Failed to create MetricsBinding controller: %v", err)
 		return err
 	}
+	if err = (&autoscalertrait.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    logger,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create AutoscalerTrait controller: %v", err)
+		return err
+	}
+	if err = (&backuptrait.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    logger,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create BackupTrait controller: %v", err)
+		return err
+	}
+	if err = (&netpolicy.Reconciler{
+		Client: mgr.GetClient(),
+		Log:    logger,
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Errorf("Failed to create ApplicationConfiguration network policy controller: %v", err)
+		return err
+	}
 	return nil
 }
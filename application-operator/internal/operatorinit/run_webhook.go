@@ -103,6 +103,12 @@ func StartWebhookServer(metricsAddr string, log *zap.SugaredLogger, enableLeader
 		return err
 	}
 
+	err = updateValidatingWebhookConfiguration(kubeClient, certificates.AppConfigQuotaValidatingWebhookName)
+	if err != nil {
+		log.Errorf("Failed to update %s: %v", certificates.AppConfigQuotaValidatingWebhookName, err)
+		return err
+	}
+
 	err = updateMutatingWebhookConfiguration(kubeClient, certificates.IstioMutatingWebhookName)
 	if err != nil {
 		log.Errorf("Failed to update %s: %v", certificates.IstioMutatingWebhookName, err)
@@ -191,6 +197,9 @@ func StartWebhookServer(metricsAddr string, log *zap.SugaredLogger, enableLeader
 	}
 	mgr.GetWebhookServer().Register(webhooks.AppConfigDefaulterPath, &webhook.Admission{Handler: appconfigWebhook})
 
+	// ApplicationConfiguration quota validating webhook
+	mgr.GetWebhookServer().Register(webhooks.AppConfigQuotaValidatorPath, &webhook.Admission{Handler: &webhooks.AppConfigQuotaValidator{}})
+
 	// MultiClusterApplicationConfiguration validating webhook
 	mgr.GetWebhookServer().Register(
 		"/validate-clusters-verrazzano-io-v1alpha1-multiclusterapplicationconfiguration",
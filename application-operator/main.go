@@ -11,6 +11,7 @@ import (
 
 	certapiv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	fluentbitv1alpha2 "github.com/fluent/fluent-operator/v2/apis/fluentbit/v1alpha2"
 	promoperapi "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	vzapp "github.com/verrazzano/verrazzano/application-operator/apis/app/v1alpha1"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
@@ -49,6 +50,7 @@ func init() {
 	_ = vmc.AddToScheme(scheme)
 	_ = certapiv1.AddToScheme(scheme)
 	_ = promoperapi.AddToScheme(scheme)
+	_ = fluentbitv1alpha2.AddToScheme(scheme)
 }
 
 var (
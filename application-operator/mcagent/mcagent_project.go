@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package mcagent
@@ -30,11 +30,13 @@ func (s *Syncer) syncVerrazzanoProjects() error {
 	for _, vp := range allAdminProjects.Items {
 		if vp.Namespace == constants.VerrazzanoMultiClusterNamespace {
 			if s.isThisCluster(vp.Spec.Placement) {
-				_, err := s.createOrUpdateVerrazzanoProject(vp)
+				vpLocal, _, err := s.createOrUpdateVerrazzanoProject(vp)
 				if err != nil {
 					s.Log.Errorw(fmt.Sprintf("Failed syncing object: %v", err),
 						"VerrazzanoProject",
 						types.NamespacedName{Namespace: vp.Namespace, Name: vp.Name})
+				} else if err := s.updateVerrazzanoProjectQuotaStatus(vp, vpLocal); err != nil {
+					s.Log.Errorf("Failed to update the quota status of VerrazzanoProject %q on the admin cluster: %v", vp.Name, err)
 				}
 			} else {
 				// Remove the VerrazzanoProject resource if it is on the local cluster but no longer
@@ -82,17 +84,18 @@ func (s *Syncer) syncVerrazzanoProjects() error {
 	return nil
 }
 
-// Create or update a VerrazzanoProject
-func (s *Syncer) createOrUpdateVerrazzanoProject(vp clustersv1alpha1.VerrazzanoProject) (controllerutil.OperationResult, error) {
+// Create or update a VerrazzanoProject, returns the VerrazzanoProject on the local cluster
+func (s *Syncer) createOrUpdateVerrazzanoProject(vp clustersv1alpha1.VerrazzanoProject) (*clustersv1alpha1.VerrazzanoProject, controllerutil.OperationResult, error) {
 	var vpNew clustersv1alpha1.VerrazzanoProject
 	vpNew.Namespace = vp.Namespace
 	vpNew.Name = vp.Name
 
 	// Create or update on the local cluster
-	return controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &vpNew, func() error {
+	opResult, err := controllerutil.CreateOrUpdate(s.Context, s.LocalClient, &vpNew, func() error {
 		mutateVerrazzanoProject(vp, &vpNew)
 		return nil
 	})
+	return &vpNew, opResult, err
 }
 
 func (s *Syncer) updateVerrazzanoProjectStatus(name types.NamespacedName, newCond clustersv1alpha1.Condition, newClusterStatus clustersv1alpha1.ClusterLevelStatus) error {
@@ -102,10 +105,20 @@ func (s *Syncer) updateVerrazzanoProjectStatus(name types.NamespacedName, newCon
 		return err
 	}
 	fetched.Status.Conditions = append(fetched.Status.Conditions, newCond)
-	clusters.SetClusterLevelStatus(&fetched.Status, newClusterStatus)
+	clusters.SetClusterLevelStatus(&fetched.Status.MultiClusterResourceStatus, newClusterStatus)
 	return s.AdminClient.Status().Update(s.Context, &fetched)
 }
 
+// updateVerrazzanoProjectQuotaStatus reports the resource quota usage of the project on this cluster, computed by the
+// local VerrazzanoProject controller, to the project on the admin cluster
+func (s *Syncer) updateVerrazzanoProjectQuotaStatus(vp clustersv1alpha1.VerrazzanoProject, vpLocal *clustersv1alpha1.VerrazzanoProject) error {
+	quotaStatus := clusters.GetProjectQuotaStatus(vpLocal.Status, s.ManagedClusterName)
+	if !clusters.SetProjectQuotaStatus(&vp.Status, s.ManagedClusterName, quotaStatus) {
+		return nil
+	}
+	return s.AdminClient.Status().Update(s.Context, &vp)
+}
+
 // mutateVerrazzanoProject mutates the VerrazzanoProject to reflect the contents of the parent VerrazzanoProject
 func mutateVerrazzanoProject(vp clustersv1alpha1.VerrazzanoProject, vpNew *clustersv1alpha1.VerrazzanoProject) {
 	vpNew.Spec.Template = vp.Spec.Template
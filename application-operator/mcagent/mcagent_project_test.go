@@ -19,10 +19,13 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var testLabels = map[string]string{"label1": "test1", "label2": "test2"}
@@ -597,3 +600,38 @@ func getTestVerrazzanoProject(vpNamespace string, vpName string, nsNames []clust
 	proj.Spec.Placement.Clusters = clusters
 	return proj, err
 }
+
+// TestUpdateVerrazzanoProjectQuotaStatus tests reporting the quota usage of a project to the admin cluster
+// GIVEN a project with the quota status of the managed cluster on the local cluster
+// WHEN updateVerrazzanoProjectQuotaStatus is called
+// THEN the quota status of the managed cluster is set on the project on the admin cluster
+func TestUpdateVerrazzanoProjectQuotaStatus(t *testing.T) {
+	assert := asserts.New(t)
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+
+	adminVP := &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: "hello"},
+		Status: clustersv1alpha1.VerrazzanoProjectStatus{
+			Quotas: []clustersv1alpha1.ProjectQuotaStatus{{Cluster: "local"}},
+		},
+	}
+	localVP := adminVP.DeepCopy()
+	localVP.Status.Quotas = []clustersv1alpha1.ProjectQuotaStatus{{
+		Cluster: testClusterName,
+		Used:    corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("500m")},
+	}}
+	adminClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(adminVP).Build()
+	s := &Syncer{
+		AdminClient:        adminClient,
+		Log:                zap.S(),
+		ManagedClusterName: testClusterName,
+		Context:            context.TODO(),
+	}
+
+	assert.NoError(s.updateVerrazzanoProjectQuotaStatus(*adminVP, localVP))
+	updated := clustersv1alpha1.VerrazzanoProject{}
+	assert.NoError(adminClient.Get(context.TODO(), client.ObjectKeyFromObject(adminVP), &updated))
+	assert.Len(updated.Status.Quotas, 2)
+	assert.Equal(localVP.Status.Quotas[0], updated.Status.Quotas[1])
+}
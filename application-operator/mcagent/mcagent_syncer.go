@@ -177,6 +177,11 @@ func (s *Syncer) updateVMCStatus() error {
 	// If Thanos is disabled, we want to empty the host so Prometheus federation returns
 	vmc.Status.ThanosQueryStore = thanosAPIHost
 
+	// Perform the Verrazzano upgrade requested by the admin cluster, and report its progress
+	if vmc.Spec.Upgrade != nil {
+		s.syncVerrazzanoUpgrade(&vmc)
+	}
+
 	// update status of VMC
 	return s.AdminClient.Status().Update(s.Context, &vmc)
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"fmt"
+
+	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	platformOperatorName       = "verrazzano-platform-operator"
+	platformOperatorVersionKey = "app.kubernetes.io/version"
+)
+
+// syncVerrazzanoUpgrade performs the Verrazzano upgrade requested in the VMC of this cluster, the same way as the
+// vz upgrade command: the platform operator of the target version is installed first, then the version of the
+// Verrazzano resource is updated. The platform operator manifest is applied by the running platform operator, which
+// the agent requests with the upgrade request ConfigMap. The progress is reported in the upgrade status of the VMC.
+func (s *Syncer) syncVerrazzanoUpgrade(vmc *clustersapi.VerrazzanoManagedCluster) {
+	upgrade := vmc.Spec.Upgrade
+	now := metav1.Now()
+	status := &clustersapi.ManagedClusterUpgradeStatus{TargetVersion: upgrade.Version, LastUpdateTime: &now}
+	vmc.Status.Upgrade = status
+
+	message, err := s.upgradeVerrazzano(upgrade, status)
+	if err != nil {
+		s.Log.Errorf("Failed to upgrade Verrazzano to version %s: %v", upgrade.Version, err)
+		message = fmt.Sprintf("Failed to upgrade Verrazzano to version %s: %v", upgrade.Version, err)
+	}
+	status.Message = message
+}
+
+// upgradeVerrazzano runs the next step of the upgrade and returns a message describing it
+func (s *Syncer) upgradeVerrazzano(upgrade *clustersapi.ManagedClusterUpgrade, status *clustersapi.ManagedClusterUpgradeStatus) (string, error) {
+	targetVersion, err := semver.NewSemVersion(upgrade.Version)
+	if err != nil {
+		return "", err
+	}
+
+	vzList := v1beta1.VerrazzanoList{}
+	if err := s.LocalClient.List(s.Context, &vzList); err != nil {
+		return "", err
+	}
+	if len(vzList.Items) == 0 {
+		return "", fmt.Errorf("no Verrazzano resource found")
+	}
+	vz := &vzList.Items[0]
+	status.Version = vz.Status.Version
+	status.State = string(vz.Status.State)
+
+	if isVersion(vz.Status.Version, targetVersion) {
+		if err := s.deleteUpgradeRequest(); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Verrazzano is at version %s", vz.Status.Version), nil
+	}
+	if installedVersion, err := semver.NewSemVersion(vz.Status.Version); err == nil && targetVersion.IsLessThan(installedVersion) {
+		return fmt.Sprintf("Verrazzano is at version %s, which is greater than the upgrade version", vz.Status.Version), nil
+	}
+	if isVersion(vz.Spec.Version, targetVersion) {
+		return fmt.Sprintf("The upgrade to version %s is in progress", upgrade.Version), nil
+	}
+
+	// The platform operator of the target version must be running before the upgrade is started
+	vpo := &appsv1.Deployment{}
+	if err := s.LocalClient.Get(s.Context, types.NamespacedName{Namespace: vzconst.VerrazzanoInstallNamespace, Name: platformOperatorName}, vpo); client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if !isVersion(vpo.Labels[platformOperatorVersionKey], targetVersion) {
+		return s.requestPlatformOperatorUpgrade(upgrade)
+	}
+	if vpo.Spec.Replicas == nil || vpo.Status.UpdatedReplicas < *vpo.Spec.Replicas || vpo.Status.AvailableReplicas < *vpo.Spec.Replicas {
+		return fmt.Sprintf("Waiting for the platform operator of version %s to be ready", upgrade.Version), nil
+	}
+
+	vz.Spec.Version = upgrade.Version
+	if err := s.LocalClient.Update(s.Context, vz); err != nil {
+		return "", err
+	}
+	s.Log.Infof("Started the upgrade of Verrazzano to version %s", upgrade.Version)
+	return fmt.Sprintf("Started the upgrade to version %s", upgrade.Version), nil
+}
+
+// requestPlatformOperatorUpgrade creates or updates the upgrade request ConfigMap, from which the platform operator
+// applies the platform operator manifest of the target version. The errors reported by the platform operator are
+// returned.
+func (s *Syncer) requestPlatformOperatorUpgrade(upgrade *clustersapi.ManagedClusterUpgrade) (string, error) {
+	request := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoInstallNamespace, Name: vzconst.UpgradeRequestConfigMapName}}
+	_, err := controllerutil.CreateOrUpdate(s.Context, s.LocalClient, request, func() error {
+		if request.Data == nil {
+			request.Data = map[string]string{}
+		}
+		// The message of the platform operator is cleared when the request changes
+		if request.Data[vzconst.UpgradeRequestVersionKey] != upgrade.Version || request.Data[vzconst.UpgradeRequestManifestURLKey] != upgrade.PlatformOperatorManifestURL {
+			delete(request.Data, vzconst.UpgradeRequestMessageKey)
+		}
+		request.Data[vzconst.UpgradeRequestVersionKey] = upgrade.Version
+		request.Data[vzconst.UpgradeRequestManifestURLKey] = upgrade.PlatformOperatorManifestURL
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if message := request.Data[vzconst.UpgradeRequestMessageKey]; message != "" {
+		return "", fmt.Errorf("the platform operator failed to install the platform operator of version %s: %s", upgrade.Version, message)
+	}
+	return fmt.Sprintf("Waiting for the platform operator of version %s to be installed", upgrade.Version), nil
+}
+
+// deleteUpgradeRequest deletes the upgrade request ConfigMap of a completed upgrade
+func (s *Syncer) deleteUpgradeRequest() error {
+	request := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoInstallNamespace, Name: vzconst.UpgradeRequestConfigMapName}}
+	return client.IgnoreNotFound(s.LocalClient.Delete(s.Context, request))
+}
+
+// isVersion returns true if the version string is the given version
+func isVersion(version string, expected *semver.SemVersion) bool {
+	if version == "" {
+		return false
+	}
+	v, err := semver.NewSemVersion(version)
+	return err == nil && v.IsEqualTo(expected)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package mcagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clustersapi "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var upgradeRequestName = types.NamespacedName{Namespace: vzconst.VerrazzanoInstallNamespace, Name: vzconst.UpgradeRequestConfigMapName}
+
+// TestSyncVerrazzanoUpgrade tests the Verrazzano upgrade requested by the admin cluster
+// GIVEN a VMC requesting the upgrade of the managed cluster to a newer version
+// WHEN the upgrade is synced as the platform operator is upgraded and Verrazzano is upgraded
+// THEN the platform operator of the target version is requested first, then the Verrazzano version is updated, and
+// the progress is reported in the VMC status
+func TestSyncVerrazzanoUpgrade(t *testing.T) {
+	asserts := assert.New(t)
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	replicas := int32(1)
+	vpo := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      platformOperatorName,
+			Namespace: vzconst.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{platformOperatorVersionKey: "1.5.2"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Status:     v1beta1.VerrazzanoStatus{Version: "1.5.2", State: v1beta1.VzStateReady},
+	}
+	localClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vpo, vz).Build()
+	s := &Syncer{LocalClient: localClient, Log: zap.S().With("test"), Context: context.TODO()}
+	vmc := &clustersapi.VerrazzanoManagedCluster{
+		Spec: clustersapi.VerrazzanoManagedClusterSpec{Upgrade: &clustersapi.ManagedClusterUpgrade{Version: "v1.6.0"}},
+	}
+
+	// The platform operator of the target version is requested
+	s.syncVerrazzanoUpgrade(vmc)
+	request := getUpgradeRequest(t, localClient)
+	asserts.Equal("v1.6.0", request.Data[vzconst.UpgradeRequestVersionKey])
+	asserts.Empty(request.Data[vzconst.UpgradeRequestManifestURLKey])
+	asserts.Equal("v1.6.0", vmc.Status.Upgrade.TargetVersion)
+	asserts.Equal("1.5.2", vmc.Status.Upgrade.Version)
+	asserts.Contains(vmc.Status.Upgrade.Message, "Waiting for the platform operator of version v1.6.0 to be installed")
+
+	// The errors of the platform operator are reported
+	request.Data[vzconst.UpgradeRequestMessageKey] = "the manifest URL is not allowed"
+	updateObject(t, localClient, request)
+	s.syncVerrazzanoUpgrade(vmc)
+	asserts.Contains(vmc.Status.Upgrade.Message, "the manifest URL is not allowed")
+
+	// The message is cleared when the request changes
+	vmc.Spec.Upgrade.PlatformOperatorManifestURL = "https://github.com/verrazzano/verrazzano/releases/download/v1.6.0/operator.yaml"
+	s.syncVerrazzanoUpgrade(vmc)
+	request = getUpgradeRequest(t, localClient)
+	asserts.Empty(request.Data[vzconst.UpgradeRequestMessageKey])
+	asserts.Equal(vmc.Spec.Upgrade.PlatformOperatorManifestURL, request.Data[vzconst.UpgradeRequestManifestURLKey])
+
+	// The upgrade waits for the platform operator to be ready
+	vpo.Labels[platformOperatorVersionKey] = "1.6.0"
+	updateObject(t, localClient, vpo)
+	s.syncVerrazzanoUpgrade(vmc)
+	asserts.Contains(vmc.Status.Upgrade.Message, "Waiting for the platform operator")
+	asserts.Empty(getVerrazzano(t, localClient).Spec.Version)
+
+	// The upgrade is started once the platform operator is ready
+	vpo.Status = appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1}
+	updateObject(t, localClient, vpo)
+	s.syncVerrazzanoUpgrade(vmc)
+	asserts.Contains(vmc.Status.Upgrade.Message, "Started the upgrade")
+	asserts.Equal("v1.6.0", getVerrazzano(t, localClient).Spec.Version)
+
+	// The upgrade completes
+	vz = getVerrazzano(t, localClient)
+	vz.Status = v1beta1.VerrazzanoStatus{Version: "1.6.0", State: v1beta1.VzStateReady}
+	updateObject(t, localClient, vz)
+	s.syncVerrazzanoUpgrade(vmc)
+	asserts.Equal("1.6.0", vmc.Status.Upgrade.Version)
+	asserts.Equal(string(v1beta1.VzStateReady), vmc.Status.Upgrade.State)
+	asserts.True(errors.IsNotFound(localClient.Get(context.TODO(), upgradeRequestName, &corev1.ConfigMap{})))
+
+	// A later upgrade of the cluster is not an error
+	vz.Status.Version = "1.7.0"
+	updateObject(t, localClient, vz)
+	s.syncVerrazzanoUpgrade(vmc)
+	asserts.Contains(vmc.Status.Upgrade.Message, "greater than the upgrade version")
+	asserts.NotContains(vmc.Status.Upgrade.Message, "Failed")
+}
+
+// TestSyncVerrazzanoDowngrade tests a Verrazzano upgrade to an older version
+// GIVEN a VMC requesting the upgrade of the managed cluster to an older version
+// WHEN the upgrade is synced
+// THEN the upgrade is not started and the installed version is reported in the VMC status
+func TestSyncVerrazzanoDowngrade(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Status:     v1beta1.VerrazzanoStatus{Version: "1.6.0", State: v1beta1.VzStateReady},
+	}
+	localClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz).Build()
+	s := &Syncer{LocalClient: localClient, Log: zap.S().With("test"), Context: context.TODO()}
+	vmc := &clustersapi.VerrazzanoManagedCluster{
+		Spec: clustersapi.VerrazzanoManagedClusterSpec{Upgrade: &clustersapi.ManagedClusterUpgrade{Version: "1.5.2"}},
+	}
+
+	s.syncVerrazzanoUpgrade(vmc)
+	assert.Contains(t, vmc.Status.Upgrade.Message, "is greater than the upgrade version")
+	assert.Equal(t, "1.6.0", vmc.Status.Upgrade.Version)
+	assert.Empty(t, getVerrazzano(t, localClient).Spec.Version)
+}
+
+func updateObject(t *testing.T, cli client.Client, obj client.Object) {
+	assert.NoError(t, cli.Update(context.TODO(), obj))
+}
+
+func getUpgradeRequest(t *testing.T, cli client.Client) *corev1.ConfigMap {
+	request := &corev1.ConfigMap{}
+	assert.NoError(t, cli.Get(context.TODO(), upgradeRequestName, request))
+	return request
+}
+
+func getVerrazzano(t *testing.T, cli client.Client) *v1beta1.Verrazzano {
+	vz := &v1beta1.Verrazzano{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	return vz
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package metricsexporter
@@ -59,6 +59,9 @@ const (
 	VzProjHandleCounter                    metricName = "VzProj handle counter"
 	VzProjHandleError                      metricName = "VzProj handle error"
 	VzProjHandleDuration                   metricName = "VzProj handle duration"
+	AppconfigQuotaHandleCounter            metricName = "appconfig quota handle counter"
+	AppconfigQuotaHandleError              metricName = "appconfig quota handle error"
+	AppconfigQuotaHandleDuration           metricName = "appconfig quota handle duration"
 )
 
 func init() {
@@ -228,6 +231,16 @@ func initCounterMetricMap() map[metricName]*SimpleCounterMetric {
 				Name: "vz_application_operator_vzproj_error_handle_total",
 				Help: "Tracks how many times the vz project handle process has failed"}),
 		},
+		AppconfigQuotaHandleCounter: {
+			metric: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "vz_application_operator_appconfig_quota_handle_total",
+				Help: "Tracks how many times the appconfig quota handle process has been successful"}),
+		},
+		AppconfigQuotaHandleError: {
+			metric: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "vz_application_operator_appconfig_quota_error_handle_total",
+				Help: "Tracks how many times the appconfig quota handle process has failed"}),
+		},
 	}
 }
 
@@ -312,6 +325,12 @@ func initDurationMetricMap() map[metricName]*DurationMetrics {
 				Help: "The duration in seconds of vao binding updater handle process",
 			}),
 		},
+		AppconfigQuotaHandleDuration: {
+			metric: prometheus.NewSummary(prometheus.SummaryOpts{
+				Name: "vz_application_operator_appconfig_quota_handle_duration",
+				Help: "The duration in seconds of vao appconfig quota handle process",
+			}),
+		},
 	}
 }
 
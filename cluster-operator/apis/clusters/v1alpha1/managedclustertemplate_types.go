@@ -0,0 +1,178 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The ManagedClusterTemplate custom resource provisions a new Kubernetes cluster with Cluster API, installs
+// Verrazzano on it and registers it as a managed cluster of the admin cluster.
+
+// ProviderType identifies the Cluster API infrastructure provider of a managed cluster.
+type ProviderType string
+
+const (
+	// ProviderDocker provisions the cluster in Docker containers with the Cluster API Docker provider (CAPD)
+	ProviderDocker ProviderType = "docker"
+	// ProviderOCI provisions the cluster in Oracle Cloud Infrastructure with the Cluster API OCI provider and the
+	// Oracle Cloud Native Environment bootstrap and control plane providers
+	ProviderOCI ProviderType = "oci"
+)
+
+// ManagedClusterTemplateSpec defines the desired state of a managed cluster provisioned with Cluster API.
+type ManagedClusterTemplateSpec struct {
+	// The Cluster API infrastructure provider of the cluster: `docker` or `oci`.
+	// +kubebuilder:validation:Enum=docker;oci
+	Provider ProviderType `json:"provider"`
+	// The Kubernetes version of the cluster, for example `v1.25.7`.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	// The description of the managed cluster, copied to the VerrazzanoManagedCluster resource.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The control plane nodes of the cluster.
+	// +optional
+	ControlPlane ControlPlaneSpec `json:"controlPlane,omitempty"`
+	// The pools of worker nodes of the cluster. Defaults to one pool named `md-0` with one node.
+	// +optional
+	WorkerPools []WorkerPoolSpec `json:"workerPools,omitempty"`
+	// The network of the cluster.
+	// +optional
+	Network ClusterNetworkSpec `json:"network,omitempty"`
+	// The configuration of the OCI provider, required if the provider is `oci`.
+	// +optional
+	OCI *OCIProviderSpec `json:"oci,omitempty"`
+	// The Verrazzano installation on the cluster.
+	// +optional
+	Verrazzano VerrazzanoInstallSpec `json:"verrazzano,omitempty"`
+}
+
+// ControlPlaneSpec defines the control plane nodes of a managed cluster.
+type ControlPlaneSpec struct {
+	// The number of control plane nodes. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// The OCI shape of the control plane nodes, used by the `oci` provider. Defaults to `VM.Standard.E4.Flex`.
+	// +optional
+	Shape string `json:"shape,omitempty"`
+}
+
+// WorkerPoolSpec defines a pool of worker nodes of a managed cluster.
+type WorkerPoolSpec struct {
+	// The name of the pool, unique in the cluster.
+	Name string `json:"name"`
+	// The number of nodes of the pool. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// The OCI shape of the nodes, used by the `oci` provider. Defaults to `VM.Standard.E4.Flex`.
+	// +optional
+	Shape string `json:"shape,omitempty"`
+}
+
+// ClusterNetworkSpec defines the network of a managed cluster.
+type ClusterNetworkSpec struct {
+	// The CIDR block of the pods. Defaults to `192.168.0.0/16` for the `docker` provider and to `10.244.0.0/16` for
+	// the `oci` provider.
+	// +optional
+	PodCIDR string `json:"podCIDR,omitempty"`
+	// The CIDR block of the services. Defaults to `10.96.0.0/16`.
+	// +optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+	// The URL of the CNI manifest applied to the cluster before Verrazzano is installed. Defaults to the Calico
+	// manifest for the `docker` provider, the `oci` provider installs its own CNI.
+	// +optional
+	CNIManifestURL string `json:"cniManifestURL,omitempty"`
+}
+
+// OCIProviderSpec defines the OCI configuration of a managed cluster.
+type OCIProviderSpec struct {
+	// The OCID of the compartment of the cluster resources.
+	CompartmentID string `json:"compartmentID"`
+	// The OCID of the image of the nodes.
+	ImageID string `json:"imageID"`
+	// The public SSH key authorized on the nodes.
+	// +optional
+	SSHPublicKey string `json:"sshPublicKey,omitempty"`
+}
+
+// VerrazzanoInstallSpec defines the Verrazzano installation on a managed cluster.
+type VerrazzanoInstallSpec struct {
+	// The URL of the Verrazzano platform operator manifest applied to the cluster. Defaults to the platform operator
+	// manifest of the Verrazzano release installed on the admin cluster.
+	// +optional
+	PlatformOperatorManifestURL string `json:"platformOperatorManifestURL,omitempty"`
+}
+
+// ManagedClusterPhase identifies the provisioning phase of a managed cluster.
+type ManagedClusterPhase string
+
+const (
+	ManagedClusterProvisioning         ManagedClusterPhase = "Provisioning"
+	ManagedClusterInstallingVerrazzano ManagedClusterPhase = "InstallingVerrazzano"
+	ManagedClusterRegistering          ManagedClusterPhase = "Registering"
+	ManagedClusterReady                ManagedClusterPhase = "Ready"
+	ManagedClusterFailed               ManagedClusterPhase = "Failed"
+	ManagedClusterDeleting             ManagedClusterPhase = "Deleting"
+)
+
+const (
+	// ConditionClusterProvisioned = true means that Cluster API has provisioned the cluster and its control plane is ready
+	ConditionClusterProvisioned ConditionType = "ClusterProvisioned"
+
+	// ConditionVerrazzanoInstalled = true means that Verrazzano is installed and ready on the cluster
+	ConditionVerrazzanoInstalled ConditionType = "VerrazzanoInstalled"
+
+	// ConditionClusterRegistered = true means that the agent of the cluster has connected to the admin cluster
+	ConditionClusterRegistered ConditionType = "ClusterRegistered"
+)
+
+// ManagedClusterTemplateStatus defines the observed state of a managed cluster provisioned with Cluster API.
+type ManagedClusterTemplateStatus struct {
+	// The provisioning phase of the managed cluster.
+	// +optional
+	Phase ManagedClusterPhase `json:"phase,omitempty"`
+	// The conditions of the provisioning steps of the managed cluster.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The name of the Secret containing the kubeconfig of the cluster, created by Cluster API.
+	// +optional
+	KubeconfigSecret string `json:"kubeconfigSecret,omitempty"`
+	// The name of the VerrazzanoManagedCluster resource of the cluster, made of the namespace and the name of the template.
+	// +optional
+	ManagedCluster string `json:"managedCluster,omitempty"`
+	// A message with details about the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mct;mcts
+// +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.provider"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ManagedClusterTemplate specifies the API to provision a managed cluster with Cluster API.
+type ManagedClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of a managed cluster provisioned with Cluster API.
+	Spec ManagedClusterTemplateSpec `json:"spec,omitempty"`
+	// The observed state of a managed cluster provisioned with Cluster API.
+	Status ManagedClusterTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagedClusterTemplateList contains a list of ManagedClusterTemplate resources.
+type ManagedClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagedClusterTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManagedClusterTemplate{}, &ManagedClusterTemplateList{})
+}
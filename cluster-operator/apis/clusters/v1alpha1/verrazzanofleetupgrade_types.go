@@ -0,0 +1,127 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The VerrazzanoFleetUpgrade custom resource upgrades Verrazzano on the managed clusters registered with the admin
+// cluster, in batches.
+
+// VerrazzanoFleetUpgradeSpec defines the desired state of a fleet upgrade.
+type VerrazzanoFleetUpgradeSpec struct {
+	// The Verrazzano version the managed clusters are upgraded to.
+	Version string `json:"version"`
+	// The URL of the Verrazzano platform operator manifest of the version, applied to each managed cluster before its
+	// upgrade. Defaults to the platform operator manifest of the Verrazzano release. The platform operators of the
+	// managed clusters only apply the manifests whose URL starts with their upgrade manifest URL prefix.
+	// +optional
+	PlatformOperatorManifestURL string `json:"platformOperatorManifestURL,omitempty"`
+	// The label selector of the VerrazzanoManagedCluster resources of the managed clusters to upgrade. Defaults to
+	// all the managed clusters.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// The number of managed clusters upgraded at the same time, the next batch is only started once all the upgrades
+	// of the current batch have completed. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+	// The number of failed managed cluster upgrades tolerated, the rollout is halted when it is exceeded. Defaults
+	// to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures int `json:"maxFailures,omitempty"`
+	// The time after which the upgrade of a managed cluster that has not completed is failed. Defaults to 2 hours.
+	// +optional
+	ClusterUpgradeTimeout *metav1.Duration `json:"clusterUpgradeTimeout,omitempty"`
+}
+
+// FleetUpgradePhase identifies the phase of a fleet upgrade.
+type FleetUpgradePhase string
+
+const (
+	FleetUpgradeInProgress FleetUpgradePhase = "InProgress"
+	FleetUpgradeCompleted  FleetUpgradePhase = "Completed"
+	FleetUpgradeHalted     FleetUpgradePhase = "Halted"
+)
+
+// ClusterUpgradeState identifies the state of the upgrade of a managed cluster in a fleet upgrade.
+type ClusterUpgradeState string
+
+const (
+	ClusterUpgradePending   ClusterUpgradeState = "Pending"
+	ClusterUpgradeUpgrading ClusterUpgradeState = "Upgrading"
+	ClusterUpgradeSucceeded ClusterUpgradeState = "Succeeded"
+	ClusterUpgradeFailed    ClusterUpgradeState = "Failed"
+)
+
+// ClusterUpgradeStatus defines the status of the upgrade of a managed cluster in a fleet upgrade.
+type ClusterUpgradeStatus struct {
+	// The name of the VerrazzanoManagedCluster resource of the managed cluster.
+	Name string `json:"name"`
+	// The state of the upgrade of the managed cluster.
+	State ClusterUpgradeState `json:"state"`
+	// A message with details about the upgrade of the managed cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The time the upgrade of the managed cluster started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the upgrade of the managed cluster completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// VerrazzanoFleetUpgradeStatus defines the observed state of a fleet upgrade.
+type VerrazzanoFleetUpgradeStatus struct {
+	// The phase of the fleet upgrade.
+	// +optional
+	Phase FleetUpgradePhase `json:"phase,omitempty"`
+	// The upgrade status of each managed cluster of the fleet, in upgrade order.
+	// +optional
+	Clusters []ClusterUpgradeStatus `json:"clusters,omitempty"`
+	// The number of managed clusters successfully upgraded.
+	// +optional
+	Succeeded int `json:"succeeded,omitempty"`
+	// The number of managed clusters whose upgrade failed.
+	// +optional
+	Failed int `json:"failed,omitempty"`
+	// A message with details about the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=vfu;vfus
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.succeeded"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VerrazzanoFleetUpgrade specifies the API to upgrade Verrazzano on the managed clusters.
+type VerrazzanoFleetUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of a fleet upgrade.
+	Spec VerrazzanoFleetUpgradeSpec `json:"spec,omitempty"`
+	// The observed state of a fleet upgrade.
+	Status VerrazzanoFleetUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VerrazzanoFleetUpgradeList contains a list of VerrazzanoFleetUpgrade resources.
+type VerrazzanoFleetUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerrazzanoFleetUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VerrazzanoFleetUpgrade{}, &VerrazzanoFleetUpgradeList{})
+}
@@ -33,6 +33,44 @@ type VerrazzanoManagedClusterSpec struct {
 	// Verrazzano Kubernetes operator.
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// The policy used to evaluate the health of the managed cluster, and the actions taken on unhealthy clusters.
+	// +optional
+	HealthPolicy *HealthPolicy `json:"healthPolicy,omitempty"`
+
+	// The Verrazzano upgrade requested on the managed cluster, performed by the agent of the managed cluster. This
+	// field is managed by the VerrazzanoFleetUpgrade controller.
+	// +optional
+	Upgrade *ManagedClusterUpgrade `json:"upgrade,omitempty"`
+}
+
+// ManagedClusterUpgrade defines a Verrazzano upgrade of a managed cluster.
+type ManagedClusterUpgrade struct {
+	// The Verrazzano version the managed cluster is upgraded to.
+	Version string `json:"version"`
+	// The URL of the Verrazzano platform operator manifest of the version, applied to the managed cluster before the
+	// upgrade. Defaults to the platform operator manifest of the Verrazzano release. The platform operator of the managed
+	// cluster only applies the manifests whose URL starts with its upgrade manifest URL prefix.
+	// +optional
+	PlatformOperatorManifestURL string `json:"platformOperatorManifestURL,omitempty"`
+}
+
+// HealthPolicy defines when a managed cluster is considered degraded or unreachable, and how it is then handled.
+type HealthPolicy struct {
+	// The age of the last agent connection after which the managed cluster is degraded. Defaults to 5 minutes.
+	// +optional
+	DegradedAfter *metav1.Duration `json:"degradedAfter,omitempty"`
+	// The age of the last agent connection after which the managed cluster is unreachable. Defaults to 15 minutes.
+	// +optional
+	UnreachableAfter *metav1.Duration `json:"unreachableAfter,omitempty"`
+	// If true, new multicluster resources cannot be placed on the managed cluster while it is degraded. New
+	// multicluster resources can never be placed on an unreachable managed cluster.
+	// +optional
+	DisablePlacementWhenDegraded bool `json:"disablePlacementWhenDegraded,omitempty"`
+	// The time a managed cluster can stay unreachable before it is deregistered by deleting this resource. If not
+	// set, unreachable managed clusters are never deregistered.
+	// +optional
+	DeregisterAfter *metav1.Duration `json:"deregisterAfter,omitempty"`
 }
 
 // ConditionType identifies the condition of the Verrazzano Managed Cluster which can be checked with `kubectl wait`.
@@ -60,6 +98,17 @@ const (
 	StatePending  StateType = "Pending"
 )
 
+// HealthStateType identifies the health state of the Verrazzano Managed Cluster.
+type HealthStateType string
+
+const (
+	// HealthUnknown means the agent of the managed cluster has not connected to the admin cluster yet
+	HealthUnknown     HealthStateType = "Unknown"
+	HealthHealthy     HealthStateType = "Healthy"
+	HealthDegraded    HealthStateType = "Degraded"
+	HealthUnreachable HealthStateType = "Unreachable"
+)
+
 // Condition describes a condition that occurred on the Verrazzano Managed Cluster.
 type Condition struct {
 	// Last time the condition transitioned from one status to another.
@@ -117,6 +166,25 @@ type ArgoCDRegistration struct {
 	Message string `json:"message,omitempty"`
 }
 
+// HealthStatus defines the health of a managed cluster.
+type HealthStatus struct {
+	// The health score of the managed cluster, from 0 (unreachable) to 100 (healthy). The score is computed from the
+	// age of the last agent connection, the reachability of the managed cluster metrics endpoint and the state of the
+	// Rancher registration.
+	Score int `json:"score"`
+	// The health state of the managed cluster.
+	State HealthStateType `json:"state"`
+	// The last time the health state changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// A message with details about the failed health checks.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// True if new multicluster resources cannot be placed on the managed cluster.
+	// +optional
+	PlacementDisabled bool `json:"placementDisabled,omitempty"`
+}
+
 // VerrazzanoManagedClusterStatus defines the observed state of a Verrazzano Managed Cluster.
 type VerrazzanoManagedClusterStatus struct {
 	// The Verrazzano API server URL for this managed cluster.
@@ -135,6 +203,28 @@ type VerrazzanoManagedClusterStatus struct {
 	ArgoCDRegistration ArgoCDRegistration `json:"argoCDRegistration,omitempty"`
 	// The state of this managed cluster.
 	State StateType `json:"state"`
+	// The health of this managed cluster.
+	// +optional
+	Health *HealthStatus `json:"health,omitempty"`
+	// The status of the Verrazzano upgrade of this managed cluster, reported by its agent.
+	// +optional
+	Upgrade *ManagedClusterUpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// ManagedClusterUpgradeStatus defines the status of a Verrazzano upgrade of a managed cluster.
+type ManagedClusterUpgradeStatus struct {
+	// The Verrazzano version the managed cluster is upgraded to.
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// The Verrazzano version installed on the managed cluster, from the status of its Verrazzano resource.
+	Version string `json:"version,omitempty"`
+	// The state of the Verrazzano resource of the managed cluster.
+	State string `json:"state,omitempty"`
+	// A message with details about the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The last time the agent reported the upgrade status.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,449 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package capi
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/vmc"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	finalizerName = "managedclustertemplate.clusters.verrazzano.io"
+
+	// The names of the kubeconfig Secret created by Cluster API for a cluster and of its data key
+	kubeconfigSecretTemplate = "%s-kubeconfig"
+	kubeconfigKey            = "value"
+
+	verrazzanoName      = "verrazzano"
+	verrazzanoNamespace = "default"
+	managedProfile      = "managed-cluster"
+	manifestKey         = "yaml"
+
+	// Provisioning a cluster and installing Verrazzano take minutes, the progress is checked periodically
+	provisioningCheckPeriod = 30 * time.Second
+)
+
+var clusterGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"}
+
+// ManagedClusterTemplateReconciler provisions the cluster of a ManagedClusterTemplate with Cluster API, installs
+// Verrazzano with the managed cluster profile on it and registers it with a VerrazzanoManagedCluster.
+type ManagedClusterTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	log    vzlog.VerrazzanoLogger
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *ManagedClusterTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustersv1alpha1.ManagedClusterTemplate{}).
+		Complete(r)
+}
+
+// Reconcile provisions, installs and registers the managed cluster of a ManagedClusterTemplate, or removes it
+func (r *ManagedClusterTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, goerrors.New("context cannot be nil")
+	}
+	mct := &clustersv1alpha1.ManagedClusterTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, mct); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		zap.S().Errorf("Failed to fetch ManagedClusterTemplate resource: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+
+	// Get the resource logger needed to log message using 'progress' and 'once' methods
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           mct.Name,
+		Namespace:      mct.Namespace,
+		ID:             string(mct.UID),
+		Generation:     mct.Generation,
+		ControllerName: "managedclustertemplate",
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for managed cluster template controller: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+	r.log = log
+
+	log.Oncef("Reconciling managed cluster template %v", req.NamespacedName)
+	res, err := r.doReconcile(ctx, log, mct)
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		log.Errorf("Failed to reconcile managed cluster template %v: %v", req.NamespacedName, err)
+		return newRequeueWithDelay(), nil
+	}
+	return res, nil
+}
+
+// doReconcile drives the managed cluster through its phases, the status is updated at the end of each reconcile
+func (r *ManagedClusterTemplateReconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger, mct *clustersv1alpha1.ManagedClusterTemplate) (ctrl.Result, error) {
+	if !mct.DeletionTimestamp.IsZero() {
+		if !vzstring.SliceContainsString(mct.Finalizers, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		return r.deleteManagedCluster(ctx, log, mct)
+	}
+
+	if !vzstring.SliceContainsString(mct.Finalizers, finalizerName) {
+		mct.Finalizers = append(mct.Finalizers, finalizerName)
+		if err := r.Update(ctx, mct); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	res, err := r.reconcilePhases(ctx, log, mct)
+	if err != nil {
+		mct.Status.Message = err.Error()
+	}
+	if updateErr := r.Status().Update(ctx, mct); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	return res, err
+}
+
+// reconcilePhases runs the provisioning, installation and registration steps of the managed cluster, each step is
+// only started once the previous one has completed
+func (r *ManagedClusterTemplateReconciler) reconcilePhases(ctx context.Context, log vzlog.VerrazzanoLogger, mct *clustersv1alpha1.ManagedClusterTemplate) (ctrl.Result, error) {
+	resources, err := renderClusterResources(mct)
+	if err != nil {
+		// The spec is invalid, there is no point retrying until it is changed
+		log.Errorf("Invalid managed cluster template %s/%s: %v", mct.Namespace, mct.Name, err)
+		mct.Status.Phase = clustersv1alpha1.ManagedClusterFailed
+		mct.Status.Message = err.Error()
+		return ctrl.Result{}, nil
+	}
+
+	// Provision the cluster
+	if mct.Status.Phase == "" || mct.Status.Phase == clustersv1alpha1.ManagedClusterFailed {
+		mct.Status.Phase = clustersv1alpha1.ManagedClusterProvisioning
+	}
+	if err := k8sutil.NewYAMLApplier(r.Client, "").ApplyS(resources); err != nil {
+		return ctrl.Result{}, err
+	}
+	kubeconfig, err := r.getKubeconfig(ctx, mct)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if kubeconfig == nil {
+		mct.Status.Message = "Waiting for Cluster API to provision the cluster"
+		setCondition(mct, clustersv1alpha1.ConditionClusterProvisioned, corev1.ConditionFalse, mct.Status.Message)
+		return ctrl.Result{RequeueAfter: provisioningCheckPeriod}, nil
+	}
+	mct.Status.KubeconfigSecret = fmt.Sprintf(kubeconfigSecretTemplate, mct.Name)
+	setCondition(mct, clustersv1alpha1.ConditionClusterProvisioned, corev1.ConditionTrue, "The cluster is provisioned")
+
+	workloadClient, err := newWorkloadClient(kubeconfig)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Install Verrazzano
+	if !isConditionTrue(mct, clustersv1alpha1.ConditionVerrazzanoInstalled) {
+		mct.Status.Phase = clustersv1alpha1.ManagedClusterInstallingVerrazzano
+		ready, err := r.installVerrazzano(ctx, log, mct, workloadClient)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !ready {
+			mct.Status.Message = "Waiting for Verrazzano to be installed on the cluster"
+			setCondition(mct, clustersv1alpha1.ConditionVerrazzanoInstalled, corev1.ConditionFalse, mct.Status.Message)
+			return ctrl.Result{RequeueAfter: provisioningCheckPeriod}, nil
+		}
+		setCondition(mct, clustersv1alpha1.ConditionVerrazzanoInstalled, corev1.ConditionTrue, "Verrazzano is installed on the cluster")
+	}
+
+	// Register the cluster
+	if !isConditionTrue(mct, clustersv1alpha1.ConditionClusterRegistered) {
+		mct.Status.Phase = clustersv1alpha1.ManagedClusterRegistering
+		registered, err := r.registerCluster(ctx, log, mct, workloadClient)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !registered {
+			mct.Status.Message = "Waiting for the agent of the cluster to connect to the admin cluster"
+			setCondition(mct, clustersv1alpha1.ConditionClusterRegistered, corev1.ConditionFalse, mct.Status.Message)
+			return ctrl.Result{RequeueAfter: provisioningCheckPeriod}, nil
+		}
+		setCondition(mct, clustersv1alpha1.ConditionClusterRegistered, corev1.ConditionTrue, "The cluster is registered")
+	}
+
+	if mct.Status.Phase != clustersv1alpha1.ManagedClusterReady {
+		log.Infof("Managed cluster %s is ready", mct.Name)
+	}
+	mct.Status.Phase = clustersv1alpha1.ManagedClusterReady
+	mct.Status.Message = "The cluster is provisioned and registered"
+	return ctrl.Result{}, nil
+}
+
+// getKubeconfig returns the kubeconfig of the cluster once Cluster API has provisioned it and its control plane is
+// ready, or nil if it is not provisioned yet
+func (r *ManagedClusterTemplateReconciler) getKubeconfig(ctx context.Context, mct *clustersv1alpha1.ManagedClusterTemplate) ([]byte, error) {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: mct.Namespace, Name: mct.Name}, cluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase")
+	controlPlaneReady, _, _ := unstructured.NestedBool(cluster.Object, "status", "controlPlaneReady")
+	if phase != "Provisioned" || !controlPlaneReady {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: mct.Namespace, Name: fmt.Sprintf(kubeconfigSecretTemplate, mct.Name)}, secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	kubeconfig, ok := secret.Data[kubeconfigKey]
+	if !ok {
+		return nil, nil
+	}
+	return kubeconfig, nil
+}
+
+// installVerrazzano applies the CNI and the platform operator manifests to the cluster and creates a Verrazzano
+// resource with the managed cluster profile. It returns true once Verrazzano is ready.
+func (r *ManagedClusterTemplateReconciler) installVerrazzano(ctx context.Context, log vzlog.VerrazzanoLogger, mct *clustersv1alpha1.ManagedClusterTemplate, workloadClient client.Client) (bool, error) {
+	vz := &v1beta1.Verrazzano{}
+	err := workloadClient.Get(ctx, types.NamespacedName{Namespace: verrazzanoNamespace, Name: verrazzanoName}, vz)
+	if err == nil {
+		return vz.Status.State == v1beta1.VzStateReady, nil
+	}
+	if !errors.IsNotFound(err) && !isNoMatchError(err) {
+		return false, err
+	}
+
+	if url := getCNIManifestURL(mct); url != "" {
+		log.Oncef("Applying the CNI manifest %s to managed cluster %s", url, mct.Name)
+		if err := applyManifest(workloadClient, url); err != nil {
+			return false, err
+		}
+	}
+	url, err := r.getPlatformOperatorManifestURL(ctx, mct)
+	if err != nil {
+		return false, err
+	}
+	log.Oncef("Applying the Verrazzano platform operator manifest %s to managed cluster %s", url, mct.Name)
+	if err := applyManifest(workloadClient, url); err != nil {
+		return false, err
+	}
+
+	vz = &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: verrazzanoNamespace, Name: verrazzanoName},
+		Spec:       v1beta1.VerrazzanoSpec{Profile: managedProfile},
+	}
+	if err := workloadClient.Create(ctx, vz); err != nil {
+		// The Verrazzano CRD is created by the platform operator, retry until it is installed
+		if isNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	log.Infof("Created the Verrazzano resource on managed cluster %s", mct.Name)
+	return false, nil
+}
+
+// registerCluster creates the VerrazzanoManagedCluster of the cluster and applies its registration manifest to the
+// cluster. It returns true once the agent of the cluster has connected to the admin cluster.
+func (r *ManagedClusterTemplateReconciler) registerCluster(ctx context.Context, log vzlog.VerrazzanoLogger, mct *clustersv1alpha1.ManagedClusterTemplate, workloadClient client.Client) (bool, error) {
+	vmcName := types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: getManagedClusterName(mct)}
+	managedCluster := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	if err := r.Get(ctx, vmcName, managedCluster); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		managedCluster = &clustersv1alpha1.VerrazzanoManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: vmcName.Namespace,
+				Name:      vmcName.Name,
+				Labels:    map[string]string{templateLabel: mct.Name, templateNamespaceLabel: mct.Namespace},
+			},
+			Spec: clustersv1alpha1.VerrazzanoManagedClusterSpec{Description: mct.Spec.Description},
+		}
+		if err := r.Create(ctx, managedCluster); err != nil {
+			return false, err
+		}
+		log.Infof("Created VerrazzanoManagedCluster %s for managed cluster template %s/%s", vmcName.Name, mct.Namespace, mct.Name)
+	} else if !isTemplateManagedCluster(mct, managedCluster) {
+		// Never adopt a cluster registered by hand or by another template
+		return false, fmt.Errorf("VerrazzanoManagedCluster %s already exists and was not created for managed cluster template %s/%s", vmcName.Name, mct.Namespace, mct.Name)
+	}
+	mct.Status.ManagedCluster = vmcName.Name
+	if managedCluster.Status.LastAgentConnectTime != nil {
+		return true, nil
+	}
+
+	// The registration manifest is generated by the VMC controller
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vmcName.Namespace, Name: vmc.GetManifestSecretName(vmcName.Name)}, secret); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	manifest, ok := secret.Data[manifestKey]
+	if !ok {
+		return false, nil
+	}
+	if err := k8sutil.NewYAMLApplier(workloadClient, "").ApplyS(string(manifest)); err != nil {
+		return false, err
+	}
+	log.Oncef("Applied the registration manifest to managed cluster %s", mct.Name)
+	return false, nil
+}
+
+// deleteManagedCluster deregisters the managed cluster and deletes the Cluster API resources of the cluster
+func (r *ManagedClusterTemplateReconciler) deleteManagedCluster(ctx context.Context, log vzlog.VerrazzanoLogger, mct *clustersv1alpha1.ManagedClusterTemplate) (ctrl.Result, error) {
+	if mct.Status.Phase != clustersv1alpha1.ManagedClusterDeleting {
+		mct.Status.Phase = clustersv1alpha1.ManagedClusterDeleting
+		mct.Status.Message = "Deleting the cluster"
+		if err := r.Status().Update(ctx, mct); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Only the VerrazzanoManagedCluster created for the template is deleted
+	managedCluster := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: getManagedClusterName(mct)}, managedCluster)
+	if client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	if err == nil && isTemplateManagedCluster(mct, managedCluster) {
+		if err := r.Delete(ctx, managedCluster); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Cluster API deletes the machines and the infrastructure of the cluster with the Cluster resource
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGVK)
+	err = r.Get(ctx, types.NamespacedName{Namespace: mct.Namespace, Name: mct.Name}, cluster)
+	if err == nil {
+		if cluster.GetDeletionTimestamp().IsZero() {
+			if err := r.Delete(ctx, cluster); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, err
+			}
+			log.Infof("Deleting the Cluster API cluster of managed cluster template %s/%s", mct.Namespace, mct.Name)
+		}
+		return ctrl.Result{RequeueAfter: provisioningCheckPeriod}, nil
+	}
+	if !errors.IsNotFound(err) && !isNoMatchError(err) {
+		return ctrl.Result{}, err
+	}
+
+	// The machine templates and bootstrap templates are not owned by the cluster
+	if resources, err := renderClusterResources(mct); err == nil {
+		if err := k8sutil.NewYAMLApplier(r.Client, "").DeleteS(resources); err != nil && !isNoMatchError(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	mct.Finalizers = vzstring.RemoveStringFromSlice(mct.Finalizers, finalizerName)
+	if err := r.Update(ctx, mct); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.Oncef("Deleted managed cluster template %s/%s", mct.Namespace, mct.Name)
+	return ctrl.Result{}, nil
+}
+
+// getPlatformOperatorManifestURL returns the URL of the platform operator manifest of the template, which defaults
+// to the manifest of the Verrazzano release installed on the admin cluster
+func (r *ManagedClusterTemplateReconciler) getPlatformOperatorManifestURL(ctx context.Context, mct *clustersv1alpha1.ManagedClusterTemplate) (string, error) {
+	if mct.Spec.Verrazzano.PlatformOperatorManifestURL != "" {
+		return mct.Spec.Verrazzano.PlatformOperatorManifestURL, nil
+	}
+	vzList := v1beta1.VerrazzanoList{}
+	if err := r.List(ctx, &vzList, &client.ListOptions{}); err != nil {
+		return "", err
+	}
+	if len(vzList.Items) == 0 || vzList.Items[0].Status.Version == "" {
+		return "", fmt.Errorf("Failed to find the version of Verrazzano installed on the admin cluster")
+	}
+	return fmt.Sprintf(platformOperatorURLTemplate, vzList.Items[0].Status.Version), nil
+}
+
+// getManagedClusterName returns the name of the VerrazzanoManagedCluster of the template, the templates of different
+// namespaces may have the same name
+func getManagedClusterName(mct *clustersv1alpha1.ManagedClusterTemplate) string {
+	return fmt.Sprintf("%s-%s", mct.Namespace, mct.Name)
+}
+
+// isTemplateManagedCluster returns true if the VerrazzanoManagedCluster was created for the template
+func isTemplateManagedCluster(mct *clustersv1alpha1.ManagedClusterTemplate, managedCluster *clustersv1alpha1.VerrazzanoManagedCluster) bool {
+	labels := managedCluster.GetLabels()
+	return labels[templateLabel] == mct.Name && labels[templateNamespaceLabel] == mct.Namespace
+}
+
+// applyManifest fetches a manifest and applies it to the cluster
+func applyManifest(workloadClient client.Client, url string) error {
+	manifest, err := fetchManifest(url)
+	if err != nil {
+		return err
+	}
+	return k8sutil.NewYAMLApplier(workloadClient, "").ApplyS(manifest)
+}
+
+// setCondition sets a condition of the managed cluster template, the transition time is only changed with the status
+func setCondition(mct *clustersv1alpha1.ManagedClusterTemplate, conditionType clustersv1alpha1.ConditionType, status corev1.ConditionStatus, message string) {
+	now := metav1.Now()
+	for i, condition := range mct.Status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != status {
+			mct.Status.Conditions[i].LastTransitionTime = &now
+		}
+		mct.Status.Conditions[i].Status = status
+		mct.Status.Conditions[i].Message = message
+		return
+	}
+	mct.Status.Conditions = append(mct.Status.Conditions, clustersv1alpha1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Message:            message,
+		LastTransitionTime: &now,
+	})
+}
+
+// isConditionTrue returns true if the condition of the managed cluster template is true
+func isConditionTrue(mct *clustersv1alpha1.ManagedClusterTemplate, conditionType clustersv1alpha1.ConditionType) bool {
+	for _, condition := range mct.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isNoMatchError returns true if the error is caused by a missing CRD
+func isNoMatchError(err error) bool {
+	return err != nil && (meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err))
+}
+
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(2, 3, time.Second)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package capi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/vmc"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testName      = "managed1"
+	testNamespace = "clusters"
+	testVMCName   = testNamespace + "-" + testName
+
+	configMapManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: default
+`
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	return scheme
+}
+
+// statusClient keeps the status of the Cluster API clusters on update, like the API server which ignores the status
+// changes in the updates of the main resource
+type statusClient struct {
+	client.Client
+}
+
+func (c statusClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok && u.GroupVersionKind() == clusterGVK {
+		existing, err := getUnstructured(c.Client, clusterGVK.Group, clusterGVK.Version, clusterGVK.Kind, u.GetName())
+		if err != nil {
+			return err
+		}
+		if status, ok := existing.Object["status"]; ok {
+			u.Object["status"] = status
+		}
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func newTemplate(provider clustersv1alpha1.ProviderType) *clustersv1alpha1.ManagedClusterTemplate {
+	return &clustersv1alpha1.ManagedClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec: clustersv1alpha1.ManagedClusterTemplateSpec{
+			Provider:          provider,
+			KubernetesVersion: "v1.25.7",
+			Description:       "test cluster",
+		},
+	}
+}
+
+func reconcileTemplate(t *testing.T, cli client.Client) *clustersv1alpha1.ManagedClusterTemplate {
+	r := &ManagedClusterTemplateReconciler{Client: cli, Scheme: newScheme()}
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName}})
+	assert.NoError(t, err)
+	mct := &clustersv1alpha1.ManagedClusterTemplate{}
+	if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, mct); err != nil {
+		assert.True(t, errors.IsNotFound(err))
+		return nil
+	}
+	return mct
+}
+
+func getUnstructured(cli client.Client, group, version, kind, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: name}, obj)
+	return obj, err
+}
+
+// TestRenderDockerCluster tests rendering the Cluster API resources of a Docker cluster
+// GIVEN a docker managed cluster template with a worker pool
+// WHEN the cluster resources are rendered
+// THEN the kubeadm and Docker resources are rendered with the defaults of the unset fields
+func TestRenderDockerCluster(t *testing.T) {
+	mct := newTemplate(clustersv1alpha1.ProviderDocker)
+	replicas := int32(3)
+	mct.Spec.WorkerPools = []clustersv1alpha1.WorkerPoolSpec{{Name: "pool1", Replicas: &replicas}}
+
+	resources, err := renderClusterResources(mct)
+	assert.NoError(t, err)
+	assert.Contains(t, resources, "kind: KubeadmControlPlane")
+	assert.Contains(t, resources, "kind: DockerCluster")
+	assert.Contains(t, resources, "name: managed1-pool1")
+	assert.Contains(t, resources, "replicas: 3")
+	assert.Contains(t, resources, "- "+defaultDockerPodCIDR)
+	assert.Contains(t, resources, "- "+defaultServiceCIDR)
+	assert.NotContains(t, resources, "OCI")
+	assert.Equal(t, defaultCNIManifest, getCNIManifestURL(mct))
+}
+
+// TestRenderOCICluster tests rendering the Cluster API resources of an OCI cluster
+// GIVEN oci managed cluster templates with and without the OCI configuration
+// WHEN the cluster resources are rendered
+// THEN the OCNE and OCI resources are rendered, and the OCI configuration is required
+func TestRenderOCICluster(t *testing.T) {
+	mct := newTemplate(clustersv1alpha1.ProviderOCI)
+	_, err := renderClusterResources(mct)
+	assert.Error(t, err)
+
+	mct.Spec.OCI = &clustersv1alpha1.OCIProviderSpec{CompartmentID: "ocid1.compartment", ImageID: "ocid1.image", SSHPublicKey: "ssh-rsa key"}
+	resources, err := renderClusterResources(mct)
+	assert.NoError(t, err)
+	assert.Contains(t, resources, "kind: OCNEControlPlane")
+	assert.Contains(t, resources, "kind: OCNEConfigTemplate")
+	assert.Contains(t, resources, "name: managed1-md-0")
+	assert.Contains(t, resources, "compartmentId: ocid1.compartment")
+	assert.Contains(t, resources, "shape: "+defaultOCIShape)
+	assert.Contains(t, resources, `ssh_authorized_keys: "ssh-rsa key"`)
+	assert.Contains(t, resources, `provider-id: "oci://{{ ds[\"id\"] }}"`)
+	assert.Contains(t, resources, "- "+defaultOCIPodCIDR)
+	assert.Empty(t, getCNIManifestURL(mct))
+}
+
+// TestProvisionManagedCluster tests provisioning, installing and registering a managed cluster
+// GIVEN a docker managed cluster template
+// WHEN the template is reconciled as Cluster API, Verrazzano and the agent progress
+// THEN the cluster resources are created, Verrazzano is installed, the cluster is registered and the phase is Ready
+func TestProvisionManagedCluster(t *testing.T) {
+	workloadClient := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	var fetched []string
+	savedNewWorkloadClient, savedFetchManifest := newWorkloadClient, fetchManifest
+	newWorkloadClient = func(kubeconfig []byte) (client.Client, error) { return workloadClient, nil }
+	fetchManifest = func(url string) (string, error) {
+		fetched = append(fetched, url)
+		return fmt.Sprintf(configMapManifest, fmt.Sprintf("manifest%d", len(fetched))), nil
+	}
+	defer func() {
+		newWorkloadClient = savedNewWorkloadClient
+		fetchManifest = savedFetchManifest
+	}()
+
+	adminVZ := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "default"},
+		Status:     v1beta1.VerrazzanoStatus{Version: "1.5.2"},
+	}
+	cli := statusClient{fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTemplate(clustersv1alpha1.ProviderDocker), adminVZ).Build()}
+
+	// Cluster API is provisioning the cluster
+	mct := reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterProvisioning, mct.Status.Phase)
+	assert.Contains(t, mct.Finalizers, finalizerName)
+	cluster, err := getUnstructured(cli, "cluster.x-k8s.io", "v1beta1", "Cluster", testName)
+	assert.NoError(t, err)
+	assert.Equal(t, testName, cluster.GetLabels()[templateLabel])
+	_, err = getUnstructured(cli, "cluster.x-k8s.io", "v1beta1", "MachineDeployment", testName+"-md-0")
+	assert.NoError(t, err)
+
+	// The cluster is provisioned, Verrazzano is being installed
+	assert.NoError(t, unstructured.SetNestedField(cluster.Object, "Provisioned", "status", "phase"))
+	assert.NoError(t, unstructured.SetNestedField(cluster.Object, true, "status", "controlPlaneReady"))
+	assert.NoError(t, cli.Client.Update(context.TODO(), cluster))
+	assert.NoError(t, cli.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: testName + "-kubeconfig", Namespace: testNamespace},
+		Data:       map[string][]byte{kubeconfigKey: []byte("kubeconfig")},
+	}))
+	mct = reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterInstallingVerrazzano, mct.Status.Phase)
+	assert.Equal(t, testName+"-kubeconfig", mct.Status.KubeconfigSecret)
+	assert.True(t, isConditionTrue(mct, clustersv1alpha1.ConditionClusterProvisioned))
+	assert.Equal(t, []string{defaultCNIManifest, "https://github.com/verrazzano/verrazzano/releases/download/v1.5.2/verrazzano-platform-operator.yaml"}, fetched)
+	vz := &v1beta1.Verrazzano{}
+	assert.NoError(t, workloadClient.Get(context.TODO(), types.NamespacedName{Namespace: verrazzanoNamespace, Name: verrazzanoName}, vz))
+	assert.Equal(t, v1beta1.ProfileType(managedProfile), vz.Spec.Profile)
+
+	// Verrazzano is ready, the cluster is being registered
+	vz.Status.State = v1beta1.VzStateReady
+	assert.NoError(t, workloadClient.Update(context.TODO(), vz))
+	mct = reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterRegistering, mct.Status.Phase)
+	assert.True(t, isConditionTrue(mct, clustersv1alpha1.ConditionVerrazzanoInstalled))
+	assert.Equal(t, testVMCName, mct.Status.ManagedCluster)
+	managedCluster := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: testVMCName}, managedCluster))
+	assert.Equal(t, "test cluster", managedCluster.Spec.Description)
+	assert.Equal(t, map[string]string{templateLabel: testName, templateNamespaceLabel: testNamespace}, managedCluster.Labels)
+
+	// The registration manifest is applied to the cluster
+	assert.NoError(t, cli.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: vmc.GetManifestSecretName(testVMCName), Namespace: vzconstants.VerrazzanoMultiClusterNamespace},
+		Data:       map[string][]byte{manifestKey: []byte(fmt.Sprintf(configMapManifest, "registration"))},
+	}))
+	mct = reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterRegistering, mct.Status.Phase)
+	assert.NoError(t, workloadClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "registration"}, &corev1.ConfigMap{}))
+
+	// The agent has connected, the cluster is ready
+	connectTime := metav1.Now()
+	managedCluster.Status.LastAgentConnectTime = &connectTime
+	assert.NoError(t, cli.Update(context.TODO(), managedCluster))
+	mct = reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterReady, mct.Status.Phase)
+	assert.True(t, isConditionTrue(mct, clustersv1alpha1.ConditionClusterRegistered))
+}
+
+// TestInvalidManagedClusterTemplate tests reconciling an invalid managed cluster template
+// GIVEN an oci managed cluster template without the OCI configuration
+// WHEN the template is reconciled
+// THEN the phase is Failed and no cluster resources are created
+func TestInvalidManagedClusterTemplate(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTemplate(clustersv1alpha1.ProviderOCI)).Build()
+
+	mct := reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterFailed, mct.Status.Phase)
+	assert.Contains(t, mct.Status.Message, "OCI compartment and image")
+	_, err := getUnstructured(cli, "cluster.x-k8s.io", "v1beta1", "Cluster", testName)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestDeleteManagedCluster tests deleting a managed cluster template
+// GIVEN a provisioned and registered managed cluster template being deleted
+// WHEN the template is reconciled
+// THEN the VMC and the Cluster API resources are deleted, then the finalizer is removed
+func TestDeleteManagedCluster(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newTemplate(clustersv1alpha1.ProviderDocker)).Build()
+	mct := reconcileTemplate(t, cli)
+	assert.NoError(t, cli.Create(context.TODO(), &clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testVMCName,
+			Namespace: vzconstants.VerrazzanoMultiClusterNamespace,
+			Labels:    map[string]string{templateLabel: testName, templateNamespaceLabel: testNamespace},
+		},
+	}))
+	assert.NoError(t, cli.Delete(context.TODO(), mct))
+
+	// The Cluster is deleted first, Cluster API deletes the machines
+	mct = reconcileTemplate(t, cli)
+	assert.Equal(t, clustersv1alpha1.ManagedClusterDeleting, mct.Status.Phase)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: testVMCName}, &clustersv1alpha1.VerrazzanoManagedCluster{})
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getUnstructured(cli, "cluster.x-k8s.io", "v1beta1", "Cluster", testName)
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getUnstructured(cli, "infrastructure.cluster.x-k8s.io", "v1beta1", "DockerMachineTemplate", testName+"-control-plane")
+	assert.NoError(t, err)
+
+	// Once the Cluster is gone, the templates are deleted
+	mct = reconcileTemplate(t, cli)
+	assert.Nil(t, mct)
+	_, err = getUnstructured(cli, "infrastructure.cluster.x-k8s.io", "v1beta1", "DockerMachineTemplate", testName+"-control-plane")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestRegisterExistingManagedCluster tests registering a cluster whose VMC name is already taken
+// GIVEN a managed cluster template, and a VMC with the same name which was not created for the template
+// WHEN the cluster is registered, and the template is deleted
+// THEN the registration fails without changing the VMC, and the VMC is not deleted with the template
+func TestRegisterExistingManagedCluster(t *testing.T) {
+	existing := &clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testVMCName, Namespace: vzconstants.VerrazzanoMultiClusterNamespace},
+		Spec:       clustersv1alpha1.VerrazzanoManagedClusterSpec{Description: "registered by hand"},
+	}
+	mct := newTemplate(clustersv1alpha1.ProviderDocker)
+	mct.Finalizers = []string{finalizerName}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(mct, existing).Build()
+	r := &ManagedClusterTemplateReconciler{Client: cli, Scheme: newScheme()}
+	log := vzlog.DefaultLogger()
+
+	registered, err := r.registerCluster(context.TODO(), log, mct, fake.NewClientBuilder().WithScheme(newScheme()).Build())
+	assert.False(t, registered)
+	assert.ErrorContains(t, err, "already exists")
+	assert.Empty(t, mct.Status.ManagedCluster)
+
+	assert.NoError(t, cli.Delete(context.TODO(), mct))
+	reconcileTemplate(t, cli)
+	reconcileTemplate(t, cli)
+	managedCluster := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(existing), managedCluster))
+	assert.Equal(t, "registered by hand", managedCluster.Spec.Description)
+}
@@ -0,0 +1,423 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package capi
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+)
+
+const (
+	templateLabel = "clusters.verrazzano.io/managed-cluster-template"
+	// templateNamespaceLabel is the namespace of the template of a VerrazzanoManagedCluster, whose name is in the
+	// template label
+	templateNamespaceLabel = "clusters.verrazzano.io/managed-cluster-template-namespace"
+
+	defaultDockerPodCIDR = "192.168.0.0/16"
+	defaultOCIPodCIDR    = "10.244.0.0/16"
+	defaultServiceCIDR   = "10.96.0.0/16"
+	defaultOCIShape      = "VM.Standard.E4.Flex"
+	defaultWorkerPool    = "md-0"
+	defaultCNIManifest   = "https://raw.githubusercontent.com/projectcalico/calico/v3.25.1/manifests/calico.yaml"
+
+	// ociProviderID is the provider ID of the OCI nodes, rendered by cloud-init from the instance metadata
+	ociProviderID = `oci://{{ ds["id"] }}`
+)
+
+// clusterTemplate is the Cluster API cluster, common to all the providers
+const clusterTemplate = `
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: Cluster
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  clusterNetwork:
+    pods:
+      cidrBlocks:
+      - {{.PodCIDR}}
+    services:
+      cidrBlocks:
+      - {{.ServiceCIDR}}
+    serviceDomain: cluster.local
+  controlPlaneRef:
+    apiVersion: controlplane.cluster.x-k8s.io/{{.ControlPlaneVersion}}
+    kind: {{.ControlPlaneKind}}
+    name: {{.Name}}-control-plane
+  infrastructureRef:
+    apiVersion: infrastructure.cluster.x-k8s.io/{{.InfrastructureVersion}}
+    kind: {{.ClusterKind}}
+    name: {{.Name}}
+{{- range .WorkerPools}}
+---
+apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: {{$.Name}}-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.Label}}: {{$.Name}}
+spec:
+  clusterName: {{$.Name}}
+  replicas: {{.Replicas}}
+  selector:
+    matchLabels: null
+  template:
+    spec:
+      clusterName: {{$.Name}}
+      version: {{$.KubernetesVersion}}
+      bootstrap:
+        configRef:
+          apiVersion: bootstrap.cluster.x-k8s.io/{{$.BootstrapVersion}}
+          kind: {{$.BootstrapKind}}
+          name: {{$.Name}}-{{.Name}}
+      infrastructureRef:
+        apiVersion: infrastructure.cluster.x-k8s.io/{{$.InfrastructureVersion}}
+        kind: {{$.MachineTemplateKind}}
+        name: {{$.Name}}-{{.Name}}
+{{- end}}
+`
+
+// dockerTemplate is the infrastructure, control plane and bootstrap configuration of a cluster provisioned with the
+// Cluster API Docker provider and the kubeadm providers
+const dockerTemplate = `
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: DockerCluster
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec: {}
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: DockerMachineTemplate
+metadata:
+  name: {{.Name}}-control-plane
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  template:
+    spec:
+      extraMounts:
+      - containerPath: /var/run/docker.sock
+        hostPath: /var/run/docker.sock
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: {{.Name}}-control-plane
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  replicas: {{.ControlPlaneReplicas}}
+  version: {{.KubernetesVersion}}
+  machineTemplate:
+    infrastructureRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+      kind: DockerMachineTemplate
+      name: {{.Name}}-control-plane
+  kubeadmConfigSpec:
+    clusterConfiguration:
+      apiServer:
+        certSANs:
+        - localhost
+        - 127.0.0.1
+        - 0.0.0.0
+        - host.docker.internal
+      controllerManager:
+        extraArgs:
+          enable-hostpath-provisioner: "true"
+    initConfiguration:
+      nodeRegistration:
+        criSocket: unix:///var/run/containerd/containerd.sock
+        kubeletExtraArgs:
+          eviction-hard: nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%
+    joinConfiguration:
+      nodeRegistration:
+        criSocket: unix:///var/run/containerd/containerd.sock
+        kubeletExtraArgs:
+          eviction-hard: nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%
+{{- range .WorkerPools}}
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: DockerMachineTemplate
+metadata:
+  name: {{$.Name}}-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.Label}}: {{$.Name}}
+spec:
+  template:
+    spec:
+      extraMounts:
+      - containerPath: /var/run/docker.sock
+        hostPath: /var/run/docker.sock
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: {{$.Name}}-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.Label}}: {{$.Name}}
+spec:
+  template:
+    spec:
+      joinConfiguration:
+        nodeRegistration:
+          criSocket: unix:///var/run/containerd/containerd.sock
+          kubeletExtraArgs:
+            eviction-hard: nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%
+{{- end}}
+`
+
+// ociTemplate is the infrastructure, control plane and bootstrap configuration of a cluster provisioned with the
+// Cluster API OCI provider and the Oracle Cloud Native Environment providers
+const ociTemplate = `
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: OCICluster
+metadata:
+  name: {{.Name}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  compartmentId: {{.OCI.CompartmentID}}
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: OCIMachineTemplate
+metadata:
+  name: {{.Name}}-control-plane
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  template:
+    spec:
+      compartmentId: {{.OCI.CompartmentID}}
+      imageId: {{.OCI.ImageID}}
+      shape: {{.ControlPlaneShape}}
+      shapeConfig:
+        ocpus: "2"
+      isPvEncryptionInTransitEnabled: false
+{{- if .OCI.SSHPublicKey}}
+      metadata:
+        ssh_authorized_keys: {{printf "%q" .OCI.SSHPublicKey}}
+{{- end}}
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1alpha1
+kind: OCNEControlPlane
+metadata:
+  name: {{.Name}}-control-plane
+  namespace: {{.Namespace}}
+  labels:
+    {{.Label}}: {{.Name}}
+spec:
+  replicas: {{.ControlPlaneReplicas}}
+  version: {{.KubernetesVersion}}
+  machineTemplate:
+    infrastructureRef:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: OCIMachineTemplate
+      name: {{.Name}}-control-plane
+  controlPlaneConfig:
+    clusterConfiguration:
+      apiServer:
+        certSANs:
+        - localhost
+        - 127.0.0.1
+    initConfiguration:
+      nodeRegistration:
+        criSocket: /var/run/crio/crio.sock
+        kubeletExtraArgs:
+          cloud-provider: external
+          provider-id: {{printf "%q" .ProviderID}}
+    joinConfiguration:
+      nodeRegistration:
+        criSocket: /var/run/crio/crio.sock
+        kubeletExtraArgs:
+          cloud-provider: external
+          provider-id: {{printf "%q" .ProviderID}}
+{{- range .WorkerPools}}
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: OCIMachineTemplate
+metadata:
+  name: {{$.Name}}-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.Label}}: {{$.Name}}
+spec:
+  template:
+    spec:
+      compartmentId: {{$.OCI.CompartmentID}}
+      imageId: {{$.OCI.ImageID}}
+      shape: {{.Shape}}
+      shapeConfig:
+        ocpus: "2"
+      isPvEncryptionInTransitEnabled: false
+{{- if $.OCI.SSHPublicKey}}
+      metadata:
+        ssh_authorized_keys: {{printf "%q" $.OCI.SSHPublicKey}}
+{{- end}}
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1alpha1
+kind: OCNEConfigTemplate
+metadata:
+  name: {{$.Name}}-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.Label}}: {{$.Name}}
+spec:
+  template:
+    spec:
+      clusterConfiguration: {}
+      joinConfiguration:
+        nodeRegistration:
+          criSocket: /var/run/crio/crio.sock
+          kubeletExtraArgs:
+            cloud-provider: external
+            provider-id: {{printf "%q" $.ProviderID}}
+{{- end}}
+`
+
+// templateParams are the parameters of the Cluster API templates
+type templateParams struct {
+	Name                  string
+	Namespace             string
+	Label                 string
+	KubernetesVersion     string
+	PodCIDR               string
+	ServiceCIDR           string
+	ControlPlaneReplicas  int32
+	ControlPlaneShape     string
+	WorkerPools           []workerPoolParams
+	OCI                   clustersv1alpha1.OCIProviderSpec
+	ProviderID            string
+	InfrastructureVersion string
+	ClusterKind           string
+	MachineTemplateKind   string
+	ControlPlaneVersion   string
+	ControlPlaneKind      string
+	BootstrapVersion      string
+	BootstrapKind         string
+}
+
+// workerPoolParams are the parameters of a worker pool in the Cluster API templates
+type workerPoolParams struct {
+	Name     string
+	Replicas int32
+	Shape    string
+}
+
+// renderClusterResources renders the Cluster API resources of the managed cluster as a YAML string
+func renderClusterResources(mct *clustersv1alpha1.ManagedClusterTemplate) (string, error) {
+	params, providerTemplate, err := newTemplateParams(mct)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("cluster").Option("missingkey=error").Parse(clusterTemplate + "---" + providerTemplate)
+	if err != nil {
+		return "", err
+	}
+	buffer := &bytes.Buffer{}
+	if err := tmpl.Execute(buffer, params); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// newTemplateParams returns the template parameters of the managed cluster with the defaults of the unset fields,
+// and the template of its provider
+func newTemplateParams(mct *clustersv1alpha1.ManagedClusterTemplate) (templateParams, string, error) {
+	spec := mct.Spec
+	if spec.KubernetesVersion == "" {
+		return templateParams{}, "", fmt.Errorf("The Kubernetes version of the cluster is required")
+	}
+	params := templateParams{
+		Name:                 mct.Name,
+		Namespace:            mct.Namespace,
+		Label:                templateLabel,
+		KubernetesVersion:    spec.KubernetesVersion,
+		PodCIDR:              spec.Network.PodCIDR,
+		ServiceCIDR:          spec.Network.ServiceCIDR,
+		ControlPlaneReplicas: 1,
+		ControlPlaneShape:    spec.ControlPlane.Shape,
+	}
+	if spec.ControlPlane.Replicas != nil {
+		params.ControlPlaneReplicas = *spec.ControlPlane.Replicas
+	}
+	if params.ControlPlaneShape == "" {
+		params.ControlPlaneShape = defaultOCIShape
+	}
+	if params.ServiceCIDR == "" {
+		params.ServiceCIDR = defaultServiceCIDR
+	}
+	for _, pool := range spec.WorkerPools {
+		poolParams := workerPoolParams{Name: pool.Name, Replicas: 1, Shape: pool.Shape}
+		if pool.Replicas != nil {
+			poolParams.Replicas = *pool.Replicas
+		}
+		if poolParams.Shape == "" {
+			poolParams.Shape = defaultOCIShape
+		}
+		params.WorkerPools = append(params.WorkerPools, poolParams)
+	}
+	if len(params.WorkerPools) == 0 {
+		params.WorkerPools = []workerPoolParams{{Name: defaultWorkerPool, Replicas: 1, Shape: defaultOCIShape}}
+	}
+
+	switch spec.Provider {
+	case clustersv1alpha1.ProviderDocker:
+		if params.PodCIDR == "" {
+			params.PodCIDR = defaultDockerPodCIDR
+		}
+		params.InfrastructureVersion = "v1beta1"
+		params.ClusterKind = "DockerCluster"
+		params.MachineTemplateKind = "DockerMachineTemplate"
+		params.ControlPlaneVersion = "v1beta1"
+		params.ControlPlaneKind = "KubeadmControlPlane"
+		params.BootstrapVersion = "v1beta1"
+		params.BootstrapKind = "KubeadmConfigTemplate"
+		return params, dockerTemplate, nil
+	case clustersv1alpha1.ProviderOCI:
+		if spec.OCI == nil || spec.OCI.CompartmentID == "" || spec.OCI.ImageID == "" {
+			return templateParams{}, "", fmt.Errorf("The OCI compartment and image of the cluster are required for the %s provider", spec.Provider)
+		}
+		if params.PodCIDR == "" {
+			params.PodCIDR = defaultOCIPodCIDR
+		}
+		params.OCI = *spec.OCI
+		params.ProviderID = ociProviderID
+		params.InfrastructureVersion = "v1beta2"
+		params.ClusterKind = "OCICluster"
+		params.MachineTemplateKind = "OCIMachineTemplate"
+		params.ControlPlaneVersion = "v1alpha1"
+		params.ControlPlaneKind = "OCNEControlPlane"
+		params.BootstrapVersion = "v1alpha1"
+		params.BootstrapKind = "OCNEConfigTemplate"
+		return params, ociTemplate, nil
+	}
+	return templateParams{}, "", fmt.Errorf("Unsupported Cluster API provider %s", spec.Provider)
+}
+
+// getCNIManifestURL returns the URL of the CNI manifest to apply to the managed cluster, or an empty string if the
+// provider installs the CNI
+func getCNIManifestURL(mct *clustersv1alpha1.ManagedClusterTemplate) string {
+	if mct.Spec.Network.CNIManifestURL != "" {
+		return mct.Spec.Network.CNIManifestURL
+	}
+	if mct.Spec.Provider == clustersv1alpha1.ProviderDocker {
+		return defaultCNIManifest
+	}
+	return ""
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fleetupgrade
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/semver"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultBatchSize             = 1
+	defaultClusterUpgradeTimeout = 2 * time.Hour
+
+	// The agents report the upgrade progress when they connect to the admin cluster, every minute
+	upgradeCheckPeriod = time.Minute
+)
+
+// VerrazzanoFleetUpgradeReconciler rolls out a Verrazzano upgrade to the managed clusters in batches. The upgrade of
+// each managed cluster is requested in its VMC and performed by its agent, which reports the state of its Verrazzano
+// resource in the VMC status.
+type VerrazzanoFleetUpgradeReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	log    vzlog.VerrazzanoLogger
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *VerrazzanoFleetUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustersv1alpha1.VerrazzanoFleetUpgrade{}).
+		Complete(r)
+}
+
+// Reconcile starts the upgrades of the next batch of managed clusters and tracks the upgrades in progress
+func (r *VerrazzanoFleetUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, goerrors.New("context cannot be nil")
+	}
+	fu := &clustersv1alpha1.VerrazzanoFleetUpgrade{}
+	if err := r.Get(ctx, req.NamespacedName, fu); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		zap.S().Errorf("Failed to fetch VerrazzanoFleetUpgrade resource: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+	if !fu.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Get the resource logger needed to log message using 'progress' and 'once' methods
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           fu.Name,
+		Namespace:      fu.Namespace,
+		ID:             string(fu.UID),
+		Generation:     fu.Generation,
+		ControllerName: "fleetupgrade",
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for fleet upgrade controller: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+	r.log = log
+
+	log.Oncef("Reconciling fleet upgrade %v", req.NamespacedName)
+	res, err := r.doReconcile(ctx, log, fu)
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		log.Errorf("Failed to reconcile fleet upgrade %v: %v", req.NamespacedName, err)
+		return newRequeueWithDelay(), nil
+	}
+	return res, nil
+}
+
+// doReconcile updates the upgrade states of the managed clusters, starts the next batch when the current one has
+// completed and halts the rollout when too many upgrades have failed
+func (r *VerrazzanoFleetUpgradeReconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger, fu *clustersv1alpha1.VerrazzanoFleetUpgrade) (ctrl.Result, error) {
+	if fu.Status.Phase == clustersv1alpha1.FleetUpgradeCompleted {
+		return ctrl.Result{}, nil
+	}
+	targetVersion, err := semver.NewSemVersion(fu.Spec.Version)
+	if err != nil {
+		fu.Status.Phase = clustersv1alpha1.FleetUpgradeHalted
+		fu.Status.Message = fmt.Sprintf("Invalid upgrade version %s: %v", fu.Spec.Version, err)
+		return ctrl.Result{}, r.Status().Update(ctx, fu)
+	}
+
+	// The fleet is the set of matching managed clusters when the upgrade starts
+	if fu.Status.Phase == "" {
+		if err := r.initClusters(ctx, fu); err != nil {
+			return ctrl.Result{}, err
+		}
+		fu.Status.Phase = clustersv1alpha1.FleetUpgradeInProgress
+		log.Infof("Upgrading %d managed clusters to Verrazzano version %s", len(fu.Status.Clusters), fu.Spec.Version)
+	}
+
+	// Track the upgrades in progress
+	upgrading := 0
+	for i := range fu.Status.Clusters {
+		cluster := &fu.Status.Clusters[i]
+		if cluster.State != clustersv1alpha1.ClusterUpgradeUpgrading {
+			continue
+		}
+		if err := r.updateClusterState(ctx, log, fu, cluster, targetVersion); err != nil {
+			return ctrl.Result{}, err
+		}
+		if cluster.State == clustersv1alpha1.ClusterUpgradeUpgrading {
+			upgrading++
+		}
+	}
+	countClusters(fu)
+
+	// Start the next batch once the current batch has completed, unless the rollout is halted
+	if fu.Status.Failed <= fu.Spec.MaxFailures && upgrading == 0 {
+		started, err := r.startBatch(ctx, log, fu)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		upgrading = started
+	}
+	if fu.Status.Failed > fu.Spec.MaxFailures {
+		if fu.Status.Phase != clustersv1alpha1.FleetUpgradeHalted {
+			log.Infof("Halted the fleet upgrade after %d failed managed cluster upgrades", fu.Status.Failed)
+		}
+		fu.Status.Phase = clustersv1alpha1.FleetUpgradeHalted
+		fu.Status.Message = fmt.Sprintf("The rollout is halted, %d managed cluster upgrades failed and at most %d are tolerated", fu.Status.Failed, fu.Spec.MaxFailures)
+	}
+
+	if fu.Status.Phase == clustersv1alpha1.FleetUpgradeInProgress {
+		if upgrading == 0 {
+			fu.Status.Phase = clustersv1alpha1.FleetUpgradeCompleted
+			fu.Status.Message = fmt.Sprintf("%d managed clusters upgraded, %d failed", fu.Status.Succeeded, fu.Status.Failed)
+			log.Infof("Completed the fleet upgrade: %s", fu.Status.Message)
+		} else {
+			fu.Status.Message = fmt.Sprintf("Upgrading %d managed clusters", upgrading)
+		}
+	}
+	if err := r.Status().Update(ctx, fu); err != nil {
+		return ctrl.Result{}, err
+	}
+	if upgrading > 0 {
+		return ctrl.Result{RequeueAfter: upgradeCheckPeriod}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// initClusters adds the managed clusters matching the selector to the status, in name order
+func (r *VerrazzanoFleetUpgradeReconciler) initClusters(ctx context.Context, fu *clustersv1alpha1.VerrazzanoFleetUpgrade) error {
+	selector := labels.Everything()
+	if fu.Spec.ClusterSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(fu.Spec.ClusterSelector); err != nil {
+			return err
+		}
+	}
+	vmcList := clustersv1alpha1.VerrazzanoManagedClusterList{}
+	if err := r.List(ctx, &vmcList, &client.ListOptions{Namespace: fu.Namespace, LabelSelector: selector}); err != nil {
+		return err
+	}
+	var names []string
+	for _, vmc := range vmcList.Items {
+		if vmc.DeletionTimestamp.IsZero() {
+			names = append(names, vmc.Name)
+		}
+	}
+	sort.Strings(names)
+	fu.Status.Clusters = nil
+	for _, name := range names {
+		fu.Status.Clusters = append(fu.Status.Clusters, clustersv1alpha1.ClusterUpgradeStatus{Name: name, State: clustersv1alpha1.ClusterUpgradePending})
+	}
+	return nil
+}
+
+// startBatch requests the upgrade of the next batch of pending managed clusters and returns the number of upgrades
+// started
+func (r *VerrazzanoFleetUpgradeReconciler) startBatch(ctx context.Context, log vzlog.VerrazzanoLogger, fu *clustersv1alpha1.VerrazzanoFleetUpgrade) (int, error) {
+	batchSize := fu.Spec.BatchSize
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+	started := 0
+	for i := range fu.Status.Clusters {
+		if started == batchSize {
+			break
+		}
+		cluster := &fu.Status.Clusters[i]
+		if cluster.State != clustersv1alpha1.ClusterUpgradePending {
+			continue
+		}
+		vmc := &clustersv1alpha1.VerrazzanoManagedCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: fu.Namespace, Name: cluster.Name}, vmc); err != nil {
+			if !errors.IsNotFound(err) {
+				return started, err
+			}
+			setClusterState(cluster, clustersv1alpha1.ClusterUpgradeFailed, "The managed cluster has been deregistered")
+			continue
+		}
+		vmc.Spec.Upgrade = &clustersv1alpha1.ManagedClusterUpgrade{
+			Version:                     fu.Spec.Version,
+			PlatformOperatorManifestURL: fu.Spec.PlatformOperatorManifestURL,
+		}
+		if err := r.Update(ctx, vmc); err != nil {
+			return started, err
+		}
+		now := metav1.Now()
+		cluster.StartTime = &now
+		setClusterState(cluster, clustersv1alpha1.ClusterUpgradeUpgrading, fmt.Sprintf("Requested the upgrade to version %s", fu.Spec.Version))
+		log.Infof("Requested the upgrade of managed cluster %s to Verrazzano version %s", cluster.Name, fu.Spec.Version)
+		started++
+	}
+	countClusters(fu)
+	return started, nil
+}
+
+// updateClusterState updates the upgrade state of a managed cluster from the upgrade status reported by its agent, the
+// upgrade request of the VMC is cleared when the upgrade has completed
+func (r *VerrazzanoFleetUpgradeReconciler) updateClusterState(ctx context.Context, log vzlog.VerrazzanoLogger, fu *clustersv1alpha1.VerrazzanoFleetUpgrade, cluster *clustersv1alpha1.ClusterUpgradeStatus, targetVersion *semver.SemVersion) error {
+	vmc := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: fu.Namespace, Name: cluster.Name}, vmc); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		setClusterState(cluster, clustersv1alpha1.ClusterUpgradeFailed, "The managed cluster has been deregistered")
+		log.Infof("The upgrade of managed cluster %s failed: %s", cluster.Name, cluster.Message)
+		return nil
+	}
+
+	status := vmc.Status.Upgrade
+	switch {
+	case status != nil && status.TargetVersion == fu.Spec.Version && isVersion(status.Version, targetVersion) && status.State == string(v1beta1.VzStateReady):
+		setClusterState(cluster, clustersv1alpha1.ClusterUpgradeSucceeded, fmt.Sprintf("Upgraded to version %s", status.Version))
+		log.Infof("Upgraded managed cluster %s to Verrazzano version %s", cluster.Name, status.Version)
+	case status != nil && status.TargetVersion == fu.Spec.Version && status.State == string(v1beta1.VzStateFailed):
+		setClusterState(cluster, clustersv1alpha1.ClusterUpgradeFailed, fmt.Sprintf("The Verrazzano resource is in state %s: %s", status.State, status.Message))
+		log.Infof("The upgrade of managed cluster %s failed: %s", cluster.Name, cluster.Message)
+	case cluster.StartTime != nil && time.Since(cluster.StartTime.Time) > getClusterUpgradeTimeout(fu):
+		setClusterState(cluster, clustersv1alpha1.ClusterUpgradeFailed, fmt.Sprintf("The upgrade did not complete within %s", getClusterUpgradeTimeout(fu)))
+		log.Infof("The upgrade of managed cluster %s failed: %s", cluster.Name, cluster.Message)
+	case status != nil && status.TargetVersion == fu.Spec.Version:
+		cluster.Message = status.Message
+	}
+
+	// The agent stops syncing the upgrade once the upgrade request is removed from the VMC
+	if cluster.State != clustersv1alpha1.ClusterUpgradeUpgrading && vmc.Spec.Upgrade != nil && vmc.Spec.Upgrade.Version == fu.Spec.Version {
+		vmc.Spec.Upgrade = nil
+		return r.Update(ctx, vmc)
+	}
+	return nil
+}
+
+// setClusterState sets the upgrade state of a managed cluster, the completion time is set for the final states
+func setClusterState(cluster *clustersv1alpha1.ClusterUpgradeStatus, state clustersv1alpha1.ClusterUpgradeState, message string) {
+	cluster.State = state
+	cluster.Message = message
+	if state == clustersv1alpha1.ClusterUpgradeSucceeded || state == clustersv1alpha1.ClusterUpgradeFailed {
+		now := metav1.Now()
+		cluster.CompletionTime = &now
+	}
+}
+
+// countClusters updates the numbers of succeeded and failed managed cluster upgrades
+func countClusters(fu *clustersv1alpha1.VerrazzanoFleetUpgrade) {
+	fu.Status.Succeeded = 0
+	fu.Status.Failed = 0
+	for _, cluster := range fu.Status.Clusters {
+		switch cluster.State {
+		case clustersv1alpha1.ClusterUpgradeSucceeded:
+			fu.Status.Succeeded++
+		case clustersv1alpha1.ClusterUpgradeFailed:
+			fu.Status.Failed++
+		}
+	}
+}
+
+// getClusterUpgradeTimeout returns the time after which the upgrade of a managed cluster is failed
+func getClusterUpgradeTimeout(fu *clustersv1alpha1.VerrazzanoFleetUpgrade) time.Duration {
+	if fu.Spec.ClusterUpgradeTimeout != nil {
+		return fu.Spec.ClusterUpgradeTimeout.Duration
+	}
+	return defaultClusterUpgradeTimeout
+}
+
+// isVersion returns true if the version string is the given version
+func isVersion(version string, expected *semver.SemVersion) bool {
+	if version == "" {
+		return false
+	}
+	v, err := semver.NewSemVersion(version)
+	return err == nil && v.IsEqualTo(expected)
+}
+
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(2, 3, time.Second)
+}
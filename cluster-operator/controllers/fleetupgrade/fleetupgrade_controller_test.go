@@ -0,0 +1,169 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package fleetupgrade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testName    = "upgrade"
+	testVersion = "1.6.0"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newVMC(name string, labels map[string]string) *clustersv1alpha1.VerrazzanoManagedCluster {
+	return &clustersv1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.VerrazzanoMultiClusterNamespace, Labels: labels},
+	}
+}
+
+func newFleetUpgrade(spec clustersv1alpha1.VerrazzanoFleetUpgradeSpec) *clustersv1alpha1.VerrazzanoFleetUpgrade {
+	spec.Version = testVersion
+	return &clustersv1alpha1.VerrazzanoFleetUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: constants.VerrazzanoMultiClusterNamespace},
+		Spec:       spec,
+	}
+}
+
+func reconcileFleetUpgrade(t *testing.T, cli client.Client) (*clustersv1alpha1.VerrazzanoFleetUpgrade, ctrl.Result) {
+	r := &VerrazzanoFleetUpgradeReconciler{Client: cli, Scheme: newScheme()}
+	name := types.NamespacedName{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: testName}
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: name})
+	assert.NoError(t, err)
+	fu := &clustersv1alpha1.VerrazzanoFleetUpgrade{}
+	assert.NoError(t, cli.Get(context.TODO(), name, fu))
+	return fu, res
+}
+
+func getVMC(t *testing.T, cli client.Client, name string) *clustersv1alpha1.VerrazzanoManagedCluster {
+	vmc := &clustersv1alpha1.VerrazzanoManagedCluster{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoMultiClusterNamespace, Name: name}, vmc))
+	return vmc
+}
+
+// reportUpgrade sets the upgrade status reported by the agent of a managed cluster
+func reportUpgrade(t *testing.T, cli client.Client, name string, version string, state v1beta1.VzStateType) {
+	vmc := getVMC(t, cli, name)
+	vmc.Status.Upgrade = &clustersv1alpha1.ManagedClusterUpgradeStatus{TargetVersion: testVersion, Version: version, State: string(state)}
+	assert.NoError(t, cli.Status().Update(context.TODO(), vmc))
+}
+
+// TestFleetUpgradeInBatches tests rolling out an upgrade to the managed clusters in batches
+// GIVEN three managed clusters matching the selector of a fleet upgrade with a batch size of two, and one which does not
+// WHEN the fleet upgrade is reconciled as the agents report the upgrades
+// THEN the upgrades are requested in the VMCs batch by batch, the requests are cleared once the upgrades have succeeded
+// and the fleet upgrade completes once all have succeeded
+func TestFleetUpgradeInBatches(t *testing.T) {
+	fleet := map[string]string{"fleet": "prod"}
+	fu := newFleetUpgrade(clustersv1alpha1.VerrazzanoFleetUpgradeSpec{
+		BatchSize:       2,
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: fleet},
+	})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(fu, newVMC("c1", fleet), newVMC("c2", fleet), newVMC("c3", fleet), newVMC("other", nil)).Build()
+
+	// The first batch is started
+	fu, res := reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.FleetUpgradeInProgress, fu.Status.Phase)
+	assert.Equal(t, upgradeCheckPeriod, res.RequeueAfter)
+	assert.Len(t, fu.Status.Clusters, 3)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeUpgrading, fu.Status.Clusters[0].State)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeUpgrading, fu.Status.Clusters[1].State)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradePending, fu.Status.Clusters[2].State)
+	assert.Equal(t, testVersion, getVMC(t, cli, "c1").Spec.Upgrade.Version)
+	assert.Nil(t, getVMC(t, cli, "c3").Spec.Upgrade)
+	assert.Nil(t, getVMC(t, cli, "other").Spec.Upgrade)
+
+	// The next batch is only started once the current batch has completed
+	reportUpgrade(t, cli, "c1", testVersion, v1beta1.VzStateReady)
+	reportUpgrade(t, cli, "c2", "1.5.2", v1beta1.VzStateUpgrading)
+	fu, _ = reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeSucceeded, fu.Status.Clusters[0].State)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeUpgrading, fu.Status.Clusters[1].State)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradePending, fu.Status.Clusters[2].State)
+	assert.Equal(t, 1, fu.Status.Succeeded)
+	assert.Nil(t, getVMC(t, cli, "c1").Spec.Upgrade)
+	assert.NotNil(t, getVMC(t, cli, "c2").Spec.Upgrade)
+
+	reportUpgrade(t, cli, "c2", testVersion, v1beta1.VzStateReady)
+	fu, _ = reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeUpgrading, fu.Status.Clusters[2].State)
+	assert.Equal(t, testVersion, getVMC(t, cli, "c3").Spec.Upgrade.Version)
+
+	reportUpgrade(t, cli, "c3", "v"+testVersion, v1beta1.VzStateReady)
+	fu, res = reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.FleetUpgradeCompleted, fu.Status.Phase)
+	assert.Equal(t, 3, fu.Status.Succeeded)
+	assert.Zero(t, res.RequeueAfter)
+}
+
+// TestFleetUpgradeHalted tests halting a rollout when the failure threshold is exceeded
+// GIVEN three managed clusters and a fleet upgrade tolerating no failure
+// WHEN the upgrade of the first managed cluster fails
+// THEN the rollout is halted and the upgrade of the other managed clusters is never requested
+func TestFleetUpgradeHalted(t *testing.T) {
+	fu := newFleetUpgrade(clustersv1alpha1.VerrazzanoFleetUpgradeSpec{})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(fu, newVMC("c1", nil), newVMC("c2", nil), newVMC("c3", nil)).Build()
+
+	_, _ = reconcileFleetUpgrade(t, cli)
+	reportUpgrade(t, cli, "c1", "1.5.2", v1beta1.VzStateFailed)
+	fu, res := reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.FleetUpgradeHalted, fu.Status.Phase)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeFailed, fu.Status.Clusters[0].State)
+	assert.Equal(t, 1, fu.Status.Failed)
+	assert.Zero(t, res.RequeueAfter)
+	assert.Nil(t, getVMC(t, cli, "c1").Spec.Upgrade)
+	assert.Nil(t, getVMC(t, cli, "c2").Spec.Upgrade)
+
+	fu, _ = reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.FleetUpgradeHalted, fu.Status.Phase)
+	assert.Nil(t, getVMC(t, cli, "c2").Spec.Upgrade)
+}
+
+// TestFleetUpgradeTimeout tests failing the upgrade of a managed cluster which does not complete in time
+// GIVEN a fleet upgrade tolerating one failure and a managed cluster whose upgrade started longer ago than the timeout
+// WHEN the fleet upgrade is reconciled
+// THEN the upgrade of the managed cluster is failed and the upgrade of the next managed cluster is requested
+func TestFleetUpgradeTimeout(t *testing.T) {
+	fu := newFleetUpgrade(clustersv1alpha1.VerrazzanoFleetUpgradeSpec{
+		MaxFailures:           1,
+		ClusterUpgradeTimeout: &metav1.Duration{Duration: time.Hour},
+	})
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	fu.Status = clustersv1alpha1.VerrazzanoFleetUpgradeStatus{
+		Phase: clustersv1alpha1.FleetUpgradeInProgress,
+		Clusters: []clustersv1alpha1.ClusterUpgradeStatus{
+			{Name: "c1", State: clustersv1alpha1.ClusterUpgradeUpgrading, StartTime: &startTime},
+			{Name: "c2", State: clustersv1alpha1.ClusterUpgradePending},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(fu, newVMC("c1", nil), newVMC("c2", nil)).Build()
+
+	fu, _ = reconcileFleetUpgrade(t, cli)
+	assert.Equal(t, clustersv1alpha1.FleetUpgradeInProgress, fu.Status.Phase)
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeFailed, fu.Status.Clusters[0].State)
+	assert.Contains(t, fu.Status.Clusters[0].Message, "did not complete")
+	assert.Equal(t, clustersv1alpha1.ClusterUpgradeUpgrading, fu.Status.Clusters[1].State)
+	assert.Equal(t, testVersion, getVMC(t, cli, "c2").Spec.Upgrade.Version)
+}
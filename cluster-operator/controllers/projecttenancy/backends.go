@@ -0,0 +1,116 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package projecttenancy
+
+import (
+	"github.com/verrazzano/verrazzano/pkg/grafana"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/opensearch"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/keycloak"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// identityProvider manages the Keycloak groups the project subjects are bound to, and the users Grafana authenticates
+// with to query the project logs
+type identityProvider interface {
+	CreateOrUpdateGroupRole(groupName, roleName string) error
+	GetGroupMembers(groupName string) ([]string, error)
+	DeleteRole(roleName string) error
+	CreateOrUpdateUserRole(userName, password, roleName string) error
+	DeleteUser(userName string) error
+}
+
+// openSearchAPI manages the OpenSearch security roles and tenants of the projects
+type openSearchAPI interface {
+	IsSecurityEnabled() (bool, error)
+	PutRole(name string, role opensearch.Role) error
+	DeleteRole(name string) error
+	PutRoleMapping(name string, mapping opensearch.RoleMapping) error
+	DeleteRoleMapping(name string) error
+	PutTenant(name string, tenant opensearch.Tenant) error
+	DeleteTenant(name string) error
+}
+
+// grafanaAPI manages the Grafana folders, teams, datasources and dashboards of the projects
+type grafanaAPI interface {
+	IsRunning() (bool, error)
+	CreateOrUpdateFolder(uid, title string) error
+	DeleteFolder(uid string) error
+	SetFolderPermissions(uid string, permissions []grafana.FolderPermission) error
+	GetOrCreateTeam(name string) (int64, error)
+	DeleteTeam(name string) error
+	SetTeamMembers(teamID int64, logins []string) error
+	CreateOrUpdateDatasource(datasource grafana.Datasource) error
+	DeleteDatasource(uid string) error
+	CreateOrUpdateDashboard(folderUID string, dashboard grafana.Dashboard) error
+}
+
+// keycloakProvider is the identityProvider of the Verrazzano Keycloak, it logs in before each use since the admin
+// session in the Keycloak pod expires
+type keycloakProvider struct {
+	ctx spi.ComponentContext
+	cfg *rest.Config
+	cli kubernetes.Interface
+}
+
+// CreateOrUpdateGroupRole creates the group and the role, and grants the role to the group
+func (k *keycloakProvider) CreateOrUpdateGroupRole(groupName, roleName string) error {
+	return keycloak.CreateOrUpdateGroupRole(k.ctx, k.cfg, k.cli, groupName, roleName)
+}
+
+// GetGroupMembers returns the user names of the members of the group
+func (k *keycloakProvider) GetGroupMembers(groupName string) ([]string, error) {
+	return keycloak.GetGroupMembers(k.ctx, k.cfg, k.cli, groupName)
+}
+
+// DeleteRole deletes the role
+func (k *keycloakProvider) DeleteRole(roleName string) error {
+	return keycloak.DeleteRole(k.ctx, k.cfg, k.cli, roleName)
+}
+
+// CreateOrUpdateUserRole creates the user and the role, sets the password of the user and grants the role to the user
+func (k *keycloakProvider) CreateOrUpdateUserRole(userName, password, roleName string) error {
+	return keycloak.CreateOrUpdateUserRole(k.ctx, k.cfg, k.cli, userName, password, roleName)
+}
+
+// DeleteUser deletes the user
+func (k *keycloakProvider) DeleteUser(userName string) error {
+	return keycloak.DeleteUser(k.ctx, k.cfg, k.cli, userName)
+}
+
+// leveraged to replace the backends (unit testing)
+var (
+	newIdentityProvider = func(c client.Client, log vzlog.VerrazzanoLogger) (identityProvider, error) {
+		cfg, cli, err := k8sutil.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		ctx, err := spi.NewMinimalContext(c, log)
+		if err != nil {
+			return nil, err
+		}
+		if err := keycloak.LoginKeycloak(ctx, cfg, cli); err != nil {
+			return nil, err
+		}
+		return &keycloakProvider{ctx: ctx, cfg: cfg, cli: cli}, nil
+	}
+	newOpenSearchAPI = func() (openSearchAPI, error) {
+		cfg, cli, err := k8sutil.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		return opensearch.NewClient(cli, cfg), nil
+	}
+	newGrafanaAPI = func() (grafanaAPI, error) {
+		cfg, cli, err := k8sutil.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		return grafana.NewClient(cli, cfg), nil
+	}
+)
@@ -0,0 +1,66 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package projecttenancy
+
+import (
+	"fmt"
+	"strings"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/grafana"
+)
+
+// metricsPanels are the panels of the project metrics dashboard, their queries are restricted to the namespaces
+// selected by the namespace variable
+var metricsPanels = []struct {
+	title string
+	unit  string
+	expr  string
+}{
+	{"CPU usage", "short", `sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{namespace=~"$namespace", container!=""}[5m]))`},
+	{"Memory usage", "bytes", `sum by (namespace, pod) (container_memory_working_set_bytes{namespace=~"$namespace", container!=""})`},
+	{"Request rate", "reqps", `sum by (destination_workload_namespace, destination_workload) (rate(istio_requests_total{reporter="destination", destination_workload_namespace=~"$namespace"}[5m]))`},
+	{"Error rate", "reqps", `sum by (destination_workload_namespace, destination_workload) (rate(istio_requests_total{reporter="destination", destination_workload_namespace=~"$namespace", response_code=~"5.."}[5m]))`},
+}
+
+// newMetricsDashboard returns the metrics dashboard of a project. The values of its namespace variable, including the
+// "All" value, only select the project namespaces, so that the dashboard only shows the metrics of the project. The
+// Prometheus datasources themselves are not restricted per project, the dashboard only scopes what the project users
+// are shown.
+func newMetricsDashboard(vp *clustersv1alpha1.VerrazzanoProject) grafana.Dashboard {
+	var namespaces []string
+	for _, ns := range vp.Spec.Template.Namespaces {
+		namespaces = append(namespaces, ns.Metadata.Name)
+	}
+	var panels []interface{}
+	for i, panel := range metricsPanels {
+		panels = append(panels, map[string]interface{}{
+			"id":          i + 1,
+			"type":        "timeseries",
+			"title":       panel.title,
+			"gridPos":     map[string]interface{}{"h": 8, "w": 12, "x": (i % 2) * 12, "y": (i / 2) * 8},
+			"fieldConfig": map[string]interface{}{"defaults": map[string]interface{}{"unit": panel.unit}},
+			"targets":     []interface{}{map[string]interface{}{"refId": "A", "expr": panel.expr}},
+		})
+	}
+	return grafana.Dashboard{
+		"uid":      fmt.Sprintf(dashboardUIDTemplate, vp.Name),
+		"title":    fmt.Sprintf("Project %s metrics", vp.Name),
+		"editable": false,
+		"time":     map[string]interface{}{"from": "now-1h", "to": "now"},
+		"templating": map[string]interface{}{
+			"list": []interface{}{map[string]interface{}{
+				"name":       "namespace",
+				"label":      "Namespace",
+				"type":       "custom",
+				"query":      strings.Join(namespaces, ","),
+				"multi":      true,
+				"includeAll": true,
+				"allValue":   strings.Join(namespaces, "|"),
+				"current":    map[string]interface{}{"text": "All", "value": "$__all"},
+			}},
+		},
+		"panels": panels,
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package projecttenancy
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/grafana"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/opensearch"
+	vzpassword "github.com/verrazzano/verrazzano/pkg/security/password"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"github.com/verrazzano/verrazzano/pkg/vzcr"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	finalizerName = "tenancy.project.verrazzano.io"
+
+	// The default project groups match the default subjects of the project role bindings
+	adminGroupTemplate   = "verrazzano-project-%s-admins"
+	monitorGroupTemplate = "verrazzano-project-%s-monitors"
+	adminRoleTemplate    = "verrazzano-project-%s-admin"
+	monitorRoleTemplate  = "verrazzano-project-%s-monitor"
+	tenantTemplate       = "verrazzano-project-%s"
+	folderUIDTemplate    = "verrazzano-project-%s"
+	datasourceTemplate   = "verrazzano-project-%s-logs"
+	dashboardUIDTemplate = "verrazzano-project-%s-metrics"
+
+	// logsUserTemplate is the name of the Keycloak user Grafana authenticates with to query the project logs, and of
+	// the secret holding its credentials
+	logsUserTemplate = "verrazzano-project-%s-logs"
+	usernameKey      = "username"
+	passwordKey      = "password"
+	passwordLength   = 32
+
+	// applicationDataStreamPrefix is the prefix of the data streams the application logs of a namespace are written to
+	applicationDataStreamPrefix = "verrazzano-application-"
+	// openSearchURL is the in-cluster URL of the Verrazzano authentication proxy for OpenSearch, which authenticates the
+	// Grafana requests and passes the Keycloak roles of the project logs user to OpenSearch as backend roles
+	openSearchURL = "http://verrazzano-authproxy-opensearch.verrazzano-system.svc.cluster.local:8775"
+
+	// The Keycloak group members are synced to the Grafana teams periodically, and Grafana only knows the users
+	// who have logged in
+	tenancySyncPeriod = 5 * time.Minute
+)
+
+// ProjectTenancyReconciler provisions the observability tenancy of each VerrazzanoProject on the admin cluster:
+// OpenSearch roles and a Dashboards tenant restricted to the logs of the project namespaces, and a Grafana folder and
+// teams with a logs datasource and a metrics dashboard restricted to the project namespaces. The OpenSearch roles and
+// the Grafana teams are bound to the project admin and monitor subjects through Keycloak groups.
+type ProjectTenancyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// projectSubjects holds the Keycloak groups and the users bound to a project role
+type projectSubjects struct {
+	role   string
+	groups []string
+	users  []string
+}
+
+// logsCredentials are the credentials of the project logs datasource
+type logsCredentials struct {
+	username string
+	password string
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *ProjectTenancyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustersv1alpha1.VerrazzanoProject{}).
+		Complete(r)
+}
+
+// Reconcile provisions or removes the observability tenancy of a VerrazzanoProject
+func (r *ProjectTenancyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		return ctrl.Result{}, goerrors.New("context cannot be nil")
+	}
+	// Only the projects of the multicluster namespace have their namespaces created
+	if req.Namespace != vzconstants.VerrazzanoMultiClusterNamespace {
+		return ctrl.Result{}, nil
+	}
+	vp := &clustersv1alpha1.VerrazzanoProject{}
+	if err := r.Get(ctx, req.NamespacedName, vp); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		zap.S().Errorf("Failed to fetch VerrazzanoProject resource: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+
+	// Get the resource logger needed to log message using 'progress' and 'once' methods
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           vp.Name,
+		Namespace:      vp.Namespace,
+		ID:             string(vp.UID),
+		Generation:     vp.Generation,
+		ControllerName: "projecttenancy",
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for project tenancy controller: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+
+	log.Oncef("Reconciling the observability tenancy of Verrazzano project %v", req.NamespacedName)
+	res, err := r.doReconcile(ctx, log, vp)
+	// Never return an error since it has already been logged and we don't want the
+	// controller runtime to log again (with stack trace).  Just re-queue if there is an error.
+	if err != nil {
+		log.Errorf("Failed to reconcile the observability tenancy of Verrazzano project %v: %v", req.NamespacedName, err)
+		return newRequeueWithDelay(), nil
+	}
+	return res, nil
+}
+
+// doReconcile provisions the tenancy in the enabled backends, or removes it when the project is deleted
+func (r *ProjectTenancyReconciler) doReconcile(ctx context.Context, log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject) (ctrl.Result, error) {
+	if !vp.DeletionTimestamp.IsZero() {
+		if !vzstring.SliceContainsString(vp.Finalizers, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		if err := r.deleteTenancy(ctx, log, vp); err != nil {
+			return ctrl.Result{}, err
+		}
+		vp.Finalizers = vzstring.RemoveStringFromSlice(vp.Finalizers, finalizerName)
+		return ctrl.Result{}, r.Update(ctx, vp)
+	}
+
+	if !vzstring.SliceContainsString(vp.Finalizers, finalizerName) {
+		vp.Finalizers = append(vp.Finalizers, finalizerName)
+		if err := r.Update(ctx, vp); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	vz, err := r.getVerrazzanoResource(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if vz == nil {
+		return ctrl.Result{}, fmt.Errorf("Verrazzano must be installed")
+	}
+	adminSubjects, monitorSubjects := getProjectSubjects(vp)
+
+	var idp identityProvider
+	var groupMembers map[string][]string
+	if vzcr.IsKeycloakEnabled(vz) {
+		if idp, err = newIdentityProvider(r.Client, log); err != nil {
+			return ctrl.Result{}, err
+		}
+		if groupMembers, err = syncKeycloakGroups(idp, adminSubjects, monitorSubjects); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	logsSecured := false
+	if vzcr.IsOpenSearchEnabled(vz) {
+		if logsSecured, err = r.syncOpenSearch(log, vp, adminSubjects, monitorSubjects); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if vzcr.IsGrafanaEnabled(vz) {
+		// The project logs can only be queried through the authentication proxy with credentials restricted to them
+		var credentials *logsCredentials
+		if logsSecured && idp != nil {
+			if credentials, err = r.syncLogsUser(ctx, idp, vp, monitorSubjects.role); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if err := r.syncGrafana(log, vp, adminSubjects, monitorSubjects, groupMembers, credentials); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: tenancySyncPeriod}, nil
+}
+
+// syncKeycloakGroups creates the project groups and grants them the project roles, and returns the members of the groups
+func syncKeycloakGroups(idp identityProvider, subjects ...projectSubjects) (map[string][]string, error) {
+	members := map[string][]string{}
+	for _, s := range subjects {
+		for _, group := range s.groups {
+			if err := idp.CreateOrUpdateGroupRole(group, s.role); err != nil {
+				return nil, err
+			}
+			var err error
+			if members[group], err = idp.GetGroupMembers(group); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return members, nil
+}
+
+// syncLogsUser creates the Keycloak user the project logs datasource authenticates with, granted the project monitor
+// role, and returns its credentials. The generated password is kept in a secret owned by the project.
+func (r *ProjectTenancyReconciler) syncLogsUser(ctx context.Context, idp identityProvider, vp *clustersv1alpha1.VerrazzanoProject, role string) (*logsCredentials, error) {
+	name := fmt.Sprintf(logsUserTemplate, vp.Name)
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: vp.Namespace, Name: name}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if errors.IsNotFound(err) {
+		password, err := vzpassword.GeneratePassword(passwordLength)
+		if err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: vp.Namespace, Name: name},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				usernameKey: []byte(name),
+				passwordKey: []byte(password),
+			},
+		}
+		if err := controllerutil.SetControllerReference(vp, secret, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return nil, err
+		}
+	}
+	credentials := &logsCredentials{username: string(secret.Data[usernameKey]), password: string(secret.Data[passwordKey])}
+	if err := idp.CreateOrUpdateUserRole(credentials.username, credentials.password, role); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// syncOpenSearch creates the project tenant, and the project roles restricted to the application logs of the project
+// namespaces, mapped to the Keycloak roles of the project groups. The Verrazzano authentication proxy passes the
+// Keycloak roles of the users to OpenSearch as backend roles. It returns false when the OpenSearch security plugin is
+// disabled, since the logs cannot be restricted then.
+func (r *ProjectTenancyReconciler) syncOpenSearch(log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject, admins, monitors projectSubjects) (bool, error) {
+	osClient, err := newOpenSearchAPI()
+	if err != nil {
+		return false, err
+	}
+	enabled, err := osClient.IsSecurityEnabled()
+	if err != nil {
+		return false, err
+	}
+	if !enabled {
+		log.Oncef("The OpenSearch security plugin is not enabled, skipping the OpenSearch tenancy of project %s", vp.Name)
+		return false, nil
+	}
+
+	tenant := fmt.Sprintf(tenantTemplate, vp.Name)
+	if err := osClient.PutTenant(tenant, opensearch.Tenant{Description: fmt.Sprintf("Verrazzano project %s", vp.Name)}); err != nil {
+		return false, err
+	}
+	indexPatterns := getIndexPatterns(vp)
+	roles := []struct {
+		subjects       projectSubjects
+		indexActions   []string
+		tenantActions  []string
+		clusterActions []string
+	}{
+		{admins, []string{"read", "indices_monitor"}, []string{"kibana_all_write"}, []string{"cluster_composite_ops_ro"}},
+		{monitors, []string{"read"}, []string{"kibana_all_read"}, []string{"cluster_composite_ops_ro"}},
+	}
+	for _, role := range roles {
+		if err := osClient.PutRole(role.subjects.role, opensearch.Role{
+			ClusterPermissions: role.clusterActions,
+			IndexPermissions:   []opensearch.IndexPermission{{IndexPatterns: indexPatterns, AllowedActions: role.indexActions}},
+			TenantPermissions:  []opensearch.TenantPermission{{TenantPatterns: []string{tenant}, AllowedActions: role.tenantActions}},
+		}); err != nil {
+			return false, err
+		}
+		if err := osClient.PutRoleMapping(role.subjects.role, opensearch.RoleMapping{
+			BackendRoles: []string{role.subjects.role},
+			Users:        role.subjects.users,
+		}); err != nil {
+			return false, err
+		}
+	}
+	log.Oncef("Provisioned the OpenSearch tenant %s of project %s", tenant, vp.Name)
+	return true, nil
+}
+
+// syncGrafana creates the project folder, editable by the project admins team and viewable by the project monitors
+// team, with a dashboard of the metrics of the project namespaces, and a logs datasource authenticated as the project
+// logs user, so that OpenSearch only returns the application logs of the project namespaces. Without credentials the
+// logs cannot be restricted, and the datasource is removed. The members of the teams are the members of the Keycloak
+// project groups and the project users.
+func (r *ProjectTenancyReconciler) syncGrafana(log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject, admins, monitors projectSubjects, groupMembers map[string][]string, credentials *logsCredentials) error {
+	gf, err := newGrafanaAPI()
+	if err != nil {
+		return err
+	}
+	running, err := gf.IsRunning()
+	if err != nil {
+		return err
+	}
+	if !running {
+		log.Progressf("Waiting for Grafana to be running to provision the Grafana tenancy of project %s", vp.Name)
+		return fmt.Errorf("Grafana is not running")
+	}
+
+	folderUID := fmt.Sprintf(folderUIDTemplate, vp.Name)
+	if err := gf.CreateOrUpdateFolder(folderUID, fmt.Sprintf("Project %s", vp.Name)); err != nil {
+		return err
+	}
+	teams := []struct {
+		name       string
+		subjects   projectSubjects
+		permission int
+	}{
+		{fmt.Sprintf(adminGroupTemplate, vp.Name), admins, grafana.PermissionEdit},
+		{fmt.Sprintf(monitorGroupTemplate, vp.Name), monitors, grafana.PermissionView},
+	}
+	var permissions []grafana.FolderPermission
+	for _, team := range teams {
+		teamID, err := gf.GetOrCreateTeam(team.name)
+		if err != nil {
+			return err
+		}
+		if err := gf.SetTeamMembers(teamID, getTeamLogins(team.subjects, groupMembers)); err != nil {
+			return err
+		}
+		permissions = append(permissions, grafana.FolderPermission{TeamID: teamID, Permission: team.permission})
+	}
+	if err := gf.SetFolderPermissions(folderUID, permissions); err != nil {
+		return err
+	}
+	if err := gf.CreateOrUpdateDashboard(folderUID, newMetricsDashboard(vp)); err != nil {
+		return err
+	}
+
+	datasourceUID := fmt.Sprintf(datasourceTemplate, vp.Name)
+	if credentials == nil {
+		log.Oncef("The logs of project %s cannot be restricted without Keycloak and the OpenSearch security plugin, skipping the Grafana logs datasource", vp.Name)
+		if err := gf.DeleteDatasource(datasourceUID); err != nil {
+			return err
+		}
+	} else {
+		var indices []string
+		for _, ns := range vp.Spec.Template.Namespaces {
+			indices = append(indices, applicationDataStreamPrefix+ns.Metadata.Name)
+		}
+		if err := gf.CreateOrUpdateDatasource(grafana.Datasource{
+			UID:      datasourceUID,
+			Name:     fmt.Sprintf("Project %s logs", vp.Name),
+			Type:     "elasticsearch",
+			Access:   "proxy",
+			URL:      openSearchURL,
+			Database: strings.Join(indices, ","),
+			JSONData: map[string]interface{}{
+				"timeField":       "@timestamp",
+				"esVersion":       "7.10.0",
+				"logMessageField": "log",
+				"logLevelField":   "level",
+			},
+			BasicAuth:      true,
+			BasicAuthUser:  credentials.username,
+			SecureJSONData: map[string]string{"basicAuthPassword": credentials.password},
+		}); err != nil {
+			return err
+		}
+	}
+	log.Oncef("Provisioned the Grafana folder %s of project %s", folderUID, vp.Name)
+	return nil
+}
+
+// deleteTenancy removes the tenancy of a deleted project from the enabled backends. The Keycloak groups are kept,
+// since users may have been added to them, only the project roles and the project logs user are deleted. The cleanup
+// is best effort: a backend that is not available must not block the deletion of the project, so its errors are
+// logged and the objects left in it have to be removed manually.
+func (r *ProjectTenancyReconciler) deleteTenancy(ctx context.Context, log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject) error {
+	vz, err := r.getVerrazzanoResource(ctx)
+	if err != nil || vz == nil {
+		// Nothing was provisioned without Verrazzano
+		return err
+	}
+	adminSubjects, monitorSubjects := getProjectSubjects(vp)
+	roles := []string{adminSubjects.role, monitorSubjects.role}
+
+	if vzcr.IsGrafanaEnabled(vz) {
+		if err := deleteGrafanaTenancy(log, vp); err != nil {
+			log.Errorf("Failed to remove the Grafana tenancy of project %s, the project folder, teams and datasource must be deleted manually: %v", vp.Name, err)
+		}
+	}
+	if vzcr.IsOpenSearchEnabled(vz) {
+		if err := deleteOpenSearchTenancy(vp, roles); err != nil {
+			log.Errorf("Failed to remove the OpenSearch tenancy of project %s, the project roles and tenant must be deleted manually: %v", vp.Name, err)
+		}
+	}
+	if vzcr.IsKeycloakEnabled(vz) {
+		if err := r.deleteKeycloakTenancy(log, vp, roles); err != nil {
+			log.Errorf("Failed to remove the Keycloak tenancy of project %s, the project roles and logs user must be deleted manually: %v", vp.Name, err)
+		}
+	}
+	log.Oncef("Removed the observability tenancy of project %s", vp.Name)
+	return nil
+}
+
+// deleteGrafanaTenancy deletes the project datasource, folder with its dashboards, and teams
+func deleteGrafanaTenancy(log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject) error {
+	gf, err := newGrafanaAPI()
+	if err != nil {
+		return err
+	}
+	running, err := gf.IsRunning()
+	if err != nil {
+		return err
+	}
+	if !running {
+		log.Infof("Grafana is not running, skipping the removal of the Grafana tenancy of project %s", vp.Name)
+		return nil
+	}
+	if err := gf.DeleteDatasource(fmt.Sprintf(datasourceTemplate, vp.Name)); err != nil {
+		return err
+	}
+	if err := gf.DeleteFolder(fmt.Sprintf(folderUIDTemplate, vp.Name)); err != nil {
+		return err
+	}
+	for _, team := range []string{fmt.Sprintf(adminGroupTemplate, vp.Name), fmt.Sprintf(monitorGroupTemplate, vp.Name)} {
+		if err := gf.DeleteTeam(team); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOpenSearchTenancy deletes the project roles, their mappings and the project tenant
+func deleteOpenSearchTenancy(vp *clustersv1alpha1.VerrazzanoProject, roles []string) error {
+	osClient, err := newOpenSearchAPI()
+	if err != nil {
+		return err
+	}
+	enabled, err := osClient.IsSecurityEnabled()
+	if err != nil || !enabled {
+		return err
+	}
+	for _, role := range roles {
+		if err := osClient.DeleteRoleMapping(role); err != nil {
+			return err
+		}
+		if err := osClient.DeleteRole(role); err != nil {
+			return err
+		}
+	}
+	return osClient.DeleteTenant(fmt.Sprintf(tenantTemplate, vp.Name))
+}
+
+// deleteKeycloakTenancy deletes the project logs user and the project roles, the secret of the logs user is deleted
+// with the project that owns it
+func (r *ProjectTenancyReconciler) deleteKeycloakTenancy(log vzlog.VerrazzanoLogger, vp *clustersv1alpha1.VerrazzanoProject, roles []string) error {
+	idp, err := newIdentityProvider(r.Client, log)
+	if err != nil {
+		return err
+	}
+	if err := idp.DeleteUser(fmt.Sprintf(logsUserTemplate, vp.Name)); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if err := idp.DeleteRole(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getVerrazzanoResource gets the installed Verrazzano resource in the cluster (of which only one is expected), or nil
+// if Verrazzano is not installed
+func (r *ProjectTenancyReconciler) getVerrazzanoResource(ctx context.Context) (*v1beta1.Verrazzano, error) {
+	verrazzano := v1beta1.VerrazzanoList{}
+	if err := r.List(ctx, &verrazzano, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if len(verrazzano.Items) == 0 {
+		return nil, nil
+	}
+	return &verrazzano.Items[0], nil
+}
+
+// getProjectSubjects returns the Keycloak groups and the users bound to the project admin and monitor roles. Like the
+// project role bindings, the subjects default to the project groups when the project does not specify them.
+func getProjectSubjects(vp *clustersv1alpha1.VerrazzanoProject) (projectSubjects, projectSubjects) {
+	adminSubjects := vp.Spec.Template.Security.ProjectAdminSubjects
+	if len(adminSubjects) == 0 {
+		adminSubjects = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: fmt.Sprintf(adminGroupTemplate, vp.Name)}}
+	}
+	monitorSubjects := vp.Spec.Template.Security.ProjectMonitorSubjects
+	if len(monitorSubjects) == 0 {
+		monitorSubjects = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: fmt.Sprintf(monitorGroupTemplate, vp.Name)}}
+	}
+	return newProjectSubjects(fmt.Sprintf(adminRoleTemplate, vp.Name), adminSubjects),
+		newProjectSubjects(fmt.Sprintf(monitorRoleTemplate, vp.Name), monitorSubjects)
+}
+
+// newProjectSubjects returns the groups and users of the subjects, service accounts have no observability access
+func newProjectSubjects(role string, subjects []rbacv1.Subject) projectSubjects {
+	s := projectSubjects{role: role}
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.GroupKind:
+			s.groups = append(s.groups, subject.Name)
+		case rbacv1.UserKind:
+			s.users = append(s.users, subject.Name)
+		}
+	}
+	return s
+}
+
+// getIndexPatterns returns the patterns of the application log data streams of the project namespaces, and of their
+// backing indices
+func getIndexPatterns(vp *clustersv1alpha1.VerrazzanoProject) []string {
+	var patterns []string
+	for _, ns := range vp.Spec.Template.Namespaces {
+		dataStream := applicationDataStreamPrefix + ns.Metadata.Name
+		patterns = append(patterns, dataStream, fmt.Sprintf(".ds-%s-*", dataStream))
+	}
+	return patterns
+}
+
+// getTeamLogins returns the sorted logins of the project users and of the members of the project groups
+func getTeamLogins(subjects projectSubjects, groupMembers map[string][]string) []string {
+	logins := map[string]bool{}
+	for _, user := range subjects.users {
+		logins[user] = true
+	}
+	for _, group := range subjects.groups {
+		for _, member := range groupMembers[group] {
+			logins[member] = true
+		}
+	}
+	var result []string
+	for login := range logins {
+		result = append(result, login)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Create a new Result that will cause a reconcile requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(2, 3, time.Second)
+}
@@ -0,0 +1,420 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package projecttenancy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
+	vzconstants "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/grafana"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	"github.com/verrazzano/verrazzano/pkg/opensearch"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const projectName = "hello"
+
+// fakeBackends records the calls made to the identity provider, OpenSearch and Grafana
+type fakeBackends struct {
+	calls           []string
+	groupMembers    map[string][]string
+	securityEnabled bool
+	grafanaRunning  bool
+	roles           map[string]opensearch.Role
+	mappings        map[string]opensearch.RoleMapping
+	teamMembers     map[int64][]string
+	permissions     []grafana.FolderPermission
+	datasource      grafana.Datasource
+	dashboard       grafana.Dashboard
+	userPasswords   map[string]string
+	failures        map[string]bool
+}
+
+func (f *fakeBackends) record(format string, args ...interface{}) {
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeBackends) CreateOrUpdateGroupRole(groupName, roleName string) error {
+	f.record("keycloak group %s role %s", groupName, roleName)
+	return nil
+}
+
+func (f *fakeBackends) GetGroupMembers(groupName string) ([]string, error) {
+	return f.groupMembers[groupName], nil
+}
+
+func (f *fakeBackends) DeleteRole(roleName string) error {
+	f.record("keycloak delete role %s", roleName)
+	return nil
+}
+
+func (f *fakeBackends) CreateOrUpdateUserRole(userName, password, roleName string) error {
+	f.record("keycloak user %s role %s", userName, roleName)
+	f.userPasswords[userName] = password
+	return nil
+}
+
+func (f *fakeBackends) DeleteUser(userName string) error {
+	f.record("keycloak delete user %s", userName)
+	return nil
+}
+
+// fakeOpenSearch is the OpenSearch API of the fake backends, its DeleteRole method conflicts with the Keycloak one
+type fakeOpenSearch struct{ *fakeBackends }
+
+func (f fakeOpenSearch) IsSecurityEnabled() (bool, error) {
+	if f.failures["opensearch"] {
+		return false, fmt.Errorf("connection refused")
+	}
+	return f.securityEnabled, nil
+}
+
+func (f fakeOpenSearch) PutRole(name string, role opensearch.Role) error {
+	f.record("opensearch role %s", name)
+	f.roles[name] = role
+	return nil
+}
+
+func (f fakeOpenSearch) DeleteRole(name string) error {
+	f.record("opensearch delete role %s", name)
+	return nil
+}
+
+func (f fakeOpenSearch) PutRoleMapping(name string, mapping opensearch.RoleMapping) error {
+	f.record("opensearch rolesmapping %s", name)
+	f.mappings[name] = mapping
+	return nil
+}
+
+func (f fakeOpenSearch) DeleteRoleMapping(name string) error {
+	f.record("opensearch delete rolesmapping %s", name)
+	return nil
+}
+
+func (f fakeOpenSearch) PutTenant(name string, _ opensearch.Tenant) error {
+	f.record("opensearch tenant %s", name)
+	return nil
+}
+
+func (f fakeOpenSearch) DeleteTenant(name string) error {
+	f.record("opensearch delete tenant %s", name)
+	return nil
+}
+
+func (f *fakeBackends) IsRunning() (bool, error) {
+	if f.failures["grafana"] {
+		return false, fmt.Errorf("connection refused")
+	}
+	return f.grafanaRunning, nil
+}
+
+func (f *fakeBackends) CreateOrUpdateFolder(uid, _ string) error {
+	f.record("grafana folder %s", uid)
+	return nil
+}
+
+func (f *fakeBackends) DeleteFolder(uid string) error {
+	f.record("grafana delete folder %s", uid)
+	return nil
+}
+
+func (f *fakeBackends) SetFolderPermissions(_ string, permissions []grafana.FolderPermission) error {
+	f.permissions = permissions
+	return nil
+}
+
+func (f *fakeBackends) GetOrCreateTeam(name string) (int64, error) {
+	f.record("grafana team %s", name)
+	if name == fmt.Sprintf(adminGroupTemplate, projectName) {
+		return 1, nil
+	}
+	return 2, nil
+}
+
+func (f *fakeBackends) DeleteTeam(name string) error {
+	f.record("grafana delete team %s", name)
+	return nil
+}
+
+func (f *fakeBackends) SetTeamMembers(teamID int64, logins []string) error {
+	f.teamMembers[teamID] = logins
+	return nil
+}
+
+func (f *fakeBackends) CreateOrUpdateDatasource(datasource grafana.Datasource) error {
+	f.record("grafana datasource %s", datasource.UID)
+	f.datasource = datasource
+	return nil
+}
+
+func (f *fakeBackends) DeleteDatasource(uid string) error {
+	f.record("grafana delete datasource %s", uid)
+	return nil
+}
+
+func (f *fakeBackends) CreateOrUpdateDashboard(folderUID string, dashboard grafana.Dashboard) error {
+	f.record("grafana dashboard %s in folder %s", dashboard["uid"], folderUID)
+	f.dashboard = dashboard
+	return nil
+}
+
+// setupBackends replaces the backends with fakes
+func setupBackends(t *testing.T) *fakeBackends {
+	f := &fakeBackends{
+		groupMembers:    map[string][]string{},
+		securityEnabled: true,
+		grafanaRunning:  true,
+		roles:           map[string]opensearch.Role{},
+		mappings:        map[string]opensearch.RoleMapping{},
+		teamMembers:     map[int64][]string{},
+		userPasswords:   map[string]string{},
+		failures:        map[string]bool{},
+	}
+	prevIdentityProvider, prevOpenSearchAPI, prevGrafanaAPI := newIdentityProvider, newOpenSearchAPI, newGrafanaAPI
+	newIdentityProvider = func(_ client.Client, _ vzlog.VerrazzanoLogger) (identityProvider, error) { return f, nil }
+	newOpenSearchAPI = func() (openSearchAPI, error) { return fakeOpenSearch{f}, nil }
+	newGrafanaAPI = func() (grafanaAPI, error) { return f, nil }
+	t.Cleanup(func() {
+		newIdentityProvider, newOpenSearchAPI, newGrafanaAPI = prevIdentityProvider, prevOpenSearchAPI, prevGrafanaAPI
+	})
+	return f
+}
+
+// newScheme returns a scheme with the types used by the controller
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clustersv1alpha1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+// newProject returns a project with two namespaces
+func newProject(security clustersv1alpha1.SecuritySpec) *clustersv1alpha1.VerrazzanoProject {
+	return &clustersv1alpha1.VerrazzanoProject{
+		ObjectMeta: metav1.ObjectMeta{Name: projectName, Namespace: vzconstants.VerrazzanoMultiClusterNamespace},
+		Spec: clustersv1alpha1.VerrazzanoProjectSpec{
+			Template: clustersv1alpha1.ProjectTemplate{
+				Namespaces: []clustersv1alpha1.NamespaceTemplate{
+					{Metadata: metav1.ObjectMeta{Name: "hello-ns1"}},
+					{Metadata: metav1.ObjectMeta{Name: "hello-ns2"}},
+				},
+				Security: security,
+			},
+		},
+	}
+}
+
+// newVerrazzano returns a Verrazzano resource with the default components enabled
+func newVerrazzano() *v1beta1.Verrazzano {
+	return &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"}}
+}
+
+// reconcileProject reconciles the project
+func reconcileProject(t *testing.T, cli client.Client) ctrl.Result {
+	r := &ProjectTenancyReconciler{Client: cli, Scheme: newScheme()}
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: projectName}})
+	assert.NoError(t, err)
+	return result
+}
+
+// TestProvisionDefaultSubjects tests provisioning the tenancy of a project with the default subjects
+// GIVEN a project without subjects and the default Verrazzano components
+// WHEN the project is reconciled
+// THEN the project groups, OpenSearch roles and tenant, the project logs user, and Grafana folder, teams, dashboard and
+// datasource are provisioned
+func TestProvisionDefaultSubjects(t *testing.T) {
+	f := setupBackends(t)
+	f.groupMembers[fmt.Sprintf(adminGroupTemplate, projectName)] = []string{"carol", "alice"}
+	f.groupMembers[fmt.Sprintf(monitorGroupTemplate, projectName)] = []string{"bob"}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newProject(clustersv1alpha1.SecuritySpec{}), newVerrazzano()).Build()
+
+	result := reconcileProject(t, cli)
+	assert.Equal(t, tenancySyncPeriod, result.RequeueAfter)
+	assert.Equal(t, []string{
+		"keycloak group verrazzano-project-hello-admins role verrazzano-project-hello-admin",
+		"keycloak group verrazzano-project-hello-monitors role verrazzano-project-hello-monitor",
+		"opensearch tenant verrazzano-project-hello",
+		"opensearch role verrazzano-project-hello-admin",
+		"opensearch rolesmapping verrazzano-project-hello-admin",
+		"opensearch role verrazzano-project-hello-monitor",
+		"opensearch rolesmapping verrazzano-project-hello-monitor",
+		"keycloak user verrazzano-project-hello-logs role verrazzano-project-hello-monitor",
+		"grafana folder verrazzano-project-hello",
+		"grafana team verrazzano-project-hello-admins",
+		"grafana team verrazzano-project-hello-monitors",
+		"grafana dashboard verrazzano-project-hello-metrics in folder verrazzano-project-hello",
+		"grafana datasource verrazzano-project-hello-logs",
+	}, f.calls)
+
+	// The OpenSearch roles are restricted to the application logs of the project namespaces
+	adminRole := f.roles["verrazzano-project-hello-admin"]
+	assert.Equal(t, []string{"verrazzano-application-hello-ns1", ".ds-verrazzano-application-hello-ns1-*",
+		"verrazzano-application-hello-ns2", ".ds-verrazzano-application-hello-ns2-*"}, adminRole.IndexPermissions[0].IndexPatterns)
+	assert.Equal(t, []string{"kibana_all_write"}, adminRole.TenantPermissions[0].AllowedActions)
+	assert.Equal(t, []string{"kibana_all_read"}, f.roles["verrazzano-project-hello-monitor"].TenantPermissions[0].AllowedActions)
+	assert.Equal(t, []string{"verrazzano-project-hello-admin"}, f.mappings["verrazzano-project-hello-admin"].BackendRoles)
+
+	// The Grafana teams hold the group members, and the datasource is restricted to the project logs
+	assert.Equal(t, []string{"alice", "carol"}, f.teamMembers[1])
+	assert.Equal(t, []string{"bob"}, f.teamMembers[2])
+	assert.Equal(t, []grafana.FolderPermission{{TeamID: 1, Permission: grafana.PermissionEdit}, {TeamID: 2, Permission: grafana.PermissionView}}, f.permissions)
+	assert.Equal(t, "verrazzano-application-hello-ns1,verrazzano-application-hello-ns2", f.datasource.Database)
+
+	// The datasource authenticates to the authentication proxy as the project logs user, whose password is kept in a
+	// secret owned by the project
+	assert.Equal(t, openSearchURL, f.datasource.URL)
+	assert.True(t, f.datasource.BasicAuth)
+	assert.Equal(t, "verrazzano-project-hello-logs", f.datasource.BasicAuthUser)
+	secret := &corev1.Secret{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: "verrazzano-project-hello-logs"}, secret))
+	assert.Len(t, secret.Data[passwordKey], passwordLength)
+	assert.Equal(t, string(secret.Data[passwordKey]), f.datasource.SecureJSONData["basicAuthPassword"])
+	assert.Equal(t, string(secret.Data[passwordKey]), f.userPasswords["verrazzano-project-hello-logs"])
+	assert.Equal(t, projectName, secret.OwnerReferences[0].Name)
+
+	// The metrics dashboard only selects the project namespaces
+	variable := f.dashboard["templating"].(map[string]interface{})["list"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "hello-ns1,hello-ns2", variable["query"])
+	assert.Equal(t, "hello-ns1|hello-ns2", variable["allValue"])
+
+	// The password is kept when the project is reconciled again
+	f.userPasswords = map[string]string{}
+	reconcileProject(t, cli)
+	assert.Equal(t, string(secret.Data[passwordKey]), f.userPasswords["verrazzano-project-hello-logs"])
+
+	vp := &clustersv1alpha1.VerrazzanoProject{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: projectName}, vp))
+	assert.Contains(t, vp.Finalizers, finalizerName)
+}
+
+// TestProvisionCustomSubjects tests provisioning the tenancy of a project with custom subjects
+// GIVEN a project with group, user and service account subjects
+// WHEN the project is reconciled
+// THEN the groups are bound to the project roles and the users are mapped to the roles and added to the teams
+func TestProvisionCustomSubjects(t *testing.T) {
+	f := setupBackends(t)
+	f.groupMembers["devs"] = []string{"alice"}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newProject(clustersv1alpha1.SecuritySpec{
+		ProjectAdminSubjects: []rbacv1.Subject{
+			{Kind: rbacv1.GroupKind, Name: "devs"},
+			{Kind: rbacv1.UserKind, Name: "zed"},
+			{Kind: rbacv1.ServiceAccountKind, Name: "deployer", Namespace: "hello-ns1"},
+		},
+		ProjectMonitorSubjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "bob"}},
+	}), newVerrazzano()).Build()
+
+	reconcileProject(t, cli)
+	assert.Contains(t, f.calls, "keycloak group devs role verrazzano-project-hello-admin")
+	assert.NotContains(t, f.calls, "keycloak group verrazzano-project-hello-monitors role verrazzano-project-hello-monitor")
+	assert.Equal(t, []string{"zed"}, f.mappings["verrazzano-project-hello-admin"].Users)
+	assert.Equal(t, []string{"bob"}, f.mappings["verrazzano-project-hello-monitor"].Users)
+	assert.Equal(t, []string{"alice", "zed"}, f.teamMembers[1])
+	assert.Equal(t, []string{"bob"}, f.teamMembers[2])
+}
+
+// TestProvisionSecurityDisabled tests provisioning the tenancy when the OpenSearch security plugin is disabled
+// GIVEN an OpenSearch without the security plugin
+// WHEN the project is reconciled
+// THEN the OpenSearch tenancy and the logs datasource are skipped and the rest of the Grafana tenancy is provisioned
+func TestProvisionSecurityDisabled(t *testing.T) {
+	f := setupBackends(t)
+	f.securityEnabled = false
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newProject(clustersv1alpha1.SecuritySpec{}), newVerrazzano()).Build()
+
+	reconcileProject(t, cli)
+	assert.Empty(t, f.roles)
+	assert.NotContains(t, f.calls, "opensearch tenant verrazzano-project-hello")
+	assert.Contains(t, f.calls, "grafana folder verrazzano-project-hello")
+	assert.Contains(t, f.calls, "grafana delete datasource verrazzano-project-hello-logs")
+	assert.NotContains(t, f.calls, "grafana datasource verrazzano-project-hello-logs")
+	assert.Empty(t, f.userPasswords)
+}
+
+// TestProjectOutsideMultiClusterNamespace tests a project outside of the multicluster namespace
+// GIVEN a request for a project in another namespace
+// WHEN the project is reconciled
+// THEN nothing is provisioned
+func TestProjectOutsideMultiClusterNamespace(t *testing.T) {
+	f := setupBackends(t)
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newVerrazzano()).Build()
+	r := &ProjectTenancyReconciler{Client: cli, Scheme: newScheme()}
+
+	result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: projectName}})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Empty(t, f.calls)
+}
+
+// TestDeleteTenancy tests removing the tenancy of a deleted project
+// GIVEN a deleted project with the tenancy finalizer
+// WHEN the project is reconciled
+// THEN the Grafana objects, OpenSearch roles and tenant, and Keycloak user and roles are deleted and the finalizer is
+// removed
+func TestDeleteTenancy(t *testing.T) {
+	f := setupBackends(t)
+	vp := newProject(clustersv1alpha1.SecuritySpec{})
+	vp.Finalizers = []string{finalizerName}
+	now := metav1.Now()
+	vp.DeletionTimestamp = &now
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vp, newVerrazzano()).Build()
+
+	reconcileProject(t, cli)
+	assert.Equal(t, []string{
+		"grafana delete datasource verrazzano-project-hello-logs",
+		"grafana delete folder verrazzano-project-hello",
+		"grafana delete team verrazzano-project-hello-admins",
+		"grafana delete team verrazzano-project-hello-monitors",
+		"opensearch delete rolesmapping verrazzano-project-hello-admin",
+		"opensearch delete role verrazzano-project-hello-admin",
+		"opensearch delete rolesmapping verrazzano-project-hello-monitor",
+		"opensearch delete role verrazzano-project-hello-monitor",
+		"opensearch delete tenant verrazzano-project-hello",
+		"keycloak delete user verrazzano-project-hello-logs",
+		"keycloak delete role verrazzano-project-hello-admin",
+		"keycloak delete role verrazzano-project-hello-monitor",
+	}, f.calls)
+
+	// The project is gone once its last finalizer is removed
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: projectName}, &clustersv1alpha1.VerrazzanoProject{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestDeleteTenancyBackendsDown tests removing the tenancy of a deleted project when backends are not available
+// GIVEN a deleted project with the tenancy finalizer
+// WHEN the project is reconciled while Grafana and OpenSearch cannot be reached
+// THEN the Keycloak tenancy is still removed and the finalizer is removed
+func TestDeleteTenancyBackendsDown(t *testing.T) {
+	f := setupBackends(t)
+	f.failures["grafana"] = true
+	f.failures["opensearch"] = true
+	vp := newProject(clustersv1alpha1.SecuritySpec{})
+	vp.Finalizers = []string{finalizerName}
+	now := metav1.Now()
+	vp.DeletionTimestamp = &now
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vp, newVerrazzano()).Build()
+
+	result := reconcileProject(t, cli)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Equal(t, []string{
+		"keycloak delete user verrazzano-project-hello-logs",
+		"keycloak delete role verrazzano-project-hello-admin",
+		"keycloak delete role verrazzano-project-hello-monitor",
+	}, f.calls)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoMultiClusterNamespace, Name: projectName}, &clustersv1alpha1.VerrazzanoProject{})
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultDegradedAfter    = 5 * time.Minute
+	defaultUnreachableAfter = 15 * time.Minute
+
+	// The weights of the health checks in the health score, which add up to 100
+	heartbeatWeight = 50
+	metricsWeight   = 25
+	rancherWeight   = 25
+
+	metricsProbeTimeout = 5 * time.Second
+)
+
+var healthStates = []clustersv1alpha1.HealthStateType{
+	clustersv1alpha1.HealthUnknown,
+	clustersv1alpha1.HealthHealthy,
+	clustersv1alpha1.HealthDegraded,
+	clustersv1alpha1.HealthUnreachable,
+}
+
+var (
+	healthScoreMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vz_cluster_operator_managed_cluster_health_score",
+		Help: "The health score of the managed cluster, from 0 (unreachable) to 100 (healthy)",
+	}, []string{"cluster"})
+	heartbeatAgeMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vz_cluster_operator_managed_cluster_agent_heartbeat_age_seconds",
+		Help: "The time since the agent of the managed cluster last connected to the admin cluster",
+	}, []string{"cluster"})
+	healthStateMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vz_cluster_operator_managed_cluster_health_state",
+		Help: "The health state of the managed cluster, set to 1 for the current state and 0 for the other states",
+	}, []string{"cluster", "state"})
+)
+
+// leveraged to replace method (unit testing)
+var probeMetricsHost = func(host string) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, "443"), metricsProbeTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// evaluateHealth computes the health of the managed cluster in the VMC status, which is updated with the other
+// status fields, and exports it as metrics. It returns true if the policy requires the cluster to be deregistered.
+func (r *VerrazzanoManagedClusterReconciler) evaluateHealth(log vzlog.VerrazzanoLogger, vmc *clustersv1alpha1.VerrazzanoManagedCluster) bool {
+	now := time.Now()
+	policy := getHealthPolicy(vmc)
+	health := computeHealth(vmc, policy, now)
+
+	previous := vmc.Status.Health
+	if previous != nil && previous.State == health.State && previous.LastTransitionTime != nil {
+		health.LastTransitionTime = previous.LastTransitionTime
+	} else {
+		transitionTime := metav1.NewTime(now)
+		health.LastTransitionTime = &transitionTime
+		if previous != nil {
+			log.Infof("The health of VMC %s changed from %s to %s: %s", vmc.Name, previous.State, health.State, health.Message)
+		}
+	}
+	vmc.Status.Health = health
+	exportHealthMetrics(vmc, now)
+
+	if health.State != clustersv1alpha1.HealthUnreachable || policy.DeregisterAfter == nil {
+		return false
+	}
+	return now.Sub(health.LastTransitionTime.Time) > policy.DeregisterAfter.Duration
+}
+
+// computeHealth computes the health score and state of a managed cluster. The agent heartbeat age determines whether
+// the cluster is unreachable, any failed check makes a reachable cluster degraded.
+func computeHealth(vmc *clustersv1alpha1.VerrazzanoManagedCluster, policy clustersv1alpha1.HealthPolicy, now time.Time) *clustersv1alpha1.HealthStatus {
+	degradedAfter := policy.DegradedAfter.Duration
+	unreachableAfter := policy.UnreachableAfter.Duration
+	state := clustersv1alpha1.HealthHealthy
+	degrade := func() {
+		if state == clustersv1alpha1.HealthHealthy {
+			state = clustersv1alpha1.HealthDegraded
+		}
+	}
+	score := 0
+	var messages []string
+
+	// Agent heartbeat
+	if vmc.Status.LastAgentConnectTime == nil {
+		state = clustersv1alpha1.HealthUnknown
+		messages = append(messages, "The agent has not connected to the admin cluster")
+	} else {
+		age := now.Sub(vmc.Status.LastAgentConnectTime.Time)
+		switch {
+		case age <= degradedAfter:
+			score += heartbeatWeight
+		case age < unreachableAfter:
+			// The heartbeat score decreases linearly until the cluster is unreachable
+			score += int(math.Round(heartbeatWeight * float64(unreachableAfter-age) / float64(unreachableAfter-degradedAfter)))
+			degrade()
+			messages = append(messages, fmt.Sprintf("The agent last connected %s ago", age.Round(time.Second)))
+		default:
+			state = clustersv1alpha1.HealthUnreachable
+			messages = append(messages, fmt.Sprintf("The agent last connected %s ago", age.Round(time.Second)))
+		}
+	}
+
+	// Metrics endpoint reachability, the Thanos Query store takes precedence over Prometheus like for the federation
+	metricsHost := vmc.Status.ThanosQueryStore
+	if metricsHost == "" {
+		metricsHost = vmc.Status.PrometheusHost
+	}
+	if metricsHost == "" {
+		// The managed cluster does not expose metrics
+		score += metricsWeight
+	} else if err := probeMetricsHost(metricsHost); err != nil {
+		degrade()
+		messages = append(messages, fmt.Sprintf("The metrics endpoint %s is not reachable: %v", metricsHost, err))
+	} else {
+		score += metricsWeight
+	}
+
+	// Rancher registration
+	switch vmc.Status.RancherRegistration.Status {
+	case clustersv1alpha1.RegistrationFailed, clustersv1alpha1.DeleteFailed:
+		degrade()
+		messages = append(messages, fmt.Sprintf("The Rancher registration status is %s", vmc.Status.RancherRegistration.Status))
+	default:
+		score += rancherWeight
+	}
+
+	if state == clustersv1alpha1.HealthUnreachable {
+		score = 0
+	}
+	return &clustersv1alpha1.HealthStatus{
+		Score:   score,
+		State:   state,
+		Message: strings.Join(messages, "; "),
+		PlacementDisabled: state == clustersv1alpha1.HealthUnreachable ||
+			(state == clustersv1alpha1.HealthDegraded && policy.DisablePlacementWhenDegraded),
+	}
+}
+
+// getHealthPolicy returns the health policy of the VMC, with the defaults of the unset thresholds
+func getHealthPolicy(vmc *clustersv1alpha1.VerrazzanoManagedCluster) clustersv1alpha1.HealthPolicy {
+	policy := clustersv1alpha1.HealthPolicy{}
+	if vmc.Spec.HealthPolicy != nil {
+		policy = *vmc.Spec.HealthPolicy.DeepCopy()
+	}
+	if policy.DegradedAfter == nil {
+		policy.DegradedAfter = &metav1.Duration{Duration: defaultDegradedAfter}
+	}
+	if policy.UnreachableAfter == nil || policy.UnreachableAfter.Duration <= policy.DegradedAfter.Duration {
+		unreachableAfter := defaultUnreachableAfter
+		if unreachableAfter <= policy.DegradedAfter.Duration {
+			unreachableAfter = 3 * policy.DegradedAfter.Duration
+		}
+		policy.UnreachableAfter = &metav1.Duration{Duration: unreachableAfter}
+	}
+	return policy
+}
+
+// exportHealthMetrics sets the health metrics of the managed cluster
+func exportHealthMetrics(vmc *clustersv1alpha1.VerrazzanoManagedCluster, now time.Time) {
+	healthScoreMetric.WithLabelValues(vmc.Name).Set(float64(vmc.Status.Health.Score))
+	if vmc.Status.LastAgentConnectTime != nil {
+		heartbeatAgeMetric.WithLabelValues(vmc.Name).Set(now.Sub(vmc.Status.LastAgentConnectTime.Time).Seconds())
+	}
+	for _, state := range healthStates {
+		value := 0.0
+		if state == vmc.Status.Health.State {
+			value = 1
+		}
+		healthStateMetric.WithLabelValues(vmc.Name, string(state)).Set(value)
+	}
+}
+
+// deleteHealthMetrics removes the health metrics of a deleted managed cluster
+func deleteHealthMetrics(vmc *clustersv1alpha1.VerrazzanoManagedCluster) {
+	healthScoreMetric.DeleteLabelValues(vmc.Name)
+	heartbeatAgeMetric.DeleteLabelValues(vmc.Name)
+	healthStateMetric.DeletePartialMatch(prometheus.Labels{"cluster": vmc.Name})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package vmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newHealthVMC returns a VMC whose agent last connected the given time ago
+func newHealthVMC(heartbeatAge time.Duration) *v1alpha1.VerrazzanoManagedCluster {
+	vmc := &v1alpha1.VerrazzanoManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "managed1", Namespace: constants.VerrazzanoMultiClusterNamespace},
+	}
+	if heartbeatAge >= 0 {
+		connectTime := metav1.NewTime(time.Now().Add(-heartbeatAge))
+		vmc.Status.LastAgentConnectTime = &connectTime
+	}
+	return vmc
+}
+
+// TestComputeHealth tests computing the health of a managed cluster
+// GIVEN managed clusters with different heartbeat ages, metrics endpoints and Rancher registrations
+// WHEN the health is computed
+// THEN the expected score and state are returned
+func TestComputeHealth(t *testing.T) {
+	probeMetricsHost = func(host string) error {
+		if host == "unreachable.example.com" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	defer func() { probeMetricsHost = func(host string) error { return nil } }()
+
+	tests := []struct {
+		name              string
+		heartbeatAge      time.Duration
+		metricsHost       string
+		rancherStatus     v1alpha1.RancherRegistrationStatus
+		policy            *v1alpha1.HealthPolicy
+		score             int
+		state             v1alpha1.HealthStateType
+		placementDisabled bool
+	}{
+		{name: "healthy", heartbeatAge: time.Minute, metricsHost: "prometheus.example.com", rancherStatus: v1alpha1.RegistrationCompleted, score: 100, state: v1alpha1.HealthHealthy},
+		{name: "never connected", heartbeatAge: -1, score: 50, state: v1alpha1.HealthUnknown},
+		{name: "late heartbeat", heartbeatAge: 10 * time.Minute, score: 75, state: v1alpha1.HealthDegraded},
+		{name: "unreachable metrics", heartbeatAge: time.Minute, metricsHost: "unreachable.example.com", score: 75, state: v1alpha1.HealthDegraded},
+		{name: "failed Rancher registration", heartbeatAge: time.Minute, rancherStatus: v1alpha1.RegistrationFailed, score: 75, state: v1alpha1.HealthDegraded},
+		{name: "placement disabled when degraded", heartbeatAge: 10 * time.Minute, policy: &v1alpha1.HealthPolicy{DisablePlacementWhenDegraded: true}, score: 75, state: v1alpha1.HealthDegraded, placementDisabled: true},
+		{name: "unreachable", heartbeatAge: time.Hour, score: 0, state: v1alpha1.HealthUnreachable, placementDisabled: true},
+		{name: "custom thresholds", heartbeatAge: time.Hour, policy: &v1alpha1.HealthPolicy{DegradedAfter: &metav1.Duration{Duration: 2 * time.Hour}}, score: 100, state: v1alpha1.HealthHealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vmc := newHealthVMC(tt.heartbeatAge)
+			vmc.Status.PrometheusHost = tt.metricsHost
+			vmc.Status.RancherRegistration.Status = tt.rancherStatus
+			vmc.Spec.HealthPolicy = tt.policy
+
+			health := computeHealth(vmc, getHealthPolicy(vmc), time.Now())
+			assert.Equal(t, tt.score, health.Score)
+			assert.Equal(t, tt.state, health.State)
+			assert.Equal(t, tt.placementDisabled, health.PlacementDisabled)
+		})
+	}
+}
+
+// TestEvaluateHealth tests evaluating the health of a managed cluster
+// GIVEN an unreachable managed cluster with a deregistration grace period
+// WHEN the health is evaluated
+// THEN the health metrics are exported, and deregistration is only required once the grace period has expired
+func TestEvaluateHealth(t *testing.T) {
+	r := &VerrazzanoManagedClusterReconciler{}
+	vmc := newHealthVMC(time.Hour)
+	vmc.Spec.HealthPolicy = &v1alpha1.HealthPolicy{DeregisterAfter: &metav1.Duration{Duration: 24 * time.Hour}}
+
+	assert.False(t, r.evaluateHealth(vzlog.DefaultLogger(), vmc))
+	assert.Equal(t, v1alpha1.HealthUnreachable, vmc.Status.Health.State)
+	assert.Equal(t, float64(0), testutil.ToFloat64(healthScoreMetric.WithLabelValues("managed1")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(healthStateMetric.WithLabelValues("managed1", string(v1alpha1.HealthUnreachable))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(healthStateMetric.WithLabelValues("managed1", string(v1alpha1.HealthHealthy))))
+
+	// The transition time is kept while the state is unchanged
+	unreachableSince := metav1.NewTime(time.Now().Add(-25 * time.Hour))
+	vmc.Status.Health.LastTransitionTime = &unreachableSince
+	assert.True(t, r.evaluateHealth(vzlog.DefaultLogger(), vmc))
+	assert.Equal(t, &unreachableSince, vmc.Status.Health.LastTransitionTime)
+
+	deleteHealthMetrics(vmc)
+	assert.Equal(t, 0, testutil.CollectAndCount(healthStateMetric))
+}
+
+// TestDeregisterUnreachableCluster tests the deregistration of an unreachable managed cluster
+// GIVEN a VMC unreachable for longer than the deregistration grace period of its health policy
+// WHEN the VMC is reconciled
+// THEN the VMC is deleted
+func TestDeregisterUnreachableCluster(t *testing.T) {
+	vmc := newHealthVMC(48 * time.Hour)
+	vmc.Finalizers = []string{finalizerName}
+	vmc.Spec.HealthPolicy = &v1alpha1.HealthPolicy{DeregisterAfter: &metav1.Duration{Duration: time.Hour}}
+	unreachableSince := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	vmc.Status.Health = &v1alpha1.HealthStatus{State: v1alpha1.HealthUnreachable, LastTransitionTime: &unreachableSince}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vmc).Build()
+	r := newVMCReconciler(cli)
+
+	_, err := r.Reconcile(context.TODO(), newRequest(vmc.Namespace, vmc.Name))
+	assert.NoError(t, err)
+
+	deleted := &v1alpha1.VerrazzanoManagedCluster{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: vmc.Namespace, Name: vmc.Name}, deleted))
+	assert.False(t, deleted.DeletionTimestamp.IsZero())
+	deleteHealthMetrics(vmc)
+}
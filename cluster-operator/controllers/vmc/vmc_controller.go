@@ -137,6 +137,8 @@ func (r *VerrazzanoManagedClusterReconciler) doReconcile(ctx context.Context, lo
 				return reconcile.Result{}, err
 			}
 
+			deleteHealthMetrics(vmc)
+
 			// Remove the finalizer and update the Verrazzano resource if the deletion has finished.
 			log.Infof("Removing finalizer %s", finalizerName)
 			vmc.ObjectMeta.Finalizers = vzstring.RemoveStringFromSlice(vmc.ObjectMeta.Finalizers, finalizerName)
@@ -157,6 +159,15 @@ func (r *VerrazzanoManagedClusterReconciler) doReconcile(ctx context.Context, lo
 		}
 	}
 
+	// Evaluate the health of the managed cluster first, so that it is updated even when the cluster cannot be synced
+	if r.evaluateHealth(log, vmc) {
+		log.Infof("Deregistering VMC %s, the managed cluster has been unreachable since %v", vmc.Name, vmc.Status.Health.LastTransitionTime)
+		if err := r.Delete(ctx, vmc); err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Sync the service account
 	log.Debugf("Syncing the ServiceAccount for VMC %s", vmc.Name)
 	err := r.syncServiceAccount(vmc)
@@ -646,6 +657,9 @@ func (r *VerrazzanoManagedClusterReconciler) updateStatus(ctx context.Context, v
 	}
 	existingVMC.Status.State = vmc.Status.State
 	existingVMC.Status.ArgoCDRegistration = vmc.Status.ArgoCDRegistration
+	if vmc.Status.Health != nil {
+		existingVMC.Status.Health = vmc.Status.Health
+	}
 
 	r.log.Debugf("Updating Status of VMC %s: %v", vmc.Name, vmc.Status.Conditions)
 	return r.Status().Update(ctx, existingVMC)
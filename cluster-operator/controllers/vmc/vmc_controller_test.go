@@ -83,6 +83,10 @@ func init() {
 	createClient = func(r *VerrazzanoManagedClusterReconciler, vmc *v1alpha1.VerrazzanoManagedCluster) error {
 		return nil
 	}
+	// stub out the probe of the managed cluster metrics endpoint for these tests
+	probeMetricsHost = func(host string) error {
+		return nil
+	}
 }
 
 // TestCreateVMC tests the Reconcile method for the following use case
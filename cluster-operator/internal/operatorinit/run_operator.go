@@ -7,6 +7,9 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/capi"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/fleetupgrade"
+	"github.com/verrazzano/verrazzano/cluster-operator/controllers/projecttenancy"
 	"github.com/verrazzano/verrazzano/cluster-operator/controllers/rancher"
 	"github.com/verrazzano/verrazzano/cluster-operator/controllers/vmc"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
@@ -97,6 +100,33 @@ func StartClusterOperator(metricsAddr string, enableLeaderElection bool, probeAd
 		os.Exit(1)
 	}
 
+	// Set up the reconciler provisioning the observability tenancy of VerrazzanoProject objects
+	if err = (&projecttenancy.ProjectTenancyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to setup controller VerrazzanoProject tenancy")
+		os.Exit(1)
+	}
+
+	// Set up the reconciler provisioning managed clusters with Cluster API from ManagedClusterTemplate objects
+	if err = (&capi.ManagedClusterTemplateReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to setup controller ManagedClusterTemplate")
+		os.Exit(1)
+	}
+
+	// Set up the reconciler rolling out Verrazzano upgrades to the managed clusters from VerrazzanoFleetUpgrade objects
+	if err = (&fleetupgrade.VerrazzanoFleetUpgradeReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "Failed to setup controller VerrazzanoFleetUpgrade")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		log.Error(err, "unable to set up health check")
 		os.Exit(1)
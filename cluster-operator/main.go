@@ -7,6 +7,7 @@ import (
 	"flag"
 	"os"
 
+	vzappclusters "github.com/verrazzano/verrazzano/application-operator/apis/clusters/v1alpha1"
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/cluster-operator/internal/operatorinit"
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
@@ -42,6 +43,7 @@ func init() {
 
 	utilruntime.Must(clustersv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(vzappclusters.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
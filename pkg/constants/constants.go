@@ -25,6 +25,10 @@ const LifecycleActionStop = "stop"
 // LifecycleActionStart - the annotation value used to start a workload
 const LifecycleActionStart = "start"
 
+// SkipUpgradeRestartLabel - the label used on an application configuration or namespace to opt out of the
+// application restart done after a Verrazzano upgrade
+const SkipUpgradeRestartLabel = "verrazzano.io/skip-upgrade-restart"
+
 // VerrazzanoWebLogicWorkloadKind - the VerrazzanoWebLogicWorkload resource kind
 const VerrazzanoWebLogicWorkloadKind = "VerrazzanoWebLogicWorkload"
 
@@ -229,3 +233,22 @@ const ThanosQueryIngress = "thanos-query-frontend"
 
 // ThanosQueryStoreIngress is the name of the ingress for the Thanos Query Store API
 const ThanosQueryStoreIngress = "thanos-query-store"
+
+// VerrazzanoProfileLabel is the label of the ConfigMaps in the verrazzano-install namespace which define custom
+// installation profiles, the value of the label is the name of the profile
+const VerrazzanoProfileLabel = "install.verrazzano.io/profile"
+
+// UpgradeRequestConfigMapName is the name of the ConfigMap in the verrazzano-install namespace used by the cluster
+// agent to request the installation of the platform operator of a new version, which the platform operator applies
+const UpgradeRequestConfigMapName = "verrazzano-upgrade-request"
+
+// Keys of the upgrade request ConfigMap
+const (
+	// UpgradeRequestVersionKey is the key of the requested Verrazzano version
+	UpgradeRequestVersionKey = "version"
+	// UpgradeRequestManifestURLKey is the key of the URL of the platform operator manifest, defaulting to the manifest
+	// of the Verrazzano release
+	UpgradeRequestManifestURLKey = "manifestURL"
+	// UpgradeRequestMessageKey is the key of the message reported by the platform operator when the request fails
+	UpgradeRequestMessageKey = "message"
+)
@@ -0,0 +1,264 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group is the API group of the Kubernetes Gateway API resources
+	Group = "gateway.networking.k8s.io"
+	// Version is the version of the Gateway API resources rendered by Verrazzano
+	Version = "v1beta1"
+
+	// DefaultGatewayClassName is the GatewayClass of the Istio Gateway API implementation
+	DefaultGatewayClassName = "istio"
+
+	// IstioIngressGatewayAddress is the address of the Istio ingress gateway service, the generated Gateways are
+	// bound to the existing ingress gateway instead of having Istio deploy a new one per Gateway
+	IstioIngressGatewayAddress = "istio-ingressgateway.istio-system.svc.cluster.local"
+
+	// PathMatchExact matches the request path exactly
+	PathMatchExact = "Exact"
+	// PathMatchPathPrefix matches the request path by prefix
+	PathMatchPathPrefix = "PathPrefix"
+	// PathMatchRegularExpression matches the request path with a regular expression
+	PathMatchRegularExpression = "RegularExpression"
+
+	httpsPort = int64(443)
+)
+
+var (
+	// GatewayGVK is the GroupVersionKind of a Gateway
+	GatewayGVK = schema.GroupVersionKind{Group: Group, Version: Version, Kind: "Gateway"}
+	// HTTPRouteGVK is the GroupVersionKind of an HTTPRoute
+	HTTPRouteGVK = schema.GroupVersionKind{Group: Group, Version: Version, Kind: "HTTPRoute"}
+	// ReferenceGrantGVK is the GroupVersionKind of a ReferenceGrant
+	ReferenceGrantGVK = schema.GroupVersionKind{Group: Group, Version: Version, Kind: "ReferenceGrant"}
+)
+
+// Listener is an HTTPS listener of a Gateway terminating TLS for a single host
+type Listener struct {
+	// Name is the name of the listener, listeners without a name are named by their index
+	Name            string
+	Hostname        string
+	SecretName      string
+	SecretNamespace string
+}
+
+// PathMatch is the path match of an HTTPRoute rule
+type PathMatch struct {
+	Type  string
+	Value string
+}
+
+// Backend is the backend service of an HTTPRoute rule
+type Backend struct {
+	Name      string
+	Namespace string
+	Port      int32
+}
+
+// RouteRule is a rule of an HTTPRoute
+type RouteRule struct {
+	Paths []PathMatch
+	// RequestHeaders are set on the requests before they are forwarded to the backend
+	RequestHeaders map[string]string
+	Backend        Backend
+}
+
+// NewGateway returns an empty Gateway with the given name and namespace
+func NewGateway(name, namespace string) *unstructured.Unstructured {
+	return newObject(GatewayGVK, name, namespace)
+}
+
+// NewHTTPRoute returns an empty HTTPRoute with the given name and namespace
+func NewHTTPRoute(name, namespace string) *unstructured.Unstructured {
+	return newObject(HTTPRouteGVK, name, namespace)
+}
+
+// NewReferenceGrant returns an empty ReferenceGrant with the given name and namespace
+func NewReferenceGrant(name, namespace string) *unstructured.Unstructured {
+	return newObject(ReferenceGrantGVK, name, namespace)
+}
+
+// NewList returns an empty list of the given Gateway API kind
+func NewList(gvk schema.GroupVersionKind) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+func newObject(gvk schema.GroupVersionKind, name, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+// SetGatewaySpec sets the spec of a Gateway served by the Istio ingress gateway, with an HTTPS listener per host.
+// Routes from all namespaces may attach to the listeners.
+func SetGatewaySpec(gateway *unstructured.Unstructured, gatewayClassName string, listeners []Listener) error {
+	if gatewayClassName == "" {
+		gatewayClassName = DefaultGatewayClassName
+	}
+	var specListeners []interface{}
+	for i, l := range listeners {
+		name := l.Name
+		if name == "" {
+			name = ListenerName(i)
+		}
+		certRef := map[string]interface{}{
+			"group": "",
+			"kind":  "Secret",
+			"name":  l.SecretName,
+		}
+		if l.SecretNamespace != "" && l.SecretNamespace != gateway.GetNamespace() {
+			certRef["namespace"] = l.SecretNamespace
+		}
+		specListeners = append(specListeners, map[string]interface{}{
+			"name":     name,
+			"hostname": l.Hostname,
+			"port":     httpsPort,
+			"protocol": "HTTPS",
+			"tls": map[string]interface{}{
+				"mode":            "Terminate",
+				"certificateRefs": []interface{}{certRef},
+			},
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{"from": "All"},
+			},
+		})
+	}
+	spec := map[string]interface{}{
+		"gatewayClassName": gatewayClassName,
+		"addresses": []interface{}{
+			map[string]interface{}{"type": "Hostname", "value": IstioIngressGatewayAddress},
+		},
+		"listeners": specListeners,
+	}
+	return unstructured.SetNestedMap(gateway.Object, spec, "spec")
+}
+
+// GetGatewayListeners returns the listeners of a Gateway
+func GetGatewayListeners(gateway *unstructured.Unstructured) []Listener {
+	items, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	var listeners []Listener
+	for _, item := range items {
+		l, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listener := Listener{SecretNamespace: gateway.GetNamespace()}
+		listener.Name, _, _ = unstructured.NestedString(l, "name")
+		listener.Hostname, _, _ = unstructured.NestedString(l, "hostname")
+		refs, _, _ := unstructured.NestedSlice(l, "tls", "certificateRefs")
+		if len(refs) > 0 {
+			if ref, ok := refs[0].(map[string]interface{}); ok {
+				listener.SecretName, _, _ = unstructured.NestedString(ref, "name")
+				if ns, found, _ := unstructured.NestedString(ref, "namespace"); found {
+					listener.SecretNamespace = ns
+				}
+			}
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// ListenerName returns the name of the listener with the given index
+func ListenerName(i int) string {
+	return fmt.Sprintf("https-%d", i)
+}
+
+// SetHTTPRouteSpec sets the spec of an HTTPRoute attached to the given Gateway
+func SetHTTPRouteSpec(route *unstructured.Unstructured, gatewayName, gatewayNamespace string, hostnames []string, rules []RouteRule) error {
+	var specHostnames []interface{}
+	for _, h := range hostnames {
+		specHostnames = append(specHostnames, h)
+	}
+	var specRules []interface{}
+	for _, rule := range rules {
+		specRule := map[string]interface{}{}
+		var matches []interface{}
+		for _, p := range rule.Paths {
+			matches = append(matches, map[string]interface{}{
+				"path": map[string]interface{}{"type": p.Type, "value": p.Value},
+			})
+		}
+		if len(matches) > 0 {
+			specRule["matches"] = matches
+		}
+		if len(rule.RequestHeaders) > 0 {
+			var headers []interface{}
+			for _, name := range sortedKeys(rule.RequestHeaders) {
+				headers = append(headers, map[string]interface{}{"name": name, "value": rule.RequestHeaders[name]})
+			}
+			specRule["filters"] = []interface{}{
+				map[string]interface{}{
+					"type":                  "RequestHeaderModifier",
+					"requestHeaderModifier": map[string]interface{}{"set": headers},
+				},
+			}
+		}
+		backendRef := map[string]interface{}{"name": rule.Backend.Name}
+		if rule.Backend.Namespace != "" && rule.Backend.Namespace != route.GetNamespace() {
+			backendRef["namespace"] = rule.Backend.Namespace
+		}
+		if rule.Backend.Port != 0 {
+			backendRef["port"] = int64(rule.Backend.Port)
+		}
+		specRule["backendRefs"] = []interface{}{backendRef}
+		specRules = append(specRules, specRule)
+	}
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"group":     Group,
+				"kind":      GatewayGVK.Kind,
+				"name":      gatewayName,
+				"namespace": gatewayNamespace,
+			},
+		},
+		"rules": specRules,
+	}
+	if len(specHostnames) > 0 {
+		spec["hostnames"] = specHostnames
+	}
+	return unstructured.SetNestedMap(route.Object, spec, "spec")
+}
+
+// SetReferenceGrantSpec sets the spec of a ReferenceGrant allowing objects of the given kind in the from namespace to
+// refer to the named objects of the to kind in the namespace of the grant
+func SetReferenceGrantSpec(grant *unstructured.Unstructured, fromKind, fromNamespace, toKind string, toNames []string) error {
+	var to []interface{}
+	for _, name := range toNames {
+		to = append(to, map[string]interface{}{"group": "", "kind": toKind, "name": name})
+	}
+	if len(to) == 0 {
+		to = append(to, map[string]interface{}{"group": "", "kind": toKind})
+	}
+	spec := map[string]interface{}{
+		"from": []interface{}{
+			map[string]interface{}{"group": Group, "kind": fromKind, "namespace": fromNamespace},
+		},
+		"to": to,
+	}
+	return unstructured.SetNestedMap(grant.Object, spec, "spec")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// TestGatewaySpec tests setting the spec of a Gateway
+// GIVEN listeners with secrets in the namespace of the Gateway and in another namespace
+// WHEN the Gateway spec is set
+// THEN the Gateway is bound to the Istio ingress gateway, the default GatewayClass is used and the listeners are
+// returned unchanged
+func TestGatewaySpec(t *testing.T) {
+	gateway := NewGateway("gw", "ns")
+	listeners := []Listener{
+		{Name: "https-0", Hostname: "a.example.com", SecretName: "a-tls", SecretNamespace: "ns"},
+		{Name: "custom", Hostname: "b.example.com", SecretName: "b-tls", SecretNamespace: "other"},
+	}
+	assert.NoError(t, SetGatewaySpec(gateway, "", []Listener{
+		{Hostname: "a.example.com", SecretName: "a-tls", SecretNamespace: "ns"},
+		listeners[1],
+	}))
+	assert.Equal(t, GatewayGVK, gateway.GroupVersionKind())
+	className, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+	assert.Equal(t, DefaultGatewayClassName, className)
+	addresses, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "addresses")
+	assert.Equal(t, IstioIngressGatewayAddress, addresses[0].(map[string]interface{})["value"])
+	assert.Equal(t, listeners, GetGatewayListeners(gateway))
+}
+
+// TestHTTPRouteSpec tests setting the spec of an HTTPRoute
+// GIVEN a route rule with a path, request headers and a backend in another namespace
+// WHEN the HTTPRoute spec is set
+// THEN the route is attached to the Gateway and the rule has the path match, header filter and backend
+func TestHTTPRouteSpec(t *testing.T) {
+	route := NewHTTPRoute("route", "ns")
+	assert.NoError(t, SetHTTPRouteSpec(route, "gw", "gw-ns", []string{"a.example.com"}, []RouteRule{{
+		Paths:          []PathMatch{{Type: PathMatchPathPrefix, Value: "/api"}},
+		RequestHeaders: map[string]string{"b": "2", "a": "1"},
+		Backend:        Backend{Name: "svc", Namespace: "other", Port: 8080},
+	}}))
+
+	parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, "gw", parents[0].(map[string]interface{})["name"])
+	assert.Equal(t, "gw-ns", parents[0].(map[string]interface{})["namespace"])
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"a.example.com"}, hostnames)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	value, _, _ := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "path", "value")
+	assert.Equal(t, "/api", value)
+	headers, _, _ := unstructured.NestedSlice(rule["filters"].([]interface{})[0].(map[string]interface{}), "requestHeaderModifier", "set")
+	assert.Equal(t, "a", headers[0].(map[string]interface{})["name"])
+	assert.Equal(t, "b", headers[1].(map[string]interface{})["name"])
+	backend := rule["backendRefs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "other", backend["namespace"])
+	assert.Equal(t, int64(8080), backend["port"])
+}
+
+// TestReferenceGrantSpec tests setting the spec of a ReferenceGrant
+// GIVEN a Gateway namespace and secret names
+// WHEN the ReferenceGrant spec is set
+// THEN Gateways of the namespace are allowed to refer to the named secrets
+func TestReferenceGrantSpec(t *testing.T) {
+	grant := NewReferenceGrant("grant", "ns")
+	assert.NoError(t, SetReferenceGrantSpec(grant, GatewayGVK.Kind, "istio-system", "Secret", []string{"a-tls", "b-tls"}))
+	from, _, _ := unstructured.NestedSlice(grant.Object, "spec", "from")
+	assert.Equal(t, map[string]interface{}{"group": Group, "kind": "Gateway", "namespace": "istio-system"}, from[0])
+	to, _, _ := unstructured.NestedSlice(grant.Object, "spec", "to")
+	assert.Len(t, to, 2)
+	assert.Equal(t, "b-tls", to[1].(map[string]interface{})["name"])
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Folder permission levels
+const (
+	PermissionView  = 1
+	PermissionEdit  = 2
+	PermissionAdmin = 4
+)
+
+// FolderPermission grants a permission on a folder to a team
+type FolderPermission struct {
+	TeamID     int64 `json:"teamId"`
+	Permission int   `json:"permission"`
+}
+
+// Datasource is a Grafana datasource
+type Datasource struct {
+	UID       string                 `json:"uid"`
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	Access    string                 `json:"access"`
+	URL       string                 `json:"url"`
+	Database  string                 `json:"database,omitempty"`
+	IsDefault bool                   `json:"isDefault"`
+	JSONData  map[string]interface{} `json:"jsonData,omitempty"`
+	// BasicAuth enables the basic authentication of the Grafana requests to the datasource URL
+	BasicAuth     bool   `json:"basicAuth"`
+	BasicAuthUser string `json:"basicAuthUser,omitempty"`
+	// SecureJSONData holds the secrets of the datasource, like the basic authentication password, which Grafana
+	// stores encrypted and never returns
+	SecureJSONData map[string]string `json:"secureJsonData,omitempty"`
+}
+
+// Dashboard is a Grafana dashboard model, see https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/view-dashboard-json-model/
+type Dashboard map[string]interface{}
+
+// teamMember is a member of a team
+type teamMember struct {
+	UserID int64  `json:"userId"`
+	Login  string `json:"login"`
+}
+
+// CreateOrUpdateFolder creates the folder with the given UID, or updates its title
+func (c *Client) CreateOrUpdateFolder(uid, title string) error {
+	_, status, err := c.call(http.MethodGet, "/api/folders/"+uid, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		_, _, err = c.call(http.MethodPost, "/api/folders", map[string]interface{}{"uid": uid, "title": title}, http.StatusOK)
+		return err
+	}
+	_, _, err = c.call(http.MethodPut, "/api/folders/"+uid, map[string]interface{}{"title": title, "overwrite": true}, http.StatusOK)
+	return err
+}
+
+// DeleteFolder deletes a folder and its dashboards if it exists
+func (c *Client) DeleteFolder(uid string) error {
+	_, _, err := c.call(http.MethodDelete, "/api/folders/"+uid, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// SetFolderPermissions replaces the permissions of a folder, only the Grafana admins and the given teams can then
+// access its dashboards
+func (c *Client) SetFolderPermissions(uid string, permissions []FolderPermission) error {
+	_, _, err := c.call(http.MethodPost, fmt.Sprintf("/api/folders/%s/permissions", uid), map[string]interface{}{"items": permissions}, http.StatusOK)
+	return err
+}
+
+// GetOrCreateTeam returns the ID of the team with the given name, creating the team if it does not exist
+func (c *Client) GetOrCreateTeam(name string) (int64, error) {
+	id, err := c.getTeamID(name)
+	if err != nil || id != 0 {
+		return id, err
+	}
+	body, _, err := c.call(http.MethodPost, "/api/teams", map[string]interface{}{"name": name}, http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	created := struct {
+		TeamID int64 `json:"teamId"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		return 0, fmt.Errorf("Failed to parse the created Grafana team %s: %v", name, err)
+	}
+	return created.TeamID, nil
+}
+
+// DeleteTeam deletes the team with the given name if it exists
+func (c *Client) DeleteTeam(name string) error {
+	id, err := c.getTeamID(name)
+	if err != nil || id == 0 {
+		return err
+	}
+	_, _, err = c.call(http.MethodDelete, fmt.Sprintf("/api/teams/%d", id), nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// SetTeamMembers makes the users with the given logins the members of the team. Users are only known to Grafana once
+// they have logged in, the users who have not are skipped and added when the members are set again.
+func (c *Client) SetTeamMembers(teamID int64, logins []string) error {
+	body, _, err := c.call(http.MethodGet, fmt.Sprintf("/api/teams/%d/members", teamID), nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var members []teamMember
+	if err := json.Unmarshal([]byte(body), &members); err != nil {
+		return fmt.Errorf("Failed to parse the members of Grafana team %d: %v", teamID, err)
+	}
+	current := map[string]int64{}
+	for _, member := range members {
+		current[member.Login] = member.UserID
+	}
+
+	desired := map[string]bool{}
+	for _, login := range logins {
+		desired[login] = true
+		if _, ok := current[login]; ok {
+			continue
+		}
+		body, status, err := c.call(http.MethodGet, "/api/users/lookup?loginOrEmail="+url.QueryEscape(login), nil, http.StatusOK, http.StatusNotFound)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusNotFound {
+			continue
+		}
+		user := struct {
+			ID int64 `json:"id"`
+		}{}
+		if err := json.Unmarshal([]byte(body), &user); err != nil {
+			return fmt.Errorf("Failed to parse Grafana user %s: %v", login, err)
+		}
+		if _, _, err := c.call(http.MethodPost, fmt.Sprintf("/api/teams/%d/members", teamID), map[string]interface{}{"userId": user.ID}, http.StatusOK); err != nil {
+			return err
+		}
+	}
+	for login, userID := range current {
+		if desired[login] {
+			continue
+		}
+		if _, _, err := c.call(http.MethodDelete, fmt.Sprintf("/api/teams/%d/members/%d", teamID, userID), nil, http.StatusOK, http.StatusNotFound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateOrUpdateDatasource creates the datasource, or updates the datasource with the same UID
+func (c *Client) CreateOrUpdateDatasource(datasource Datasource) error {
+	path := "/api/datasources/uid/" + datasource.UID
+	_, status, err := c.call(http.MethodGet, path, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		_, _, err = c.call(http.MethodPost, "/api/datasources", datasource, http.StatusOK)
+		return err
+	}
+	_, _, err = c.call(http.MethodPut, path, datasource, http.StatusOK)
+	return err
+}
+
+// DeleteDatasource deletes a datasource if it exists
+func (c *Client) DeleteDatasource(uid string) error {
+	_, _, err := c.call(http.MethodDelete, "/api/datasources/uid/"+uid, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// CreateOrUpdateDashboard creates the dashboard in the folder with the given UID, or replaces the dashboard with the
+// same UID
+func (c *Client) CreateOrUpdateDashboard(folderUID string, dashboard Dashboard) error {
+	_, _, err := c.call(http.MethodPost, "/api/dashboards/db", map[string]interface{}{"dashboard": dashboard, "folderUid": folderUID, "overwrite": true}, http.StatusOK)
+	return err
+}
+
+// getTeamID returns the ID of the team with the given name, or 0 if it does not exist
+func (c *Client) getTeamID(name string) (int64, error) {
+	body, _, err := c.call(http.MethodGet, "/api/teams/search?name="+url.QueryEscape(name), nil, http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	result := struct {
+		Teams []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"teams"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		return 0, fmt.Errorf("Failed to parse the Grafana teams: %v", err)
+	}
+	for _, team := range result.Teams {
+		if team.Name == name {
+			return team.ID, nil
+		}
+	}
+	return 0, nil
+}
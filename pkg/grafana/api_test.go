@@ -0,0 +1,211 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+var requestPattern = regexp.MustCompile(`-X (\w+) 'http://localhost:3000([^']*)'( -H 'Content-Type: application/json' --data-binary @-)?$`)
+
+// request is a Grafana HTTP API request made by the fake pod executor
+type request struct {
+	method string
+	path   string
+	body   string
+}
+
+// setupExec fakes the pod executor, answering the Grafana requests with the given function
+func setupExec(t *testing.T, respond func(r request) (string, int)) *[]request {
+	requests := &[]request{}
+	stdin := ""
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	k8sutilfake.PodExecStdin = func(url *url.URL, in string) { stdin = in }
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		command := url.Query()["command"][2]
+		if !strings.Contains(command, `-u "${GF_SECURITY_ADMIN_USER}:${GF_SECURITY_ADMIN_PASSWORD}"`) {
+			return "", "", fmt.Errorf("missing credentials in command %s", command)
+		}
+		match := requestPattern.FindStringSubmatch(command)
+		if match == nil {
+			return "", "", fmt.Errorf("unexpected command %s", command)
+		}
+		r := request{method: match[1], path: match[2]}
+		if match[3] != "" {
+			r.body, stdin = stdin, ""
+		}
+		*requests = append(*requests, r)
+		body, status := respond(r)
+		return fmt.Sprintf("%s\n%d", body, status), "", nil
+	}
+	t.Cleanup(func() {
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
+		k8sutilfake.PodExecStdin = func(url *url.URL, stdin string) {}
+	})
+	return requests
+}
+
+// newClient returns a Client for a cluster with the given pods
+func newClient(objects ...runtime.Object) *Client {
+	cfg, cli := k8sutilfake.NewClientsetConfig(objects...)
+	return NewClient(cli, cfg)
+}
+
+// newGrafanaPod returns a Grafana pod
+func newGrafanaPod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.VerrazzanoSystemNamespace,
+			Name:      "vmi-system-grafana-0",
+			Labels:    map[string]string{"app": "system-grafana"},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+// TestCreateOrUpdateFolder tests creating and updating a folder
+// GIVEN a folder that does not exist, then exists
+// WHEN the folder is created or updated
+// THEN the folder is created, then its title is updated
+func TestCreateOrUpdateFolder(t *testing.T) {
+	exists := false
+	requests := setupExec(t, func(r request) (string, int) {
+		if r.method == "GET" && !exists {
+			return `{"message":"folder not found"}`, 404
+		}
+		return `{}`, 200
+	})
+	client := newClient(newGrafanaPod(corev1.PodRunning))
+
+	assert.NoError(t, client.CreateOrUpdateFolder("hello", "Project hello"))
+	assert.Equal(t, request{method: "POST", path: "/api/folders", body: `{"title":"Project hello","uid":"hello"}`}, (*requests)[1])
+
+	exists = true
+	*requests = nil
+	assert.NoError(t, client.CreateOrUpdateFolder("hello", "Project hello"))
+	assert.Equal(t, request{method: "PUT", path: "/api/folders/hello", body: `{"overwrite":true,"title":"Project hello"}`}, (*requests)[1])
+}
+
+// TestGetOrCreateTeam tests getting and creating a team
+// GIVEN a team that does not exist, then exists
+// WHEN GetOrCreateTeam is called
+// THEN the team is created, then the ID of the existing team is returned
+func TestGetOrCreateTeam(t *testing.T) {
+	exists := false
+	requests := setupExec(t, func(r request) (string, int) {
+		if r.method == "POST" {
+			return `{"message":"Team created","teamId":7}`, 200
+		}
+		if exists {
+			return `{"teams":[{"id":3,"name":"hello-admins-old"},{"id":7,"name":"hello-admins"}]}`, 200
+		}
+		return `{"teams":[]}`, 200
+	})
+	client := newClient(newGrafanaPod(corev1.PodRunning))
+
+	id, err := client.GetOrCreateTeam("hello-admins")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.Equal(t, "/api/teams/search?name=hello-admins", (*requests)[0].path)
+	assert.Equal(t, request{method: "POST", path: "/api/teams", body: `{"name":"hello-admins"}`}, (*requests)[1])
+
+	exists = true
+	*requests = nil
+	id, err = client.GetOrCreateTeam("hello-admins")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.Len(t, *requests, 1)
+}
+
+// TestSetTeamMembers tests setting the members of a team
+// GIVEN a team with a member to remove, a user to add and a user who has not logged in to Grafana
+// WHEN SetTeamMembers is called
+// THEN the user is added and the member is removed, the unknown user is skipped
+func TestSetTeamMembers(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) {
+		switch {
+		case r.path == "/api/teams/7/members" && r.method == "GET":
+			return `[{"userId":1,"login":"alice"},{"userId":2,"login":"bob"}]`, 200
+		case r.path == "/api/users/lookup?loginOrEmail=carol":
+			return `{"id":3,"login":"carol"}`, 200
+		case r.path == "/api/users/lookup?loginOrEmail=dave":
+			return `{"message":"user not found"}`, 404
+		}
+		return `{}`, 200
+	})
+	client := newClient(newGrafanaPod(corev1.PodRunning))
+
+	assert.NoError(t, client.SetTeamMembers(7, []string{"alice", "carol", "dave"}))
+	assert.Equal(t, []request{
+		{method: "GET", path: "/api/teams/7/members"},
+		{method: "GET", path: "/api/users/lookup?loginOrEmail=carol"},
+		{method: "POST", path: "/api/teams/7/members", body: `{"userId":3}`},
+		{method: "GET", path: "/api/users/lookup?loginOrEmail=dave"},
+		{method: "DELETE", path: "/api/teams/7/members/2"},
+	}, *requests)
+}
+
+// TestDatasourceAndPermissions tests managing a datasource and the permissions of a folder
+// GIVEN a datasource that does not exist
+// WHEN the datasource is created and deleted, and the folder permissions are set
+// THEN the requests are sent to the Grafana HTTP API
+func TestDatasourceAndPermissions(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) {
+		if r.method == "GET" || r.method == "DELETE" {
+			return `{"message":"not found"}`, 404
+		}
+		return `{}`, 200
+	})
+	client := newClient(newGrafanaPod(corev1.PodRunning))
+
+	assert.NoError(t, client.CreateOrUpdateDatasource(Datasource{UID: "hello-logs", Name: "hello logs", Type: "elasticsearch", Access: "proxy", URL: "http://opensearch:9200", Database: "hello*"}))
+	assert.Equal(t, request{method: "POST", path: "/api/datasources", body: `{"uid":"hello-logs","name":"hello logs","type":"elasticsearch","access":"proxy","url":"http://opensearch:9200","database":"hello*","isDefault":false,"basicAuth":false}`}, (*requests)[1])
+
+	// The secrets of the datasource are only passed in the request body
+	assert.NoError(t, client.CreateOrUpdateDatasource(Datasource{UID: "hello-logs", Name: "hello logs", Type: "elasticsearch", Access: "proxy", URL: "http://opensearch:9200",
+		BasicAuth: true, BasicAuthUser: "hello", SecureJSONData: map[string]string{"basicAuthPassword": "it's secret"}}))
+	assert.Equal(t, request{method: "POST", path: "/api/datasources", body: `{"uid":"hello-logs","name":"hello logs","type":"elasticsearch","access":"proxy","url":"http://opensearch:9200","isDefault":false,"basicAuth":true,"basicAuthUser":"hello","secureJsonData":{"basicAuthPassword":"it's secret"}}`}, (*requests)[3])
+	*requests = (*requests)[:2]
+
+	assert.NoError(t, client.CreateOrUpdateDashboard("hello", Dashboard{"uid": "hello-metrics", "title": "hello metrics"}))
+	assert.Equal(t, request{method: "POST", path: "/api/dashboards/db", body: `{"dashboard":{"title":"hello metrics","uid":"hello-metrics"},"folderUid":"hello","overwrite":true}`}, (*requests)[2])
+	*requests = (*requests)[:2]
+
+	assert.NoError(t, client.SetFolderPermissions("hello", []FolderPermission{{TeamID: 7, Permission: PermissionEdit}}))
+	assert.Equal(t, request{method: "POST", path: "/api/folders/hello/permissions", body: `{"items":[{"teamId":7,"permission":2}]}`}, (*requests)[2])
+
+	// A missing datasource is already deleted
+	assert.NoError(t, client.DeleteDatasource("hello-logs"))
+}
+
+// TestCallErrors tests the errors of the Grafana HTTP API calls
+// GIVEN no running Grafana pod, or an error response
+// WHEN the API is called
+// THEN an error is returned
+func TestCallErrors(t *testing.T) {
+	setupExec(t, func(r request) (string, int) { return `{"message":"Permission denied"}`, 403 })
+
+	running, err := newClient(newGrafanaPod(corev1.PodPending)).IsRunning()
+	assert.NoError(t, err)
+	assert.False(t, running)
+	err = newClient(newGrafanaPod(corev1.PodPending)).DeleteFolder("hello")
+	assert.ErrorContains(t, err, "No running Grafana pod")
+
+	err = newClient(newGrafanaPod(corev1.PodRunning)).DeleteFolder("hello")
+	assert.ErrorContains(t, err, "status 403")
+}
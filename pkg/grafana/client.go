@@ -0,0 +1,107 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	grafanaPodSelector = "app=system-grafana"
+	grafanaContainer   = "grafana"
+	localURL           = "http://localhost:3000"
+)
+
+// Client manages the Verrazzano Grafana through its HTTP API. Like the OpenSearch client, the API is called from the
+// Grafana pod since the authorization policies of the Verrazzano system namespace only let the authentication proxy
+// reach Grafana. The calls authenticate with the admin credentials of the Grafana container environment, so that they
+// never appear in the exec requests.
+type Client struct {
+	kubeClient kubernetes.Interface
+	config     *rest.Config
+}
+
+// NewClient returns a Client executing the HTTP API calls in the pods of the given cluster
+func NewClient(kubeClient kubernetes.Interface, config *rest.Config) *Client {
+	return &Client{
+		kubeClient: kubeClient,
+		config:     config,
+	}
+}
+
+// IsRunning returns true if a Grafana pod is running
+func (c *Client) IsRunning() (bool, error) {
+	pod, err := c.getGrafanaPod()
+	return pod != nil, err
+}
+
+// call calls the Grafana HTTP API from the Grafana pod, returning the response body if the response status is one of
+// the expected statuses
+func (c *Client) call(method, path string, request interface{}, expectedStatuses ...int) (string, int, error) {
+	pod, err := c.getGrafanaPod()
+	if err != nil {
+		return "", 0, err
+	}
+	if pod == nil {
+		return "", 0, fmt.Errorf("No running Grafana pod found in namespace %s", constants.VerrazzanoSystemNamespace)
+	}
+	cmd := fmt.Sprintf(`curl -s -w '\n%%{http_code}' -u "${GF_SECURITY_ADMIN_USER}:${GF_SECURITY_ADMIN_PASSWORD}" -X %s '%s%s'`, method, localURL, path)
+	var stdout, stderr string
+	if request != nil {
+		// The request body is passed on the standard input, since it may contain secrets like datasource passwords
+		var data []byte
+		if data, err = json.Marshal(request); err != nil {
+			return "", 0, err
+		}
+		cmd = fmt.Sprintf("%s -H 'Content-Type: application/json' --data-binary @-", cmd)
+		stdout, stderr, err = k8sutil.ExecPodWithStdin(c.kubeClient, c.config, pod, grafanaContainer, []string{"sh", "-c", cmd}, string(data))
+	} else {
+		stdout, stderr, err = k8sutil.ExecPodNoTty(c.kubeClient, c.config, pod, grafanaContainer, []string{"sh", "-c", cmd})
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("Failed calling Grafana %s %s: %v %s", method, path, err, stderr)
+	}
+
+	// The status code is written on the last line, after the response body
+	stdout = strings.TrimRight(stdout, "\n")
+	body, statusText := "", stdout
+	if i := strings.LastIndex(stdout, "\n"); i >= 0 {
+		body, statusText = stdout[:i], stdout[i+1:]
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(statusText))
+	if err != nil {
+		return "", 0, fmt.Errorf("Failed calling Grafana %s %s, unexpected response: %s", method, path, stdout)
+	}
+	for _, expected := range expectedStatuses {
+		if status == expected {
+			return body, status, nil
+		}
+	}
+	return "", status, fmt.Errorf("Failed calling Grafana %s %s, status %d: %s", method, path, status, body)
+}
+
+// getGrafanaPod returns a running Grafana pod, or nil if there is none
+func (c *Client) getGrafanaPod() (*corev1.Pod, error) {
+	pods, err := c.kubeClient.CoreV1().Pods(constants.VerrazzanoSystemNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: grafanaPodSelector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"sigs.k8s.io/yaml"
+)
+
+// globalValuesKey is the key of the values shared by a chart and its subcharts
+const globalValuesKey = "global"
+
+// additionalPropertyError is the type of the schema error of a key which is not defined by a schema
+const additionalPropertyError = "additional_property_not_allowed"
+
+// ValuesSchemaResult is the result of the validation of override values against the values schema of a chart
+type ValuesSchemaResult struct {
+	// UnknownKeys are the paths of the override values which are not defined by the chart
+	UnknownKeys []string
+	// TypeErrors are the override values which do not match the values schema of the chart
+	TypeErrors []string
+}
+
+// charts caches the charts loaded from the chart directories, which do not change while the operator runs
+var charts sync.Map
+
+// ValidateValuesSchema validates override values against the values schema of a chart. A schema is generated from the
+// values.yaml file of the charts which do not have a values.schema.json file. The overrides are YAML documents, the first
+// document has the highest precedence.
+func ValidateValuesSchema(chartDir string, overrides ...string) (*ValuesSchemaResult, error) {
+	chrt, err := loadCachedChart(chartDir)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	for _, override := range overrides {
+		overrideValues := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(override), &overrideValues); err != nil {
+			return nil, fmt.Errorf("Failed to parse the override values of the %s chart: %v", chrt.Name(), err)
+		}
+		values = chartutil.CoalesceTables(values, overrideValues)
+	}
+
+	result := &ValuesSchemaResult{}
+	if err := findUnknownKeys(chrt, values, "", result); err != nil {
+		return nil, err
+	}
+	// The chart values are validated with their defaults, like Helm does
+	coalesced, err := chartutil.CoalesceValues(chrt, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTypes(chrt, coalesced, "", result); err != nil {
+		return nil, err
+	}
+	sort.Strings(result.UnknownKeys)
+	sort.Strings(result.TypeErrors)
+	return result, nil
+}
+
+// loadCachedChart loads a chart and its subcharts from a chart directory
+func loadCachedChart(chartDir string) (*chart.Chart, error) {
+	if chrt, ok := charts.Load(chartDir); ok {
+		return chrt.(*chart.Chart), nil
+	}
+	chrt, err := loader.Load(chartDir)
+	if err != nil {
+		return nil, err
+	}
+	charts.Store(chartDir, chrt)
+	return chrt, nil
+}
+
+// getSchema returns the values schema of a chart, or a schema generated from the chart values
+func getSchema(chrt *chart.Chart) (map[string]interface{}, error) {
+	if len(chrt.Schema) == 0 {
+		return generateSchema(chrt.Values), nil
+	}
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(chrt.Schema, &schema); err != nil {
+		return nil, fmt.Errorf("Failed to parse the values schema of the %s chart: %v", chrt.Name(), err)
+	}
+	return schema, nil
+}
+
+// generateSchema generates the schema of a value of the chart values. The maps of the chart values define the keys of
+// the objects, except the empty maps which accept any key. The scalar values accept any scalar type, since the chart
+// templates commonly accept strings, numbers and booleans for the same value.
+func generateSchema(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return map[string]interface{}{"type": "object"}
+		}
+		properties := map[string]interface{}{}
+		for key, propertyValue := range v {
+			properties[key] = generateSchema(propertyValue)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case []interface{}:
+		return map[string]interface{}{"type": "array"}
+	case nil:
+		return map[string]interface{}{}
+	default:
+		return map[string]interface{}{"type": []interface{}{"string", "number", "boolean"}}
+	}
+}
+
+// findUnknownKeys finds the override values which are not defined by the schema of a chart or of its subcharts
+func findUnknownKeys(chrt *chart.Chart, values map[string]interface{}, path string, result *ValuesSchemaResult) error {
+	schema, err := getSchema(chrt)
+	if err != nil {
+		return err
+	}
+	chartValues := map[string]interface{}{}
+	for key, value := range values {
+		subchart := getSubchart(chrt, key)
+		subchartValues, isMap := value.(map[string]interface{})
+		switch {
+		case key == globalValuesKey:
+			continue
+		case subchart != nil && isMap:
+			if err := findUnknownKeys(subchart, subchartValues, joinPath(path, key), result); err != nil {
+				return err
+			}
+		default:
+			chartValues[key] = value
+		}
+	}
+	unknownKeys := findUnknownSchemaKeys(schema, chartValues, path)
+	if len(chrt.Schema) > 0 {
+		// The schemas of some charts only define part of the chart values, the keys defined by the chart values are known
+		definedKeys := map[string]bool{}
+		for _, key := range findUnknownSchemaKeys(generateSchema(chrt.Values), chartValues, path) {
+			definedKeys[key] = true
+		}
+		var undefinedKeys []string
+		for _, key := range unknownKeys {
+			if definedKeys[key] {
+				undefinedKeys = append(undefinedKeys, key)
+			}
+		}
+		unknownKeys = undefinedKeys
+	}
+	result.UnknownKeys = append(result.UnknownKeys, unknownKeys...)
+	return nil
+}
+
+// findUnknownSchemaKeys returns the paths of the values which are not defined by a schema. The objects without
+// properties, or which accept additional properties, accept any key.
+func findUnknownSchemaKeys(schema map[string]interface{}, values map[string]interface{}, path string) []string {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if additionalProperties, ok := schema["additionalProperties"]; ok && additionalProperties != false {
+		return nil
+	}
+	if _, ok := schema["patternProperties"]; ok {
+		return nil
+	}
+	var unknownKeys []string
+	for key, value := range values {
+		keyPath := joinPath(path, key)
+		propertySchema, ok := properties[key].(map[string]interface{})
+		if !ok {
+			unknownKeys = append(unknownKeys, keyPath)
+			continue
+		}
+		if valueMap, ok := value.(map[string]interface{}); ok {
+			unknownKeys = append(unknownKeys, findUnknownSchemaKeys(propertySchema, valueMap, keyPath)...)
+		}
+	}
+	return unknownKeys
+}
+
+// validateTypes validates the values of a chart and of its subcharts against their schemas
+func validateTypes(chrt *chart.Chart, values map[string]interface{}, path string, result *ValuesSchemaResult) error {
+	schema, err := getSchema(chrt)
+	if err != nil {
+		return err
+	}
+	// The subchart values are validated against the subchart schemas
+	chartValues := map[string]interface{}{}
+	for key, value := range values {
+		if getSubchart(chrt, key) == nil {
+			chartValues[key] = value
+		}
+	}
+	validation, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(chartValues))
+	if err != nil {
+		return fmt.Errorf("Failed to validate the values of the %s chart: %v", chrt.Name(), err)
+	}
+	for _, desc := range validation.Errors() {
+		// The unknown keys are not type errors
+		if desc.Type() == additionalPropertyError {
+			continue
+		}
+		field := path
+		if desc.Field() != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			field = joinPath(path, desc.Field())
+		}
+		if field == "" {
+			field = chrt.Name()
+		}
+		result.TypeErrors = append(result.TypeErrors, fmt.Sprintf("%s: %s", field, desc.Description()))
+	}
+
+	for _, subchart := range chrt.Dependencies() {
+		subchartValues, ok := values[subchart.Name()].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validateTypes(subchart, subchartValues, joinPath(path, subchart.Name()), result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSubchart returns the subchart of a chart with the given name, or nil if the chart has no such subchart
+func getSubchart(chrt *chart.Chart, name string) *chart.Chart {
+	for _, subchart := range chrt.Dependencies() {
+		if subchart.Name() == name {
+			return subchart
+		}
+	}
+	return nil
+}
+
+// joinPath joins the path of a value with a key
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return strings.Join([]string{path, key}, ".")
+}
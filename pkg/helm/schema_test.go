@@ -0,0 +1,81 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	generatedSchemaChart = "./testdata/schema/generated"
+	valuesSchemaChart    = "./testdata/schema/withschema"
+)
+
+// TestValidateValuesSchemaGenerated tests validating override values against a schema generated from the chart values
+// GIVEN a chart with a subchart, both without a values schema
+// WHEN override values are validated
+// THEN the keys which are not in the chart values are unknown, and values of the wrong type are type errors
+func TestValidateValuesSchemaGenerated(t *testing.T) {
+	asserts := assert.New(t)
+
+	result, err := ValidateValuesSchema(generatedSchemaChart,
+		"replicas: 2\nimage:\n  tag: v2\nresources:\n  limits:\n    cpu: 1\nnodeSelector:\n  zone: a\nsub:\n  enabled: false\nglobal:\n  registry: test\n")
+	asserts.NoError(err)
+	asserts.Empty(result.UnknownKeys)
+	asserts.Empty(result.TypeErrors)
+
+	result, err = ValidateValuesSchema(generatedSchemaChart,
+		"replicass: 2\nimage:\n  tagg: v2\nsub:\n  enabld: false\n")
+	asserts.NoError(err)
+	asserts.Equal([]string{"image.tagg", "replicass", "sub.enabld"}, result.UnknownKeys)
+	asserts.Empty(result.TypeErrors)
+
+	result, err = ValidateValuesSchema(generatedSchemaChart, "image: test\ntolerations:\n  key: test\nsub:\n  enabled:\n    value: true\n")
+	asserts.NoError(err)
+	asserts.Empty(result.UnknownKeys)
+	asserts.Len(result.TypeErrors, 3)
+	asserts.Contains(result.TypeErrors[0], "image")
+	asserts.Contains(result.TypeErrors[1], "sub.enabled")
+	asserts.Contains(result.TypeErrors[2], "tolerations")
+}
+
+// TestValidateValuesSchemaFromChart tests validating override values against the values schema of a chart
+// GIVEN a chart with a values.schema.json file
+// WHEN override values are validated
+// THEN the override with the highest precedence is validated, the schema constraints are enforced, and the keys
+// defined by the chart values are known
+func TestValidateValuesSchemaFromChart(t *testing.T) {
+	asserts := assert.New(t)
+
+	result, err := ValidateValuesSchema(valuesSchemaChart, "replicas: 3\nlabels:\n  app: test\n", "replicas: bad\n")
+	asserts.NoError(err)
+	asserts.Empty(result.UnknownKeys)
+	asserts.Empty(result.TypeErrors)
+
+	// The values which are not defined by the schema, but are defined by the chart values, are known
+	result, err = ValidateValuesSchema(valuesSchemaChart, "podAnnotations:\n  test: value\n")
+	asserts.NoError(err)
+	asserts.Empty(result.UnknownKeys)
+	asserts.Empty(result.TypeErrors)
+
+	result, err = ValidateValuesSchema(valuesSchemaChart, "replicas: 0\nlabels:\n  app: 1\nunknown: true\n")
+	asserts.NoError(err)
+	asserts.Equal([]string{"unknown"}, result.UnknownKeys)
+	asserts.Len(result.TypeErrors, 2)
+	asserts.Contains(result.TypeErrors[0], "labels.app")
+	asserts.Contains(result.TypeErrors[1], "replicas")
+}
+
+// TestValidateValuesSchemaErrors tests the errors validating override values
+// GIVEN a missing chart or invalid override values
+// WHEN override values are validated
+// THEN an error is returned
+func TestValidateValuesSchemaErrors(t *testing.T) {
+	_, err := ValidateValuesSchema("./testdata/schema/missing")
+	assert.Error(t, err)
+	_, err = ValidateValuesSchema(valuesSchemaChart, "replicas: [")
+	assert.Error(t, err)
+}
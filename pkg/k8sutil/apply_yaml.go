@@ -103,6 +103,11 @@ func (y *YAMLApplier) ApplyF(filePath string) error {
 	return y.doFileAction(filePath, y.applyAction)
 }
 
+// ApplyS applies the objects of a YAML string to Kubernetes
+func (y *YAMLApplier) ApplyS(yamlString string) error {
+	return y.doAction(bufio.NewReader(strings.NewReader(yamlString)), y.applyAction)
+}
+
 // ApplyFT applies a file template spec (go text.template) to Kubernetes
 func (y *YAMLApplier) ApplyFT(filePath string, args map[string]interface{}) error {
 	return y.doTemplatedFileAction(filePath, y.applyAction, args)
@@ -122,6 +127,11 @@ func (y *YAMLApplier) ApplyFTDefaultConfig(filePath string, args map[string]inte
 	return y.ApplyFT(filePath, args)
 }
 
+// DeleteS deletes the objects of a YAML string from Kubernetes
+func (y *YAMLApplier) DeleteS(yamlString string) error {
+	return y.doAction(bufio.NewReader(strings.NewReader(yamlString)), y.deleteAction)
+}
+
 // DeleteF deletes a file spec from Kubernetes
 func (y *YAMLApplier) DeleteF(filePath string) error {
 	return y.doFileAction(filePath, y.deleteAction)
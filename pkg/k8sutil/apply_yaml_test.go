@@ -352,6 +352,38 @@ func TestApplyDT(t *testing.T) {
 	}
 }
 
+// TestApplySDeleteS tests applying and deleting the objects of a YAML string
+// GIVEN a YAML string with two services
+// WHEN the YAML is applied, then deleted
+// THEN the services are created in the overridden namespace, then deleted
+func TestApplySDeleteS(t *testing.T) {
+	const services = `apiVersion: v1
+kind: Service
+metadata:
+  name: service1
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: service2
+spec:
+  ports:
+  - port: 80
+`
+	c := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	y := k8sutil.NewYAMLApplier(c, "test")
+	assert.NoError(t, y.ApplyS(services))
+	assert.Len(t, y.Objects(), 2)
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "service2"}, &corev1.Service{}))
+
+	assert.NoError(t, y.DeleteS(services))
+	assert.Error(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "test", Name: "service2"}, &corev1.Service{}))
+	assert.Error(t, y.ApplyS("not yaml: ["))
+}
+
 func TestDeleteF(t *testing.T) {
 	var tests = []struct {
 		name    string
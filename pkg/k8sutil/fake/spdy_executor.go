@@ -1,10 +1,11 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package fake
 
 import (
 	"bytes"
+	"io"
 	"net/url"
 
 	"k8s.io/client-go/rest"
@@ -14,6 +15,9 @@ import (
 // PodExecResult can be used to output arbitrary strings during unit testing
 var PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
 
+// PodExecStdin receives the standard input of the commands run during unit testing
+var PodExecStdin = func(url *url.URL, stdin string) {}
+
 // NewPodExecutor should be used instead of remotecommand.NewSPDYExecutor in unit tests
 func NewPodExecutor(config *rest.Config, method string, url *url.URL) (remotecommand.Executor, error) {
 	return &dummyExecutor{method: method, url: url}, nil
@@ -25,8 +29,15 @@ type dummyExecutor struct {
 	url    *url.URL
 }
 
-// Stream on a dummyExecutor sets stdout to PodExecResult
+// Stream on a dummyExecutor passes stdin to PodExecStdin and sets stdout to PodExecResult
 func (f *dummyExecutor) Stream(options remotecommand.StreamOptions) error {
+	if options.Stdin != nil {
+		stdin, err := io.ReadAll(options.Stdin)
+		if err != nil {
+			return err
+		}
+		PodExecStdin(f.url, string(stdin))
+	}
 	stdout, stderr, err := PodExecResult(f.url)
 	if options.Stdout != nil {
 		buf := new(bytes.Buffer)
@@ -417,6 +417,43 @@ func ExecPodNoTty(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, co
 	return stdout.String(), stderr.String(), nil
 }
 
+// ExecPodWithStdin runs a remote command a pod with the given standard input, returning the stdout and stderr of the
+// command. Secrets passed to the command on the standard input do not appear in the command line of the process.
+func ExecPodWithStdin(client kubernetes.Interface, cfg *rest.Config, pod *v1.Pod, container string, command []string, stdin string) (string, string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	request := client.
+		CoreV1().
+		RESTClient().
+		Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+			TTY:       false,
+		}, scheme.ParameterCodec)
+	executor, err := NewPodExecutor(cfg, "POST", request.URL())
+	if err != nil {
+		return "", "", err
+	}
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  strings.NewReader(stdin),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error running command %s on %v/%v: %v", command, pod.Namespace, pod.Name, err)
+	}
+
+	return stdout.String(), stderr.String(), nil
+}
+
 // GetGoClient returns a go-client
 func GetGoClient(log ...vzlog.VerrazzanoLogger) (kubernetes.Interface, error) {
 	if fakeClient != nil {
@@ -378,6 +378,30 @@ func TestExecPodNoTtyFailure(t *testing.T) {
 	assert.Equal(t, "", stdout)
 }
 
+// TestExecPodWithStdin tests running a command on a remote pod with a standard input
+// GIVEN a pod in a cluster, a command to run on that pod and its standard input
+//
+//	WHEN ExecPodWithStdin is called
+//	THEN ExecPodWithStdin passes the standard input to the command and returns the stdout, stderr, and a nil error
+func TestExecPodWithStdin(t *testing.T) {
+	k8sutil.NewPodExecutor = spdyfake.NewPodExecutor
+	spdyfake.PodExecResult = func(url *url.URL) (string, string, error) { return resultString, "", nil }
+	var stdin string
+	spdyfake.PodExecStdin = func(url *url.URL, in string) { stdin = in }
+	defer func() { spdyfake.PodExecStdin = func(url *url.URL, stdin string) {} }()
+	cfg, client := spdyfake.NewClientsetConfig()
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	}
+	stdout, _, err := k8sutil.ExecPodWithStdin(client, cfg, pod, "container", []string{"run", "some", "command"}, "input")
+	assert.Nil(t, err)
+	assert.Equal(t, resultString, stdout)
+	assert.Equal(t, "input", stdin)
+}
+
 // TestGetURLForIngress tests getting the host URL from an ingress
 // GIVEN an ingress name and its namespace
 //
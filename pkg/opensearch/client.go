@@ -0,0 +1,102 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	masterPodSelector = "app=system-es-master"
+	masterContainer   = "es-master"
+	localURL          = "http://localhost:9200"
+)
+
+// Client manages the Verrazzano OpenSearch cluster through its REST API. The API is called
+// from an OpenSearch master pod, since the authorization policies of the Verrazzano system namespace only let a few
+// clients reach OpenSearch.
+type Client struct {
+	kubeClient kubernetes.Interface
+	config     *rest.Config
+}
+
+// NewClient returns a Client executing the REST API calls in the pods of the given cluster
+func NewClient(kubeClient kubernetes.Interface, config *rest.Config) *Client {
+	return &Client{
+		kubeClient: kubeClient,
+		config:     config,
+	}
+}
+
+// call calls the OpenSearch REST API from a master pod, returning the response body if the response status is one
+// of the expected statuses
+func (c *Client) call(method, path string, request interface{}, expectedStatuses ...int) (string, error) {
+	body, status, err := c.do(method, path, request)
+	if err != nil {
+		return "", err
+	}
+	for _, expected := range expectedStatuses {
+		if status == expected {
+			return body, nil
+		}
+	}
+	return "", fmt.Errorf("Failed calling OpenSearch %s %s, status %d: %s", method, path, status, body)
+}
+
+// do calls the OpenSearch REST API from a master pod, returning the response body and status
+func (c *Client) do(method, path string, request interface{}) (string, int, error) {
+	pod, err := c.getMasterPod()
+	if err != nil {
+		return "", 0, err
+	}
+	cmd := fmt.Sprintf("curl -s -w '\\n%%{http_code}' -X %s '%s%s'", method, localURL, path)
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return "", 0, err
+		}
+		cmd = fmt.Sprintf("%s -H 'Content-Type: application/json' -d '%s'", cmd, strings.ReplaceAll(string(data), "'", `'\''`))
+	}
+	stdout, stderr, err := k8sutil.ExecPodNoTty(c.kubeClient, c.config, pod, masterContainer, []string{"bash", "-c", cmd})
+	if err != nil {
+		return "", 0, fmt.Errorf("Failed calling OpenSearch %s %s: %v %s", method, path, err, stderr)
+	}
+
+	// The status code is written on the last line, after the response body
+	stdout = strings.TrimRight(stdout, "\n")
+	body, statusText := "", stdout
+	if i := strings.LastIndex(stdout, "\n"); i >= 0 {
+		body, statusText = stdout[:i], stdout[i+1:]
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(statusText))
+	if err != nil {
+		return "", 0, fmt.Errorf("Failed calling OpenSearch %s %s, unexpected response: %s", method, path, stdout)
+	}
+	return body, status, nil
+}
+
+// getMasterPod returns a running OpenSearch master pod
+func (c *Client) getMasterPod() (*corev1.Pod, error) {
+	pods, err := c.kubeClient.CoreV1().Pods(constants.VerrazzanoSystemNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: masterPodSelector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("No running OpenSearch master pod found in namespace %s", constants.VerrazzanoSystemNamespace)
+}
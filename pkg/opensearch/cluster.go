@@ -0,0 +1,135 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+)
+
+const (
+	// Cluster health statuses reported by OpenSearch
+	HealthGreen  = "green"
+	HealthYellow = "yellow"
+	HealthRed    = "red"
+
+	allocationExcludeSetting = "cluster.routing.allocation.exclude._name"
+)
+
+// ClusterHealth is the health of an OpenSearch cluster
+type ClusterHealth struct {
+	Status             string `json:"status"`
+	NumberOfDataNodes  int    `json:"number_of_data_nodes"`
+	RelocatingShards   int    `json:"relocating_shards"`
+	InitializingShards int    `json:"initializing_shards"`
+	UnassignedShards   int    `json:"unassigned_shards"`
+}
+
+// Shard is an index shard allocated to a node. Node is empty for an unassigned shard.
+type Shard struct {
+	Index  string `json:"index"`
+	Shard  string `json:"shard"`
+	Prirep string `json:"prirep"`
+	State  string `json:"state"`
+	Node   string `json:"node"`
+}
+
+// GetClusterHealth returns the health of the cluster
+func (c *Client) GetClusterHealth() (*ClusterHealth, error) {
+	body, err := c.call(http.MethodGet, "/_cluster/health", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	health := &ClusterHealth{}
+	if err := json.Unmarshal([]byte(body), health); err != nil {
+		return nil, fmt.Errorf("Failed to parse the cluster health: %v", err)
+	}
+	return health, nil
+}
+
+// GetShards returns the shards of all indices
+func (c *Client) GetShards() ([]Shard, error) {
+	body, err := c.call(http.MethodGet, "/_cat/shards?format=json&h=index,shard,prirep,state,node", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	var shards []Shard
+	if err := json.Unmarshal([]byte(body), &shards); err != nil {
+		return nil, fmt.Errorf("Failed to parse the shards: %v", err)
+	}
+	return shards, nil
+}
+
+// GetAllocationExclude returns the node name patterns excluded from shard allocation
+func (c *Client) GetAllocationExclude() ([]string, error) {
+	body, err := c.call(http.MethodGet, "/_cluster/settings?flat_settings=true", nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	settings := struct {
+		Persistent map[string]interface{} `json:"persistent"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &settings); err != nil {
+		return nil, fmt.Errorf("Failed to parse the cluster settings: %v", err)
+	}
+	value, _ := settings.Persistent[allocationExcludeSetting].(string)
+	var nodeNames []string
+	for _, nodeName := range strings.Split(value, ",") {
+		if nodeName = strings.TrimSpace(nodeName); nodeName != "" {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+	return nodeNames, nil
+}
+
+// AddAllocationExclude excludes the nodes matching the given name pattern from shard allocation, which relocates
+// their shards to the other nodes. The nodes that are already excluded stay excluded.
+func (c *Client) AddAllocationExclude(nodeNames string) error {
+	excluded, err := c.GetAllocationExclude()
+	if err != nil {
+		return err
+	}
+	if vzstring.SliceContainsString(excluded, nodeNames) {
+		return nil
+	}
+	return c.putAllocationExclude(append(excluded, nodeNames))
+}
+
+// RemoveAllocationExclude lets shards be allocated again to the nodes matching the given name patterns. The nodes
+// excluded with other patterns stay excluded.
+func (c *Client) RemoveAllocationExclude(nodeNames ...string) error {
+	excluded, err := c.GetAllocationExclude()
+	if err != nil {
+		return err
+	}
+	var remaining []string
+	for _, existing := range excluded {
+		if !vzstring.SliceContainsString(nodeNames, existing) {
+			remaining = append(remaining, existing)
+		}
+	}
+	if len(remaining) == len(excluded) {
+		return nil
+	}
+	return c.putAllocationExclude(remaining)
+}
+
+// putAllocationExclude sets the excluded node name patterns, an empty list resets the setting
+func (c *Client) putAllocationExclude(nodeNames []string) error {
+	var value interface{}
+	if len(nodeNames) > 0 {
+		value = strings.Join(nodeNames, ",")
+	}
+	request := map[string]interface{}{
+		"persistent": map[string]interface{}{
+			allocationExcludeSetting: value,
+		},
+	}
+	_, err := c.call(http.MethodPut, "/_cluster/settings", request, http.StatusOK)
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestGetClusterHealth tests getting the cluster health
+// GIVEN a yellow cluster relocating a shard
+// WHEN the cluster health is requested
+// THEN the status and the shard counts are returned
+func TestGetClusterHealth(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) {
+		return `{"cluster_name":"verrazzano-system","status":"yellow","number_of_data_nodes":3,"relocating_shards":1,"unassigned_shards":2}`, 200
+	})
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	health, err := client.GetClusterHealth()
+	assert.NoError(t, err)
+	assert.Equal(t, &ClusterHealth{Status: HealthYellow, NumberOfDataNodes: 3, RelocatingShards: 1, UnassignedShards: 2}, health)
+	assert.Equal(t, []request{{method: "GET", path: "/_cluster/health"}}, *requests)
+}
+
+// TestGetShards tests listing the shards
+// GIVEN an index with an assigned primary shard and an unassigned replica shard
+// WHEN the shards are listed
+// THEN both shards are returned
+func TestGetShards(t *testing.T) {
+	setupExec(t, func(r request) (string, int) {
+		return `[{"index":"logs","shard":"0","prirep":"p","state":"STARTED","node":"vmi-system-es-data-0-abc"},{"index":"logs","shard":"0","prirep":"r","state":"UNASSIGNED","node":null}]`, 200
+	})
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	shards, err := client.GetShards()
+	assert.NoError(t, err)
+	assert.Equal(t, []Shard{
+		{Index: "logs", Shard: "0", Prirep: "p", State: "STARTED", Node: "vmi-system-es-data-0-abc"},
+		{Index: "logs", Shard: "0", Prirep: "r", State: "UNASSIGNED"},
+	}, shards)
+}
+
+// setupAllocationExcludeExec fakes the cluster settings API with the given JSON value of the allocation exclusion
+// setting, which is updated by the PUT requests
+func setupAllocationExcludeExec(t *testing.T, excluded string) *[]request {
+	return setupExec(t, func(r request) (string, int) {
+		if r.method == "GET" {
+			return fmt.Sprintf(`{"persistent":{"cluster.routing.allocation.exclude._name":%s},"transient":{}}`, excluded), 200
+		}
+		excluded = strings.TrimSuffix(strings.TrimPrefix(r.body, `{"persistent":{"cluster.routing.allocation.exclude._name":`), "}}")
+		return `{"acknowledged":true}`, 200
+	})
+}
+
+// TestAllocationExclude tests excluding nodes from shard allocation
+// GIVEN a cluster with a node excluded from shard allocation by someone else
+// WHEN nodes are excluded from shard allocation, then the exclusion is removed
+// THEN the nodes are added to the persistent allocation setting, then removed from it, keeping the other node
+func TestAllocationExclude(t *testing.T) {
+	requests := setupAllocationExcludeExec(t, `"other-node"`)
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	assert.NoError(t, client.AddAllocationExclude("vmi-system-es-data-2-*"))
+	nodeNames, err := client.GetAllocationExclude()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other-node", "vmi-system-es-data-2-*"}, nodeNames)
+	assert.NoError(t, client.RemoveAllocationExclude("vmi-system-es-data-2-*"))
+	assert.Equal(t, []request{
+		{method: "GET", path: "/_cluster/settings?flat_settings=true"},
+		{method: "PUT", path: "/_cluster/settings", body: `{"persistent":{"cluster.routing.allocation.exclude._name":"other-node,vmi-system-es-data-2-*"}}`},
+		{method: "GET", path: "/_cluster/settings?flat_settings=true"},
+		{method: "GET", path: "/_cluster/settings?flat_settings=true"},
+		{method: "PUT", path: "/_cluster/settings", body: `{"persistent":{"cluster.routing.allocation.exclude._name":"other-node"}}`},
+	}, *requests)
+}
+
+// TestRemoveLastAllocationExclude tests removing the only excluded nodes
+// GIVEN a cluster with nodes excluded from shard allocation
+// WHEN the exclusion of the nodes is removed, then the exclusion of nodes that are not excluded is removed
+// THEN the persistent allocation setting is reset, then it is not changed
+func TestRemoveLastAllocationExclude(t *testing.T) {
+	requests := setupAllocationExcludeExec(t, `"vmi-system-es-data-2-*"`)
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	assert.NoError(t, client.RemoveAllocationExclude("vmi-system-es-data-2-*"))
+	assert.NoError(t, client.RemoveAllocationExclude("vmi-system-es-data-2-*"))
+	assert.Equal(t, []request{
+		{method: "GET", path: "/_cluster/settings?flat_settings=true"},
+		{method: "PUT", path: "/_cluster/settings", body: `{"persistent":{"cluster.routing.allocation.exclude._name":null}}`},
+		{method: "GET", path: "/_cluster/settings?flat_settings=true"},
+	}, *requests)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"net/http"
+)
+
+const securityAPIPath = "/_plugins/_security/api"
+
+// Role is a role of the OpenSearch security plugin
+type Role struct {
+	ClusterPermissions []string           `json:"cluster_permissions,omitempty"`
+	IndexPermissions   []IndexPermission  `json:"index_permissions,omitempty"`
+	TenantPermissions  []TenantPermission `json:"tenant_permissions,omitempty"`
+}
+
+// IndexPermission grants actions on the indices matching the patterns
+type IndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+// TenantPermission grants actions on the OpenSearch Dashboards tenants matching the patterns
+type TenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+// RoleMapping maps a role to backend roles and users
+type RoleMapping struct {
+	BackendRoles []string `json:"backend_roles,omitempty"`
+	Users        []string `json:"users,omitempty"`
+}
+
+// Tenant is an OpenSearch Dashboards tenant, holding its own saved objects
+type Tenant struct {
+	Description string `json:"description,omitempty"`
+}
+
+// IsSecurityEnabled returns true if the OpenSearch security plugin is installed and enabled
+func (c *Client) IsSecurityEnabled() (bool, error) {
+	// The health endpoint has no handler when the plugin is missing or disabled
+	_, status, err := c.do(http.MethodGet, "/_plugins/_security/health", nil)
+	if err != nil {
+		return false, err
+	}
+	return status == http.StatusOK, nil
+}
+
+// PutRole creates or replaces a role
+func (c *Client) PutRole(name string, role Role) error {
+	_, err := c.call(http.MethodPut, securityAPIPath+"/roles/"+name, role, http.StatusOK, http.StatusCreated)
+	return err
+}
+
+// DeleteRole deletes a role if it exists
+func (c *Client) DeleteRole(name string) error {
+	_, err := c.call(http.MethodDelete, securityAPIPath+"/roles/"+name, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// PutRoleMapping creates or replaces the mapping of a role
+func (c *Client) PutRoleMapping(name string, mapping RoleMapping) error {
+	_, err := c.call(http.MethodPut, securityAPIPath+"/rolesmapping/"+name, mapping, http.StatusOK, http.StatusCreated)
+	return err
+}
+
+// DeleteRoleMapping deletes the mapping of a role if it exists
+func (c *Client) DeleteRoleMapping(name string) error {
+	_, err := c.call(http.MethodDelete, securityAPIPath+"/rolesmapping/"+name, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// PutTenant creates or replaces a tenant
+func (c *Client) PutTenant(name string, tenant Tenant) error {
+	_, err := c.call(http.MethodPut, securityAPIPath+"/tenants/"+name, tenant, http.StatusOK, http.StatusCreated)
+	return err
+}
+
+// DeleteTenant deletes a tenant if it exists
+func (c *Client) DeleteTenant(name string) error {
+	_, err := c.call(http.MethodDelete, securityAPIPath+"/tenants/"+name, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
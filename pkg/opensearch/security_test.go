@@ -0,0 +1,63 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestIsSecurityEnabled tests detecting the OpenSearch security plugin
+// GIVEN an OpenSearch cluster with and without the security plugin
+// WHEN IsSecurityEnabled is called
+// THEN true is returned only when the security health endpoint answers
+func TestIsSecurityEnabled(t *testing.T) {
+	status := 200
+	setupExec(t, func(r request) (string, int) { return `{}`, status })
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	enabled, err := client.IsSecurityEnabled()
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	status = 400
+	enabled, err = client.IsSecurityEnabled()
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+}
+
+// TestSecurityObjects tests managing the roles, role mappings and tenants of the security plugin
+// GIVEN an OpenSearch cluster with the security plugin
+// WHEN a role, a role mapping and a tenant are put, then deleted
+// THEN the requests are sent to the security REST API
+func TestSecurityObjects(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) {
+		if r.method == "DELETE" {
+			return `{"status":"NOT_FOUND"}`, 404
+		}
+		return `{"status":"CREATED"}`, 201
+	})
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	role := Role{
+		IndexPermissions:  []IndexPermission{{IndexPatterns: []string{"verrazzano-application-hello*"}, AllowedActions: []string{"read"}}},
+		TenantPermissions: []TenantPermission{{TenantPatterns: []string{"hello"}, AllowedActions: []string{"kibana_all_read"}}},
+	}
+	assert.NoError(t, client.PutRole("hello", role))
+	assert.NoError(t, client.PutRoleMapping("hello", RoleMapping{BackendRoles: []string{"hello-monitor"}}))
+	assert.NoError(t, client.PutTenant("hello", Tenant{Description: "Project hello"}))
+	assert.Equal(t, []request{
+		{method: "PUT", path: "/_plugins/_security/api/roles/hello", body: `{"index_permissions":[{"index_patterns":["verrazzano-application-hello*"],"allowed_actions":["read"]}],"tenant_permissions":[{"tenant_patterns":["hello"],"allowed_actions":["kibana_all_read"]}]}`},
+		{method: "PUT", path: "/_plugins/_security/api/rolesmapping/hello", body: `{"backend_roles":["hello-monitor"]}`},
+		{method: "PUT", path: "/_plugins/_security/api/tenants/hello", body: `{"description":"Project hello"}`},
+	}, *requests)
+
+	// Missing objects are already deleted
+	assert.NoError(t, client.DeleteRoleMapping("hello"))
+	assert.NoError(t, client.DeleteRole("hello"))
+	assert.NoError(t, client.DeleteTenant("hello"))
+	assert.Len(t, *requests, 6)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// DefaultSnapshotRepository is the name of the Verrazzano snapshot repository when none is specified
+	DefaultSnapshotRepository = "verrazzano-snapshots"
+
+	// Snapshot states reported by OpenSearch
+	SnapshotStateSuccess    = "SUCCESS"
+	SnapshotStateInProgress = "IN_PROGRESS"
+	SnapshotStatePartial    = "PARTIAL"
+	SnapshotStateFailed     = "FAILED"
+)
+
+// Repository is an OpenSearch snapshot repository
+type Repository struct {
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings"`
+}
+
+// Snapshot is a snapshot stored in an OpenSearch snapshot repository
+type Snapshot struct {
+	Snapshot  string   `json:"snapshot"`
+	State     string   `json:"state"`
+	StartTime string   `json:"start_time,omitempty"`
+	EndTime   string   `json:"end_time,omitempty"`
+	Indices   []string `json:"indices,omitempty"`
+}
+
+// SnapshotPolicy is an OpenSearch Snapshot Management policy, creating snapshots on a cron schedule and deleting the
+// oldest snapshots beyond a maximum count
+type SnapshotPolicy struct {
+	Description    string                 `json:"description,omitempty"`
+	Creation       PolicyCreation         `json:"creation"`
+	Deletion       *PolicyDeletion        `json:"deletion,omitempty"`
+	SnapshotConfig map[string]interface{} `json:"snapshot_config"`
+}
+
+// PolicyCreation is the creation schedule of a snapshot policy
+type PolicyCreation struct {
+	Schedule PolicySchedule `json:"schedule"`
+}
+
+// PolicyDeletion is the deletion schedule and condition of a snapshot policy
+type PolicyDeletion struct {
+	Schedule  PolicySchedule          `json:"schedule"`
+	Condition PolicyDeletionCondition `json:"condition"`
+}
+
+// PolicyDeletionCondition is the condition deleting the oldest snapshots of a snapshot policy
+type PolicyDeletionCondition struct {
+	MaxCount int32 `json:"max_count"`
+}
+
+// PolicySchedule is the cron schedule of a snapshot policy
+type PolicySchedule struct {
+	Cron PolicyCron `json:"cron"`
+}
+
+// PolicyCron is a cron expression evaluated in a time zone
+type PolicyCron struct {
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone"`
+}
+
+// PutRepository registers or updates a snapshot repository
+func (c *Client) PutRepository(name string, repo Repository) error {
+	_, err := c.call(http.MethodPut, "/_snapshot/"+name, repo, http.StatusOK)
+	return err
+}
+
+// GetSnapshots returns the snapshots of a repository, oldest first
+func (c *Client) GetSnapshots(repo string) ([]Snapshot, error) {
+	body, err := c.call(http.MethodGet, fmt.Sprintf("/_snapshot/%s/_all", repo), nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &snapshots); err != nil {
+		return nil, fmt.Errorf("Failed to parse the snapshots of repository %s: %v", repo, err)
+	}
+	return snapshots.Snapshots, nil
+}
+
+// PutPolicy creates or updates a snapshot policy
+func (c *Client) PutPolicy(name string, policy SnapshotPolicy) error {
+	path := "/_plugins/_sm/policies/" + name
+	body, err := c.call(http.MethodGet, path, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	existing := struct {
+		SeqNo       *int64 `json:"_seq_no"`
+		PrimaryTerm *int64 `json:"_primary_term"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &existing); err != nil {
+		return fmt.Errorf("Failed to parse snapshot policy %s: %v", name, err)
+	}
+	if existing.SeqNo == nil || existing.PrimaryTerm == nil {
+		_, err = c.call(http.MethodPost, path, policy, http.StatusOK, http.StatusCreated)
+		return err
+	}
+	// An update must match the sequence number of the existing policy
+	_, err = c.call(http.MethodPut, fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, *existing.SeqNo, *existing.PrimaryTerm), policy, http.StatusOK)
+	return err
+}
+
+// DeletePolicy deletes a snapshot policy if it exists
+func (c *Client) DeletePolicy(name string) error {
+	_, err := c.call(http.MethodDelete, "/_plugins/_sm/policies/"+name, nil, http.StatusOK, http.StatusNotFound)
+	return err
+}
+
+// RestoreSnapshot restores the indices matching a pattern from a snapshot. When renamePrefix is not empty, the indices
+// are restored under new names starting with the prefix, otherwise the restored indices must not be open.
+func (c *Client) RestoreSnapshot(repo, snapshot, indices, renamePrefix string) error {
+	request := map[string]interface{}{
+		"indices":              indices,
+		"include_global_state": false,
+	}
+	if renamePrefix != "" {
+		request["rename_pattern"] = "(.+)"
+		request["rename_replacement"] = renamePrefix + "$1"
+	}
+	_, err := c.call(http.MethodPost, fmt.Sprintf("/_snapshot/%s/%s/_restore", repo, snapshot), request, http.StatusOK, http.StatusAccepted)
+	return err
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+var requestPattern = regexp.MustCompile(`-X (\w+) 'http://localhost:9200([^']*)'(?: -H 'Content-Type: application/json' -d '(.*)')?`)
+
+// request is an OpenSearch REST API request made by the fake pod executor
+type request struct {
+	method string
+	path   string
+	body   string
+}
+
+// setupExec fakes the pod executor, answering the OpenSearch requests with the given function
+func setupExec(t *testing.T, respond func(r request) (string, int)) *[]request {
+	requests := &[]request{}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		match := requestPattern.FindStringSubmatch(url.Query()["command"][2])
+		if match == nil {
+			return "", "", fmt.Errorf("unexpected command %v", url.Query()["command"])
+		}
+		r := request{method: match[1], path: match[2], body: match[3]}
+		*requests = append(*requests, r)
+		body, status := respond(r)
+		return fmt.Sprintf("%s\n%d", body, status), "", nil
+	}
+	t.Cleanup(func() {
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
+	})
+	return requests
+}
+
+// newClient returns a Client for a cluster with the given pods
+func newClient(objects ...runtime.Object) *Client {
+	cfg, cli := k8sutilfake.NewClientsetConfig(objects...)
+	return NewClient(cli, cfg)
+}
+
+// newMasterPod returns an OpenSearch master pod
+func newMasterPod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.VerrazzanoSystemNamespace,
+			Name:      "vmi-system-es-master-0",
+			Labels:    map[string]string{"app": "system-es-master"},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+// TestPutRepository tests registering a snapshot repository
+// GIVEN a running OpenSearch master pod
+// WHEN a repository is registered
+// THEN the repository is sent to the snapshot API
+func TestPutRepository(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) { return `{"acknowledged":true}`, 200 })
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	err := client.PutRepository("repo", Repository{Type: "fs", Settings: map[string]string{"location": "/snapshots"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []request{{method: "PUT", path: "/_snapshot/repo", body: `{"type":"fs","settings":{"location":"/snapshots"}}`}}, *requests)
+}
+
+// TestPutPolicy tests creating and updating a snapshot policy
+// GIVEN a snapshot policy that does not exist, then exists
+// WHEN the policy is put
+// THEN the policy is created, then updated with the sequence number of the existing policy
+func TestPutPolicy(t *testing.T) {
+	exists := false
+	requests := setupExec(t, func(r request) (string, int) {
+		if r.method == "GET" {
+			if exists {
+				return `{"_id":"policy","_seq_no":3,"_primary_term":1}`, 200
+			}
+			return `{"error":"not found"}`, 404
+		}
+		if r.method == "POST" {
+			return `{}`, 201
+		}
+		return `{}`, 200
+	})
+	client := newClient(newMasterPod(corev1.PodRunning))
+	policy := SnapshotPolicy{
+		Creation:       PolicyCreation{Schedule: PolicySchedule{Cron: PolicyCron{Expression: "0 2 * * *", Timezone: "UTC"}}},
+		SnapshotConfig: map[string]interface{}{"repository": "repo"},
+	}
+
+	assert.NoError(t, client.PutPolicy("policy", policy))
+	assert.Len(t, *requests, 2)
+	assert.Equal(t, "POST", (*requests)[1].method)
+	assert.Equal(t, "/_plugins/_sm/policies/policy", (*requests)[1].path)
+	assert.Equal(t, `{"creation":{"schedule":{"cron":{"expression":"0 2 * * *","timezone":"UTC"}}},"snapshot_config":{"repository":"repo"}}`, (*requests)[1].body)
+
+	exists = true
+	*requests = nil
+	assert.NoError(t, client.PutPolicy("policy", policy))
+	assert.Equal(t, "PUT", (*requests)[1].method)
+	assert.Equal(t, "/_plugins/_sm/policies/policy?if_seq_no=3&if_primary_term=1", (*requests)[1].path)
+}
+
+// TestGetSnapshots tests listing the snapshots of a repository
+// GIVEN a repository with two snapshots
+// WHEN the snapshots are listed
+// THEN both snapshots are returned
+func TestGetSnapshots(t *testing.T) {
+	setupExec(t, func(r request) (string, int) {
+		return `{"snapshots":[{"snapshot":"first","state":"SUCCESS","indices":["a","b"]},{"snapshot":"second","state":"IN_PROGRESS"}]}`, 200
+	})
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	snapshots, err := client.GetSnapshots("repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []Snapshot{
+		{Snapshot: "first", State: SnapshotStateSuccess, Indices: []string{"a", "b"}},
+		{Snapshot: "second", State: SnapshotStateInProgress},
+	}, snapshots)
+}
+
+// TestRestoreSnapshot tests restoring a snapshot under new index names
+// GIVEN a snapshot
+// WHEN the snapshot is restored with a rename prefix
+// THEN the restore request renames the indices
+func TestRestoreSnapshot(t *testing.T) {
+	requests := setupExec(t, func(r request) (string, int) { return `{"accepted":true}`, 200 })
+	client := newClient(newMasterPod(corev1.PodRunning))
+
+	assert.NoError(t, client.RestoreSnapshot("repo", "snap", "verrazzano-*", "restored-"))
+	assert.Equal(t, "/_snapshot/repo/snap/_restore", (*requests)[0].path)
+	assert.Equal(t, `{"include_global_state":false,"indices":"verrazzano-*","rename_pattern":"(.+)","rename_replacement":"restored-$1"}`, (*requests)[0].body)
+}
+
+// TestCallErrors tests the errors of the OpenSearch REST API calls
+// GIVEN no running master pod, or an error response
+// WHEN the API is called
+// THEN an error is returned
+func TestCallErrors(t *testing.T) {
+	setupExec(t, func(r request) (string, int) { return `{"error":"repository_missing_exception"}`, 404 })
+
+	_, err := newClient(newMasterPod(corev1.PodPending)).GetSnapshots("repo")
+	assert.ErrorContains(t, err, "No running OpenSearch master pod")
+
+	_, err = newClient(newMasterPod(corev1.PodRunning)).GetSnapshots("repo")
+	assert.ErrorContains(t, err, "status 404")
+	assert.True(t, strings.Contains(err.Error(), "repository_missing_exception"))
+
+	// A missing policy is already deleted
+	assert.NoError(t, newClient(newMasterPod(corev1.PodRunning)).DeletePolicy("policy"))
+}
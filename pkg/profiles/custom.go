@@ -0,0 +1,130 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ProfileLabel labels the ConfigMaps of the verrazzano-install namespace which define custom profiles
+	ProfileLabel = constants.VerrazzanoProfileLabel
+	// ExtendsKey is the ConfigMap data key of the profile extended by a custom profile, the prod profile by default
+	ExtendsKey = "extends"
+	// ProfileKey is the ConfigMap data key of the Verrazzano resource in the v1beta1 format defining a custom profile
+	ProfileKey = "profile"
+)
+
+// CustomProfile is a user-defined installation profile, which extends a built-in profile or another custom profile
+type CustomProfile struct {
+	Name    string
+	Extends string
+	Profile string
+}
+
+// CustomProfileGetter returns the custom profile with the given name, or nil if it is not defined
+type CustomProfileGetter func(name string) (*CustomProfile, error)
+
+// IsBuiltInProfile returns true if the profile is one of the profiles shipped with Verrazzano
+func IsBuiltInProfile(name string) bool {
+	switch v1beta1.ProfileType(name) {
+	case v1beta1.Prod, v1beta1.Dev, v1beta1.ManagedCluster, v1beta1.None:
+		return true
+	}
+	return false
+}
+
+// NewConfigMapProfileGetter returns a CustomProfileGetter which reads the profile ConfigMaps of the verrazzano-install namespace
+func NewConfigMapProfileGetter(cli client.Reader) CustomProfileGetter {
+	return func(name string) (*CustomProfile, error) {
+		return GetConfigMapProfile(context.TODO(), cli, name)
+	}
+}
+
+// GetConfigMapProfile returns the custom profile defined by a ConfigMap of the verrazzano-install namespace,
+// or nil if no ConfigMap defines the profile
+func GetConfigMapProfile(ctx context.Context, cli client.Reader, name string) (*CustomProfile, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := cli.List(ctx, cmList, client.InNamespace(constants.VerrazzanoInstallNamespace), client.MatchingLabels{ProfileLabel: name}); err != nil {
+		return nil, err
+	}
+	switch len(cmList.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return NewConfigMapProfile(&cmList.Items[0]), nil
+	default:
+		return nil, fmt.Errorf("The profile %s is defined by more than one ConfigMap in namespace %s", name, constants.VerrazzanoInstallNamespace)
+	}
+}
+
+// NewConfigMapProfile returns the custom profile defined by a profile ConfigMap
+func NewConfigMapProfile(cm *corev1.ConfigMap) *CustomProfile {
+	extends := strings.TrimSpace(cm.Data[ExtendsKey])
+	if extends == "" {
+		extends = string(v1beta1.Prod)
+	}
+	return &CustomProfile{
+		Name:    cm.Labels[ProfileLabel],
+		Extends: extends,
+		Profile: cm.Data[ProfileKey],
+	}
+}
+
+// ResolveCustomProfile follows the chain of profiles extended by a custom profile. It returns the built-in profile at the
+// root of the chain, and the custom profiles of the chain in the order they are merged.
+func ResolveCustomProfile(name string, getProfile CustomProfileGetter) (string, []*CustomProfile, error) {
+	var chain []*CustomProfile
+	visited := map[string]bool{}
+	for !IsBuiltInProfile(name) {
+		if visited[name] {
+			return "", nil, fmt.Errorf("The profile %s extends itself through the profiles it extends", name)
+		}
+		visited[name] = true
+
+		profile, err := getProfile(name)
+		if err != nil {
+			return "", nil, err
+		}
+		if profile == nil {
+			return "", nil, fmt.Errorf("The profile %s is not a built-in profile and is not defined by a ConfigMap with the label %s in namespace %s",
+				name, ProfileLabel, constants.VerrazzanoInstallNamespace)
+		}
+		if err := yaml.UnmarshalStrict([]byte(profile.Profile), &v1beta1.Verrazzano{}); err != nil {
+			return "", nil, fmt.Errorf("The profile %s is not a valid Verrazzano resource: %v", name, err)
+		}
+		chain = append([]*CustomProfile{profile}, chain...)
+		name = profile.Extends
+	}
+	return name, chain, nil
+}
+
+// UsesProfile returns true if a profile is the given profile, or extends it directly or through other custom profiles
+func UsesProfile(name string, profile string, getProfile CustomProfileGetter) (bool, error) {
+	visited := map[string]bool{}
+	for !visited[name] {
+		if name == profile {
+			return true, nil
+		}
+		if IsBuiltInProfile(name) {
+			return false, nil
+		}
+		visited[name] = true
+
+		p, err := getProfile(name)
+		if err != nil || p == nil {
+			return false, err
+		}
+		name = p.Extends
+	}
+	return false, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const customProfile = `apiVersion: install.verrazzano.io/v1beta1
+kind: Verrazzano
+spec:
+  components:
+    kiali:
+      enabled: false
+`
+
+func newProfileConfigMap(name string, profile string, extends string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-profile",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{ProfileLabel: name},
+		},
+		Data: map[string]string{ProfileKey: profile, ExtendsKey: extends},
+	}
+}
+
+// TestGetConfigMapProfile tests reading the custom profiles defined by ConfigMaps
+// GIVEN profile ConfigMaps in the verrazzano-install namespace
+// WHEN the custom profiles are read
+// THEN the profiles are returned, extending the prod profile by default, and nil is returned for undefined profiles
+func TestGetConfigMapProfile(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newProfileConfigMap("small", customProfile, ""),
+		newProfileConfigMap("small-dev", customProfile, "dev"),
+	).Build()
+	getProfile := NewConfigMapProfileGetter(cli)
+
+	profile, err := getProfile("small")
+	assert.NoError(t, err)
+	assert.Equal(t, &CustomProfile{Name: "small", Extends: "prod", Profile: customProfile}, profile)
+
+	profile, err = getProfile("small-dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", profile.Extends)
+
+	profile, err = getProfile("missing")
+	assert.NoError(t, err)
+	assert.Nil(t, profile)
+}
+
+// TestResolveCustomProfile tests resolving the chain of profiles extended by a custom profile
+// GIVEN custom profiles extending built-in and other custom profiles
+// WHEN the custom profiles are resolved
+// THEN the built-in profile and the custom profiles are returned in merge order, and invalid chains return an error
+func TestResolveCustomProfile(t *testing.T) {
+	profiles := map[string]*CustomProfile{
+		"small":    {Name: "small", Extends: "dev", Profile: customProfile},
+		"smaller":  {Name: "smaller", Extends: "small"},
+		"cycle-a":  {Name: "cycle-a", Extends: "cycle-b"},
+		"cycle-b":  {Name: "cycle-b", Extends: "cycle-a"},
+		"invalid":  {Name: "invalid", Extends: "dev", Profile: "spec:\n  unknown: true\n"},
+		"dangling": {Name: "dangling", Extends: "missing"},
+	}
+	getProfile := func(name string) (*CustomProfile, error) {
+		return profiles[name], nil
+	}
+
+	builtIn, chain, err := ResolveCustomProfile("smaller", getProfile)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", builtIn)
+	assert.Equal(t, []*CustomProfile{profiles["small"], profiles["smaller"]}, chain)
+
+	builtIn, chain, err = ResolveCustomProfile("prod", getProfile)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", builtIn)
+	assert.Empty(t, chain)
+
+	_, _, err = ResolveCustomProfile("cycle-a", getProfile)
+	assert.ErrorContains(t, err, "extends itself")
+	_, _, err = ResolveCustomProfile("invalid", getProfile)
+	assert.ErrorContains(t, err, "is not a valid Verrazzano resource")
+	_, _, err = ResolveCustomProfile("dangling", getProfile)
+	assert.ErrorContains(t, err, "The profile missing is not a built-in profile")
+
+	uses, err := UsesProfile("smaller", "small", getProfile)
+	assert.NoError(t, err)
+	assert.True(t, uses)
+	uses, err = UsesProfile("small", "smaller", getProfile)
+	assert.NoError(t, err)
+	assert.False(t, uses)
+	uses, err = UsesProfile("cycle-a", "small", getProfile)
+	assert.NoError(t, err)
+	assert.False(t, uses)
+}
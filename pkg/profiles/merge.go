@@ -57,8 +57,22 @@ func MergeProfiles(actualCR *v1alpha1.Verrazzano, profileFiles ...string) (*v1al
 // MergeProfilesForV1beta1 merges a list of v1beta1.Verrazzano profile files with an existing Verrazzano CR.
 // The profiles must be in the Verrazzano CR format
 func MergeProfilesForV1beta1(actualCR *v1beta1.Verrazzano, profileFiles ...string) (*v1beta1.Verrazzano, error) {
+	var profiles []string
+	for _, profileFile := range profileFiles {
+		data, err := os.ReadFile(profileFile)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, string(data))
+	}
+	return MergeProfileDataForV1beta1(actualCR, profiles...)
+}
+
+// MergeProfileDataForV1beta1 merges a list of v1beta1.Verrazzano profiles with an existing Verrazzano CR.
+// The profiles must be YAML strings in the Verrazzano CR format
+func MergeProfileDataForV1beta1(actualCR *v1beta1.Verrazzano, profiles ...string) (*v1beta1.Verrazzano, error) {
 	// First merge the profiles
-	profileStrings, err := appendProfileComponentOverridesV1beta1(profileFiles...)
+	profileStrings, err := appendProfileComponentOverridesV1beta1(profiles...)
 	if err != nil {
 		return nil, err
 	}
@@ -125,24 +139,20 @@ func appendProfileComponentOverrides(profileFiles ...string) ([]string, error) {
 	return profileStrings, nil
 }
 
-func appendProfileComponentOverridesV1beta1(profileFiles ...string) ([]string, error) {
+func appendProfileComponentOverridesV1beta1(profiles ...string) ([]string, error) {
 	var profileCR *v1beta1.Verrazzano
 	var profileStrings []string
-	for i := range profileFiles {
-		profileFile := profileFiles[len(profileFiles)-1-i]
-		data, err := os.ReadFile(profileFile)
-		if err != nil {
-			return nil, err
-		}
+	for i := range profiles {
+		data := profiles[len(profiles)-1-i]
 		cr := &v1beta1.Verrazzano{}
-		if err := yaml.Unmarshal(data, cr); err != nil {
+		if err := yaml.Unmarshal([]byte(data), cr); err != nil {
 			return nil, err
 		}
 		if profileCR == nil {
 			profileCR = cr
 		} else {
 			AppendComponentOverridesV1beta1(profileCR, cr)
-			profileStrings = append(profileStrings, string(data))
+			profileStrings = append(profileStrings, data)
 		}
 
 	}
@@ -149,15 +149,15 @@ func IsCertManagerWebhookOCIEnabled(cr runtime.Object) bool {
 
 // IsCertManagerWebhookOCIRequired - Returns true if the ExternalCertManager component is explicitly enabled, OR
 // if all of the following is true:
-// - ACME/LetsEncrypt certificates are configured
+// - ACME certificates are configured, either LetsEncrypt or a custom ACME server
 // - OCI DNS is enabled
 // - the ClusterIssuerComponent is enabled
 //
 // This behavior is to allow backwards compatibility with earlier releases where the behavior was not implemented in
 // a separate component, and was implicitly enabled by the other conditions
 func IsCertManagerWebhookOCIRequired(cr runtime.Object) bool {
-	isLetsEncryptConfig, _ := IsLetsEncryptConfig(cr)
-	return IsCertManagerWebhookOCIEnabled(cr) || IsOCIDNSEnabled(cr) && isLetsEncryptConfig && IsClusterIssuerEnabled(cr)
+	isACMEConfig, _ := IsACMEConfig(cr)
+	return IsCertManagerWebhookOCIEnabled(cr) || IsOCIDNSEnabled(cr) && isACMEConfig && IsClusterIssuerEnabled(cr)
 }
 
 // IsLetsEncryptConfig - Check if cert-type is LetsEncrypt
@@ -181,7 +181,28 @@ func IsLetsEncryptConfig(cr runtime.Object) (bool, error) {
 	return false, fmt.Errorf("Illegal configuration state, unable to resolve ClusterIssuerComponent type: %v", cr)
 }
 
-// IsCAConfig - Check if cert-type is CA, if not it is assumed to be Acme
+// IsACMEConfig - Check if cert-type is ACME, either LetsEncrypt or a custom ACME server
+func IsACMEConfig(cr runtime.Object) (bool, error) {
+	if cr == nil {
+		return false, fmt.Errorf("Nil CR passed in")
+	}
+	if vzv1alpha1, ok := cr.(*installv1alpha1.Verrazzano); ok {
+		componentSpec := vzv1alpha1.Spec.Components
+		if componentSpec.ClusterIssuer == nil {
+			return false, nil
+		}
+		return componentSpec.ClusterIssuer.IsACMEIssuer()
+	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
+		componentSpec := vzv1beta1.Spec.Components
+		if componentSpec.ClusterIssuer == nil {
+			return false, nil
+		}
+		return componentSpec.ClusterIssuer.IsACMEIssuer()
+	}
+	return false, fmt.Errorf("Illegal configuration state, unable to resolve ClusterIssuerComponent type: %v", cr)
+}
+
+// IsCAConfig - Check if cert-type is CA
 func IsCAConfig(cr runtime.Object) (bool, error) {
 	if cr == nil {
 		return false, fmt.Errorf("Nil CR passed in")
@@ -331,7 +352,7 @@ func IsRancherEnabled(cr runtime.Object) bool {
 
 // IsExternalDNSEnabled Indicates if the external-dns service is expected to be deployed, true if OCI DNS is configured
 func IsExternalDNSEnabled(cr runtime.Object) bool {
-	return IsOCIDNSEnabled(cr)
+	return IsOCIDNSEnabled(cr) || IsRFC2136DNSEnabled(cr)
 }
 
 // IsOCIDNSEnabled Returns true if OCI DNS is configured
@@ -348,6 +369,20 @@ func IsOCIDNSEnabled(cr runtime.Object) bool {
 	return false
 }
 
+// IsRFC2136DNSEnabled Returns true if RFC2136 DNS is configured
+func IsRFC2136DNSEnabled(cr runtime.Object) bool {
+	if vzv1alpha1, ok := cr.(*installv1alpha1.Verrazzano); ok {
+		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.DNS != nil && vzv1alpha1.Spec.Components.DNS.RFC2136 != nil {
+			return true
+		}
+	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.DNS != nil && vzv1beta1.Spec.Components.DNS.RFC2136 != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // IsVMOEnabled - Returns false if all VMO components are disabled
 func IsVMOEnabled(vz runtime.Object) bool {
 	return IsOpenSearchDashboardsEnabled(vz) || IsOpenSearchEnabled(vz) || IsGrafanaEnabled(vz)
@@ -617,6 +652,20 @@ func IsThanosEnabled(cr runtime.Object) bool {
 	return false
 }
 
+// IsGatewayAPIEnabled returns true only if the Gateway API ingress backend is explicitly enabled in the CR
+func IsGatewayAPIEnabled(cr runtime.Object) bool {
+	if vzv1alpha1, ok := cr.(*installv1alpha1.Verrazzano); ok {
+		if vzv1alpha1 != nil && vzv1alpha1.Spec.Components.GatewayAPI != nil && vzv1alpha1.Spec.Components.GatewayAPI.Enabled != nil {
+			return *vzv1alpha1.Spec.Components.GatewayAPI.Enabled
+		}
+	} else if vzv1beta1, ok := cr.(*installv1beta1.Verrazzano); ok {
+		if vzv1beta1 != nil && vzv1beta1.Spec.Components.GatewayAPI != nil && vzv1beta1.Spec.Components.GatewayAPI.Enabled != nil {
+			return *vzv1beta1.Spec.Components.GatewayAPI.Enabled
+		}
+	}
+	return false
+}
+
 // IsClusterAgentEnabled returns false only if Cluster Agent is explicitly disabled in the CR
 func IsClusterAgentEnabled(cr runtime.Object) bool {
 	if vzv1alpha1, ok := cr.(*installv1alpha1.Verrazzano); ok {
@@ -66,6 +66,42 @@ func TestIsExternalDNSEnabledOCIDNS(t *testing.T) {
 	assert.True(t, IsExternalDNSEnabled(vzv1beta1))
 }
 
+// TestIsExternalDNSEnabledRFC2136DNS tests the IsExternalDNSEnabled function
+// GIVEN a call to IsExternalDNSEnabled
+//
+//	WHEN the VZ config has RFC2136 DNS configured
+//	THEN true is returned
+func TestIsExternalDNSEnabledRFC2136DNS(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				DNS: &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						DNSZoneName: "mydomain.com",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IsExternalDNSEnabled(vz))
+	assert.True(t, IsRFC2136DNSEnabled(vz))
+	assert.False(t, IsOCIDNSEnabled(vz))
+
+	vzv1beta1 := &installv1beta1.Verrazzano{
+		Spec: installv1beta1.VerrazzanoSpec{
+			Components: installv1beta1.ComponentSpec{
+				DNS: &installv1beta1.DNSComponent{
+					RFC2136: &installv1beta1.RFC2136{
+						DNSZoneName: "mydomain.com",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, IsExternalDNSEnabled(vzv1beta1))
+	assert.True(t, IsRFC2136DNSEnabled(vzv1beta1))
+}
+
 // TestIsExternalDNSEnabledWildcardDNS tests the IsExternalDNSEnabled function
 // GIVEN a call to IsExternalDNSEnabled
 //
@@ -495,6 +531,46 @@ func TestIsCAConfig(t *testing.T) {
 		}}))
 }
 
+// TestIsACMEConfig tests the IsACMEConfig function
+// GIVEN a call to IsACMEConfig
+// THEN true is returned for LetsEncrypt and custom ACME issuers, false otherwise
+func TestIsACMEConfig(t *testing.T) {
+	asserts := assert.New(t)
+
+	isACME, err := IsACMEConfig(&corev1.Secret{})
+	asserts.False(isACME)
+	asserts.Error(err)
+
+	asserts.False(IsACMEConfig(&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{}}))
+
+	asserts.True(IsACMEConfig(
+		&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				ClusterIssuer: &vzapi.ClusterIssuerComponent{
+					IssuerConfig: vzapi.IssuerConfig{LetsEncrypt: &vzapi.LetsEncryptACMEIssuer{}},
+				},
+			},
+		}}))
+
+	asserts.True(IsACMEConfig(
+		&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{
+			Components: installv1beta1.ComponentSpec{
+				ClusterIssuer: &installv1beta1.ClusterIssuerComponent{
+					IssuerConfig: installv1beta1.IssuerConfig{ACME: &installv1beta1.ACMEIssuer{}},
+				},
+			},
+		}}))
+
+	asserts.False(IsACMEConfig(
+		&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{
+			Components: installv1beta1.ComponentSpec{
+				ClusterIssuer: &installv1beta1.ClusterIssuerComponent{
+					IssuerConfig: installv1beta1.IssuerConfig{Vault: &installv1beta1.VaultIssuer{}},
+				},
+			},
+		}}))
+}
+
 // TestIsConsoleEnabled tests the IsConsoleEnabled function
 // GIVEN a call to IsConsoleEnabled
 //
@@ -1528,6 +1604,35 @@ func TestIsArgoCDEnabled(t *testing.T) {
 		}}))
 }
 
+// TestIsGatewayAPIEnabled tests the IsGatewayAPIEnabled function
+// GIVEN a call to IsGatewayAPIEnabled
+//
+//	THEN the value of the Enabled flag is returned if present, false otherwise (disabled by default)
+func TestIsGatewayAPIEnabled(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.False(IsGatewayAPIEnabled(nil))
+	asserts.False(IsGatewayAPIEnabled(&vzapi.Verrazzano{}))
+	asserts.False(IsGatewayAPIEnabled(&installv1beta1.Verrazzano{}))
+	asserts.True(IsGatewayAPIEnabled(
+		&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				GatewayAPI: &vzapi.GatewayAPIComponent{Enabled: &trueValue},
+			},
+		}}))
+	asserts.True(IsGatewayAPIEnabled(
+		&installv1beta1.Verrazzano{Spec: installv1beta1.VerrazzanoSpec{
+			Components: installv1beta1.ComponentSpec{
+				GatewayAPI: &installv1beta1.GatewayAPIComponent{Enabled: &trueValue},
+			},
+		}}))
+	asserts.False(IsGatewayAPIEnabled(
+		&vzapi.Verrazzano{Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				GatewayAPI: &vzapi.GatewayAPIComponent{Enabled: &falseValue},
+			},
+		}}))
+}
+
 func TestIsComponentEnabled(t *testing.T) {
 	var tests = []struct {
 		name      string
@@ -0,0 +1,83 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1
+
+import (
+	"encoding/json"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// CertificateAnnotation preserves the deprecated v1beta1 cert-manager certificate, which has no v1 equivalent,
+// so that converting a Verrazzano resource to v1 and back is lossless
+const CertificateAnnotation = "install.verrazzano.io/v1beta1-certificate"
+
+// ConvertTo converts a v1.Verrazzano to a v1beta1.Verrazzano
+func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
+	out := dstRaw.(*v1beta1.Verrazzano)
+	if out == nil || in == nil {
+		return nil
+	}
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+
+	// The v1 API is the v1beta1 API without the deprecated fields, so the spec and status are converted as JSON
+	if err := convertJSON(in.Spec, &out.Spec); err != nil {
+		return err
+	}
+	if err := convertJSON(in.Status, &out.Status); err != nil {
+		return err
+	}
+
+	certificate, ok := out.Annotations[CertificateAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(out.Annotations, CertificateAnnotation)
+	if len(out.Annotations) == 0 {
+		out.Annotations = nil
+	}
+	if out.Spec.Components.CertManager == nil {
+		out.Spec.Components.CertManager = &v1beta1.CertManagerComponent{}
+	}
+	return json.Unmarshal([]byte(certificate), &out.Spec.Components.CertManager.Certificate)
+}
+
+// ConvertFrom converts from v1beta1.Verrazzano to v1.Verrazzano
+func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Verrazzano)
+	if src == nil {
+		return nil
+	}
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if err := convertJSON(src.Spec, &in.Spec); err != nil {
+		return err
+	}
+	if err := convertJSON(src.Status, &in.Status); err != nil {
+		return err
+	}
+
+	certManager := src.Spec.Components.CertManager
+	if certManager == nil || certManager.Certificate == (v1beta1.Certificate{}) {
+		return nil
+	}
+	certificate, err := json.Marshal(certManager.Certificate)
+	if err != nil {
+		return err
+	}
+	if in.Annotations == nil {
+		in.Annotations = map[string]string{}
+	}
+	in.Annotations[CertificateAnnotation] = string(certificate)
+	return nil
+}
+
+// convertJSON converts between the equivalent v1 and v1beta1 types
+func convertJSON(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
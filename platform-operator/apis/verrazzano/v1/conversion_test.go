@@ -0,0 +1,115 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+const fuzzIterations = 200
+
+// fuzzerFuncs generates the values which only have a valid JSON representation
+func fuzzerFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(j *apiextensionsv1.JSON, c fuzz.Continue) {
+			j.Raw = []byte(`{"key":"value"}`)
+		},
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+	}
+}
+
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	seed := rand.Int63()
+	t.Logf("Fuzzer seed %d", seed)
+	funcs := fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, fuzzerFuncs)
+	return fuzzer.FuzzerFor(funcs, rand.NewSource(seed), runtimeserializer.NewCodecFactory(scheme))
+}
+
+// TestHubRoundTrip tests that converting a v1beta1 resource to v1 and back is lossless
+// GIVEN random v1beta1 Verrazzano resources, including the deprecated fields
+// WHEN they are converted to v1 and back to v1beta1
+// THEN the resources are unchanged
+func TestHubRoundTrip(t *testing.T) {
+	f := newFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		hub := &v1beta1.Verrazzano{}
+		f.Fuzz(hub)
+
+		vz := &Verrazzano{}
+		assert.NoError(t, vz.ConvertFrom(hub.DeepCopy()))
+		result := &v1beta1.Verrazzano{}
+		assert.NoError(t, vz.ConvertTo(result))
+		if !apiequality.Semantic.DeepEqual(hub, result) {
+			t.Fatalf("The v1beta1 resource changed after a round trip: %s", diff.ObjectReflectDiff(hub, result))
+		}
+	}
+}
+
+// TestSpokeRoundTrip tests that converting a v1 resource to v1beta1 and back is lossless
+// GIVEN random v1 Verrazzano resources
+// WHEN they are converted to v1beta1 and back to v1
+// THEN the resources are unchanged
+func TestSpokeRoundTrip(t *testing.T) {
+	f := newFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		vz := &Verrazzano{}
+		f.Fuzz(vz)
+
+		hub := &v1beta1.Verrazzano{}
+		assert.NoError(t, vz.DeepCopy().ConvertTo(hub))
+		result := &Verrazzano{}
+		assert.NoError(t, result.ConvertFrom(hub))
+		if !apiequality.Semantic.DeepEqual(vz, result) {
+			t.Fatalf("The v1 resource changed after a round trip: %s", diff.ObjectReflectDiff(vz, result))
+		}
+	}
+}
+
+// TestConvertDeprecatedCertificate tests the conversion of the deprecated cert-manager certificate
+// GIVEN a v1beta1 Verrazzano resource with a CA certificate
+// WHEN it is converted to v1
+// THEN the certificate is preserved in an annotation, and restored when the resource is converted back to v1beta1
+func TestConvertDeprecatedCertificate(t *testing.T) {
+	hub := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: v1beta1.VerrazzanoSpec{
+			Profile: v1beta1.Dev,
+			Components: v1beta1.ComponentSpec{
+				CertManager: &v1beta1.CertManagerComponent{
+					Certificate: v1beta1.Certificate{CA: v1beta1.CA{ClusterResourceNamespace: "cert-manager", SecretName: "my-ca"}},
+				},
+			},
+		},
+	}
+
+	vz := &Verrazzano{}
+	assert.NoError(t, vz.ConvertFrom(hub))
+	assert.Equal(t, Dev, vz.Spec.Profile)
+	assert.NotNil(t, vz.Spec.Components.CertManager)
+	assert.JSONEq(t, `{"acme":{"provider":""},"ca":{"clusterResourceNamespace":"cert-manager","secretName":"my-ca"}}`, vz.Annotations[CertificateAnnotation])
+	assert.Empty(t, hub.Annotations)
+
+	result := &v1beta1.Verrazzano{}
+	assert.NoError(t, vz.ConvertTo(result))
+	assert.Equal(t, hub, result)
+}
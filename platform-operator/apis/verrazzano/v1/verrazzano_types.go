@@ -0,0 +1,1636 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1
+
+import (
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ProfileType is the type of installation profile.
+type ProfileType string
+
+const (
+	// Dev identifies the development install profile
+	Dev ProfileType = "dev"
+	// Prod identifies the production install profile
+	Prod ProfileType = "prod"
+	// None identifies a profile with all components disabled
+	None ProfileType = "none"
+	// ManagedCluster identifies the production managed-cluster install profile
+	ManagedCluster ProfileType = "managed-cluster"
+)
+const (
+	// LoadBalancer is an ingress type of LoadBalancer.  This is the default value.
+	LoadBalancer IngressType = "LoadBalancer"
+	// NodePort is an ingress type of NodePort.
+	NodePort IngressType = "NodePort"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=verrazzanos
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=vz;vzs
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.available",description="Available/Enabled Verrazzano Components."
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[-1:].type",description="The current status of the install/uninstall."
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="The current version of the Verrazzano installation."
+// +genclient
+
+// Verrazzano specifies the Verrazzano API.
+type Verrazzano struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VerrazzanoSpec   `json:"spec,omitempty"`
+	Status VerrazzanoStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VerrazzanoList contains a list of Verrazzano resources.
+type VerrazzanoList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Verrazzano `json:"items"`
+}
+
+// VerrazzanoSpec defines the desired state of Verrazzano resource.
+type VerrazzanoSpec struct {
+	// Defines how the applications are restarted after an upgrade.
+	// +optional
+	ApplicationRestart *ApplicationRestartSpec `json:"applicationRestart,omitempty"`
+	// The Verrazzano components.
+	// +optional
+	// +patchStrategy=merge
+	Components ComponentSpec `json:"components,omitempty" patchStrategy:"merge"`
+	// Defines the type of volume to be used for persistence for all components unless overridden, and can be one of
+	// either EmptyDirVolumeSource or PersistentVolumeClaimVolumeSource. If PersistentVolumeClaimVolumeSource is
+	// declared, then the `claimName` must reference the name of an existing `VolumeClaimSpecTemplate` declared in the
+	// `volumeClaimSpecTemplates` section.
+	// +optional
+	// +patchStrategy=replace
+	DefaultVolumeSource *corev1.VolumeSource `json:"defaultVolumeSource,omitempty" patchStrategy:"replace"`
+	// Name of the installation. This name is part of the endpoint access URLs that are generated.
+	// The default value is `default`.
+	// +optional
+	EnvironmentName string `json:"environmentName,omitempty"`
+	// The installation profile to select. Valid values are `prod` (production), `dev` (development), and `managed-cluster`.
+	// The default is `prod`.
+	// +optional
+	Profile ProfileType `json:"profile,omitempty"`
+	// Security specifies Verrazzano security configuration.
+	// +optional
+	Security SecuritySpec `json:"security,omitempty"`
+	// The version to install. Valid versions can be found
+	// <a href="https://github.com/verrazzano/verrazzano/releases/">here</a>.
+	// Defaults to the current version supported by the Verrazzano platform operator.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Defines a named set of PVC configurations that can be referenced from components to configure persistent volumes.
+	// +optional
+	// +patchStrategy=merge,retainKeys
+	VolumeClaimSpecTemplates []VolumeClaimSpecTemplate `json:"volumeClaimSpecTemplates,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// ApplicationRestartSpec defines how the applications are restarted after a Verrazzano upgrade so that they get
+// the new Istio proxy sidecar and Fluentd images.
+type ApplicationRestartSpec struct {
+	// The maximum number of applications that are restarted at the same time. The default value is `1`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentApplications *int `json:"maxConcurrentApplications,omitempty"`
+	// The maximum number, or percentage, of application pods that can belong to the applications being restarted at
+	// the same time. A percentage is calculated from the total number of application pods. An application is always
+	// allowed to restart when no other application is being restarted. By default, the number of pods is not limited.
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailablePods *intstr.IntOrString `json:"maxUnavailablePods,omitempty"`
+	// The maintenance windows in which applications can be restarted. An application is restarted in the first
+	// maintenance window that selects its namespace. Applications in namespaces not selected by any maintenance
+	// window can be restarted at any time. The upgrade does not wait for the maintenance windows, the applications
+	// waiting for a window are restarted after the upgrade has completed.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window in which the applications of a set of namespaces can be restarted.
+type MaintenanceWindow struct {
+	// The days of the week on which the window starts, for example `Saturday`. If not specified, then the window
+	// starts every day.
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// The length of the window, for example `4h`.
+	Duration metav1.Duration `json:"duration"`
+	// Selects the namespaces that the window applies to. If not specified, then the window applies to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// The UTC time at which the window starts, in the format `HH:MM`.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+}
+
+// SecuritySpec defines the security configuration for Verrazzano.
+type SecuritySpec struct {
+	// Specifies subjects that should be bound to the verrazzano-admin role.
+	// +optional
+	AdminSubjects []rbacv1.Subject `json:"adminSubjects,omitempty"`
+	// Defines the automated rotation of the credentials generated by Verrazzano.
+	// +optional
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
+	// Specifies subjects that should be bound to the verrazzano-monitor role.
+	// +optional
+	MonitorSubjects []rbacv1.Subject `json:"monitorSubjects,omitempty"`
+}
+
+// CredentialName identifies a credential generated by Verrazzano.
+// +kubebuilder:validation:Enum=keycloak-admin;mysql-root;mysql-user;grafana-admin
+type CredentialName string
+
+const (
+	// KeycloakAdminCredential is the password of the Keycloak admin user
+	KeycloakAdminCredential CredentialName = "keycloak-admin"
+	// MySQLRootCredential is the password of the MySQL root user
+	MySQLRootCredential CredentialName = "mysql-root"
+	// MySQLUserCredential is the password of the MySQL user used by Keycloak
+	MySQLUserCredential CredentialName = "mysql-user"
+	// GrafanaAdminCredential is the password of the Grafana admin user
+	GrafanaAdminCredential CredentialName = "grafana-admin"
+)
+
+// CredentialRotationSpec defines the automated rotation of the credentials generated by Verrazzano.
+type CredentialRotationSpec struct {
+	// The credentials to rotate. Valid values are `keycloak-admin`, `mysql-root`, `mysql-user`, and `grafana-admin`.
+	// If not specified, then all the credentials are rotated.
+	// +optional
+	Credentials []CredentialName `json:"credentials,omitempty"`
+	// The number of days between two rotations of a credential. The default value is `90`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IntervalDays *int `json:"intervalDays,omitempty"`
+	// Changing this value rotates the credentials immediately, without waiting for the rotation interval.
+	// +optional
+	RotationVersion string `json:"rotationVersion,omitempty"`
+}
+
+// VolumeClaimSpecTemplate Contains common PVC configuration that can be referenced from Components; these
+// do not actually result in generated PVCs, but can be used to provide common configuration to components that
+// declare a PersistentVolumeClaimVolumeSource.
+type VolumeClaimSpecTemplate struct {
+	// Metadata about the PersistentVolumeClaimSpec template.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// A `PersistentVolumeClaimSpec` template that can be referenced by a Component to override its default storage
+	// settings for a profile. At present, only a subset of the `resources.requests` object are honored depending on
+	// the component.
+	Spec corev1.PersistentVolumeClaimSpec `json:"spec,omitempty"`
+}
+
+// InstanceInfo details of the installed Verrazzano instance maintained in status field.
+type InstanceInfo struct {
+	// The Argo CD UI URL for this Verrazzano installation.
+	ArgoCDURL *string `json:"argoCDUrl,omitempty"`
+	// The Console URL for this Verrazzano installation.
+	ConsoleURL *string `json:"consoleUrl,omitempty"`
+	// The Grafana URL for this Verrazzano installation.
+	GrafanaURL *string `json:"grafanaUrl,omitempty"`
+	// The Jaeger UI URL for this Verrazzano installation.
+	JaegerURL *string `json:"jaegerUrl,omitempty"`
+	// The KeyCloak URL for this Verrazzano installation.
+	KeyCloakURL *string `json:"keyCloakUrl,omitempty"`
+	// The Kiali URL for this Verrazzano installation.
+	KialiURL *string `json:"kialiUrl,omitempty"`
+	// The OpenSearch Dashboards URL for this Verrazzano installation.
+	OpenSearchDashboardsURL *string `json:"openSearchDashboardsUrl,omitempty"`
+	// The OpenSearch URL for this Verrazzano installation.
+	OpenSearchURL *string `json:"openSearchUrl,omitempty"`
+	// The Prometheus URL for this Verrazzano installation.
+	PrometheusURL *string `json:"prometheusUrl,omitempty"`
+	// The Rancher URL for this Verrazzano installation.
+	RancherURL *string `json:"rancherUrl,omitempty"`
+	// The Thanos Query URL for this Verrazzano installation.
+	// The Thanos Query ingress gets forwarded to the Thanos Query Frontend service.
+	ThanosQueryURL *string `json:"thanosQueryUrl,omitempty"`
+}
+
+// VerrazzanoStatus defines the observed state of a Verrazzano resource.
+type VerrazzanoStatus struct {
+	// The progress of the application restart done after an upgrade.
+	ApplicationRestart *ApplicationRestartStatus `json:"applicationRestart,omitempty"`
+	// The summary of Verrazzano component availability.
+	Available *string `json:"available,omitempty"`
+	// States of the individual installed components.
+	Components ComponentStatusMap `json:"components,omitempty"`
+	// The rotation state of the credentials generated by Verrazzano.
+	Credentials []CredentialStatus `json:"credentials,omitempty"`
+	// The latest available observations of an object's current state.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The snapshots of the OpenSearch cluster.
+	OpenSearchSnapshots *OpenSearchSnapshotStatus `json:"openSearchSnapshots,omitempty"`
+	// The most recent remediation actions taken by the Verrazzano platform operator, oldest first.
+	Remediations []RemediationRecord `json:"remediations,omitempty"`
+	// State of the Verrazzano custom resource.
+	State VzStateType `json:"state,omitempty"`
+	// The status of Thanos.
+	Thanos *ThanosStatus `json:"thanos,omitempty"`
+	// The Verrazzano instance info.
+	VerrazzanoInstance *InstanceInfo `json:"instance,omitempty"`
+	// The version of Verrazzano that is installed.
+	Version string `json:"version,omitempty"`
+}
+
+// ApplicationRestartStatus reports the progress of the application restart done after a Verrazzano upgrade.
+type ApplicationRestartStatus struct {
+	// The names, in the format `namespace/name`, of the applications that are waiting for a maintenance window.
+	Deferred []string `json:"deferred,omitempty"`
+	// The names, in the format `namespace/name`, of the applications being restarted.
+	InProgress []string `json:"inProgress,omitempty"`
+	// The number of applications that still need to be restarted.
+	Pending int `json:"pending,omitempty"`
+	// The number of applications that have been restarted.
+	Restarted int `json:"restarted,omitempty"`
+	// The restart version set on the applications, derived from the Verrazzano version of the upgrade.
+	RestartVersion string `json:"restartVersion,omitempty"`
+	// The names, in the format `namespace/name`, of the applications that opted out of the restart.
+	Skipped []string `json:"skipped,omitempty"`
+	// The total number of applications that need to be restarted.
+	Total int `json:"total,omitempty"`
+}
+
+// CredentialStatus reports the rotation of a credential generated by Verrazzano.
+type CredentialStatus struct {
+	// The latest available observations of the rotation of the credential.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The time of the last successful rotation of the credential.
+	LastRotationTime string `json:"lastRotationTime,omitempty"`
+	// The name of the credential.
+	Name CredentialName `json:"name"`
+	// The rotation version used by the last successful rotation of the credential.
+	RotationVersion string `json:"rotationVersion,omitempty"`
+}
+
+// RemediationResult is the result of a remediation action.
+// +kubebuilder:validation:Enum=Succeeded;Failed;AttemptsExhausted
+type RemediationResult string
+
+const (
+	// RemediationSucceeded means that the repair of a detected problem succeeded
+	RemediationSucceeded RemediationResult = "Succeeded"
+	// RemediationFailed means that the repair of a detected problem failed
+	RemediationFailed RemediationResult = "Failed"
+	// RemediationAttemptsExhausted means that a detected problem persists after the maximum number of repairs
+	RemediationAttemptsExhausted RemediationResult = "AttemptsExhausted"
+)
+
+// RemediationRecord records a remediation action taken by the Verrazzano platform operator.
+type RemediationRecord struct {
+	// The repair attempt number for the detected problem.
+	Attempt int `json:"attempt,omitempty"`
+	// The name of the repaired component.
+	Component string `json:"component,omitempty"`
+	// Details about the remediation action.
+	Message string `json:"message,omitempty"`
+	// The result of the remediation action.
+	Result RemediationResult `json:"result"`
+	// The name of the remediation rule.
+	Rule string `json:"rule"`
+	// The time of the remediation action.
+	Time string `json:"time,omitempty"`
+}
+
+// ComponentStatusMap is a map of components status details.
+type ComponentStatusMap map[string]*ComponentStatusDetails
+
+// ComponentStatusDetails defines the observed state of a component.
+type ComponentStatusDetails struct {
+	// Whether a component is available for use.
+	Available *ComponentAvailability `json:"available,omitempty"`
+	// Information about the current state of a component.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The generation of the last Verrazzano resource the Component was successfully reconciled against.
+	LastReconciledGeneration int64 `json:"lastReconciledGeneration,omitempty"`
+	// Name of the component.
+	Name string `json:"name,omitempty"`
+	// The progress of a long running operation of the component, such as the removal of OpenSearch data nodes.
+	Progress string `json:"progress,omitempty"`
+	// The generation of the Verrazzano resource the Component is currently being reconciled against.
+	ReconcilingGeneration int64 `json:"reconcilingGeneration,omitempty"`
+	// The state of a component.
+	State CompStateType `json:"state,omitempty"`
+	// The version of a component.
+	Version string `json:"version,omitempty"`
+}
+
+// ConditionType identifies the condition of the install, uninstall, or upgrade, which can be checked with `kubectl wait`.
+type ConditionType string
+
+const (
+	// CondPreInstall means an install about to start.
+	CondPreInstall ConditionType = "PreInstall"
+
+	// CondInstallStarted means an install is in progress.
+	CondInstallStarted ConditionType = "InstallStarted"
+
+	// CondInstallComplete means the install job has completed its execution successfully
+	CondInstallComplete ConditionType = "InstallComplete"
+
+	// CondInstallFailed means the install job has failed during execution.
+	CondInstallFailed ConditionType = "InstallFailed"
+
+	// CondUninstallStarted means an uninstall is in progress.
+	CondUninstallStarted ConditionType = "UninstallStarted"
+
+	// CondUninstallComplete means the uninstall job has completed its execution successfully
+	CondUninstallComplete ConditionType = "UninstallComplete"
+
+	// CondUninstallFailed means the uninstall job has failed during execution.
+	CondUninstallFailed ConditionType = "UninstallFailed"
+
+	// CondUpgradeStarted means that an upgrade has been started.
+	CondUpgradeStarted ConditionType = "UpgradeStarted"
+
+	// CondUpgradePaused means that an upgrade has been paused awaiting a VZ version update.
+	CondUpgradePaused ConditionType = "UpgradePaused"
+
+	// CondUpgradeFailed means the upgrade has failed during execution.
+	CondUpgradeFailed ConditionType = "UpgradeFailed"
+
+	// CondUpgradeComplete means the upgrade has completed successfully
+	CondUpgradeComplete ConditionType = "UpgradeComplete"
+
+	// CondCredentialRotated means a credential has been rotated successfully
+	CondCredentialRotated ConditionType = "CredentialRotated"
+
+	// CondCredentialRotationFailed means the rotation of a credential has failed
+	CondCredentialRotationFailed ConditionType = "CredentialRotationFailed"
+)
+
+// Condition describes the current state of an installation.
+type Condition struct {
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+	// Human readable message indicating details about the last transition.
+	Message string `json:"message,omitempty"`
+	// Status of the condition: one of `True`, `False`, or `Unknown`.
+	Status corev1.ConditionStatus `json:"status"`
+	// Type of condition.
+	Type ConditionType `json:"type"`
+}
+
+// ComponentAvailability identifies the availability of a Verrazzano Component.
+type ComponentAvailability string
+
+const (
+	//ComponentAvailable signifies that a Verrazzano Component is ready for use.
+	ComponentAvailable = "Available"
+	//ComponentUnavailable signifies that a Verrazzano Component is not ready for use.
+	ComponentUnavailable = "Unavailable"
+)
+
+// VzStateType identifies the state of a Verrazzano installation.
+type VzStateType string
+
+const (
+	// VzStateUninstalling is the state when an uninstall is in progress
+	VzStateUninstalling VzStateType = "Uninstalling"
+
+	// VzStateUpgrading is the state when an upgrade is in progress
+	VzStateUpgrading VzStateType = "Upgrading"
+
+	// VzStatePaused is the state when an upgrade is paused due to version mismatch
+	VzStatePaused VzStateType = "Paused"
+
+	// VzStateReady is the state when a Verrazzano resource can perform an uninstall or upgrade
+	VzStateReady VzStateType = "Ready"
+
+	// VzStateFailed is the state when an install/uninstall/upgrade has failed
+	VzStateFailed VzStateType = "Failed"
+
+	// VzStateReconciling is the state when a resource is in progress reconciling
+	VzStateReconciling VzStateType = "Reconciling"
+)
+
+// CompStateType identifies the state of a component.
+type CompStateType string
+
+const (
+	// CompStateDisabled is the state for when a component is not currently installed
+	CompStateDisabled CompStateType = "Disabled"
+
+	// CompStatePreInstalling is the state when an install is about to be started
+	CompStatePreInstalling CompStateType = "PreInstalling"
+
+	// CompStateInstalling is the state when an install is in progress
+	CompStateInstalling CompStateType = "Installing"
+
+	// CompStateUninstalling is the state when an uninstall is in progress
+	CompStateUninstalling CompStateType = "Uninstalling"
+
+	// CompStateUninstalled is the state when a component has been uninstalled
+	CompStateUninstalled CompStateType = "Uninstalled"
+
+	// CompStateUpgrading is the state when an upgrade is in progress
+	CompStateUpgrading CompStateType = "Upgrading"
+
+	// CompStateError is the state when a Verrazzano resource has experienced an error that may leave it in an unstable state
+	CompStateError CompStateType = "Error"
+
+	// CompStateReady is the state when a Verrazzano resource can perform an uninstall or upgrade
+	CompStateReady CompStateType = "Ready"
+
+	// CompStateFailed is the state when an install/uninstall/upgrade has failed
+	CompStateFailed CompStateType = "Failed"
+)
+
+// ComponentSpec contains a set of components used by Verrazzano.
+type ComponentSpec struct {
+	// The Application Operator component configuration.
+	// +optional
+	ApplicationOperator *ApplicationOperatorComponent `json:"applicationOperator,omitempty"`
+
+	// The Argo CD component configuration.
+	// +optional
+	ArgoCD *ArgoCDComponent `json:"argoCD,omitempty"`
+
+	// The AuthProxy component configuration.
+	// +optional
+	AuthProxy *AuthProxyComponent `json:"authProxy,omitempty"`
+
+	// The ClusterAPI component configuration.
+	// +optional
+	ClusterAPI *ClusterAPIComponent `json:"clusterAPI,omitempty"`
+
+	// The ClusterAgent configuration.
+	// +optional
+	ClusterAgent *ClusterAgentComponent `json:"clusterAgent,omitempty"`
+
+	// ClusterIssuer defines the Cert-Manager ClusterIssuer configuration for Verrazzano
+	// +optional
+	ClusterIssuer *ClusterIssuerComponent `json:"clusterIssuer,omitempty"`
+
+	// The Verrazzano-managed Cert-Manager component configuration; note that this is mutually exclusive of the
+	// ExternalCertManager component
+	// +optional
+	CertManager *CertManagerComponent `json:"certManager,omitempty"`
+
+	// CertManagerWebhookOCI configures the Verrazzano OCI DNS webhook plugin for Cert-Manager
+	// +optional
+	CertManagerWebhookOCI *CertManagerWebhookOCIComponent `json:"certManagerWebhookOCI,omitempty"`
+
+	// The Cluster Operator component configuration.
+	// +optional
+	ClusterOperator *ClusterOperatorComponent `json:"clusterOperator,omitempty"`
+
+	// The Coherence Operator component configuration.
+	// +optional
+	CoherenceOperator *CoherenceOperatorComponent `json:"coherenceOperator,omitempty"`
+
+	// The Verrazzano Console component configuration.
+	// +optional
+	Console *ConsoleComponent `json:"console,omitempty"`
+
+	// The DNS component configuration.
+	// +optional
+	// +patchStrategy=replace
+	DNS *DNSComponent `json:"dns,omitempty" patchStrategy:"replace"`
+
+	// The Fluentd component configuration.
+	// +optional
+	Fluentd *FluentdComponent `json:"fluentd,omitempty"`
+
+	// The FluentOperator component configuration.
+	// +optional
+	FluentOperator *FluentOperatorComponent `json:"fluentOperator,omitempty"`
+
+	// The FluentbitOpensearchOutput component configuration.
+	// +optional
+	FluentbitOpensearchOutput *FluentbitOpensearchOutputComponent `json:"fluentbitOpensearchOutput,omitempty"`
+
+	// The Kubernetes Gateway API configuration. If enabled, the ingresses of the Verrazzano components and the
+	// ingress traits of applications are rendered as Gateway API resources.
+	// +optional
+	GatewayAPI *GatewayAPIComponent `json:"gatewayAPI,omitempty"`
+
+	// The Grafana component configuration.
+	// +optional
+	Grafana *GrafanaComponent `json:"grafana,omitempty"`
+
+	// The ingress NGINX component configuration.
+	// +optional
+	IngressNGINX *IngressNginxComponent `json:"ingressNGINX,omitempty"`
+
+	// The Istio component configuration.
+	// +optional
+	Istio *IstioComponent `json:"istio,omitempty"`
+
+	// The Jaeger Operator component configuration.
+	// +optional
+	JaegerOperator *JaegerOperatorComponent `json:"jaegerOperator,omitempty"`
+
+	// The Keycloak component configuration.
+	// +optional
+	Keycloak *KeycloakComponent `json:"keycloak,omitempty"`
+
+	// The Kiali component configuration.
+	// +optional
+	Kiali *KialiComponent `json:"kiali,omitempty"`
+
+	// The kube-state-metrics  component configuration.
+	// +optional
+	KubeStateMetrics *KubeStateMetricsComponent `json:"kubeStateMetrics,omitempty"`
+
+	// The MySQL Operator component configuration.
+	// +optional
+	MySQLOperator *MySQLOperatorComponent `json:"mySQLOperator,omitempty"`
+
+	// The OAM component configuration.
+	// +optional
+	OAM *OAMComponent `json:"oam,omitempty"`
+
+	// The OpenSearch component configuration.
+	// +optional
+	OpenSearch *OpenSearchComponent `json:"opensearch,omitempty"`
+
+	// The OpenSearch Dashboards component configuration.
+	// +optional
+	OpenSearchDashboards *OpenSearchDashboardsComponent `json:"opensearchDashboards,omitempty"`
+
+	// The Prometheus component configuration.
+	// +optional
+	Prometheus *PrometheusComponent `json:"prometheus,omitempty"`
+
+	// The Prometheus Adapter component configuration.
+	// +optional
+	PrometheusAdapter *PrometheusAdapterComponent `json:"prometheusAdapter,omitempty"`
+
+	// The Prometheus Node Exporter component configuration.
+	// +optional
+	PrometheusNodeExporter *PrometheusNodeExporterComponent `json:"prometheusNodeExporter,omitempty"`
+
+	// The Prometheus Operator component configuration.
+	// +optional
+	PrometheusOperator *PrometheusOperatorComponent `json:"prometheusOperator,omitempty"`
+
+	// The Prometheus Pushgateway component configuration.
+	// +optional
+	PrometheusPushgateway *PrometheusPushgatewayComponent `json:"prometheusPushgateway,omitempty"`
+
+	// The Rancher component configuration.
+	// +optional
+	Rancher *RancherComponent `json:"rancher,omitempty"`
+
+	// The rancherBackup component configuration.
+	// +optional
+	RancherBackup *RancherBackupComponent `json:"rancherBackup,omitempty"`
+
+	// The Thanos component configuration.
+	// +optional
+	Thanos *ThanosComponent `json:"thanos,omitempty"`
+
+	// The Velero component configuration.
+	// +optional
+	Velero *VeleroComponent `json:"velero,omitempty"`
+
+	// The Verrazzano component configuration.
+	// +optional
+	Verrazzano *VerrazzanoComponent `json:"verrazzano,omitempty"`
+
+	// The WebLogic Kubernetes Operator component configuration.
+	// +optional
+	WebLogicOperator *WebLogicOperatorComponent `json:"weblogicOperator,omitempty"`
+}
+
+type FluentbitOpensearchOutputComponent struct {
+	// If true, then the FluentbitOpensearchOutput will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/fluentbit-opensearch-output/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// OpenSearchComponent specifies the OpenSearch configuration.
+type OpenSearchComponent struct {
+	// If true, then OpenSearch will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// A list of OpenSearch node groups. For sample usage, see
+	// <a href="../../../docs/observability/logging/configure-opensearch/opensearch/">Customize OpenSearch</a>.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	Nodes []OpenSearchNode `json:"nodes,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+	// A list of <a href="https://opensearch.org/docs/2.3/im-plugin/ism/index/">Index State Management</a> policies
+	// to enable on OpenSearch.
+	// +optional
+	Policies []vmov1.IndexManagementPolicy `json:"policies,omitempty"`
+	// Enable to add 3rd Party / Custom plugins not offered in the default OpenSearch image.
+	// +optional
+	Plugins vmov1.OpenSearchPlugins `json:"plugins,omitempty"`
+	// To disable the default ISM policies.
+	DisableDefaultPolicy bool `json:"disableDefaultPolicy,omitempty"`
+	// The snapshot repository, schedule and retention of the OpenSearch cluster.
+	// +optional
+	Snapshots *OpenSearchSnapshots `json:"snapshots,omitempty"`
+}
+
+// OpenSearchSnapshots specifies the snapshots of the OpenSearch cluster.
+type OpenSearchSnapshots struct {
+	// The repository storing the snapshots.
+	Repository OpenSearchSnapshotRepository `json:"repository"`
+	// The maximum number of snapshots kept in the repository. The oldest snapshots beyond this count are deleted on
+	// the snapshot schedule. If not specified, then snapshots are not deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
+	// The cron expression, in UTC, of the snapshot schedule, for example `0 2 * * *`. If not specified, then snapshots
+	// are not created automatically.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// OpenSearchSnapshotRepositoryType identifies the storage of an OpenSearch snapshot repository.
+// +kubebuilder:validation:Enum=fs;s3
+type OpenSearchSnapshotRepositoryType string
+
+const (
+	// SnapshotRepositoryFS is a repository on a shared file system mounted on all the OpenSearch nodes
+	SnapshotRepositoryFS OpenSearchSnapshotRepositoryType = "fs"
+	// SnapshotRepositoryS3 is a repository in an S3-compatible object storage
+	SnapshotRepositoryS3 OpenSearchSnapshotRepositoryType = "s3"
+)
+
+// OpenSearchSnapshotRepository specifies an OpenSearch snapshot repository. An `s3` repository requires the
+// `repository-s3` plugin, and the credentials of the object storage in the OpenSearch keystore.
+type OpenSearchSnapshotRepository struct {
+	// For an `s3` repository, the path of the snapshots in the bucket.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+	// For an `s3` repository, the name of the bucket.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// For an `s3` repository, the endpoint of the object storage, for example the address of a MinIO server. If not
+	// specified, then the AWS S3 endpoint of the region is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// For an `fs` repository, the path of the shared file system. The path must be mounted on all the OpenSearch
+	// nodes and listed in the `path.repo` setting.
+	// +optional
+	Location string `json:"location,omitempty"`
+	// The name of the repository. The default value is `verrazzano-snapshots`.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// For an `s3` repository, if true, then the bucket is accessed with path-style URLs, as required by MinIO.
+	// +optional
+	PathStyleAccess bool `json:"pathStyleAccess,omitempty"`
+	// For an `s3` repository, the region of the bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// The type of the repository: `fs` for a shared file system or `s3` for an S3-compatible object storage.
+	Type OpenSearchSnapshotRepositoryType `json:"type"`
+}
+
+// ThanosStatus is the status of Thanos.
+type ThanosStatus struct {
+	// The time of the last scheduled run of the Thanos compactor.
+	CompactorLastScheduleTime string `json:"compactorLastScheduleTime,omitempty"`
+	// The time of the last successful run of the Thanos compactor.
+	CompactorLastSuccessfulTime string `json:"compactorLastSuccessfulTime,omitempty"`
+}
+
+// OpenSearchSnapshotStatus reports the snapshots of the OpenSearch cluster.
+type OpenSearchSnapshotStatus struct {
+	// The name of the most recent snapshot.
+	LastSnapshot string `json:"lastSnapshot,omitempty"`
+	// The state of the most recent snapshot, as reported by OpenSearch: `SUCCESS`, `IN_PROGRESS`, `PARTIAL` or `FAILED`.
+	LastSnapshotState string `json:"lastSnapshotState,omitempty"`
+	// The start time of the most recent snapshot.
+	LastSnapshotTime string `json:"lastSnapshotTime,omitempty"`
+	// The error that prevented the snapshots from being configured or queried, if any.
+	Message string `json:"message,omitempty"`
+	// The name of the snapshot repository.
+	Repository string `json:"repository,omitempty"`
+	// The number of snapshots in the repository.
+	SnapshotCount int `json:"snapshotCount,omitempty"`
+	// The time the snapshots were last queried.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// OpenSearchNode specifies a node group in the OpenSearch cluster.
+type OpenSearchNode struct {
+	// Name of the node group.
+	Name string `json:"name,omitempty"`
+	// Node group replica count.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Kubernetes container resources for nodes in the node group.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Role or roles that nodes in the group will assume: may be `master`, `data`, and/or `ingest`.
+	Roles []vmov1.NodeRole `json:"roles,omitempty"`
+	// Storage settings for the node group.
+	// +optional
+	Storage *OpenSearchNodeStorage `json:"storage,omitempty"`
+	// JavaOpts settings for the OpenSearch JVM.
+	// +optional
+	JavaOpts string `json:"javaOpts,omitempty"`
+}
+
+type OpenSearchNodeStorage struct {
+	// Node group storage size expressed as a
+	// <a href="https://kubernetes.io/docs/reference/kubernetes-api/common-definitions/quantity/#Quantity">Quantity</a>.
+	Size string `json:"size"`
+}
+
+// OpenSearchDashboardsComponent specifies the OpenSearch Dashboards configuration.
+type OpenSearchDashboardsComponent struct {
+	// If true, then OpenSearch Dashboards will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The number of pods to replicate. The default is `1`.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Enable to add 3rd Party / Custom plugins not offered in the default OpenSearch-Dashboard image
+	// +optional
+	Plugins vmov1.OpenSearchDashboardsPlugins `json:"plugins,omitempty"`
+}
+
+// KubeStateMetricsComponent specifies the kube-state-metrics configuration.
+type KubeStateMetricsComponent struct {
+	// If true, then kube-state-metrics will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/prometheus-community/kube-state-metrics/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// DatabaseInfo specifies the database connection information for the Grafana DB instance.
+type DatabaseInfo struct {
+	// The host of the database.
+	Host string `json:"host,omitempty"`
+	// The name of the database.
+	Name string `json:"name,omitempty"`
+}
+
+// GrafanaComponent specifies the Grafana configuration.
+type GrafanaComponent struct {
+	// The information to configure a connection to an external Grafana database.
+	// +optional
+	Database *DatabaseInfo `json:"database,omitempty"`
+	// If true, then Grafana will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The number of pods to replicate. The default is `1`.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// The SMTP notification settings.
+	// +optional
+	SMTP *vmov1.SMTPInfo `json:"smtp,omitempty"`
+}
+
+// PrometheusComponent specifies the Prometheus configuration.
+type PrometheusComponent struct {
+	// If true, then Prometheus will be installed.
+	// This is a legacy setting; the preferred way to configure Prometheus is using the
+	// [PrometheusOperatorComponent](#install.verrazzano.io/v1beta1.PrometheusOperatorComponent).
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// PrometheusAdapterComponent specifies the Prometheus Adapter configuration.
+type PrometheusAdapterComponent struct {
+	// If true, then Prometheus Adaptor will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/prometheus-community/prometheus-adapter/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// PrometheusNodeExporterComponent specifies the Prometheus Node Exporter configuration.
+type PrometheusNodeExporterComponent struct {
+	// If true, then Prometheus Node Exporter will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/prometheus-community/prometheus-node-exporter/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// PrometheusOperatorComponent specifies the Prometheus Operator configuration.
+type PrometheusOperatorComponent struct {
+	// If true, then Prometheus Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/prometheus-community/kube-prometheus-stack/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// PrometheusPushgatewayComponent specifies the Prometheus Pushgateway configuration.
+type PrometheusPushgatewayComponent struct {
+	// If true, then Prometheus Pushgateway will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/prometheus-community/prometheus-pushgateway/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ClusterAPIComponent specifies the Cluster API configuration.
+type ClusterAPIComponent struct {
+	// If true, then Cluster API Providers will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Overrides are merged together, but in the event of conflicting fields, the last override in the list
+	// takes precedence over any others. You can find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/overrides/cluster-api-values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ClusterIssuerComponent configures the Verrazzano ClusterIssuer
+type ClusterIssuerComponent struct {
+	// Enabled indicates that Verrazzano ClusterIssuer shall be configured
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The clusterResourceNamespace configured for the Verrazzano Cert-Manager instance; if an externally-managed
+	// Cert-Manager is being used with a non-default location, this should point to the clusterResourceNamespace used by
+	// that installation. See the Cert-Manager documentation details on this namespace.
+	// +kubebuilder:default=cert-manager
+	ClusterResourceNamespace string `json:"clusterResourceNamespace,omitempty"`
+	// IssuerConfig contains the configuration for the Verrazzano Cert-Manager ClusterIssuer
+	IssuerConfig `json:",inline"`
+}
+
+// CertManagerWebhookOCIComponent configures the CertManager OCI DNS solver webhook; the
+// webhook is required for LetsEncrypt Certificates using OCI DNS
+type CertManagerWebhookOCIComponent struct {
+	// Enabled will deploy the webhook if true, or if the LetsEncrypt issuer is configured with OCI DNS
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-cert-manager-ocidns-webhook/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// CertManagerComponent specifies the cert-manager configuration.
+type CertManagerComponent struct {
+	// If true, then cert-manager will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/cert-manager/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ClusterAgentComponent configures the Cluster Agent
+type ClusterAgentComponent struct {
+	// If true, then Cluster Agent will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-cluster-agent/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// CoherenceOperatorComponent specifies the Coherence Operator configuration.
+type CoherenceOperatorComponent struct {
+	// If true, then the Coherence Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/coherence-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ApplicationOperatorComponent specifies the Application Operator configuration.
+type ApplicationOperatorComponent struct {
+	// If true, then the Application Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-application-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// AuthProxyComponent specifies the AuthProxy configuration.
+type AuthProxyComponent struct {
+	// If true, then AuthProxy will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-authproxy/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// OAMComponent specifies the OAM configuration.
+type OAMComponent struct {
+	// If true, then OAM will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/oam-kubernetes-runtime/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// VerrazzanoComponent specifies the Verrazzano configuration.
+type VerrazzanoComponent struct {
+	// If true, then Verrazzano will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ClusterOperatorComponent specifies the Cluster Operator configuration.
+type ClusterOperatorComponent struct {
+	// If true, then the Cluster Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-cluster-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// KialiComponent specifies the Kiali configuration.
+type KialiComponent struct {
+	// If true, then Kiali will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/kiali-server/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ConsoleComponent specifies the Verrazzano Console configuration.
+type ConsoleComponent struct {
+	// If true, then Verrazzano Console will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-console/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+type DNSConfig DNSComponent
+
+// DNSComponent specifies the DNS configuration.
+type DNSComponent struct {
+	// External DNS configuration.
+	// +optional
+	External *External `json:"external,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/external-dns/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// Oracle Cloud Infrastructure DNS configuration.
+	// +optional
+	OCI *OCI `json:"oci,omitempty"`
+	// RFC2136 DNS configuration, for DNS servers such as BIND that accept dynamic updates authenticated with a
+	// TSIG key.
+	// +optional
+	RFC2136 *RFC2136 `json:"rfc2136,omitempty"`
+	// Wildcard DNS configuration. This is the default with a domain of nip.io.
+	// +optional
+	Wildcard *Wildcard `json:"wildcard,omitempty"`
+}
+
+// GatewayAPIComponent specifies the Kubernetes Gateway API configuration.
+type GatewayAPIComponent struct {
+	// If true, then the ingresses of the Verrazzano components and the ingress traits of applications are rendered
+	// as Gateway API `Gateway`, `HTTPRoute` and `ReferenceGrant` resources, which are served by the Istio ingress
+	// gateway. The Gateway API CRDs must be installed in the cluster. The default is `false`.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The name of the GatewayClass of the generated gateways. The default is `istio`.
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+}
+
+// IngressNginxComponent specifies the ingress NGINX configuration.
+type IngressNginxComponent struct {
+	// If true, then ingress NGINX will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Name of the ingress class used by the ingress controller. Defaults to `verrazzano-nginx`.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/ingress-nginx/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// The list of port configurations used by the ingress.
+	// +optional
+	Ports []corev1.ServicePort `json:"ports,omitempty"`
+	// The ingress type. Valid values are `LoadBalancer` and `NodePort`. The default value is `LoadBalancer`. If the ingress
+	// type is `NodePort`, then a valid and accessible IP address must be specified using the `controller.service.externalIPs`
+	// key in the [InstallOverrides](#install.verrazzano.io/v1beta1.InstallOverrides). For sample usage, see
+	// <a href="../../../docs/networking/traffic/externallbs/">External Load Balancers</a>.
+	// +optional
+	Type IngressType `json:"type,omitempty"`
+}
+
+// IstioComponent specifies the Istio configuration.
+type IstioComponent struct {
+	// If true, then Istio will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Istio sidecar injection enabled for installed components.  Default is `true`.
+	// +optional
+	InjectionEnabled *bool `json:"injectionEnabled,omitempty"`
+	// List of Overrides for default IstioOperator. Overrides are merged together, but in the event of conflicting
+	// fields, the last override in the list takes precedence over any others. You can find all possible values
+	// <a href="https://istio.io/v1.13/docs/reference/config/istio.operator.v1alpha1/#IstioOperatorSpec">here</a>
+	// Passing through an invalid IstioOperator resource will result in an error.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// IsInjectionEnabled is istio sidecar injection enabled check.
+func (c *IstioComponent) IsInjectionEnabled() bool {
+	if c.Enabled == nil || *c.Enabled {
+		return c.InjectionEnabled == nil || *c.InjectionEnabled
+	}
+	return c.InjectionEnabled != nil && *c.InjectionEnabled
+}
+
+// JaegerOperatorComponent specifies the Jaeger Operator configuration.
+type JaegerOperatorComponent struct {
+	// If true, then Jaeger Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/jaegertracing/jaeger-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// KeycloakComponent specifies the Keycloak configuration.
+type KeycloakComponent struct {
+	// If true, then Keycloak will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/keycloak/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// Contains the MySQL component configuration needed for Keycloak.
+	// +optional
+	MySQL MySQLComponent `json:"mysql,omitempty"`
+}
+
+// MySQLComponent specifies the MySQL configuration.
+type MySQLComponent struct {
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/mysql/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// Defines the type of volume to be used for persistence for Keycloak/MySQL, and can be one of either
+	// EmptyDirVolumeSource or PersistentVolumeClaimVolumeSource. If PersistentVolumeClaimVolumeSource is declared,
+	// then the `claimName` must reference the name of a `VolumeClaimSpecTemplate` declared in the
+	// `volumeClaimSpecTemplates` section.
+	// +optional
+	// +patchStrategy=replace
+	VolumeSource *corev1.VolumeSource `json:"volumeSource,omitempty" patchStrategy:"replace"`
+}
+
+// MySQLOperatorComponent specifies the MySQL Operator configuration.
+type MySQLOperatorComponent struct {
+	// If true, then MySQL Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/mysql-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// RancherComponent specifies the Rancher configuration.
+type RancherComponent struct {
+	// If true, then Rancher will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/rancher/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// KeycloakAuthEnabled specifies whether the Keycloak Auth provider is enabled.  Default is `false`.
+	// +optional
+	KeycloakAuthEnabled *bool `json:"keycloakAuthEnabled,omitempty"`
+}
+
+// ArgoCDComponent specifies the Argo CD configuration.
+type ArgoCDComponent struct {
+	// If true, then Argo CD will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/argo-cd/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// RancherBackupComponent specifies the rancherBackup configuration.
+type RancherBackupComponent struct {
+	// If true, then rancherBackup will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/rancher-backup/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// FluentdComponent specifies the Fluentd configuration.
+type FluentdComponent struct {
+	// If true, then Fluentd will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// A list of host path volume mounts, in addition to `/var/log`, into the Fluentd DaemonSet. The Fluentd component
+	// collects log files in the `/var/log/containers` directory of Kubernetes worker nodes. The `/var/log/containers`
+	// directory may contain symbolic links to files located outside the `/var/log` directory. If the host path
+	// directory containing the log files is located outside `/var/log`, the Fluentd DaemonSet must have the volume
+	// mount of that directory to collect the logs.
+	// +optional
+	// +patchStrategy=merge,retainKeys
+	ExtraVolumeMounts []VolumeMount `json:"extraVolumeMounts,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"source"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/helm_config/charts/verrazzano-fluentd/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// The Oracle Cloud Infrastructure Logging configuration.
+	// +optional
+	OCI *OciLoggingConfiguration `json:"oci,omitempty"`
+	// The secret containing the credentials for connecting to OpenSearch. This secret needs to be created in the
+	// `verrazzano-install` namespace prior to creating the Verrazzano custom resource. Specify the OpenSearch login
+	// credentials in the `username` and `password` fields in this secret. Specify the CA for verifying the OpenSearch
+	// certificate in the `ca-bundle` field, if applicable. The default `verrazzano` is the secret for connecting to
+	// the VMI OpenSearch.
+	// +optional
+	OpenSearchSecret string `json:"opensearchSecret,omitempty"`
+	// The target OpenSearch URLs.
+	// Specify this option in this <a href="https://docs.fluentd.org/output/opensearch#hosts-optional">format</a>.
+	// The default `http://vmi-system-es-ingest-oidc:8775` is the VMI OpenSearch URL.
+	// +optional
+	OpenSearchURL string `json:"opensearchURL,omitempty"`
+}
+
+// WebLogicOperatorComponent specifies the WebLogic Kubernetes Operator configuration.
+type WebLogicOperatorComponent struct {
+	// If true, then the WebLogic Kubernetes Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/weblogic-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// FluentOperatorComponent specifies the Fluent Operator configuration.
+type FluentOperatorComponent struct {
+	// If true, then the Fluent Operator will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/fluent-operator/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// VeleroComponent specifies the Velero configuration.
+type VeleroComponent struct {
+	// If true, then Velero will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/velero/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+}
+
+// ThanosComponent specifies the Thanos configuration.
+type ThanosComponent struct {
+	// The Thanos compactor configuration. The compactor requires an object store.
+	// +optional
+	Compactor *ThanosCompactor `json:"compactor,omitempty"`
+	// If true, then Thanos will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// List of Overrides for the default `values.yaml` file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others. You can
+	// find all possible values
+	// [here]( {{% release_source_url path=platform-operator/thirdparty/charts/thanos/values.yaml %}} )
+	// and invalid values will be ignored.
+	// +optional
+	InstallOverrides `json:",inline"`
+	// The object store keeping the metrics for the long term. The Prometheus Thanos sidecar uploads the metrics blocks
+	// to the object store, and the Thanos store gateway serves them to Thanos Query.
+	// +optional
+	ObjectStore *ThanosObjectStore `json:"objectStore,omitempty"`
+	// The Thanos store gateway configuration. The store gateway requires an object store.
+	// +optional
+	StoreGateway *ThanosStoreGateway `json:"storeGateway,omitempty"`
+}
+
+// ThanosObjectStore identifies the secret holding the Thanos object store configuration.
+type ThanosObjectStore struct {
+	// The key of the object store configuration in the secret. The default is `objstore.yml`.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// The name of the secret holding the object store configuration, in the `verrazzano-monitoring` namespace. The
+	// configuration format is described in the [Thanos documentation](https://thanos.io/tip/thanos/storage.md/).
+	SecretName string `json:"secretName"`
+}
+
+// ThanosCompactor specifies the Thanos compactor configuration. The compactor runs as a CronJob, compacting and
+// downsampling the metrics blocks of the object store, and deleting the blocks beyond their retention.
+type ThanosCompactor struct {
+	// If true, then the metrics blocks are downsampled to the 5m and 1h resolutions. The default is `true`.
+	// +optional
+	Downsampling *bool `json:"downsampling,omitempty"`
+	// If true, then the Thanos compactor will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The retention of the metrics blocks per resolution.
+	// +optional
+	Retention ThanosRetention `json:"retention,omitempty"`
+	// The schedule of the compactor runs, in Cron format. The default is every six hours.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// ThanosRetention specifies how long the metrics blocks are kept per resolution, as a duration such as `30d` or `10y`.
+// A duration of `0d` keeps the blocks forever.
+type ThanosRetention struct {
+	// The retention of the raw metrics blocks. The default is `30d`.
+	// +optional
+	Raw string `json:"raw,omitempty"`
+	// The retention of the metrics blocks downsampled to a 1h resolution. The default is `10y`.
+	// +optional
+	Resolution1h string `json:"resolution1h,omitempty"`
+	// The retention of the metrics blocks downsampled to a 5m resolution. The default is `30d`.
+	// +optional
+	Resolution5m string `json:"resolution5m,omitempty"`
+}
+
+// ThanosStoreGateway specifies the Thanos store gateway configuration.
+type ThanosStoreGateway struct {
+	// If true, then the Thanos store gateway will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The number of store gateway shards. Each shard is a StatefulSet serving a hash partition of the metrics blocks.
+	// The default is a single store gateway serving all the blocks.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Shards *int32 `json:"shards,omitempty"`
+}
+
+// VolumeMount defines a hostPath type Volume mount.
+type VolumeMount struct {
+	// The destination path on the Fluentd Container, defaults to the source host path.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// Specifies if the volume mount is read-only, defaults to `true`.
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+	// The source host path.
+	Source string `json:"source"`
+}
+
+// LetsEncryptAcmeIssuer identifies the configuration used for the LetsEncrypt cert issuer
+type LetsEncryptACMEIssuer struct {
+	// Email address of the user.
+	// +optional
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// Environment can be "staging" or "production"
+	// +optional
+	Environment string `json:"environment,omitempty"`
+}
+
+// CAIssuer Identifies the configuration used for the Certificate Authority issuer
+type CAIssuer struct {
+	// The secret name.
+	SecretName string `json:"secretName"`
+}
+
+// ACMESolverType identifies the ACME challenge type solved by the ACME issuer
+type ACMESolverType string
+
+const (
+	// ACMESolverDNS01 solves DNS01 challenges with the managed DNS provider, either OCI or RFC2136 DNS
+	ACMESolverDNS01 ACMESolverType = "dns01"
+	// ACMESolverHTTP01 solves HTTP01 challenges with the Verrazzano ingress controller
+	ACMESolverHTTP01 ACMESolverType = "http01"
+)
+
+// ACMEIssuer identifies the configuration used for an ACME issuer with a custom ACME server, for example step-ca
+type ACMEIssuer struct {
+	// The name of the secret in the `clusterResourceNamespace` with the PEM encoded certificate of the private CA of
+	// the ACME server, in the `ca.crt` data key. It is required for ACME servers with a private CA, for example step-ca,
+	// so that Rancher trusts the certificates issued by the ACME server.
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// Email address of the user.
+	// +optional
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// The External Account Binding of the ACME account, required by some ACME servers.
+	// +optional
+	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+	// The URL of the ACME server directory.
+	Server string `json:"server"`
+	// Skip the verification of the TLS certificate of the ACME server, for ACME servers with a private CA.
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+	// The ACME challenge type, either "dns01" or "http01". The default is "dns01", which requires OCI or RFC2136 DNS.
+	// +optional
+	Solver ACMESolverType `json:"solver,omitempty"`
+}
+
+// ACMEExternalAccountBinding identifies the External Account Binding of an ACME account
+type ACMEExternalAccountBinding struct {
+	// The key ID of the account in the ACME server.
+	KeyID string `json:"keyID"`
+	// The name of the secret in the `clusterResourceNamespace` with the base64url encoded HMAC key of the account,
+	// in the `secret` data key.
+	KeySecretName string `json:"keySecretName"`
+}
+
+// VaultIssuer identifies the configuration used for a HashiCorp Vault PKI issuer
+type VaultIssuer struct {
+	// The AppRole authentication configuration.
+	// +optional
+	AppRole *VaultAppRoleAuth `json:"appRole,omitempty"`
+	// The name of the secret in the `clusterResourceNamespace` with the PEM encoded CA certificate of the Vault server,
+	// in the `ca.crt` data key. The CA certificate is also trusted by Rancher, so it must include the CA of the
+	// certificates issued by the Vault PKI when that CA is not the CA of the Vault server.
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// The Kubernetes service account authentication configuration.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+	// The Vault Enterprise namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The path of the Vault PKI signing endpoint, for example `pki_int/sign/verrazzano`.
+	Path string `json:"path"`
+	// The address of the Vault server, for example `https://vault.example.com:8200`.
+	Server string `json:"server"`
+}
+
+// VaultKubernetesAuth identifies the Vault Kubernetes authentication configuration. Verrazzano authenticates with
+// the token of the `verrazzano-vault-issuer` service account in the `clusterResourceNamespace`.
+type VaultKubernetesAuth struct {
+	// The mount path of the Vault Kubernetes authentication method. The default is `/v1/auth/kubernetes`.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// The Vault role bound to the service account.
+	Role string `json:"role"`
+}
+
+// VaultAppRoleAuth identifies the Vault AppRole authentication configuration
+type VaultAppRoleAuth struct {
+	// The mount path of the Vault AppRole authentication method. The default is `approle`.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// The role ID of the AppRole.
+	RoleID string `json:"roleID"`
+	// The name of the secret in the `clusterResourceNamespace` with the secret ID of the AppRole, in the `secretId`
+	// data key.
+	SecretName string `json:"secretName"`
+}
+
+// IssuerConfig identifies the configuration for the Verrazzano ClusterIssuer.  Only one value may be set.
+type IssuerConfig struct {
+	// The LetsEncrypt issuer configuration.
+	// +optional
+	LetsEncrypt *LetsEncryptACMEIssuer `json:"letsEncrypt,omitempty"`
+	// The certificate configuration.
+	// +optional
+	CA *CAIssuer `json:"ca,omitempty"`
+	// The ACME issuer configuration, for ACME servers other than LetsEncrypt.
+	// +optional
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+	// The HashiCorp Vault issuer configuration.
+	// +optional
+	Vault *VaultIssuer `json:"vault,omitempty"`
+}
+
+// OciPrivateKeyFileName is the private key file name.
+const OciPrivateKeyFileName = "oci_api_key.pem"
+
+// OciConfigSecretFile is the name of the Oracle Cloud Infrastructure configuration yaml file.
+const OciConfigSecretFile = "oci.yaml"
+
+// Wildcard DNS type.
+type Wildcard struct {
+	// The type of wildcard DNS domain. For example, nip.io, sslip.io, and such.
+	Domain string `json:"domain"`
+}
+
+// OCI DNS type.
+type OCI struct {
+	// Scope of the Oracle Cloud Infrastructure DNS zone (`PRIVATE`, `GLOBAL`). If not specified, then defaults to `GLOBAL`.
+	// +optional
+	DNSScope string `json:"dnsScope,omitempty"`
+	// The Oracle Cloud Infrastructure DNS compartment OCID.
+	DNSZoneCompartmentOCID string `json:"dnsZoneCompartmentOCID"`
+	// The Oracle Cloud Infrastructure DNS zone OCID.
+	DNSZoneOCID string `json:"dnsZoneOCID"`
+	// Name of Oracle Cloud Infrastructure DNS zone.
+	DNSZoneName string `json:"dnsZoneName"`
+	// Name of the Oracle Cloud Infrastructure configuration secret. Generate a secret based on the
+	// Oracle Cloud Infrastructure configuration profile you want to use. You can specify a profile other than
+	// `DEFAULT` and specify the secret name. See instructions by running `./install/create_oci_config_secret.sh`.
+	OCIConfigSecret string `json:"ociConfigSecret"`
+}
+
+// RFC2136 DNS type.
+type RFC2136 struct {
+	// The host name or IP address of the DNS server.
+	Nameserver string `json:"nameserver"`
+	// The port of the DNS server. The default is 53.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Name of the DNS zone that is updated.
+	DNSZoneName string `json:"dnsZoneName"`
+	// Name of the TSIG key configured in the DNS server.
+	TSIGKeyName string `json:"tsigKeyName"`
+	// The TSIG algorithm (`hmac-md5`, `hmac-sha1`, `hmac-sha256`, `hmac-sha512`). The default is `hmac-sha256`.
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+	// Name of the secret in the `verrazzano-install` namespace that contains the base64-encoded TSIG key
+	// in the `secret` data key.
+	TSIGSecret string `json:"tsigSecret"`
+}
+
+// External DNS type.
+type External struct {
+	// The suffix for DNS names.
+	Suffix string `json:"suffix"`
+}
+
+// IngressType is the type of ingress.
+type IngressType string
+
+func init() {
+	SchemeBuilder.Register(&Verrazzano{}, &VerrazzanoList{})
+}
+
+// OciLoggingConfiguration is the Oracle Cloud Infrastructure logging configuration for Fluentd.
+type OciLoggingConfiguration struct {
+	// The name of the secret containing the Oracle Cloud Infrastructure API configuration and private key.
+	// +optional
+	APISecret string `json:"apiSecret,omitempty"`
+	// The OCID of the Oracle Cloud Infrastructure Log that will collect application logs.
+	DefaultAppLogID string `json:"defaultAppLogId"`
+	// The OCID of the Oracle Cloud Infrastructure Log that will collect system logs.
+	SystemLogID string `json:"systemLogId"`
+}
+
+// InstallOverrides are used to pass installation overrides to components.
+type InstallOverrides struct {
+	// If false, then Verrazzano updates will ignore any configuration changes to this component. Defaults to `true`.
+	// +optional
+	MonitorChanges *bool `json:"monitorChanges,omitempty"`
+	// List of overrides for the default values.yaml file for the component Helm chart. Overrides are merged together,
+	// but in the event of conflicting fields, the last override in the list takes precedence over any others.
+	// Invalid override values will be ignored.
+	// +optional
+	ValueOverrides []Overrides `json:"overrides,omitempty"`
+}
+
+// Overrides identifies overrides for a component.
+type Overrides struct {
+	// Selector for ConfigMap containing override data.
+	// For sample usage, see
+	// <a href="../../../docs/setup/installationoverrides/#configmap">ConfigMapRef</a>.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+	// Selector for Secret containing override data.
+	// For sample usage, see
+	// <a href="../../../docs/setup/installationoverrides/#secret">SecretRef</a>.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+	// Configure overrides using inline YAML.
+	// For sample usage, see
+	// <a href="../../../docs/setup/installationoverrides/#values">Values</a>.
+	// +optional
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+}
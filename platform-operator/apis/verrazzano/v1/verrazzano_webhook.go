@@ -0,0 +1,53 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager is used to let the controller manager know about the webhook
+func (v *Verrazzano) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(v).
+		Complete()
+}
+
+var _ webhook.Validator = &Verrazzano{}
+
+// ValidateCreate implements webhook.Validator, the resource is validated as v1beta1
+func (v *Verrazzano) ValidateCreate() error {
+	vz := &v1beta1.Verrazzano{}
+	if err := v.ConvertTo(vz); err != nil {
+		return err
+	}
+	return vz.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.Validator, the resources are validated as v1beta1
+func (v *Verrazzano) ValidateUpdate(old runtime.Object) error {
+	oldResource, ok := old.(*Verrazzano)
+	if !ok {
+		return fmt.Errorf("Couldn't convert old resource to a Verrazzano resource")
+	}
+	oldVz := &v1beta1.Verrazzano{}
+	if err := oldResource.ConvertTo(oldVz); err != nil {
+		return err
+	}
+	vz := &v1beta1.Verrazzano{}
+	if err := v.ConvertTo(vz); err != nil {
+		return err
+	}
+	return vz.ValidateUpdate(oldVz)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (v *Verrazzano) ValidateDelete() error {
+	return nil
+}
@@ -0,0 +1,2198 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	vmcontrollerv1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEExternalAccountBinding) DeepCopyInto(out *ACMEExternalAccountBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEExternalAccountBinding.
+func (in *ACMEExternalAccountBinding) DeepCopy() *ACMEExternalAccountBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEExternalAccountBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
+	*out = *in
+	if in.ExternalAccountBinding != nil {
+		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
+		*out = new(ACMEExternalAccountBinding)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuer.
+func (in *ACMEIssuer) DeepCopy() *ACMEIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationOperatorComponent) DeepCopyInto(out *ApplicationOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationOperatorComponent.
+func (in *ApplicationOperatorComponent) DeepCopy() *ApplicationOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRestartSpec) DeepCopyInto(out *ApplicationRestartSpec) {
+	*out = *in
+	if in.MaxConcurrentApplications != nil {
+		in, out := &in.MaxConcurrentApplications, &out.MaxConcurrentApplications
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxUnavailablePods != nil {
+		in, out := &in.MaxUnavailablePods, &out.MaxUnavailablePods
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRestartSpec.
+func (in *ApplicationRestartSpec) DeepCopy() *ApplicationRestartSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRestartSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRestartStatus) DeepCopyInto(out *ApplicationRestartStatus) {
+	*out = *in
+	if in.Deferred != nil {
+		in, out := &in.Deferred, &out.Deferred
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InProgress != nil {
+		in, out := &in.InProgress, &out.InProgress
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRestartStatus.
+func (in *ApplicationRestartStatus) DeepCopy() *ApplicationRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDComponent) DeepCopyInto(out *ArgoCDComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDComponent.
+func (in *ArgoCDComponent) DeepCopy() *ArgoCDComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthProxyComponent) DeepCopyInto(out *AuthProxyComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthProxyComponent.
+func (in *AuthProxyComponent) DeepCopy() *AuthProxyComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthProxyComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuer) DeepCopyInto(out *CAIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuer.
+func (in *CAIssuer) DeepCopy() *CAIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerComponent) DeepCopyInto(out *CertManagerComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerComponent.
+func (in *CertManagerComponent) DeepCopy() *CertManagerComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerWebhookOCIComponent) DeepCopyInto(out *CertManagerWebhookOCIComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerWebhookOCIComponent.
+func (in *CertManagerWebhookOCIComponent) DeepCopy() *CertManagerWebhookOCIComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerWebhookOCIComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAPIComponent) DeepCopyInto(out *ClusterAPIComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAPIComponent.
+func (in *ClusterAPIComponent) DeepCopy() *ClusterAPIComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAPIComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAgentComponent) DeepCopyInto(out *ClusterAgentComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAgentComponent.
+func (in *ClusterAgentComponent) DeepCopy() *ClusterAgentComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAgentComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIssuerComponent) DeepCopyInto(out *ClusterIssuerComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIssuerComponent.
+func (in *ClusterIssuerComponent) DeepCopy() *ClusterIssuerComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIssuerComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorComponent) DeepCopyInto(out *ClusterOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorComponent.
+func (in *ClusterOperatorComponent) DeepCopy() *ClusterOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoherenceOperatorComponent) DeepCopyInto(out *CoherenceOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoherenceOperatorComponent.
+func (in *CoherenceOperatorComponent) DeepCopy() *CoherenceOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CoherenceOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.ApplicationOperator != nil {
+		in, out := &in.ApplicationOperator, &out.ApplicationOperator
+		*out = new(ApplicationOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArgoCD != nil {
+		in, out := &in.ArgoCD, &out.ArgoCD
+		*out = new(ArgoCDComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthProxy != nil {
+		in, out := &in.AuthProxy, &out.AuthProxy
+		*out = new(AuthProxyComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(ClusterAPIComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAgent != nil {
+		in, out := &in.ClusterAgent, &out.ClusterAgent
+		*out = new(ClusterAgentComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterIssuer != nil {
+		in, out := &in.ClusterIssuer, &out.ClusterIssuer
+		*out = new(ClusterIssuerComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManagerWebhookOCI != nil {
+		in, out := &in.CertManagerWebhookOCI, &out.CertManagerWebhookOCI
+		*out = new(CertManagerWebhookOCIComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterOperator != nil {
+		in, out := &in.ClusterOperator, &out.ClusterOperator
+		*out = new(ClusterOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoherenceOperator != nil {
+		in, out := &in.CoherenceOperator, &out.CoherenceOperator
+		*out = new(CoherenceOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Console != nil {
+		in, out := &in.Console, &out.Console
+		*out = new(ConsoleComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fluentd != nil {
+		in, out := &in.Fluentd, &out.Fluentd
+		*out = new(FluentdComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FluentOperator != nil {
+		in, out := &in.FluentOperator, &out.FluentOperator
+		*out = new(FluentOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FluentbitOpensearchOutput != nil {
+		in, out := &in.FluentbitOpensearchOutput, &out.FluentbitOpensearchOutput
+		*out = new(FluentbitOpensearchOutputComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayAPI != nil {
+		in, out := &in.GatewayAPI, &out.GatewayAPI
+		*out = new(GatewayAPIComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Grafana != nil {
+		in, out := &in.Grafana, &out.Grafana
+		*out = new(GrafanaComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressNGINX != nil {
+		in, out := &in.IngressNGINX, &out.IngressNGINX
+		*out = new(IngressNginxComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Istio != nil {
+		in, out := &in.Istio, &out.Istio
+		*out = new(IstioComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JaegerOperator != nil {
+		in, out := &in.JaegerOperator, &out.JaegerOperator
+		*out = new(JaegerOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = new(KeycloakComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kiali != nil {
+		in, out := &in.Kiali, &out.Kiali
+		*out = new(KialiComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeStateMetrics != nil {
+		in, out := &in.KubeStateMetrics, &out.KubeStateMetrics
+		*out = new(KubeStateMetricsComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MySQLOperator != nil {
+		in, out := &in.MySQLOperator, &out.MySQLOperator
+		*out = new(MySQLOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAM != nil {
+		in, out := &in.OAM, &out.OAM
+		*out = new(OAMComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenSearch != nil {
+		in, out := &in.OpenSearch, &out.OpenSearch
+		*out = new(OpenSearchComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenSearchDashboards != nil {
+		in, out := &in.OpenSearchDashboards, &out.OpenSearchDashboards
+		*out = new(OpenSearchDashboardsComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusAdapter != nil {
+		in, out := &in.PrometheusAdapter, &out.PrometheusAdapter
+		*out = new(PrometheusAdapterComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusNodeExporter != nil {
+		in, out := &in.PrometheusNodeExporter, &out.PrometheusNodeExporter
+		*out = new(PrometheusNodeExporterComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusOperator != nil {
+		in, out := &in.PrometheusOperator, &out.PrometheusOperator
+		*out = new(PrometheusOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusPushgateway != nil {
+		in, out := &in.PrometheusPushgateway, &out.PrometheusPushgateway
+		*out = new(PrometheusPushgatewayComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rancher != nil {
+		in, out := &in.Rancher, &out.Rancher
+		*out = new(RancherComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RancherBackup != nil {
+		in, out := &in.RancherBackup, &out.RancherBackup
+		*out = new(RancherBackupComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Thanos != nil {
+		in, out := &in.Thanos, &out.Thanos
+		*out = new(ThanosComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Velero != nil {
+		in, out := &in.Velero, &out.Velero
+		*out = new(VeleroComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verrazzano != nil {
+		in, out := &in.Verrazzano, &out.Verrazzano
+		*out = new(VerrazzanoComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebLogicOperator != nil {
+		in, out := &in.WebLogicOperator, &out.WebLogicOperator
+		*out = new(WebLogicOperatorComponent)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatusDetails) DeepCopyInto(out *ComponentStatusDetails) {
+	*out = *in
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		*out = new(ComponentAvailability)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusDetails.
+func (in *ComponentStatusDetails) DeepCopy() *ComponentStatusDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatusDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ComponentStatusMap) DeepCopyInto(out *ComponentStatusMap) {
+	{
+		in := &in
+		*out = make(ComponentStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal *ComponentStatusDetails
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ComponentStatusDetails)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusMap.
+func (in ComponentStatusMap) DeepCopy() ComponentStatusMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatusMap)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleComponent) DeepCopyInto(out *ConsoleComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleComponent.
+func (in *ConsoleComponent) DeepCopy() *ConsoleComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationSpec) DeepCopyInto(out *CredentialRotationSpec) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]CredentialName, len(*in))
+		copy(*out, *in)
+	}
+	if in.IntervalDays != nil {
+		in, out := &in.IntervalDays, &out.IntervalDays
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationSpec.
+func (in *CredentialRotationSpec) DeepCopy() *CredentialRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
+func (in *CredentialStatus) DeepCopy() *CredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSComponent) DeepCopyInto(out *DNSComponent) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(External)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCI)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136)
+		**out = **in
+	}
+	if in.Wildcard != nil {
+		in, out := &in.Wildcard, &out.Wildcard
+		*out = new(Wildcard)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSComponent.
+func (in *DNSComponent) DeepCopy() *DNSComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(External)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCI)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136)
+		**out = **in
+	}
+	if in.Wildcard != nil {
+		in, out := &in.Wildcard, &out.Wildcard
+		*out = new(Wildcard)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSConfig.
+func (in *DNSConfig) DeepCopy() *DNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInfo) DeepCopyInto(out *DatabaseInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInfo.
+func (in *DatabaseInfo) DeepCopy() *DatabaseInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *External) DeepCopyInto(out *External) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new External.
+func (in *External) DeepCopy() *External {
+	if in == nil {
+		return nil
+	}
+	out := new(External)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentOperatorComponent) DeepCopyInto(out *FluentOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentOperatorComponent.
+func (in *FluentOperatorComponent) DeepCopy() *FluentOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentbitOpensearchOutputComponent) DeepCopyInto(out *FluentbitOpensearchOutputComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentbitOpensearchOutputComponent.
+func (in *FluentbitOpensearchOutputComponent) DeepCopy() *FluentbitOpensearchOutputComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentbitOpensearchOutputComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentdComponent) DeepCopyInto(out *FluentdComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OciLoggingConfiguration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentdComponent.
+func (in *FluentdComponent) DeepCopy() *FluentdComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentdComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPIComponent) DeepCopyInto(out *GatewayAPIComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIComponent.
+func (in *GatewayAPIComponent) DeepCopy() *GatewayAPIComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPIComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaComponent) DeepCopyInto(out *GrafanaComponent) {
+	*out = *in
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DatabaseInfo)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(vmcontrollerv1.SMTPInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaComponent.
+func (in *GrafanaComponent) DeepCopy() *GrafanaComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressNginxComponent) DeepCopyInto(out *IngressNginxComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressNginxComponent.
+func (in *IngressNginxComponent) DeepCopy() *IngressNginxComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressNginxComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallOverrides) DeepCopyInto(out *InstallOverrides) {
+	*out = *in
+	if in.MonitorChanges != nil {
+		in, out := &in.MonitorChanges, &out.MonitorChanges
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValueOverrides != nil {
+		in, out := &in.ValueOverrides, &out.ValueOverrides
+		*out = make([]Overrides, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallOverrides.
+func (in *InstallOverrides) DeepCopy() *InstallOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceInfo) DeepCopyInto(out *InstanceInfo) {
+	*out = *in
+	if in.ArgoCDURL != nil {
+		in, out := &in.ArgoCDURL, &out.ArgoCDURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConsoleURL != nil {
+		in, out := &in.ConsoleURL, &out.ConsoleURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.GrafanaURL != nil {
+		in, out := &in.GrafanaURL, &out.GrafanaURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.JaegerURL != nil {
+		in, out := &in.JaegerURL, &out.JaegerURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyCloakURL != nil {
+		in, out := &in.KeyCloakURL, &out.KeyCloakURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.KialiURL != nil {
+		in, out := &in.KialiURL, &out.KialiURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.OpenSearchDashboardsURL != nil {
+		in, out := &in.OpenSearchDashboardsURL, &out.OpenSearchDashboardsURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.OpenSearchURL != nil {
+		in, out := &in.OpenSearchURL, &out.OpenSearchURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrometheusURL != nil {
+		in, out := &in.PrometheusURL, &out.PrometheusURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.RancherURL != nil {
+		in, out := &in.RancherURL, &out.RancherURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ThanosQueryURL != nil {
+		in, out := &in.ThanosQueryURL, &out.ThanosQueryURL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceInfo.
+func (in *InstanceInfo) DeepCopy() *InstanceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
+	*out = *in
+	if in.LetsEncrypt != nil {
+		in, out := &in.LetsEncrypt, &out.LetsEncrypt
+		*out = new(LetsEncryptACMEIssuer)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		**out = **in
+	}
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(ACMEIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerConfig.
+func (in *IssuerConfig) DeepCopy() *IssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioComponent) DeepCopyInto(out *IstioComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InjectionEnabled != nil {
+		in, out := &in.InjectionEnabled, &out.InjectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioComponent.
+func (in *IstioComponent) DeepCopy() *IstioComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerOperatorComponent) DeepCopyInto(out *JaegerOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerOperatorComponent.
+func (in *JaegerOperatorComponent) DeepCopy() *JaegerOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakComponent) DeepCopyInto(out *KeycloakComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	in.MySQL.DeepCopyInto(&out.MySQL)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponent.
+func (in *KeycloakComponent) DeepCopy() *KeycloakComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KialiComponent) DeepCopyInto(out *KialiComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KialiComponent.
+func (in *KialiComponent) DeepCopy() *KialiComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KialiComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeStateMetricsComponent) DeepCopyInto(out *KubeStateMetricsComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeStateMetricsComponent.
+func (in *KubeStateMetricsComponent) DeepCopy() *KubeStateMetricsComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeStateMetricsComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LetsEncryptACMEIssuer) DeepCopyInto(out *LetsEncryptACMEIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LetsEncryptACMEIssuer.
+func (in *LetsEncryptACMEIssuer) DeepCopy() *LetsEncryptACMEIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(LetsEncryptACMEIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLComponent) DeepCopyInto(out *MySQLComponent) {
+	*out = *in
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(corev1.VolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLComponent.
+func (in *MySQLComponent) DeepCopy() *MySQLComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLOperatorComponent) DeepCopyInto(out *MySQLOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLOperatorComponent.
+func (in *MySQLOperatorComponent) DeepCopy() *MySQLOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAMComponent) DeepCopyInto(out *OAMComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAMComponent.
+func (in *OAMComponent) DeepCopy() *OAMComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(OAMComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCI) DeepCopyInto(out *OCI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCI.
+func (in *OCI) DeepCopy() *OCI {
+	if in == nil {
+		return nil
+	}
+	out := new(OCI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OciLoggingConfiguration) DeepCopyInto(out *OciLoggingConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OciLoggingConfiguration.
+func (in *OciLoggingConfiguration) DeepCopy() *OciLoggingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(OciLoggingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchComponent) DeepCopyInto(out *OpenSearchComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]OpenSearchNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]vmcontrollerv1.IndexManagementPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Plugins.DeepCopyInto(&out.Plugins)
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(OpenSearchSnapshots)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchComponent.
+func (in *OpenSearchComponent) DeepCopy() *OpenSearchComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsComponent) DeepCopyInto(out *OpenSearchDashboardsComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Plugins.DeepCopyInto(&out.Plugins)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsComponent.
+func (in *OpenSearchDashboardsComponent) DeepCopy() *OpenSearchDashboardsComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNode) DeepCopyInto(out *OpenSearchNode) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]vmcontrollerv1.NodeRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(OpenSearchNodeStorage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNode.
+func (in *OpenSearchNode) DeepCopy() *OpenSearchNode {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNodeStorage) DeepCopyInto(out *OpenSearchNodeStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNodeStorage.
+func (in *OpenSearchNodeStorage) DeepCopy() *OpenSearchNodeStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNodeStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotRepository) DeepCopyInto(out *OpenSearchSnapshotRepository) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotRepository.
+func (in *OpenSearchSnapshotRepository) DeepCopy() *OpenSearchSnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotStatus) DeepCopyInto(out *OpenSearchSnapshotStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotStatus.
+func (in *OpenSearchSnapshotStatus) DeepCopy() *OpenSearchSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshots) DeepCopyInto(out *OpenSearchSnapshots) {
+	*out = *in
+	out.Repository = in.Repository
+	if in.RetentionCount != nil {
+		in, out := &in.RetentionCount, &out.RetentionCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshots.
+func (in *OpenSearchSnapshots) DeepCopy() *OpenSearchSnapshots {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshots)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Overrides) DeepCopyInto(out *Overrides) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Overrides.
+func (in *Overrides) DeepCopy() *Overrides {
+	if in == nil {
+		return nil
+	}
+	out := new(Overrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAdapterComponent) DeepCopyInto(out *PrometheusAdapterComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAdapterComponent.
+func (in *PrometheusAdapterComponent) DeepCopy() *PrometheusAdapterComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAdapterComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusComponent) DeepCopyInto(out *PrometheusComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusComponent.
+func (in *PrometheusComponent) DeepCopy() *PrometheusComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusNodeExporterComponent) DeepCopyInto(out *PrometheusNodeExporterComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusNodeExporterComponent.
+func (in *PrometheusNodeExporterComponent) DeepCopy() *PrometheusNodeExporterComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusNodeExporterComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOperatorComponent) DeepCopyInto(out *PrometheusOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorComponent.
+func (in *PrometheusOperatorComponent) DeepCopy() *PrometheusOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusPushgatewayComponent) DeepCopyInto(out *PrometheusPushgatewayComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusPushgatewayComponent.
+func (in *PrometheusPushgatewayComponent) DeepCopy() *PrometheusPushgatewayComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusPushgatewayComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136) DeepCopyInto(out *RFC2136) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136.
+func (in *RFC2136) DeepCopy() *RFC2136 {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RancherBackupComponent) DeepCopyInto(out *RancherBackupComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherBackupComponent.
+func (in *RancherBackupComponent) DeepCopy() *RancherBackupComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherBackupComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RancherComponent) DeepCopyInto(out *RancherComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.KeycloakAuthEnabled != nil {
+		in, out := &in.KeycloakAuthEnabled, &out.KeycloakAuthEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherComponent.
+func (in *RancherComponent) DeepCopy() *RancherComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationRecord) DeepCopyInto(out *RemediationRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationRecord.
+func (in *RemediationRecord) DeepCopy() *RemediationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
+	*out = *in
+	if in.AdminSubjects != nil {
+		in, out := &in.AdminSubjects, &out.AdminSubjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(CredentialRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitorSubjects != nil {
+		in, out := &in.MonitorSubjects, &out.MonitorSubjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
+func (in *SecuritySpec) DeepCopy() *SecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosCompactor) DeepCopyInto(out *ThanosCompactor) {
+	*out = *in
+	if in.Downsampling != nil {
+		in, out := &in.Downsampling, &out.Downsampling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.Retention = in.Retention
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosCompactor.
+func (in *ThanosCompactor) DeepCopy() *ThanosCompactor {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosCompactor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosComponent) DeepCopyInto(out *ThanosComponent) {
+	*out = *in
+	if in.Compactor != nil {
+		in, out := &in.Compactor, &out.Compactor
+		*out = new(ThanosCompactor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ThanosObjectStore)
+		**out = **in
+	}
+	if in.StoreGateway != nil {
+		in, out := &in.StoreGateway, &out.StoreGateway
+		*out = new(ThanosStoreGateway)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosComponent.
+func (in *ThanosComponent) DeepCopy() *ThanosComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosObjectStore) DeepCopyInto(out *ThanosObjectStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosObjectStore.
+func (in *ThanosObjectStore) DeepCopy() *ThanosObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosRetention) DeepCopyInto(out *ThanosRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosRetention.
+func (in *ThanosRetention) DeepCopy() *ThanosRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosStatus) DeepCopyInto(out *ThanosStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosStatus.
+func (in *ThanosStatus) DeepCopy() *ThanosStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosStoreGateway) DeepCopyInto(out *ThanosStoreGateway) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosStoreGateway.
+func (in *ThanosStoreGateway) DeepCopy() *ThanosStoreGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosStoreGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAppRoleAuth) DeepCopyInto(out *VaultAppRoleAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAppRoleAuth.
+func (in *VaultAppRoleAuth) DeepCopy() *VaultAppRoleAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAppRoleAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
+	*out = *in
+	if in.AppRole != nil {
+		in, out := &in.AppRole, &out.AppRole
+		*out = new(VaultAppRoleAuth)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(VaultKubernetesAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIssuer.
+func (in *VaultIssuer) DeepCopy() *VaultIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroComponent) DeepCopyInto(out *VeleroComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroComponent.
+func (in *VeleroComponent) DeepCopy() *VeleroComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Verrazzano) DeepCopyInto(out *Verrazzano) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Verrazzano.
+func (in *Verrazzano) DeepCopy() *Verrazzano {
+	if in == nil {
+		return nil
+	}
+	out := new(Verrazzano)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Verrazzano) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoComponent) DeepCopyInto(out *VerrazzanoComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoComponent.
+func (in *VerrazzanoComponent) DeepCopy() *VerrazzanoComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoList) DeepCopyInto(out *VerrazzanoList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Verrazzano, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoList.
+func (in *VerrazzanoList) DeepCopy() *VerrazzanoList {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerrazzanoList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoSpec) DeepCopyInto(out *VerrazzanoSpec) {
+	*out = *in
+	if in.ApplicationRestart != nil {
+		in, out := &in.ApplicationRestart, &out.ApplicationRestart
+		*out = new(ApplicationRestartSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Components.DeepCopyInto(&out.Components)
+	if in.DefaultVolumeSource != nil {
+		in, out := &in.DefaultVolumeSource, &out.DefaultVolumeSource
+		*out = new(corev1.VolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Security.DeepCopyInto(&out.Security)
+	if in.VolumeClaimSpecTemplates != nil {
+		in, out := &in.VolumeClaimSpecTemplates, &out.VolumeClaimSpecTemplates
+		*out = make([]VolumeClaimSpecTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoSpec.
+func (in *VerrazzanoSpec) DeepCopy() *VerrazzanoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerrazzanoStatus) DeepCopyInto(out *VerrazzanoStatus) {
+	*out = *in
+	if in.ApplicationRestart != nil {
+		in, out := &in.ApplicationRestart, &out.ApplicationRestart
+		*out = new(ApplicationRestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		*out = new(string)
+		**out = **in
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(ComponentStatusMap, len(*in))
+		for key, val := range *in {
+			var outVal *ComponentStatusDetails
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ComponentStatusDetails)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]CredentialStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpenSearchSnapshots != nil {
+		in, out := &in.OpenSearchSnapshots, &out.OpenSearchSnapshots
+		*out = new(OpenSearchSnapshotStatus)
+		**out = **in
+	}
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]RemediationRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thanos != nil {
+		in, out := &in.Thanos, &out.Thanos
+		*out = new(ThanosStatus)
+		**out = **in
+	}
+	if in.VerrazzanoInstance != nil {
+		in, out := &in.VerrazzanoInstance, &out.VerrazzanoInstance
+		*out = new(InstanceInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerrazzanoStatus.
+func (in *VerrazzanoStatus) DeepCopy() *VerrazzanoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerrazzanoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimSpecTemplate) DeepCopyInto(out *VolumeClaimSpecTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimSpecTemplate.
+func (in *VolumeClaimSpecTemplate) DeepCopy() *VolumeClaimSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMount) DeepCopyInto(out *VolumeMount) {
+	*out = *in
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMount.
+func (in *VolumeMount) DeepCopy() *VolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebLogicOperatorComponent) DeepCopyInto(out *WebLogicOperatorComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.InstallOverrides.DeepCopyInto(&out.InstallOverrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebLogicOperatorComponent.
+func (in *WebLogicOperatorComponent) DeepCopy() *WebLogicOperatorComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(WebLogicOperatorComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Wildcard) DeepCopyInto(out *Wildcard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Wildcard.
+func (in *Wildcard) DeepCopy() *Wildcard {
+	if in == nil {
+		return nil
+	}
+	out := new(Wildcard)
+	in.DeepCopyInto(out)
+	return out
+}
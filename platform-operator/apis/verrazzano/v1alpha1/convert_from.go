@@ -24,6 +24,7 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	in.Spec.DefaultVolumeSource = src.Spec.DefaultVolumeSource
 	in.Spec.VolumeClaimSpecTemplates = convertVoumeClaimTemplatesFromV1Beta1(src.Spec.VolumeClaimSpecTemplates)
 	in.Spec.Security = convertSecuritySpecFromV1Beta1(src.Spec.Security)
+	in.Spec.ApplicationRestart = convertApplicationRestartSpecFromV1Beta1(src.Spec.ApplicationRestart)
 
 	// Convert status
 	in.Status.State = VzStateType(src.Status.State)
@@ -32,6 +33,11 @@ func (in *Verrazzano) ConvertFrom(srcRaw conversion.Hub) error {
 	in.Status.Components = convertComponentStatusMapFromV1Beta1(src.Status.Components)
 	in.Status.VerrazzanoInstance = convertVerrazzanoInstanceFromV1Beta1(src.Status.VerrazzanoInstance)
 	in.Status.Available = src.Status.Available
+	in.Status.ApplicationRestart = convertApplicationRestartStatusFromV1Beta1(src.Status.ApplicationRestart)
+	in.Status.Credentials = convertCredentialStatusFromV1Beta1(src.Status.Credentials)
+	in.Status.Remediations = convertRemediationRecordsFromV1Beta1(src.Status.Remediations)
+	in.Status.OpenSearchSnapshots = convertOpenSearchSnapshotStatusFromV1Beta1(src.Status.OpenSearchSnapshots)
+	in.Status.Thanos = convertThanosStatusFromV1Beta1(src.Status.Thanos)
 	return nil
 }
 
@@ -74,6 +80,7 @@ func convertComponentStatusMapFromV1Beta1(components v1beta1.ComponentStatusMap)
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				Progress:                 detail.Progress,
 			}
 		}
 	}
@@ -109,8 +116,67 @@ func convertVerrazzanoInstanceFromV1Beta1(instance *v1beta1.InstanceInfo) *Insta
 
 func convertSecuritySpecFromV1Beta1(security v1beta1.SecuritySpec) SecuritySpec {
 	return SecuritySpec{
-		AdminSubjects:   security.AdminSubjects,
-		MonitorSubjects: security.MonitorSubjects,
+		AdminSubjects:      security.AdminSubjects,
+		CredentialRotation: convertCredentialRotationSpecFromV1Beta1(security.CredentialRotation),
+		MonitorSubjects:    security.MonitorSubjects,
+	}
+}
+
+func convertCredentialRotationSpecFromV1Beta1(src *v1beta1.CredentialRotationSpec) *CredentialRotationSpec {
+	if src == nil {
+		return nil
+	}
+	var credentials []CredentialName
+	for _, credential := range src.Credentials {
+		credentials = append(credentials, CredentialName(credential))
+	}
+	return &CredentialRotationSpec{
+		Credentials:     credentials,
+		IntervalDays:    src.IntervalDays,
+		RotationVersion: src.RotationVersion,
+	}
+}
+
+func convertCredentialStatusFromV1Beta1(src []v1beta1.CredentialStatus) []CredentialStatus {
+	var out []CredentialStatus
+	for _, credential := range src {
+		out = append(out, CredentialStatus{
+			Conditions:       convertConditionsFromV1Beta1(credential.Conditions),
+			LastRotationTime: credential.LastRotationTime,
+			Name:             CredentialName(credential.Name),
+			RotationVersion:  credential.RotationVersion,
+		})
+	}
+	return out
+}
+
+func convertRemediationRecordsFromV1Beta1(src []v1beta1.RemediationRecord) []RemediationRecord {
+	var out []RemediationRecord
+	for _, record := range src {
+		out = append(out, RemediationRecord{
+			Attempt:   record.Attempt,
+			Component: record.Component,
+			Message:   record.Message,
+			Result:    RemediationResult(record.Result),
+			Rule:      record.Rule,
+			Time:      record.Time,
+		})
+	}
+	return out
+}
+
+func convertOpenSearchSnapshotStatusFromV1Beta1(src *v1beta1.OpenSearchSnapshotStatus) *OpenSearchSnapshotStatus {
+	if src == nil {
+		return nil
+	}
+	return &OpenSearchSnapshotStatus{
+		LastSnapshot:      src.LastSnapshot,
+		LastSnapshotState: src.LastSnapshotState,
+		LastSnapshotTime:  src.LastSnapshotTime,
+		Message:           src.Message,
+		Repository:        src.Repository,
+		SnapshotCount:     src.SnapshotCount,
+		UpdateTime:        src.UpdateTime,
 	}
 }
 
@@ -141,6 +207,7 @@ func convertComponentsFromV1Beta1(in v1beta1.ComponentSpec) ComponentSpec {
 		Fluentd:                   convertFluentdFromV1Beta1(in.Fluentd),
 		FluentOperator:            convertFluentOperatorFromV1Beta1(in.FluentOperator),
 		FluentbitOpensearchOutput: convertFluentbitOpensearchOutputFromV1Beta1(in.FluentbitOpensearchOutput),
+		GatewayAPI:                convertGatewayAPIFromV1Beta1(in.GatewayAPI),
 		Grafana:                   convertGrafanaFromV1Beta1(in.Grafana),
 		Ingress:                   convertIngressNGINXFromV1Beta1(in.IngressNGINX),
 		Istio:                     convertIstioFromV1Beta1(in.Istio),
@@ -193,6 +260,58 @@ func convertIssuerConfigFromV1Beta1(in v1beta1.IssuerConfig) IssuerConfig {
 	return IssuerConfig{
 		LetsEncrypt: leIssuer,
 		CA:          caIssuer,
+		ACME:        convertACMEIssuerFromV1Beta1(in.ACME),
+		Vault:       convertVaultIssuerFromV1Beta1(in.Vault),
+	}
+}
+
+func convertACMEIssuerFromV1Beta1(acme *v1beta1.ACMEIssuer) *ACMEIssuer {
+	if acme == nil {
+		return nil
+	}
+	var eab *ACMEExternalAccountBinding
+	if acme.ExternalAccountBinding != nil {
+		eab = &ACMEExternalAccountBinding{
+			KeyID:         acme.ExternalAccountBinding.KeyID,
+			KeySecretName: acme.ExternalAccountBinding.KeySecretName,
+		}
+	}
+	return &ACMEIssuer{
+		CABundleSecret:         acme.CABundleSecret,
+		EmailAddress:           acme.EmailAddress,
+		ExternalAccountBinding: eab,
+		Server:                 acme.Server,
+		SkipTLSVerify:          acme.SkipTLSVerify,
+		Solver:                 ACMESolverType(acme.Solver),
+	}
+}
+
+func convertVaultIssuerFromV1Beta1(vault *v1beta1.VaultIssuer) *VaultIssuer {
+	if vault == nil {
+		return nil
+	}
+	var appRole *VaultAppRoleAuth
+	if vault.AppRole != nil {
+		appRole = &VaultAppRoleAuth{
+			Path:       vault.AppRole.Path,
+			RoleID:     vault.AppRole.RoleID,
+			SecretName: vault.AppRole.SecretName,
+		}
+	}
+	var kubernetes *VaultKubernetesAuth
+	if vault.Kubernetes != nil {
+		kubernetes = &VaultKubernetesAuth{
+			MountPath: vault.Kubernetes.MountPath,
+			Role:      vault.Kubernetes.Role,
+		}
+	}
+	return &VaultIssuer{
+		AppRole:        appRole,
+		CABundleSecret: vault.CABundleSecret,
+		Kubernetes:     kubernetes,
+		Namespace:      vault.Namespace,
+		Path:           vault.Path,
+		Server:         vault.Server,
 	}
 }
 
@@ -301,6 +420,27 @@ func convertOpenSearchFromV1Beta1(in *v1beta1.OpenSearchComponent) *Elasticsearc
 		Nodes:                convertOSNodesFromV1Beta1(in.Nodes),
 		Plugins:              in.Plugins,
 		DisableDefaultPolicy: in.DisableDefaultPolicy,
+		Snapshots:            convertOpenSearchSnapshotsFromV1Beta1(in.Snapshots),
+	}
+}
+
+func convertOpenSearchSnapshotsFromV1Beta1(in *v1beta1.OpenSearchSnapshots) *OpenSearchSnapshots {
+	if in == nil {
+		return nil
+	}
+	return &OpenSearchSnapshots{
+		Repository: OpenSearchSnapshotRepository{
+			BasePath:        in.Repository.BasePath,
+			Bucket:          in.Repository.Bucket,
+			Endpoint:        in.Repository.Endpoint,
+			Location:        in.Repository.Location,
+			Name:            in.Repository.Name,
+			PathStyleAccess: in.Repository.PathStyleAccess,
+			Region:          in.Repository.Region,
+			Type:            OpenSearchSnapshotRepositoryType(in.Repository.Type),
+		},
+		RetentionCount: in.RetentionCount,
+		Schedule:       in.Schedule,
 	}
 }
 
@@ -332,6 +472,7 @@ func convertDNSFromV1Beta1(in *v1beta1.DNSComponent) *DNSComponent {
 	return &DNSComponent{
 		Wildcard:         convertWildcardDNSFromV1Beta1(in.Wildcard),
 		OCI:              convertOCIDNSFromV1Beta1(in.OCI),
+		RFC2136:          convertRFC2136DNSFromV1Beta1(in.RFC2136),
 		External:         convertExternalDNSFromV1Beta1(in.External),
 		InstallOverrides: convertInstallOverridesFromV1Beta1(in.InstallOverrides),
 	}
@@ -359,6 +500,20 @@ func convertOCIDNSFromV1Beta1(oci *v1beta1.OCI) *OCI {
 	}
 }
 
+func convertRFC2136DNSFromV1Beta1(rfc2136 *v1beta1.RFC2136) *RFC2136 {
+	if rfc2136 == nil {
+		return nil
+	}
+	return &RFC2136{
+		Nameserver:    rfc2136.Nameserver,
+		Port:          rfc2136.Port,
+		DNSZoneName:   rfc2136.DNSZoneName,
+		TSIGKeyName:   rfc2136.TSIGKeyName,
+		TSIGAlgorithm: rfc2136.TSIGAlgorithm,
+		TSIGSecret:    rfc2136.TSIGSecret,
+	}
+}
+
 func convertExternalDNSFromV1Beta1(external *v1beta1.External) *External {
 	if external == nil {
 		return nil
@@ -642,6 +797,16 @@ func convertArgoCDFromV1Beta1(in *v1beta1.ArgoCDComponent) *ArgoCDComponent {
 	}
 }
 
+func convertGatewayAPIFromV1Beta1(in *v1beta1.GatewayAPIComponent) *GatewayAPIComponent {
+	if in == nil {
+		return nil
+	}
+	return &GatewayAPIComponent{
+		Enabled:          in.Enabled,
+		GatewayClassName: in.GatewayClassName,
+	}
+}
+
 func convertClusterOperatorFromV1Beta1(in *v1beta1.ClusterOperatorComponent) *ClusterOperatorComponent {
 	if in == nil {
 		return nil
@@ -656,10 +821,76 @@ func convertThanosFromV1Beta1(src *v1beta1.ThanosComponent) *ThanosComponent {
 	if src == nil {
 		return nil
 	}
-	return &ThanosComponent{
+	out := &ThanosComponent{
 		Enabled:          src.Enabled,
 		InstallOverrides: convertInstallOverridesFromV1Beta1(src.InstallOverrides),
 	}
+	if src.Compactor != nil {
+		out.Compactor = &ThanosCompactor{
+			Downsampling: src.Compactor.Downsampling,
+			Enabled:      src.Compactor.Enabled,
+			Retention:    ThanosRetention(src.Compactor.Retention),
+			Schedule:     src.Compactor.Schedule,
+		}
+	}
+	if src.ObjectStore != nil {
+		out.ObjectStore = &ThanosObjectStore{
+			SecretKey:  src.ObjectStore.SecretKey,
+			SecretName: src.ObjectStore.SecretName,
+		}
+	}
+	if src.StoreGateway != nil {
+		out.StoreGateway = &ThanosStoreGateway{
+			Enabled: src.StoreGateway.Enabled,
+			Shards:  src.StoreGateway.Shards,
+		}
+	}
+	return out
+}
+
+func convertThanosStatusFromV1Beta1(src *v1beta1.ThanosStatus) *ThanosStatus {
+	if src == nil {
+		return nil
+	}
+	return &ThanosStatus{
+		CompactorLastScheduleTime:   src.CompactorLastScheduleTime,
+		CompactorLastSuccessfulTime: src.CompactorLastSuccessfulTime,
+	}
+}
+
+func convertApplicationRestartSpecFromV1Beta1(src *v1beta1.ApplicationRestartSpec) *ApplicationRestartSpec {
+	if src == nil {
+		return nil
+	}
+	var windows []MaintenanceWindow
+	for _, window := range src.MaintenanceWindows {
+		windows = append(windows, MaintenanceWindow{
+			Days:              window.Days,
+			Duration:          window.Duration,
+			NamespaceSelector: window.NamespaceSelector,
+			Start:             window.Start,
+		})
+	}
+	return &ApplicationRestartSpec{
+		MaxConcurrentApplications: src.MaxConcurrentApplications,
+		MaxUnavailablePods:        src.MaxUnavailablePods,
+		MaintenanceWindows:        windows,
+	}
+}
+
+func convertApplicationRestartStatusFromV1Beta1(src *v1beta1.ApplicationRestartStatus) *ApplicationRestartStatus {
+	if src == nil {
+		return nil
+	}
+	return &ApplicationRestartStatus{
+		Deferred:       src.Deferred,
+		InProgress:     src.InProgress,
+		Pending:        src.Pending,
+		Restarted:      src.Restarted,
+		RestartVersion: src.RestartVersion,
+		Skipped:        src.Skipped,
+		Total:          src.Total,
+	}
 }
 
 func convertInstallOverridesFromV1Beta1(in v1beta1.InstallOverrides) InstallOverrides {
@@ -36,6 +36,16 @@ func TestConvertFromV1Beta1(t *testing.T) {
 			testCaseClusterOperator,
 			false,
 		},
+		{
+			"converts to v1alpha1 in the ACME issuer case",
+			testCaseACMEIssuer,
+			false,
+		},
+		{
+			"converts to v1alpha1 in the Vault issuer case",
+			testCaseVaultIssuer,
+			false,
+		},
 	}
 
 	for _, tt := range tests {
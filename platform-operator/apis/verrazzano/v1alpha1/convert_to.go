@@ -65,6 +65,7 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out.Spec.VolumeClaimSpecTemplates = ConvertVolumeClaimTemplateTo(in.Spec.VolumeClaimSpecTemplates)
 	out.Spec.Components = components
 	out.Spec.Security = convertSecuritySpecTo(in.Spec.Security)
+	out.Spec.ApplicationRestart = convertApplicationRestartSpecTo(in.Spec.ApplicationRestart)
 
 	// Convert Status
 	out.Status.State = v1beta1.VzStateType(in.Status.State)
@@ -73,6 +74,11 @@ func (in *Verrazzano) ConvertTo(dstRaw conversion.Hub) error {
 	out.Status.Components = convertComponentStatusMapTo(in.Status.Components)
 	out.Status.VerrazzanoInstance = convertVerrazzanoInstanceTo(in.Status.VerrazzanoInstance)
 	out.Status.Available = in.Status.Available
+	out.Status.ApplicationRestart = convertApplicationRestartStatusTo(in.Status.ApplicationRestart)
+	out.Status.Credentials = convertCredentialStatusTo(in.Status.Credentials)
+	out.Status.Remediations = convertRemediationRecordsTo(in.Status.Remediations)
+	out.Status.OpenSearchSnapshots = convertOpenSearchSnapshotStatusTo(in.Status.OpenSearchSnapshots)
+	out.Status.Thanos = convertThanosStatusTo(in.Status.Thanos)
 	return nil
 }
 
@@ -139,6 +145,7 @@ func convertComponentsTo(src ComponentSpec) (v1beta1.ComponentSpec, error) {
 		Fluentd:                   convertFluentdToV1Beta1(src.Fluentd),
 		FluentOperator:            convertFluentOperatorToV1Beta1(src.FluentOperator),
 		FluentbitOpensearchOutput: convertFluentbitOpensearchOutputToV1Beta1(src.FluentbitOpensearchOutput),
+		GatewayAPI:                convertGatewayAPIToV1Beta1(src.GatewayAPI),
 		Grafana:                   convertGrafanaToV1Beta1(src.Grafana),
 		IngressNGINX:              ingressComponent,
 		Istio:                     istioComponent,
@@ -190,6 +197,58 @@ func convertIssuerConfig(src IssuerConfig) v1beta1.IssuerConfig {
 	return v1beta1.IssuerConfig{
 		LetsEncrypt: leIssuer,
 		CA:          caIssuer,
+		ACME:        convertACMEIssuerToV1Beta1(src.ACME),
+		Vault:       convertVaultIssuerToV1Beta1(src.Vault),
+	}
+}
+
+func convertACMEIssuerToV1Beta1(acme *ACMEIssuer) *v1beta1.ACMEIssuer {
+	if acme == nil {
+		return nil
+	}
+	var eab *v1beta1.ACMEExternalAccountBinding
+	if acme.ExternalAccountBinding != nil {
+		eab = &v1beta1.ACMEExternalAccountBinding{
+			KeyID:         acme.ExternalAccountBinding.KeyID,
+			KeySecretName: acme.ExternalAccountBinding.KeySecretName,
+		}
+	}
+	return &v1beta1.ACMEIssuer{
+		CABundleSecret:         acme.CABundleSecret,
+		EmailAddress:           acme.EmailAddress,
+		ExternalAccountBinding: eab,
+		Server:                 acme.Server,
+		SkipTLSVerify:          acme.SkipTLSVerify,
+		Solver:                 v1beta1.ACMESolverType(acme.Solver),
+	}
+}
+
+func convertVaultIssuerToV1Beta1(vault *VaultIssuer) *v1beta1.VaultIssuer {
+	if vault == nil {
+		return nil
+	}
+	var appRole *v1beta1.VaultAppRoleAuth
+	if vault.AppRole != nil {
+		appRole = &v1beta1.VaultAppRoleAuth{
+			Path:       vault.AppRole.Path,
+			RoleID:     vault.AppRole.RoleID,
+			SecretName: vault.AppRole.SecretName,
+		}
+	}
+	var kubernetes *v1beta1.VaultKubernetesAuth
+	if vault.Kubernetes != nil {
+		kubernetes = &v1beta1.VaultKubernetesAuth{
+			MountPath: vault.Kubernetes.MountPath,
+			Role:      vault.Kubernetes.Role,
+		}
+	}
+	return &v1beta1.VaultIssuer{
+		AppRole:        appRole,
+		CABundleSecret: vault.CABundleSecret,
+		Kubernetes:     kubernetes,
+		Namespace:      vault.Namespace,
+		Path:           vault.Path,
+		Server:         vault.Server,
 	}
 }
 
@@ -306,6 +365,7 @@ func convertDNSToV1Beta1(src *DNSComponent) *v1beta1.DNSComponent {
 	return &v1beta1.DNSComponent{
 		Wildcard:         convertWildcardDNSToV1Beta1(src.Wildcard),
 		OCI:              convertOCIDNSToV1Beta1(src.OCI),
+		RFC2136:          convertRFC2136DNSToV1Beta1(src.RFC2136),
 		External:         convertExternalDNSToV1Beta1(src.External),
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
@@ -333,6 +393,20 @@ func convertOCIDNSToV1Beta1(oci *OCI) *v1beta1.OCI {
 	}
 }
 
+func convertRFC2136DNSToV1Beta1(rfc2136 *RFC2136) *v1beta1.RFC2136 {
+	if rfc2136 == nil {
+		return nil
+	}
+	return &v1beta1.RFC2136{
+		Nameserver:    rfc2136.Nameserver,
+		Port:          rfc2136.Port,
+		DNSZoneName:   rfc2136.DNSZoneName,
+		TSIGKeyName:   rfc2136.TSIGKeyName,
+		TSIGAlgorithm: rfc2136.TSIGAlgorithm,
+		TSIGSecret:    rfc2136.TSIGSecret,
+	}
+}
+
 func convertExternalDNSToV1Beta1(external *External) *v1beta1.External {
 	if external == nil {
 		return nil
@@ -354,9 +428,30 @@ func convertOpenSearchToV1Beta1(src *ElasticsearchComponent) (*v1beta1.OpenSearc
 		Nodes:                nodes,
 		Plugins:              src.Plugins,
 		DisableDefaultPolicy: src.DisableDefaultPolicy,
+		Snapshots:            convertOpenSearchSnapshotsToV1Beta1(src.Snapshots),
 	}, nil
 }
 
+func convertOpenSearchSnapshotsToV1Beta1(src *OpenSearchSnapshots) *v1beta1.OpenSearchSnapshots {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.OpenSearchSnapshots{
+		Repository: v1beta1.OpenSearchSnapshotRepository{
+			BasePath:        src.Repository.BasePath,
+			Bucket:          src.Repository.Bucket,
+			Endpoint:        src.Repository.Endpoint,
+			Location:        src.Repository.Location,
+			Name:            src.Repository.Name,
+			PathStyleAccess: src.Repository.PathStyleAccess,
+			Region:          src.Repository.Region,
+			Type:            v1beta1.OpenSearchSnapshotRepositoryType(src.Repository.Type),
+		},
+		RetentionCount: src.RetentionCount,
+		Schedule:       src.Schedule,
+	}
+}
+
 func convertOSNodesToV1Beta1(args []InstallArgs, nodes []OpenSearchNode) ([]v1beta1.OpenSearchNode, error) {
 	var out []v1beta1.OpenSearchNode
 	installArgNodes, err := convertInstallArgsToOSNodes(args)
@@ -830,6 +925,16 @@ func convertArgoCDToV1Beta1(src *ArgoCDComponent) *v1beta1.ArgoCDComponent {
 	}
 }
 
+func convertGatewayAPIToV1Beta1(src *GatewayAPIComponent) *v1beta1.GatewayAPIComponent {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.GatewayAPIComponent{
+		Enabled:          src.Enabled,
+		GatewayClassName: src.GatewayClassName,
+	}
+}
+
 func convertVerrazzanoToV1Beta1(src *VerrazzanoComponent) (*v1beta1.VerrazzanoComponent, error) {
 	if src == nil {
 		return nil, nil
@@ -858,10 +963,31 @@ func convertThanosToV1Beta1(src *ThanosComponent) *v1beta1.ThanosComponent {
 	if src == nil {
 		return nil
 	}
-	return &v1beta1.ThanosComponent{
+	out := &v1beta1.ThanosComponent{
 		Enabled:          src.Enabled,
 		InstallOverrides: convertInstallOverridesToV1Beta1(src.InstallOverrides),
 	}
+	if src.Compactor != nil {
+		out.Compactor = &v1beta1.ThanosCompactor{
+			Downsampling: src.Compactor.Downsampling,
+			Enabled:      src.Compactor.Enabled,
+			Retention:    v1beta1.ThanosRetention(src.Compactor.Retention),
+			Schedule:     src.Compactor.Schedule,
+		}
+	}
+	if src.ObjectStore != nil {
+		out.ObjectStore = &v1beta1.ThanosObjectStore{
+			SecretKey:  src.ObjectStore.SecretKey,
+			SecretName: src.ObjectStore.SecretName,
+		}
+	}
+	if src.StoreGateway != nil {
+		out.StoreGateway = &v1beta1.ThanosStoreGateway{
+			Enabled: src.StoreGateway.Enabled,
+			Shards:  src.StoreGateway.Shards,
+		}
+	}
+	return out
 }
 
 func convertConditionsTo(conditions []Condition) []v1beta1.Condition {
@@ -892,6 +1018,7 @@ func convertComponentStatusMapTo(components ComponentStatusMap) v1beta1.Componen
 				Version:                  detail.Version,
 				LastReconciledGeneration: detail.LastReconciledGeneration,
 				ReconcilingGeneration:    detail.ReconcilingGeneration,
+				Progress:                 detail.Progress,
 			}
 		}
 	}
@@ -927,8 +1054,112 @@ func convertVerrazzanoInstanceTo(instance *InstanceInfo) *v1beta1.InstanceInfo {
 
 func convertSecuritySpecTo(security SecuritySpec) v1beta1.SecuritySpec {
 	return v1beta1.SecuritySpec{
-		AdminSubjects:   security.AdminSubjects,
-		MonitorSubjects: security.MonitorSubjects,
+		AdminSubjects:      security.AdminSubjects,
+		CredentialRotation: convertCredentialRotationSpecTo(security.CredentialRotation),
+		MonitorSubjects:    security.MonitorSubjects,
+	}
+}
+
+func convertCredentialRotationSpecTo(src *CredentialRotationSpec) *v1beta1.CredentialRotationSpec {
+	if src == nil {
+		return nil
+	}
+	var credentials []v1beta1.CredentialName
+	for _, credential := range src.Credentials {
+		credentials = append(credentials, v1beta1.CredentialName(credential))
+	}
+	return &v1beta1.CredentialRotationSpec{
+		Credentials:     credentials,
+		IntervalDays:    src.IntervalDays,
+		RotationVersion: src.RotationVersion,
+	}
+}
+
+func convertCredentialStatusTo(src []CredentialStatus) []v1beta1.CredentialStatus {
+	var out []v1beta1.CredentialStatus
+	for _, credential := range src {
+		out = append(out, v1beta1.CredentialStatus{
+			Conditions:       convertConditionsTo(credential.Conditions),
+			LastRotationTime: credential.LastRotationTime,
+			Name:             v1beta1.CredentialName(credential.Name),
+			RotationVersion:  credential.RotationVersion,
+		})
+	}
+	return out
+}
+
+func convertRemediationRecordsTo(src []RemediationRecord) []v1beta1.RemediationRecord {
+	var out []v1beta1.RemediationRecord
+	for _, record := range src {
+		out = append(out, v1beta1.RemediationRecord{
+			Attempt:   record.Attempt,
+			Component: record.Component,
+			Message:   record.Message,
+			Result:    v1beta1.RemediationResult(record.Result),
+			Rule:      record.Rule,
+			Time:      record.Time,
+		})
+	}
+	return out
+}
+
+func convertOpenSearchSnapshotStatusTo(src *OpenSearchSnapshotStatus) *v1beta1.OpenSearchSnapshotStatus {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.OpenSearchSnapshotStatus{
+		LastSnapshot:      src.LastSnapshot,
+		LastSnapshotState: src.LastSnapshotState,
+		LastSnapshotTime:  src.LastSnapshotTime,
+		Message:           src.Message,
+		Repository:        src.Repository,
+		SnapshotCount:     src.SnapshotCount,
+		UpdateTime:        src.UpdateTime,
+	}
+}
+
+func convertThanosStatusTo(src *ThanosStatus) *v1beta1.ThanosStatus {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.ThanosStatus{
+		CompactorLastScheduleTime:   src.CompactorLastScheduleTime,
+		CompactorLastSuccessfulTime: src.CompactorLastSuccessfulTime,
+	}
+}
+
+func convertApplicationRestartSpecTo(src *ApplicationRestartSpec) *v1beta1.ApplicationRestartSpec {
+	if src == nil {
+		return nil
+	}
+	var windows []v1beta1.MaintenanceWindow
+	for _, window := range src.MaintenanceWindows {
+		windows = append(windows, v1beta1.MaintenanceWindow{
+			Days:              window.Days,
+			Duration:          window.Duration,
+			NamespaceSelector: window.NamespaceSelector,
+			Start:             window.Start,
+		})
+	}
+	return &v1beta1.ApplicationRestartSpec{
+		MaxConcurrentApplications: src.MaxConcurrentApplications,
+		MaxUnavailablePods:        src.MaxUnavailablePods,
+		MaintenanceWindows:        windows,
+	}
+}
+
+func convertApplicationRestartStatusTo(src *ApplicationRestartStatus) *v1beta1.ApplicationRestartStatus {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.ApplicationRestartStatus{
+		Deferred:       src.Deferred,
+		InProgress:     src.InProgress,
+		Pending:        src.Pending,
+		Restarted:      src.Restarted,
+		RestartVersion: src.RestartVersion,
+		Skipped:        src.Skipped,
+		Total:          src.Total,
 	}
 }
 
@@ -405,6 +405,16 @@ func TestConvertToV1Beta1(t *testing.T) {
 			testCaseClusterOperator,
 			false,
 		},
+		{
+			"converts from v1alpha1 in the ACME issuer case",
+			testCaseACMEIssuer,
+			false,
+		},
+		{
+			"converts from v1alpha1 in the Vault issuer case",
+			testCaseVaultIssuer,
+			false,
+		},
 	}
 
 	for _, tt := range tests {
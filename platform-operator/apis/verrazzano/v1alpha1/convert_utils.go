@@ -35,6 +35,8 @@ const (
 	testDevProfile            = "dev"
 	testManagedClusterProfile = "managed-cluster"
 	testCaseClusterOperator   = "clusteroperator"
+	testCaseACMEIssuer        = "acmeissuer"
+	testCaseVaultIssuer       = "vaultissuer"
 )
 
 type converisonTestCase struct {
@@ -21,22 +21,52 @@ func NewDefaultClusterIssuer() *ClusterIssuerComponent {
 
 // IsCAIssuer returns true of the issuer configuration is for a CA issuer, or an error if it is misconfigured
 func (c *ClusterIssuerComponent) IsCAIssuer() (bool, error) {
-	if c.CA == nil && c.LetsEncrypt == nil {
-		return false, fmt.Errorf("Illegal state, either CAIssuer or LetsEncrypt issuer must be configured")
-	}
-	if c.CA != nil && c.LetsEncrypt != nil {
-		return false, fmt.Errorf("Illegal state, can not configure CAIssuer and LetsEncrypt issuer simultaneously")
+	if err := c.validateSingleIssuer(); err != nil {
+		return false, err
 	}
 	return c.CA != nil, nil
 }
 
 // IsLetsEncryptIssuer returns true of the issuer configuration is for a LetsEncrypt issuer, or an error if it is misconfigured
 func (c *ClusterIssuerComponent) IsLetsEncryptIssuer() (bool, error) {
-	isCAIssuer, err := c.IsCAIssuer()
-	if err != nil {
+	if err := c.validateSingleIssuer(); err != nil {
 		return false, err
 	}
-	return !isCAIssuer, nil
+	return c.LetsEncrypt != nil, nil
+}
+
+// IsACMEIssuer returns true of the issuer configuration is for an ACME issuer, either LetsEncrypt or a custom ACME
+// server, or an error if it is misconfigured
+func (c *ClusterIssuerComponent) IsACMEIssuer() (bool, error) {
+	if err := c.validateSingleIssuer(); err != nil {
+		return false, err
+	}
+	return c.LetsEncrypt != nil || c.ACME != nil, nil
+}
+
+// IsVaultIssuer returns true of the issuer configuration is for a Vault issuer, or an error if it is misconfigured
+func (c *ClusterIssuerComponent) IsVaultIssuer() (bool, error) {
+	if err := c.validateSingleIssuer(); err != nil {
+		return false, err
+	}
+	return c.Vault != nil, nil
+}
+
+// validateSingleIssuer returns an error unless exactly one issuer is configured
+func (c *ClusterIssuerComponent) validateSingleIssuer() error {
+	configured := 0
+	for _, isSet := range []bool{c.CA != nil, c.LetsEncrypt != nil, c.ACME != nil, c.Vault != nil} {
+		if isSet {
+			configured++
+		}
+	}
+	if configured == 0 {
+		return fmt.Errorf("Illegal state, either CAIssuer, LetsEncrypt, ACME or Vault issuer must be configured")
+	}
+	if configured > 1 {
+		return fmt.Errorf("Illegal state, can not configure more than one of CAIssuer, LetsEncrypt, ACME and Vault issuer simultaneously")
+	}
+	return nil
 }
 
 // IsDefaultIssuer returns true of the issuer configuration is for the Verrazzano default self-signed issuer, or an error if it is misconfigured
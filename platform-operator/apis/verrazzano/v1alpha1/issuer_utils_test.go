@@ -161,3 +161,45 @@ func TestClusterIssuerComponentBadIssuerConfig(t *testing.T) {
 	asserts.Error(err)
 	asserts.False(isDefIssuer)
 }
+
+// TestClusterIssuerComponentIsACMEIssuerIsVaultIssuer Tests the IsACMEIssuer and IsVaultIssuer methods
+// GIVEN a call to IsACMEIssuer() or IsVaultIssuer() for various configurations
+// THEN the functions behave as expected, and an error is returned if more than one issuer is configured
+func TestClusterIssuerComponentIsACMEIssuerIsVaultIssuer(t *testing.T) {
+	asserts := assert.New(t)
+
+	acmeIssuer := ClusterIssuerComponent{
+		ClusterResourceNamespace: constants.CertManagerNamespace,
+		IssuerConfig: IssuerConfig{
+			ACME: &ACMEIssuer{Server: "https://step-ca:9000/acme/acme/directory"},
+		},
+	}
+	isACMEIssuer, err := acmeIssuer.IsACMEIssuer()
+	asserts.True(isACMEIssuer)
+	asserts.NoError(err)
+	isLEIssuer, err := acmeIssuer.IsLetsEncryptIssuer()
+	asserts.False(isLEIssuer)
+	asserts.NoError(err)
+	isCAIssuer, err := acmeIssuer.IsCAIssuer()
+	asserts.False(isCAIssuer)
+	asserts.NoError(err)
+
+	vaultIssuer := ClusterIssuerComponent{
+		ClusterResourceNamespace: constants.CertManagerNamespace,
+		IssuerConfig: IssuerConfig{
+			Vault: &VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"},
+		},
+	}
+	isVaultIssuer, err := vaultIssuer.IsVaultIssuer()
+	asserts.True(isVaultIssuer)
+	asserts.NoError(err)
+	isACMEIssuer, err = vaultIssuer.IsACMEIssuer()
+	asserts.False(isACMEIssuer)
+	asserts.NoError(err)
+
+	vaultIssuer.ACME = acmeIssuer.ACME
+	_, err = vaultIssuer.IsVaultIssuer()
+	asserts.Error(err)
+	_, err = vaultIssuer.IsCAIssuer()
+	asserts.Error(err)
+}
@@ -6,6 +6,8 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"strings"
+
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +29,12 @@ func ValidateProfile(requestedProfile ProfileType) error {
 	return nil
 }
 
+// ValidateInstallProfile checks that requestedProfile is a built-in profile, or a custom profile defined by a ConfigMap
+// in the verrazzano-install namespace
+func ValidateInstallProfile(c client.Client, requestedProfile ProfileType) error {
+	return v1beta1.ValidateInstallProfile(c, v1beta1.ProfileType(requestedProfile))
+}
+
 // ValidateActiveInstall enforces that only one install of Verrazzano is allowed.
 func ValidateActiveInstall(client client.Client) error {
 	vzList := &VerrazzanoList{}
@@ -128,6 +136,40 @@ func ValidateInstallOverridesV1Beta1(overrides []v1beta1.Overrides) error {
 	return nil
 }
 
+// rfc2136TSIGAlgorithms are the TSIG algorithms supported by both External-DNS and cert-manager
+var rfc2136TSIGAlgorithms = []string{"hmac-md5", "hmac-sha1", "hmac-sha256", "hmac-sha512"}
+
+// validateRFC2136DNS validates the RFC2136 DNS configuration and that its TSIG secret exists, if configured
+func validateRFC2136DNS(client client.Client, spec *VerrazzanoSpec) error {
+	if spec.Components.DNS == nil || spec.Components.DNS.RFC2136 == nil {
+		return nil
+	}
+	rfc2136 := spec.Components.DNS.RFC2136
+	if rfc2136.Nameserver == "" || rfc2136.DNSZoneName == "" || rfc2136.TSIGKeyName == "" || rfc2136.TSIGSecret == "" {
+		return fmt.Errorf("RFC2136 DNS requires the nameserver, dnsZoneName, tsigKeyName and tsigSecret fields")
+	}
+	if rfc2136.TSIGAlgorithm != "" && !isRFC2136TSIGAlgorithm(rfc2136.TSIGAlgorithm) {
+		return fmt.Errorf("RFC2136 TSIG algorithm \"%s\" must be one of %v", rfc2136.TSIGAlgorithm, rfc2136TSIGAlgorithms)
+	}
+	secret := &corev1.Secret{}
+	if err := validators.GetInstallSecret(client, rfc2136.TSIGSecret, secret); err != nil {
+		return err
+	}
+	if len(secret.Data["secret"]) == 0 {
+		return fmt.Errorf("Secret \"%s\" for RFC2136 DNS must have the TSIG key in the \"secret\" data key", rfc2136.TSIGSecret)
+	}
+	return nil
+}
+
+func isRFC2136TSIGAlgorithm(algorithm string) bool {
+	for _, supported := range rfc2136TSIGAlgorithms {
+		if strings.EqualFold(algorithm, supported) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateInstallOverrides checks that the overrides slice has only one override type per slice item
 func ValidateInstallOverrides(overrides []Overrides) error {
 	for _, override := range overrides {
@@ -9,6 +9,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ProfileType is the type of installation profile.
@@ -61,6 +62,9 @@ type VerrazzanoList struct {
 
 // VerrazzanoSpec defines the desired state of a Verrazzano resource.
 type VerrazzanoSpec struct {
+	// Defines how the applications are restarted after an upgrade.
+	// +optional
+	ApplicationRestart *ApplicationRestartSpec `json:"applicationRestart,omitempty"`
 	// The Verrazzano components.
 	// +optional
 	// +patchStrategy=merge
@@ -104,16 +108,86 @@ type CommonKubernetesSpec struct {
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
+// ApplicationRestartSpec defines how the applications are restarted after a Verrazzano upgrade so that they get
+// the new Istio proxy sidecar and Fluentd images.
+type ApplicationRestartSpec struct {
+	// The maximum number of applications that are restarted at the same time. The default value is `1`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentApplications *int `json:"maxConcurrentApplications,omitempty"`
+	// The maximum number, or percentage, of application pods that can belong to the applications being restarted at
+	// the same time. A percentage is calculated from the total number of application pods. An application is always
+	// allowed to restart when no other application is being restarted. By default, the number of pods is not limited.
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailablePods *intstr.IntOrString `json:"maxUnavailablePods,omitempty"`
+	// The maintenance windows in which applications can be restarted. An application is restarted in the first
+	// maintenance window that selects its namespace. Applications in namespaces not selected by any maintenance
+	// window can be restarted at any time. The upgrade does not wait for the maintenance windows, the applications
+	// waiting for a window are restarted after the upgrade has completed.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window in which the applications of a set of namespaces can be restarted.
+type MaintenanceWindow struct {
+	// The days of the week on which the window starts, for example `Saturday`. If not specified, then the window
+	// starts every day.
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// The length of the window, for example `4h`.
+	Duration metav1.Duration `json:"duration"`
+	// Selects the namespaces that the window applies to. If not specified, then the window applies to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// The UTC time at which the window starts, in the format `HH:MM`.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+}
+
 // SecuritySpec defines the security configuration for Verrazzano.
 type SecuritySpec struct {
 	// Specifies subjects that should be bound to the verrazzano-admin role.
 	// +optional
 	AdminSubjects []rbacv1.Subject `json:"adminSubjects,omitempty"`
+	// Defines the automated rotation of the credentials generated by Verrazzano.
+	// +optional
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
 	// Specifies subjects that should be bound to the verrazzano-monitor role.
 	// +optional
 	MonitorSubjects []rbacv1.Subject `json:"monitorSubjects,omitempty"`
 }
 
+// CredentialName identifies a credential generated by Verrazzano.
+// +kubebuilder:validation:Enum=keycloak-admin;mysql-root;mysql-user;grafana-admin
+type CredentialName string
+
+const (
+	// KeycloakAdminCredential is the password of the Keycloak admin user
+	KeycloakAdminCredential CredentialName = "keycloak-admin"
+	// MySQLRootCredential is the password of the MySQL root user
+	MySQLRootCredential CredentialName = "mysql-root"
+	// MySQLUserCredential is the password of the MySQL user used by Keycloak
+	MySQLUserCredential CredentialName = "mysql-user"
+	// GrafanaAdminCredential is the password of the Grafana admin user
+	GrafanaAdminCredential CredentialName = "grafana-admin"
+)
+
+// CredentialRotationSpec defines the automated rotation of the credentials generated by Verrazzano.
+type CredentialRotationSpec struct {
+	// The credentials to rotate. Valid values are `keycloak-admin`, `mysql-root`, `mysql-user`, and `grafana-admin`.
+	// If not specified, then all the credentials are rotated.
+	// +optional
+	Credentials []CredentialName `json:"credentials,omitempty"`
+	// The number of days between two rotations of a credential. The default value is `90`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IntervalDays *int `json:"intervalDays,omitempty"`
+	// Changing this value rotates the credentials immediately, without waiting for the rotation interval.
+	// +optional
+	RotationVersion string `json:"rotationVersion,omitempty"`
+}
+
 // VolumeClaimSpecTemplate Contains common PVC configurations that can be referenced from Components; these
 // do not actually result in generated PVCs, but can be used to provide common configurations to components that
 // declare a PersistentVolumeClaimVolumeSource.
@@ -156,20 +230,89 @@ type InstanceInfo struct {
 
 // VerrazzanoStatus defines the observed state of a Verrazzano resource.
 type VerrazzanoStatus struct {
+	// The progress of the application restart done after an upgrade.
+	ApplicationRestart *ApplicationRestartStatus `json:"applicationRestart,omitempty"`
 	// The summary of Verrazzano component availability.
 	Available *string `json:"available,omitempty"`
 	// States of the individual installed components.
 	Components ComponentStatusMap `json:"components,omitempty"`
+	// The rotation state of the credentials generated by Verrazzano.
+	Credentials []CredentialStatus `json:"credentials,omitempty"`
 	// The latest available observations of an object's current state.
 	Conditions []Condition `json:"conditions,omitempty"`
+	// The snapshots of the OpenSearch cluster.
+	OpenSearchSnapshots *OpenSearchSnapshotStatus `json:"openSearchSnapshots,omitempty"`
+	// The most recent remediation actions taken by the Verrazzano platform operator, oldest first.
+	Remediations []RemediationRecord `json:"remediations,omitempty"`
 	// State of the Verrazzano custom resource.
 	State VzStateType `json:"state,omitempty"`
+	// The status of Thanos.
+	Thanos *ThanosStatus `json:"thanos,omitempty"`
 	// The Verrazzano instance information.
 	VerrazzanoInstance *InstanceInfo `json:"instance,omitempty"`
 	// The version of Verrazzano that is installed.
 	Version string `json:"version,omitempty"`
 }
 
+// ApplicationRestartStatus reports the progress of the application restart done after a Verrazzano upgrade.
+type ApplicationRestartStatus struct {
+	// The names, in the format `namespace/name`, of the applications that are waiting for a maintenance window.
+	Deferred []string `json:"deferred,omitempty"`
+	// The names, in the format `namespace/name`, of the applications being restarted.
+	InProgress []string `json:"inProgress,omitempty"`
+	// The number of applications that still need to be restarted.
+	Pending int `json:"pending,omitempty"`
+	// The number of applications that have been restarted.
+	Restarted int `json:"restarted,omitempty"`
+	// The restart version set on the applications, derived from the Verrazzano version of the upgrade.
+	RestartVersion string `json:"restartVersion,omitempty"`
+	// The names, in the format `namespace/name`, of the applications that opted out of the restart.
+	Skipped []string `json:"skipped,omitempty"`
+	// The total number of applications that need to be restarted.
+	Total int `json:"total,omitempty"`
+}
+
+// CredentialStatus reports the rotation of a credential generated by Verrazzano.
+type CredentialStatus struct {
+	// The latest available observations of the rotation of the credential.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The time of the last successful rotation of the credential.
+	LastRotationTime string `json:"lastRotationTime,omitempty"`
+	// The name of the credential.
+	Name CredentialName `json:"name"`
+	// The rotation version used by the last successful rotation of the credential.
+	RotationVersion string `json:"rotationVersion,omitempty"`
+}
+
+// RemediationResult is the result of a remediation action.
+// +kubebuilder:validation:Enum=Succeeded;Failed;AttemptsExhausted
+type RemediationResult string
+
+const (
+	// RemediationSucceeded means that the repair of a detected problem succeeded
+	RemediationSucceeded RemediationResult = "Succeeded"
+	// RemediationFailed means that the repair of a detected problem failed
+	RemediationFailed RemediationResult = "Failed"
+	// RemediationAttemptsExhausted means that a detected problem persists after the maximum number of repairs
+	RemediationAttemptsExhausted RemediationResult = "AttemptsExhausted"
+)
+
+// RemediationRecord records a remediation action taken by the Verrazzano platform operator.
+type RemediationRecord struct {
+	// The repair attempt number for the detected problem.
+	Attempt int `json:"attempt,omitempty"`
+	// The name of the repaired component.
+	Component string `json:"component,omitempty"`
+	// Details about the remediation action.
+	Message string `json:"message,omitempty"`
+	// The result of the remediation action.
+	Result RemediationResult `json:"result"`
+	// The name of the remediation rule.
+	Rule string `json:"rule"`
+	// The time of the remediation action.
+	Time string `json:"time,omitempty"`
+}
+
 // ComponentStatusMap is a map of components status details.
 type ComponentStatusMap map[string]*ComponentStatusDetails
 
@@ -183,6 +326,8 @@ type ComponentStatusDetails struct {
 	LastReconciledGeneration int64 `json:"lastReconciledGeneration,omitempty"`
 	// Name of the component.
 	Name string `json:"name,omitempty"`
+	// The progress of a long running operation of the component, such as the removal of OpenSearch data nodes.
+	Progress string `json:"progress,omitempty"`
 	// The generation of the Verrazzano resource the Component is currently being reconciled against.
 	ReconcilingGeneration int64 `json:"reconcilingGeneration,omitempty"`
 	// The state of a component.
@@ -227,6 +372,12 @@ const (
 
 	// CondUpgradeComplete means the upgrade has completed successfully
 	CondUpgradeComplete ConditionType = "UpgradeComplete"
+
+	// CondCredentialRotated means a credential has been rotated successfully
+	CondCredentialRotated ConditionType = "CredentialRotated"
+
+	// CondCredentialRotationFailed means the rotation of a credential has failed
+	CondCredentialRotationFailed ConditionType = "CredentialRotationFailed"
 )
 
 // Condition describes the current state of an installation.
@@ -374,6 +525,11 @@ type ComponentSpec struct {
 	// +optional
 	FluentbitOpensearchOutput *FluentbitOpensearchOutputComponent `json:"fluentbitOpensearchOutput,omitempty"`
 
+	// The Kubernetes Gateway API configuration. If enabled, the ingresses of the Verrazzano components and the
+	// ingress traits of applications are rendered as Gateway API resources.
+	// +optional
+	GatewayAPI *GatewayAPIComponent `json:"gatewayAPI,omitempty"`
+
 	// The Grafana component configuration.
 	// +optional
 	Grafana *GrafanaComponent `json:"grafana,omitempty"`
@@ -497,6 +653,91 @@ type ElasticsearchComponent struct {
 	Plugins vmov1.OpenSearchPlugins `json:"plugins,omitempty"`
 	// To disable the default ISM policies.
 	DisableDefaultPolicy bool `json:"disableDefaultPolicy,omitempty"`
+	// The snapshot repository, schedule and retention of the OpenSearch cluster.
+	// +optional
+	Snapshots *OpenSearchSnapshots `json:"snapshots,omitempty"`
+}
+
+// OpenSearchSnapshots specifies the snapshots of the OpenSearch cluster.
+type OpenSearchSnapshots struct {
+	// The repository storing the snapshots.
+	Repository OpenSearchSnapshotRepository `json:"repository"`
+	// The maximum number of snapshots kept in the repository. The oldest snapshots beyond this count are deleted on
+	// the snapshot schedule. If not specified, then snapshots are not deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RetentionCount *int32 `json:"retentionCount,omitempty"`
+	// The cron expression, in UTC, of the snapshot schedule, for example `0 2 * * *`. If not specified, then snapshots
+	// are not created automatically.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// OpenSearchSnapshotRepositoryType identifies the storage of an OpenSearch snapshot repository.
+// +kubebuilder:validation:Enum=fs;s3
+type OpenSearchSnapshotRepositoryType string
+
+const (
+	// SnapshotRepositoryFS is a repository on a shared file system mounted on all the OpenSearch nodes
+	SnapshotRepositoryFS OpenSearchSnapshotRepositoryType = "fs"
+	// SnapshotRepositoryS3 is a repository in an S3-compatible object storage
+	SnapshotRepositoryS3 OpenSearchSnapshotRepositoryType = "s3"
+)
+
+// OpenSearchSnapshotRepository specifies an OpenSearch snapshot repository. An `s3` repository requires the
+// `repository-s3` plugin, and the credentials of the object storage in the OpenSearch keystore.
+type OpenSearchSnapshotRepository struct {
+	// For an `s3` repository, the path of the snapshots in the bucket.
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+	// For an `s3` repository, the name of the bucket.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// For an `s3` repository, the endpoint of the object storage, for example the address of a MinIO server. If not
+	// specified, then the AWS S3 endpoint of the region is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// For an `fs` repository, the path of the shared file system. The path must be mounted on all the OpenSearch
+	// nodes and listed in the `path.repo` setting.
+	// +optional
+	Location string `json:"location,omitempty"`
+	// The name of the repository. The default value is `verrazzano-snapshots`.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// For an `s3` repository, if true, then the bucket is accessed with path-style URLs, as required by MinIO.
+	// +optional
+	PathStyleAccess bool `json:"pathStyleAccess,omitempty"`
+	// For an `s3` repository, the region of the bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// The type of the repository: `fs` for a shared file system or `s3` for an S3-compatible object storage.
+	Type OpenSearchSnapshotRepositoryType `json:"type"`
+}
+
+// ThanosStatus is the status of Thanos.
+type ThanosStatus struct {
+	// The time of the last scheduled run of the Thanos compactor.
+	CompactorLastScheduleTime string `json:"compactorLastScheduleTime,omitempty"`
+	// The time of the last successful run of the Thanos compactor.
+	CompactorLastSuccessfulTime string `json:"compactorLastSuccessfulTime,omitempty"`
+}
+
+// OpenSearchSnapshotStatus reports the snapshots of the OpenSearch cluster.
+type OpenSearchSnapshotStatus struct {
+	// The name of the most recent snapshot.
+	LastSnapshot string `json:"lastSnapshot,omitempty"`
+	// The state of the most recent snapshot, as reported by OpenSearch: `SUCCESS`, `IN_PROGRESS`, `PARTIAL` or `FAILED`.
+	LastSnapshotState string `json:"lastSnapshotState,omitempty"`
+	// The start time of the most recent snapshot.
+	LastSnapshotTime string `json:"lastSnapshotTime,omitempty"`
+	// The error that prevented the snapshots from being configured or queried, if any.
+	Message string `json:"message,omitempty"`
+	// The name of the snapshot repository.
+	Repository string `json:"repository,omitempty"`
+	// The number of snapshots in the repository.
+	SnapshotCount int `json:"snapshotCount,omitempty"`
+	// The time the snapshots were last queried.
+	UpdateTime string `json:"updateTime,omitempty"`
 }
 
 // OpenSearchNode specifies a node group in the OpenSearch cluster.
@@ -868,11 +1109,27 @@ type DNSComponent struct {
 	// Oracle Cloud Infrastructure DNS configuration.
 	// +optional
 	OCI *OCI `json:"oci,omitempty"`
+	// RFC2136 DNS configuration, for DNS servers such as BIND that accept dynamic updates authenticated with a
+	// TSIG key.
+	// +optional
+	RFC2136 *RFC2136 `json:"rfc2136,omitempty"`
 	// Wildcard DNS configuration. This is the default with a domain of nip.io.
 	// +optional
 	Wildcard *Wildcard `json:"wildcard,omitempty"`
 }
 
+// GatewayAPIComponent specifies the Kubernetes Gateway API configuration.
+type GatewayAPIComponent struct {
+	// If true, then the ingresses of the Verrazzano components and the ingress traits of applications are rendered
+	// as Gateway API `Gateway`, `HTTPRoute` and `ReferenceGrant` resources, which are served by the Istio ingress
+	// gateway. The Gateway API CRDs must be installed in the cluster. The default is `false`.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The name of the GatewayClass of the generated gateways. The default is `istio`.
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+}
+
 // IngressNginxComponent specifies the ingress-nginx configuration.
 type IngressNginxComponent struct {
 	// If true, then ingress NGINX will be installed.
@@ -1166,6 +1423,9 @@ type ArgoCDComponent struct {
 
 // ThanosComponent specifies the Thanos configuration.
 type ThanosComponent struct {
+	// The Thanos compactor configuration. The compactor requires an object store.
+	// +optional
+	Compactor *ThanosCompactor `json:"compactor,omitempty"`
 	// If true, then Thanos will be installed.
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
@@ -1176,6 +1436,66 @@ type ThanosComponent struct {
 	// and invalid values will be ignored.
 	// +optional
 	InstallOverrides `json:",inline"`
+	// The object store keeping the metrics for the long term. The Prometheus Thanos sidecar uploads the metrics blocks
+	// to the object store, and the Thanos store gateway serves them to Thanos Query.
+	// +optional
+	ObjectStore *ThanosObjectStore `json:"objectStore,omitempty"`
+	// The Thanos store gateway configuration. The store gateway requires an object store.
+	// +optional
+	StoreGateway *ThanosStoreGateway `json:"storeGateway,omitempty"`
+}
+
+// ThanosObjectStore identifies the secret holding the Thanos object store configuration.
+type ThanosObjectStore struct {
+	// The key of the object store configuration in the secret. The default is `objstore.yml`.
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+	// The name of the secret holding the object store configuration, in the `verrazzano-monitoring` namespace. The
+	// configuration format is described in the [Thanos documentation](https://thanos.io/tip/thanos/storage.md/).
+	SecretName string `json:"secretName"`
+}
+
+// ThanosCompactor specifies the Thanos compactor configuration. The compactor runs as a CronJob, compacting and
+// downsampling the metrics blocks of the object store, and deleting the blocks beyond their retention.
+type ThanosCompactor struct {
+	// If true, then the metrics blocks are downsampled to the 5m and 1h resolutions. The default is `true`.
+	// +optional
+	Downsampling *bool `json:"downsampling,omitempty"`
+	// If true, then the Thanos compactor will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The retention of the metrics blocks per resolution.
+	// +optional
+	Retention ThanosRetention `json:"retention,omitempty"`
+	// The schedule of the compactor runs, in Cron format. The default is every six hours.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// ThanosRetention specifies how long the metrics blocks are kept per resolution, as a duration such as `30d` or `10y`.
+// A duration of `0d` keeps the blocks forever.
+type ThanosRetention struct {
+	// The retention of the raw metrics blocks. The default is `30d`.
+	// +optional
+	Raw string `json:"raw,omitempty"`
+	// The retention of the metrics blocks downsampled to a 1h resolution. The default is `10y`.
+	// +optional
+	Resolution1h string `json:"resolution1h,omitempty"`
+	// The retention of the metrics blocks downsampled to a 5m resolution. The default is `30d`.
+	// +optional
+	Resolution5m string `json:"resolution5m,omitempty"`
+}
+
+// ThanosStoreGateway specifies the Thanos store gateway configuration.
+type ThanosStoreGateway struct {
+	// If true, then the Thanos store gateway will be installed.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The number of store gateway shards. Each shard is a StatefulSet serving a hash partition of the metrics blocks.
+	// The default is a single store gateway serving all the blocks.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Shards *int32 `json:"shards,omitempty"`
 }
 
 // InstallArgs identifies a name/value or name/value list needed for the install.
@@ -1251,6 +1571,92 @@ type CAIssuer struct {
 	SecretName string `json:"secretName"`
 }
 
+// ACMESolverType identifies the ACME challenge type solved by the ACME issuer
+type ACMESolverType string
+
+const (
+	// ACMESolverDNS01 solves DNS01 challenges with the managed DNS provider, either OCI or RFC2136 DNS
+	ACMESolverDNS01 ACMESolverType = "dns01"
+	// ACMESolverHTTP01 solves HTTP01 challenges with the Verrazzano ingress controller
+	ACMESolverHTTP01 ACMESolverType = "http01"
+)
+
+// ACMEIssuer identifies the configuration used for an ACME issuer with a custom ACME server, for example step-ca
+type ACMEIssuer struct {
+	// The name of the secret in the `clusterResourceNamespace` with the PEM encoded certificate of the private CA of
+	// the ACME server, in the `ca.crt` data key. It is required for ACME servers with a private CA, for example step-ca,
+	// so that Rancher trusts the certificates issued by the ACME server.
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// Email address of the user.
+	// +optional
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// The External Account Binding of the ACME account, required by some ACME servers.
+	// +optional
+	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+	// The URL of the ACME server directory.
+	Server string `json:"server"`
+	// Skip the verification of the TLS certificate of the ACME server, for ACME servers with a private CA.
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+	// The ACME challenge type, either "dns01" or "http01". The default is "dns01", which requires OCI or RFC2136 DNS.
+	// +optional
+	Solver ACMESolverType `json:"solver,omitempty"`
+}
+
+// ACMEExternalAccountBinding identifies the External Account Binding of an ACME account
+type ACMEExternalAccountBinding struct {
+	// The key ID of the account in the ACME server.
+	KeyID string `json:"keyID"`
+	// The name of the secret in the `clusterResourceNamespace` with the base64url encoded HMAC key of the account,
+	// in the `secret` data key.
+	KeySecretName string `json:"keySecretName"`
+}
+
+// VaultIssuer identifies the configuration used for a HashiCorp Vault PKI issuer
+type VaultIssuer struct {
+	// The AppRole authentication configuration.
+	// +optional
+	AppRole *VaultAppRoleAuth `json:"appRole,omitempty"`
+	// The name of the secret in the `clusterResourceNamespace` with the PEM encoded CA certificate of the Vault server,
+	// in the `ca.crt` data key. The CA certificate is also trusted by Rancher, so it must include the CA of the
+	// certificates issued by the Vault PKI when that CA is not the CA of the Vault server.
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+	// The Kubernetes service account authentication configuration.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+	// The Vault Enterprise namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The path of the Vault PKI signing endpoint, for example `pki_int/sign/verrazzano`.
+	Path string `json:"path"`
+	// The address of the Vault server, for example `https://vault.example.com:8200`.
+	Server string `json:"server"`
+}
+
+// VaultKubernetesAuth identifies the Vault Kubernetes authentication configuration. Verrazzano authenticates with
+// the token of the `verrazzano-vault-issuer` service account in the `clusterResourceNamespace`.
+type VaultKubernetesAuth struct {
+	// The mount path of the Vault Kubernetes authentication method. The default is `/v1/auth/kubernetes`.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// The Vault role bound to the service account.
+	Role string `json:"role"`
+}
+
+// VaultAppRoleAuth identifies the Vault AppRole authentication configuration
+type VaultAppRoleAuth struct {
+	// The mount path of the Vault AppRole authentication method. The default is `approle`.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// The role ID of the AppRole.
+	RoleID string `json:"roleID"`
+	// The name of the secret in the `clusterResourceNamespace` with the secret ID of the AppRole, in the `secretId`
+	// data key.
+	SecretName string `json:"secretName"`
+}
+
 // IssuerConfig identifies the configuration for the Verrazzano ClusterIssuer.  Only one value may be set.
 type IssuerConfig struct {
 	// The certificate configuration.
@@ -1259,6 +1665,12 @@ type IssuerConfig struct {
 	// The certificate configuration.
 	// +optional
 	CA *CAIssuer `json:"ca,omitempty"`
+	// The ACME issuer configuration, for ACME servers other than LetsEncrypt.
+	// +optional
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+	// The HashiCorp Vault issuer configuration.
+	// +optional
+	Vault *VaultIssuer `json:"vault,omitempty"`
 }
 
 // Certificate - Deprecated. Represents the type of cert issuer for an installation.
@@ -1300,6 +1712,25 @@ type OCI struct {
 	OCIConfigSecret string `json:"ociConfigSecret"`
 }
 
+// RFC2136 DNS type.
+type RFC2136 struct {
+	// The host name or IP address of the DNS server.
+	Nameserver string `json:"nameserver"`
+	// The port of the DNS server. The default is 53.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Name of the DNS zone that is updated.
+	DNSZoneName string `json:"dnsZoneName"`
+	// Name of the TSIG key configured in the DNS server.
+	TSIGKeyName string `json:"tsigKeyName"`
+	// The TSIG algorithm (`hmac-md5`, `hmac-sha1`, `hmac-sha256`, `hmac-sha512`). The default is `hmac-sha256`.
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+	// Name of the secret in the `verrazzano-install` namespace that contains the base64-encoded TSIG key
+	// in the `secret` data key.
+	TSIGSecret string `json:"tsigSecret"`
+}
+
 // External DNS type.
 type External struct {
 	// The suffix for DNS names.
@@ -82,7 +82,7 @@ func (v *Verrazzano) ValidateCreate() error {
 		return err
 	}
 
-	if err := ValidateProfile(v.Spec.Profile); err != nil {
+	if err := ValidateInstallProfile(client, v.Spec.Profile); err != nil {
 		return err
 	}
 
@@ -90,6 +90,10 @@ func (v *Verrazzano) ValidateCreate() error {
 		return err
 	}
 
+	if err := validateRFC2136DNS(client, &v.Spec); err != nil {
+		return err
+	}
+
 	// hand the Verrazzano to component validator to validate
 	if componentValidator != nil {
 		if errs := componentValidator.ValidateInstall(v); len(errs) > 0 {
@@ -149,6 +153,10 @@ func (v *Verrazzano) ValidateUpdate(old runtime.Object) error {
 		return err
 	}
 
+	if err := validateRFC2136DNS(client, &v.Spec); err != nil {
+		return err
+	}
+
 	// hand the old and new Verrazzano to component validator to validate
 	if componentValidator != nil {
 		if errs := componentValidator.ValidateUpdate(oldResource, v); len(errs) > 0 {
@@ -0,0 +1,119 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentDefinitionStateType identifies the state of a ComponentDefinition.
+type ComponentDefinitionStateType string
+
+const (
+	// ComponentDefinitionStateRegistered is the state when the component is registered with the Verrazzano platform operator
+	ComponentDefinitionStateRegistered ComponentDefinitionStateType = "Registered"
+	// ComponentDefinitionStateInvalid is the state when the component definition cannot be registered
+	ComponentDefinitionStateInvalid ComponentDefinitionStateType = "Invalid"
+	// ComponentDefinitionStateUninstalling is the state when the component is being uninstalled
+	ComponentDefinitionStateUninstalling ComponentDefinitionStateType = "Uninstalling"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=componentdefinitions
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=compdef;compdefs
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.namespace",description="The namespace the component is installed in."
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="The state of the component definition."
+// +genclient
+
+// ComponentDefinition registers a Helm chart as a Verrazzano component, so that the Verrazzano platform operator
+// installs, upgrades and uninstalls it along with the built-in components.
+type ComponentDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentDefinitionSpec   `json:"spec,omitempty"`
+	Status ComponentDefinitionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentDefinitionList contains a list of ComponentDefinition resources.
+type ComponentDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentDefinition `json:"items"`
+}
+
+// ComponentDefinitionSpec defines the Helm chart of a component and how it is installed.
+type ComponentDefinitionSpec struct {
+	// The objects that must be available for the component to be available.
+	// +optional
+	AvailabilityObjects *ComponentAvailabilityObjects `json:"availabilityObjects,omitempty"`
+	// The certificates created for the component.
+	// +optional
+	Certificates []NamespacedObjectReference `json:"certificates,omitempty"`
+	// The path of the Helm chart, relative to the third-party charts directory of the Verrazzano platform operator. The
+	// chart must be shipped in the platform operator image, paths outside of that directory are rejected.
+	ChartPath string `json:"chartPath"`
+	// The names of the components that must be ready before the component is installed. These can be built-in
+	// components or other defined components.
+	// +optional
+	Dependencies []string `json:"dependencies,omitempty"`
+	// The ingresses created for the component.
+	// +optional
+	IngressNames []NamespacedObjectReference `json:"ingressNames,omitempty"`
+	// The minimum version of Verrazzano required to install the component.
+	// +optional
+	MinVerrazzanoVersion string `json:"minVerrazzanoVersion,omitempty"`
+	// The namespace in which the Helm release is installed.
+	Namespace string `json:"namespace"`
+	// List of overrides for the Helm chart values, the overrides must be in the namespace of the Verrazzano resource.
+	// +optional
+	Overrides []Overrides `json:"overrides,omitempty"`
+	// If true, then the Verrazzano platform operator installs and upgrades the component. The default value is `true`.
+	// +optional
+	SupportsInstall *bool `json:"supportsInstall,omitempty"`
+	// If true, then the Verrazzano platform operator uninstalls the component. The default value is `true`.
+	// +optional
+	SupportsUninstall *bool `json:"supportsUninstall,omitempty"`
+}
+
+// ComponentAvailabilityObjects identifies the objects that must be available for a component to be available.
+type ComponentAvailabilityObjects struct {
+	// The Daemonsets that must be available.
+	// +optional
+	DaemonSets []NamespacedObjectReference `json:"daemonSets,omitempty"`
+	// The Deployments that must be available.
+	// +optional
+	Deployments []NamespacedObjectReference `json:"deployments,omitempty"`
+	// The StatefulSets that must be available.
+	// +optional
+	StatefulSets []NamespacedObjectReference `json:"statefulSets,omitempty"`
+}
+
+// NamespacedObjectReference identifies an object in a namespace.
+type NamespacedObjectReference struct {
+	// The name of the object.
+	Name string `json:"name"`
+	// The namespace of the object.
+	Namespace string `json:"namespace"`
+}
+
+// ComponentDefinitionStatus defines the observed state of a ComponentDefinition.
+type ComponentDefinitionStatus struct {
+	// Information about the state of the component definition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The generation of the component definition that was last registered.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The state of the component definition.
+	// +optional
+	State ComponentDefinitionStateType `json:"state,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentDefinition{}, &ComponentDefinitionList{})
+}
@@ -1,7 +1,7 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1beta1
 
-// Hub Because v1beta1 is the storage version, it is used as the Hub interface for conversion
+// Hub v1beta1 is the Hub interface for conversion, the v1alpha1 and v1 versions are converted through it
 func (v *Verrazzano) Hub() {}
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package v1beta1
@@ -11,6 +11,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
@@ -1407,6 +1408,23 @@ func TestValidateProfileInvalidProfile(t *testing.T) {
 	assert.Error(t, ValidateProfile("wrong-profile"))
 }
 
+// TestValidateInstallProfileCustomProfile Tests ValidateInstallProfile() for custom profiles
+// GIVEN a request for a custom profile
+// WHEN the profile is defined by a profile ConfigMap in the verrazzano-install namespace
+// THEN no error is returned, and an error is returned for a custom profile which is not defined
+func TestValidateInstallProfileCustomProfile(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "small-profile",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{vzconst.VerrazzanoProfileLabel: "small"},
+		},
+	}).Build()
+	assert.NoError(t, ValidateInstallProfile(client, Dev))
+	assert.NoError(t, ValidateInstallProfile(client, "small"))
+	assert.ErrorContains(t, ValidateInstallProfile(client, "wrong-profile"), "Requested profile wrong-profile is invalid")
+}
+
 func TestValidateInstallOverrides(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1473,3 +1491,49 @@ func generateTestPrivateKeyWithType(keyType string) ([]byte, error) {
 	)
 	return keyPEM, nil
 }
+
+// TestValidateRFC2136DNS tests validateRFC2136DNS
+// GIVEN a Verrazzano spec containing an RFC2136 DNS configuration
+// WHEN validateRFC2136DNS is called
+// THEN an error is returned if a required field is missing, the TSIG algorithm is not supported, or the TSIG secret
+// does not exist or has no TSIG key
+func TestValidateRFC2136DNS(t *testing.T) {
+	newSpec := func(algorithm string) *VerrazzanoSpec {
+		return &VerrazzanoSpec{
+			Components: ComponentSpec{
+				DNS: &DNSComponent{
+					RFC2136: &RFC2136{
+						Nameserver:    "10.0.0.10",
+						DNSZoneName:   "example.com",
+						TSIGKeyName:   "externaldns-key",
+						TSIGAlgorithm: algorithm,
+						TSIGSecret:    "tsig",
+					},
+				},
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	newSecret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tsig", Namespace: constants.VerrazzanoInstallNamespace},
+			Data:       data,
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newSecret(map[string][]byte{"secret": []byte("a2V5")})).Build()
+	assert.NoError(t, validateRFC2136DNS(client, newSpec("")))
+	assert.NoError(t, validateRFC2136DNS(client, newSpec("HMAC-SHA512")))
+	assert.Error(t, validateRFC2136DNS(client, newSpec("hmac-sha3")))
+
+	spec := newSpec("")
+	spec.Components.DNS.RFC2136.Nameserver = ""
+	assert.Error(t, validateRFC2136DNS(client, spec))
+
+	client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(newSecret(map[string][]byte{"key": []byte("a2V5")})).Build()
+	assert.Error(t, validateRFC2136DNS(client, newSpec("")))
+
+	client = fake.NewClientBuilder().WithScheme(scheme).Build()
+	assert.Error(t, validateRFC2136DNS(client, newSpec("")))
+}
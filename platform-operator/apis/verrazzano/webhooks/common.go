@@ -1,11 +1,19 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
 
 import (
+	"encoding/json"
+
 	"github.com/Jeffail/gabs/v2"
 	"github.com/verrazzano/verrazzano/pkg/semver"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/yaml"
 )
 
@@ -16,8 +24,35 @@ const (
 	RequirementsWebhook            = "verrazzano-platform-requirements-validator"
 	RequirementsV1beta1Path        = "/v1beta1-validate-requirements"
 	RequirementsV1alpha1Path       = "/v1alpha1-validate-requirements"
+	HelmValuesWebhook              = "verrazzano-platform-helm-values-validator"
+	HelmValuesV1beta1Path          = "/v1beta1-validate-helm-values"
+	HelmValuesV1alpha1Path         = "/v1alpha1-validate-helm-values"
 )
 
+// decodeV1beta1 decodes a v1alpha1, v1beta1 or v1 Verrazzano resource as v1beta1, the v1 resources are validated by
+// the v1beta1 webhooks
+func decodeV1beta1(decoder *admission.Decoder, rawObj runtime.RawExtension, vz *v1beta1.Verrazzano) error {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(rawObj.Raw, &typeMeta); err != nil {
+		return err
+	}
+	switch typeMeta.APIVersion {
+	case installv1.SchemeGroupVersion.String():
+		vzV1 := &installv1.Verrazzano{}
+		if err := decoder.DecodeRaw(rawObj, vzV1); err != nil {
+			return err
+		}
+		return vzV1.ConvertTo(vz)
+	case v1alpha1.SchemeGroupVersion.String():
+		vzV1alpha1 := &v1alpha1.Verrazzano{}
+		if err := decoder.DecodeRaw(rawObj, vzV1alpha1); err != nil {
+			return err
+		}
+		return vzV1alpha1.ConvertTo(vz)
+	}
+	return decoder.DecodeRaw(rawObj, vz)
+}
+
 // isMinVersion indicates whether the provide version is greater than the min version provided
 func isMinVersion(vzVersion, minVersion string) bool {
 	vzSemver, err := semver.NewSemVersion(vzVersion)
@@ -0,0 +1,73 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	vzlog "github.com/verrazzano/verrazzano/pkg/log"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"go.uber.org/zap"
+	k8sadmission "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// OverridesSchemaValidator validates the install overrides of the components against the values schemas of their
+// Helm charts
+type OverridesSchemaValidator interface {
+	ValidateOverridesSchemaV1Beta1(cli client.Client, vz *v1beta1.Verrazzano) ([]string, []error)
+}
+
+// HelmValuesValidator is a struct holding objects used during validation.
+type HelmValuesValidator struct {
+	client    client.Client
+	decoder   *admission.Decoder
+	Validator OverridesSchemaValidator
+}
+
+// InjectClient injects the client.
+func (v *HelmValuesValidator) InjectClient(c client.Client) error {
+	v.client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder.
+func (v *HelmValuesValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle validates the install overrides of created or updated Verrazzano resources against the values schemas of the
+// component Helm charts. Values of the wrong type are rejected, the users are warned about unknown keys.
+func (v *HelmValuesValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var log = zap.S().With(vzlog.FieldResourceNamespace, req.Namespace, vzlog.FieldResourceName, req.Name, vzlog.FieldWebhook, HelmValuesWebhook)
+
+	vz := &v1beta1.Verrazzano{}
+	if err := decodeV1beta1(v.decoder, req.Object, vz); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !vz.ObjectMeta.DeletionTimestamp.IsZero() || v.Validator == nil {
+		return admission.Allowed("")
+	}
+	switch req.Operation {
+	case k8sadmission.Create, k8sadmission.Update:
+		log.Debugf("Validating the install overrides against the Helm values schemas")
+		warnings, errs := v.Validator.ValidateOverridesSchemaV1Beta1(v.client, vz)
+		for _, warning := range warnings {
+			log.Warn(warning)
+		}
+		if len(errs) > 0 {
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			return admission.Denied(strings.Join(messages, "\n")).WithWarnings(warnings...)
+		}
+		return admission.Allowed("").WithWarnings(warnings...)
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// fakeOverridesSchemaValidator returns the warnings and errors of its test case, and records the validated resource
+type fakeOverridesSchemaValidator struct {
+	warnings  []string
+	errs      []error
+	validated *v1beta1.Verrazzano
+}
+
+func (f *fakeOverridesSchemaValidator) ValidateOverridesSchemaV1Beta1(_ client.Client, vz *v1beta1.Verrazzano) ([]string, []error) {
+	f.validated = vz
+	return f.warnings, f.errs
+}
+
+// newHelmValuesValidator creates a new HelmValuesValidator
+func newHelmValuesValidator(validator OverridesSchemaValidator) HelmValuesValidator {
+	scheme := newScheme()
+	_ = v1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	v := HelmValuesValidator{Validator: validator}
+	v.InjectDecoder(decoder)
+	v.InjectClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+	return v
+}
+
+// TestHelmValuesValidatorWarnings tests validating install overrides with unknown keys
+// GIVEN a call to validate a Verrazzano resource
+// WHEN the install overrides set keys which are not defined by the component charts
+// THEN the admission request should be allowed but with warnings
+func TestHelmValuesValidatorWarnings(t *testing.T) {
+	asrt := assert.New(t)
+	validator := &fakeOverridesSchemaValidator{warnings: []string{"unknown key"}}
+	v := newHelmValuesValidator(validator)
+	vz := &v1beta1.Verrazzano{ObjectMeta: metav1.ObjectMeta{Name: "verrazzano"}}
+
+	res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Create, vz, nil))
+	asrt.True(res.Allowed, allowedFailureMessage)
+	asrt.Equal([]string{"unknown key"}, res.Warnings)
+	asrt.Equal("verrazzano", validator.validated.Name)
+}
+
+// TestHelmValuesValidatorErrors tests validating install overrides with type errors
+// GIVEN a call to validate an updated Verrazzano resource
+// WHEN the install overrides set values of the wrong type
+// THEN the admission request should be denied
+func TestHelmValuesValidatorErrors(t *testing.T) {
+	asrt := assert.New(t)
+	v := newHelmValuesValidator(&fakeOverridesSchemaValidator{errs: []error{fmt.Errorf("invalid type")}})
+	vz := &v1beta1.Verrazzano{}
+
+	res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Update, vz, vz))
+	asrt.False(res.Allowed)
+	asrt.Contains(string(res.Result.Reason), "invalid type")
+}
+
+// TestHelmValuesValidatorV1alpha1 tests validating the install overrides of a v1alpha1 resource
+// GIVEN a call to validate a v1alpha1 Verrazzano resource
+// WHEN the install overrides are validated
+// THEN the resource is validated as v1beta1
+func TestHelmValuesValidatorV1alpha1(t *testing.T) {
+	asrt := assert.New(t)
+	validator := &fakeOverridesSchemaValidator{}
+	v := newHelmValuesValidator(validator)
+	vz := &v1alpha1.Verrazzano{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Verrazzano"},
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano"},
+		Spec:       v1alpha1.VerrazzanoSpec{Profile: v1alpha1.Dev},
+	}
+
+	res := v.Handle(context.TODO(), newAdmissionRequest(admissionv1.Create, vz, nil))
+	asrt.True(res.Allowed, allowedFailureMessage)
+	asrt.Empty(res.Warnings, noWarningsFailureMessage)
+	asrt.Equal(v1beta1.Dev, validator.validated.Spec.Profile)
+}
@@ -37,7 +37,7 @@ func (v *MysqlValuesValidatorV1beta1) Handle(ctx context.Context, req admission.
 	var log = zap.S().With(vzlog.FieldResourceNamespace, req.Namespace, vzlog.FieldResourceName, req.Name, vzlog.FieldWebhook, "verrazzano-platform-mysqlinstalloverrides")
 
 	vz := &v1beta1.Verrazzano{}
-	err := v.decoder.Decode(req, vz)
+	err := decodeV1beta1(v.decoder, req.Object, vz)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
@@ -46,7 +46,7 @@ func (v *MysqlValuesValidatorV1beta1) Handle(ctx context.Context, req admission.
 		switch req.Operation {
 		case k8sadmission.Update:
 			oldVz := v1beta1.Verrazzano{}
-			if err := v.decoder.DecodeRaw(req.OldObject, &oldVz); err != nil {
+			if err := decodeV1beta1(v.decoder, req.OldObject, &oldVz); err != nil {
 				return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "unable to decode existing Verrazzano object"))
 			}
 			return v.validateMysqlValuesV1beta1(log, oldVz, vz)
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package webhooks
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	admissionv1 "k8s.io/api/admission/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -93,6 +94,7 @@ func newMysqlValuesValidatorV1beta1() MysqlValuesValidatorV1beta1 {
 func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	v1beta1.AddToScheme(scheme)
+	installv1.AddToScheme(scheme)
 	clientgoscheme.AddToScheme(scheme)
 	return scheme
 }
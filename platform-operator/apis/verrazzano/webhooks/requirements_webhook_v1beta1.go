@@ -44,7 +44,7 @@ func (v *RequirementsValidatorV1beta1) Handle(ctx context.Context, req admission
 	var log = zap.S().With(vzlog.FieldResourceNamespace, req.Namespace, vzlog.FieldResourceName, req.Name, vzlog.FieldWebhook, RequirementsWebhook)
 	log.Infof("Processing Requirements validator webhook")
 	vz := &v1beta1.Verrazzano{}
-	err := v.decoder.Decode(req, vz)
+	err := decodeV1beta1(v.decoder, req.Object, vz)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
@@ -54,7 +54,7 @@ func (v *RequirementsValidatorV1beta1) Handle(ctx context.Context, req admission
 			return validateRequirementsV1beta1(log, v.client, vz)
 		case k8sadmission.Update:
 			oldVz := v1beta1.Verrazzano{}
-			if err := v.decoder.DecodeRaw(req.OldObject, &oldVz); err != nil {
+			if err := decodeV1beta1(v.decoder, req.OldObject, &oldVz); err != nil {
 				return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "unable to decode existing Verrazzano object"))
 			}
 			return validateUpdatev1beta1(log, v.client, oldVz, vz)
@@ -6,6 +6,7 @@ package webhooks
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -48,6 +49,29 @@ func TestPrerequisiteValidationWarningForV1beta1(t *testing.T) {
 	asrt.Contains(res.Warnings[2], "minimum required memory is 32G but the memory on node node1 is 16G")
 }
 
+// TestPrerequisiteValidationWarningForV1 tests presenting a user warning for a v1 resource
+// GIVEN a call to validate a v1 Verrazzano resource
+// WHEN the nodes do not meet the prerequisites
+// THEN the resource should be validated as v1beta1 and the admission request should be allowed but with a warning.
+func TestPrerequisiteValidationWarningForV1(t *testing.T) {
+	asrt := assert.New(t)
+	var nodes []client.Object
+	nodes = append(nodes, node("node1", "3", "16G", "400G"))
+	m := newRequirementsValidatorV1beta1(nodes)
+	vz := &installv1.Verrazzano{
+		TypeMeta: v1.TypeMeta{APIVersion: installv1.SchemeGroupVersion.String(), Kind: "Verrazzano"},
+		Spec:     installv1.VerrazzanoSpec{Profile: installv1.Prod},
+	}
+	req := newAdmissionRequest(admissionv1.Update, vz, vz)
+	config.Set(config.OperatorConfig{ResourceRequirementsValidation: true})
+	defer func() {
+		config.Set(config.OperatorConfig{ResourceRequirementsValidation: false})
+	}()
+	res := m.Handle(context.TODO(), req)
+	asrt.True(res.Allowed, allowedFailureMessage)
+	asrt.Len(res.Warnings, 3, expectedWarningFailureMessage)
+}
+
 // TestPrerequisiteValidationNoWarningForV1beta1 tests presenting a user with no warning
 // GIVEN a call to validate a Verrazzano resource
 // WHEN the nodes meet the prerequisites
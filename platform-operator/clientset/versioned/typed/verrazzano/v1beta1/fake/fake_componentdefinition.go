@@ -0,0 +1,129 @@
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComponentDefinitions implements ComponentDefinitionInterface
+type FakeComponentDefinitions struct {
+	Fake *FakeVerrazzanoV1beta1
+	ns   string
+}
+
+var componentdefinitionsResource = schema.GroupVersionResource{Group: "install.verrazzano.io", Version: "v1beta1", Resource: "componentdefinitions"}
+
+var componentdefinitionsKind = schema.GroupVersionKind{Group: "install.verrazzano.io", Version: "v1beta1", Kind: "ComponentDefinition"}
+
+// Get takes name of the componentDefinition, and returns the corresponding componentDefinition object, and an error if there is any.
+func (c *FakeComponentDefinitions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ComponentDefinition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(componentdefinitionsResource, c.ns, name), &v1beta1.ComponentDefinition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ComponentDefinition), err
+}
+
+// List takes label and field selectors, and returns the list of ComponentDefinitions that match those selectors.
+func (c *FakeComponentDefinitions) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ComponentDefinitionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(componentdefinitionsResource, componentdefinitionsKind, c.ns, opts), &v1beta1.ComponentDefinitionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ComponentDefinitionList{ListMeta: obj.(*v1beta1.ComponentDefinitionList).ListMeta}
+	for _, item := range obj.(*v1beta1.ComponentDefinitionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested componentDefinitions.
+func (c *FakeComponentDefinitions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(componentdefinitionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a componentDefinition and creates it.  Returns the server's representation of the componentDefinition, and an error, if there is any.
+func (c *FakeComponentDefinitions) Create(ctx context.Context, componentDefinition *v1beta1.ComponentDefinition, opts v1.CreateOptions) (result *v1beta1.ComponentDefinition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(componentdefinitionsResource, c.ns, componentDefinition), &v1beta1.ComponentDefinition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ComponentDefinition), err
+}
+
+// Update takes the representation of a componentDefinition and updates it. Returns the server's representation of the componentDefinition, and an error, if there is any.
+func (c *FakeComponentDefinitions) Update(ctx context.Context, componentDefinition *v1beta1.ComponentDefinition, opts v1.UpdateOptions) (result *v1beta1.ComponentDefinition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(componentdefinitionsResource, c.ns, componentDefinition), &v1beta1.ComponentDefinition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ComponentDefinition), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeComponentDefinitions) UpdateStatus(ctx context.Context, componentDefinition *v1beta1.ComponentDefinition, opts v1.UpdateOptions) (*v1beta1.ComponentDefinition, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(componentdefinitionsResource, "status", c.ns, componentDefinition), &v1beta1.ComponentDefinition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ComponentDefinition), err
+}
+
+// Delete takes name of the componentDefinition and deletes it. Returns an error if one occurs.
+func (c *FakeComponentDefinitions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(componentdefinitionsResource, c.ns, name, opts), &v1beta1.ComponentDefinition{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComponentDefinitions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(componentdefinitionsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ComponentDefinitionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched componentDefinition.
+func (c *FakeComponentDefinitions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ComponentDefinition, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(componentdefinitionsResource, c.ns, name, pt, data, subresources...), &v1beta1.ComponentDefinition{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ComponentDefinition), err
+}
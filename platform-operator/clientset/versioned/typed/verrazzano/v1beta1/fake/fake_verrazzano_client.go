@@ -15,6 +15,10 @@ type FakeVerrazzanoV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeVerrazzanoV1beta1) ComponentDefinitions(namespace string) v1beta1.ComponentDefinitionInterface {
+	return &FakeComponentDefinitions{c, namespace}
+}
+
 func (c *FakeVerrazzanoV1beta1) Verrazzanos(namespace string) v1beta1.VerrazzanoInterface {
 	return &FakeVerrazzanos{c, namespace}
 }
@@ -5,4 +5,6 @@
 
 package v1beta1
 
+type ComponentDefinitionExpansion interface{}
+
 type VerrazzanoExpansion interface{}
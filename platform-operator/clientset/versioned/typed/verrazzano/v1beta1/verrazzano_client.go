@@ -15,6 +15,7 @@ import (
 
 type VerrazzanoV1beta1Interface interface {
 	RESTClient() rest.Interface
+	ComponentDefinitionsGetter
 	VerrazzanosGetter
 }
 
@@ -23,6 +24,10 @@ type VerrazzanoV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *VerrazzanoV1beta1Client) ComponentDefinitions(namespace string) ComponentDefinitionInterface {
+	return newComponentDefinitions(c, namespace)
+}
+
 func (c *VerrazzanoV1beta1Client) Verrazzanos(namespace string) VerrazzanoInterface {
 	return newVerrazzanos(c, namespace)
 }
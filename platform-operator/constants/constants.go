@@ -215,6 +215,9 @@ const OverridesFinalizer = "overrides.finalizers.verrazzano.io/finalizer"
 // DevComponentFinalizer is a label value for dev components configmap finalizer
 const DevComponentFinalizer = "components.finalizers.verrazzano.io/finalizer"
 
+// ComponentDefinitionFinalizer is the finalizer used to uninstall the component of a ComponentDefinition
+const ComponentDefinitionFinalizer = "componentdefinitions.finalizers.verrazzano.io/finalizer"
+
 // ConfigMapKind is a label value for ConfigMap kind
 const ConfigMapKind = "ConfigMap"
 
@@ -267,5 +270,8 @@ const ThanosInternalUserSecretName = "verrazzano-thanos-internal" //nolint:gosec
 // ThanosInternalUserName is the name of the VZ internal Thanos user
 const ThanosInternalUserName = "verrazzano-thanos-internal"
 
+// ThanosObjstoreSecretKey is the default key of the Thanos object store configuration in its secret
+const ThanosObjstoreSecretKey = "objstore.yml"
+
 // VerrazzanoPlatformOperatorHelmName is the Helm release name of the Verrazzano Platform Operator
 const VerrazzanoPlatformOperatorHelmName = "verrazzano-platform-operator"
@@ -0,0 +1,119 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package componentdefinition
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/k8s/ready"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	helmcomp "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// definedComponent is a Helm component declared by a ComponentDefinition
+type definedComponent struct {
+	helmcomp.HelmComponent
+}
+
+var _ spi.Component = definedComponent{}
+
+// newDefinedComponent creates the component for a ComponentDefinition
+func newDefinedComponent(cd *v1beta1.ComponentDefinition) (definedComponent, error) {
+	if cd.Spec.ChartPath == "" {
+		return definedComponent{}, fmt.Errorf("ComponentDefinition %s does not contain the chartPath field", cd.Name)
+	}
+	if !isThirdPartyChartPath(cd.Spec.ChartPath) {
+		return definedComponent{}, fmt.Errorf("ComponentDefinition %s chartPath %s is not a path in the third-party charts directory", cd.Name, cd.Spec.ChartPath)
+	}
+	if cd.Spec.Namespace == "" {
+		return definedComponent{}, fmt.Errorf("ComponentDefinition %s does not contain the namespace field", cd.Name)
+	}
+	for _, dependency := range cd.Spec.Dependencies {
+		if dependency == cd.Name {
+			return definedComponent{}, fmt.Errorf("ComponentDefinition %s cannot depend on itself", cd.Name)
+		}
+	}
+
+	overrides := cd.Spec.Overrides
+	return definedComponent{
+		helmcomp.HelmComponent{
+			ReleaseName:             cd.Name,
+			ChartDir:                filepath.Join(config.GetThirdPartyDir(), cd.Spec.ChartPath),
+			ChartNamespace:          cd.Spec.Namespace,
+			IgnoreNamespaceOverride: true,
+			SupportsOperatorInstall: boolOrDefault(cd.Spec.SupportsInstall),
+			// Uninstall of a defined component is done when the ComponentDefinition is deleted, and also when
+			// the Verrazzano resource is deleted
+			SupportsOperatorUninstall: boolOrDefault(cd.Spec.SupportsUninstall),
+			ImagePullSecretKeyname:    constants.GlobalImagePullSecName,
+			Dependencies:              cd.Spec.Dependencies,
+			MinVerrazzanoVersion:      cd.Spec.MinVerrazzanoVersion,
+			IngressNames:              toNamespacedNames(cd.Spec.IngressNames),
+			Certificates:              toNamespacedNames(cd.Spec.Certificates),
+			AvailabilityObjects:       toAvailabilityObjects(cd.Spec.AvailabilityObjects),
+			GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+				if _, ok := object.(*v1beta1.Verrazzano); ok {
+					return overrides
+				}
+				return toV1Alpha1Overrides(overrides)
+			},
+		},
+	}, nil
+}
+
+// isThirdPartyChartPath returns true if the chart path is a relative path that stays in the third-party charts
+// directory, the charts of defined components must be shipped in the platform operator image
+func isThirdPartyChartPath(chartPath string) bool {
+	if filepath.IsAbs(chartPath) {
+		return false
+	}
+	cleaned := filepath.Clean(chartPath)
+	return cleaned != "." && cleaned != ".." && !strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
+
+func boolOrDefault(b *bool) bool {
+	if b == nil {
+		return true
+	}
+	return *b
+}
+
+func toNamespacedNames(refs []v1beta1.NamespacedObjectReference) []types.NamespacedName {
+	var names []types.NamespacedName
+	for _, ref := range refs {
+		names = append(names, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return names
+}
+
+func toAvailabilityObjects(objects *v1beta1.ComponentAvailabilityObjects) *ready.AvailabilityObjects {
+	if objects == nil {
+		return nil
+	}
+	return &ready.AvailabilityObjects{
+		DaemonsetNames:   toNamespacedNames(objects.DaemonSets),
+		DeploymentNames:  toNamespacedNames(objects.Deployments),
+		StatefulsetNames: toNamespacedNames(objects.StatefulSets),
+	}
+}
+
+func toV1Alpha1Overrides(in []v1beta1.Overrides) []v1alpha1.Overrides {
+	out := []v1alpha1.Overrides{}
+	for _, o := range in {
+		out = append(out, v1alpha1.Overrides{
+			ConfigMapRef: o.ConfigMapRef,
+			SecretRef:    o.SecretRef,
+			Values:       o.Values,
+		})
+	}
+	return out
+}
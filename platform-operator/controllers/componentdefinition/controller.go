@@ -0,0 +1,222 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package componentdefinition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Reconciler reconciles ComponentDefinition resources, registering the defined components with the component registry
+type Reconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	DryRun        bool
+	StatusUpdater vzstatus.Updater
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.ComponentDefinition{}).
+		Complete(r)
+}
+
+// Reconcile registers the component of a ComponentDefinition, and uninstalls and unregisters it when the
+// ComponentDefinition is deleted
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	verrazzanos := &vzapi.VerrazzanoList{}
+	if err := r.List(ctx, verrazzanos); err != nil {
+		zap.S().Errorf("Failed to get Verrazzanos for ComponentDefinition %s/%s: %v", req.Namespace, req.Name, err)
+		return newRequeueWithDelay(), nil
+	}
+	if len(verrazzanos.Items) == 0 {
+		zap.S().Debug("No Verrazzanos found in the cluster")
+		return newRequeueWithDelay(), nil
+	}
+	vz := &verrazzanos.Items[0]
+
+	cd := &v1beta1.ComponentDefinition{}
+	if err := r.Get(ctx, req.NamespacedName, cd); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		zap.S().Errorf("Failed to get ComponentDefinition %s/%s: %v", req.Namespace, req.Name, err)
+		return newRequeueWithDelay(), nil
+	}
+
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           cd.Name,
+		Namespace:      cd.Namespace,
+		ID:             string(cd.UID),
+		Generation:     cd.Generation,
+		ControllerName: "componentdefinition",
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create controller logger for ComponentDefinition %s/%s: %v", cd.Namespace, cd.Name, err)
+		return newRequeueWithDelay(), nil
+	}
+
+	compCtx, err := spi.NewContext(log, r.Client, vz, nil, r.DryRun)
+	if err != nil {
+		log.Errorf("Failed to create component context: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+
+	if !cd.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(compCtx, cd)
+	}
+	return r.reconcileRegister(compCtx, cd)
+}
+
+// reconcileRegister registers the component with the registry and triggers a Verrazzano reconcile to install or
+// update it
+func (r *Reconciler) reconcileRegister(ctx spi.ComponentContext, cd *v1beta1.ComponentDefinition) (ctrl.Result, error) {
+	vz := ctx.ActualCR()
+	if cd.Namespace != vz.Namespace {
+		return r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateInvalid,
+			fmt.Sprintf("ComponentDefinition must be in the same namespace as the Verrazzano resource, ComponentDefinition namespace: %s, Verrazzano namespace: %s", cd.Namespace, vz.Namespace))
+	}
+	comp, err := newDefinedComponent(cd)
+	if err != nil {
+		return r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateInvalid, err.Error())
+	}
+	if err := registry.CheckDependencies(comp); err != nil {
+		// Requeue, the missing dependency may be registered by another ComponentDefinition, or the cycle broken
+		// by a change to another ComponentDefinition
+		if res, err := r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateInvalid, err.Error()); err != nil || res.Requeue {
+			return res, err
+		}
+		return newRequeueWithDelay(), nil
+	}
+
+	// Add the finalizer before registering, so that the component is always uninstalled
+	if !controllerutil.ContainsFinalizer(cd, constants.ComponentDefinitionFinalizer) {
+		controllerutil.AddFinalizer(cd, constants.ComponentDefinitionFinalizer)
+		if err := r.Update(context.TODO(), cd); err != nil {
+			ctx.Log().Errorf("Failed adding finalizer %s to ComponentDefinition %s: %v", constants.ComponentDefinitionFinalizer, cd.Name, err)
+			return newRequeueWithDelay(), nil
+		}
+	}
+
+	if err := registry.RegisterComponent(comp); err != nil {
+		return r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateInvalid, err.Error())
+	}
+
+	if cd.Status.State == v1beta1.ComponentDefinitionStateRegistered && cd.Status.ObservedGeneration == cd.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	// Trigger a Verrazzano reconcile, a new component is installed by the install flow and a changed component
+	// re-enters the install flow
+	if _, ok := vz.Status.Components[comp.Name()]; ok {
+		if err := controllers.UpdateVerrazzanoForInstallOverrides(r.StatusUpdater, ctx, comp.Name()); err != nil {
+			return newRequeueWithDelay(), nil
+		}
+	} else {
+		r.StatusUpdater.Update(&vzstatus.UpdateEvent{
+			Verrazzano: vz,
+			Components: map[string]*vzapi.ComponentStatusDetails{
+				comp.Name(): {
+					Name:  comp.Name(),
+					State: vzapi.CompStateDisabled,
+				},
+			},
+		})
+	}
+	ctx.Log().Oncef("Registered component %s", comp.Name())
+	return r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateRegistered, fmt.Sprintf("Component %s is registered", comp.Name()))
+}
+
+// reconcileDelete uninstalls and unregisters the component, then removes the finalizer
+func (r *Reconciler) reconcileDelete(ctx spi.ComponentContext, cd *v1beta1.ComponentDefinition) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cd, constants.ComponentDefinitionFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// Only a registered component was installed, an invalid ComponentDefinition may have the name of a built-in component
+	found, comp := registry.FindComponent(cd.Name)
+	if _, isDefined := comp.(definedComponent); found && isDefined {
+		if cd.Status.State != v1beta1.ComponentDefinitionStateUninstalling {
+			if res, err := r.updateStatus(ctx, cd, v1beta1.ComponentDefinitionStateUninstalling, fmt.Sprintf("Component %s is uninstalling", comp.Name())); err != nil || res.Requeue {
+				return res, err
+			}
+		}
+		if comp.IsOperatorUninstallSupported() {
+			if err := doUninstall(ctx, comp); err != nil {
+				ctx.Log().Errorf("Failed uninstalling component %s: %v", comp.Name(), err)
+				return newRequeueWithDelay(), nil
+			}
+		}
+		registry.UnregisterComponent(comp.Name())
+		if _, ok := ctx.ActualCR().Status.Components[comp.Name()]; ok {
+			r.StatusUpdater.Update(&vzstatus.UpdateEvent{
+				Verrazzano: ctx.ActualCR(),
+				Components: map[string]*vzapi.ComponentStatusDetails{comp.Name(): nil},
+			})
+		}
+		ctx.Log().Infof("Unregistered component %s", comp.Name())
+	}
+
+	controllerutil.RemoveFinalizer(cd, constants.ComponentDefinitionFinalizer)
+	if err := r.Update(context.TODO(), cd); err != nil {
+		ctx.Log().Errorf("Failed removing finalizer %s from ComponentDefinition %s: %v", constants.ComponentDefinitionFinalizer, cd.Name, err)
+		return newRequeueWithDelay(), nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// updateStatus updates the status of the ComponentDefinition
+func (r *Reconciler) updateStatus(ctx spi.ComponentContext, cd *v1beta1.ComponentDefinition, state v1beta1.ComponentDefinitionStateType, message string) (ctrl.Result, error) {
+	if state == v1beta1.ComponentDefinitionStateInvalid {
+		ctx.Log().Errorf("Invalid ComponentDefinition %s/%s: %s", cd.Namespace, cd.Name, message)
+	}
+	if cd.Status.State == state && cd.Status.Message == message && cd.Status.ObservedGeneration == cd.Generation {
+		return ctrl.Result{}, nil
+	}
+	cd.Status.State = state
+	cd.Status.Message = message
+	cd.Status.ObservedGeneration = cd.Generation
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		ctx.Log().Errorf("Failed updating the status of ComponentDefinition %s: %v", cd.Name, err)
+		return newRequeueWithDelay(), nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func doUninstall(ctx spi.ComponentContext, comp spi.Component) error {
+	if err := comp.PreUninstall(ctx); err != nil {
+		return err
+	}
+	if err := comp.Uninstall(ctx); err != nil {
+		return err
+	}
+	return comp.PostUninstall(ctx)
+}
+
+// Create a new Result that will cause reconcile to requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(3, 5, time.Second)
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package componentdefinition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testBomFilePath = "../verrazzano/testdata/test_bom.json"
+	profilesDir     = "../../manifests/profiles"
+	testCompName    = "test-component"
+)
+
+// TestRegisterComponentDefinition tests registering the component of a ComponentDefinition
+// GIVEN a valid ComponentDefinition in the namespace of the Verrazzano resource
+// WHEN the ComponentDefinition is reconciled
+// THEN the component is registered, the finalizer is added, the component is added to the Verrazzano status and the
+// ComponentDefinition is Registered
+func TestRegisterComponentDefinition(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+	defer registry.UnregisterComponent(testCompName)
+	cd := newComponentDefinition(constants.VerrazzanoInstallNamespace)
+	cli := buildFakeClient(newVerrazzano(), cd)
+
+	res, err := newReconciler(cli).Reconcile(context.TODO(), newRequest(cd))
+	assert.NoError(t, err)
+	assert.False(t, res.Requeue)
+
+	found, comp := registry.FindComponent(testCompName)
+	assert.True(t, found)
+	assert.Equal(t, []string{"verrazzano"}, comp.GetDependencies())
+	assert.Equal(t, "1.6.0", comp.GetMinVerrazzanoVersion())
+	assert.False(t, comp.IsOperatorUninstallSupported())
+	assert.True(t, comp.IsOperatorInstallSupported())
+	assert.Equal(t, []types.NamespacedName{{Namespace: "test-ns", Name: "test-ingress"}}, comp.GetIngressNames(nil))
+
+	updated := getComponentDefinition(t, cli)
+	assert.Contains(t, updated.Finalizers, constants.ComponentDefinitionFinalizer)
+	assert.Equal(t, v1beta1.ComponentDefinitionStateRegistered, updated.Status.State)
+	assert.Equal(t, updated.Generation, updated.Status.ObservedGeneration)
+
+	vz := getVerrazzano(t, cli)
+	assert.Equal(t, vzapi.CompStateDisabled, vz.Status.Components[testCompName].State)
+}
+
+// TestUpdateComponentDefinition tests reconciling a changed ComponentDefinition
+// GIVEN a registered ComponentDefinition with a new generation
+// WHEN the ComponentDefinition is reconciled
+// THEN the component re-enters the install flow of the Verrazzano resource
+func TestUpdateComponentDefinition(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+	defer registry.UnregisterComponent(testCompName)
+	cd := newComponentDefinition(constants.VerrazzanoInstallNamespace)
+	cd.Finalizers = []string{constants.ComponentDefinitionFinalizer}
+	cd.Generation = 2
+	cd.Status = v1beta1.ComponentDefinitionStatus{State: v1beta1.ComponentDefinitionStateRegistered, ObservedGeneration: 1}
+	vz := newVerrazzano()
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		testCompName: {Name: testCompName, State: vzapi.CompStateReady, LastReconciledGeneration: 1, ReconcilingGeneration: 0},
+	}
+	cli := buildFakeClient(vz, cd)
+
+	_, err := newReconciler(cli).Reconcile(context.TODO(), newRequest(cd))
+	assert.NoError(t, err)
+
+	vz = getVerrazzano(t, cli)
+	assert.Equal(t, int64(1), vz.Status.Components[testCompName].ReconcilingGeneration)
+	assert.Equal(t, int64(2), getComponentDefinition(t, cli).Status.ObservedGeneration)
+}
+
+// TestInvalidComponentDefinition tests reconciling invalid ComponentDefinitions
+// GIVEN a ComponentDefinition that is not valid
+// WHEN the ComponentDefinition is reconciled
+// THEN the component is not registered and the ComponentDefinition is Invalid
+func TestInvalidComponentDefinition(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cd *v1beta1.ComponentDefinition)
+	}{
+		{
+			name:   "wrong namespace",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Namespace = constants.VerrazzanoSystemNamespace },
+		},
+		{
+			name:   "no chart path",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.ChartPath = "" },
+		},
+		{
+			name:   "chart path outside of the third-party charts",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.ChartPath = "../../etc" },
+		},
+		{
+			name:   "absolute chart path",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.ChartPath = "/tmp/chart" },
+		},
+		{
+			name:   "no namespace",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.Namespace = "" },
+		},
+		{
+			name:   "depends on itself",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.Dependencies = []string{testCompName} },
+		},
+		{
+			name:   "unknown dependency",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Spec.Dependencies = []string{"unknown"} },
+		},
+		{
+			name:   "built-in component name",
+			modify: func(cd *v1beta1.ComponentDefinition) { cd.Name = "verrazzano" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SetDefaultBomFilePath(testBomFilePath)
+			config.TestProfilesDir = profilesDir
+			defer func() { config.TestProfilesDir = "" }()
+			cd := newComponentDefinition(constants.VerrazzanoInstallNamespace)
+			tt.modify(cd)
+			cli := buildFakeClient(newVerrazzano(), cd)
+
+			_, err := newReconciler(cli).Reconcile(context.TODO(), newRequest(cd))
+			assert.NoError(t, err)
+
+			updated := &v1beta1.ComponentDefinition{}
+			assert.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(cd), updated))
+			assert.Equal(t, v1beta1.ComponentDefinitionStateInvalid, updated.Status.State)
+			found, _ := registry.FindComponent(testCompName)
+			assert.False(t, found)
+		})
+	}
+}
+
+// TestDeleteComponentDefinition tests deleting a ComponentDefinition
+// GIVEN a registered ComponentDefinition that is being deleted
+// WHEN the ComponentDefinition is reconciled
+// THEN the component is unregistered, removed from the Verrazzano status and the finalizer is removed
+func TestDeleteComponentDefinition(t *testing.T) {
+	config.SetDefaultBomFilePath(testBomFilePath)
+	config.TestProfilesDir = profilesDir
+	defer func() { config.TestProfilesDir = "" }()
+	defer registry.UnregisterComponent(testCompName)
+	cd := newComponentDefinition(constants.VerrazzanoInstallNamespace)
+	cd.Finalizers = []string{constants.ComponentDefinitionFinalizer}
+	now := metav1.Now()
+	cd.DeletionTimestamp = &now
+	comp, err := newDefinedComponent(cd)
+	assert.NoError(t, err)
+	assert.NoError(t, registry.RegisterComponent(comp))
+	vz := newVerrazzano()
+	vz.Status.Components = vzapi.ComponentStatusMap{
+		testCompName: {Name: testCompName, State: vzapi.CompStateReady},
+	}
+	cli := buildFakeClient(vz, cd)
+
+	_, err = newReconciler(cli).Reconcile(context.TODO(), newRequest(cd))
+	assert.NoError(t, err)
+
+	found, _ := registry.FindComponent(testCompName)
+	assert.False(t, found)
+	assert.NotContains(t, getVerrazzano(t, cli).Status.Components, testCompName)
+	updated := &v1beta1.ComponentDefinition{}
+	err = cli.Get(context.TODO(), client.ObjectKeyFromObject(cd), updated)
+	if err == nil {
+		assert.NotContains(t, updated.Finalizers, constants.ComponentDefinitionFinalizer)
+	}
+}
+
+func newComponentDefinition(namespace string) *v1beta1.ComponentDefinition {
+	supportsUninstall := false
+	return &v1beta1.ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testCompName,
+			Namespace:  namespace,
+			Generation: 1,
+		},
+		Spec: v1beta1.ComponentDefinitionSpec{
+			ChartPath:            testCompName,
+			Namespace:            "test-ns",
+			Dependencies:         []string{"verrazzano"},
+			MinVerrazzanoVersion: "1.6.0",
+			IngressNames:         []v1beta1.NamespacedObjectReference{{Namespace: "test-ns", Name: "test-ingress"}},
+			SupportsUninstall:    &supportsUninstall,
+		},
+	}
+}
+
+func newVerrazzano() *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-vz",
+			Namespace: constants.VerrazzanoInstallNamespace,
+		},
+	}
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	return scheme
+}
+
+func buildFakeClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build()
+}
+
+func newReconciler(c client.Client) *Reconciler {
+	return &Reconciler{
+		Client:        c,
+		Scheme:        newScheme(),
+		StatusUpdater: &vzstatus.FakeVerrazzanoStatusUpdater{Client: c},
+	}
+}
+
+func newRequest(cd *v1beta1.ComponentDefinition) ctrl.Request {
+	return ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cd)}
+}
+
+func getComponentDefinition(t *testing.T, cli client.Client) *v1beta1.ComponentDefinition {
+	cd := &v1beta1.ComponentDefinition{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: testCompName}, cd))
+	return cd
+}
+
+func getVerrazzano(t *testing.T, cli client.Client) *vzapi.Verrazzano {
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: "test-vz"}, vz))
+	return vz
+}
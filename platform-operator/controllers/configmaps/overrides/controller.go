@@ -22,6 +22,9 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common/override"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 
 	"go.uber.org/zap"
@@ -130,6 +133,11 @@ func (r *OverridesConfigMapsReconciler) reconcileInstallOverrideConfigMap(ctx co
 				}
 			}
 
+			// The component is reconciled again when the ConfigMap is fixed
+			if configMap.DeletionTimestamp.IsZero() && !r.validateInstallOverrides(componentCtx, componentName) {
+				return ctrl.Result{}, nil
+			}
+
 			err := controllers.UpdateVerrazzanoForInstallOverrides(r.StatusUpdater, componentCtx, componentName)
 			if err != nil {
 				r.log.ErrorfThrottled("Failed to reconcile ConfigMap: %v", err)
@@ -141,6 +149,31 @@ func (r *OverridesConfigMapsReconciler) reconcileInstallOverrideConfigMap(ctx co
 	return ctrl.Result{}, nil
 }
 
+// validateInstallOverrides validates the install overrides of a component against the values schema of its Helm chart,
+// before the changed overrides are applied. The unknown keys are logged as warnings, and the overrides are not applied
+// when they set values of the wrong type.
+func (r *OverridesConfigMapsReconciler) validateInstallOverrides(componentCtx spi.ComponentContext, componentName string) bool {
+	found, comp := registry.FindComponent(componentName)
+	if !found {
+		return true
+	}
+	overrides, err := override.GetInstallOverridesYAML(componentCtx, comp.GetOverrides(componentCtx.EffectiveCR()).([]installv1alpha1.Overrides))
+	if err != nil {
+		// The missing override sources are reported when the component is reconciled
+		r.log.Debugf("Could not get the install overrides of the %s component to validate them: %v", componentName, err)
+		return true
+	}
+	warnings, err := helm.ValidateOverridesSchema(comp, overrides)
+	for _, warning := range warnings {
+		r.log.Once(warning)
+	}
+	if err != nil {
+		r.log.ErrorfThrottled("Not applying the changed install overrides: %v", err)
+		return false
+	}
+	return true
+}
+
 // initialize logger for ConfigMap
 func (r *OverridesConfigMapsReconciler) initLogger(cm corev1.ConfigMap) (ctrl.Result, error) {
 	// Get the resource logger needed to log message using 'progress' and 'once' methods
@@ -16,6 +16,9 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/mocks"
 
@@ -79,6 +82,69 @@ func TestAddFinalizer(t *testing.T) {
 	asserts.True(controllerutil.ContainsFinalizer(&cm, constants.OverridesFinalizer))
 }
 
+// TestConfigMapInvalidOverrides tests the Reconcile loop for the following use cases
+// GIVEN a request to reconcile a ConfigMap with the install overrides of a component installed from a Helm chart
+// WHEN the overrides set values of the wrong type, or valid values
+// THEN the component is not reconciled with the invalid overrides, and is reconciled with the valid overrides
+func TestConfigMapInvalidOverrides(t *testing.T) {
+	const compName = "helm-values-test"
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{
+			helm.HelmComponent{
+				ReleaseName: compName,
+				ChartDir:    "../../../thirdparty/charts/keycloak",
+				GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+					return object.(*vzapi.Verrazzano).Spec.Components.Keycloak.ValueOverrides
+				},
+			},
+		}
+	})
+	defer registry.ResetGetComponentsFn()
+	config.TestProfilesDir = "../../../manifests/profiles"
+	defer func() { config.TestProfilesDir = "" }()
+
+	tests := []struct {
+		values              string
+		reconcileGeneration int64
+	}{
+		{values: "fullnameOverride: 2", reconcileGeneration: 0},
+		{values: "fullnameOverride: test", reconcileGeneration: 1},
+	}
+	for _, tt := range tests {
+		asserts := assert.New(t)
+		vz := &vzapi.Verrazzano{
+			ObjectMeta: metav1.ObjectMeta{Name: testVZName, Namespace: testNS},
+			Spec: vzapi.VerrazzanoSpec{
+				Components: vzapi.ComponentSpec{Keycloak: &vzapi.KeycloakComponent{
+					InstallOverrides: vzapi.InstallOverrides{
+						ValueOverrides: []vzapi.Overrides{{ConfigMapRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: testCMName},
+							Key:                  "values.yaml",
+						}}},
+					},
+				}},
+			},
+			Status: vzapi.VerrazzanoStatus{
+				Components: vzapi.ComponentStatusMap{compName: &vzapi.ComponentStatusDetails{Name: compName}},
+			},
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testCMName, Namespace: testNS, Finalizers: []string{constants.OverridesFinalizer}},
+			Data:       map[string]string{"values.yaml": tt.values},
+		}
+		cli := fake.NewClientBuilder().WithObjects(vz, cm).WithScheme(newScheme()).Build()
+
+		reconciler := newConfigMapReconciler(cli)
+		res, err := reconciler.Reconcile(context.TODO(), newRequest(testNS, testCMName))
+		asserts.NoError(err)
+		asserts.False(res.Requeue)
+
+		err = cli.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: testVZName}, vz)
+		asserts.NoError(err)
+		asserts.Equal(tt.reconcileGeneration, vz.Status.Components[compName].ReconcilingGeneration)
+	}
+}
+
 // TestOtherFinalizers tests the Reconcile loop for the following use case
 // GIVEN a request to reconcile a ConfigMap that qualifies as an override resource and is scheduled for deletion
 // WHEN the ConfigMap is found with finalizers but the override finalizer is missing
@@ -0,0 +1,128 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// profileHashAnnotation records the hash of the profile the Verrazzano components were last reconciled with
+const profileHashAnnotation = "install.verrazzano.io/profile-hash"
+
+// ProfileConfigMapsReconciler reconciles the ConfigMaps which define custom profiles.
+// When a custom profile used by the Verrazzano CR changes, the components are reconciled with the new profile
+type ProfileConfigMapsReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	StatusUpdater vzstatus.Updater
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *ProfileConfigMapsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithEventFilter(predicate.NewPredicateFuncs(isProfileConfigMap)).
+		Complete(r)
+}
+
+// isProfileConfigMap returns true if the object is a ConfigMap defining a custom profile
+func isProfileConfigMap(object client.Object) bool {
+	_, ok := object.GetLabels()[vzprofiles.ProfileLabel]
+	return ok && object.GetNamespace() == constants.VerrazzanoInstallNamespace
+}
+
+// Reconcile the profile ConfigMap
+func (r *ProfileConfigMapsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, configMap); err != nil {
+		// A deleted profile is reported when the Verrazzano CR merges its profiles
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !configMap.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// Skip the profiles which did not change since the components were reconciled, for instance when the operator restarts
+	hash := getProfileHash(configMap)
+	if configMap.Annotations[profileHashAnnotation] == hash {
+		return reconcile.Result{}, nil
+	}
+
+	vzList := &installv1alpha1.VerrazzanoList{}
+	if err := r.List(ctx, vzList); err != nil {
+		zap.S().Errorf("Failed to fetch Verrazzano resource: %v", err)
+		return newRequeueWithDelay(), err
+	}
+	profileName := configMap.Labels[vzprofiles.ProfileLabel]
+	for i := range vzList.Items {
+		vz := &vzList.Items[i]
+		uses, err := vzprofiles.UsesProfile(string(vz.Spec.Profile), profileName, vzprofiles.NewConfigMapProfileGetter(r.Client))
+		if err != nil {
+			zap.S().Errorf("Failed to resolve the profile %s of the Verrazzano resource %s/%s: %v", vz.Spec.Profile, vz.Namespace, vz.Name, err)
+			return newRequeueWithDelay(), err
+		}
+		if uses {
+			zap.S().Infof("The profile %s changed, reconciling the components of the Verrazzano resource %s/%s", profileName, vz.Namespace, vz.Name)
+			r.reconcileComponents(vz)
+		}
+	}
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[profileHashAnnotation] = hash
+	if err := r.Update(ctx, configMap); err != nil {
+		return newRequeueWithDelay(), err
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileComponents sets the ReconcilingGeneration of the components to 1, so that they re-enter the install flow
+func (r *ProfileConfigMapsReconciler) reconcileComponents(vz *installv1alpha1.Verrazzano) {
+	// The components have not been reconciled yet, the new profile is merged when they are
+	if len(vz.Status.Components) == 0 {
+		return
+	}
+	componentsToUpdate := map[string]*installv1alpha1.ComponentStatusDetails{}
+	for name, status := range vz.Status.Components {
+		details := status.DeepCopy()
+		details.ReconcilingGeneration = 1
+		componentsToUpdate[name] = details
+	}
+	r.StatusUpdater.Update(&vzstatus.UpdateEvent{
+		Verrazzano: vz,
+		Components: componentsToUpdate,
+	})
+}
+
+// getProfileHash returns the hash of the profile defined by a ConfigMap
+func getProfileHash(configMap *corev1.ConfigMap) string {
+	profile := vzprofiles.NewConfigMapProfile(configMap)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(profile.Extends+"\n"+profile.Profile)))
+}
+
+// Create a new Result that will cause a reconcile requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(3, 5, time.Second)
+}
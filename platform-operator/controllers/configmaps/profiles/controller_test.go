@@ -0,0 +1,132 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNS     = "default"
+	testVZName = "verrazzano"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	return scheme
+}
+
+func newProfileConfigMap(name string, extends string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-profile",
+			Namespace: constants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{vzprofiles.ProfileLabel: name},
+		},
+		Data: map[string]string{vzprofiles.ExtendsKey: extends},
+	}
+}
+
+func newVerrazzano(profile string) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: testVZName},
+		Spec:       vzapi.VerrazzanoSpec{Profile: vzapi.ProfileType(profile)},
+		Status: vzapi.VerrazzanoStatus{
+			Components: vzapi.ComponentStatusMap{
+				"keycloak": &vzapi.ComponentStatusDetails{Name: "keycloak", ReconcilingGeneration: 5},
+				"rancher":  &vzapi.ComponentStatusDetails{Name: "rancher", ReconcilingGeneration: 5},
+			},
+		},
+	}
+}
+
+func newReconciler(c client.Client) ProfileConfigMapsReconciler {
+	return ProfileConfigMapsReconciler{
+		Client:        c,
+		Scheme:        newScheme(),
+		StatusUpdater: &vzstatus.FakeVerrazzanoStatusUpdater{Client: c},
+	}
+}
+
+func reconcileProfile(t *testing.T, cli client.Client, name string) {
+	reconciler := newReconciler(cli)
+	res, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: name + "-profile"}})
+	assert.NoError(t, err)
+	assert.False(t, res.Requeue)
+}
+
+func getReconcilingGenerations(t *testing.T, cli client.Client) []int64 {
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: testNS, Name: testVZName}, vz))
+	return []int64{vz.Status.Components["keycloak"].ReconcilingGeneration, vz.Status.Components["rancher"].ReconcilingGeneration}
+}
+
+// TestReconcileExtendedProfile tests reconciling a profile ConfigMap
+// GIVEN a Verrazzano CR using a custom profile which extends the custom profile of the ConfigMap
+// WHEN the profile ConfigMap is reconciled
+// THEN the ReconcilingGeneration of the components is set to 1, and the profile hash is recorded
+func TestReconcileExtendedProfile(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		newVerrazzano("small-dev"), newProfileConfigMap("small-dev", "small"), newProfileConfigMap("small", "dev"),
+	).Build()
+
+	reconcileProfile(t, cli, "small")
+	assert.Equal(t, []int64{1, 1}, getReconcilingGenerations(t, cli))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: "small-profile"}, cm))
+	assert.Equal(t, getProfileHash(cm), cm.Annotations[profileHashAnnotation])
+}
+
+// TestReconcileUnchangedProfile tests reconciling a profile ConfigMap which did not change
+// GIVEN a Verrazzano CR using the custom profile of the ConfigMap
+// WHEN the profile ConfigMap is reconciled with the hash of its profile already recorded
+// THEN the components are not reconciled
+func TestReconcileUnchangedProfile(t *testing.T) {
+	cm := newProfileConfigMap("small", "dev")
+	cm.Annotations = map[string]string{profileHashAnnotation: getProfileHash(cm)}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newVerrazzano("small"), cm).Build()
+
+	reconcileProfile(t, cli, "small")
+	assert.Equal(t, []int64{5, 5}, getReconcilingGenerations(t, cli))
+}
+
+// TestReconcileUnusedProfile tests reconciling a profile ConfigMap which is not used
+// GIVEN a Verrazzano CR using a built-in profile
+// WHEN a profile ConfigMap is reconciled
+// THEN the components are not reconciled
+func TestReconcileUnusedProfile(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(newVerrazzano("dev"), newProfileConfigMap("small", "dev")).Build()
+
+	reconcileProfile(t, cli, "small")
+	assert.Equal(t, []int64{5, 5}, getReconcilingGenerations(t, cli))
+}
+
+// TestIsProfileConfigMap tests the filter of the profile ConfigMaps
+// GIVEN ConfigMaps with and without the profile label
+// WHEN the ConfigMaps are filtered
+// THEN only the labeled ConfigMaps of the verrazzano-install namespace are reconciled
+func TestIsProfileConfigMap(t *testing.T) {
+	cm := newProfileConfigMap("small", "dev")
+	assert.True(t, isProfileConfigMap(cm))
+	cm.Namespace = testNS
+	assert.False(t, isProfileConfigMap(cm))
+	assert.False(t, isProfileConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoInstallNamespace}}))
+}
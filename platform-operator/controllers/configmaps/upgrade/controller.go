@@ -0,0 +1,157 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// appliedManifestAnnotation records the URL of the platform operator manifest applied for the upgrade request
+	appliedManifestAnnotation = "install.verrazzano.io/applied-manifest"
+
+	platformOperatorURLTemplate = "https://github.com/verrazzano/verrazzano/releases/download/v%s/verrazzano-platform-operator.yaml"
+	manifestFetchTimeout        = 30 * time.Second
+)
+
+// leveraged to replace method (unit testing)
+var fetchPlatformOperatorManifest = func(manifestURL string) (string, error) {
+	httpClient := &http.Client{Timeout: manifestFetchTimeout}
+	resp, err := httpClient.Get(manifestURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch the platform operator manifest %s, the response status is %s", manifestURL, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// UpgradeRequestReconciler reconciles the upgrade request ConfigMap created by the cluster agent of a managed cluster.
+// The platform operator manifest of the requested version is applied by the platform operator, so that the cluster
+// agent does not need the permissions to install it.
+type UpgradeRequestReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *UpgradeRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithEventFilter(predicate.NewPredicateFuncs(isUpgradeRequestConfigMap)).
+		Complete(r)
+}
+
+// isUpgradeRequestConfigMap returns true if the object is the upgrade request ConfigMap
+func isUpgradeRequestConfigMap(object client.Object) bool {
+	return object.GetNamespace() == vzconst.VerrazzanoInstallNamespace && object.GetName() == vzconst.UpgradeRequestConfigMapName
+}
+
+// Reconcile the upgrade request ConfigMap
+func (r *UpgradeRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, req.NamespacedName, configMap); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !configMap.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	version := strings.TrimPrefix(configMap.Data[vzconst.UpgradeRequestVersionKey], "v")
+	if version == "" {
+		return reconcile.Result{}, nil
+	}
+	manifestURL := configMap.Data[vzconst.UpgradeRequestManifestURLKey]
+	if manifestURL == "" {
+		manifestURL = fmt.Sprintf(platformOperatorURLTemplate, version)
+	}
+	// The manifest of the request has already been applied
+	if configMap.Annotations[appliedManifestAnnotation] == manifestURL {
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateManifestURL(manifestURL); err != nil {
+		// The request is not retried until the cluster agent changes it
+		zap.S().Errorf("Rejected the upgrade request to version %s: %v", version, err)
+		return r.updateRequest(ctx, configMap, "", err.Error())
+	}
+	manifest, err := fetchPlatformOperatorManifest(manifestURL)
+	if err == nil {
+		err = k8sutil.NewYAMLApplier(r.Client, "").ApplyS(manifest)
+	}
+	if err != nil {
+		zap.S().Errorf("Failed to apply the platform operator manifest %s: %v", manifestURL, err)
+		if _, updateErr := r.updateRequest(ctx, configMap, "", fmt.Sprintf("Failed to apply the platform operator manifest %s: %v", manifestURL, err)); updateErr != nil {
+			return newRequeueWithDelay(), updateErr
+		}
+		return newRequeueWithDelay(), nil
+	}
+	zap.S().Infof("Applied the platform operator manifest %s requested for the upgrade to version %s", manifestURL, version)
+	return r.updateRequest(ctx, configMap, manifestURL, "")
+}
+
+// validateManifestURL returns an error if a manifest URL is not allowed by the upgrade manifest URL prefix of the
+// operator configuration
+func validateManifestURL(manifestURL string) error {
+	prefix := config.Get().UpgradeManifestURLPrefix
+	u, err := url.Parse(manifestURL)
+	if err != nil {
+		return fmt.Errorf("the platform operator manifest URL %s is invalid: %v", manifestURL, err)
+	}
+	if u.Scheme != "https" || strings.Contains(u.Path, "..") || !strings.HasPrefix(manifestURL, prefix) {
+		return fmt.Errorf("the platform operator manifest URL %s is not allowed, only the HTTPS URLs starting with %s can be applied", manifestURL, prefix)
+	}
+	return nil
+}
+
+// updateRequest records the applied manifest and the error message of the upgrade request
+func (r *UpgradeRequestReconciler) updateRequest(ctx context.Context, configMap *corev1.ConfigMap, appliedURL string, message string) (ctrl.Result, error) {
+	if appliedURL != "" {
+		if configMap.Annotations == nil {
+			configMap.Annotations = map[string]string{}
+		}
+		configMap.Annotations[appliedManifestAnnotation] = appliedURL
+	}
+	if message != "" {
+		configMap.Data[vzconst.UpgradeRequestMessageKey] = message
+	} else {
+		delete(configMap.Data, vzconst.UpgradeRequestMessageKey)
+	}
+	if err := r.Update(ctx, configMap); err != nil {
+		return newRequeueWithDelay(), err
+	}
+	return ctrl.Result{}, nil
+}
+
+// Create a new Result that will cause a reconcile requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(3, 5, time.Second)
+}
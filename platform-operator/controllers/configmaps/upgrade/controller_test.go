@@ -0,0 +1,120 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testPlatformOperatorManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: platform-operator-manifest
+  namespace: verrazzano-install
+`
+
+var requestName = types.NamespacedName{Namespace: vzconst.VerrazzanoInstallNamespace, Name: vzconst.UpgradeRequestConfigMapName}
+
+func newUpgradeRequest(version string, manifestURL string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: requestName.Namespace, Name: requestName.Name},
+		Data: map[string]string{
+			vzconst.UpgradeRequestVersionKey:     version,
+			vzconst.UpgradeRequestManifestURLKey: manifestURL,
+		},
+	}
+}
+
+func reconcileRequest(t *testing.T, cli client.Client) (ctrl.Result, *corev1.ConfigMap) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	reconciler := UpgradeRequestReconciler{Client: cli, Scheme: scheme}
+	res, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: requestName})
+	assert.NoError(t, err)
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, cli.Get(context.TODO(), requestName, configMap))
+	return res, configMap
+}
+
+// TestApplyUpgradeRequest tests applying the platform operator manifest of an upgrade request
+// GIVEN an upgrade request ConfigMap without a manifest URL
+// WHEN the request is reconciled
+// THEN the manifest of the Verrazzano release is applied once
+func TestApplyUpgradeRequest(t *testing.T) {
+	asserts := assert.New(t)
+	var fetchedURLs []string
+	savedFetch := fetchPlatformOperatorManifest
+	defer func() { fetchPlatformOperatorManifest = savedFetch }()
+	fetchPlatformOperatorManifest = func(manifestURL string) (string, error) {
+		fetchedURLs = append(fetchedURLs, manifestURL)
+		return testPlatformOperatorManifest, nil
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(newUpgradeRequest("v1.6.0", "")).Build()
+	res, configMap := reconcileRequest(t, cli)
+	asserts.False(res.Requeue)
+	asserts.Equal([]string{"https://github.com/verrazzano/verrazzano/releases/download/v1.6.0/verrazzano-platform-operator.yaml"}, fetchedURLs)
+	asserts.Equal(fetchedURLs[0], configMap.Annotations[appliedManifestAnnotation])
+	asserts.NoError(cli.Get(context.TODO(), types.NamespacedName{Namespace: vzconst.VerrazzanoInstallNamespace, Name: "platform-operator-manifest"}, &corev1.ConfigMap{}))
+
+	// The applied manifest is not applied again
+	reconcileRequest(t, cli)
+	asserts.Len(fetchedURLs, 1)
+}
+
+// TestRejectUpgradeRequest tests an upgrade request with a manifest URL which is not allowed
+// GIVEN an upgrade request ConfigMap with a manifest URL outside of the allowed prefix
+// WHEN the request is reconciled
+// THEN the manifest is not fetched and the error is reported in the ConfigMap
+func TestRejectUpgradeRequest(t *testing.T) {
+	savedFetch := fetchPlatformOperatorManifest
+	defer func() { fetchPlatformOperatorManifest = savedFetch }()
+	fetchPlatformOperatorManifest = func(manifestURL string) (string, error) {
+		assert.Fail(t, "unexpected fetch of the manifest %s", manifestURL)
+		return "", nil
+	}
+
+	for _, manifestURL := range []string{
+		"https://example.com/verrazzano-platform-operator.yaml",
+		"http://github.com/verrazzano/verrazzano/releases/download/v1.6.0/verrazzano-platform-operator.yaml",
+		"https://github.com/verrazzano/verrazzano/releases/download/../../../other/manifest.yaml",
+	} {
+		cli := fake.NewClientBuilder().WithObjects(newUpgradeRequest("1.6.0", manifestURL)).Build()
+		res, configMap := reconcileRequest(t, cli)
+		assert.False(t, res.Requeue)
+		assert.Contains(t, configMap.Data[vzconst.UpgradeRequestMessageKey], "is not allowed")
+		assert.Empty(t, configMap.Annotations[appliedManifestAnnotation])
+	}
+}
+
+// TestUpgradeRequestFetchError tests an upgrade request when the manifest cannot be fetched
+// GIVEN an upgrade request ConfigMap
+// WHEN the manifest fetch fails
+// THEN the request is requeued and the error is reported in the ConfigMap
+func TestUpgradeRequestFetchError(t *testing.T) {
+	savedFetch := fetchPlatformOperatorManifest
+	defer func() { fetchPlatformOperatorManifest = savedFetch }()
+	fetchPlatformOperatorManifest = func(manifestURL string) (string, error) {
+		return "", fmt.Errorf("not found")
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(newUpgradeRequest("1.6.0", "")).Build()
+	res, configMap := reconcileRequest(t, cli)
+	assert.True(t, res.Requeue)
+	assert.Contains(t, configMap.Data[vzconst.UpgradeRequestMessageKey], "not found")
+	assert.Empty(t, configMap.Annotations[appliedManifestAnnotation])
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vzctrl "github.com/verrazzano/verrazzano/pkg/controller"
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
+	"github.com/verrazzano/verrazzano/pkg/vzcr"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
+	"go.uber.org/zap"
+	istioclinet "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// GatewayName is the name of the Gateway serving the ingresses of the Verrazzano components
+	GatewayName = "verrazzano-gateway"
+
+	// renderedLabel marks the resources rendered from the Verrazzano ingresses
+	renderedLabel = "verrazzano.io/gateway-api-rendered"
+)
+
+// Reconciler renders the ingresses of the Verrazzano ingress class as Gateway API resources when the Gateway API
+// ingress backend is enabled in the Verrazzano resource. Every event results in a full sync of the rendered resources.
+type Reconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager creates a new controller and adds it to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("gatewayapi").
+		For(&netv1.Ingress{}).
+		Watches(&source.Kind{Type: &vzapi.Verrazzano{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: constants.IstioSystemNamespace, Name: GatewayName}}}
+		})).
+		Complete(r)
+}
+
+// Reconcile syncs the Gateway API resources with the ingresses of the Verrazzano ingress class
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	verrazzanos := &vzapi.VerrazzanoList{}
+	if err := r.List(ctx, verrazzanos); err != nil {
+		zap.S().Errorf("Failed to get Verrazzanos: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+	if len(verrazzanos.Items) == 0 || !vzcr.IsGatewayAPIEnabled(&verrazzanos.Items[0]) {
+		if err := r.deleteStale(ctx, map[string]bool{}); err != nil {
+			zap.S().Errorf("Failed to delete the Gateway API resources of the Verrazzano ingresses: %v", err)
+			return newRequeueWithDelay(), nil
+		}
+		return ctrl.Result{}, nil
+	}
+	vz := &verrazzanos.Items[0]
+	if err := r.sync(ctx, vz); err != nil {
+		zap.S().Errorf("Failed to render the Verrazzano ingresses as Gateway API resources: %v", err)
+		return newRequeueWithDelay(), nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// sync renders the shared Gateway, and the HTTPRoutes, ReferenceGrants and DestinationRules of the ingresses,
+// then deletes the rendered resources that are no longer desired
+func (r *Reconciler) sync(ctx context.Context, vz *vzapi.Verrazzano) error {
+	ingressList := &netv1.IngressList{}
+	if err := r.List(ctx, ingressList); err != nil {
+		return err
+	}
+	ingressClassName := vzconfig.GetIngressClassName(vz)
+	var ingresses []*netv1.Ingress
+	for i := range ingressList.Items {
+		ing := &ingressList.Items[i]
+		if ing.DeletionTimestamp.IsZero() && getIngressClassName(ing) == ingressClassName {
+			ingresses = append(ingresses, ing)
+		}
+	}
+
+	desired := map[string]bool{}
+	var listeners []gatewayapi.Listener
+	secrets := map[string][]string{}
+	hosts := map[string]bool{}
+	for _, ing := range ingresses {
+		for _, tls := range ing.Spec.TLS {
+			for _, host := range tls.Hosts {
+				if hosts[host] || tls.SecretName == "" {
+					continue
+				}
+				hosts[host] = true
+				listeners = append(listeners, gatewayapi.Listener{Hostname: host, SecretName: tls.SecretName, SecretNamespace: ing.Namespace})
+				secrets[ing.Namespace] = appendUnique(secrets[ing.Namespace], tls.SecretName)
+			}
+		}
+	}
+
+	gateway := gatewayapi.NewGateway(GatewayName, constants.IstioSystemNamespace)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, gateway, func() error {
+		setRenderedLabel(gateway)
+		return gatewayapi.SetGatewaySpec(gateway, vzconfig.GetGatewayClassName(vz), listeners)
+	}); err != nil {
+		return err
+	}
+	desired[renderedKey(gateway)] = true
+
+	// Allow the Gateway to use the TLS secrets of the ingresses in other namespaces
+	for ns, names := range secrets {
+		if ns == constants.IstioSystemNamespace {
+			continue
+		}
+		grant := gatewayapi.NewReferenceGrant(GatewayName, ns)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, grant, func() error {
+			setRenderedLabel(grant)
+			return gatewayapi.SetReferenceGrantSpec(grant, gatewayapi.GatewayGVK.Kind, constants.IstioSystemNamespace, "Secret", names)
+		}); err != nil {
+			return err
+		}
+		desired[renderedKey(grant)] = true
+	}
+
+	for _, ing := range ingresses {
+		keys, err := r.renderIngress(ctx, ing)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			desired[key] = true
+		}
+	}
+	return r.deleteStale(ctx, desired)
+}
+
+// deleteStale deletes the rendered resources that are not in the desired set. A missing Gateway API CRD means
+// that there is nothing to delete.
+func (r *Reconciler) deleteStale(ctx context.Context, desired map[string]bool) error {
+	selector := client.MatchingLabels{renderedLabel: "true"}
+	for _, gvk := range []schema.GroupVersionKind{gatewayapi.GatewayGVK, gatewayapi.HTTPRouteGVK, gatewayapi.ReferenceGrantGVK} {
+		list := gatewayapi.NewList(gvk)
+		if err := r.List(ctx, list, selector); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+		for i := range list.Items {
+			if err := r.deleteIfStale(ctx, &list.Items[i], desired); err != nil {
+				return err
+			}
+		}
+	}
+	destinationRules := &istioclinet.DestinationRuleList{}
+	if err := r.List(ctx, destinationRules, selector); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for _, dr := range destinationRules.Items {
+		if err := r.deleteIfStale(ctx, dr, desired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteIfStale deletes a rendered resource if it is not in the desired set
+func (r *Reconciler) deleteIfStale(ctx context.Context, obj client.Object, desired map[string]bool) error {
+	if desired[renderedKey(obj)] {
+		return nil
+	}
+	zap.S().Infof("Deleting Gateway API resource %s that no longer matches a Verrazzano ingress", renderedKey(obj))
+	if err := r.Delete(ctx, obj); err != nil && !isNotFoundOrNoMatch(err) {
+		return err
+	}
+	return nil
+}
+
+func setRenderedLabel(obj client.Object) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[renderedLabel] = "true"
+	obj.SetLabels(labels)
+}
+
+// renderedKey returns the key of a rendered resource in the desired set
+func renderedKey(obj client.Object) string {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return fmt.Sprintf("%s/%s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+	}
+	return fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())
+}
+
+// getIngressClassName returns the ingress class of an ingress, from the spec or the legacy annotation
+func getIngressClassName(ing *netv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations["kubernetes.io/ingress.class"]
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func isNotFoundOrNoMatch(err error) bool {
+	return k8serrors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+// Create a new Result that will cause reconcile to requeue after a short delay
+func newRequeueWithDelay() ctrl.Result {
+	return vzctrl.NewRequeueWithDelay(3, 5, time.Second)
+}
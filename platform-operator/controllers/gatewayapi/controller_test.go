@@ -0,0 +1,224 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	istioclinet "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testHost   = "keycloak.default.11.22.33.44.nip.io"
+	testSecret = "keycloak-tls"
+	testNS     = "keycloak"
+)
+
+// TestSyncIngresses tests rendering the Verrazzano ingresses as Gateway API resources
+// GIVEN a Verrazzano resource with the Gateway API enabled and an ingress of the Verrazzano ingress class
+// WHEN the controller reconciles
+// THEN a listener is added to the shared Gateway, the ingress is rendered as an HTTPRoute, the Gateway is granted
+// access to the TLS secret and the cookie affinity is rendered as a DestinationRule
+func TestSyncIngresses(t *testing.T) {
+	otherClass := "other"
+	other := newIngress("other", "/other")
+	other.Spec.IngressClassName = &otherClass
+	cli := buildFakeClient(newVerrazzano(true), newIngress("keycloak", "/()(.*)"), other)
+
+	res, err := newReconciler(cli).Reconcile(context.TODO(), ctrl.Request{})
+	assert.NoError(t, err)
+	assert.False(t, res.Requeue)
+
+	gateway := getObject(t, cli, gatewayapi.GatewayGVK, constants.IstioSystemNamespace, GatewayName)
+	className, _, _ := unstructured.NestedString(gateway.Object, "spec", "gatewayClassName")
+	assert.Equal(t, gatewayapi.DefaultGatewayClassName, className)
+	assert.Equal(t, []gatewayapi.Listener{{Name: gatewayapi.ListenerName(0), Hostname: testHost, SecretName: testSecret, SecretNamespace: testNS}}, gatewayapi.GetGatewayListeners(gateway))
+
+	route := getObject(t, cli, gatewayapi.HTTPRouteGVK, testNS, "keycloak-0")
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{testHost}, hostnames)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	pathType, _, _ := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "path", "type")
+	pathValue, _, _ := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "path", "value")
+	assert.Equal(t, gatewayapi.PathMatchPathPrefix, pathType)
+	assert.Equal(t, "/", pathValue)
+	backend := rule["backendRefs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "keycloak-http", backend["name"])
+	assert.Equal(t, int64(80), backend["port"])
+	assert.Len(t, route.GetOwnerReferences(), 1)
+
+	grant := getObject(t, cli, gatewayapi.ReferenceGrantGVK, testNS, GatewayName)
+	fromNS, _, _ := unstructured.NestedString(grant.Object["spec"].(map[string]interface{})["from"].([]interface{})[0].(map[string]interface{}), "namespace")
+	assert.Equal(t, constants.IstioSystemNamespace, fromNS)
+	toName, _, _ := unstructured.NestedString(grant.Object["spec"].(map[string]interface{})["to"].([]interface{})[0].(map[string]interface{}), "name")
+	assert.Equal(t, testSecret, toName)
+
+	dr := &istioclinet.DestinationRule{}
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Namespace: testNS, Name: "keycloak-keycloak-http"}, dr))
+	assert.Equal(t, "keycloak-http.keycloak.svc.cluster.local", dr.Spec.Host)
+	cookie := dr.Spec.TrafficPolicy.LoadBalancer.GetConsistentHash().GetHttpCookie()
+	assert.Equal(t, "keycloak", cookie.Name)
+	assert.Equal(t, "/", cookie.Path)
+	assert.Equal(t, 86400*time.Second, cookie.Ttl.AsDuration())
+
+	assertNotFound(t, cli, gatewayapi.HTTPRouteGVK, testNS, "other-0")
+}
+
+// TestDeleteStale tests deleting the rendered resources of an ingress that was deleted
+// GIVEN a Verrazzano resource with the Gateway API enabled and a rendered HTTPRoute without an ingress
+// WHEN the controller reconciles
+// THEN the HTTPRoute is deleted and resources that were not rendered by the controller are kept
+func TestDeleteStale(t *testing.T) {
+	stale := gatewayapi.NewHTTPRoute("deleted-0", testNS)
+	setRenderedLabel(stale)
+	foreign := gatewayapi.NewHTTPRoute("foreign", testNS)
+	cli := buildFakeClient(newVerrazzano(true), newIngress("keycloak", "/"), stale, foreign)
+
+	_, err := newReconciler(cli).Reconcile(context.TODO(), ctrl.Request{})
+	assert.NoError(t, err)
+	assertNotFound(t, cli, gatewayapi.HTTPRouteGVK, testNS, "deleted-0")
+	getObject(t, cli, gatewayapi.HTTPRouteGVK, testNS, "foreign")
+	getObject(t, cli, gatewayapi.HTTPRouteGVK, testNS, "keycloak-0")
+}
+
+// TestGatewayAPIDisabled tests disabling the Gateway API ingress backend
+// GIVEN a Verrazzano resource without the Gateway API enabled and previously rendered resources
+// WHEN the controller reconciles
+// THEN the rendered resources are deleted
+func TestGatewayAPIDisabled(t *testing.T) {
+	gateway := gatewayapi.NewGateway(GatewayName, constants.IstioSystemNamespace)
+	setRenderedLabel(gateway)
+	route := gatewayapi.NewHTTPRoute("keycloak-0", testNS)
+	setRenderedLabel(route)
+	dr := &istioclinet.DestinationRule{ObjectMeta: metav1.ObjectMeta{Name: "keycloak-keycloak-http", Namespace: testNS}}
+	setRenderedLabel(dr)
+	cli := buildFakeClient(newVerrazzano(false), newIngress("keycloak", "/"), gateway, route, dr)
+
+	_, err := newReconciler(cli).Reconcile(context.TODO(), ctrl.Request{})
+	assert.NoError(t, err)
+	assertNotFound(t, cli, gatewayapi.GatewayGVK, constants.IstioSystemNamespace, GatewayName)
+	assertNotFound(t, cli, gatewayapi.HTTPRouteGVK, testNS, "keycloak-0")
+	err = cli.Get(context.TODO(), client.ObjectKey{Namespace: testNS, Name: "keycloak-keycloak-http"}, &istioclinet.DestinationRule{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+// TestConvertPath tests converting ingress paths to HTTPRoute path matches
+// GIVEN ingress paths of the different path types
+// WHEN the paths are converted
+// THEN NGINX rewrite capture groups are dropped from path prefixes and other regular expressions are kept
+func TestConvertPath(t *testing.T) {
+	exact := netv1.PathTypeExact
+	prefix := netv1.PathTypePrefix
+	specific := netv1.PathTypeImplementationSpecific
+	tests := []struct {
+		path     string
+		pathType *netv1.PathType
+		expected gatewayapi.PathMatch
+	}{
+		{"", nil, gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: "/"}},
+		{"/healthz", &exact, gatewayapi.PathMatch{Type: gatewayapi.PathMatchExact, Value: "/healthz"}},
+		{"/api", &prefix, gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: "/api"}},
+		{"/()(.*)", &specific, gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: "/"}},
+		{"/grafana(/|$)(.*)", &specific, gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: "/grafana"}},
+		{"/api/v[0-9]+/.*", &specific, gatewayapi.PathMatch{Type: gatewayapi.PathMatchRegularExpression, Value: "/api/v[0-9]+/.*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, convertPath(tt.path, tt.pathType))
+		})
+	}
+}
+
+func newReconciler(cli client.Client) *Reconciler {
+	return &Reconciler{Client: cli, Scheme: newScheme()}
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = k8scheme.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	_ = istioclinet.AddToScheme(scheme)
+	return scheme
+}
+
+func buildFakeClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objs...).Build()
+}
+
+func newVerrazzano(enabled bool) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Name: "verrazzano", Namespace: "default"},
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				GatewayAPI: &vzapi.GatewayAPIComponent{Enabled: &enabled},
+			},
+		},
+	}
+}
+
+func newIngress(name, path string) *netv1.Ingress {
+	className := "verrazzano-nginx"
+	pathType := netv1.PathTypeImplementationSpecific
+	return &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNS,
+			Annotations: common.SameSiteCookieAnnotations("keycloak"),
+		},
+		Spec: netv1.IngressSpec{
+			IngressClassName: &className,
+			TLS:              []netv1.IngressTLS{{Hosts: []string{testHost}, SecretName: testSecret}},
+			Rules: []netv1.IngressRule{{
+				Host: testHost,
+				IngressRuleValue: netv1.IngressRuleValue{
+					HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: &pathType,
+							Backend: netv1.IngressBackend{
+								Service: &netv1.IngressServiceBackend{
+									Name: "keycloak-http",
+									Port: netv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func getObject(t *testing.T, cli client.Client, gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	assert.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, obj))
+	return obj
+}
+
+func assertNotFound(t *testing.T, cli client.Client, gvk schema.GroupVersionKind, namespace, name string) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := cli.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, obj)
+	assert.True(t, k8serrors.IsNotFound(err), "expected %s %s/%s to be deleted", gvk.Kind, namespace, name)
+}
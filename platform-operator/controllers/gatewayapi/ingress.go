@@ -0,0 +1,183 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"google.golang.org/protobuf/types/known/durationpb"
+	istionet "istio.io/api/networking/v1alpha3"
+	istioclinet "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	affinityAnnotation     = "nginx.ingress.kubernetes.io/affinity"
+	cookieNameAnnotation   = "nginx.ingress.kubernetes.io/session-cookie-name"
+	cookiePathAnnotation   = "nginx.ingress.kubernetes.io/session-cookie-path"
+	cookieMaxAgeAnnotation = "nginx.ingress.kubernetes.io/session-cookie-max-age"
+
+	// defaultCookieName is the session cookie name of ingress NGINX
+	defaultCookieName = "INGRESSCOOKIE"
+
+	// regexChars are the characters that make an ingress path a regular expression
+	regexChars = "()[]{}*+?|^$\\"
+)
+
+// rewriteSuffixes are the capture groups the Verrazzano ingresses append to a path prefix for the NGINX rewrite target
+var rewriteSuffixes = []string{"()(.*)", "(/|$)(.*)", "(.*)"}
+
+// renderIngress renders an HTTPRoute per rule of the ingress and a cookie affinity DestinationRule per backend when
+// the ingress uses NGINX cookie affinity. The keys of the rendered resources are returned.
+func (r *Reconciler) renderIngress(ctx context.Context, ing *netv1.Ingress) ([]string, error) {
+	var keys []string
+	backends := map[string]bool{}
+	for i, ingRule := range ing.Spec.Rules {
+		if ingRule.HTTP == nil {
+			continue
+		}
+		var rules []gatewayapi.RouteRule
+		for _, path := range ingRule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			port, err := r.getBackendPort(ctx, ing.Namespace, path.Backend.Service)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, gatewayapi.RouteRule{
+				Paths:   []gatewayapi.PathMatch{convertPath(path.Path, path.PathType)},
+				Backend: gatewayapi.Backend{Name: path.Backend.Service.Name, Port: port},
+			})
+			backends[path.Backend.Service.Name] = true
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		var hostnames []string
+		if ingRule.Host != "" {
+			hostnames = append(hostnames, ingRule.Host)
+		}
+		route := gatewayapi.NewHTTPRoute(fmt.Sprintf("%s-%d", ing.Name, i), ing.Namespace)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+			setRenderedLabel(route)
+			if err := gatewayapi.SetHTTPRouteSpec(route, GatewayName, constants.IstioSystemNamespace, hostnames, rules); err != nil {
+				return err
+			}
+			return controllerutil.SetOwnerReference(ing, route, r.Scheme)
+		}); err != nil {
+			return nil, err
+		}
+		keys = append(keys, renderedKey(route))
+	}
+
+	if ing.Annotations[affinityAnnotation] != "cookie" {
+		return keys, nil
+	}
+	for backend := range backends {
+		dr := &istioclinet.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", ing.Name, backend), Namespace: ing.Namespace},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, dr, func() error {
+			setRenderedLabel(dr)
+			dr.Spec = newCookieDestinationRule(ing, backend)
+			return controllerutil.SetOwnerReference(ing, dr, r.Scheme)
+		}); err != nil {
+			return nil, err
+		}
+		keys = append(keys, renderedKey(dr))
+	}
+	return keys, nil
+}
+
+// getBackendPort returns the port number of an ingress service backend, resolving a named port from the service
+func (r *Reconciler) getBackendPort(ctx context.Context, namespace string, backend *netv1.IngressServiceBackend) (int32, error) {
+	if backend.Port.Name == "" {
+		return backend.Port.Number, nil
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: backend.Name}, svc); err != nil {
+		if isNotFoundOrNoMatch(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == backend.Port.Name {
+			return port.Port, nil
+		}
+	}
+	return 0, nil
+}
+
+// convertPath converts an ingress path to an HTTPRoute path match. The NGINX rewrite capture groups of a path
+// prefix are dropped, since the HTTPRoute forwards the request path unchanged.
+func convertPath(path string, pathType *netv1.PathType) gatewayapi.PathMatch {
+	if path == "" {
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: "/"}
+	}
+	if pathType != nil {
+		switch *pathType {
+		case netv1.PathTypeExact:
+			return gatewayapi.PathMatch{Type: gatewayapi.PathMatchExact, Value: path}
+		case netv1.PathTypePrefix:
+			return gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: path}
+		}
+	}
+	if !strings.ContainsAny(path, regexChars) {
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: path}
+	}
+	for _, suffix := range rewriteSuffixes {
+		prefix := strings.TrimSuffix(path, suffix)
+		if prefix == path || strings.ContainsAny(prefix, regexChars) {
+			continue
+		}
+		if prefix == "" {
+			prefix = "/"
+		}
+		return gatewayapi.PathMatch{Type: gatewayapi.PathMatchPathPrefix, Value: prefix}
+	}
+	return gatewayapi.PathMatch{Type: gatewayapi.PathMatchRegularExpression, Value: path}
+}
+
+// newCookieDestinationRule returns a DestinationRule with the cookie affinity of the NGINX annotations of an ingress
+func newCookieDestinationRule(ing *netv1.Ingress, backend string) istionet.DestinationRule {
+	cookie := &istionet.LoadBalancerSettings_ConsistentHashLB_HTTPCookie{
+		Name: ing.Annotations[cookieNameAnnotation],
+		Path: ing.Annotations[cookiePathAnnotation],
+	}
+	if cookie.Name == "" {
+		cookie.Name = defaultCookieName
+	}
+	if cookie.Path == "" {
+		cookie.Path = "/"
+	}
+	if maxAge, err := strconv.Atoi(ing.Annotations[cookieMaxAgeAnnotation]); err == nil && maxAge > 0 {
+		cookie.Ttl = durationpb.New(time.Duration(maxAge) * time.Second)
+	}
+	return istionet.DestinationRule{
+		Host: fmt.Sprintf("%s.%s.svc.cluster.local", backend, ing.Namespace),
+		TrafficPolicy: &istionet.TrafficPolicy{
+			LoadBalancer: &istionet.LoadBalancerSettings{
+				LbPolicy: &istionet.LoadBalancerSettings_ConsistentHash{
+					ConsistentHash: &istionet.LoadBalancerSettings_ConsistentHashLB{
+						HashKey: &istionet.LoadBalancerSettings_ConsistentHashLB_HttpCookie{
+							HttpCookie: cookie,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -63,8 +63,9 @@ func (r *VerrazzanoSecretsReconciler) reconcileVerrazzanoTLS(ctx context.Context
 		return newRequeueWithDelay(), nil
 	}
 
-	// Update the Rancher TLS CA secret with the CA in Verrazzano TLS Secret
-	if isVzIngressSecret {
+	// Update the Rancher TLS CA secret with the CA in Verrazzano TLS Secret.  Certificates issued by ACME issuers have
+	// no CA, the Rancher TLS CA secret of an ACME issuer with a private CA is kept
+	if isVzIngressSecret && len(caSecret.Data[caKey]) > 0 {
 		result, err := r.updateSecret(vzconst.RancherSystemNamespace, vzconst.RancherTLSCA,
 			vzconst.RancherTLSCAKey, caKey, caSecret, false)
 		if err != nil {
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package appoper
@@ -14,9 +14,11 @@ import (
 	oamv1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/verrazzano/verrazzano/pkg/bom"
 	"github.com/verrazzano/verrazzano/pkg/k8s/ready"
+	"github.com/verrazzano/verrazzano/pkg/vzcr"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -100,6 +102,18 @@ func AppendApplicationOperatorOverrides(compContext spi.ComponentContext, _ stri
 		Value: weblogicMonitoringExporterImage,
 	})
 
+	// Render the ingress traits as Gateway API resources, for ENV GATEWAY_API_ENABLED and GATEWAY_CLASS_NAME
+	if compContext != nil && vzcr.IsGatewayAPIEnabled(compContext.EffectiveCR()) {
+		kvs = append(kvs, bom.KeyValue{
+			Key:   "gatewayAPI.enabled",
+			Value: "true",
+		})
+		kvs = append(kvs, bom.KeyValue{
+			Key:   "gatewayAPI.gatewayClassName",
+			Value: vzconfig.GetGatewayClassName(compContext.EffectiveCR()),
+		})
+	}
+
 	return kvs, nil
 }
 
@@ -1,4 +1,4 @@
-// Copyright (c) 2021, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2021, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package appoper
@@ -12,6 +12,7 @@ import (
 	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core"
 	oamv1alpha2 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	"github.com/stretchr/testify/assert"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
@@ -67,6 +68,35 @@ func TestAppendAppOperatorOverrides(t *testing.T) {
 	a.Equalf(expectedWeblogicMonitoringExporterImage, kvs[3].Value, "Did not get expected weblogicMonitoringExporterImage Value")
 }
 
+// TestAppendAppOperatorGatewayAPIOverrides tests the Gateway API overrides of the application operator
+// GIVEN a Verrazzano resource with the Gateway API enabled
+//
+//	WHEN I call AppendApplicationOperatorOverrides
+//	THEN the gatewayAPI Keys are set with the Gateway API configuration.
+func TestAppendAppOperatorGatewayAPIOverrides(t *testing.T) {
+	a := assert.New(t)
+
+	config.SetDefaultBomFilePath(testBomFilePath)
+
+	enabled := true
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				GatewayAPI: &vzapi.GatewayAPIComponent{Enabled: &enabled, GatewayClassName: "test-class"},
+			},
+		},
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false)
+	kvs, err := AppendApplicationOperatorOverrides(ctx, "", "", "", nil)
+	a.NoError(err, "AppendApplicationOperatorOverrides returned an error ")
+	values := map[string]string{}
+	for _, kv := range kvs {
+		values[kv.Key] = kv.Value
+	}
+	a.Equal("true", values["gatewayAPI.enabled"])
+	a.Equal("test-class", values["gatewayAPI.gatewayClassName"])
+}
+
 // TestIsApplicationOperatorReady tests the isApplicationOperatorReady function
 // GIVEN a call to isApplicationOperatorReady
 //
@@ -26,10 +26,13 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/vzcr"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	cmcommon "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,28 +55,20 @@ const (
 	letsEncryptProdEndpoint  = "https://acme-v02.api.letsencrypt.org/directory"
 	letsEncryptStageEndpoint = "https://acme-staging-v02.api.letsencrypt.org/directory"
 
-	certRequestNameAnnotation = "cert-manager.io/certificate-name"
-
-	// InstancePrincipal is used for instance principle auth type
-	instancePrincipal authenticationType = "instance_principal"
-)
+	// ACME issuer constants
+	acmeCABundleSecretKey = "ca.crt"
+	acmeEABSecretKey      = "secret"
 
-type authenticationType string
+	// Vault issuer constants
+	vaultAppRoleSecretKey           = "secretId" //nolint:gosec //#gosec G101
+	vaultCABundleSecretKey          = "ca.crt"
+	vaultDefaultAppRolePath         = "approle"
+	vaultDefaultKubernetesMountPath = "/v1/auth/kubernetes"
+	vaultServiceAccountName         = "verrazzano-vault-issuer"
+	vaultTokenSecretName            = "verrazzano-vault-issuer-token" //nolint:gosec //#gosec G101
 
-// OCI DNS Secret Auth
-type authData struct {
-	Region      string             `json:"region"`
-	Tenancy     string             `json:"tenancy"`
-	User        string             `json:"user"`
-	Key         string             `json:"key"`
-	Fingerprint string             `json:"fingerprint"`
-	AuthType    authenticationType `json:"authtype"`
-}
-
-// OCI DNS Secret Auth Wrapper
-type ociAuth struct {
-	Auth authData `json:"auth"`
-}
+	certRequestNameAnnotation = "cert-manager.io/certificate-name"
+)
 
 var (
 	// Statically define the well-known Let's Encrypt CA Common Names
@@ -100,29 +95,14 @@ spec:
     server: "{{.Server}}"
     preferredChain: ""
     privateKeySecretRef:
-      name: {{.AcmeSecretName}}
-    solvers:
-      - dns01:
-          webhook:
-            groupName: verrazzano.io
-            solverName: oci
-            config:
-              compartmentOCID: {{ .CompartmentOCID }}
-              useInstancePrincipals: {{ .UseInstancePrincipals }}
-              ociProfileSecretName: {{.SecretName}}
-              ociProfileSecretKey: "oci.yaml"
-              ociZoneName: {{.OCIZoneName}}`
+      name: {{.AcmeSecretName}}`
 
 // Template data for ClusterIssuer
 type templateData struct {
-	AcmeSecretName        string
-	ClusterIssuerName     string
-	Email                 string
-	Server                string
-	SecretName            string
-	OCIZoneName           string
-	CompartmentOCID       string
-	UseInstancePrincipals bool
+	AcmeSecretName    string
+	ClusterIssuerName string
+	Email             string
+	Server            string
 }
 
 // CertIssuerType identifies the certificate issuer type
@@ -347,7 +327,7 @@ func createOrUpdateAcmeResources(log vzlog.VerrazzanoLogger, client crtclient.Cl
 		return opResult, err
 	}
 	// Update or create the unstructured object
-	log.Debug("Applying ClusterIssuer with OCI DNS")
+	log.Debug("Applying ClusterIssuer with managed DNS")
 	if opResult, err = controllerutil.CreateOrUpdate(context.TODO(), client, getCIObject, func() error {
 		ciObject, err := createACMEIssuerObject(log, client, vz, config)
 		if err != nil {
@@ -362,24 +342,22 @@ func createOrUpdateAcmeResources(log vzlog.VerrazzanoLogger, client crtclient.Cl
 }
 
 func createACMEIssuerObject(log vzlog.VerrazzanoLogger, client crtclient.Client, vz *vzapi.Verrazzano, config *vzapi.ClusterIssuerComponent) (*unstructured.Unstructured, error) {
-	// Initialize Acme variables for the cluster issuer
-	var ociDNSConfigSecret string
-	var ociDNSZoneName string
-	var ociDNSCompartmentID string
-	vzDNS := vz.Spec.Components.DNS
-	vzCertAcme := config.LetsEncrypt
-	if vzDNS != nil && vzDNS.OCI != nil {
-		ociDNSConfigSecret = vzDNS.OCI.OCIConfigSecret
-		ociDNSZoneName = vzDNS.OCI.DNSZoneName
-		ociDNSCompartmentID = vzDNS.OCI.DNSZoneCompartmentOCID
+	if config.ACME != nil {
+		return createCustomACMEIssuerObject(log, client, vz, config)
 	}
-	// Verify that the secret exists
-	secret := v1.Secret{}
-	if err := client.Get(context.TODO(), crtclient.ObjectKey{Name: ociDNSConfigSecret, Namespace: config.ClusterResourceNamespace}, &secret); err != nil {
-		return nil, log.ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
+
+	// The DNS01 challenges are solved with the managed DNS provider
+	dnsProvider := common.GetDNSProvider(vz)
+	if dnsProvider == nil {
+		return nil, log.ErrorfNewErr("Failed, the LetsEncrypt ClusterIssuer requires OCI or RFC2136 DNS")
+	}
+	solver, err := dnsProvider.DNS01Solver(log, client, config.ClusterResourceNamespace)
+	if err != nil {
+		return nil, err
 	}
 
 	// Verify the acme environment and set the server
+	vzCertAcme := config.LetsEncrypt
 	acmeServer := letsEncryptProdEndpoint
 	if cmcommon.IsLetsEncryptStagingEnv(*vzCertAcme) {
 		acmeServer = letsEncryptStageEndpoint
@@ -391,24 +369,86 @@ func createACMEIssuerObject(log vzlog.VerrazzanoLogger, client crtclient.Client,
 		AcmeSecretName:    caAcmeSecretName,
 		Email:             vzCertAcme.EmailAddress,
 		Server:            acmeServer,
-		SecretName:        ociDNSConfigSecret,
-		OCIZoneName:       ociDNSZoneName,
-		CompartmentOCID:   ociDNSCompartmentID,
 	}
 
-	for key := range secret.Data {
-		var authProp ociAuth
-		if err := yaml.Unmarshal(secret.Data[key], &authProp); err != nil {
-			return nil, err
+	ciObject, err := createAcmeClusterIssuer(log, clusterIssuerData)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAcmeSolver(ciObject, solver); err != nil {
+		return nil, log.ErrorfNewErr("Failed to set the ClusterIssuer DNS01 solver: %v", err)
+	}
+	return ciObject, nil
+}
+
+// createCustomACMEIssuerObject creates the ClusterIssuer object for an ACME server other than LetsEncrypt
+func createCustomACMEIssuerObject(log vzlog.VerrazzanoLogger, client crtclient.Client, vz *vzapi.Verrazzano, config *vzapi.ClusterIssuerComponent) (*unstructured.Unstructured, error) {
+	acme := config.ACME
+	solver, err := getCustomACMESolver(log, client, vz, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ciObject, err := createAcmeClusterIssuer(log, templateData{
+		ClusterIssuerName: constants.VerrazzanoClusterIssuerName,
+		AcmeSecretName:    caAcmeSecretName,
+		Email:             acme.EmailAddress,
+		Server:            acme.Server,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(acme.EmailAddress) == 0 {
+		unstructured.RemoveNestedField(ciObject.Object, "spec", "acme", "email")
+	}
+	if acme.SkipTLSVerify {
+		if err := unstructured.SetNestedField(ciObject.Object, true, "spec", "acme", "skipTLSVerify"); err != nil {
+			return nil, log.ErrorfNewErr("Failed to set the ClusterIssuer skipTLSVerify field: %v", err)
 		}
-		if authProp.Auth.AuthType == instancePrincipal {
-			clusterIssuerData.UseInstancePrincipals = true
-			break
+	}
+	if eab := acme.ExternalAccountBinding; eab != nil {
+		eabObject := map[string]interface{}{
+			"keyID": eab.KeyID,
+			"keySecretRef": map[string]interface{}{
+				"name": eab.KeySecretName,
+				"key":  acmeEABSecretKey,
+			},
+		}
+		if err := unstructured.SetNestedMap(ciObject.Object, eabObject, "spec", "acme", "externalAccountBinding"); err != nil {
+			return nil, log.ErrorfNewErr("Failed to set the ClusterIssuer external account binding: %v", err)
 		}
 	}
+	if err := setAcmeSolver(ciObject, solver); err != nil {
+		return nil, log.ErrorfNewErr("Failed to set the ClusterIssuer solver: %v", err)
+	}
+	return ciObject, nil
+}
 
-	ciObject, err := createAcmeClusterIssuer(log, clusterIssuerData)
-	return ciObject, err
+// getCustomACMESolver returns the HTTP01 solver using the Verrazzano ingress class, or the DNS01 solver of the
+// managed DNS provider
+func getCustomACMESolver(log vzlog.VerrazzanoLogger, client crtclient.Client, vz *vzapi.Verrazzano, config *vzapi.ClusterIssuerComponent) (*acmev1.ACMEChallengeSolver, error) {
+	if config.ACME.Solver == vzapi.ACMESolverHTTP01 {
+		ingressClassName := vzconfig.GetIngressClassName(vz)
+		return &acmev1.ACMEChallengeSolver{
+			HTTP01: &acmev1.ACMEChallengeSolverHTTP01{
+				Ingress: &acmev1.ACMEChallengeSolverHTTP01Ingress{Class: &ingressClassName},
+			},
+		}, nil
+	}
+	dnsProvider := common.GetDNSProvider(vz)
+	if dnsProvider == nil {
+		return nil, log.ErrorfNewErr("Failed, the ACME ClusterIssuer DNS01 solver requires OCI or RFC2136 DNS")
+	}
+	return dnsProvider.DNS01Solver(log, client, config.ClusterResourceNamespace)
+}
+
+// setAcmeSolver sets the solver of the ACME ClusterIssuer
+func setAcmeSolver(ciObject *unstructured.Unstructured, solver *acmev1.ACMEChallengeSolver) error {
+	solverObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(solver)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedSlice(ciObject.Object, []interface{}{solverObject}, "spec", "acme", "solvers")
 }
 
 func createAcmeClusterIssuer(log vzlog.VerrazzanoLogger, clusterIssuerData templateData) (*unstructured.Unstructured, error) {
@@ -527,6 +567,111 @@ func createOrUpdateCAResources(log vzlog.VerrazzanoLogger, crtClient crtclient.C
 	return opResult, nil
 }
 
+// createOrUpdateVaultResources Create or update the Vault ClusterIssuer
+// - returns OperationResultNone/error on error
+// - returns OperationResultCreated/nil if the CI is created (initial install)
+// - returns OperationResultUpdated/nil if the CI is updated
+func createOrUpdateVaultResources(log vzlog.VerrazzanoLogger, crtClient crtclient.Client, issuerConfig *vzapi.ClusterIssuerComponent) (controllerutil.OperationResult, error) {
+	vault := issuerConfig.Vault
+	vaultIssuer := &certv1.VaultIssuer{
+		Server:    vault.Server,
+		Path:      vault.Path,
+		Namespace: vault.Namespace,
+	}
+
+	if len(vault.CABundleSecret) > 0 {
+		secret := v1.Secret{}
+		if err := crtClient.Get(context.TODO(), crtclient.ObjectKey{Name: vault.CABundleSecret, Namespace: issuerConfig.ClusterResourceNamespace}, &secret); err != nil {
+			return controllerutil.OperationResultNone, log.ErrorfNewErr("Failed to get the Vault CA bundle secret %s: %v", vault.CABundleSecret, err)
+		}
+		vaultIssuer.CABundle = secret.Data[vaultCABundleSecretKey]
+	}
+
+	if vault.Kubernetes != nil {
+		if err := createOrUpdateVaultServiceAccount(log, crtClient, issuerConfig.ClusterResourceNamespace); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		mountPath := vault.Kubernetes.MountPath
+		if len(mountPath) == 0 {
+			mountPath = vaultDefaultKubernetesMountPath
+		}
+		vaultIssuer.Auth.Kubernetes = &certv1.VaultKubernetesAuth{
+			Path: mountPath,
+			Role: vault.Kubernetes.Role,
+			SecretRef: certmetav1.SecretKeySelector{
+				LocalObjectReference: certmetav1.LocalObjectReference{Name: vaultTokenSecretName},
+				Key:                  v1.ServiceAccountTokenKey,
+			},
+		}
+	} else if vault.AppRole != nil {
+		path := vault.AppRole.Path
+		if len(path) == 0 {
+			path = vaultDefaultAppRolePath
+		}
+		vaultIssuer.Auth.AppRole = &certv1.VaultAppRole{
+			Path:   path,
+			RoleId: vault.AppRole.RoleID,
+			SecretRef: certmetav1.SecretKeySelector{
+				LocalObjectReference: certmetav1.LocalObjectReference{Name: vault.AppRole.SecretName},
+				Key:                  vaultAppRoleSecretKey,
+			},
+		}
+	}
+
+	log.Debug("Applying ClusterIssuer for Vault")
+	clusterIssuer := certv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constants.VerrazzanoClusterIssuerName,
+		},
+	}
+	opResult, err := controllerutil.CreateOrUpdate(context.TODO(), crtClient, &clusterIssuer, func() error {
+		clusterIssuer.Spec = certv1.IssuerSpec{
+			IssuerConfig: certv1.IssuerConfig{
+				Vault: vaultIssuer,
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return opResult, log.ErrorfNewErr("Failed to create or update the ClusterIssuer: %v", err)
+	}
+	return opResult, nil
+}
+
+// createOrUpdateVaultServiceAccount creates the service account of the Vault Kubernetes authentication, and the
+// secret with its token
+func createOrUpdateVaultServiceAccount(log vzlog.VerrazzanoLogger, crtClient crtclient.Client, namespace string) error {
+	serviceAccount := v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vaultServiceAccountName,
+			Namespace: namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), crtClient, &serviceAccount, func() error {
+		return nil
+	}); err != nil {
+		return log.ErrorfNewErr("Failed to create or update the Vault issuer service account: %v", err)
+	}
+
+	tokenSecret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vaultTokenSecretName,
+			Namespace: namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), crtClient, &tokenSecret, func() error {
+		if tokenSecret.Annotations == nil {
+			tokenSecret.Annotations = map[string]string{}
+		}
+		tokenSecret.Annotations[v1.ServiceAccountNameKey] = vaultServiceAccountName
+		tokenSecret.Type = v1.SecretTypeServiceAccountToken
+		return nil
+	}); err != nil {
+		return log.ErrorfNewErr("Failed to create or update the Vault issuer service account token secret: %v", err)
+	}
+	return nil
+}
+
 func findIssuerCommonName(config *vzapi.ClusterIssuerComponent) ([]string, error) {
 	isCAIssuer, err := config.IsCAIssuer()
 	if err != nil {
@@ -608,16 +753,22 @@ func cleanupUnusedResourcesForNamespace(compContext spi.ComponentContext, cluste
 	client := compContext.Client()
 	log := compContext.Log()
 	defaultCANotUsed := func() bool {
-		// We're not using the default CA if we're either configured for ACME or it's a Customer-provided CA
+		// We're not using the default CA if we're either configured for ACME or Vault, or it's a Customer-provided CA
 		return !isCAValue || clusterIssuer.CA.SecretName != constants.DefaultVerrazzanoCASecretName
 	}
-	if isCAValue {
+	if clusterIssuer.LetsEncrypt == nil && clusterIssuer.ACME == nil {
 		log.Oncef("Clean up ACME issuer secret")
 		// clean up ACME secret if present
 		if err := deleteObject(client, caAcmeSecretName, clusterResourceNamespace, &v1.Secret{}); err != nil {
 			return err
 		}
 	}
+	if clusterIssuer.Vault == nil || clusterIssuer.Vault.Kubernetes == nil {
+		// clean up the Vault Kubernetes authentication service account if present
+		if err := deleteVaultServiceAccount(client, clusterResourceNamespace); err != nil {
+			return err
+		}
+	}
 	if defaultCANotUsed() {
 		// Issuer is either the default or Custom issuer; clean up the default Verrazzano issuer resources
 		// - self-signed Issuer object
@@ -637,6 +788,14 @@ func cleanupUnusedResourcesForNamespace(compContext spi.ComponentContext, cluste
 	return nil
 }
 
+// deleteVaultServiceAccount deletes the service account of the Vault Kubernetes authentication and its token secret
+func deleteVaultServiceAccount(client crtclient.Client, namespace string) error {
+	if err := deleteObject(client, vaultTokenSecretName, namespace, &v1.Secret{}); err != nil {
+		return err
+	}
+	return deleteObject(client, vaultServiceAccountName, namespace, &v1.ServiceAccount{})
+}
+
 func (c clusterIssuerComponent) createOrUpdateClusterIssuer(compContext spi.ComponentContext) error {
 
 	effectiveCR := compContext.EffectiveCR()
@@ -651,7 +810,12 @@ func (c clusterIssuerComponent) createOrUpdateClusterIssuer(compContext spi.Comp
 	}
 
 	var opResult controllerutil.OperationResult
-	if !isCAValue {
+	if clusterIssuerConfig.Vault != nil {
+		// Create resources needed for Vault certificates
+		if opResult, err = createOrUpdateVaultResources(compContext.Log(), compContext.Client(), clusterIssuerConfig); err != nil {
+			return compContext.Log().ErrorfNewErr("Failed creating Vault resources: %v", err)
+		}
+	} else if !isCAValue {
 		// Create resources needed for Acme certificates
 		if opResult, err = createOrUpdateAcmeResources(compContext.Log(), compContext.Client(), effectiveCR, clusterIssuerConfig); err != nil {
 			return compContext.Log().ErrorfNewErr("Failed creating Acme resources: %v", err)
@@ -668,18 +832,21 @@ func (c clusterIssuerComponent) createOrUpdateClusterIssuer(compContext spi.Comp
 		// We're in the initial install phase, and created the ClusterIssuer for the first time,
 		// so skip the renewal checks
 		compContext.Log().Oncef("Initial install, skipping certificate renewal checks")
-		return nil
+		return reissueComponentCertificates(compContext, opResult)
 	}
 	// CertManager configuration was updated, cleanup any old resources from previous configuration
 	// and renew certificates against the new ClusterIssuer
 	if err := cleanupUnusedResources(compContext, isCAValue); err != nil {
 		return err
 	}
-	if err := checkRenewAllCertificates(compContext.Log(), compContext.Client(), clusterIssuerConfig); err != nil {
-		compContext.Log().Errorf("Error requesting certificate renewal: %s", err.Error())
-		return err
+	if clusterIssuerConfig.CA != nil || clusterIssuerConfig.LetsEncrypt != nil {
+		// The issuer common names are only known for the CA and LetsEncrypt issuers
+		if err := checkRenewAllCertificates(compContext.Log(), compContext.Client(), clusterIssuerConfig); err != nil {
+			compContext.Log().Errorf("Error requesting certificate renewal: %s", err.Error())
+			return err
+		}
 	}
-	return nil
+	return reissueComponentCertificates(compContext, opResult)
 }
 
 // uninstallVerrazzanoCertManagerResources is the implementation for the cert-manager uninstall step
@@ -714,7 +881,9 @@ func (c clusterIssuerComponent) uninstallVerrazzanoCertManagerResources(compCont
 	if err != nil {
 		return err
 	}
-	return nil
+
+	// Delete the Vault service account if present
+	return deleteVaultServiceAccount(compContext.Client(), issuerConfig.ClusterResourceNamespace)
 }
 
 func (c clusterIssuerComponent) deleteCertManagerIssuerResources(log vzlog.VerrazzanoLogger, client crtclient.Client, config *vzapi.ClusterIssuerComponent) error {
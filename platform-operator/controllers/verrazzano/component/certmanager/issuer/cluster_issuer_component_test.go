@@ -5,10 +5,12 @@ package issuer
 
 import (
 	"context"
+	"encoding/json"
 	cmconstants "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager/constants"
 	"testing"
 
 	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	certv1fake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
@@ -24,10 +26,12 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/networkpolicies"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1fake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	apiextv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -342,10 +346,9 @@ auth:
 		Email:          acme.EmailAddress,
 		AcmeSecretName: caAcmeSecretName,
 		Server:         acme.Environment,
-		SecretName:     oci.OCIConfigSecret,
-		OCIZoneName:    oci.DNSZoneName,
 	}
 	existingIssuer, _ := createAcmeClusterIssuer(vzlog.DefaultLogger(), existingIssuerTemplateData)
+	_ = setAcmeSolver(existingIssuer, newOCIWebhookSolver(oci, false))
 
 	updatedVz := defaultVZConfig.DeepCopy()
 	newAcme := vzapi.Acme{
@@ -363,17 +366,12 @@ auth:
 	updatedVz.Spec.Components.DNS = &vzapi.DNSComponent{OCI: newOCI}
 
 	expectedIssuerTemplateData := templateData{
-		Email:           newAcme.EmailAddress,
-		AcmeSecretName:  caAcmeSecretName,
-		Server:          letsEncryptProdEndpoint,
-		SecretName:      newOCI.OCIConfigSecret,
-		OCIZoneName:     newOCI.DNSZoneName,
-		CompartmentOCID: newCompartmentOCID,
-	}
-	if useIPInSecret {
-		expectedIssuerTemplateData.UseInstancePrincipals = true
+		Email:          newAcme.EmailAddress,
+		AcmeSecretName: caAcmeSecretName,
+		Server:         letsEncryptProdEndpoint,
 	}
 	expectedIssuer, _ := createAcmeClusterIssuer(vzlog.DefaultLogger(), expectedIssuerTemplateData)
+	_ = setAcmeSolver(expectedIssuer, newOCIWebhookSolver(newOCI, useIPInSecret))
 
 	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(localvz, oldSecret, newSecret, existingIssuer).Build()
 	ctx := spi.NewFakeContext(client, updatedVz, nil, false, profileDir)
@@ -392,6 +390,172 @@ auth:
 	assert.Equal(t, expectedIssuer.Object["spec"], actualIssuer.Object["spec"])
 }
 
+// newOCIWebhookSolver returns the expected DNS01 solver of the Verrazzano OCI DNS webhook
+func newOCIWebhookSolver(oci *vzapi.OCI, useInstancePrincipals bool) *acmev1.ACMEChallengeSolver {
+	config, _ := json.Marshal(map[string]interface{}{
+		"compartmentOCID":       oci.DNSZoneCompartmentOCID,
+		"useInstancePrincipals": useInstancePrincipals,
+		"ociProfileSecretName":  oci.OCIConfigSecret,
+		"ociProfileSecretKey":   "oci.yaml",
+		"ociZoneName":           oci.DNSZoneName,
+	})
+	return &acmev1.ACMEChallengeSolver{
+		DNS01: &acmev1.ACMEChallengeSolverDNS01{
+			Webhook: &acmev1.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  "verrazzano.io",
+				SolverName: "oci",
+				Config:     &apiextensionsv1.JSON{Raw: config},
+			},
+		},
+	}
+}
+
+// TestClusterIssuerUpdatedRFC2136 tests the createACMEIssuerObject function
+// GIVEN a LetsEncrypt configuration with RFC2136 DNS
+// WHEN the ACME ClusterIssuer object is created
+// THEN the TSIG secret is copied to the cluster resource namespace and the ClusterIssuer uses the cert-manager RFC2136
+// solver with the TSIG key of the secret
+func TestClusterIssuerUpdatedRFC2136(t *testing.T) {
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.ClusterIssuer = &vzapi.ClusterIssuerComponent{
+		ClusterResourceNamespace: ComponentNamespace,
+		IssuerConfig: vzapi.IssuerConfig{
+			LetsEncrypt: &vzapi.LetsEncryptACMEIssuer{EmailAddress: "foo@bar.com", Environment: letsEncryptStaging},
+		},
+	}
+	localvz.Spec.Components.DNS = &vzapi.DNSComponent{RFC2136: &vzapi.RFC2136{
+		Nameserver:    "10.0.0.10",
+		Port:          5353,
+		DNSZoneName:   testDNSDomain,
+		TSIGKeyName:   "externaldns-key",
+		TSIGAlgorithm: "hmac-sha512",
+		TSIGSecret:    "tsig-secret",
+	}}
+	tsigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig-secret", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{"secret": []byte("c2VjcmV0")},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(tsigSecret).Build()
+
+	issuer, err := createACMEIssuerObject(vzlog.DefaultLogger(), client, localvz, localvz.Spec.Components.ClusterIssuer)
+	assert.NoError(t, err)
+	server, _, _ := unstructured.NestedString(issuer.Object, "spec", "acme", "server")
+	assert.Equal(t, letsEncryptStageEndpoint, server)
+	solvers, _, _ := unstructured.NestedSlice(issuer.Object, "spec", "acme", "solvers")
+	assert.Len(t, solvers, 1)
+	rfc2136 := solvers[0].(map[string]interface{})["dns01"].(map[string]interface{})["rfc2136"].(map[string]interface{})
+	assert.Equal(t, "10.0.0.10:5353", rfc2136["nameserver"])
+	assert.Equal(t, "externaldns-key", rfc2136["tsigKeyName"])
+	assert.Equal(t, "HMACSHA512", rfc2136["tsigAlgorithm"])
+	assert.Equal(t, map[string]interface{}{"name": "tsig-secret", "key": "secret"}, rfc2136["tsigSecretSecretRef"])
+
+	copied := &corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "tsig-secret", Namespace: ComponentNamespace}, copied))
+	assert.Equal(t, tsigSecret.Data, copied.Data)
+
+	// The TSIG secret is required
+	client = fake.NewClientBuilder().WithScheme(testScheme).Build()
+	_, err = createACMEIssuerObject(vzlog.DefaultLogger(), client, localvz, localvz.Spec.Components.ClusterIssuer)
+	assert.Error(t, err)
+}
+
+// TestClusterIssuerCustomACME tests the createACMEIssuerObject function
+// GIVEN an ACME issuer with a custom ACME server, an external account binding and the HTTP01 solver
+// WHEN createACMEIssuerObject is called
+// THEN the ClusterIssuer uses the ACME server, the external account binding and the Verrazzano ingress class
+func TestClusterIssuerCustomACME(t *testing.T) {
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.ClusterIssuer = &vzapi.ClusterIssuerComponent{
+		ClusterResourceNamespace: ComponentNamespace,
+		IssuerConfig: vzapi.IssuerConfig{
+			ACME: &vzapi.ACMEIssuer{
+				Server:                 "https://step-ca:9000/acme/acme/directory",
+				SkipTLSVerify:          true,
+				Solver:                 vzapi.ACMESolverHTTP01,
+				ExternalAccountBinding: &vzapi.ACMEExternalAccountBinding{KeyID: "kid", KeySecretName: "eab"},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	issuer, err := createACMEIssuerObject(vzlog.DefaultLogger(), client, localvz, localvz.Spec.Components.ClusterIssuer)
+	assert.NoError(t, err)
+	server, _, _ := unstructured.NestedString(issuer.Object, "spec", "acme", "server")
+	assert.Equal(t, "https://step-ca:9000/acme/acme/directory", server)
+	_, found, _ := unstructured.NestedString(issuer.Object, "spec", "acme", "email")
+	assert.False(t, found)
+	skipTLSVerify, _, _ := unstructured.NestedBool(issuer.Object, "spec", "acme", "skipTLSVerify")
+	assert.True(t, skipTLSVerify)
+	eab, _, _ := unstructured.NestedMap(issuer.Object, "spec", "acme", "externalAccountBinding")
+	assert.Equal(t, map[string]interface{}{"keyID": "kid", "keySecretRef": map[string]interface{}{"name": "eab", "key": acmeEABSecretKey}}, eab)
+	solvers, _, _ := unstructured.NestedSlice(issuer.Object, "spec", "acme", "solvers")
+	assert.Len(t, solvers, 1)
+	ingressClass, _, _ := unstructured.NestedString(solvers[0].(map[string]interface{}), "http01", "ingress", "class")
+	assert.Equal(t, "verrazzano-nginx", ingressClass)
+
+	// The DNS01 solver requires a managed DNS provider
+	localvz.Spec.Components.ClusterIssuer.ACME.Solver = vzapi.ACMESolverDNS01
+	_, err = createACMEIssuerObject(vzlog.DefaultLogger(), client, localvz, localvz.Spec.Components.ClusterIssuer)
+	assert.Error(t, err)
+}
+
+// TestClusterIssuerVault tests the createOrUpdateClusterIssuer function
+// GIVEN a Vault issuer with Kubernetes authentication and a CA bundle secret
+// WHEN createOrUpdateClusterIssuer is called
+// THEN the Vault ClusterIssuer is created with the token secret of the Vault issuer service account, and switching to
+// AppRole authentication removes the service account
+func TestClusterIssuerVault(t *testing.T) {
+	localvz := defaultVZConfig.DeepCopy()
+	localvz.Spec.Components.ClusterIssuer = &vzapi.ClusterIssuerComponent{
+		ClusterResourceNamespace: ComponentNamespace,
+		IssuerConfig: vzapi.IssuerConfig{
+			Vault: &vzapi.VaultIssuer{
+				Server:         "http://vault:8200",
+				Path:           "pki/sign/verrazzano",
+				CABundleSecret: "vault-ca",
+				Kubernetes:     &vzapi.VaultKubernetesAuth{Role: "issuer"},
+			},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-ca", Namespace: ComponentNamespace},
+		Data:       map[string][]byte{vaultCABundleSecretKey: []byte("ca")},
+	}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(caSecret).Build()
+	setupReissueTest(t)
+	component := NewComponent().(clusterIssuerComponent)
+	assert.NoError(t, component.createOrUpdateClusterIssuer(spi.NewFakeContext(client, localvz, nil, false)))
+
+	clusterIssuer := &certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: constants2.VerrazzanoClusterIssuerName}, clusterIssuer))
+	vault := clusterIssuer.Spec.Vault
+	assert.Equal(t, "http://vault:8200", vault.Server)
+	assert.Equal(t, "pki/sign/verrazzano", vault.Path)
+	assert.Equal(t, []byte("ca"), vault.CABundle)
+	assert.Equal(t, vaultDefaultKubernetesMountPath, vault.Auth.Kubernetes.Path)
+	assert.Equal(t, "issuer", vault.Auth.Kubernetes.Role)
+	assert.Equal(t, vaultTokenSecretName, vault.Auth.Kubernetes.SecretRef.Name)
+	tokenSecret := &corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: vaultTokenSecretName, Namespace: ComponentNamespace}, tokenSecret))
+	assert.Equal(t, corev1.SecretTypeServiceAccountToken, tokenSecret.Type)
+	assert.Equal(t, vaultServiceAccountName, tokenSecret.Annotations[corev1.ServiceAccountNameKey])
+	assertFound(t, client, vaultServiceAccountName, ComponentNamespace, &corev1.ServiceAccount{})
+
+	localvz.Spec.Components.ClusterIssuer.Vault.CABundleSecret = ""
+	localvz.Spec.Components.ClusterIssuer.Vault.Kubernetes = nil
+	localvz.Spec.Components.ClusterIssuer.Vault.AppRole = &vzapi.VaultAppRoleAuth{RoleID: "role", SecretName: "approle"}
+	assert.NoError(t, component.createOrUpdateClusterIssuer(spi.NewFakeContext(client, localvz, nil, false)))
+
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: constants2.VerrazzanoClusterIssuerName}, clusterIssuer))
+	vault = clusterIssuer.Spec.Vault
+	assert.Nil(t, vault.Auth.Kubernetes)
+	assert.Equal(t, vaultDefaultAppRolePath, vault.Auth.AppRole.Path)
+	assert.Equal(t, "role", vault.Auth.AppRole.RoleId)
+	assert.Equal(t, cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "approle"}, Key: vaultAppRoleSecretKey}, vault.Auth.AppRole.SecretRef)
+	assertNotFound(t, client, vaultTokenSecretName, ComponentNamespace, &corev1.Secret{})
+	assertNotFound(t, client, vaultServiceAccountName, ComponentNamespace, &corev1.ServiceAccount{})
+}
+
 // TestClusterIssuerUpdated tests the createOrUpdateClusterIssuer function
 // GIVEN a call to createOrUpdateClusterIssuer
 // WHEN the ClusterIssuer is updated and there are existing certificates with failed and successful CertificateRequests
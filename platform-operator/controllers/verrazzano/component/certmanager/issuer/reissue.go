@@ -0,0 +1,145 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package issuer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/verrazzano/verrazzano/pkg/constants"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// issuerSpecHashAnnotation records the hash of the ClusterIssuer spec the component certificates were re-issued for,
+// on the ClusterIssuer once all the certificates are issued, and on each certificate once its renewal is requested
+const issuerSpecHashAnnotation = "verrazzano.io/cluster-issuer-spec-hash"
+
+// CertificateNamesFunc returns the names of the certificates of the enabled Verrazzano components
+type CertificateNamesFunc func(ctx spi.ComponentContext) []types.NamespacedName
+
+var getCertificateNamesFunc CertificateNamesFunc
+
+// SetCertificateNamesFunc sets the function returning the names of the component certificates, the ClusterIssuer
+// component can not use the component registry itself
+func SetCertificateNamesFunc(f CertificateNamesFunc) {
+	getCertificateNamesFunc = f
+}
+
+// reissueComponentCertificates re-issues the certificates listed by the components when the ClusterIssuer changes,
+// and records the progress in the component status until all of them are issued by the new ClusterIssuer
+func reissueComponentCertificates(compContext spi.ComponentContext, opResult controllerutil.OperationResult) error {
+	log := compContext.Log()
+	cli := compContext.Client()
+	ctx := context.TODO()
+
+	clusterIssuer := certv1.ClusterIssuer{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: constants.VerrazzanoClusterIssuerName}, &clusterIssuer); err != nil {
+		return err
+	}
+	specHash, err := getIssuerSpecHash(&clusterIssuer)
+	if err != nil {
+		return err
+	}
+	reissuedHash, found := clusterIssuer.Annotations[issuerSpecHashAnnotation]
+	if reissuedHash == specHash {
+		return nil
+	}
+	if opResult == controllerutil.OperationResultCreated || (!found && opResult == controllerutil.OperationResultNone) {
+		// The certificates are issued by the current ClusterIssuer, it was either just created or it has not changed
+		// since the upgrade from a release that did not record the hash
+		return setIssuerSpecHash(cli, &clusterIssuer, specHash)
+	}
+
+	var certNames []types.NamespacedName
+	if getCertificateNamesFunc != nil {
+		certNames = getCertificateNamesFunc(compContext)
+	}
+	cmClient, err := getCMClientFunc()
+	if err != nil {
+		return err
+	}
+	issued := 0
+	for _, certName := range certNames {
+		cert := certv1.Certificate{}
+		if err := cli.Get(ctx, certName, &cert); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The certificate will be issued by the current ClusterIssuer when it is created
+				issued++
+				continue
+			}
+			return err
+		}
+		if cert.Spec.IssuerRef.Name != constants.VerrazzanoClusterIssuerName {
+			log.Oncef("Certificate %s/%s not issued by the Verrazzano cluster issuer, skipping", cert.Namespace, cert.Name)
+			issued++
+			continue
+		}
+		if cert.Annotations[issuerSpecHashAnnotation] == specHash {
+			if isCertificateIssued(&cert) {
+				issued++
+			}
+			continue
+		}
+		original := cert.DeepCopy()
+		// The certificate may already be renewed after the issuer common name changed
+		if !cmutil.CertificateHasCondition(&cert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: certmetav1.ConditionTrue}) {
+			if err := renewCertificate(ctx, cmClient, log, &cert); err != nil {
+				return err
+			}
+		}
+		annotated := original.DeepCopy()
+		if annotated.Annotations == nil {
+			annotated.Annotations = map[string]string{}
+		}
+		annotated.Annotations[issuerSpecHashAnnotation] = specHash
+		if err := cli.Patch(ctx, annotated, crtclient.MergeFrom(original)); err != nil {
+			return err
+		}
+	}
+
+	if issued < len(certNames) {
+		progress := fmt.Sprintf("Re-issuing the certificates from the updated ClusterIssuer, %d of %d issued", issued, len(certNames))
+		log.Progressf(progress)
+		spi.RecordProgress(compContext, ComponentName, progress)
+		return ctrlerrors.RetryableError{Source: ComponentName}
+	}
+	log.Oncef("All %d component certificates are issued by the updated ClusterIssuer", len(certNames))
+	spi.RecordProgress(compContext, ComponentName, "")
+	return setIssuerSpecHash(cli, &clusterIssuer, specHash)
+}
+
+// isCertificateIssued returns true if the certificate is ready and no issuance is in progress
+func isCertificateIssued(cert *certv1.Certificate) bool {
+	return cmutil.CertificateHasCondition(cert, certv1.CertificateCondition{Type: certv1.CertificateConditionReady, Status: certmetav1.ConditionTrue}) &&
+		!cmutil.CertificateHasCondition(cert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: certmetav1.ConditionTrue})
+}
+
+// getIssuerSpecHash returns the hash of the ClusterIssuer spec
+func getIssuerSpecHash(clusterIssuer *certv1.ClusterIssuer) (string, error) {
+	spec, err := json.Marshal(clusterIssuer.Spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(spec)), nil
+}
+
+// setIssuerSpecHash records on the ClusterIssuer that the component certificates are issued for its spec
+func setIssuerSpecHash(cli crtclient.Client, clusterIssuer *certv1.ClusterIssuer, specHash string) error {
+	original := clusterIssuer.DeepCopy()
+	if clusterIssuer.Annotations == nil {
+		clusterIssuer.Annotations = map[string]string{}
+	}
+	clusterIssuer.Annotations[issuerSpecHashAnnotation] = specHash
+	return cli.Patch(context.TODO(), clusterIssuer, crtclient.MergeFrom(original))
+}
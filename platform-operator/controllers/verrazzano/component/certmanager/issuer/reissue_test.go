@@ -0,0 +1,154 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package issuer
+
+import (
+	"context"
+	"testing"
+
+	cmutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	certv1fake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	certv1client "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	constants2 "github.com/verrazzano/verrazzano/pkg/constants"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// TestReissueComponentCertificatesInitialInstall tests the reissueComponentCertificates function
+// GIVEN a ClusterIssuer that was just created
+// WHEN reissueComponentCertificates is called
+// THEN no certificate is renewed and the hash of the ClusterIssuer spec is recorded
+func TestReissueComponentCertificatesInitialInstall(t *testing.T) {
+	clusterIssuer := newVaultClusterIssuer()
+	cert := newComponentCertificate()
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(clusterIssuer, cert).Build()
+	ctx := spi.NewFakeContext(client, defaultVZConfig.DeepCopy(), nil, false)
+	cmClient := setupReissueTest(t, cert)
+
+	assert.NoError(t, reissueComponentCertificates(ctx, controllerutil.OperationResultCreated))
+
+	assertIssuerSpecHashRecorded(t, client, true)
+	updatedCert, err := cmClient.CertmanagerV1().Certificates(cert.Namespace).Get(context.TODO(), cert.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, cmutil.CertificateHasCondition(updatedCert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}))
+}
+
+// TestReissueComponentCertificates tests the reissueComponentCertificates function
+// GIVEN a ClusterIssuer that was updated
+// WHEN reissueComponentCertificates is called
+// THEN the component certificates are renewed, the progress is recorded in the component status until they are
+// issued, and then the hash of the ClusterIssuer spec is recorded
+func TestReissueComponentCertificates(t *testing.T) {
+	clusterIssuer := newVaultClusterIssuer()
+	clusterIssuer.Annotations = map[string]string{issuerSpecHashAnnotation: "previous"}
+	cert := newComponentCertificate()
+	otherCert := newComponentCertificate()
+	otherCert.Name = "other"
+	otherCert.Spec.IssuerRef.Name = "other-issuer"
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(clusterIssuer, cert, otherCert).Build()
+	vz := defaultVZConfig.DeepCopy()
+	vz.Status.Components = vzapi.ComponentStatusMap{ComponentName: &vzapi.ComponentStatusDetails{Name: ComponentName}}
+	ctx := spi.NewFakeContext(client, vz, nil, false)
+	cmClient := setupReissueTest(t, cert, otherCert)
+
+	// The renewal of the Verrazzano certificate is requested
+	err := reissueComponentCertificates(ctx, controllerutil.OperationResultUpdated)
+	assert.Error(t, err)
+	assert.True(t, ctrlerrors.IsRetryableError(err))
+	updatedCert, err := cmClient.CertmanagerV1().Certificates(cert.Namespace).Get(context.TODO(), cert.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, cmutil.CertificateHasCondition(updatedCert, certv1.CertificateCondition{Type: certv1.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}))
+	assert.Equal(t, "Re-issuing the certificates from the updated ClusterIssuer, 1 of 2 issued", ctx.ActualCR().Status.Components[ComponentName].Progress)
+	assertIssuerSpecHashRecorded(t, client, false)
+
+	// The certificate is issued by the new ClusterIssuer
+	annotatedCert := &certv1.Certificate{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, annotatedCert))
+	assert.NotEmpty(t, annotatedCert.Annotations[issuerSpecHashAnnotation])
+	annotatedCert.Status.Conditions = []certv1.CertificateCondition{{Type: certv1.CertificateConditionReady, Status: cmmeta.ConditionTrue}}
+	assert.NoError(t, client.Update(context.TODO(), annotatedCert))
+
+	assert.NoError(t, reissueComponentCertificates(ctx, controllerutil.OperationResultNone))
+	assert.Empty(t, ctx.ActualCR().Status.Components[ComponentName].Progress)
+	assertIssuerSpecHashRecorded(t, client, true)
+}
+
+// TestReissueComponentCertificatesAfterUpgrade tests the reissueComponentCertificates function
+// GIVEN a ClusterIssuer that has not changed, without the hash of its spec
+// WHEN reissueComponentCertificates is called
+// THEN the hash of the ClusterIssuer spec is recorded without renewing the certificates
+func TestReissueComponentCertificatesAfterUpgrade(t *testing.T) {
+	cert := newComponentCertificate()
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVaultClusterIssuer(), cert).Build()
+	ctx := spi.NewFakeContext(client, defaultVZConfig.DeepCopy(), nil, false)
+	cmClient := setupReissueTest(t, cert)
+
+	assert.NoError(t, reissueComponentCertificates(ctx, controllerutil.OperationResultNone))
+	assertIssuerSpecHashRecorded(t, client, true)
+	updatedCert, err := cmClient.CertmanagerV1().Certificates(cert.Namespace).Get(context.TODO(), cert.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, updatedCert.Status.Conditions)
+}
+
+func setupReissueTest(t *testing.T, certs ...*certv1.Certificate) *certv1fake.Clientset {
+	var objs []runtime.Object
+	for _, cert := range certs {
+		objs = append(objs, cert)
+	}
+	cmClient := certv1fake.NewSimpleClientset(objs...)
+	getCMClientFunc = func() (certv1client.CertmanagerV1Interface, error) {
+		return cmClient.CertmanagerV1(), nil
+	}
+	SetCertificateNamesFunc(func(_ spi.ComponentContext) []types.NamespacedName {
+		var names []types.NamespacedName
+		for _, cert := range certs {
+			names = append(names, types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace})
+		}
+		return names
+	})
+	t.Cleanup(func() {
+		getCMClientFunc = GetCertManagerClientset
+		SetCertificateNamesFunc(nil)
+	})
+	return cmClient
+}
+
+func assertIssuerSpecHashRecorded(t *testing.T, client clipkg.Client, recorded bool) {
+	clusterIssuer := &certv1.ClusterIssuer{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: constants2.VerrazzanoClusterIssuerName}, clusterIssuer))
+	specHash, err := getIssuerSpecHash(clusterIssuer)
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, clusterIssuer.Annotations[issuerSpecHashAnnotation] == specHash)
+}
+
+func newVaultClusterIssuer() *certv1.ClusterIssuer {
+	return &certv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: constants2.VerrazzanoClusterIssuerName},
+		Spec: certv1.IssuerSpec{
+			IssuerConfig: certv1.IssuerConfig{
+				Vault: &certv1.VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"},
+			},
+		},
+	}
+}
+
+func newComponentCertificate() *certv1.Certificate {
+	return &certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls-component", Namespace: "verrazzano-system"},
+		Spec: certv1.CertificateSpec{
+			IssuerRef:  cmmeta.ObjectReference{Name: constants2.VerrazzanoClusterIssuerName},
+			SecretName: "tls-component",
+		},
+	}
+}
@@ -10,6 +10,7 @@ import (
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"net/mail"
+	"net/url"
 
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
@@ -63,6 +64,9 @@ func getDNSSuffix(effectiveCR runtime.Object) (string, bool) {
 		} else if cr.Spec.Components.DNS.OCI != nil {
 			wildcard = false
 			dnsSuffix = cr.Spec.Components.DNS.OCI.DNSZoneName
+		} else if cr.Spec.Components.DNS.RFC2136 != nil {
+			wildcard = false
+			dnsSuffix = cr.Spec.Components.DNS.RFC2136.DNSZoneName
 		} else if cr.Spec.Components.DNS.External != nil {
 			wildcard = false
 			dnsSuffix = cr.Spec.Components.DNS.External.Suffix
@@ -76,6 +80,9 @@ func getDNSSuffix(effectiveCR runtime.Object) (string, bool) {
 	} else if cr.Spec.Components.DNS.OCI != nil {
 		wildcard = false
 		dnsSuffix = cr.Spec.Components.DNS.OCI.DNSZoneName
+	} else if cr.Spec.Components.DNS.RFC2136 != nil {
+		wildcard = false
+		dnsSuffix = cr.Spec.Components.DNS.RFC2136.DNSZoneName
 	} else if cr.Spec.Components.DNS.External != nil {
 		wildcard = false
 		dnsSuffix = cr.Spec.Components.DNS.External.Suffix
@@ -84,17 +91,17 @@ func getDNSSuffix(effectiveCR runtime.Object) (string, bool) {
 }
 
 // validateConfiguration Validates the ClusterIssuer Certificate configuration
-// - Verifies that only one of either the CA or LetsEncrypt fields is set
-// - Validates the CA or LetsEncrypt configurations if necessary
+// - Verifies that only one of the CA, LetsEncrypt, ACME or Vault fields is set
+// - Validates the configuration of the issuer that is set
 // - returns an error if anything is misconfigured
 func validateConfiguration(vz *v1beta1.Verrazzano) (err error) {
 	if err := validateCertificate(vz.Spec.Components.CertManager); err != nil {
 		return err
 	}
-	return validateIssuerConfig(vz.Spec.Components.ClusterIssuer)
+	return validateIssuerConfig(vz, vz.Spec.Components.ClusterIssuer)
 }
 
-func validateIssuerConfig(issuerComponent *v1beta1.ClusterIssuerComponent) error {
+func validateIssuerConfig(vz *v1beta1.Verrazzano, issuerComponent *v1beta1.ClusterIssuerComponent) error {
 	if issuerComponent == nil {
 		return nil
 	}
@@ -111,8 +118,14 @@ func validateIssuerConfig(issuerComponent *v1beta1.ClusterIssuerComponent) error
 		}
 		return nil
 	}
-	// Validate the LetsEncrypt config otherwise
-	return validateAcmeConfiguration(*issuerComponent.LetsEncrypt)
+	switch {
+	case issuerComponent.LetsEncrypt != nil:
+		return validateAcmeConfiguration(*issuerComponent.LetsEncrypt)
+	case issuerComponent.ACME != nil:
+		return validateCustomACMEConfiguration(vz, issuerComponent.ACME, issuerComponent.ClusterResourceNamespace)
+	default:
+		return validateVaultConfiguration(issuerComponent.Vault, issuerComponent.ClusterResourceNamespace)
+	}
 }
 
 func checkClusterResourceNamespaceExists(issuerComponent *v1beta1.ClusterIssuerComponent) error {
@@ -150,6 +163,89 @@ func validateAcmeConfiguration(acme v1beta1.LetsEncryptACMEIssuer) error {
 	return nil
 }
 
+// validateCustomACMEConfiguration Validate the configuration of an ACME issuer with a custom ACME server
+func validateCustomACMEConfiguration(vz *v1beta1.Verrazzano, acme *v1beta1.ACMEIssuer, clusterResourceNamespace string) error {
+	if err := validateServerURL("ACME", acme.Server); err != nil {
+		return err
+	}
+	if len(acme.EmailAddress) > 0 {
+		if _, err := mail.ParseAddress(acme.EmailAddress); err != nil {
+			return err
+		}
+	}
+	switch acme.Solver {
+	case "", v1beta1.ACMESolverDNS01:
+		dns := vz.Spec.Components.DNS
+		if dns == nil || (dns.OCI == nil && dns.RFC2136 == nil) {
+			return fmt.Errorf("The ACME issuer DNS01 solver requires OCI or RFC2136 DNS, use the %s solver otherwise", v1beta1.ACMESolverHTTP01)
+		}
+	case v1beta1.ACMESolverHTTP01:
+	default:
+		return fmt.Errorf("Invalid ACME issuer solver %s, it must be %s or %s", acme.Solver, v1beta1.ACMESolverDNS01, v1beta1.ACMESolverHTTP01)
+	}
+	if acme.ExternalAccountBinding != nil {
+		if len(acme.ExternalAccountBinding.KeyID) == 0 {
+			return fmt.Errorf("The ACME issuer external account binding requires a key ID")
+		}
+		if err := validateSecretKey(clusterResourceNamespace, acme.ExternalAccountBinding.KeySecretName, acmeEABSecretKey); err != nil {
+			return err
+		}
+	}
+	if len(acme.CABundleSecret) > 0 {
+		return validateSecretKey(clusterResourceNamespace, acme.CABundleSecret, acmeCABundleSecretKey)
+	}
+	return nil
+}
+
+// validateVaultConfiguration Validate the configuration of a Vault issuer
+func validateVaultConfiguration(vault *v1beta1.VaultIssuer, clusterResourceNamespace string) error {
+	if err := validateServerURL("Vault", vault.Server); err != nil {
+		return err
+	}
+	if len(vault.Path) == 0 {
+		return fmt.Errorf("The Vault issuer requires the path of the Vault PKI signing endpoint")
+	}
+	if (vault.Kubernetes == nil) == (vault.AppRole == nil) {
+		return fmt.Errorf("The Vault issuer requires either Kubernetes or AppRole authentication")
+	}
+	if vault.Kubernetes != nil && len(vault.Kubernetes.Role) == 0 {
+		return fmt.Errorf("The Vault issuer Kubernetes authentication requires a role")
+	}
+	if vault.AppRole != nil {
+		if len(vault.AppRole.RoleID) == 0 {
+			return fmt.Errorf("The Vault issuer AppRole authentication requires a role ID")
+		}
+		if err := validateSecretKey(clusterResourceNamespace, vault.AppRole.SecretName, vaultAppRoleSecretKey); err != nil {
+			return err
+		}
+	}
+	if len(vault.CABundleSecret) > 0 {
+		return validateSecretKey(clusterResourceNamespace, vault.CABundleSecret, vaultCABundleSecretKey)
+	}
+	return nil
+}
+
+// validateServerURL Validate that the server of an issuer is an absolute HTTP or HTTPS URL
+func validateServerURL(issuerType string, server string) error {
+	serverURL, err := url.Parse(server)
+	if err != nil || (serverURL.Scheme != "https" && serverURL.Scheme != "http") || len(serverURL.Host) == 0 {
+		return fmt.Errorf("Invalid %s issuer server URL \"%s\"", issuerType, server)
+	}
+	return nil
+}
+
+// validateSecretKey Validate that a secret exists and has the data key
+func validateSecretKey(namespace string, name string, key string) error {
+	secret, err := cmcommon.GetSecret(namespace, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := secret.Data[key]; !ok {
+		return fmt.Errorf("The secret %s/%s does not have the %s data key", namespace, name, key)
+	}
+	return nil
+}
+
 func validateCertManagerTypesExist() error {
 	ok, err := checkCertManagerCRDFunc()
 	if err != nil {
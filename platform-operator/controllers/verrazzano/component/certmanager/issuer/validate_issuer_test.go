@@ -29,6 +29,9 @@ func createFakeClient(objs ...runtime.Object) *k8sfake.Clientset {
 const emailAddress = "joeblow@foo.com"
 const secretName = "newsecret"
 const secretNamespace = "ns"
+const stepCAServer = "https://step-ca:9000/acme/acme/directory"
+const vaultServer = "http://vault:8200"
+const vaultPath = "pki/sign/verrazzano"
 
 // TestValidateClusterResourceNamespace tests the checkClusterResourceNamespaceExists function
 // GIVEN a call to checkClusterResourceNamespaceExists
@@ -387,6 +390,117 @@ var issuerConfigurationTests = []validationTestStruct{
 		},
 		wantErr: true,
 	},
+	{
+		name:                             "validCustomACMEHTTP01",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          false,
+	},
+	{
+		name:                             "customACMEDNS01WithoutManagedDNS",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name:                             "customACMEInvalidServer",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: "step-ca", Solver: vzapi.ACMESolverHTTP01}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name: "validCustomACMEExternalAccountBinding",
+		old:  &vzapi.Verrazzano{},
+		new: getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01,
+			ExternalAccountBinding: &vzapi.ACMEExternalAccountBinding{KeyID: "kid", KeySecretName: secretName}}}),
+		caSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: secretNamespace},
+			Data:       map[string][]byte{acmeEABSecretKey: []byte("key")},
+		},
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          false,
+	},
+	{
+		name: "customACMEExternalAccountBindingSecretNotFound",
+		old:  &vzapi.Verrazzano{},
+		new: getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01,
+			ExternalAccountBinding: &vzapi.ACMEExternalAccountBinding{KeyID: "kid", KeySecretName: secretName}}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name: "validCustomACMECABundle",
+		old:  &vzapi.Verrazzano{},
+		new:  getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01, CABundleSecret: secretName}}),
+		caSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: secretNamespace},
+			Data:       map[string][]byte{acmeCABundleSecretKey: []byte("ca")},
+		},
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          false,
+	},
+	{
+		name:                             "customACMECABundleSecretNotFound",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01, CABundleSecret: secretName}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name:                             "validVaultKubernetesAuth",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: vaultServer, Path: vaultPath, Kubernetes: &vzapi.VaultKubernetesAuth{Role: "issuer"}}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          false,
+	},
+	{
+		name: "validVaultAppRoleAuth",
+		old:  &vzapi.Verrazzano{},
+		new:  getIssuerCR(vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: vaultServer, Path: vaultPath, AppRole: &vzapi.VaultAppRoleAuth{RoleID: "role", SecretName: secretName}}}),
+		caSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: secretNamespace},
+			Data:       map[string][]byte{vaultAppRoleSecretKey: []byte("secret-id")},
+		},
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          false,
+	},
+	{
+		name: "vaultAppRoleSecretWithoutSecretID",
+		old:  &vzapi.Verrazzano{},
+		new:  getIssuerCR(vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: vaultServer, Path: vaultPath, AppRole: &vzapi.VaultAppRoleAuth{RoleID: "role", SecretName: secretName}}}),
+		caSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: secretNamespace},
+		},
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name:                             "vaultWithoutAuth",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: vaultServer, Path: vaultPath}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name:                             "vaultWithoutPath",
+		old:                              &vzapi.Verrazzano{},
+		new:                              getIssuerCR(vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: vaultServer, Kubernetes: &vzapi.VaultKubernetesAuth{Role: "issuer"}}}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
+	{
+		name: "vaultAndCustomACME",
+		old:  &vzapi.Verrazzano{},
+		new: getIssuerCR(vzapi.IssuerConfig{
+			ACME:  &vzapi.ACMEIssuer{Server: stepCAServer, Solver: vzapi.ACMESolverHTTP01},
+			Vault: &vzapi.VaultIssuer{Server: vaultServer, Path: vaultPath, Kubernetes: &vzapi.VaultKubernetesAuth{Role: "issuer"}},
+		}),
+		expectedClusterResourceNamespace: secretNamespace,
+		wantErr:                          true,
+	},
 }
 
 // All of this below is to make Sonar happy
@@ -507,3 +621,16 @@ func getCaSecretCR() *vzapi.Verrazzano {
 		},
 	}
 }
+
+func getIssuerCR(issuerConfig vzapi.IssuerConfig) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				ClusterIssuer: &vzapi.ClusterIssuerComponent{
+					ClusterResourceNamespace: secretNamespace,
+					IssuerConfig:             issuerConfig,
+				},
+			},
+		},
+	}
+}
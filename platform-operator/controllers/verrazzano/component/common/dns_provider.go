@@ -0,0 +1,240 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	certmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RFC2136TSIGSecretKey is the data key of the TSIG key in the RFC2136 TSIG secret
+	RFC2136TSIGSecretKey = "secret"
+
+	defaultRFC2136Port          = 53
+	defaultRFC2136TSIGAlgorithm = "hmac-sha256"
+	rfc2136TSIGSecretEnvVar     = "EXTERNAL_DNS_RFC2136_TSIG_SECRET" //nolint:gosec //#gosec G101
+)
+
+// DNSProvider is a managed DNS provider. External-DNS maintains the records of the Verrazzano ingresses in the zone
+// of the provider, and the DNS01 solver of the Verrazzano ACME ClusterIssuer uses the provider for the challenges.
+type DNSProvider interface {
+	// Name returns the External-DNS provider name
+	Name() string
+	// ZoneName returns the name of the managed DNS zone
+	ZoneName() string
+	// SecretName returns the name of the credentials secret of the provider in the verrazzano-install namespace
+	SecretName() string
+	// CopySecret copies the credentials secret of the provider from the verrazzano-install namespace to the
+	// target namespace
+	CopySecret(log vzlog.VerrazzanoLogger, cli client.Client, targetNamespace string) error
+	// ExternalDNSOverrides returns the External-DNS Helm overrides of the provider
+	ExternalDNSOverrides() []bom.KeyValue
+	// DNS01Solver returns the ACME DNS01 challenge solver of the provider, using the copy of the credentials secret
+	// in the cluster resource namespace of cert-manager
+	DNS01Solver(log vzlog.VerrazzanoLogger, cli client.Client, clusterResourceNamespace string) (*acmev1.ACMEChallengeSolver, error)
+}
+
+// GetDNSProvider returns the managed DNS provider of the Verrazzano CR, or nil if the CR uses wildcard or
+// external DNS
+func GetDNSProvider(vz *vzapi.Verrazzano) DNSProvider {
+	dns := vz.Spec.Components.DNS
+	if dns == nil {
+		return nil
+	}
+	if dns.OCI != nil {
+		return ociDNSProvider{dns.OCI}
+	}
+	if dns.RFC2136 != nil {
+		return rfc2136DNSProvider{dns.RFC2136}
+	}
+	return nil
+}
+
+// ociDNSProvider is the Oracle Cloud Infrastructure DNS provider
+type ociDNSProvider struct {
+	oci *vzapi.OCI
+}
+
+var _ DNSProvider = ociDNSProvider{}
+
+func (p ociDNSProvider) Name() string {
+	return "oci"
+}
+
+func (p ociDNSProvider) ZoneName() string {
+	return p.oci.DNSZoneName
+}
+
+func (p ociDNSProvider) SecretName() string {
+	return p.oci.OCIConfigSecret
+}
+
+func (p ociDNSProvider) CopySecret(log vzlog.VerrazzanoLogger, cli client.Client, targetNamespace string) error {
+	return copyOCIDNSSecret(log, cli, p.oci, targetNamespace)
+}
+
+func (p ociDNSProvider) ExternalDNSOverrides() []bom.KeyValue {
+	return []bom.KeyValue{
+		{Key: "provider", Value: p.Name()},
+		{Key: "domainFilters[0]", Value: p.oci.DNSZoneName},
+		{Key: "zoneIDFilters[0]", Value: p.oci.DNSZoneOCID},
+		{Key: "ociDnsScope", Value: p.oci.DNSScope},
+		{Key: "extraVolumes[0].name", Value: "config"},
+		{Key: "extraVolumes[0].secret.secretName", Value: p.oci.OCIConfigSecret},
+		{Key: "extraVolumeMounts[0].name", Value: "config"},
+		{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"},
+	}
+}
+
+// DNS01Solver returns the solver of the Verrazzano OCI DNS cert-manager webhook
+func (p ociDNSProvider) DNS01Solver(log vzlog.VerrazzanoLogger, cli client.Client, clusterResourceNamespace string) (*acmev1.ACMEChallengeSolver, error) {
+	// Verify that the secret exists
+	secret := v1.Secret{}
+	if err := cli.Get(context.TODO(), client.ObjectKey{Name: p.oci.OCIConfigSecret, Namespace: clusterResourceNamespace}, &secret); err != nil {
+		return nil, log.ErrorfNewErr("Failed to retrieve the OCI DNS config secret: %v", err)
+	}
+	useInstancePrincipals := false
+	for key := range secret.Data {
+		var authProp validators.OciAuth
+		if err := yaml.Unmarshal(secret.Data[key], &authProp); err != nil {
+			return nil, err
+		}
+		if authProp.Auth.AuthType == validators.InstancePrincipal {
+			useInstancePrincipals = true
+			break
+		}
+	}
+	config, err := json.Marshal(map[string]interface{}{
+		"compartmentOCID":       p.oci.DNSZoneCompartmentOCID,
+		"useInstancePrincipals": useInstancePrincipals,
+		"ociProfileSecretName":  p.oci.OCIConfigSecret,
+		"ociProfileSecretKey":   ociSecretFileName,
+		"ociZoneName":           p.oci.DNSZoneName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &acmev1.ACMEChallengeSolver{
+		DNS01: &acmev1.ACMEChallengeSolverDNS01{
+			Webhook: &acmev1.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  "verrazzano.io",
+				SolverName: "oci",
+				Config:     &apiextensionsv1.JSON{Raw: config},
+			},
+		},
+	}, nil
+}
+
+// rfc2136DNSProvider is the RFC2136 dynamic update DNS provider, for DNS servers such as BIND
+type rfc2136DNSProvider struct {
+	rfc2136 *vzapi.RFC2136
+}
+
+var _ DNSProvider = rfc2136DNSProvider{}
+
+func (p rfc2136DNSProvider) Name() string {
+	return "rfc2136"
+}
+
+func (p rfc2136DNSProvider) ZoneName() string {
+	return p.rfc2136.DNSZoneName
+}
+
+func (p rfc2136DNSProvider) SecretName() string {
+	return p.rfc2136.TSIGSecret
+}
+
+// CopySecret copies the TSIG key of the secret to the target namespace
+func (p rfc2136DNSProvider) CopySecret(log vzlog.VerrazzanoLogger, cli client.Client, targetNamespace string) error {
+	tsigSecret := v1.Secret{}
+	if err := cli.Get(context.TODO(), client.ObjectKey{Name: p.rfc2136.TSIGSecret, Namespace: constants.VerrazzanoInstallNamespace}, &tsigSecret); err != nil {
+		return log.ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", p.rfc2136.TSIGSecret, constants.VerrazzanoInstallNamespace, err)
+	}
+	tsigKey, ok := tsigSecret.Data[RFC2136TSIGSecretKey]
+	if !ok {
+		return log.ErrorfNewErr("Failed, the RFC2136 TSIG secret %s has no %s data key", p.rfc2136.TSIGSecret, RFC2136TSIGSecretKey)
+	}
+
+	targetSecret := v1.Secret{}
+	targetSecret.Namespace = targetNamespace
+	targetSecret.Name = tsigSecret.Name
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), cli, &targetSecret, func() error {
+		targetSecret.Data = map[string][]byte{RFC2136TSIGSecretKey: tsigKey}
+		return nil
+	}); err != nil {
+		return log.ErrorfNewErr("Failed to create or update the RFC2136 TSIG secret: %v", err)
+	}
+	return nil
+}
+
+func (p rfc2136DNSProvider) ExternalDNSOverrides() []bom.KeyValue {
+	return []bom.KeyValue{
+		{Key: "provider", Value: p.Name()},
+		{Key: "domainFilters[0]", Value: p.rfc2136.DNSZoneName},
+		{Key: "rfc2136.host", Value: p.rfc2136.Nameserver},
+		{Key: "rfc2136.port", Value: fmt.Sprintf("%d", p.port())},
+		{Key: "rfc2136.zone", Value: p.rfc2136.DNSZoneName},
+		{Key: "rfc2136.tsigKeyname", Value: p.rfc2136.TSIGKeyName},
+		{Key: "rfc2136.tsigSecretAlg", Value: p.tsigAlgorithm()},
+		{Key: "extraEnv[0].name", Value: rfc2136TSIGSecretEnvVar},
+		{Key: "extraEnv[0].valueFrom.secretKeyRef.name", Value: p.rfc2136.TSIGSecret},
+		{Key: "extraEnv[0].valueFrom.secretKeyRef.key", Value: RFC2136TSIGSecretKey},
+	}
+}
+
+// DNS01Solver returns the cert-manager RFC2136 solver, after copying the TSIG secret to the cluster resource namespace
+func (p rfc2136DNSProvider) DNS01Solver(log vzlog.VerrazzanoLogger, cli client.Client, clusterResourceNamespace string) (*acmev1.ACMEChallengeSolver, error) {
+	if err := p.CopySecret(log, cli, clusterResourceNamespace); err != nil {
+		return nil, err
+	}
+	return &acmev1.ACMEChallengeSolver{
+		DNS01: &acmev1.ACMEChallengeSolverDNS01{
+			RFC2136: &acmev1.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver:    fmt.Sprintf("%s:%d", p.rfc2136.Nameserver, p.port()),
+				TSIGKeyName:   p.rfc2136.TSIGKeyName,
+				TSIGAlgorithm: GetCertManagerTSIGAlgorithm(p.tsigAlgorithm()),
+				TSIGSecret: certmetav1.SecretKeySelector{
+					LocalObjectReference: certmetav1.LocalObjectReference{Name: p.rfc2136.TSIGSecret},
+					Key:                  RFC2136TSIGSecretKey,
+				},
+			},
+		},
+	}, nil
+}
+
+func (p rfc2136DNSProvider) port() int32 {
+	if p.rfc2136.Port > 0 {
+		return p.rfc2136.Port
+	}
+	return defaultRFC2136Port
+}
+
+func (p rfc2136DNSProvider) tsigAlgorithm() string {
+	if p.rfc2136.TSIGAlgorithm != "" {
+		return strings.ToLower(p.rfc2136.TSIGAlgorithm)
+	}
+	return defaultRFC2136TSIGAlgorithm
+}
+
+// GetCertManagerTSIGAlgorithm converts an External-DNS TSIG algorithm name, for example hmac-sha256, to the
+// cert-manager name, for example HMACSHA256
+func GetCertManagerTSIGAlgorithm(algorithm string) string {
+	return strings.ToUpper(strings.ReplaceAll(algorithm, "-", ""))
+}
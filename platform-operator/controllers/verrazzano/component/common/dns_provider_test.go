@@ -0,0 +1,98 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestGetDNSProvider tests the GetDNSProvider function
+// GIVEN a Verrazzano CR
+// WHEN GetDNSProvider is called
+// THEN the OCI or RFC2136 provider is returned if configured, otherwise nil
+func TestGetDNSProvider(t *testing.T) {
+	vz := &vzapi.Verrazzano{}
+	assert.Nil(t, GetDNSProvider(vz))
+
+	vz.Spec.Components.DNS = &vzapi.DNSComponent{Wildcard: &vzapi.Wildcard{Domain: "nip.io"}}
+	assert.Nil(t, GetDNSProvider(vz))
+
+	vz.Spec.Components.DNS = &vzapi.DNSComponent{OCI: &vzapi.OCI{DNSZoneName: "oci.example.com", OCIConfigSecret: "oci"}}
+	provider := GetDNSProvider(vz)
+	assert.Equal(t, "oci", provider.Name())
+	assert.Equal(t, "oci.example.com", provider.ZoneName())
+	assert.Equal(t, "oci", provider.SecretName())
+
+	vz.Spec.Components.DNS = &vzapi.DNSComponent{RFC2136: &vzapi.RFC2136{DNSZoneName: "bind.example.com", TSIGSecret: "tsig"}}
+	provider = GetDNSProvider(vz)
+	assert.Equal(t, "rfc2136", provider.Name())
+	assert.Equal(t, "bind.example.com", provider.ZoneName())
+	assert.Equal(t, "tsig", provider.SecretName())
+}
+
+// TestOCIDNS01Solver tests the DNS01Solver function of the OCI DNS provider
+// GIVEN OCI DNS with an instance principal config secret in the cluster resource namespace
+// WHEN DNS01Solver is called
+// THEN the solver of the Verrazzano OCI DNS webhook is returned and uses instance principals
+func TestOCIDNS01Solver(t *testing.T) {
+	oci := &vzapi.OCI{DNSZoneName: "oci.example.com", OCIConfigSecret: "oci", DNSZoneCompartmentOCID: "ocid"}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oci", Namespace: "cert-manager"},
+		Data:       map[string][]byte{"oci.yaml": []byte("auth:\n  authtype: instance_principal\n")},
+	}).Build()
+
+	solver, err := ociDNSProvider{oci}.DNS01Solver(vzlog.DefaultLogger(), client, "cert-manager")
+	assert.NoError(t, err)
+	assert.Equal(t, "oci", solver.DNS01.Webhook.SolverName)
+	config := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(solver.DNS01.Webhook.Config.Raw, &config))
+	assert.Equal(t, true, config["useInstancePrincipals"])
+	assert.Equal(t, "oci.example.com", config["ociZoneName"])
+	assert.Equal(t, "ocid", config["compartmentOCID"])
+
+	_, err = ociDNSProvider{oci}.DNS01Solver(vzlog.DefaultLogger(), client, "other")
+	assert.Error(t, err)
+}
+
+// TestRFC2136CopySecret tests the CopySecret function of the RFC2136 DNS provider
+// GIVEN a TSIG secret in the verrazzano-install namespace
+// WHEN CopySecret is called
+// THEN only the TSIG key is copied to the target namespace, and an error is returned if the secret has no TSIG key
+func TestRFC2136CopySecret(t *testing.T) {
+	provider := rfc2136DNSProvider{&vzapi.RFC2136{TSIGSecret: "tsig"}}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{RFC2136TSIGSecretKey: []byte("a2V5"), "other": []byte("other")},
+	}).Build()
+	assert.NoError(t, provider.CopySecret(vzlog.DefaultLogger(), client, "target"))
+	secret := &corev1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "tsig", Namespace: "target"}, secret))
+	assert.Equal(t, map[string][]byte{RFC2136TSIGSecretKey: []byte("a2V5")}, secret.Data)
+
+	client = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{"other": []byte("other")},
+	}).Build()
+	assert.Error(t, provider.CopySecret(vzlog.DefaultLogger(), client, "target"))
+}
+
+// TestGetCertManagerTSIGAlgorithm tests the GetCertManagerTSIGAlgorithm function
+// GIVEN External-DNS TSIG algorithm names
+// WHEN GetCertManagerTSIGAlgorithm is called
+// THEN the cert-manager algorithm names are returned
+func TestGetCertManagerTSIGAlgorithm(t *testing.T) {
+	assert.Equal(t, "HMACSHA256", GetCertManagerTSIGAlgorithm("hmac-sha256"))
+	assert.Equal(t, "HMACMD5", GetCertManagerTSIGAlgorithm("hmac-md5"))
+}
@@ -6,6 +6,8 @@ package common
 import (
 	"context"
 	"fmt"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"k8s.io/api/core/v1"
@@ -19,36 +21,50 @@ const (
 	dnsPrivate        = "PRIVATE"
 )
 
+// CopyOCIDNSSecret copies the OCI DNS config secret from the verrazzano-install namespace to the target namespace,
+// if OCI DNS is configured
 func CopyOCIDNSSecret(compContext spi.ComponentContext, targetNamespace string) error {
 	dns := compContext.EffectiveCR().Spec.Components.DNS
 	if dns == nil || dns.OCI == nil {
 		return nil
 	}
-	ociDNS := dns.OCI
+	return copyOCIDNSSecret(compContext.Log(), compContext.Client(), dns.OCI, targetNamespace)
+}
+
+// CopyDNSProviderSecret copies the credentials secret of the managed DNS provider from the verrazzano-install namespace
+// to the target namespace, if a managed DNS provider is configured
+func CopyDNSProviderSecret(compContext spi.ComponentContext, targetNamespace string) error {
+	provider := GetDNSProvider(compContext.EffectiveCR())
+	if provider == nil {
+		return nil
+	}
+	return provider.CopySecret(compContext.Log(), compContext.Client(), targetNamespace)
+}
 
+func copyOCIDNSSecret(log vzlog.VerrazzanoLogger, cli client.Client, ociDNS *vzapi.OCI, targetNamespace string) error {
 	// Get OCI DNS secret from the verrazzano-install namespace
 	dnsSecret := v1.Secret{}
-	if err := compContext.Client().Get(context.TODO(), client.ObjectKey{Name: ociDNS.OCIConfigSecret, Namespace: constants.VerrazzanoInstallNamespace}, &dnsSecret); err != nil {
-		return compContext.Log().ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", ociDNS.OCIConfigSecret, constants.VerrazzanoInstallNamespace, err)
+	if err := cli.Get(context.TODO(), client.ObjectKey{Name: ociDNS.OCIConfigSecret, Namespace: constants.VerrazzanoInstallNamespace}, &dnsSecret); err != nil {
+		return log.ErrorfNewErr("Failed to find secret %s in the %s namespace: %v", ociDNS.OCIConfigSecret, constants.VerrazzanoInstallNamespace, err)
 	}
 
 	//check if scope value is valid
 	scope := ociDNS.DNSScope
 	if scope != dnsGlobal && scope != dnsPrivate && scope != "" {
-		return compContext.Log().ErrorfNewErr("Failed, invalid OCI DNS scope value: %s. If set, value can only be 'GLOBAL' or 'PRIVATE", ociDNS.DNSScope)
+		return log.ErrorfNewErr("Failed, invalid OCI DNS scope value: %s. If set, value can only be 'GLOBAL' or 'PRIVATE", ociDNS.DNSScope)
 	}
 
 	// Attach compartment field to secret and apply it in the external DNS namespace
 	targetDNSSecret := v1.Secret{}
-	compContext.Log().Debug("Creating the external DNS secret")
+	log.Debug("Creating the external DNS secret")
 	targetDNSSecret.Namespace = targetNamespace
 	targetDNSSecret.Name = dnsSecret.Name
-	if _, err := controllerutil.CreateOrUpdate(context.TODO(), compContext.Client(), &targetDNSSecret, func() error {
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), cli, &targetDNSSecret, func() error {
 		targetDNSSecret.Data = make(map[string][]byte)
 
 		// Verify that the oci secret has one value
 		if len(dnsSecret.Data) != 1 {
-			return compContext.Log().ErrorNewErr("Failed, OCI secret for OCI DNS should be created from one file")
+			return log.ErrorNewErr("Failed, OCI secret for OCI DNS should be created from one file")
 		}
 
 		// Extract data and create secret in the external DNS namespace
@@ -58,7 +74,7 @@ func CopyOCIDNSSecret(compContext spi.ComponentContext, targetNamespace string)
 
 		return nil
 	}); err != nil {
-		return compContext.Log().ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
+		return log.ErrorfNewErr("Failed to create or update the external DNS secret: %v", err)
 	}
 	return nil
 }
@@ -59,7 +59,7 @@ func preInstall(compContext spi.ComponentContext) error {
 		return compContext.Log().ErrorfNewErr("Failed to create or update the %s namespace: %v", ComponentNamespace, err)
 	}
 
-	if err := common.CopyOCIDNSSecret(compContext, ComponentNamespace); err != nil {
+	if err := common.CopyDNSProviderSecret(compContext, ComponentNamespace); err != nil {
 		return err
 	}
 	return nil
@@ -129,11 +129,11 @@ func (c *externalDNSComponent) isExternalDNSReady(compContext spi.ComponentConte
 // AppendOverrides builds the set of external-dns overrides for the helm install
 func AppendOverrides(compContext spi.ComponentContext, releaseName string, namespace string, _ string, kvs []bom.KeyValue) ([]bom.KeyValue, error) {
 	effectiveCR := compContext.EffectiveCR()
-	oci, err := getOCIDNS(effectiveCR)
+	provider, err := getDNSProvider(effectiveCR)
 	if err != nil {
 		return kvs, err
 	}
-	// OCI DNS is configured, append all helm overrides for external DNS
+	// A managed DNS provider is configured, append all helm overrides for external DNS
 	ids, err := getOrBuildIDs(compContext, releaseName, namespace)
 	if err != nil {
 		return kvs, err
@@ -141,17 +141,11 @@ func AppendOverrides(compContext spi.ComponentContext, releaseName string, names
 	ownerID := ids[0]
 	txtPrefix := ids[1]
 	compContext.Log().Debugf("Owner ID: %s, TXT record prefix: %s", ownerID, txtPrefix)
-	arguments := []bom.KeyValue{
-		{Key: "domainFilters[0]", Value: oci.DNSZoneName},
-		{Key: "zoneIDFilters[0]", Value: oci.DNSZoneOCID},
-		{Key: "ociDnsScope", Value: oci.DNSScope},
-		{Key: "txtOwnerId", Value: ownerID},
-		{Key: "txtPrefix", Value: txtPrefix},
-		{Key: "extraVolumes[0].name", Value: "config"},
-		{Key: "extraVolumes[0].secret.secretName", Value: oci.OCIConfigSecret},
-		{Key: "extraVolumeMounts[0].name", Value: "config"},
-		{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"},
-	}
+	arguments := provider.ExternalDNSOverrides()
+	arguments = append(arguments,
+		bom.KeyValue{Key: "txtOwnerId", Value: ownerID},
+		bom.KeyValue{Key: "txtPrefix", Value: txtPrefix},
+	)
 	for i, source := range getSources(effectiveCR) {
 		arguments = append(arguments, bom.KeyValue{
 			Key:   fmt.Sprintf("sources[%v]", i),
@@ -171,17 +165,16 @@ func getSources(vz *vzapi.Verrazzano) []string {
 	return sources
 }
 
-func getOCIDNS(vz *vzapi.Verrazzano) (*vzapi.OCI, error) {
-	dns := vz.Spec.Components.DNS
+func getDNSProvider(vz *vzapi.Verrazzano) (common.DNSProvider, error) {
 	// Should never fail the next error checks if IsEnabled() is correct, but can't hurt to check
-	if dns == nil {
+	if vz.Spec.Components.DNS == nil {
 		return nil, fmt.Errorf("DNS not configured for component %s", ComponentName)
 	}
-	oci := dns.OCI
-	if oci == nil {
-		return nil, fmt.Errorf("OCI must be configured for component %s", ComponentName)
+	provider := common.GetDNSProvider(vz)
+	if provider == nil {
+		return nil, fmt.Errorf("OCI or RFC2136 DNS must be configured for component %s", ComponentName)
 	}
-	return oci, nil
+	return provider, nil
 }
 
 // getOrBuildIDs Get the owner and TXT prefix IDs from the Helm release if they exist and preserve it, otherwise build a new ones
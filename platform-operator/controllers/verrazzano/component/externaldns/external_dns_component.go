@@ -103,7 +103,7 @@ func (c *externalDNSComponent) PostUninstall(ctx spi.ComponentContext) error {
 func (c *externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if c.IsEnabled(old) && !c.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
 	}
 	return c.HelmComponent.ValidateUpdate(old, new)
 }
@@ -112,7 +112,7 @@ func (c *externalDNSComponent) ValidateUpdate(old *vzapi.Verrazzano, new *vzapi.
 func (c *externalDNSComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano, new *installv1beta1.Verrazzano) error {
 	// Do not allow any changes except to enable the component post-install
 	if c.IsEnabled(old) && !c.IsEnabled(new) {
-		return fmt.Errorf("Disabling an existing OCI DNS configuration is not allowed")
+		return fmt.Errorf("Disabling an existing OCI or RFC2136 DNS configuration is not allowed")
 	}
 	return c.HelmComponent.ValidateUpdateV1Beta1(old, new)
 }
@@ -4,6 +4,7 @@
 package externaldns
 
 import (
+	"context"
 	"fmt"
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
@@ -17,6 +18,7 @@ import (
 	"helm.sh/helm/v3/pkg/time"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	corev1Cli "k8s.io/client-go/kubernetes/typed/core/v1"
 	"strings"
@@ -276,20 +278,21 @@ func runOverridesTest(t *testing.T, localvz *vzapi.Verrazzano, asserts *assert.A
 	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
 	assert.NoError(t, err)
 
-	expectedLength := 11
-	asserts.Equal(kvs[0], bom.KeyValue{Key: "domainFilters[0]", Value: zoneName})
-	asserts.Equal(kvs[1], bom.KeyValue{Key: "zoneIDFilters[0]", Value: zoneID})
-	asserts.Equal(kvs[2], bom.KeyValue{Key: "ociDnsScope", Value: ""})
-	asserts.Equal(kvs[3], bom.KeyValue{Key: "txtOwnerId", Value: "v8o-811c9dc5"})
-	asserts.Equal(kvs[4], bom.KeyValue{Key: "txtPrefix", Value: "_v8o-811c9dc5-"})
-	asserts.Equal(kvs[5], bom.KeyValue{Key: "extraVolumes[0].name", Value: "config"})
-	asserts.Equal(kvs[6], bom.KeyValue{Key: "extraVolumes[0].secret.secretName", Value: ociDNSSecretName})
-	asserts.Equal(kvs[7], bom.KeyValue{Key: "extraVolumeMounts[0].name", Value: "config"})
-	asserts.Equal(kvs[8], bom.KeyValue{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"})
-	asserts.Equal(kvs[9], bom.KeyValue{Key: "sources[0]", Value: "ingress"})
-	asserts.Equal(kvs[10], bom.KeyValue{Key: "sources[1]", Value: "service"})
+	expectedLength := 12
+	asserts.Equal(kvs[0], bom.KeyValue{Key: "provider", Value: "oci"})
+	asserts.Equal(kvs[1], bom.KeyValue{Key: "domainFilters[0]", Value: zoneName})
+	asserts.Equal(kvs[2], bom.KeyValue{Key: "zoneIDFilters[0]", Value: zoneID})
+	asserts.Equal(kvs[3], bom.KeyValue{Key: "ociDnsScope", Value: ""})
+	asserts.Equal(kvs[4], bom.KeyValue{Key: "extraVolumes[0].name", Value: "config"})
+	asserts.Equal(kvs[5], bom.KeyValue{Key: "extraVolumes[0].secret.secretName", Value: ociDNSSecretName})
+	asserts.Equal(kvs[6], bom.KeyValue{Key: "extraVolumeMounts[0].name", Value: "config"})
+	asserts.Equal(kvs[7], bom.KeyValue{Key: "extraVolumeMounts[0].mountPath", Value: "/etc/kubernetes/"})
+	asserts.Equal(kvs[8], bom.KeyValue{Key: "txtOwnerId", Value: "v8o-811c9dc5"})
+	asserts.Equal(kvs[9], bom.KeyValue{Key: "txtPrefix", Value: "_v8o-811c9dc5-"})
+	asserts.Equal(kvs[10], bom.KeyValue{Key: "sources[0]", Value: "ingress"})
+	asserts.Equal(kvs[11], bom.KeyValue{Key: "sources[1]", Value: "service"})
 	if vzcr.IsIstioEnabled(localvz) {
-		asserts.Equal(kvs[11], bom.KeyValue{Key: "sources[2]", Value: "istio-gateway"})
+		asserts.Equal(kvs[12], bom.KeyValue{Key: "sources[2]", Value: "istio-gateway"})
 		expectedLength++
 	}
 
@@ -297,6 +300,61 @@ func runOverridesTest(t *testing.T, localvz *vzapi.Verrazzano, asserts *assert.A
 
 }
 
+// TestAppendExternalDNSOverridesRFC2136 tests the AppendOverrides fn
+// GIVEN a call to AppendOverrides
+// WHEN a VZ spec is passed with RFC2136 DNS without a port and TSIG algorithm
+// THEN the rfc2136 provider overrides are created with the default port and algorithm, and the TSIG key is read
+// from the TSIG secret
+func TestAppendExternalDNSOverridesRFC2136(t *testing.T) {
+	defer helm.SetDefaultActionConfigFunction()
+	helm.SetActionConfigFunction(testActionConfigWithInstallationNoValues)
+
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS = &vzapi.DNSComponent{RFC2136: &vzapi.RFC2136{
+		Nameserver:  "10.0.0.10",
+		DNSZoneName: zoneName,
+		TSIGKeyName: "externaldns-key",
+		TSIGSecret:  "tsig-secret",
+	}}
+	kvs, err := AppendOverrides(spi.NewFakeContext(nil, localvz, nil, false, profileDir), ComponentName, ComponentNamespace, "", []bom.KeyValue{})
+	assert.NoError(t, err)
+
+	assert.Contains(t, kvs, bom.KeyValue{Key: "provider", Value: "rfc2136"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "domainFilters[0]", Value: zoneName})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.host", Value: "10.0.0.10"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.port", Value: "53"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.zone", Value: zoneName})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigKeyname", Value: "externaldns-key"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "rfc2136.tsigSecretAlg", Value: "hmac-sha256"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "extraEnv[0].valueFrom.secretKeyRef.name", Value: "tsig-secret"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "extraEnv[0].valueFrom.secretKeyRef.key", Value: "secret"})
+	assert.Contains(t, kvs, bom.KeyValue{Key: "txtOwnerId", Value: "v8o-811c9dc5"})
+	assert.NotContains(t, kvs, bom.KeyValue{Key: "extraVolumes[0].name", Value: "config"})
+}
+
+// TestExternalDNSPreInstallRFC2136 tests the PreInstall fn
+// GIVEN a call to this fn
+// WHEN RFC2136 DNS is configured and the TSIG secret exists in the verrazzano-install namespace
+// THEN the TSIG key is copied to the external-dns namespace
+func TestExternalDNSPreInstallRFC2136(t *testing.T) {
+	localvz := vz.DeepCopy()
+	localvz.Spec.Components.DNS = &vzapi.DNSComponent{RFC2136: &vzapi.RFC2136{
+		Nameserver:  "10.0.0.10",
+		DNSZoneName: zoneName,
+		TSIGKeyName: "externaldns-key",
+		TSIGSecret:  "tsig-secret",
+	}}
+	client := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig-secret", Namespace: constants.VerrazzanoInstallNamespace},
+		Data:       map[string][]byte{"secret": []byte("c2VjcmV0"), "other": []byte("other")},
+	}).Build()
+	assert.NoError(t, preInstall(spi.NewFakeContext(client, localvz, nil, false)))
+
+	secret := &v1.Secret{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "tsig-secret", Namespace: ComponentNamespace}, secret))
+	assert.Equal(t, map[string][]byte{"secret": []byte("c2VjcmV0")}, secret.Data)
+}
+
 // TestExternalDNSPreInstallDryRun tests the PreInstall fn
 // GIVEN a call to this fn
 // WHEN I call PreInstall with dry-run = true
@@ -114,6 +114,14 @@ type HelmComponent struct {
 // Verify that HelmComponent implements Component
 var _ spi.Component = HelmComponent{}
 
+// ChartComponent is implemented by the components installed from a Helm chart
+type ChartComponent interface {
+	// GetChartDir returns the directory of the chart the component is installed from
+	GetChartDir() string
+}
+
+var _ ChartComponent = HelmComponent{}
+
 // preInstallFuncSig is the signature for the optional function to run before installing; any KeyValue pairs should be prepended to the Helm overrides list
 type preInstallFuncSig func(context spi.ComponentContext, releaseName string, namespace string, chartDir string) error
 
@@ -181,6 +189,11 @@ func (h HelmComponent) GetOverrides(cr runtime.Object) interface{} {
 
 }
 
+// GetChartDir returns the directory of the chart the component is installed from
+func (h HelmComponent) GetChartDir() string {
+	return h.ChartDir
+}
+
 // GetDependencies returns the Dependencies of this component
 func (h HelmComponent) GetDependencies() []string {
 	return h.Dependencies
@@ -304,6 +317,29 @@ func (h HelmComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1bet
 	return h.v1beta1Validate(new)
 }
 
+// ValidateOverridesSchema validates the install overrides of a component against the values schema of its Helm chart.
+// The keys which are not defined by the chart are returned as warnings, and the values of the wrong type as an error.
+// The components which are not installed from a Helm chart are not validated.
+func ValidateOverridesSchema(comp spi.Component, overrides []string) ([]string, error) {
+	chartComp, ok := comp.(ChartComponent)
+	if !ok || len(chartComp.GetChartDir()) == 0 || len(overrides) == 0 {
+		return nil, nil
+	}
+	result, err := helm.ValidateValuesSchema(chartComp.GetChartDir(), overrides...)
+	if err != nil {
+		// The overrides are still validated by Helm when the component is installed
+		return []string{fmt.Sprintf("Could not validate the install overrides of the %s component against the values schema of its Helm chart: %v", comp.Name(), err)}, nil
+	}
+	var warnings []string
+	for _, key := range result.UnknownKeys {
+		warnings = append(warnings, fmt.Sprintf("The install overrides of the %s component set the value %s, which is not defined by its Helm chart", comp.Name(), key))
+	}
+	if len(result.TypeErrors) > 0 {
+		return warnings, fmt.Errorf("The install overrides of the %s component are not valid for its Helm chart: %s", comp.Name(), strings.Join(result.TypeErrors, "; "))
+	}
+	return warnings, nil
+}
+
 func (h HelmComponent) MonitorOverrides(ctx spi.ComponentContext) bool {
 	return true
 }
@@ -0,0 +1,155 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+// CreateOrUpdateGroupRole creates the realm role and the group, as a subgroup of the Verrazzano users group, if they
+// do not exist, and grants the role to the group. The members of the group get the role in their tokens, which the
+// Verrazzano authentication proxy passes on to OpenSearch as backend roles.
+func CreateOrUpdateGroupRole(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, groupName string, roleName string) error {
+	if err := createVerrazzanoRole(ctx, cfg, cli, roleName); err != nil {
+		return err
+	}
+	kcPod := keycloakPod()
+	keycloakGroups, err := getKeycloakGroups(ctx, cfg, cli, kcPod)
+	if err != nil {
+		return err
+	}
+	groupID := getGroupID(keycloakGroups, groupName)
+	if groupID == "" {
+		groupID, err = createVerrazzanoGroup(ctx, cfg, cli, groupName, getGroupID(keycloakGroups, vzUsersGroup))
+		if err != nil {
+			return err
+		}
+	}
+	// Keycloak API does not fail if the group already has the role
+	cmd := fmt.Sprintf("%s add-roles -r %s --gid %s --rolename %s", kcAdminScript, vzSysRealm, groupID, roleName)
+	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed granting role %s to group %s: stdout = %s, stderr = %s", roleName, groupName, stdout, stderr)
+		return err
+	}
+	return nil
+}
+
+// GetGroupMembers returns the user names of the members of a group, or nil if the group does not exist
+func GetGroupMembers(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, groupName string) ([]string, error) {
+	kcPod := keycloakPod()
+	keycloakGroups, err := getKeycloakGroups(ctx, cfg, cli, kcPod)
+	if err != nil {
+		return nil, err
+	}
+	groupID := getGroupID(keycloakGroups, groupName)
+	if groupID == "" {
+		return nil, nil
+	}
+	cmd := fmt.Sprintf("%s get groups/%s/members -r %s", kcAdminScript, groupID, vzSysRealm)
+	out, _, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed retrieving the members of group %s: %v", groupName, err)
+		return nil, err
+	}
+	var members []KeycloakUser
+	if err := json.Unmarshal([]byte(out), &members); err != nil {
+		ctx.Log().Errorf("Component Keycloak failed unmarshalling the members of group %s: %v", groupName, err)
+		return nil, err
+	}
+	var userNames []string
+	for _, member := range members {
+		userNames = append(userNames, member.Username)
+	}
+	return userNames, nil
+}
+
+// DeleteRole deletes a realm role if it exists, the groups it was granted to are left alone
+func DeleteRole(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, roleName string) error {
+	kcPod := keycloakPod()
+	keycloakRoles, err := getKeycloakRoles(ctx, cfg, cli, kcPod)
+	if err != nil {
+		return err
+	}
+	if !roleExists(keycloakRoles, roleName) {
+		return nil
+	}
+	cmd := fmt.Sprintf("%s delete roles/%s -r %s", kcAdminScript, roleName, vzSysRealm)
+	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed deleting role %s: stdout = %s, stderr = %s", roleName, stdout, stderr)
+		return err
+	}
+	ctx.Log().Oncef("Component Keycloak successfully deleted the %s role", roleName)
+	return nil
+}
+
+// setUserPasswordCommand reads the new password from the standard input, so that it does not appear in the command line
+const setUserPasswordCommand = `IFS= read -r NEW_PASSWORD && \
+USER_ID=$(%[1]s get users -r %[2]s -q username=%[3]s -q exact=true --fields id --format csv --noquotes) && \
+printf '{"type":"password","temporary":false,"value":"%%s"}' "$NEW_PASSWORD" | \
+%[1]s update users/$USER_ID/reset-password -r %[2]s -n -f -`
+
+// CreateOrUpdateUserRole creates the realm role and the user, outside of the Verrazzano users group, if they do not
+// exist, sets the password of the user and grants the role to the user. Such users are the identities of the services
+// calling the Verrazzano authentication proxy on behalf of a project.
+func CreateOrUpdateUserRole(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, userName string, password string, roleName string) error {
+	if err := createVerrazzanoRole(ctx, cfg, cli, roleName); err != nil {
+		return err
+	}
+	kcPod := keycloakPod()
+	keycloakUsers, err := getKeycloakUsers(ctx, cfg, cli, kcPod)
+	if err != nil {
+		return err
+	}
+	if !userExists(keycloakUsers, userName) {
+		cmd := fmt.Sprintf("%s create users -r %s -s username=%s -s enabled=true", kcAdminScript, vzSysRealm, userName)
+		stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+		if err != nil {
+			ctx.Log().Errorf("Component Keycloak failed creating user %s: stdout = %s, stderr = %s", userName, stdout, stderr)
+			return err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully created user %s", userName)
+	}
+	cmd := fmt.Sprintf(setUserPasswordCommand, kcAdminScript, vzSysRealm, userName)
+	if _, stderr, err := k8sutil.ExecPodWithStdin(cli, cfg, kcPod, ComponentName, bashCMD(cmd), password+"\n"); err != nil {
+		ctx.Log().Errorf("Component Keycloak failed setting the password of user %s: stderr = %s", userName, stderr)
+		return err
+	}
+	cmd = fmt.Sprintf("%s add-roles -r %s --uusername %s --rolename %s", kcAdminScript, vzSysRealm, userName, roleName)
+	stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+	if err != nil {
+		ctx.Log().Errorf("Component Keycloak failed granting role %s to user %s: stdout = %s, stderr = %s", roleName, userName, stdout, stderr)
+		return err
+	}
+	return nil
+}
+
+// DeleteUser deletes a user of the Verrazzano realm if it exists
+func DeleteUser(ctx spi.ComponentContext, cfg *restclient.Config, cli kubernetes.Interface, userName string) error {
+	kcPod := keycloakPod()
+	keycloakUsers, err := getKeycloakUsers(ctx, cfg, cli, kcPod)
+	if err != nil {
+		return err
+	}
+	for _, user := range keycloakUsers {
+		if user.Username != userName {
+			continue
+		}
+		cmd := fmt.Sprintf("%s delete users/%s -r %s", kcAdminScript, user.ID, vzSysRealm)
+		stdout, stderr, err := k8sutil.ExecPod(cli, cfg, kcPod, ComponentName, bashCMD(cmd))
+		if err != nil {
+			ctx.Log().Errorf("Component Keycloak failed deleting user %s: stdout = %s, stderr = %s", userName, stdout, stderr)
+			return err
+		}
+		ctx.Log().Oncef("Component Keycloak successfully deleted user %s", userName)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package keycloak
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testGroups = `[{"id":"users-id","name":"verrazzano-users","path":"/verrazzano-users","subGroups":[
+	{"id":"admins-id","name":"verrazzano-project-hello-admins","path":"/verrazzano-users/verrazzano-project-hello-admins","subGroups":[]}]}]`
+
+// setupKeycloakExec fakes the Keycloak admin commands, recording them and answering them with the given function
+func setupKeycloakExec(t *testing.T, respond func(cmd string) string) *[]string {
+	commands := &[]string{}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	podExecFunc := k8sutilfake.PodExecResult
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		cmd := url.Query()["command"][2]
+		*commands = append(*commands, cmd)
+		return respond(cmd), "", nil
+	}
+	t.Cleanup(func() {
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = podExecFunc
+	})
+	return commands
+}
+
+// TestCreateOrUpdateGroupRole tests the CreateOrUpdateGroupRole function
+// GIVEN a project group that does not exist in Keycloak
+// WHEN CreateOrUpdateGroupRole is called
+// THEN the role is created, the group is created under the Verrazzano users group and the role is granted to it
+func TestCreateOrUpdateGroupRole(t *testing.T) {
+	commands := setupKeycloakExec(t, func(cmd string) string {
+		switch {
+		case strings.Contains(cmd, "get-roles"):
+			return `[{"name":"vz_api_access"}]`
+		case strings.Contains(cmd, "get groups"):
+			return testGroups
+		case strings.Contains(cmd, "create groups"):
+			return "Created new group with id 'monitors-id'"
+		}
+		return ""
+	})
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	err := CreateOrUpdateGroupRole(ctx, cfg, cli, "verrazzano-project-hello-monitors", "verrazzano-project-hello-monitor")
+	assert.NoError(t, err)
+	assert.Contains(t, strings.Join(*commands, "\n"), "create roles -r verrazzano-system -s name=verrazzano-project-hello-monitor")
+	assert.Contains(t, strings.Join(*commands, "\n"), "create groups/users-id/children -r verrazzano-system -s name=verrazzano-project-hello-monitors")
+	assert.Contains(t, (*commands)[len(*commands)-1], "add-roles -r verrazzano-system --gid monitors-id --rolename verrazzano-project-hello-monitor")
+}
+
+// TestCreateOrUpdateGroupRoleExistingGroup tests the CreateOrUpdateGroupRole function
+// GIVEN a project group and role that exist in Keycloak
+// WHEN CreateOrUpdateGroupRole is called
+// THEN the role is granted to the group without creating anything
+func TestCreateOrUpdateGroupRoleExistingGroup(t *testing.T) {
+	commands := setupKeycloakExec(t, func(cmd string) string {
+		switch {
+		case strings.Contains(cmd, "get-roles"):
+			return `[{"name":"verrazzano-project-hello-admin"}]`
+		case strings.Contains(cmd, "get groups"):
+			return testGroups
+		}
+		return ""
+	})
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	err := CreateOrUpdateGroupRole(ctx, cfg, cli, "verrazzano-project-hello-admins", "verrazzano-project-hello-admin")
+	assert.NoError(t, err)
+	for _, cmd := range *commands {
+		assert.NotContains(t, cmd, " create ")
+	}
+	assert.Contains(t, (*commands)[len(*commands)-1], "--gid admins-id --rolename verrazzano-project-hello-admin")
+}
+
+// TestGetGroupMembers tests the GetGroupMembers function
+// GIVEN a project group with members, and a group that does not exist
+// WHEN GetGroupMembers is called
+// THEN the user names of the members are returned, and nil for the missing group
+func TestGetGroupMembers(t *testing.T) {
+	setupKeycloakExec(t, func(cmd string) string {
+		switch {
+		case strings.Contains(cmd, "get groups/admins-id/members"):
+			return `[{"id":"1","username":"alice"},{"id":"2","username":"bob"}]`
+		case strings.Contains(cmd, "get groups"):
+			return testGroups
+		}
+		return ""
+	})
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	members, err := GetGroupMembers(ctx, cfg, cli, "verrazzano-project-hello-admins")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, members)
+
+	members, err = GetGroupMembers(ctx, cfg, cli, "verrazzano-project-other-admins")
+	assert.NoError(t, err)
+	assert.Nil(t, members)
+}
+
+// TestDeleteRole tests the DeleteRole function
+// GIVEN a role that exists in Keycloak and one that does not
+// WHEN DeleteRole is called
+// THEN only the existing role is deleted
+func TestDeleteRole(t *testing.T) {
+	commands := setupKeycloakExec(t, func(cmd string) string {
+		if strings.Contains(cmd, "get-roles") {
+			return `[{"name":"verrazzano-project-hello-admin"}]`
+		}
+		return ""
+	})
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	assert.NoError(t, DeleteRole(ctx, cfg, cli, "verrazzano-project-hello-admin"))
+	assert.Contains(t, (*commands)[len(*commands)-1], "delete roles/verrazzano-project-hello-admin -r verrazzano-system")
+
+	*commands = nil
+	assert.NoError(t, DeleteRole(ctx, cfg, cli, "verrazzano-project-hello-monitor"))
+	assert.Len(t, *commands, 1)
+}
+
+// TestCreateOrUpdateUserRole tests the CreateOrUpdateUserRole function
+// GIVEN a project user that does not exist in Keycloak
+// WHEN CreateOrUpdateUserRole is called
+// THEN the user is created, its password is passed on the standard input and the role is granted to it
+func TestCreateOrUpdateUserRole(t *testing.T) {
+	commands := setupKeycloakExec(t, func(cmd string) string {
+		switch {
+		case strings.Contains(cmd, "get-roles"):
+			return `[{"name":"verrazzano-project-hello-monitor"}]`
+		case strings.Contains(cmd, "get users"):
+			return `[{"id":"1","username":"verrazzano"}]`
+		}
+		return ""
+	})
+	var stdin []string
+	podExecStdin := k8sutilfake.PodExecStdin
+	k8sutilfake.PodExecStdin = func(_ *url.URL, in string) { stdin = append(stdin, in) }
+	defer func() { k8sutilfake.PodExecStdin = podExecStdin }()
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	err := CreateOrUpdateUserRole(ctx, cfg, cli, "verrazzano-project-hello-logs", "secret-pw", "verrazzano-project-hello-monitor")
+	assert.NoError(t, err)
+	assert.Contains(t, strings.Join(*commands, "\n"), "create users -r verrazzano-system -s username=verrazzano-project-hello-logs -s enabled=true")
+	assert.Contains(t, strings.Join(*commands, "\n"), "reset-password -r verrazzano-system")
+	assert.NotContains(t, strings.Join(*commands, "\n"), "secret-pw")
+	assert.Equal(t, []string{"secret-pw\n"}, stdin)
+	assert.Contains(t, (*commands)[len(*commands)-1], "add-roles -r verrazzano-system --uusername verrazzano-project-hello-logs --rolename verrazzano-project-hello-monitor")
+}
+
+// TestDeleteUser tests the DeleteUser function
+// GIVEN a user that exists in Keycloak and one that does not
+// WHEN DeleteUser is called
+// THEN only the existing user is deleted
+func TestDeleteUser(t *testing.T) {
+	commands := setupKeycloakExec(t, func(cmd string) string {
+		if strings.Contains(cmd, "get users") {
+			return `[{"id":"logs-id","username":"verrazzano-project-hello-logs"}]`
+		}
+		return ""
+	})
+	cfg, cli, _ := fakeRESTConfig()
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build(), testVZ, nil, false)
+
+	assert.NoError(t, DeleteUser(ctx, cfg, cli, "verrazzano-project-hello-logs"))
+	assert.Contains(t, (*commands)[len(*commands)-1], "delete users/logs-id -r verrazzano-system")
+
+	*commands = nil
+	assert.NoError(t, DeleteUser(ctx, cfg, cli, "verrazzano-project-other-logs"))
+	assert.Len(t, *commands, 1)
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	osapi "github.com/verrazzano/verrazzano/pkg/opensearch"
+	vzstring "github.com/verrazzano/verrazzano/pkg/string"
+	"github.com/verrazzano/verrazzano/pkg/vzcr"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// legacyDataNodePool is the name of the data node pool configured with the nodes.data install args
+const legacyDataNodePool = "es-data"
+
+// allocationExcludeAnnotation is the annotation of the VMI with the node name patterns that were excluded from shard
+// allocation to remove data nodes
+const allocationExcludeAnnotation = "verrazzano.io/opensearch-allocation-exclude"
+
+// reconcileVMI creates or updates the VMI, changing the OpenSearch node pools safely:
+//   - data nodes are removed one at a time, once their shards have been relocated to the other nodes and the cluster is green
+//   - the VMI is not updated while the cluster is red, unless nodes are only added
+//   - upgrades are refused while the cluster is red. The VMO rolls the data nodes one at a time while the cluster is green.
+//
+// A RetryableError is returned until all the data nodes to remove are gone, the progress is recorded in the component status.
+func reconcileVMI(ctx spi.ComponentContext) error {
+	if !vzcr.IsVMOEnabled(ctx.EffectiveCR()) {
+		return nil
+	}
+	existingVMI := common.NewVMI()
+	if err := ctx.Client().Get(context.TODO(), clipkg.ObjectKeyFromObject(existingVMI), existingVMI); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctx.Log().ErrorfNewErr("Failed getting the VMI: %v", err)
+		}
+		// A new cluster has no data to protect
+		return common.CreateOrUpdateVMI(ctx, updateFunc)
+	}
+	desiredVMI, err := getDesiredVMI(ctx, existingVMI)
+	if err != nil {
+		return err
+	}
+	existingPools := dataNodePools(&existingVMI.Spec.Opensearch)
+	desiredPools := dataNodePools(&desiredVMI.Spec.Opensearch)
+	pool, shrinking := nextShrinkingPool(existingPools, desiredPools)
+	upgrading := ctx.GetOperation() == constants.UpgradeOperation
+	inProgress := getProgress(ctx) != ""
+	excluding := len(getExcludedDataNodes(existingVMI)) > 0
+	if !shrinking && !upgrading && !inProgress && !excluding {
+		return common.CreateOrUpdateVMI(ctx, updateFunc)
+	}
+
+	client, err := newOpenSearchClient()
+	var health *osapi.ClusterHealth
+	if err == nil {
+		health, err = client.GetClusterHealth()
+	}
+	if err != nil {
+		if shrinking {
+			return retryNodePools(ctx, fmt.Sprintf("Waiting for the OpenSearch cluster to be reachable before removing data nodes: %v", err))
+		}
+		// An unreachable cluster may need the VMI update to recover
+		ctx.Log().Infof("Component %s could not get the OpenSearch cluster health: %v", ComponentName, err)
+	} else if health.Status == osapi.HealthRed && (shrinking || upgrading) {
+		return retryNodePools(ctx, "Waiting for the OpenSearch cluster health to recover from red")
+	}
+
+	if shrinking {
+		return removeDataNode(ctx, client, health, existingVMI, existingPools, desiredPools, pool)
+	}
+	if err := waitForRemovedDataNodes(ctx, existingPools); err != nil {
+		return err
+	}
+	if excluding && health != nil {
+		if err := removeDataNodeExclusions(ctx, client, existingVMI, ""); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed removing the OpenSearch shard allocation exclusion of the removed data nodes: %v", err)
+		}
+	}
+	if err := common.CreateOrUpdateVMI(ctx, updateFunc); err != nil {
+		return err
+	}
+	spi.RecordProgress(ctx, ComponentName, "")
+	return nil
+}
+
+// removeDataNode removes the last data node of a node pool. The node is first excluded from shard allocation, then it
+// is removed from the VMI once it holds no shards and the cluster is green.
+func removeDataNode(ctx spi.ComponentContext, client *osapi.Client, health *osapi.ClusterHealth, existingVMI *vmov1.VerrazzanoMonitoringInstance, existingPools, desiredPools map[string]int32, pool string) error {
+	if err := waitForRemovedDataNodes(ctx, existingPools); err != nil {
+		return err
+	}
+
+	// The node names are the pod names of the data node deployment
+	nodeName := fmt.Sprintf("%s-%d", fmt.Sprintf(nodeNamePrefix, pool), existingPools[pool]-1)
+	nodeNames := nodeName + "-*"
+	// The exclusion is recorded before it is added, so that it is removed even if the node is not removed after all
+	if excluded := getExcludedDataNodes(existingVMI); !vzstring.SliceContainsString(excluded, nodeNames) {
+		if err := setExcludedDataNodes(ctx, existingVMI, append(excluded, nodeNames)); err != nil {
+			return err
+		}
+	}
+	if err := client.AddAllocationExclude(nodeNames); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed excluding OpenSearch data node %s from shard allocation: %v", nodeName, err)
+	}
+	if err := removeDataNodeExclusions(ctx, client, existingVMI, nodeNames); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed removing the OpenSearch shard allocation exclusion of the data nodes that are not removed: %v", err)
+	}
+	shards, err := client.GetShards()
+	if err != nil {
+		return ctx.Log().ErrorfNewErr("Failed getting the OpenSearch shards: %v", err)
+	}
+	nodeShards := 0
+	for _, shard := range shards {
+		if strings.HasPrefix(shard.Node, nodeName+"-") {
+			nodeShards++
+		}
+	}
+	if nodeShards > 0 || health.Status != osapi.HealthGreen || health.RelocatingShards > 0 {
+		return retryNodePools(ctx, fmt.Sprintf("Relocating %d shards from OpenSearch data node %s, the cluster health is %s", nodeShards, nodeName, health.Status))
+	}
+
+	spi.RecordProgress(ctx, ComponentName, fmt.Sprintf("Removing OpenSearch data node %s, scaling node pool %s from %d to %d data nodes", nodeName, pool, existingPools[pool], desiredPools[pool]))
+	err = common.CreateOrUpdateVMI(ctx, func(ctx spi.ComponentContext, storage *common.ResourceRequestValues, vmi *vmov1.VerrazzanoMonitoringInstance, existing *vmov1.VerrazzanoMonitoringInstance) error {
+		if err := updateFunc(ctx, storage, vmi, existing); err != nil {
+			return err
+		}
+		// The other shrinking node pools keep their data nodes until their turn
+		for name, replicas := range existingPools {
+			if desiredPools[name] < replicas {
+				setDataNodePoolReplicas(&vmi.Spec.Opensearch, &existingVMI.Spec.Opensearch, name, replicas)
+			}
+		}
+		setDataNodePoolReplicas(&vmi.Spec.Opensearch, &existingVMI.Spec.Opensearch, pool, existingPools[pool]-1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return ctrlerrors.RetryableError{Source: ComponentName}
+}
+
+// removeDataNodeExclusions removes the shard allocation exclusions added by removeDataNode, except the exclusion of
+// the data node that is being removed. The exclusions added by others are kept.
+func removeDataNodeExclusions(ctx spi.ComponentContext, client *osapi.Client, vmi *vmov1.VerrazzanoMonitoringInstance, removingNodeNames string) error {
+	excluded := getExcludedDataNodes(vmi)
+	var stale, remaining []string
+	for _, nodeNames := range excluded {
+		if nodeNames == removingNodeNames {
+			remaining = append(remaining, nodeNames)
+			continue
+		}
+		stale = append(stale, nodeNames)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	if err := client.RemoveAllocationExclude(stale...); err != nil {
+		return err
+	}
+	return setExcludedDataNodes(ctx, vmi, remaining)
+}
+
+// getExcludedDataNodes returns the node name patterns that removeDataNode excluded from shard allocation
+func getExcludedDataNodes(vmi *vmov1.VerrazzanoMonitoringInstance) []string {
+	value := vmi.Annotations[allocationExcludeAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setExcludedDataNodes records the node name patterns that removeDataNode excluded from shard allocation in an
+// annotation of the VMI
+func setExcludedDataNodes(ctx spi.ComponentContext, vmi *vmov1.VerrazzanoMonitoringInstance, nodeNames []string) error {
+	patch := clipkg.MergeFrom(vmi.DeepCopy())
+	if len(nodeNames) == 0 {
+		delete(vmi.Annotations, allocationExcludeAnnotation)
+	} else {
+		if vmi.Annotations == nil {
+			vmi.Annotations = map[string]string{}
+		}
+		vmi.Annotations[allocationExcludeAnnotation] = strings.Join(nodeNames, ",")
+	}
+	if err := ctx.Client().Patch(context.TODO(), vmi, patch); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed recording the OpenSearch shard allocation exclusions in the VMI: %v", err)
+	}
+	return nil
+}
+
+// waitForRemovedDataNodes returns a RetryableError until the VMO has removed the data nodes beyond the replicas of
+// the VMI, otherwise shards could be allocated to them again
+func waitForRemovedDataNodes(ctx spi.ComponentContext, existingPools map[string]int32) error {
+	for pool, replicas := range existingPools {
+		removedNode := fmt.Sprintf("%s-%d", fmt.Sprintf(nodeNamePrefix, pool), replicas)
+		exists, err := deploymentExists(ctx, removedNode)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return retryNodePools(ctx, fmt.Sprintf("Waiting for OpenSearch data node %s to be removed", removedNode))
+		}
+	}
+	return nil
+}
+
+// retryNodePools records the progress of the node pool changes and returns a RetryableError
+func retryNodePools(ctx spi.ComponentContext, progress string) error {
+	ctx.Log().Progressf("Component %s: %s", ComponentName, progress)
+	spi.RecordProgress(ctx, ComponentName, progress)
+	return ctrlerrors.RetryableError{Source: ComponentName}
+}
+
+// getDesiredVMI returns the VMI for the effective CR, without updating it
+func getDesiredVMI(ctx spi.ComponentContext, existingVMI *vmov1.VerrazzanoMonitoringInstance) (*vmov1.VerrazzanoMonitoringInstance, error) {
+	storage, err := common.FindStorageOverride(ctx.EffectiveCR())
+	if err != nil {
+		return nil, ctx.Log().ErrorfNewErr("failed to get storage overrides: %v", err)
+	}
+	desiredVMI := existingVMI.DeepCopy()
+	if err := updateFunc(ctx, storage, desiredVMI, existingVMI); err != nil {
+		return nil, err
+	}
+	return desiredVMI, nil
+}
+
+// dataNodePools returns the replicas of the data node pools, which are the nodes with the data role and without the
+// master role. Master nodes are statefulsets, scaled by their quorum rather than their shards.
+func dataNodePools(opensearch *vmov1.Opensearch) map[string]int32 {
+	pools := map[string]int32{}
+	if opensearch.DataNode.Replicas > 0 {
+		pools[legacyDataNodePool] = opensearch.DataNode.Replicas
+	}
+	for _, node := range opensearch.Nodes {
+		if hasRole(node.Roles, vmov1.DataRole) && !hasRole(node.Roles, vmov1.MasterRole) {
+			pools[node.Name] = node.Replicas
+		}
+	}
+	return pools
+}
+
+// nextShrinkingPool returns the first data node pool, by name, with fewer desired replicas than existing replicas
+func nextShrinkingPool(existingPools, desiredPools map[string]int32) (string, bool) {
+	var names []string
+	for name, replicas := range existingPools {
+		if desiredPools[name] < replicas {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+// setDataNodePoolReplicas sets the replicas of a data node pool, adding the node pool of the existing VMI back if
+// it was removed from the VMI
+func setDataNodePoolReplicas(opensearch, existing *vmov1.Opensearch, pool string, replicas int32) {
+	if pool == legacyDataNodePool && existing.DataNode.Replicas > 0 {
+		opensearch.DataNode.Replicas = replicas
+		return
+	}
+	for i := range opensearch.Nodes {
+		if opensearch.Nodes[i].Name == pool {
+			opensearch.Nodes[i].Replicas = replicas
+			return
+		}
+	}
+	for _, node := range existing.Nodes {
+		if node.Name == pool {
+			node.Replicas = replicas
+			opensearch.Nodes = append(opensearch.Nodes, node)
+			return
+		}
+	}
+}
+
+// deploymentExists returns true if a deployment exists in the component namespace
+func deploymentExists(ctx spi.ComponentContext, name string) (bool, error) {
+	err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: name}, &appsv1.Deployment{})
+	if err == nil {
+		return true, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, ctx.Log().ErrorfNewErr("Failed getting deployment %s/%s: %v", ComponentNamespace, name, err)
+}
+
+// recordUpgradeProgress records how many data nodes the VMO has updated during an upgrade
+func recordUpgradeProgress(ctx spi.ComponentContext, ready bool) {
+	if ready {
+		spi.RecordProgress(ctx, ComponentName, "")
+		return
+	}
+	var total, updated int
+	if ctx.EffectiveCR().Spec.Components.Elasticsearch != nil {
+		for _, node := range ctx.EffectiveCR().Spec.Components.Elasticsearch.Nodes {
+			if !hasRole(node.Roles, vmov1.DataRole) || hasRole(node.Roles, vmov1.MasterRole) {
+				continue
+			}
+			for _, key := range dataDeploymentObjectKeys(node, getNodeControllerName(node)) {
+				total++
+				deployment := &appsv1.Deployment{}
+				if err := ctx.Client().Get(context.TODO(), key, deployment); err == nil && isDeploymentUpdated(deployment) {
+					updated++
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return
+	}
+	spi.RecordProgress(ctx, ComponentName, fmt.Sprintf("Rolling upgrade of OpenSearch data nodes, %d of %d data nodes updated", updated, total))
+}
+
+// isDeploymentUpdated returns true if all the replicas of a deployment run its latest spec
+func isDeploymentUpdated(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas
+}
+
+// getProgress returns the progress recorded in the component status
+func getProgress(ctx spi.ComponentContext) string {
+	if ctx.ActualCR() == nil {
+		return ""
+	}
+	if details, ok := ctx.ActualCR().Status.Components[ComponentName]; ok && details != nil {
+		return details.Progress
+	}
+	return ""
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vmov1 "github.com/verrazzano/verrazzano-monitoring-operator/pkg/apis/vmcontroller/v1"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testAllocationExclude is the shard allocation exclusion setting returned by the fake OpenSearch cluster API
+var testAllocationExclude string
+
+// setupNodePoolExec fakes the OpenSearch cluster API in a running master pod with the given cluster health and
+// shards, returning the requests made and the recorded progress
+func setupNodePoolExec(t *testing.T, health string, shards string) (*[]string, *[]string) {
+	requests := &[]string{}
+	progress := &[]string{}
+	prevClientConfig := k8sutil.ClientConfig
+	k8sutil.ClientConfig = func() (*rest.Config, kubernetes.Interface, error) {
+		cfg, cli := k8sutilfake.NewClientsetConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-es-master-0", Labels: map[string]string{"app": "system-es-master"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+		return cfg, cli, nil
+	}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		match := snapshotRequestPattern.FindStringSubmatch(url.Query()["command"][2])
+		if match == nil {
+			return "", "", fmt.Errorf("unexpected command %v", url.Query()["command"])
+		}
+		*requests = append(*requests, fmt.Sprintf("%s %s %s", match[1], match[2], match[3]))
+		switch match[2] {
+		case "/_cluster/health":
+			return fmt.Sprintf("{\"status\":\"%s\"}\n200", health), "", nil
+		case "/_cat/shards?format=json&h=index,shard,prirep,state,node":
+			return shards + "\n200", "", nil
+		case "/_cluster/settings?flat_settings=true":
+			return fmt.Sprintf("{\"persistent\":{\"cluster.routing.allocation.exclude._name\":\"%s\"}}\n200", testAllocationExclude), "", nil
+		}
+		return "{\"acknowledged\":true}\n200", "", nil
+	}
+	spi.SetProgressRecorder(func(vz *vzapi.Verrazzano, component string, p string) {
+		*progress = append(*progress, p)
+	})
+	t.Cleanup(func() {
+		k8sutil.ClientConfig = prevClientConfig
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
+		spi.SetProgressRecorder(nil)
+		testAllocationExclude = ""
+	})
+	return requests, progress
+}
+
+// newNodePoolVZ returns a Verrazzano resource with a data node pool of the given replicas and the given progress
+func newNodePoolVZ(replicas int32, progress string) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				DNS: dnsComponents.DNS,
+				Elasticsearch: &vzapi.ElasticsearchComponent{
+					Nodes: []vzapi.OpenSearchNode{{Name: "data", Replicas: &replicas, Roles: []vmov1.NodeRole{vmov1.DataRole}}},
+				},
+			},
+		},
+		Status: vzapi.VerrazzanoStatus{
+			Components: vzapi.ComponentStatusMap{
+				ComponentName: {Name: ComponentName, Progress: progress},
+			},
+		},
+	}
+}
+
+// newNodePoolVMI returns a VMI with a data node pool of the given replicas
+func newNodePoolVMI(replicas int32) *vmov1.VerrazzanoMonitoringInstance {
+	vmi := common.NewVMI()
+	vmi.Spec.Opensearch.Nodes = []vmov1.ElasticsearchNode{{Name: "data", Replicas: replicas, Roles: []vmov1.NodeRole{vmov1.DataRole}}}
+	return vmi
+}
+
+// getVMI returns the VMI
+func getVMI(t *testing.T, c client.Client) *vmov1.VerrazzanoMonitoringInstance {
+	vmi := common.NewVMI()
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(vmi), vmi))
+	return vmi
+}
+
+// getDataReplicas returns the replicas of the data node pool of the VMI
+func getDataReplicas(t *testing.T, c client.Client) int32 {
+	return dataNodePools(&getVMI(t, c).Spec.Opensearch)["data"]
+}
+
+// TestRemoveDataNode tests removing a data node
+// GIVEN a VMI with 3 data nodes and a Verrazzano resource with 1 data node
+// WHEN the VMI is reconciled, while the last data node holds shards, then once it holds none
+// THEN the last data node is excluded from shard allocation, and it is removed from the VMI only once it holds no shards
+func TestRemoveDataNode(t *testing.T) {
+	requests, progress := setupNodePoolExec(t, "green", `[{"index":"logs","shard":"0","prirep":"p","state":"RELOCATING","node":"vmi-system-data-2-6d5f-x7k2"}]`)
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newNodePoolVMI(3)).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(1, ""), nil, false)
+
+	err := NewComponent().Install(ctx)
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.Equal(t, int32(3), getDataReplicas(t, c))
+	assert.Contains(t, *requests, `PUT /_cluster/settings {"persistent":{"cluster.routing.allocation.exclude._name":"vmi-system-data-2-*"}}`)
+	assert.Equal(t, []string{"vmi-system-data-2-*"}, getExcludedDataNodes(getVMI(t, c)))
+	assert.Equal(t, []string{"Relocating 1 shards from OpenSearch data node vmi-system-data-2, the cluster health is green"}, *progress)
+
+	requests, progress = setupNodePoolExec(t, "green", `[{"index":"logs","shard":"0","prirep":"p","state":"STARTED","node":"vmi-system-data-0-6d5f-a1b2"}]`)
+	err = NewComponent().Install(ctx)
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.Equal(t, int32(2), getDataReplicas(t, c))
+	assert.Equal(t, []string{"Removing OpenSearch data node vmi-system-data-2, scaling node pool data from 3 to 1 data nodes"}, *progress)
+}
+
+// TestWaitForRemovedDataNode tests waiting for the VMO to remove a data node
+// GIVEN a VMI with 2 data nodes, whose third data node deployment still exists
+// WHEN the VMI is reconciled
+// THEN no other data node is excluded from shard allocation until the deployment is removed
+func TestWaitForRemovedDataNode(t *testing.T) {
+	requests, progress := setupNodePoolExec(t, "green", "[]")
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newNodePoolVMI(2), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-data-2"},
+	}).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(1, "Removing OpenSearch data node vmi-system-data-2"), nil, false)
+
+	err := NewComponent().Install(ctx)
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.Equal(t, []string{"GET /_cluster/health "}, *requests)
+	assert.Equal(t, []string{"Waiting for OpenSearch data node vmi-system-data-2 to be removed"}, *progress)
+	assert.Equal(t, int32(2), getDataReplicas(t, c))
+}
+
+// TestNodePoolsComplete tests completing the node pool changes
+// GIVEN a VMI with the data nodes of the Verrazzano resource, a node pool change in progress, and a removed data node
+// excluded from shard allocation along with nodes excluded by others
+// WHEN the VMI is reconciled
+// THEN only the shard allocation exclusion of the removed data node is removed and the progress is cleared
+func TestNodePoolsComplete(t *testing.T) {
+	requests, progress := setupNodePoolExec(t, "green", "[]")
+	testAllocationExclude = "vmi-system-data-0-other,vmi-system-data-1-*,other-node-*"
+	vmi := newNodePoolVMI(1)
+	vmi.Annotations = map[string]string{allocationExcludeAnnotation: "vmi-system-data-1-*"}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(vmi).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(1, "Removing OpenSearch data node vmi-system-data-1"), nil, false)
+
+	assert.NoError(t, NewComponent().Install(ctx))
+	assert.Equal(t, []string{
+		"GET /_cluster/health ",
+		"GET /_cluster/settings?flat_settings=true ",
+		`PUT /_cluster/settings {"persistent":{"cluster.routing.allocation.exclude._name":"vmi-system-data-0-other,other-node-*"}}`,
+	}, *requests)
+	assert.Empty(t, getExcludedDataNodes(getVMI(t, c)))
+	assert.Equal(t, []string{""}, *progress)
+	assert.Empty(t, ctx.ActualCR().Status.Components[ComponentName].Progress)
+}
+
+// TestGrowBackWhileDraining tests reverting the removal of a data node while its shards are relocated
+// GIVEN a VMI with 3 data nodes whose last data node is excluded from shard allocation, and a Verrazzano resource
+// scaled back to 3 data nodes without any recorded progress
+// WHEN the VMI is reconciled
+// THEN the shard allocation exclusion of the data node is removed
+func TestGrowBackWhileDraining(t *testing.T) {
+	requests, _ := setupNodePoolExec(t, "green", "[]")
+	testAllocationExclude = "vmi-system-data-2-*"
+	vmi := newNodePoolVMI(3)
+	vmi.Annotations = map[string]string{allocationExcludeAnnotation: "vmi-system-data-2-*"}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(vmi).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(3, ""), nil, false)
+
+	assert.NoError(t, NewComponent().Install(ctx))
+	assert.Contains(t, *requests, `PUT /_cluster/settings {"persistent":{"cluster.routing.allocation.exclude._name":null}}`)
+	assert.Empty(t, getExcludedDataNodes(getVMI(t, c)))
+	assert.Equal(t, int32(3), getDataReplicas(t, c))
+}
+
+// TestRemoveOtherDataNode tests removing a data node of another node pool while a data node is excluded
+// GIVEN a VMI whose data node vmi-system-data-2 is excluded from shard allocation, and a Verrazzano resource that
+// keeps that node pool and shrinks another node pool
+// WHEN the VMI is reconciled
+// THEN the data node of the other node pool is excluded and the exclusion of vmi-system-data-2 is removed
+func TestRemoveOtherDataNode(t *testing.T) {
+	requests, _ := setupNodePoolExec(t, "green", `[{"index":"logs","shard":"0","prirep":"p","state":"STARTED","node":"vmi-system-hot-1-6d5f-x7k2"}]`)
+	testAllocationExclude = "vmi-system-data-2-*"
+	vmi := newNodePoolVMI(3)
+	vmi.Annotations = map[string]string{allocationExcludeAnnotation: "vmi-system-data-2-*"}
+	vmi.Spec.Opensearch.Nodes = append(vmi.Spec.Opensearch.Nodes, vmov1.ElasticsearchNode{Name: "hot", Replicas: 2, Roles: []vmov1.NodeRole{vmov1.DataRole}})
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(vmi).Build()
+	vz := newNodePoolVZ(3, "")
+	hotReplicas := int32(1)
+	vz.Spec.Components.Elasticsearch.Nodes = append(vz.Spec.Components.Elasticsearch.Nodes, vzapi.OpenSearchNode{Name: "hot", Replicas: &hotReplicas, Roles: []vmov1.NodeRole{vmov1.DataRole}})
+	ctx := spi.NewFakeContext(c, vz, nil, false)
+
+	err := NewComponent().Install(ctx)
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.Contains(t, *requests, `PUT /_cluster/settings {"persistent":{"cluster.routing.allocation.exclude._name":"vmi-system-data-2-*,vmi-system-hot-1-*"}}`)
+	assert.Equal(t, []string{"vmi-system-hot-1-*"}, getExcludedDataNodes(getVMI(t, c)))
+}
+
+// TestUpgradeRedCluster tests upgrading a red OpenSearch cluster
+// GIVEN a red OpenSearch cluster
+// WHEN the component is upgraded, or nodes are added to it
+// THEN the upgrade is refused until the cluster recovers, and the nodes are added
+func TestUpgradeRedCluster(t *testing.T) {
+	_, progress := setupNodePoolExec(t, "red", "[]")
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newNodePoolVMI(1)).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(2, ""), nil, false)
+
+	err := NewComponent().Upgrade(ctx.Operation(constants.UpgradeOperation))
+	assert.IsType(t, ctrlerrors.RetryableError{}, err)
+	assert.Equal(t, []string{"Waiting for the OpenSearch cluster health to recover from red"}, *progress)
+	assert.Equal(t, int32(1), getDataReplicas(t, c))
+
+	assert.NoError(t, NewComponent().Install(ctx.Operation(constants.InstallOperation)))
+	assert.Equal(t, int32(2), getDataReplicas(t, c))
+}
+
+// TestRecordUpgradeProgress tests recording the rolling upgrade progress of the data nodes
+// GIVEN a Verrazzano resource with 2 data nodes, one of them updated
+// WHEN the upgrade progress is recorded, then the component is ready
+// THEN the number of updated data nodes is recorded, then the progress is cleared
+func TestRecordUpgradeProgress(t *testing.T) {
+	_, progress := setupNodePoolExec(t, "green", "[]")
+	replicas := int32(1)
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-data-0"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-data-1"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+	).Build()
+	ctx := spi.NewFakeContext(c, newNodePoolVZ(2, ""), nil, false)
+
+	recordUpgradeProgress(ctx, false)
+	recordUpgradeProgress(ctx, false)
+	recordUpgradeProgress(ctx, true)
+	assert.Equal(t, []string{"Rolling upgrade of OpenSearch data nodes, 1 of 2 data nodes updated", ""}, *progress)
+}
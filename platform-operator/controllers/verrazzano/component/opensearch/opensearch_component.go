@@ -115,9 +115,9 @@ func (o opensearchComponent) PreInstall(ctx spi.ComponentContext) error {
 	return nil
 }
 
-// Install OpenSearch component install processing
+// Install OpenSearch component install processing, data nodes are removed one at a time
 func (o opensearchComponent) Install(ctx spi.ComponentContext) error {
-	return common.CreateOrUpdateVMI(ctx, updateFunc)
+	return reconcileVMI(ctx)
 }
 
 func (o opensearchComponent) IsOperatorUninstallSupported() bool {
@@ -145,9 +145,9 @@ func (o opensearchComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	return common.EnsureVMISecret(ctx.Client())
 }
 
-// Upgrade OpenSearch component upgrade processing
+// Upgrade OpenSearch component upgrade processing, refused while the OpenSearch cluster is red
 func (o opensearchComponent) Upgrade(ctx spi.ComponentContext) error {
-	return common.CreateOrUpdateVMI(ctx, updateFunc)
+	return reconcileVMI(ctx)
 }
 
 func (o opensearchComponent) IsAvailable(ctx spi.ComponentContext) (reason string, available vzapi.ComponentAvailability) {
@@ -156,7 +156,11 @@ func (o opensearchComponent) IsAvailable(ctx spi.ComponentContext) (reason strin
 
 // IsReady component check
 func (o opensearchComponent) IsReady(ctx spi.ComponentContext) bool {
-	return isOSReady(ctx)
+	ready := isOSReady(ctx)
+	if ctx.GetOperation() == constants.UpgradeOperation {
+		recordUpgradeProgress(ctx, ready)
+	}
+	return ready
 }
 
 // PostInstall OpenSearch post-install processing
@@ -165,12 +169,18 @@ func (o opensearchComponent) PostInstall(ctx spi.ComponentContext) error {
 	if err := common.CreateOrDeleteFluentbitFilterAndParser(ctx, fluentbitFilterAndParserTemplate, ComponentNamespace, false); err != nil {
 		return err
 	}
+	if err := reconcileSnapshots(ctx); err != nil {
+		return err
+	}
 	return common.CheckIngressesAndCerts(ctx, o)
 }
 
 // PostUpgrade OpenSearch post-upgrade processing
 func (o opensearchComponent) PostUpgrade(ctx spi.ComponentContext) error {
 	ctx.Log().Debugf("OpenSearch component post-upgrade")
+	if err := reconcileSnapshots(ctx); err != nil {
+		return err
+	}
 	if err := common.CheckIngressesAndCerts(ctx, o); err != nil {
 		return err
 	}
@@ -216,12 +226,18 @@ func (o opensearchComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzan
 		return err
 	}
 	// Reject edits that duplicate names of install args or node groups
-	return validateNoDuplicatedConfiguration(new)
+	if err := validateNoDuplicatedConfiguration(new); err != nil {
+		return err
+	}
+	return validateSnapshots(new)
 }
 
 // ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
 func (o opensearchComponent) ValidateInstallV1Beta1(vz *installv1beta1.Verrazzano) error {
-	return validateNoDuplicatedConfiguration(vz)
+	if err := validateNoDuplicatedConfiguration(vz); err != nil {
+		return err
+	}
+	return validateSnapshots(vz)
 }
 
 // Name returns the component name
@@ -0,0 +1,212 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	osapi "github.com/verrazzano/verrazzano/pkg/opensearch"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+)
+
+const (
+	// snapshotPolicyName is the name of the Snapshot Management policy creating and deleting the snapshots
+	snapshotPolicyName = "verrazzano-snapshot-policy"
+	snapshotTimezone   = "UTC"
+	cronFieldCount     = 5
+)
+
+// SnapshotStatusRecorder records the snapshot status in the status of the Verrazzano resource
+type SnapshotStatusRecorder func(vz *vzapi.Verrazzano, status *vzapi.OpenSearchSnapshotStatus)
+
+// snapshotStatusRecorder is set by the operator, the snapshot status is not recorded when it is nil
+var snapshotStatusRecorder SnapshotStatusRecorder
+
+// getCurrentTime returns the current time, it is overridden by unit tests
+var getCurrentTime = time.Now
+
+// SetSnapshotStatusRecorder sets the function recording the snapshot status
+func SetSnapshotStatusRecorder(recorder SnapshotStatusRecorder) {
+	snapshotStatusRecorder = recorder
+}
+
+// reconcileSnapshots registers the snapshot repository and creates the snapshot policy of the Verrazzano resource,
+// then records the snapshot status. The policy is deleted when the snapshots are removed from the Verrazzano
+// resource, the snapshots and the repository are kept.
+func reconcileSnapshots(ctx spi.ComponentContext) error {
+	var snapshots *vzapi.OpenSearchSnapshots
+	if ctx.EffectiveCR().Spec.Components.Elasticsearch != nil {
+		snapshots = ctx.EffectiveCR().Spec.Components.Elasticsearch.Snapshots
+	}
+	if snapshots == nil && ctx.ActualCR().Status.OpenSearchSnapshots == nil {
+		return nil
+	}
+
+	client, err := newOpenSearchClient()
+	if err != nil {
+		return err
+	}
+	if snapshots == nil {
+		ctx.Log().Infof("Deleting OpenSearch snapshot policy %s", snapshotPolicyName)
+		if err := client.DeletePolicy(snapshotPolicyName); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting OpenSearch snapshot policy %s: %v", snapshotPolicyName, err)
+		}
+		// An empty status removes the snapshot status from the Verrazzano resource
+		recordSnapshotStatus(ctx, &vzapi.OpenSearchSnapshotStatus{})
+		return nil
+	}
+
+	repoName := getSnapshotRepositoryName(snapshots)
+	status := &vzapi.OpenSearchSnapshotStatus{
+		Repository: repoName,
+		UpdateTime: getCurrentTime().UTC().Format(time.RFC3339),
+	}
+	if err := configureSnapshots(ctx, client, snapshots, repoName); err != nil {
+		status.Message = err.Error()
+		recordSnapshotStatus(ctx, status)
+		return err
+	}
+	list, err := client.GetSnapshots(repoName)
+	if err != nil {
+		status.Message = err.Error()
+		recordSnapshotStatus(ctx, status)
+		return ctx.Log().ErrorfNewErr("Failed getting the snapshots of OpenSearch repository %s: %v", repoName, err)
+	}
+	status.SnapshotCount = len(list)
+	if len(list) > 0 {
+		last := list[len(list)-1]
+		status.LastSnapshot = last.Snapshot
+		status.LastSnapshotState = last.State
+		status.LastSnapshotTime = last.StartTime
+	}
+	recordSnapshotStatus(ctx, status)
+	return nil
+}
+
+// configureSnapshots registers the snapshot repository, and creates or deletes the snapshot policy depending on
+// whether a schedule is specified
+func configureSnapshots(ctx spi.ComponentContext, client *osapi.Client, snapshots *vzapi.OpenSearchSnapshots, repoName string) error {
+	ctx.Log().Debugf("Registering OpenSearch snapshot repository %s", repoName)
+	if err := client.PutRepository(repoName, newRepository(snapshots.Repository)); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed registering OpenSearch snapshot repository %s: %v", repoName, err)
+	}
+	if snapshots.Schedule == "" {
+		if err := client.DeletePolicy(snapshotPolicyName); err != nil {
+			return ctx.Log().ErrorfNewErr("Failed deleting OpenSearch snapshot policy %s: %v", snapshotPolicyName, err)
+		}
+		return nil
+	}
+	ctx.Log().Debugf("Updating OpenSearch snapshot policy %s", snapshotPolicyName)
+	if err := client.PutPolicy(snapshotPolicyName, newSnapshotPolicy(snapshots, repoName)); err != nil {
+		return ctx.Log().ErrorfNewErr("Failed updating OpenSearch snapshot policy %s: %v", snapshotPolicyName, err)
+	}
+	return nil
+}
+
+// newOpenSearchClient returns a client calling the OpenSearch REST API
+func newOpenSearchClient() (*osapi.Client, error) {
+	cfg, cli, err := k8sutil.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return osapi.NewClient(cli, cfg), nil
+}
+
+// recordSnapshotStatus records the snapshot status if a recorder is set
+func recordSnapshotStatus(ctx spi.ComponentContext, status *vzapi.OpenSearchSnapshotStatus) {
+	if snapshotStatusRecorder != nil {
+		snapshotStatusRecorder(ctx.ActualCR(), status)
+	}
+}
+
+// getSnapshotRepositoryName returns the name of the snapshot repository
+func getSnapshotRepositoryName(snapshots *vzapi.OpenSearchSnapshots) string {
+	if snapshots.Repository.Name == "" {
+		return osapi.DefaultSnapshotRepository
+	}
+	return snapshots.Repository.Name
+}
+
+// newRepository returns the OpenSearch settings of a snapshot repository
+func newRepository(repo vzapi.OpenSearchSnapshotRepository) osapi.Repository {
+	settings := map[string]string{}
+	if repo.Type == vzapi.SnapshotRepositoryFS {
+		settings["location"] = repo.Location
+		return osapi.Repository{Type: string(repo.Type), Settings: settings}
+	}
+	settings["bucket"] = repo.Bucket
+	if repo.BasePath != "" {
+		settings["base_path"] = repo.BasePath
+	}
+	if repo.Endpoint != "" {
+		settings["endpoint"] = repo.Endpoint
+	}
+	if repo.Region != "" {
+		settings["region"] = repo.Region
+	}
+	if repo.PathStyleAccess {
+		settings["path_style_access"] = strconv.FormatBool(repo.PathStyleAccess)
+	}
+	return osapi.Repository{Type: string(repo.Type), Settings: settings}
+}
+
+// newSnapshotPolicy returns the Snapshot Management policy creating snapshots on the schedule, and deleting the
+// oldest snapshots beyond the retention count on the same schedule
+func newSnapshotPolicy(snapshots *vzapi.OpenSearchSnapshots, repoName string) osapi.SnapshotPolicy {
+	schedule := osapi.PolicySchedule{
+		Cron: osapi.PolicyCron{
+			Expression: snapshots.Schedule,
+			Timezone:   snapshotTimezone,
+		},
+	}
+	policy := osapi.SnapshotPolicy{
+		Description: "Snapshots of the Verrazzano OpenSearch cluster",
+		Creation:    osapi.PolicyCreation{Schedule: schedule},
+		SnapshotConfig: map[string]interface{}{
+			"repository":           repoName,
+			"indices":              "*",
+			"include_global_state": false,
+		},
+	}
+	if snapshots.RetentionCount != nil {
+		policy.Deletion = &osapi.PolicyDeletion{
+			Schedule:  schedule,
+			Condition: osapi.PolicyDeletionCondition{MaxCount: *snapshots.RetentionCount},
+		}
+	}
+	return policy
+}
+
+// validateSnapshots rejects snapshot repositories missing their location and invalid schedules
+func validateSnapshots(vz *v1beta1.Verrazzano) error {
+	if vz.Spec.Components.OpenSearch == nil || vz.Spec.Components.OpenSearch.Snapshots == nil {
+		return nil
+	}
+	snapshots := vz.Spec.Components.OpenSearch.Snapshots
+	switch snapshots.Repository.Type {
+	case v1beta1.SnapshotRepositoryFS:
+		if snapshots.Repository.Location == "" {
+			return fmt.Errorf("OpenSearch snapshot repository of type %s requires a location", snapshots.Repository.Type)
+		}
+	case v1beta1.SnapshotRepositoryS3:
+		if snapshots.Repository.Bucket == "" {
+			return fmt.Errorf("OpenSearch snapshot repository of type %s requires a bucket", snapshots.Repository.Type)
+		}
+	default:
+		return fmt.Errorf("OpenSearch snapshot repository type %s is not supported", snapshots.Repository.Type)
+	}
+	if snapshots.Schedule != "" && len(strings.Fields(snapshots.Schedule)) != cronFieldCount {
+		return fmt.Errorf("OpenSearch snapshot schedule %s is not a valid cron expression", snapshots.Schedule)
+	}
+	if snapshots.RetentionCount != nil && *snapshots.RetentionCount < 1 {
+		return fmt.Errorf("OpenSearch snapshot retention count must be at least 1")
+	}
+	return nil
+}
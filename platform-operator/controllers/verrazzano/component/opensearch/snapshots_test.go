@@ -0,0 +1,200 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package opensearch
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	snapshotRequestPattern = regexp.MustCompile(`-X (\w+) 'http://localhost:9200([^']*)'(?: -H 'Content-Type: application/json' -d '(.*)')?`)
+	snapshotTestTime       = time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+)
+
+// setupSnapshotExec fakes the OpenSearch snapshot API in a running master pod, returning the requests made. The
+// snapshot policy does not exist and the repository contains two snapshots.
+func setupSnapshotExec(t *testing.T, repoStatus int) *[]string {
+	requests := &[]string{}
+	prevClientConfig := k8sutil.ClientConfig
+	k8sutil.ClientConfig = func() (*rest.Config, kubernetes.Interface, error) {
+		cfg, cli := k8sutilfake.NewClientsetConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "vmi-system-es-master-0", Labels: map[string]string{"app": "system-es-master"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+		return cfg, cli, nil
+	}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		match := snapshotRequestPattern.FindStringSubmatch(url.Query()["command"][2])
+		if match == nil {
+			return "", "", fmt.Errorf("unexpected command %v", url.Query()["command"])
+		}
+		*requests = append(*requests, fmt.Sprintf("%s %s %s", match[1], match[2], match[3]))
+		switch {
+		case match[1] == "PUT" && match[2] == "/_snapshot/backups":
+			return fmt.Sprintf("{}\n%d", repoStatus), "", nil
+		case match[1] == "GET" && match[2] == "/_snapshot/backups/_all":
+			return "{\"snapshots\":[{\"snapshot\":\"first\",\"state\":\"SUCCESS\",\"start_time\":\"2023-05-31T02:00:00.000Z\"},{\"snapshot\":\"second\",\"state\":\"PARTIAL\",\"start_time\":\"2023-06-01T02:00:00.000Z\"}]}\n200", "", nil
+		case match[1] == "GET":
+			return "{}\n404", "", nil
+		case match[1] == "POST":
+			return "{}\n201", "", nil
+		}
+		return "{}\n200", "", nil
+	}
+	getCurrentTime = func() time.Time { return snapshotTestTime }
+	t.Cleanup(func() {
+		k8sutil.ClientConfig = prevClientConfig
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
+		getCurrentTime = time.Now
+		snapshotStatusRecorder = nil
+	})
+	return requests
+}
+
+// recordStatuses records the snapshot statuses in a slice
+func recordStatuses() *[]vzapi.OpenSearchSnapshotStatus {
+	statuses := &[]vzapi.OpenSearchSnapshotStatus{}
+	SetSnapshotStatusRecorder(func(vz *vzapi.Verrazzano, status *vzapi.OpenSearchSnapshotStatus) {
+		*statuses = append(*statuses, *status)
+	})
+	return statuses
+}
+
+// newSnapshotsVZ returns a Verrazzano resource with the given OpenSearch snapshots
+func newSnapshotsVZ(snapshots *vzapi.OpenSearchSnapshots) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Elasticsearch: &vzapi.ElasticsearchComponent{Snapshots: snapshots},
+			},
+		},
+	}
+}
+
+// TestReconcileSnapshots tests configuring the OpenSearch snapshots
+// GIVEN a Verrazzano resource with an S3 snapshot repository, a schedule and a retention count
+// WHEN the snapshots are reconciled
+// THEN the repository is registered, the snapshot policy is created and the snapshot status is recorded
+func TestReconcileSnapshots(t *testing.T) {
+	requests := setupSnapshotExec(t, 200)
+	statuses := recordStatuses()
+	retention := int32(7)
+	vz := newSnapshotsVZ(&vzapi.OpenSearchSnapshots{
+		Repository: vzapi.OpenSearchSnapshotRepository{
+			Name:            "backups",
+			Type:            vzapi.SnapshotRepositoryS3,
+			Bucket:          "opensearch",
+			Endpoint:        "http://minio.minio:9000",
+			PathStyleAccess: true,
+		},
+		Schedule:       "0 2 * * *",
+		RetentionCount: &retention,
+	})
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+
+	assert.NoError(t, reconcileSnapshots(ctx))
+	assert.Equal(t, []string{
+		`PUT /_snapshot/backups {"type":"s3","settings":{"bucket":"opensearch","endpoint":"http://minio.minio:9000","path_style_access":"true"}}`,
+		`GET /_plugins/_sm/policies/verrazzano-snapshot-policy `,
+		`POST /_plugins/_sm/policies/verrazzano-snapshot-policy {"description":"Snapshots of the Verrazzano OpenSearch cluster","creation":{"schedule":{"cron":{"expression":"0 2 * * *","timezone":"UTC"}}},"deletion":{"schedule":{"cron":{"expression":"0 2 * * *","timezone":"UTC"}},"condition":{"max_count":7}},"snapshot_config":{"include_global_state":false,"indices":"*","repository":"backups"}}`,
+		`GET /_snapshot/backups/_all `,
+	}, *requests)
+	assert.Equal(t, []vzapi.OpenSearchSnapshotStatus{{
+		LastSnapshot:      "second",
+		LastSnapshotState: "PARTIAL",
+		LastSnapshotTime:  "2023-06-01T02:00:00.000Z",
+		Repository:        "backups",
+		SnapshotCount:     2,
+		UpdateTime:        "2023-06-01T12:00:00Z",
+	}}, *statuses)
+}
+
+// TestReconcileSnapshotsFailure tests a repository that cannot be registered
+// GIVEN a Verrazzano resource with a file system repository that OpenSearch rejects
+// WHEN the snapshots are reconciled
+// THEN an error is returned and recorded in the snapshot status
+func TestReconcileSnapshotsFailure(t *testing.T) {
+	setupSnapshotExec(t, 500)
+	statuses := recordStatuses()
+	vz := newSnapshotsVZ(&vzapi.OpenSearchSnapshots{
+		Repository: vzapi.OpenSearchSnapshotRepository{Name: "backups", Type: vzapi.SnapshotRepositoryFS, Location: "/snapshots"},
+	})
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+
+	assert.Error(t, reconcileSnapshots(ctx))
+	assert.Len(t, *statuses, 1)
+	assert.Equal(t, "backups", (*statuses)[0].Repository)
+	assert.Contains(t, (*statuses)[0].Message, "Failed registering OpenSearch snapshot repository backups")
+}
+
+// TestReconcileRemovedSnapshots tests removing the snapshots from the Verrazzano resource
+// GIVEN a Verrazzano resource without snapshots, with a snapshot status
+// WHEN the snapshots are reconciled
+// THEN the snapshot policy is deleted and the snapshot status is cleared
+// AND nothing is done for a Verrazzano resource that never had snapshots
+func TestReconcileRemovedSnapshots(t *testing.T) {
+	requests := setupSnapshotExec(t, 200)
+	statuses := recordStatuses()
+	vz := newSnapshotsVZ(nil)
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+	assert.NoError(t, reconcileSnapshots(ctx))
+	assert.Empty(t, *requests)
+	assert.Empty(t, *statuses)
+
+	vz.Status.OpenSearchSnapshots = &vzapi.OpenSearchSnapshotStatus{Repository: "backups"}
+	assert.NoError(t, reconcileSnapshots(ctx))
+	assert.Equal(t, []string{`DELETE /_plugins/_sm/policies/verrazzano-snapshot-policy `}, *requests)
+	assert.Equal(t, []vzapi.OpenSearchSnapshotStatus{{}}, *statuses)
+}
+
+// TestValidateSnapshots tests the validation of the OpenSearch snapshots
+func TestValidateSnapshots(t *testing.T) {
+	zero := int32(0)
+	tests := []struct {
+		name      string
+		snapshots *v1beta1.OpenSearchSnapshots
+		hasError  bool
+	}{
+		{"no snapshots", nil, false},
+		{"fs repository", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryFS, Location: "/snapshots"}, Schedule: "0 2 * * *"}, false},
+		{"fs repository without location", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryFS}}, true},
+		{"s3 repository", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryS3, Bucket: "opensearch"}}, false},
+		{"s3 repository without bucket", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryS3}}, true},
+		{"unknown repository type", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: "hdfs"}}, true},
+		{"invalid schedule", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryFS, Location: "/snapshots"}, Schedule: "daily"}, true},
+		{"invalid retention", &v1beta1.OpenSearchSnapshots{Repository: v1beta1.OpenSearchSnapshotRepository{Type: v1beta1.SnapshotRepositoryFS, Location: "/snapshots"}, RetentionCount: &zero}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{
+				OpenSearch: &v1beta1.OpenSearchComponent{Snapshots: tt.snapshots},
+			}}}
+			err := NewComponent().ValidateInstallV1Beta1(vz)
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
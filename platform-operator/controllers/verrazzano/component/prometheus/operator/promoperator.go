@@ -340,6 +340,8 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 		if err != nil {
 			return kvs, ctx.Log().ErrorfNewErr("Failed applying additional volume overrides for Prometheus")
 		}
+
+		kvs = appendThanosObjectStoreOverrides(ctx, kvs)
 	} else {
 		kvs = append(kvs, bom.KeyValue{
 			Key:   "prometheus.enabled",
@@ -459,6 +461,23 @@ func appendThanosImageOverrides(ctx spi.ComponentContext, kvs []bom.KeyValue) ([
 	return kvs, nil
 }
 
+// appendThanosObjectStoreOverrides appends overrides for the Thanos sidecar to upload the Prometheus metrics blocks to the
+// Thanos object store
+func appendThanosObjectStoreOverrides(ctx spi.ComponentContext, kvs []bom.KeyValue) []bom.KeyValue {
+	thanos := ctx.EffectiveCR().Spec.Components.Thanos
+	if !vzcr.IsThanosEnabled(ctx.EffectiveCR()) || thanos.ObjectStore == nil {
+		return kvs
+	}
+	key := thanos.ObjectStore.SecretKey
+	if key == "" {
+		key = constants.ThanosObjstoreSecretKey
+	}
+	return append(kvs, []bom.KeyValue{
+		{Key: "prometheus.prometheusSpec.thanos.objectStorageConfig.name", Value: thanos.ObjectStore.SecretName},
+		{Key: "prometheus.prometheusSpec.thanos.objectStorageConfig.key", Value: key},
+	}...)
+}
+
 // validatePrometheusOperator checks scenarios in which the Verrazzano CR violates install verification due to Prometheus Operator specifications
 func (c prometheusComponent) validatePrometheusOperator(vz *installv1beta1.Verrazzano) error {
 	// Validate if Prometheus is enabled, Prometheus Operator should be enabled
@@ -1388,3 +1388,27 @@ func TestAppendResourceRequestOverrides(t *testing.T) {
 		})
 	}
 }
+
+// TestAppendThanosObjectStoreOverrides tests the Thanos sidecar object store overrides
+// GIVEN a Verrazzano CR with Thanos enabled and an object store, or Thanos disabled
+// WHEN the Thanos object store overrides are appended
+// THEN the Thanos sidecar is configured with the object store secret only when Thanos is enabled
+func TestAppendThanosObjectStoreOverrides(t *testing.T) {
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				Thanos: &vzapi.ThanosComponent{
+					Enabled:     &trueValue,
+					ObjectStore: &vzapi.ThanosObjectStore{SecretName: "thanos-objstore"},
+				},
+			},
+		},
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(testScheme).Build(), vz, nil, false)
+	kvs := appendThanosObjectStoreOverrides(ctx, nil)
+	assert.Equal(t, "thanos-objstore", bom.FindKV(kvs, "prometheus.prometheusSpec.thanos.objectStorageConfig.name"))
+	assert.Equal(t, "objstore.yml", bom.FindKV(kvs, "prometheus.prometheusSpec.thanos.objectStorageConfig.key"))
+
+	vz.Spec.Components.Thanos.Enabled = &falseValue
+	assert.Empty(t, appendThanosObjectStoreOverrides(ctx, nil))
+}
@@ -216,7 +216,7 @@ func appendRegistryOverrides(kvs []bom.KeyValue) []bom.KeyValue {
 	return kvs
 }
 
-// appendCAOverrides sets overrides for CA Issuers, LetsEncrypt or CA.
+// appendCAOverrides sets overrides for CA Issuers, LetsEncrypt, CA, ACME or Vault.
 func appendCAOverrides(log vzlog.VerrazzanoLogger, kvs []bom.KeyValue, ctx spi.ComponentContext) ([]bom.KeyValue, error) {
 	cm := ctx.EffectiveCR().Spec.Components.ClusterIssuer
 	if cm == nil {
@@ -242,15 +242,18 @@ func appendCAOverrides(log vzlog.VerrazzanoLogger, kvs []bom.KeyValue, ctx spi.C
 				Key:   additionalTrustedCAsKey,
 				Value: strconv.FormatBool(useAdditionalCAs(*cm.LetsEncrypt)),
 			})
-	} else { // Certificate issuer type is CA
+	} else { // Certificate issuer type is CA, ACME or Vault
 		kvs = append(kvs, bom.KeyValue{
 			Key:   ingressTLSSourceKey,
 			Value: caTLSSource,
 		})
-		kvs = append(kvs, bom.KeyValue{
-			Key:   privateCAKey,
-			Value: privateCAValue,
-		})
+		// Rancher trusts the private CA of the CA issuer, of an ACME issuer with a private CA and of a Vault issuer
+		if _, _, ok := getPrivateCASecret(cm); ok {
+			kvs = append(kvs, bom.KeyValue{
+				Key:   privateCAKey,
+				Value: privateCAValue,
+			})
+		}
 	}
 
 	return kvs, nil
@@ -400,7 +403,7 @@ func (r rancherComponent) ValidateUpdateV1Beta1(old *installv1beta1.Verrazzano,
 /* Sets up the environment for Rancher
 - Create the Rancher namespace if it is not present (cattle-namespace)
 - note: VZ-5241 the rancher-operator-namespace is no longer used in 2.6.3
-- Copy TLS certificates for Rancher if using the default Verrazzano CA
+- Copy the CA certificate for Rancher if using a private CA
 - Create additional LetsEncrypt TLS certificates for Rancher if using LE
 */
 func (r rancherComponent) PreInstall(ctx spi.ComponentContext) error {
@@ -411,8 +414,8 @@ func (r rancherComponent) PreInstall(ctx spi.ComponentContext) error {
 		log.ErrorfThrottledNewErr("Failed creating cattle-system namespace: %s", err.Error())
 		return err
 	}
-	if err := copyDefaultCACertificate(log, c, vz); err != nil {
-		log.ErrorfThrottledNewErr("Failed copying default CA certificate: %s", err.Error())
+	if err := copyPrivateCACertificate(log, c, vz); err != nil {
+		log.ErrorfThrottledNewErr("Failed copying private CA certificate: %s", err.Error())
 		return err
 	}
 	return r.HelmComponent.PreInstall(ctx)
@@ -355,6 +355,65 @@ func TestAppendIssuerCustomCAOverrides(t *testing.T) {
 	assert.Equal(t, privateCAValue, v)
 }
 
+// TestAppendPrivateIssuerCAOverrides verifies that CA overrides are added for ACME issuers with a private CA and
+// for Vault issuers
+// GIVEN a Verrzzano CR with an ACME or a Vault ClusterIssuer
+//
+//	WHEN AppendOverrides is called
+//	THEN AppendOverrides should add private CA overrides, unless the ACME issuer has no CA bundle
+func TestAppendPrivateIssuerCAOverrides(t *testing.T) {
+	tests := []struct {
+		name         string
+		issuerConfig vzapi.IssuerConfig
+		privateCA    bool
+	}{
+		{
+			name:         "ACME issuer with a private CA",
+			issuerConfig: vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: "https://step-ca:9000/acme/acme/directory", CABundleSecret: "step-ca-root"}},
+			privateCA:    true,
+		},
+		{
+			name:         "ACME issuer with a public CA",
+			issuerConfig: vzapi.IssuerConfig{ACME: &vzapi.ACMEIssuer{Server: "https://acme.example.com/directory"}},
+			privateCA:    false,
+		},
+		{
+			name:         "Vault issuer",
+			issuerConfig: vzapi.IssuerConfig{Vault: &vzapi.VaultIssuer{Server: "https://vault:8200", Path: "pki/sign/verrazzano", CABundleSecret: "vault-ca"}},
+			privateCA:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &vzapi.Verrazzano{
+				Spec: vzapi.VerrazzanoSpec{
+					Components: vzapi.ComponentSpec{
+						DNS: &vzapi.DNSComponent{
+							External: &vzapi.External{Suffix: common.RancherName},
+						},
+						ClusterIssuer: &vzapi.ClusterIssuerComponent{
+							ClusterResourceNamespace: "cert-manager",
+							IssuerConfig:             tt.issuerConfig,
+						},
+					},
+				},
+			}
+
+			config.SetDefaultBomFilePath(testBomFilePath)
+			defer func() { config.SetDefaultBomFilePath("") }()
+			ctx := spi.NewFakeContext(fake.NewClientBuilder().WithScheme(getScheme()).Build(), vz, nil, false)
+
+			kvs, err := AppendOverrides(ctx, "", "", "", []bom.KeyValue{})
+			assert.Nil(t, err)
+			v, ok := getValue(kvs, ingressTLSSourceKey)
+			assert.True(t, ok)
+			assert.Equal(t, caTLSSource, v)
+			_, ok = getValue(kvs, privateCAKey)
+			assert.Equal(t, tt.privateCA, ok)
+		})
+	}
+}
+
 // TestIsReady verifies Rancher is enabled or disabled as expected
 // GIVEN a Verrzzano CR
 //
@@ -47,11 +47,11 @@ func patchRancherIngress(c client.Client, vz *vzapi.Verrazzano) error {
 	ingress.Annotations["cert-manager.io/cluster-issuer"] = constants.VerrazzanoClusterIssuerName
 	ingress.Annotations["cert-manager.io/common-name"] = fmt.Sprintf("%s.%s.%s", common.RancherName, vz.Spec.EnvironmentName, dnsSuffix)
 
-	isLEIssuer, err := clusterIssuer.IsLetsEncryptIssuer()
+	isACMEIssuer, err := clusterIssuer.IsACMEIssuer()
 	if err != nil {
 		return err
 	}
-	if isLEIssuer {
+	if isACMEIssuer {
 		addAcmeIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
 	} else {
 		addCAIngressAnnotations(vz.Spec.EnvironmentName, dnsSuffix, ingress)
@@ -60,7 +60,7 @@ func patchRancherIngress(c client.Client, vz *vzapi.Verrazzano) error {
 	return c.Patch(context.TODO(), ingress, ingressMerge)
 }
 
-// addAcmeIngressAnnotations annotate ingress with LetsEncrypt specific values
+// addAcmeIngressAnnotations annotate ingress with ACME specific values
 func addAcmeIngressAnnotations(name, dnsSuffix string, ingress *networking.Ingress) {
 	ingress.Annotations["nginx.ingress.kubernetes.io/auth-realm"] = fmt.Sprintf("%s auth", dnsSuffix)
 	ingress.Annotations["external-dns.alpha.kubernetes.io/target"] = fmt.Sprintf("verrazzano-ingress.%s.%s", name, dnsSuffix)
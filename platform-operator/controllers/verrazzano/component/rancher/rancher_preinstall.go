@@ -39,57 +39,66 @@ func createCattleSystemNamespace(log vzlog.VerrazzanoLogger, c client.Client) er
 	return nil
 }
 
-// copyDefaultCACertificate copies the defaultVerrazzanoName TLS Secret to the ComponentNamespace for use by Rancher
-// This method will only copy defaultVerrazzanoName if default CA certificates are being used.
-func copyDefaultCACertificate(log vzlog.VerrazzanoLogger, c client.Client, vz *vzapi.Verrazzano) error {
+// copyPrivateCACertificate copies the CA certificate of a private ClusterIssuer to the ComponentNamespace for use by
+// Rancher.  This is the CA of the CA issuer, or the CA bundle of an ACME issuer with a private CA or of a Vault issuer.
+func copyPrivateCACertificate(log vzlog.VerrazzanoLogger, c client.Client, vz *vzapi.Verrazzano) error {
 	clusterIssuer := vz.Spec.Components.ClusterIssuer
 	if clusterIssuer == nil {
 		// Not necessarily an error, since CM and the ClusterIssuer could be disabled
 		log.Progressf("No cluster issuer found, skipping CA certificate bundle configuration")
 		return nil
 	}
-	isCAIssuer, err := clusterIssuer.IsCAIssuer()
-	if err != nil {
+	if clusterIssuer.Vault != nil && len(clusterIssuer.Vault.CABundleSecret) == 0 {
+		log.Oncef("The Vault issuer has no CA bundle secret, Rancher does not trust the CA of the issued certificates")
+	}
+	caSecretName, certKey, ok := getPrivateCASecret(clusterIssuer)
+	if !ok {
+		return nil
+	}
+	caSecretNamespace := clusterIssuer.ClusterResourceNamespace
+	caSecret := &v1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: caSecretNamespace, Name: caSecretName}, caSecret); err != nil {
 		return err
 	}
-	if isCAIssuer {
-		caSecretNamespace := clusterIssuer.ClusterResourceNamespace
-		caSecretName := clusterIssuer.CA.SecretName
-		namespacedName := types.NamespacedName{
-			Namespace: caSecretNamespace,
-			Name:      caSecretName,
+	if len(caSecret.Data[certKey]) < 1 {
+		return log.ErrorfNewErr("Failed, secret %s/%s does not have a value for %s",
+			caSecretNamespace,
+			caSecretName, certKey)
+	}
+	rancherCaSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: common.CattleSystem,
+			Name:      rancherTLSSecretName,
+		},
+	}
+	log.Debugf("Copying the private CA secret to Rancher namespace")
+	if _, err := controllerruntime.CreateOrUpdate(context.TODO(), c, rancherCaSecret, func() error {
+		rancherCaSecret.Data = map[string][]byte{
+			caCertsPem: caSecret.Data[certKey],
 		}
-		certKey := caCert
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getPrivateCASecret returns the name of the secret in the cluster resource namespace with the CA certificate of a
+// private ClusterIssuer, and the data key of the CA certificate.  False is returned for public ACME issuers, whose
+// certificates are trusted without a private CA, and for a Vault issuer without a CA bundle secret.
+func getPrivateCASecret(clusterIssuer *vzapi.ClusterIssuerComponent) (string, string, bool) {
+	switch {
+	case clusterIssuer.CA != nil:
 		if isDefault, _ := clusterIssuer.IsDefaultIssuer(); !isDefault {
-			certKey = customCACertKey
-		}
-		caSecret := &v1.Secret{}
-		if err := c.Get(context.TODO(), namespacedName, caSecret); err != nil {
-			return err
-		}
-		if len(caSecret.Data[certKey]) < 1 {
-			return log.ErrorfNewErr("Failed, secret %s/%s does not have a value for %s",
-				caSecretNamespace,
-				caSecretName, certKey)
-		}
-		rancherCaSecret := &v1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: common.CattleSystem,
-				Name:      rancherTLSSecretName,
-			},
-		}
-		log.Debugf("Copying default Verrazzano secret to Rancher namespace")
-		if _, err := controllerruntime.CreateOrUpdate(context.TODO(), c, rancherCaSecret, func() error {
-			rancherCaSecret.Data = map[string][]byte{
-				caCertsPem: caSecret.Data[certKey],
-			}
-			return nil
-		}); err != nil {
-			return err
+			return clusterIssuer.CA.SecretName, customCACertKey, true
 		}
+		return clusterIssuer.CA.SecretName, caCert, true
+	case clusterIssuer.ACME != nil && len(clusterIssuer.ACME.CABundleSecret) > 0:
+		return clusterIssuer.ACME.CABundleSecret, caCert, true
+	case clusterIssuer.Vault != nil && len(clusterIssuer.Vault.CABundleSecret) > 0:
+		return clusterIssuer.Vault.CABundleSecret, caCert, true
 	}
-
-	return nil
+	return "", "", false
 }
 
 func isUsingDefaultCACertificate(cm *vzapi.ClusterIssuerComponent) bool {
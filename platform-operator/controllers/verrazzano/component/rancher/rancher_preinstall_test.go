@@ -4,6 +4,7 @@
 package rancher
 
 import (
+	"context"
 	"errors"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"io"
@@ -53,12 +54,12 @@ func TestCreateCattleNamespace(t *testing.T) {
 	}
 }
 
-// TestIsUsingDefaultCACertificate verifies whether the CerManager component specifies to use the default CA certificate or not
+// TestCopyPrivateCACertificate verifies copying the CA certificate of a private ClusterIssuer
 // GIVEN a CertManager component
 //
-//	WHEN isUsingDefaultCACertificate is called
-//	THEN isUsingDefaultCACertificate should return true or false if the default CA certificate is required
-func TestCopyDefaultCACertificate(t *testing.T) {
+//	WHEN copyPrivateCACertificate is called
+//	THEN copyPrivateCACertificate should copy the CA secret only when a private CA is used
+func TestCopyPrivateCACertificate(t *testing.T) {
 	log := getTestLogger(t)
 	secret := createCASecret()
 	var tests = []struct {
@@ -90,7 +91,7 @@ func TestCopyDefaultCACertificate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			ctx := spi.NewFakeContext(tt.c, tt.vz, nil, false, profilesRelativePath)
-			err := copyDefaultCACertificate(log, tt.c, ctx.EffectiveCR())
+			err := copyPrivateCACertificate(log, tt.c, ctx.EffectiveCR())
 			if tt.isErr {
 				assert.NotNil(t, err)
 			} else {
@@ -100,6 +101,36 @@ func TestCopyDefaultCACertificate(t *testing.T) {
 	}
 }
 
+// TestCopyACMEPrivateCACertificate verifies copying the CA bundle of an ACME issuer with a private CA
+// GIVEN an ACME ClusterIssuer with a CA bundle secret
+//
+//	WHEN copyPrivateCACertificate is called
+//	THEN the CA bundle is copied to the Rancher TLS CA secret
+func TestCopyACMEPrivateCACertificate(t *testing.T) {
+	caBundle := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "step-ca-root"},
+		Data:       map[string][]byte{caCert: []byte("step-ca")},
+	}
+	vz := &vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				ClusterIssuer: &vzapi.ClusterIssuerComponent{
+					ClusterResourceNamespace: "cert-manager",
+					IssuerConfig: vzapi.IssuerConfig{
+						ACME: &vzapi.ACMEIssuer{Server: "https://step-ca:9000/acme/acme/directory", CABundleSecret: "step-ca-root"},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(getScheme()).WithObjects(caBundle).Build()
+	assert.NoError(t, copyPrivateCACertificate(getTestLogger(t), c, vz))
+
+	rancherCaSecret := &v1.Secret{}
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: common.CattleSystem, Name: rancherTLSSecretName}, rancherCaSecret))
+	assert.Equal(t, []byte("step-ca"), rancherCaSecret.Data[caCertsPem])
+}
+
 // TestIsUsingDefaultCACertificate verifies whether the CerManager component specifies to use the default CA certificate or not
 // GIVEN a CertManager component
 //
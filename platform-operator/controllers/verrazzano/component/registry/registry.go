@@ -4,6 +4,9 @@
 package registry
 
 import (
+	"fmt"
+	"sync"
+
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/appoper"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/argocd"
@@ -56,6 +59,14 @@ var componentsRegistry []spi.Component
 
 var getComponentsMap map[string]spi.Component
 
+// externalComponents are the components registered from outside of the registry, they are processed after the
+// built-in components in the order they were registered
+var externalComponents []spi.Component
+
+var externalComponentsMutex sync.RWMutex
+
+var getComponentsMapMutex sync.Mutex
+
 // OverrideGetComponentsFn Allows overriding the set of registry components for testing purposes
 func OverrideGetComponentsFn(fnType GetCompoentsFnType) {
 	getComponentsFn = fnType
@@ -125,10 +136,109 @@ func GetComponents() []spi.Component {
 
 // getComponents is the internal impl function for GetComponents, to allow overriding it for testing purposes
 func getComponents() []spi.Component {
-	return componentsRegistry
+	externalComponentsMutex.RLock()
+	defer externalComponentsMutex.RUnlock()
+	if len(externalComponents) == 0 {
+		return componentsRegistry
+	}
+	components := make([]spi.Component, 0, len(componentsRegistry)+len(externalComponents))
+	components = append(components, componentsRegistry...)
+	return append(components, externalComponents...)
+}
+
+// RegisterComponent adds a component that is defined outside of the platform operator to the registry, so that it is
+// installed, upgraded and uninstalled like the built-in components.  A registered component with the same name is
+// replaced.  An error is returned if the name is the name of a built-in component.
+func RegisterComponent(comp spi.Component) error {
+	if len(componentsRegistry) == 0 {
+		InitRegistry()
+	}
+	for _, builtIn := range componentsRegistry {
+		if builtIn.Name() == comp.Name() {
+			return fmt.Errorf("Component %s is a built-in component and cannot be registered", comp.Name())
+		}
+	}
+
+	externalComponentsMutex.Lock()
+	// Copy the slice so that callers iterating over the components are not affected
+	components := make([]spi.Component, 0, len(externalComponents)+1)
+	replaced := false
+	for _, existing := range externalComponents {
+		if existing.Name() == comp.Name() {
+			components = append(components, comp)
+			replaced = true
+			continue
+		}
+		components = append(components, existing)
+	}
+	if !replaced {
+		components = append(components, comp)
+	}
+	externalComponents = components
+	externalComponentsMutex.Unlock()
+
+	forgetComponent(comp.Name())
+	return nil
+}
+
+// CheckDependencies returns an error if a dependency of a component that is about to be registered is not in the
+// registry, or if registering the component would create a dependency cycle
+func CheckDependencies(comp spi.Component) error {
+	dependencies := map[string][]string{}
+	for _, existing := range GetComponents() {
+		dependencies[existing.Name()] = existing.GetDependencies()
+	}
+	dependencies[comp.Name()] = comp.GetDependencies()
+
+	for _, dependencyName := range comp.GetDependencies() {
+		if _, ok := dependencies[dependencyName]; !ok {
+			return fmt.Errorf("Component %s depends on component %s, which is not registered", comp.Name(), dependencyName)
+		}
+	}
+
+	// Any new cycle goes through the component, so look for the component in its transitive dependencies
+	visited := map[string]bool{}
+	pending := append([]string{}, comp.GetDependencies()...)
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if name == comp.Name() {
+			return fmt.Errorf("Component %s has a dependency cycle", comp.Name())
+		}
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+		pending = append(pending, dependencies[name]...)
+	}
+	return nil
+}
+
+// UnregisterComponent removes a component added with RegisterComponent from the registry
+func UnregisterComponent(componentName string) {
+	externalComponentsMutex.Lock()
+	var components []spi.Component
+	for _, existing := range externalComponents {
+		if existing.Name() != componentName {
+			components = append(components, existing)
+		}
+	}
+	externalComponents = components
+	externalComponentsMutex.Unlock()
+
+	forgetComponent(componentName)
+}
+
+// forgetComponent removes a component from the map of looked up components
+func forgetComponent(componentName string) {
+	getComponentsMapMutex.Lock()
+	defer getComponentsMapMutex.Unlock()
+	delete(getComponentsMap, componentName)
 }
 
 func FindComponent(componentName string) (bool, spi.Component) {
+	getComponentsMapMutex.Lock()
+	defer getComponentsMapMutex.Unlock()
 	// check if component is in map of looked up components
 	existingComponent, ok := getComponentsMap[componentName]
 	if !ok {
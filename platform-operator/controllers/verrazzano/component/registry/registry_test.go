@@ -211,6 +211,52 @@ func TestFindComponent(t *testing.T) {
 	assert.Equal(t, istio.ComponentName, comp.Name())
 }
 
+// TestRegisterComponent tests RegisterComponent and UnregisterComponent
+// GIVEN a component that is not a built-in component
+//
+//	WHEN I call RegisterComponent
+//	THEN the component is returned after the built-in components and can be found, until it is unregistered
+func TestRegisterComponent(t *testing.T) {
+	defer UnregisterComponent("external")
+	builtInCount := len(GetComponents())
+
+	assert.NoError(t, RegisterComponent(helm2.HelmComponent{ReleaseName: "external", ChartNamespace: "ns1"}))
+	// Registering a component with the same name replaces it
+	assert.NoError(t, RegisterComponent(helm2.HelmComponent{ReleaseName: "external", ChartNamespace: "ns2"}))
+	comps := GetComponents()
+	assert.Len(t, comps, builtInCount+1)
+	assert.Equal(t, "external", comps[builtInCount].Name())
+	found, comp := FindComponent("external")
+	assert.True(t, found)
+	assert.Equal(t, "ns2", comp.Namespace())
+
+	UnregisterComponent("external")
+	assert.Len(t, GetComponents(), builtInCount)
+	found, _ = FindComponent("external")
+	assert.False(t, found)
+
+	// GIVEN a component with the name of a built-in component
+	// WHEN I call RegisterComponent
+	// THEN an error is returned
+	assert.Error(t, RegisterComponent(helm2.HelmComponent{ReleaseName: istio.ComponentName}))
+}
+
+// TestCheckDependencies tests CheckDependencies
+// GIVEN components that are about to be registered
+// WHEN I call CheckDependencies
+// THEN an error is returned if a dependency is not registered or if the dependencies of the component form a cycle
+func TestCheckDependencies(t *testing.T) {
+	defer UnregisterComponent("external1")
+	assert.NoError(t, CheckDependencies(helm2.HelmComponent{ReleaseName: "external1", Dependencies: []string{istio.ComponentName}}))
+	assert.Error(t, CheckDependencies(helm2.HelmComponent{ReleaseName: "external1", Dependencies: []string{"unknown"}}))
+
+	// external1 depends on external2, so external2 cannot depend on external1
+	assert.NoError(t, RegisterComponent(helm2.HelmComponent{ReleaseName: "external1", Dependencies: []string{"external2"}}))
+	assert.NoError(t, CheckDependencies(helm2.HelmComponent{ReleaseName: "external2", Dependencies: []string{istio.ComponentName}}))
+	assert.Error(t, CheckDependencies(helm2.HelmComponent{ReleaseName: "external2", Dependencies: []string{"external1"}}))
+	assert.Error(t, CheckDependencies(helm2.HelmComponent{ReleaseName: "external2", Dependencies: []string{"external2"}}))
+}
+
 // TestComponentDependenciesMet tests ComponentDependenciesMet
 // GIVEN a component
 //
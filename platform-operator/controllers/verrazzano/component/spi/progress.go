@@ -0,0 +1,37 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package spi
+
+import (
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+)
+
+// ProgressRecorder records the progress of a component operation in the Verrazzano status
+type ProgressRecorder func(vz *v1alpha1.Verrazzano, component string, progress string)
+
+var progressRecorder ProgressRecorder
+
+// SetProgressRecorder sets the recorder of the component progress, the components cannot update the Verrazzano status
+// themselves
+func SetProgressRecorder(recorder ProgressRecorder) {
+	progressRecorder = recorder
+}
+
+// RecordProgress records the progress of a long running component operation, an empty progress clears it. The
+// progress is only recorded when it changes, and it is also set in the actual CR of the context so that later status
+// updates of the reconcile loop keep it.
+func RecordProgress(ctx ComponentContext, component string, progress string) {
+	vz := ctx.ActualCR()
+	if vz == nil || ctx.IsDryRun() {
+		return
+	}
+	details, ok := vz.Status.Components[component]
+	if !ok || details == nil || details.Progress == progress {
+		return
+	}
+	details.Progress = progress
+	if progressRecorder != nil {
+		progressRecorder(vz, component, progress)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package thanos
+
+import (
+	"context"
+	"time"
+
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const compactorCronJob = "thanos-compactor"
+
+// CompactorStatusRecorder records the status of the Thanos compactor in the Verrazzano status
+type CompactorStatusRecorder func(vz *vzapi.Verrazzano, status *vzapi.ThanosStatus)
+
+var compactorStatusRecorder CompactorStatusRecorder
+
+// SetCompactorStatusRecorder sets the recorder of the compactor status, the component cannot update the Verrazzano
+// status itself
+func SetCompactorStatusRecorder(recorder CompactorStatusRecorder) {
+	compactorStatusRecorder = recorder
+}
+
+// recordCompactorStatus records the last scheduled and successful runs of the compactor CronJob when they change
+func recordCompactorStatus(ctx spi.ComponentContext) {
+	vz := ctx.ActualCR()
+	if vz == nil || compactorStatusRecorder == nil {
+		return
+	}
+	cronJob := &batchv1.CronJob{}
+	if err := ctx.Client().Get(context.TODO(), types.NamespacedName{Namespace: ComponentNamespace, Name: compactorCronJob}, cronJob); err != nil {
+		return
+	}
+	status := &vzapi.ThanosStatus{
+		CompactorLastScheduleTime:   formatTime(cronJob.Status.LastScheduleTime),
+		CompactorLastSuccessfulTime: formatTime(cronJob.Status.LastSuccessfulTime),
+	}
+	if vz.Status.Thanos != nil && *vz.Status.Thanos == *status {
+		return
+	}
+	ctx.Log().Debugf("Recording the Thanos compactor status %v", status)
+	compactorStatusRecorder(vz, status)
+}
+
+// formatTime formats the time of a CronJob run, a missing time is empty
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
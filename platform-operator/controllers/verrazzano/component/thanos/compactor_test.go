@@ -0,0 +1,44 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package thanos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestRecordCompactorStatus tests recording the last runs of the Thanos compactor
+// GIVEN a compactor CronJob that ran successfully
+// WHEN the availability of Thanos is checked, before and after the status is recorded
+// THEN the last runs of the compactor are recorded once
+func TestRecordCompactorStatus(t *testing.T) {
+	var recorded []*v1alpha1.ThanosStatus
+	SetCompactorStatusRecorder(func(vz *v1alpha1.Verrazzano, status *v1alpha1.ThanosStatus) {
+		recorded = append(recorded, status)
+		vz.Status.Thanos = status
+	})
+	defer SetCompactorStatusRecorder(nil)
+
+	lastRun := metav1.NewTime(time.Date(2023, 4, 1, 6, 0, 0, 0, time.UTC))
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: compactorCronJob},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: &lastRun, LastSuccessfulTime: &lastRun},
+	}).Build()
+	ctx := spi.NewFakeContext(client, &v1alpha1.Verrazzano{}, nil, false)
+
+	NewComponent().IsAvailable(ctx)
+	NewComponent().IsAvailable(ctx)
+	assert.Equal(t, []*v1alpha1.ThanosStatus{{
+		CompactorLastScheduleTime:   "2023-04-01T06:00:00Z",
+		CompactorLastSuccessfulTime: "2023-04-01T06:00:00Z",
+	}}, recorded)
+}
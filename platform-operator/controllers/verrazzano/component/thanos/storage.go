@@ -0,0 +1,215 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package thanos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	disableDownsamplingFlag = "--downsampling.disable"
+	cronFieldCount          = 5
+
+	// The compactor downsamples the raw blocks older than 40 hours to 5m, and the 5m blocks older than 10 days to 1h
+	minRawRetention = 40 * time.Hour
+	min5mRetention  = 10 * 24 * time.Hour
+)
+
+var (
+	retentionPattern = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+	retentionPart    = regexp.MustCompile(`([0-9]+)(ms|s|m|h|d|w|y)`)
+	retentionUnits   = map[string]time.Duration{
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+	}
+)
+
+// appendStorageOverrides appends the overrides for the object store, the compactor and the store gateway
+func appendStorageOverrides(ctx spi.ComponentContext, kvs []bom.KeyValue) []bom.KeyValue {
+	thanos := ctx.EffectiveCR().Spec.Components.Thanos
+	if thanos == nil {
+		return kvs
+	}
+	if thanos.ObjectStore != nil {
+		kvs = append(kvs, bom.KeyValue{Key: "existingObjstoreSecret", Value: thanos.ObjectStore.SecretName})
+		// The Thanos components read the configuration from the objstore.yml file of the secret volume
+		if key := thanos.ObjectStore.SecretKey; key != "" && key != constants.ThanosObjstoreSecretKey {
+			kvs = append(kvs, []bom.KeyValue{
+				{Key: "existingObjstoreSecretItems[0].key", Value: key},
+				{Key: "existingObjstoreSecretItems[0].path", Value: constants.ThanosObjstoreSecretKey},
+			}...)
+		}
+	}
+	if compactor := thanos.Compactor; compactor != nil {
+		if compactor.Enabled != nil {
+			kvs = append(kvs, bom.KeyValue{Key: "compactor.enabled", Value: strconv.FormatBool(*compactor.Enabled)})
+		}
+		// The compactor runs as a CronJob, whose status reports the last successful run
+		kvs = append(kvs, bom.KeyValue{Key: "compactor.cronJob.enabled", Value: "true"})
+		if compactor.Schedule != "" {
+			kvs = append(kvs, bom.KeyValue{Key: "compactor.cronJob.schedule", Value: compactor.Schedule})
+		}
+		retention := map[string]string{
+			"compactor.retentionResolutionRaw": compactor.Retention.Raw,
+			"compactor.retentionResolution5m":  compactor.Retention.Resolution5m,
+			"compactor.retentionResolution1h":  compactor.Retention.Resolution1h,
+		}
+		for _, key := range []string{"compactor.retentionResolutionRaw", "compactor.retentionResolution5m", "compactor.retentionResolution1h"} {
+			if retention[key] != "" {
+				kvs = append(kvs, bom.KeyValue{Key: key, Value: retention[key], SetString: true})
+			}
+		}
+		if compactor.Downsampling != nil && !*compactor.Downsampling {
+			kvs = append(kvs, bom.KeyValue{Key: "compactor.extraFlags[0]", Value: disableDownsamplingFlag})
+		}
+	}
+	if storeGateway := thanos.StoreGateway; storeGateway != nil {
+		if storeGateway.Enabled != nil {
+			kvs = append(kvs, bom.KeyValue{Key: "storegateway.enabled", Value: strconv.FormatBool(*storeGateway.Enabled)})
+		}
+		if storeGateway.Shards != nil && *storeGateway.Shards > 1 {
+			kvs = append(kvs, []bom.KeyValue{
+				{Key: "storegateway.sharded.enabled", Value: "true"},
+				{Key: "storegateway.sharded.hashPartitioning.shards", Value: strconv.Itoa(int(*storeGateway.Shards))},
+			}...)
+		}
+	}
+	return kvs
+}
+
+// getStoreGatewayShards returns the statefulsets of the store gateway shards, when the store gateway is sharded
+func getStoreGatewayShards(ctx spi.ComponentContext) []types.NamespacedName {
+	thanos := ctx.EffectiveCR().Spec.Components.Thanos
+	if thanos == nil || thanos.StoreGateway == nil || thanos.StoreGateway.Shards == nil || *thanos.StoreGateway.Shards <= 1 {
+		return nil
+	}
+	var shards []types.NamespacedName
+	for i := 0; i < int(*thanos.StoreGateway.Shards); i++ {
+		shards = append(shards, types.NamespacedName{Namespace: ComponentNamespace, Name: fmt.Sprintf("%s-%d", storeGatewayStatefulset, i)})
+	}
+	return shards
+}
+
+// checkObjectStoreSecret checks that the object store secret exists with the object store configuration
+func checkObjectStoreSecret(ctx spi.ComponentContext) error {
+	thanos := ctx.EffectiveCR().Spec.Components.Thanos
+	if thanos == nil || thanos.ObjectStore == nil {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: ComponentNamespace, Name: thanos.ObjectStore.SecretName}
+	if err := ctx.Client().Get(context.TODO(), name, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctx.Log().ErrorfThrottledNewErr("Thanos object store secret %s not found", name)
+		}
+		return ctx.Log().ErrorfNewErr("Failed getting Thanos object store secret %s: %v", name, err)
+	}
+	key := getObjectStoreSecretKey(thanos.ObjectStore.SecretKey)
+	if _, ok := secret.Data[key]; !ok {
+		return ctx.Log().ErrorfThrottledNewErr("Thanos object store secret %s has no key %s", name, key)
+	}
+	return nil
+}
+
+// getObjectStoreSecretKey returns the key of the object store configuration in its secret
+func getObjectStoreSecretKey(key string) string {
+	if key == "" {
+		return constants.ThanosObjstoreSecretKey
+	}
+	return key
+}
+
+// validateThanos validates the object store, compactor and store gateway configuration
+func validateThanos(vz *v1beta1.Verrazzano) error {
+	thanos := vz.Spec.Components.Thanos
+	if thanos == nil {
+		return nil
+	}
+	if thanos.ObjectStore != nil && thanos.ObjectStore.SecretName == "" {
+		return fmt.Errorf("The Thanos object store secret name is required")
+	}
+	if compactor := thanos.Compactor; compactor != nil {
+		if compactor.Enabled != nil && *compactor.Enabled && thanos.ObjectStore == nil {
+			return fmt.Errorf("The Thanos compactor requires an object store")
+		}
+		if compactor.Schedule != "" && len(strings.Fields(compactor.Schedule)) != cronFieldCount {
+			return fmt.Errorf("The Thanos compactor schedule %s is not a cron expression with %d fields", compactor.Schedule, cronFieldCount)
+		}
+		if err := validateRetention(compactor); err != nil {
+			return err
+		}
+	}
+	if storeGateway := thanos.StoreGateway; storeGateway != nil {
+		if storeGateway.Enabled != nil && *storeGateway.Enabled && thanos.ObjectStore == nil {
+			return fmt.Errorf("The Thanos store gateway requires an object store")
+		}
+		if storeGateway.Shards != nil && *storeGateway.Shards < 1 {
+			return fmt.Errorf("The Thanos store gateway shards must be at least 1, not %d", *storeGateway.Shards)
+		}
+	}
+	return nil
+}
+
+// validateRetention validates the retention durations of the compactor. When downsampling, the blocks must be kept
+// long enough to be downsampled.
+func validateRetention(compactor *v1beta1.ThanosCompactor) error {
+	raw, err := parseRetention("raw", compactor.Retention.Raw)
+	if err != nil {
+		return err
+	}
+	resolution5m, err := parseRetention("5m", compactor.Retention.Resolution5m)
+	if err != nil {
+		return err
+	}
+	if _, err := parseRetention("1h", compactor.Retention.Resolution1h); err != nil {
+		return err
+	}
+	if compactor.Downsampling != nil && !*compactor.Downsampling {
+		return nil
+	}
+	if raw > 0 && raw < minRawRetention {
+		return fmt.Errorf("The Thanos raw retention %s is shorter than %v, raw blocks would be deleted before being downsampled", compactor.Retention.Raw, minRawRetention)
+	}
+	if resolution5m > 0 && resolution5m < min5mRetention {
+		return fmt.Errorf("The Thanos 5m retention %s is shorter than %v, 5m blocks would be deleted before being downsampled", compactor.Retention.Resolution5m, min5mRetention)
+	}
+	return nil
+}
+
+// parseRetention parses a Thanos retention duration such as 30d or 1y, an empty retention is 0
+func parseRetention(resolution string, retention string) (time.Duration, error) {
+	if retention == "" {
+		return 0, nil
+	}
+	if !retentionPattern.MatchString(retention) {
+		return 0, fmt.Errorf("The Thanos %s retention %s is not a duration such as 30d", resolution, retention)
+	}
+	var duration time.Duration
+	for _, part := range retentionPart.FindAllStringSubmatch(retention, -1) {
+		value, err := strconv.Atoi(part[1])
+		if err != nil {
+			return 0, fmt.Errorf("The Thanos %s retention %s is not a duration such as 30d", resolution, retention)
+		}
+		duration += time.Duration(value) * retentionUnits[part[2]]
+	}
+	return duration, nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package thanos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/bom"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestAppendStorageOverrides tests the object store, compactor and store gateway overrides
+// GIVEN a Verrazzano CR with an object store, a compactor and a sharded store gateway
+// WHEN the storage overrides are appended
+// THEN the overrides configure the object store secret, the compactor CronJob, its retention and the store shards
+func TestAppendStorageOverrides(t *testing.T) {
+	enabled := true
+	disabled := false
+	shards := int32(3)
+	vz := &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Components: v1alpha1.ComponentSpec{
+				Thanos: &v1alpha1.ThanosComponent{
+					ObjectStore: &v1alpha1.ThanosObjectStore{SecretName: "thanos-objstore", SecretKey: "oci.yml"},
+					Compactor: &v1alpha1.ThanosCompactor{
+						Enabled:      &enabled,
+						Downsampling: &disabled,
+						Schedule:     "0 2 * * *",
+						Retention:    v1alpha1.ThanosRetention{Raw: "90d", Resolution1h: "0d"},
+					},
+					StoreGateway: &v1alpha1.ThanosStoreGateway{Enabled: &enabled, Shards: &shards},
+				},
+			},
+		},
+	}
+	ctx := spi.NewFakeContext(fake.NewClientBuilder().Build(), vz, nil, false)
+	kvs := appendStorageOverrides(ctx, nil)
+	expected := map[string]string{
+		"existingObjstoreSecret":                       "thanos-objstore",
+		"existingObjstoreSecretItems[0].key":           "oci.yml",
+		"existingObjstoreSecretItems[0].path":          "objstore.yml",
+		"compactor.enabled":                            "true",
+		"compactor.cronJob.enabled":                    "true",
+		"compactor.cronJob.schedule":                   "0 2 * * *",
+		"compactor.retentionResolutionRaw":             "90d",
+		"compactor.retentionResolution1h":              "0d",
+		"compactor.extraFlags[0]":                      "--downsampling.disable",
+		"storegateway.enabled":                         "true",
+		"storegateway.sharded.enabled":                 "true",
+		"storegateway.sharded.hashPartitioning.shards": "3",
+	}
+	assert.Len(t, kvs, len(expected))
+	for key, value := range expected {
+		assert.Equal(t, value, bom.FindKV(kvs, key), key)
+	}
+	assert.Len(t, getStoreGatewayShards(ctx), 3)
+
+	// GIVEN a Verrazzano CR without Thanos storage configuration
+	// WHEN the storage overrides are appended
+	// THEN no overrides are appended
+	ctx = spi.NewFakeContext(fake.NewClientBuilder().Build(), &v1alpha1.Verrazzano{}, nil, false)
+	assert.Empty(t, appendStorageOverrides(ctx, nil))
+	assert.Empty(t, getStoreGatewayShards(ctx))
+}
+
+// TestValidateThanos tests the validation of the Thanos storage configuration
+// GIVEN Verrazzano CRs with valid and invalid Thanos storage configurations
+// WHEN the component validates them
+// THEN the invalid configurations are rejected
+func TestValidateThanos(t *testing.T) {
+	enabled := true
+	disabled := false
+	noShards := int32(0)
+	objectStore := &v1beta1.ThanosObjectStore{SecretName: "thanos-objstore"}
+	tests := []struct {
+		name    string
+		thanos  *v1beta1.ThanosComponent
+		wantErr string
+	}{
+		{
+			name:   "no Thanos",
+			thanos: nil,
+		},
+		{
+			name: "valid",
+			thanos: &v1beta1.ThanosComponent{
+				ObjectStore: objectStore,
+				Compactor: &v1beta1.ThanosCompactor{
+					Enabled:   &enabled,
+					Schedule:  "0 */6 * * *",
+					Retention: v1beta1.ThanosRetention{Raw: "2d", Resolution5m: "4w", Resolution1h: "1y6w"},
+				},
+				StoreGateway: &v1beta1.ThanosStoreGateway{Enabled: &enabled},
+			},
+		},
+		{
+			name:    "no secret name",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: &v1beta1.ThanosObjectStore{}},
+			wantErr: "secret name is required",
+		},
+		{
+			name:    "compactor without object store",
+			thanos:  &v1beta1.ThanosComponent{Compactor: &v1beta1.ThanosCompactor{Enabled: &enabled}},
+			wantErr: "compactor requires an object store",
+		},
+		{
+			name:    "store gateway without object store",
+			thanos:  &v1beta1.ThanosComponent{StoreGateway: &v1beta1.ThanosStoreGateway{Enabled: &enabled}},
+			wantErr: "store gateway requires an object store",
+		},
+		{
+			name:    "invalid schedule",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: objectStore, Compactor: &v1beta1.ThanosCompactor{Schedule: "@daily"}},
+			wantErr: "not a cron expression",
+		},
+		{
+			name:    "invalid retention",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: objectStore, Compactor: &v1beta1.ThanosCompactor{Retention: v1beta1.ThanosRetention{Resolution1h: "ten years"}}},
+			wantErr: "1h retention ten years is not a duration",
+		},
+		{
+			name:    "raw retention too short to downsample",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: objectStore, Compactor: &v1beta1.ThanosCompactor{Retention: v1beta1.ThanosRetention{Raw: "1d"}}},
+			wantErr: "raw retention 1d is shorter than 40h0m0s",
+		},
+		{
+			name:    "5m retention too short to downsample",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: objectStore, Compactor: &v1beta1.ThanosCompactor{Retention: v1beta1.ThanosRetention{Resolution5m: "1w"}}},
+			wantErr: "5m retention 1w is shorter",
+		},
+		{
+			name: "short retention without downsampling",
+			thanos: &v1beta1.ThanosComponent{ObjectStore: objectStore, Compactor: &v1beta1.ThanosCompactor{
+				Downsampling: &disabled,
+				Retention:    v1beta1.ThanosRetention{Raw: "1d", Resolution5m: "1d"},
+			}},
+		},
+		{
+			name:    "no shards",
+			thanos:  &v1beta1.ThanosComponent{ObjectStore: objectStore, StoreGateway: &v1beta1.ThanosStoreGateway{Shards: &noShards}},
+			wantErr: "shards must be at least 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vz := &v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Components: v1beta1.ComponentSpec{Thanos: tt.thanos}}}
+			err := NewComponent().ValidateInstallV1Beta1(vz)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.ErrorContains(t, NewComponent().ValidateUpdateV1Beta1(&v1beta1.Verrazzano{}, vz), tt.wantErr)
+		})
+	}
+}
+
+// TestCheckObjectStoreSecret tests checking the object store secret before installing or upgrading Thanos
+// GIVEN a Verrazzano CR with an object store
+// WHEN the secret is missing, has no object store configuration, or has it
+// THEN the pre-install fails until the secret holds the object store configuration
+func TestCheckObjectStoreSecret(t *testing.T) {
+	vz := &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{
+			Components: v1alpha1.ComponentSpec{
+				Thanos: &v1alpha1.ThanosComponent{ObjectStore: &v1alpha1.ThanosObjectStore{SecretName: "thanos-objstore"}},
+			},
+		},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ComponentNamespace, Name: "thanos-objstore"}}
+	client := fake.NewClientBuilder().WithScheme(k8scheme.Scheme).Build()
+	ctx := spi.NewFakeContext(client, vz, nil, false)
+	assert.ErrorContains(t, preInstallUpgrade(ctx), "not found")
+
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(secret.DeepCopy()).Build()
+	ctx = spi.NewFakeContext(client, vz, nil, false)
+	assert.ErrorContains(t, preInstallUpgrade(ctx), "has no key objstore.yml")
+
+	secret.Data = map[string][]byte{"objstore.yml": []byte("type: S3")}
+	client = fake.NewClientBuilder().WithScheme(k8scheme.Scheme).WithObjects(secret).Build()
+	ctx = spi.NewFakeContext(client, vz, nil, false)
+	assert.NoError(t, preInstallUpgrade(ctx))
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: ComponentNamespace}, &corev1.Namespace{}))
+}
@@ -61,6 +61,7 @@ func AppendOverrides(ctx spi.ComponentContext, _ string, _ string, _ string, kvs
 	kvs = append(kvs, image...)
 
 	kvs = appendVerrazzanoOverrides(ctx, kvs)
+	kvs = appendStorageOverrides(ctx, kvs)
 
 	return appendIngressOverrides(ctx, kvs)
 }
@@ -85,7 +86,10 @@ func preInstallUpgrade(ctx spi.ComponentContext) error {
 
 	// Create the verrazzano-monitoring namespace if not already created
 	ctx.Log().Debugf("Creating namespace %s for Thanos", constants.VerrazzanoMonitoringNamespace)
-	return common.EnsureVerrazzanoMonitoringNamespace(ctx)
+	if err := common.EnsureVerrazzanoMonitoringNamespace(ctx); err != nil {
+		return err
+	}
+	return checkObjectStoreSecret(ctx)
 }
 
 // appendIngressOverrides generates overrides for ingress objects in the Thanos component
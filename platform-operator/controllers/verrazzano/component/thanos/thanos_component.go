@@ -14,8 +14,10 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/k8s/ready"
 	"github.com/verrazzano/verrazzano/pkg/vzcr"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentoperator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/networkpolicies"
@@ -88,8 +90,9 @@ func (t ThanosComponent) IsReady(ctx spi.ComponentContext) bool {
 }
 
 // IsAvailable returns the component availability for ThanosComponent, also accounting for optional
-// subcomponents like store gateway
+// subcomponents like store gateway. The last run of the compactor is recorded in the Verrazzano status.
 func (t ThanosComponent) IsAvailable(ctx spi.ComponentContext) (string, v1alpha1.ComponentAvailability) {
+	recordCompactorStatus(ctx)
 	deployments := t.getEnabledDeployments(ctx)
 	statefulsets := t.getEnabledStatefulsets(ctx)
 	actualAvailabilityObjects := ready.AvailabilityObjects{
@@ -124,7 +127,7 @@ func (t ThanosComponent) getEnabledDeployments(ctx spi.ComponentContext) []types
 
 func (t ThanosComponent) getEnabledStatefulsets(ctx spi.ComponentContext) []types.NamespacedName {
 	enabledStatefulsets := []types.NamespacedName{}
-	for _, stsName := range t.AvailabilityObjects.StatefulsetNames {
+	for _, stsName := range append(t.AvailabilityObjects.StatefulsetNames, getStoreGatewayShards(ctx)...) {
 		if exists, err := ready.DoesStatefulsetExist(ctx.Client(), stsName); err == nil && exists {
 			enabledStatefulsets = append(enabledStatefulsets, stsName)
 		}
@@ -155,6 +158,44 @@ func (t ThanosComponent) PreUpgrade(ctx spi.ComponentContext) error {
 	return t.HelmComponent.PreUpgrade(ctx)
 }
 
+// ValidateInstall checks if the specified Verrazzano CR is valid for this component to be installed
+func (t ThanosComponent) ValidateInstall(vz *v1alpha1.Verrazzano) error {
+	convertedVZ := v1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(vz, &convertedVZ); err != nil {
+		return err
+	}
+	return t.ValidateInstallV1Beta1(&convertedVZ)
+}
+
+// ValidateUpdate checks if the specified new Verrazzano CR is valid for this component to be updated
+func (t ThanosComponent) ValidateUpdate(old *v1alpha1.Verrazzano, new *v1alpha1.Verrazzano) error {
+	convertedOld := v1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(old, &convertedOld); err != nil {
+		return err
+	}
+	convertedNew := v1beta1.Verrazzano{}
+	if err := common.ConvertVerrazzanoCR(new, &convertedNew); err != nil {
+		return err
+	}
+	return t.ValidateUpdateV1Beta1(&convertedOld, &convertedNew)
+}
+
+// ValidateInstallV1Beta1 checks if the specified Verrazzano CR is valid for this component to be installed
+func (t ThanosComponent) ValidateInstallV1Beta1(vz *v1beta1.Verrazzano) error {
+	if err := validateThanos(vz); err != nil {
+		return err
+	}
+	return t.HelmComponent.ValidateInstallV1Beta1(vz)
+}
+
+// ValidateUpdateV1Beta1 checks if the specified new Verrazzano CR is valid for this component to be updated
+func (t ThanosComponent) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, new *v1beta1.Verrazzano) error {
+	if err := validateThanos(new); err != nil {
+		return err
+	}
+	return t.HelmComponent.ValidateUpdateV1Beta1(old, new)
+}
+
 // GetIngressNames returns the Thanos ingress names
 func (t ThanosComponent) GetIngressNames(ctx spi.ComponentContext) []types.NamespacedName {
 	var ingressNames []types.NamespacedName
@@ -0,0 +1,276 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	controllerName    = "CredentialRotator"
+	channelBufferSize = 100
+
+	// defaultRotationIntervalDays is the number of days between two rotations of a credential when the rotation
+	// policy does not specify it
+	defaultRotationIntervalDays = 90
+
+	// rotationRetryDelay is the time to wait before retrying a failed rotation
+	rotationRetryDelay = 10 * time.Minute
+)
+
+// getCurrentTime returns the current time, it is overridden by unit tests
+var getCurrentTime = time.Now
+
+// CredentialRotator periodically rotates the credentials generated by Verrazzano, according to the credential
+// rotation policy of the Verrazzano resource.
+type CredentialRotator struct {
+	client        clipkg.Client
+	statusUpdater vzstatus.Updater
+	tickTime      time.Duration
+	log           vzlog.VerrazzanoLogger
+	shutdown      chan int // The channel on which shutdown signals are sent/received
+}
+
+// NewCredentialRotator - instantiate a CredentialRotator context
+func NewCredentialRotator(c clipkg.Client, statusUpdater vzstatus.Updater, tick time.Duration) (*CredentialRotator, error) {
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           controllerName,
+		Namespace:      "",
+		ID:             controllerName,
+		Generation:     0,
+		ControllerName: controllerName,
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create resource logger for %s: %v", controllerName, err)
+		return nil, err
+	}
+	return &CredentialRotator{
+		client:        c,
+		statusUpdater: statusUpdater,
+		tickTime:      tick,
+		log:           log,
+	}, nil
+}
+
+// Start starts the CredentialRotator if it is not already running.
+// It is safe to call Start multiple times, additional goroutines will not be created
+func (cr *CredentialRotator) Start() {
+	if cr.shutdown != nil {
+		// already running, so nothing to do
+		return
+	}
+	cr.shutdown = make(chan int, channelBufferSize)
+
+	// goroutine rotates the credentials that are due every cr.tickTime. If a shutdown signal is received (or channel
+	// is closed), the goroutine returns.
+	go func() {
+		ticker := time.NewTicker(cr.tickTime)
+		for {
+			select {
+			case <-ticker.C:
+				if err := cr.RotateCredentials(); err != nil {
+					cr.log.ErrorfThrottled("Failed to rotate credentials: %v", err)
+				}
+			case <-cr.shutdown:
+				// shutdown event causes termination
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Pause pauses the CredentialRotator if it was running.
+// It is safe to call Pause multiple times
+func (cr *CredentialRotator) Pause() {
+	if cr.shutdown != nil {
+		close(cr.shutdown)
+		cr.shutdown = nil
+	}
+}
+
+// RotateCredentials rotates the credentials of the rotation policy that are due for rotation, completes the rotations
+// that did not complete, and records the result of each rotation in the Verrazzano status
+func (cr *CredentialRotator) RotateCredentials() error {
+	vzList := &vzapi.VerrazzanoList{}
+	if err := cr.client.List(context.TODO(), vzList); err != nil {
+		return err
+	}
+	if len(vzList.Items) == 0 {
+		return nil
+	}
+	vz := &vzList.Items[0]
+	policy := vz.Spec.Security.CredentialRotation
+	// Credentials are only rotated when Verrazzano is not being installed, upgraded or uninstalled
+	if vz.Status.State != vzapi.VzStateReady || !vz.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	pending, err := cr.getPendingPasswords()
+	if err != nil {
+		return err
+	}
+	if policy == nil && len(pending) == 0 {
+		return nil
+	}
+
+	statuses := make([]vzapi.CredentialStatus, len(vz.Status.Credentials))
+	for i := range vz.Status.Credentials {
+		vz.Status.Credentials[i].DeepCopyInto(&statuses[i])
+	}
+	updated := false
+	for _, name := range getRotatedCredentials(policy, pending) {
+		cred, ok := credentials[name]
+		if !ok || !cred.isEnabled(vz) {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := cr.client.Get(context.TODO(), cred.secret, secret); err != nil {
+			if errors.IsNotFound(err) {
+				// The credential has not been generated yet
+				continue
+			}
+			return err
+		}
+		status := getCredentialStatus(&statuses, name)
+		if isRetryDelayed(status) {
+			continue
+		}
+		// The rotations that did not complete are always retried
+		if _, ok := pending[name]; !ok && !isRotationDue(policy, status, secret) {
+			continue
+		}
+
+		cr.log.Infof("Rotating credential %s", name)
+		now := getCurrentTime().UTC().Format(time.RFC3339)
+		if err := cr.rotate(name, cred, secret); err != nil {
+			cr.log.Errorf("Failed rotating credential %s: %v", name, err)
+			setCondition(status, vzapi.CondCredentialRotationFailed, now, err.Error())
+		} else {
+			cr.log.Infof("Rotated credential %s", name)
+			status.LastRotationTime = now
+			if policy != nil {
+				status.RotationVersion = policy.RotationVersion
+			}
+			setCondition(status, vzapi.CondCredentialRotated, now, fmt.Sprintf("Credential %s was rotated", name))
+		}
+		updated = true
+	}
+
+	if updated {
+		cr.statusUpdater.Update(&vzstatus.UpdateEvent{
+			Verrazzano:  vz,
+			Credentials: statuses,
+		})
+	}
+	return nil
+}
+
+// getRotatedCredentials returns the credentials rotated by the policy, all the credentials by default, and the
+// credentials whose rotation has not completed
+func getRotatedCredentials(policy *vzapi.CredentialRotationSpec, pending map[vzapi.CredentialName]string) []vzapi.CredentialName {
+	allCredentials := []vzapi.CredentialName{
+		vzapi.KeycloakAdminCredential,
+		vzapi.MySQLRootCredential,
+		vzapi.MySQLUserCredential,
+		vzapi.GrafanaAdminCredential,
+	}
+	var names []vzapi.CredentialName
+	if policy != nil {
+		names = allCredentials
+		if len(policy.Credentials) > 0 {
+			names = append([]vzapi.CredentialName{}, policy.Credentials...)
+		}
+	}
+	for _, name := range allCredentials {
+		if _, ok := pending[name]; ok && !containsCredential(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func containsCredential(names []vzapi.CredentialName, name vzapi.CredentialName) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getCredentialStatus returns the status of a credential, adding it to the statuses if it does not exist
+func getCredentialStatus(statuses *[]vzapi.CredentialStatus, name vzapi.CredentialName) *vzapi.CredentialStatus {
+	for i := range *statuses {
+		if (*statuses)[i].Name == name {
+			return &(*statuses)[i]
+		}
+	}
+	*statuses = append(*statuses, vzapi.CredentialStatus{Name: name})
+	return &(*statuses)[len(*statuses)-1]
+}
+
+// isRetryDelayed returns true if the last rotation of a credential failed less than the retry delay ago
+func isRetryDelayed(status *vzapi.CredentialStatus) bool {
+	if failed := getCondition(status, vzapi.CondCredentialRotationFailed); failed != nil && failed.Status == corev1.ConditionTrue {
+		if failedTime, err := time.Parse(time.RFC3339, failed.LastTransitionTime); err == nil && getCurrentTime().Before(failedTime.Add(rotationRetryDelay)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRotationDue returns true if the rotation version of the policy has changed, or if the rotation interval has
+// elapsed since the last rotation of the credential, or since the credential was generated
+func isRotationDue(policy *vzapi.CredentialRotationSpec, status *vzapi.CredentialStatus, secret *corev1.Secret) bool {
+	if policy.RotationVersion != "" && policy.RotationVersion != status.RotationVersion {
+		return true
+	}
+
+	lastRotation := secret.CreationTimestamp.Time
+	if status.LastRotationTime != "" {
+		if t, err := time.Parse(time.RFC3339, status.LastRotationTime); err == nil {
+			lastRotation = t
+		}
+	}
+	intervalDays := defaultRotationIntervalDays
+	if policy.IntervalDays != nil {
+		intervalDays = *policy.IntervalDays
+	}
+	return !getCurrentTime().Before(lastRotation.Add(time.Duration(intervalDays) * 24 * time.Hour))
+}
+
+// setCondition records the result of a rotation, the rotated and failed conditions are mutually exclusive
+func setCondition(status *vzapi.CredentialStatus, condType vzapi.ConditionType, now string, message string) {
+	var conditions []vzapi.Condition
+	for _, condition := range status.Conditions {
+		if condition.Type != vzapi.CondCredentialRotated && condition.Type != vzapi.CondCredentialRotationFailed {
+			conditions = append(conditions, condition)
+		}
+	}
+	status.Conditions = append(conditions, vzapi.Condition{
+		Type:               condType,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: now,
+		Message:            message,
+	})
+}
+
+func getCondition(status *vzapi.CredentialStatus, condType vzapi.ConditionType) *vzapi.Condition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	vzstatus "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	oldPassword  = "oldpassword"
+	rootPassword = "rootpassword"
+)
+
+var testTime = time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// TestRotateDueCredential tests rotating a credential when the rotation interval has elapsed
+// GIVEN a Keycloak admin secret created more than 90 days ago
+// WHEN RotateCredentials is called
+// THEN the password is changed in Keycloak, the secret is updated and the rotation is recorded in the status
+func TestRotateDueCredential(t *testing.T) {
+	commands := setupExec(t, nil)
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{Credentials: []vzapi.CredentialName{vzapi.KeycloakAdminCredential}})
+	secret := newSecret(keycloakNamespace, "keycloak-http", testTime.Add(-91*24*time.Hour), map[string]string{"username": "keycloakadmin", "password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	newPassword := getSecretData(t, cli, keycloakNamespace, "keycloak-http", "password")
+	assert.NotEqual(t, oldPassword, newPassword)
+	assert.Len(t, *commands, 1)
+	assert.Contains(t, (*commands)[0].command, "--user keycloakadmin")
+	assert.NotContains(t, (*commands)[0].command, oldPassword)
+	assert.NotContains(t, (*commands)[0].command, newPassword)
+	assert.Equal(t, oldPassword+"\n"+newPassword+"\n", (*commands)[0].stdin)
+	assert.Empty(t, getSecretData(t, cli, vzconst.VerrazzanoInstallNamespace, rotationSecretName, string(vzapi.KeycloakAdminCredential)))
+
+	status := getStatus(t, cli, vzapi.KeycloakAdminCredential)
+	assert.Equal(t, testTime.Format(time.RFC3339), status.LastRotationTime)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, vzapi.CondCredentialRotated, status.Conditions[0].Type)
+}
+
+// TestCredentialNotDue tests that a credential is not rotated before the rotation interval has elapsed
+// GIVEN a Keycloak admin secret rotated less than the rotation interval ago
+// WHEN RotateCredentials is called
+// THEN the credential is not rotated
+func TestCredentialNotDue(t *testing.T) {
+	commands := setupExec(t, nil)
+	interval := 30
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{IntervalDays: &interval})
+	vz.Status.Credentials = []vzapi.CredentialStatus{{Name: vzapi.KeycloakAdminCredential, LastRotationTime: testTime.Add(-29 * 24 * time.Hour).Format(time.RFC3339)}}
+	secret := newSecret(keycloakNamespace, "keycloak-http", testTime.Add(-100*24*time.Hour), map[string]string{"username": "keycloakadmin", "password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	assert.Equal(t, oldPassword, getSecretData(t, cli, keycloakNamespace, "keycloak-http", "password"))
+	assert.Empty(t, *commands)
+}
+
+// TestRotateMySQLUserOnDemand tests rotating a credential when the rotation version changes
+// GIVEN a MySQL cluster secret that is not due for rotation and a new rotation version
+// WHEN RotateCredentials is called
+// THEN the password is changed in MySQL, the secrets are updated and Keycloak is restarted
+func TestRotateMySQLUserOnDemand(t *testing.T) {
+	commands := setupExec(t, nil)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: keycloakNamespace, Name: "keycloak"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "keycloak"}},
+		},
+	}
+	sts.Spec.Template.Spec.Containers = []corev1.Container{{Name: keycloakContainerName, Env: []corev1.EnvVar{{Name: keycloakDBUserEnvVar, Value: "kcuser"}}}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: keycloakNamespace, Name: keycloakPodName, Labels: map[string]string{"app": "keycloak"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: keycloakContainerName,
+			Env: []corev1.EnvVar{{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "mysql-cluster-secret"},
+				Key:                  mySQLUserKey,
+			}}}},
+		}}},
+	}
+	goClient := k8sfake.NewSimpleClientset(sts, pod)
+	k8sutil.SetFakeClient(goClient)
+	defer k8sutil.ClearFakeClient()
+
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{Credentials: []vzapi.CredentialName{vzapi.MySQLUserCredential}, RotationVersion: "1"})
+	clusterSecret := newSecret(keycloakNamespace, "mysql-cluster-secret", testTime, map[string]string{mySQLRootKey: rootPassword, mySQLUserKey: oldPassword})
+	legacySecret := newSecret(keycloakNamespace, "mysql", testTime, map[string]string{"mysql-password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, clusterSecret, legacySecret, sts).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	newPassword := getSecretData(t, cli, keycloakNamespace, "mysql-cluster-secret", mySQLUserKey)
+	assert.NotEqual(t, oldPassword, newPassword)
+	assert.Equal(t, rootPassword, getSecretData(t, cli, keycloakNamespace, "mysql-cluster-secret", mySQLRootKey))
+	assert.Equal(t, newPassword, getSecretData(t, cli, keycloakNamespace, "mysql", "mysql-password"))
+	assert.Len(t, *commands, 1)
+	assert.NotContains(t, (*commands)[0].command, rootPassword)
+	assert.Equal(t, fmt.Sprintf("%s\nALTER USER 'kcuser'@'%%' IDENTIFIED BY '%s';\n", rootPassword, newPassword), (*commands)[0].stdin)
+
+	updatedSts, err := goClient.AppsV1().StatefulSets(keycloakNamespace).Get(context.TODO(), "keycloak", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, updatedSts.Spec.Template.Annotations, vzconst.VerrazzanoRestartAnnotation)
+	assert.Equal(t, "1", getStatus(t, cli, vzapi.MySQLUserCredential).RotationVersion)
+}
+
+// TestRotateGrafanaAdmin tests rotating the Grafana admin password
+// GIVEN a Grafana admin secret with a new rotation version and a running Grafana pod
+// WHEN RotateCredentials is called
+// THEN the password is changed with the Grafana API, with the credentials passed in the curl configuration
+func TestRotateGrafanaAdmin(t *testing.T) {
+	commands := setupExec(t, nil)
+	labels := map[string]string{"app": "system-grafana"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoSystemNamespace, Name: grafanaDeployment},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: vzconst.VerrazzanoSystemNamespace, Name: "grafana-pod", Labels: labels},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{Credentials: []vzapi.CredentialName{vzapi.GrafanaAdminCredential}, RotationVersion: "1"})
+	secret := newSecret(vzconst.VerrazzanoSystemNamespace, constants.GrafanaSecret, testTime, map[string]string{"username": "verrazzano", "password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret, deployment, pod).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	newPassword := getSecretData(t, cli, vzconst.VerrazzanoSystemNamespace, constants.GrafanaSecret, "password")
+	assert.NotEqual(t, oldPassword, newPassword)
+	assert.Len(t, *commands, 1)
+	assert.NotContains(t, (*commands)[0].command, oldPassword)
+	assert.Equal(t, fmt.Sprintf("user = \"verrazzano:%s\"\ndata = \"{\\\"confirmNew\\\":\\\"%s\\\",\\\"newPassword\\\":\\\"%s\\\",\\\"oldPassword\\\":\\\"%s\\\"}\"\n",
+		oldPassword, newPassword, newPassword, oldPassword), (*commands)[0].stdin)
+}
+
+// TestRotationFailure tests recording a failed rotation
+// GIVEN a Keycloak admin secret that is due for rotation and a failure changing the password in Keycloak
+// WHEN RotateCredentials is called
+// THEN the secret is not changed, the new password is persisted, the failure is recorded without passwords and the
+// rotation is not retried right away
+func TestRotationFailure(t *testing.T) {
+	commands := setupExec(t, fmt.Errorf("failed"))
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{Credentials: []vzapi.CredentialName{vzapi.KeycloakAdminCredential}, RotationVersion: "1"})
+	secret := newSecret(keycloakNamespace, "keycloak-http", testTime, map[string]string{"username": "keycloakadmin", "password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret).Build()
+	rotator := newRotator(t, cli)
+
+	assert.NoError(t, rotator.RotateCredentials())
+
+	assert.Equal(t, oldPassword, getSecretData(t, cli, keycloakNamespace, "keycloak-http", "password"))
+	status := getStatus(t, cli, vzapi.KeycloakAdminCredential)
+	assert.Empty(t, status.LastRotationTime)
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, vzapi.CondCredentialRotationFailed, status.Conditions[0].Type)
+	assert.False(t, strings.Contains(status.Conditions[0].Message, oldPassword))
+	assert.NotEmpty(t, getSecretData(t, cli, vzconst.VerrazzanoInstallNamespace, rotationSecretName, string(vzapi.KeycloakAdminCredential)))
+
+	// The password change is attempted with the current password, then with the persisted password
+	assert.Len(t, *commands, 2)
+
+	// The failed rotation is not retried right away
+	assert.NoError(t, rotator.RotateCredentials())
+	assert.Len(t, *commands, 2)
+}
+
+// TestResumeRotation tests completing a rotation which changed the password before failing to update the secrets
+// GIVEN a Keycloak admin password persisted by a failed rotation, which is already the password of Keycloak
+// WHEN RotateCredentials is called, although the credential is no longer rotated by the policy
+// THEN the persisted password is used, the secret is updated and the persisted password is removed
+func TestResumeRotation(t *testing.T) {
+	pendingPassword := "pendingpassword"
+	// Keycloak only accepts the persisted password
+	commands := setupExecFunc(t, func(exec podExec) error {
+		if !strings.HasPrefix(exec.stdin, pendingPassword+"\n") {
+			return fmt.Errorf("invalid password")
+		}
+		return nil
+	})
+	vz := newVerrazzano(&vzapi.CredentialRotationSpec{Credentials: []vzapi.CredentialName{vzapi.GrafanaAdminCredential}})
+	secret := newSecret(keycloakNamespace, "keycloak-http", testTime, map[string]string{"username": "keycloakadmin", "password": oldPassword})
+	rotationSecret := newSecret(vzconst.VerrazzanoInstallNamespace, rotationSecretName, testTime, map[string]string{string(vzapi.KeycloakAdminCredential): pendingPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret, rotationSecret).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	assert.Len(t, *commands, 2)
+	assert.Equal(t, pendingPassword, getSecretData(t, cli, keycloakNamespace, "keycloak-http", "password"))
+	assert.Empty(t, getSecretData(t, cli, vzconst.VerrazzanoInstallNamespace, rotationSecretName, string(vzapi.KeycloakAdminCredential)))
+	assert.Equal(t, vzapi.CondCredentialRotated, getStatus(t, cli, vzapi.KeycloakAdminCredential).Conditions[0].Type)
+}
+
+// TestNoRotationPolicy tests that credentials are not rotated without a rotation policy
+// GIVEN a Verrazzano resource without a credential rotation policy
+// WHEN RotateCredentials is called
+// THEN no credential is rotated
+func TestNoRotationPolicy(t *testing.T) {
+	commands := setupExec(t, nil)
+	vz := newVerrazzano(nil)
+	secret := newSecret(keycloakNamespace, "keycloak-http", testTime.Add(-365*24*time.Hour), map[string]string{"username": "keycloakadmin", "password": oldPassword})
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(vz, secret).Build()
+
+	assert.NoError(t, newRotator(t, cli).RotateCredentials())
+
+	assert.Equal(t, oldPassword, getSecretData(t, cli, keycloakNamespace, "keycloak-http", "password"))
+	assert.Empty(t, *commands)
+}
+
+// podExec is a command run in a pod with its standard input
+type podExec struct {
+	command string
+	stdin   string
+}
+
+// setupExec fakes the pod exec and returns the commands that are run
+func setupExec(t *testing.T, execErr error) *[]podExec {
+	return setupExecFunc(t, func(podExec) error { return execErr })
+}
+
+// setupExecFunc fakes the pod exec with a function returning the error of each command, and returns the commands
+// that are run
+func setupExecFunc(t *testing.T, execFunc func(podExec) error) *[]podExec {
+	commands := &[]podExec{}
+	prevClientConfig := k8sutil.ClientConfig
+	prevExecutor := k8sutil.NewPodExecutor
+	prevResult := k8sutilfake.PodExecResult
+	prevStdin := k8sutilfake.PodExecStdin
+	k8sutil.ClientConfig = func() (*rest.Config, kubernetes.Interface, error) {
+		cfg, cli := k8sutilfake.NewClientsetConfig()
+		return cfg, cli, nil
+	}
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	var stdin string
+	k8sutilfake.PodExecStdin = func(url *url.URL, in string) {
+		stdin = in
+	}
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		exec := podExec{command: strings.Join(url.Query()["command"], " "), stdin: stdin}
+		*commands = append(*commands, exec)
+		stdin = ""
+		return "", "", execFunc(exec)
+	}
+	getCurrentTime = func() time.Time { return testTime }
+	t.Cleanup(func() {
+		k8sutil.ClientConfig = prevClientConfig
+		k8sutil.NewPodExecutor = prevExecutor
+		k8sutilfake.PodExecResult = prevResult
+		k8sutilfake.PodExecStdin = prevStdin
+		getCurrentTime = time.Now
+	})
+	return commands
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = vzapi.AddToScheme(scheme)
+	return scheme
+}
+
+func newRotator(t *testing.T, cli clipkg.Client) *CredentialRotator {
+	return &CredentialRotator{
+		client:        cli,
+		statusUpdater: &vzstatus.FakeVerrazzanoStatusUpdater{Client: cli},
+		tickTime:      time.Minute,
+		log:           vzlog.DefaultLogger(),
+	}
+}
+
+func newVerrazzano(policy *vzapi.CredentialRotationSpec) *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Spec: vzapi.VerrazzanoSpec{
+			Security: vzapi.SecuritySpec{CredentialRotation: policy},
+		},
+		Status: vzapi.VerrazzanoStatus{State: vzapi.VzStateReady},
+	}
+}
+
+func newSecret(namespace string, name string, created time.Time, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func getSecretData(t *testing.T, cli clipkg.Client, namespace string, name string, key string) string {
+	secret := &corev1.Secret{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret))
+	return string(secret.Data[key])
+}
+
+func getStatus(t *testing.T, cli clipkg.Client, name vzapi.CredentialName) vzapi.CredentialStatus {
+	vz := &vzapi.Verrazzano{}
+	assert.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, vz))
+	for _, status := range vz.Status.Credentials {
+		if status.Name == name {
+			return status
+		}
+	}
+	assert.Failf(t, "missing status", "No status for credential %s", name)
+	return vzapi.CredentialStatus{}
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/verrazzano/verrazzano/pkg/k8s/ready"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	vzpassword "github.com/verrazzano/verrazzano/pkg/security/password"
+	"github.com/verrazzano/verrazzano/pkg/vzcr"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/reconcile/restart"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	keycloakNamespace     = "keycloak"
+	keycloakPodName       = "keycloak-0"
+	keycloakContainerName = "keycloak"
+	kcAdminScript         = "/opt/keycloak/bin/kcadm.sh"
+
+	// The MySQL user of Keycloak is the database user of the Keycloak StatefulSet
+	keycloakStatefulSet  = "keycloak"
+	keycloakDBUserEnvVar = "KC_DB_USERNAME"
+
+	mySQLPodName       = "mysql-0"
+	mySQLContainerName = "mysql"
+	mySQLRootKey       = "rootPassword"
+	mySQLRootHostKey   = "rootHost"
+	mySQLUserKey       = "userPassword"
+
+	grafanaDeployment    = "vmi-system-grafana"
+	grafanaContainerName = "grafana"
+
+	// rotationSecretName is the secret in which the new passwords are persisted before they are changed, until the
+	// secrets depending on them are updated
+	rotationSecretName = "verrazzano-credential-rotation"
+
+	passwordMask = "******"
+)
+
+// The commands read the passwords from the standard input, so that they do not appear in the command lines
+const (
+	// keycloakSetAdminPasswordCommand reads the current and the new password
+	keycloakSetAdminPasswordCommand = `IFS= read -r KC_CLI_PASSWORD && IFS= read -r NEW_PASSWORD && export KC_CLI_PASSWORD && \
+%[1]s config credentials --server http://localhost:8080/auth --realm master --user %[2]s --config /tmp/kcadm-rotate.config && \
+USER_ID=$(%[1]s get users -r master -q username=%[2]s -q exact=true --fields id --format csv --noquotes --config /tmp/kcadm-rotate.config) && \
+printf '{"type":"password","temporary":false,"value":"%%s"}' "$NEW_PASSWORD" | \
+%[1]s update users/$USER_ID/reset-password -r master -n -f - --config /tmp/kcadm-rotate.config; rc=$?; rm -f /tmp/kcadm-rotate.config; exit $rc`
+
+	// mySQLCommand reads the root password, then the statements to run
+	mySQLCommand = `IFS= read -r MYSQL_PWD && export MYSQL_PWD && /usr/bin/mysql -uroot`
+
+	mySQLAlterUserStatement = "ALTER USER '%s'@'%s' IDENTIFIED BY '%s';\n"
+
+	// grafanaSetAdminPasswordCommand reads the curl configuration with the credentials and the request body
+	grafanaSetAdminPasswordCommand = `curl -s -f -K - -X PUT -H "Content-Type: application/json" http://localhost:3000/api/user/password`
+)
+
+// secretKey identifies a key of a secret
+type secretKey struct {
+	types.NamespacedName
+	key string
+}
+
+// credential defines where a credential generated by Verrazzano is stored, how its password is changed in the system
+// that owns it, and which secrets and workloads depend on it
+type credential struct {
+	// secret is the secret in which the credential is generated
+	secret types.NamespacedName
+	// passwordKey is the key of the password in the secret
+	passwordKey string
+	// passwordLength is the length of a generated password
+	passwordLength int
+	// dependents are the secrets that hold a copy of the password, they are updated if they exist
+	dependents []secretKey
+	// restartConsumers is true if the workloads using the secret read the password at startup and need a restart
+	restartConsumers bool
+	// isEnabled returns true if the component owning the credential is enabled
+	isEnabled func(cr runtime.Object) bool
+	// changePassword changes the password in the system that owns the credential
+	changePassword func(cr *CredentialRotator, secret *corev1.Secret, oldPassword string, newPassword string) error
+}
+
+// credentials are the credentials that can be rotated
+var credentials = map[vzapi.CredentialName]credential{
+	vzapi.KeycloakAdminCredential: {
+		secret:         types.NamespacedName{Namespace: keycloakNamespace, Name: "keycloak-http"},
+		passwordKey:    "password",
+		passwordLength: 15,
+		isEnabled:      vzcr.IsKeycloakEnabled,
+		changePassword: changeKeycloakAdminPassword,
+	},
+	vzapi.MySQLRootCredential: {
+		secret:         types.NamespacedName{Namespace: keycloakNamespace, Name: "mysql-cluster-secret"},
+		passwordKey:    mySQLRootKey,
+		passwordLength: 12,
+		dependents: []secretKey{
+			{NamespacedName: types.NamespacedName{Namespace: keycloakNamespace, Name: "mysql"}, key: "mysql-root-password"},
+		},
+		isEnabled:      vzcr.IsKeycloakEnabled,
+		changePassword: changeMySQLRootPassword,
+	},
+	vzapi.MySQLUserCredential: {
+		secret:         types.NamespacedName{Namespace: keycloakNamespace, Name: "mysql-cluster-secret"},
+		passwordKey:    mySQLUserKey,
+		passwordLength: 12,
+		dependents: []secretKey{
+			{NamespacedName: types.NamespacedName{Namespace: keycloakNamespace, Name: "mysql"}, key: "mysql-password"},
+		},
+		// Keycloak reads the database password at startup
+		restartConsumers: true,
+		isEnabled:        vzcr.IsKeycloakEnabled,
+		changePassword:   changeMySQLUserPassword,
+	},
+	vzapi.GrafanaAdminCredential: {
+		secret:         types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: constants.GrafanaSecret},
+		passwordKey:    "password",
+		passwordLength: 32,
+		// Grafana reads the admin password at startup
+		restartConsumers: true,
+		isEnabled:        vzcr.IsGrafanaEnabled,
+		changePassword:   changeGrafanaAdminPassword,
+	},
+}
+
+// rotate changes the password of a credential in the system that owns it, then updates the secrets and restarts the
+// workloads that depend on the credential. The new password is persisted before it is changed, so that the rotation
+// of a credential which failed after changing the password in the system converges when it is retried.
+func (cr *CredentialRotator) rotate(name vzapi.CredentialName, cred credential, secret *corev1.Secret) error {
+	oldPassword := string(secret.Data[cred.passwordKey])
+	if oldPassword == "" {
+		return fmt.Errorf("Secret %s/%s does not contain the %s key", secret.Namespace, secret.Name, cred.passwordKey)
+	}
+	newPassword, err := cr.getPendingPassword(name)
+	if err != nil {
+		return err
+	}
+	if newPassword == "" {
+		if newPassword, err = vzpassword.GeneratePassword(cred.passwordLength); err != nil {
+			return err
+		}
+		if err := cr.setPendingPassword(name, newPassword); err != nil {
+			return err
+		}
+	}
+
+	if err := cred.changePassword(cr, secret, oldPassword, newPassword); err != nil {
+		// A previous rotation may have changed the password in the system before failing to update the secrets
+		if oldPassword == newPassword || cred.changePassword(cr, secret, newPassword, newPassword) != nil {
+			return fmt.Errorf("%s", maskPasswords(err.Error(), oldPassword, newPassword))
+		}
+	}
+
+	keys := append([]secretKey{{NamespacedName: cred.secret, key: cred.passwordKey}}, cred.dependents...)
+	for _, key := range keys {
+		if err := cr.updateSecret(key, newPassword); err != nil {
+			return err
+		}
+	}
+	if err := cr.setPendingPassword(name, ""); err != nil {
+		return err
+	}
+
+	if cred.restartConsumers {
+		return restart.RestartComponents(cr.log, []string{cred.secret.Namespace}, getCurrentTime().Unix(), &restart.SecretPodMatcher{SecretName: cred.secret.Name})
+	}
+	return nil
+}
+
+// getPendingPasswords returns the new passwords of the credentials whose rotation has not completed
+func (cr *CredentialRotator) getPendingPasswords() (map[vzapi.CredentialName]string, error) {
+	secret := &corev1.Secret{}
+	if err := cr.client.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: rotationSecretName}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	pending := map[vzapi.CredentialName]string{}
+	for name, password := range secret.Data {
+		pending[vzapi.CredentialName(name)] = string(password)
+	}
+	return pending, nil
+}
+
+// getPendingPassword returns the new password of a credential whose rotation has not completed, or an empty string
+func (cr *CredentialRotator) getPendingPassword(name vzapi.CredentialName) (string, error) {
+	pending, err := cr.getPendingPasswords()
+	if err != nil {
+		return "", err
+	}
+	return pending[name], nil
+}
+
+// setPendingPassword persists the new password of a credential, an empty password removes it
+func (cr *CredentialRotator) setPendingPassword(name vzapi.CredentialName, password string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		err := cr.client.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoInstallNamespace, Name: rotationSecretName}, secret)
+		if errors.IsNotFound(err) {
+			if password == "" {
+				return nil
+			}
+			secret.Namespace = constants.VerrazzanoInstallNamespace
+			secret.Name = rotationSecretName
+			secret.Data = map[string][]byte{string(name): []byte(password)}
+			return cr.client.Create(context.TODO(), secret)
+		}
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if password == "" {
+			delete(secret.Data, string(name))
+		} else {
+			secret.Data[string(name)] = []byte(password)
+		}
+		return cr.client.Update(context.TODO(), secret)
+	})
+}
+
+// updateSecret sets the password in a secret, a dependent secret that does not exist is ignored
+func (cr *CredentialRotator) updateSecret(key secretKey, password string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		if err := cr.client.Get(context.TODO(), key.NamespacedName, secret); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[key.key] = []byte(password)
+		return cr.client.Update(context.TODO(), secret)
+	})
+}
+
+// changeKeycloakAdminPassword changes the password of the Keycloak admin user with the Keycloak admin API
+func changeKeycloakAdminPassword(_ *CredentialRotator, secret *corev1.Secret, oldPassword string, newPassword string) error {
+	username := string(secret.Data["username"])
+	cmd := fmt.Sprintf(keycloakSetAdminPasswordCommand, kcAdminScript, username)
+	pod := &corev1.Pod{}
+	pod.Namespace = keycloakNamespace
+	pod.Name = keycloakPodName
+	return execPod(pod, keycloakContainerName, cmd, oldPassword+"\n"+newPassword+"\n")
+}
+
+// changeMySQLRootPassword changes the password of the MySQL root user
+func changeMySQLRootPassword(_ *CredentialRotator, secret *corev1.Secret, oldPassword string, newPassword string) error {
+	rootHost := string(secret.Data[mySQLRootHostKey])
+	if rootHost == "" {
+		rootHost = "%"
+	}
+	return alterMySQLUser(oldPassword, "root", rootHost, newPassword)
+}
+
+// changeMySQLUserPassword changes the password of the MySQL user used by Keycloak
+func changeMySQLUserPassword(cr *CredentialRotator, secret *corev1.Secret, _ string, newPassword string) error {
+	username, err := cr.getKeycloakDBUser()
+	if err != nil {
+		return err
+	}
+	return alterMySQLUser(string(secret.Data[mySQLRootKey]), username, "%", newPassword)
+}
+
+// getKeycloakDBUser returns the MySQL user configured in the Keycloak StatefulSet
+func (cr *CredentialRotator) getKeycloakDBUser() (string, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := cr.client.Get(context.TODO(), types.NamespacedName{Namespace: keycloakNamespace, Name: keycloakStatefulSet}, sts); err != nil {
+		return "", err
+	}
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == keycloakDBUserEnvVar && env.Value != "" {
+				return env.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("StatefulSet %s/%s does not define the %s environment variable", sts.Namespace, sts.Name, keycloakDBUserEnvVar)
+}
+
+func alterMySQLUser(rootPassword string, user string, host string, newPassword string) error {
+	pod := &corev1.Pod{}
+	pod.Namespace = keycloakNamespace
+	pod.Name = mySQLPodName
+	statement := fmt.Sprintf(mySQLAlterUserStatement, quoteSQL(user), quoteSQL(host), quoteSQL(newPassword))
+	return execPod(pod, mySQLContainerName, mySQLCommand, rootPassword+"\n"+statement)
+}
+
+// quoteSQL escapes a value quoted in a SQL statement
+func quoteSQL(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(value)
+}
+
+// changeGrafanaAdminPassword changes the password of the Grafana admin user with the Grafana API
+func changeGrafanaAdminPassword(cr *CredentialRotator, secret *corev1.Secret, oldPassword string, newPassword string) error {
+	deployment := &appsv1.Deployment{}
+	if err := cr.client.Get(context.TODO(), types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: grafanaDeployment}, deployment); err != nil {
+		return err
+	}
+	pods := ready.GetPodsList(cr.log, cr.client, types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}, deployment.Spec.Selector)
+	if pods == nil {
+		return fmt.Errorf("Failed getting the pods of Deployment %s/%s", deployment.Namespace, deployment.Name)
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase == corev1.PodRunning {
+			username := string(secret.Data["username"])
+			body, err := json.Marshal(map[string]string{"oldPassword": oldPassword, "newPassword": newPassword, "confirmNew": newPassword})
+			if err != nil {
+				return err
+			}
+			curlConfig := fmt.Sprintf("user = %s\ndata = %s\n", quoteCurlConfig(username+":"+oldPassword), quoteCurlConfig(string(body)))
+			return execPod(&pods.Items[i], grafanaContainerName, grafanaSetAdminPasswordCommand, curlConfig)
+		}
+	}
+	return fmt.Errorf("No running pod found for Deployment %s/%s", deployment.Namespace, deployment.Name)
+}
+
+// quoteCurlConfig quotes a value of a curl configuration file
+func quoteCurlConfig(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// execPod runs a bash command in a pod container, passing it the standard input
+func execPod(pod *corev1.Pod, container string, cmd string, stdin string) error {
+	cfg, cli, err := k8sutil.ClientConfig()
+	if err != nil {
+		return err
+	}
+	stdout, stderr, err := k8sutil.ExecPodWithStdin(cli, cfg, pod, container, []string{"bash", "-c", cmd}, stdin)
+	if err != nil {
+		return fmt.Errorf("Failed running command in pod %s/%s: stdout = %s, stderr = %s, err = %v", pod.Namespace, pod.Name, stdout, stderr, err)
+	}
+	return nil
+}
+
+// maskPasswords replaces the passwords in a message
+func maskPasswords(message string, passwords ...string) string {
+	for _, pw := range passwords {
+		if pw != "" {
+			message = strings.ReplaceAll(message, pw, passwordMask)
+		}
+	}
+	return message
+}
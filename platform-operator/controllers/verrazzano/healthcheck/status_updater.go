@@ -23,15 +23,24 @@ type Updater interface {
 // UpdateEvent defines an event used during Verrazzano update. Event fields are merged into the Verrazzano
 // resource's status object.
 type UpdateEvent struct {
-	Verrazzano   *vzapi.Verrazzano // resource reference for test injection
-	Version      *string
-	State        vzapi.VzStateType
-	Conditions   []vzapi.Condition
-	Availability *AvailabilityStatus
-	InstanceInfo *vzapi.InstanceInfo
-	Components   map[string]*vzapi.ComponentStatusDetails
+	Verrazzano        *vzapi.Verrazzano // resource reference for test injection
+	Version           *string
+	State             vzapi.VzStateType
+	Conditions        []vzapi.Condition
+	Availability      *AvailabilityStatus
+	InstanceInfo      *vzapi.InstanceInfo
+	Components        map[string]*vzapi.ComponentStatusDetails
+	ComponentProgress map[string]string
+	AppRestart        *vzapi.ApplicationRestartStatus
+	Credentials       []vzapi.CredentialStatus
+	Remediations      []vzapi.RemediationRecord
+	Snapshots         *vzapi.OpenSearchSnapshotStatus
+	Thanos            *vzapi.ThanosStatus
 }
 
+// maxRemediationHistory is the number of remediation records kept in the Verrazzano status
+const maxRemediationHistory = 20
+
 // VerrazzanoStatusUpdater implement Updater for asynchronous status updates, using updateChannel to receive UpdateEvent objects
 // from other goroutines
 type VerrazzanoStatusUpdater struct {
@@ -135,13 +144,23 @@ func (u *UpdateEvent) merge(vz *vzapi.Verrazzano) {
 	if u.Conditions != nil {
 		vz.Status.Conditions = u.Conditions
 	}
-	// Add component status details
+	// Add component status details, nil details remove the status of a component that is no longer registered
 	for component, details := range u.Components {
+		if details == nil {
+			delete(vz.Status.Components, component)
+			continue
+		}
 		if vz.Status.Components == nil {
 			vz.Status.Components = map[string]*vzapi.ComponentStatusDetails{}
 		}
 		vz.Status.Components[component] = details
 	}
+	// Add the progress of component operations, without changing the rest of the component status details
+	for component, progress := range u.ComponentProgress {
+		if details, ok := vz.Status.Components[component]; ok && details != nil {
+			details.Progress = progress
+		}
+	}
 	// Add instance info
 	if u.InstanceInfo != nil {
 		vz.Status.VerrazzanoInstance = u.InstanceInfo
@@ -150,4 +169,30 @@ func (u *UpdateEvent) merge(vz *vzapi.Verrazzano) {
 	if u.Availability != nil {
 		u.Availability.merge(vz)
 	}
+	// Add application restart progress
+	if u.AppRestart != nil {
+		vz.Status.ApplicationRestart = u.AppRestart
+	}
+	// Add credential rotation state
+	if u.Credentials != nil {
+		vz.Status.Credentials = u.Credentials
+	}
+	// Add OpenSearch snapshot status, an empty status removes it
+	if u.Snapshots != nil {
+		vz.Status.OpenSearchSnapshots = u.Snapshots
+		if u.Snapshots.Repository == "" {
+			vz.Status.OpenSearchSnapshots = nil
+		}
+	}
+	// Add Thanos compactor status
+	if u.Thanos != nil {
+		vz.Status.Thanos = u.Thanos
+	}
+	// Append remediation records, keeping only the most recent ones
+	if len(u.Remediations) > 0 {
+		vz.Status.Remediations = append(vz.Status.Remediations, u.Remediations...)
+		if len(vz.Status.Remediations) > maxRemediationHistory {
+			vz.Status.Remediations = vz.Status.Remediations[len(vz.Status.Remediations)-maxRemediationHistory:]
+		}
+	}
 }
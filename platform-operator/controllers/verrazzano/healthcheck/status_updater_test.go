@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package healthcheck
@@ -131,6 +131,103 @@ func TestStatusUpdater(t *testing.T) {
 	})
 }
 
+// TestMergeAppRestart tests merging the application restart progress into the Verrazzano status
+// GIVEN an update event with application restart progress
+// WHEN the event is merged
+// THEN the application restart progress is set and the other status fields are not changed
+func TestMergeAppRestart(t *testing.T) {
+	vz := &vzapi.Verrazzano{Status: vzapi.VerrazzanoStatus{Version: "1.6.0"}}
+	restartStatus := &vzapi.ApplicationRestartStatus{RestartVersion: "upgrade-2", Total: 2, Restarted: 1, InProgress: []string{"hello/app2"}}
+	u := &UpdateEvent{AppRestart: restartStatus}
+	u.merge(vz)
+	assert.Equal(t, restartStatus, vz.Status.ApplicationRestart)
+	assert.Equal(t, "1.6.0", vz.Status.Version)
+}
+
+// TestMergeRemovedComponent tests removing the status of a component from the Verrazzano status
+// GIVEN an update event with nil status details for a component
+// WHEN the event is merged
+// THEN the status of the component is removed and the other components are not changed
+func TestMergeRemovedComponent(t *testing.T) {
+	vz := &vzapi.Verrazzano{Status: vzapi.VerrazzanoStatus{Components: vzapi.ComponentStatusMap{
+		"a": {Name: "a", State: vzapi.CompStateReady},
+		"b": {Name: "b", State: vzapi.CompStateReady},
+	}}}
+	u := &UpdateEvent{Components: map[string]*vzapi.ComponentStatusDetails{"a": nil}}
+	u.merge(vz)
+	assert.Len(t, vz.Status.Components, 1)
+	assert.Contains(t, vz.Status.Components, "b")
+}
+
+// TestMergeRemediations tests appending remediation records to the Verrazzano status
+// GIVEN an update event with remediation records and a full remediation history
+// WHEN the event is merged
+// THEN the records are appended and only the most recent records are kept
+func TestMergeRemediations(t *testing.T) {
+	vz := &vzapi.Verrazzano{}
+	for i := 0; i < maxRemediationHistory; i++ {
+		vz.Status.Remediations = append(vz.Status.Remediations, vzapi.RemediationRecord{Rule: "old", Attempt: i + 1})
+	}
+	u := &UpdateEvent{Remediations: []vzapi.RemediationRecord{{Rule: "new", Result: vzapi.RemediationSucceeded}}}
+	u.merge(vz)
+	assert.Len(t, vz.Status.Remediations, maxRemediationHistory)
+	assert.Equal(t, 2, vz.Status.Remediations[0].Attempt)
+	assert.Equal(t, "new", vz.Status.Remediations[maxRemediationHistory-1].Rule)
+}
+
+// TestMergeSnapshots tests merging the OpenSearch snapshot status into the Verrazzano status
+// GIVEN update events with a snapshot status, without snapshot status and with an empty snapshot status
+// WHEN the events are merged
+// THEN the snapshot status is replaced, kept, then removed
+func TestMergeSnapshots(t *testing.T) {
+	vz := &vzapi.Verrazzano{}
+	u := &UpdateEvent{Snapshots: &vzapi.OpenSearchSnapshotStatus{Repository: "backups", SnapshotCount: 2}}
+	u.merge(vz)
+	assert.Equal(t, 2, vz.Status.OpenSearchSnapshots.SnapshotCount)
+
+	u = &UpdateEvent{}
+	u.merge(vz)
+	assert.NotNil(t, vz.Status.OpenSearchSnapshots)
+
+	u = &UpdateEvent{Snapshots: &vzapi.OpenSearchSnapshotStatus{}}
+	u.merge(vz)
+	assert.Nil(t, vz.Status.OpenSearchSnapshots)
+}
+
+// TestMergeComponentProgress tests merging the progress of a component operation
+// GIVEN a Verrazzano resource with a component status
+// WHEN the progress of the component and of an unknown component is merged
+// THEN the progress of the component is set, without changing its state, and the unknown component is ignored
+func TestMergeComponentProgress(t *testing.T) {
+	vz := &vzapi.Verrazzano{Status: vzapi.VerrazzanoStatus{Components: vzapi.ComponentStatusMap{
+		"opensearch": {Name: "opensearch", State: vzapi.CompStateUpgrading},
+	}}}
+	u := &UpdateEvent{ComponentProgress: map[string]string{"opensearch": "Removing data node vmi-system-es-data-2", "unknown": "Ignored"}}
+	u.merge(vz)
+	assert.Equal(t, "Removing data node vmi-system-es-data-2", vz.Status.Components["opensearch"].Progress)
+	assert.Equal(t, vzapi.CompStateUpgrading, vz.Status.Components["opensearch"].State)
+	assert.Len(t, vz.Status.Components, 1)
+
+	u = &UpdateEvent{ComponentProgress: map[string]string{"opensearch": ""}}
+	u.merge(vz)
+	assert.Empty(t, vz.Status.Components["opensearch"].Progress)
+}
+
+// TestMergeThanosStatus tests merging the Thanos compactor status
+// GIVEN an update event with the Thanos compactor status
+// WHEN the event is merged into the Verrazzano status
+// THEN the Thanos status is set
+func TestMergeThanosStatus(t *testing.T) {
+	vz := &vzapi.Verrazzano{}
+	u := &UpdateEvent{Thanos: &vzapi.ThanosStatus{CompactorLastSuccessfulTime: "2023-04-01T06:00:00Z"}}
+	u.merge(vz)
+	assert.Equal(t, "2023-04-01T06:00:00Z", vz.Status.Thanos.CompactorLastSuccessfulTime)
+
+	u = &UpdateEvent{}
+	u.merge(vz)
+	assert.NotNil(t, vz.Status.Thanos)
+}
+
 func checkUpdate(t *testing.T, c client.Client, u *UpdateEvent) bool {
 	vz := &vzapi.Verrazzano{}
 	if err := c.Get(context.TODO(), types.NamespacedName{
@@ -6,13 +6,13 @@ package mysqlcheck
 import (
 	"context"
 	"fmt"
-	"time"
 
 	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
 	k8sready "github.com/verrazzano/verrazzano/pkg/k8s/ready"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysqloperator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/remediation"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,133 +31,47 @@ const (
 	mysqlRouterComponentName = "mysqlrouter"
 )
 
-var (
-	innoDBClusterGVK = schema.GroupVersionKind{
-		Group:   "mysql.oracle.com",
-		Version: "v2",
-		Kind:    "InnoDBCluster",
-	}
-
-	// The start of the timer for determining if an IC object is stuck terminating
-	initialTimeICUninstallChecked time.Time
-
-	// The start of the timer for determining if any MySQL pods are stuck terminating
-	initialTimeMySQLPodsStuckChecked time.Time
-
-	// The start of the timer for determining if any MySQL pods are waiting for readiness gates
-	initialTimeReadinessGateChecked time.Time
-)
-
-// resetInitialTimeICUninstallChecked allocates an empty time struct
-func resetInitialTimeICUninstallChecked() {
-	initialTimeICUninstallChecked = time.Time{}
-}
-
-// getInitialTimeICUninstallChecked returns the time struct
-func getInitialTimeICUninstallChecked() time.Time {
-	return initialTimeICUninstallChecked
-}
-
-// setInitialTimeICUninstallChecked sets the time struct
-func setInitialTimeICUninstallChecked(time time.Time) {
-	initialTimeICUninstallChecked = time
-}
-
-// resetInitialTimeMySQLPodsStuckChecked allocates an empty time struct
-func resetInitialTimeMySQLPodsStuckChecked() {
-	initialTimeMySQLPodsStuckChecked = time.Time{}
-}
-
-// getInitialTimeMySQLPodsStuckChecked returns the time struct
-func getInitialTimeMySQLPodsStuckChecked() time.Time {
-	return initialTimeMySQLPodsStuckChecked
-}
-
-// setInitialTimeMySQLPodsStuckChecked sets the time struct
-func setInitialTimeMySQLPodsStuckChecked(time time.Time) {
-	initialTimeMySQLPodsStuckChecked = time
-}
-
-// getLastTimeReadinessGateChecked returns the time struct
-func getLastTimeReadinessGateChecked() time.Time {
-	return initialTimeReadinessGateChecked
-}
-
-// setInitialTimeReadinessGateChecked sets the time struct
-func setInitialTimeReadinessGateChecked(time time.Time) {
-	initialTimeReadinessGateChecked = time
-}
-
-// resetInitialTimeReadinessGateChecked sets the time struct
-func resetInitialTimeReadinessGateChecked() {
-	initialTimeReadinessGateChecked = time.Time{}
+var innoDBClusterGVK = schema.GroupVersionKind{
+	Group:   "mysql.oracle.com",
+	Version: "v2",
+	Kind:    "InnoDBCluster",
 }
 
 // RepairICStuckDeleting - temporary workaround to repair issue where a InnoDBCluster object
-// can be stuck terminating (e.g. during uninstall).  The workaround is to recycle the mysql-operator.
+// can be stuck terminating (e.g. during uninstall).  The workaround is to recycle the mysql-operator,
+// which is done by the remediation engine once the InnoDBCluster has been stuck for the repair timeout.
 func RepairICStuckDeleting(ctx spi.ComponentContext) error {
-	// Get the IC object
-	innoDBCluster, err := getInnoDBCluster(ctx)
+	var stuck bool
+	var err error
+	if engine := remediation.GetEngine(); engine != nil {
+		stuck, err = engine.Check(ICStuckDeletingRule)
+	} else {
+		stuck, err = isICStuckDeleting(ctx.Log(), ctx.Client())
+	}
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil
-		}
 		return err
 	}
-	if innoDBCluster.GetDeletionTimestamp() == nil {
-		resetInitialTimeICUninstallChecked()
-		return nil
-	}
-
-	// Found an IC object with a deletion timestamp. Start a timer if this is the first time.
-	if getInitialTimeICUninstallChecked().IsZero() {
-		setInitialTimeICUninstallChecked(time.Now())
-		ctx.Log().Progressf("Starting check to insure the InnoDBCluster %s/%s is not stuck deleting", componentNamespace, helmReleaseName)
+	if stuck {
+		ctx.Log().Progressf("Waiting for InnoDBCluster %s/%s to be deleted", componentNamespace, helmReleaseName)
 		return ctrlerrors.RetryableError{}
 	}
-
-	// Initiate repair only if time to wait period has been exceeded
-	expiredTime := getInitialTimeICUninstallChecked().Add(GetMySQLChecker().RepairTimeout)
-	if time.Now().After(expiredTime) {
-		return restartMySQLOperator(ctx.Log(), ctx.Client(), "InnoDBCluster stuck deleting")
-	}
-
-	ctx.Log().Progressf("Waiting for InnoDBCluster %s/%s to be deleted", componentNamespace, helmReleaseName)
-
-	return ctrlerrors.RetryableError{}
+	return nil
 }
 
-// RepairMySQLPodsWaitingReadinessGates - temporary workaround to repair issue were a MySQL pod
-// can be stuck waiting for its readiness gates to be met.  The workaround is to recycle the mysql-operator.
-func (mc *MySQLChecker) RepairMySQLPodsWaitingReadinessGates() error {
-	podsWaiting, err := isPodsWaitingForReadinessGates(mc.log, mc.client)
+// isICStuckDeleting - return boolean indicating if the InnoDBCluster object is being deleted
+func isICStuckDeleting(_ vzlog.VerrazzanoLogger, client clipkg.Client) (bool, error) {
+	innoDBCluster, err := getInnoDBCluster(client)
 	if err != nil {
-		return err
-	}
-	if podsWaiting {
-		mc.log.Progressf("MySQL pods are waiting for readiness gates")
-
-		// Start a timer the first time pods are waiting for readiness gates
-		if getLastTimeReadinessGateChecked().IsZero() {
-			mc.log.Infof("Resetting initial MySQL pod readiness check internal timer to 0")
-			setInitialTimeReadinessGateChecked(time.Now())
-			return nil
-		}
-
-		// Initiate repair only if time to wait period has been exceeded
-		expiredTime := getLastTimeReadinessGateChecked().Add(mc.RepairTimeout)
-		if time.Now().After(expiredTime) {
-			mc.log.Infof("Time to restart MySQL Operator to fix the stuck readiness gates")
-			return restartMySQLOperator(mc.log, mc.client, "MySQL pods waiting for readiness gates")
+		if errors.IsNotFound(err) {
+			return false, nil
 		}
-		return nil
+		return false, err
 	}
-
-	// Clear the timer when no pods are waiting
-	resetInitialTimeReadinessGateChecked()
-	return nil
+	return innoDBCluster.GetDeletionTimestamp() != nil, nil
 }
 
+// isPodsWaitingForReadinessGates - return boolean indicating if any MySQL pods are waiting for their readiness
+// gates to be met.  A MySQL pod can be stuck waiting, the workaround is to recycle the mysql-operator.
 func isPodsWaitingForReadinessGates(log vzlog.VerrazzanoLogger, client clipkg.Client) (bool, error) {
 	log.Debug("Checking if MySQL pods waiting for readiness gates")
 
@@ -208,88 +122,73 @@ func getMySQLOperatorPod(log vzlog.VerrazzanoLogger, client clipkg.Client) (*v1.
 }
 
 // getInnoDBCluster - get the InnoDBCluster object
-func getInnoDBCluster(ctx spi.ComponentContext) (*unstructured.Unstructured, error) {
+func getInnoDBCluster(client clipkg.Client) (*unstructured.Unstructured, error) {
 	innoDBCluster := unstructured.Unstructured{}
 	innoDBCluster.SetGroupVersionKind(innoDBClusterGVK)
 
 	// The InnoDBCluster resource name is the helm release name
 	nsn := types.NamespacedName{Namespace: componentNamespace, Name: helmReleaseName}
-	if err := ctx.Client().Get(context.Background(), nsn, &innoDBCluster); err != nil {
+	if err := client.Get(context.Background(), nsn, &innoDBCluster); err != nil {
 		return nil, err
 	}
 	return &innoDBCluster, nil
 }
 
-// RepairMySQLPodStuckDeleting - temporary workaround to repair issue where a MySQL pod
-// can be stuck terminating (e.g. during uninstall).  The workaround is to recycle the mysql-operator.
-func (mc *MySQLChecker) RepairMySQLPodStuckDeleting() error {
-	// Check if any MySQL pods are in the process of terminating
+// isPodsStuckDeleting - return boolean indicating if any MySQL pods are terminating.  A MySQL pod
+// can be stuck terminating (e.g. during uninstall), the workaround is to recycle the mysql-operator.
+func isPodsStuckDeleting(log vzlog.VerrazzanoLogger, client clipkg.Client) (bool, error) {
 	selector := metav1.LabelSelectorRequirement{Key: mySQLComponentLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{mySQLDComponentName}}
-	podList := k8sready.GetPodsList(mc.log, mc.client, types.NamespacedName{Namespace: componentNamespace}, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{selector}})
+	podList := k8sready.GetPodsList(log, client, types.NamespacedName{Namespace: componentNamespace}, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{selector}})
 	if podList == nil || len(podList.Items) == 0 {
 		// No MySQL pods found, assume they have finished deleting
-		resetInitialTimeMySQLPodsStuckChecked()
-		return nil
+		return false, nil
 	}
 
-	foundPodsDeleting := false
 	for i := range podList.Items {
-		pod := podList.Items[i]
-		if !pod.GetDeletionTimestamp().IsZero() {
-			foundPodsDeleting = true
-			break
-		}
-	}
-
-	if foundPodsDeleting {
-		// First time through start a timer
-		if getInitialTimeMySQLPodsStuckChecked().IsZero() {
-			setInitialTimeMySQLPodsStuckChecked(time.Now())
-			mc.log.Progressf("Waiting for MySQL pods to terminate in namespace %s", componentNamespace)
-			return nil
-		}
-
-		// Initiate repair only if time to wait period has been exceeded
-		expiredTime := getInitialTimeMySQLPodsStuckChecked().Add(mc.RepairTimeout)
-		if time.Now().After(expiredTime) {
-			if err := restartMySQLOperator(mc.log, mc.client, "MySQL pods stuck terminating"); err != nil {
-				return err
-			}
-		} else {
-			// Keep trying until no pods deleting or timer expires
-			return nil
+		if !podList.Items[i].GetDeletionTimestamp().IsZero() {
+			log.Progressf("Waiting for MySQL pods to terminate in namespace %s", componentNamespace)
+			return true, nil
 		}
 	}
-
-	// Clear the timer
-	resetInitialTimeMySQLPodsStuckChecked()
-	return nil
+	return false, nil
 }
 
-// RepairMySQLRouterPodsCrashLoopBackoff - repair mysql-router pods stuck in CrashLoopBackoff.
-// The workaround is to delete the pod.
-func (mc *MySQLChecker) RepairMySQLRouterPodsCrashLoopBackoff() error {
+// getRouterPodsCrashLoopBackOff - return the mysql-router pods stuck in CrashLoopBackOff
+func getRouterPodsCrashLoopBackOff(log vzlog.VerrazzanoLogger, client clipkg.Client) []v1.Pod {
 	selector := metav1.LabelSelectorRequirement{Key: mySQLComponentLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{mysqlRouterComponentName}}
-	podList := k8sready.GetPodsList(mc.log, mc.client, types.NamespacedName{Namespace: componentNamespace}, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{selector}})
-	if podList == nil || len(podList.Items) == 0 {
-		// No MySQL pods found
+	podList := k8sready.GetPodsList(log, client, types.NamespacedName{Namespace: componentNamespace}, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{selector}})
+	if podList == nil {
 		return nil
 	}
 
+	var pods []v1.Pod
 	for i := range podList.Items {
-		pod := podList.Items[i]
-		for _, container := range pod.Status.ContainerStatuses {
-			if waiting := container.State.Waiting; waiting != nil {
-				if waiting.Reason == "CrashLoopBackOff" {
-					// Terminate the pod
-					mc.log.Infof("Terminating pod %s/%s because it was stuck in CrashLoopBackOff", pod.Namespace, pod.Name)
-					if err := mc.client.Delete(context.TODO(), &pod, &clipkg.DeleteOptions{}); err != nil {
-						return err
-					}
-				}
+		for _, container := range podList.Items[i].Status.ContainerStatuses {
+			if waiting := container.State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" {
+				pods = append(pods, podList.Items[i])
+				break
 			}
 		}
 	}
+	return pods
+}
+
+// isRouterPodsCrashLoopBackOff - return boolean indicating if any mysql-router pods are stuck in CrashLoopBackOff
+func isRouterPodsCrashLoopBackOff(log vzlog.VerrazzanoLogger, client clipkg.Client) (bool, error) {
+	return len(getRouterPodsCrashLoopBackOff(log, client)) > 0, nil
+}
+
+// deleteRouterPodsCrashLoopBackOff - repair mysql-router pods stuck in CrashLoopBackOff.
+// The workaround is to delete the pods.
+func deleteRouterPodsCrashLoopBackOff(log vzlog.VerrazzanoLogger, client clipkg.Client) error {
+	pods := getRouterPodsCrashLoopBackOff(log, client)
+	for i := range pods {
+		// Terminate the pod
+		log.Infof("Terminating pod %s/%s because it was stuck in CrashLoopBackOff", pods[i].Namespace, pods[i].Name)
+		if err := client.Delete(context.TODO(), &pods[i], &clipkg.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -302,14 +201,5 @@ func restartMySQLOperator(log vzlog.VerrazzanoLogger, client clipkg.Client, reas
 		return fmt.Errorf("Failed restarting the mysql-operator to repair MySQL pods stuck deleting: %v", err)
 	}
 
-	if err = client.Delete(context.TODO(), operPod, &clipkg.DeleteOptions{}); err != nil {
-		return err
-	}
-
-	// Reset all timers that have workarounds of restarting the MySQL operator.
-	resetInitialTimeMySQLPodsStuckChecked()
-	resetInitialTimeReadinessGateChecked()
-	resetInitialTimeICUninstallChecked()
-
-	return nil
+	return client.Delete(context.TODO(), operPod, &clipkg.DeleteOptions{})
 }
@@ -5,13 +5,15 @@ package mysqlcheck
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	ctrlerrors "github.com/verrazzano/verrazzano/pkg/controller/errors"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/mysqloperator"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/remediation"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +21,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	k8scheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -35,6 +39,7 @@ const (
 
 func init() {
 	_ = k8scheme.AddToScheme(testScheme)
+	_ = vzapi.AddToScheme(testScheme)
 }
 
 // TestRepairMySQLPodsWaitingReadinessGates tests the temporary workaround for MySQL
@@ -43,16 +48,6 @@ func init() {
 // WHEN they are not all ready after a given time period
 // THEN recycle the mysql-operator
 func TestRepairMySQLPodsWaitingReadinessGates(t *testing.T) {
-	mySQLOperatorPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      mysqloperator.ComponentName,
-			Namespace: mysqloperator.ComponentNamespace,
-			Labels: map[string]string{
-				"name": mysqloperator.ComponentName,
-			},
-		},
-	}
-
 	mySQLPod := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "mysql-0",
@@ -72,43 +67,28 @@ func TestRepairMySQLPodsWaitingReadinessGates(t *testing.T) {
 		},
 	}
 
-	// Set up the initial context
-	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLPod, mySQLOperatorPod).Build()
-	fakeCtx := spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err := NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-	assert.True(t, getLastTimeReadinessGateChecked().IsZero())
-
-	// Timer should remain zero when all conditions are true. Expect no error and mysql-operator pod to still exist.
-	err = mysqlCheck.RepairMySQLPodsWaitingReadinessGates()
-	assert.NoError(t, err)
-	assert.True(t, getLastTimeReadinessGateChecked().IsZero())
-	pod := v1.Pod{}
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
-	assert.NoError(t, err)
+	// All conditions are true. Expect no repair and the mysql-operator pod to still exist.
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), mySQLPod, newMySQLOperatorPod()).Build()
+	engine, records := newTestEngine(t, cli, timeoutDuration)
+	engine.EvaluateRules()
+	assert.True(t, mySQLOperatorPodExists(t, cli))
+	assert.Empty(t, *records)
 
-	// Set one of the conditions to false
+	// Set one of the conditions to false, expect the repair to wait for the repair timeout
 	mySQLPod.Status.Conditions = []v1.PodCondition{{Type: "gate1", Status: v1.ConditionTrue}, {Type: "gate2", Status: v1.ConditionFalse}}
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLPod, mySQLOperatorPod).Build()
-	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err = NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-
-	// Expect timer to get started when ond of the conditions is not met.  The mysql-operator pod should still exist.
-	err = mysqlCheck.RepairMySQLPodsWaitingReadinessGates()
-	assert.NoError(t, err)
-	assert.False(t, getLastTimeReadinessGateChecked().IsZero())
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
-	assert.NoError(t, err)
-
-	// Set the last time readiness gate checked to exceed the RepairTimeout period.  Expect the mysql-operator to get recycled.
-	setInitialTimeReadinessGateChecked(time.Now().Add(-time.Hour * 2))
-	err = mysqlCheck.RepairMySQLPodsWaitingReadinessGates()
-	assert.NoError(t, err, fmt.Sprintf("unexpected error: %v", err))
-	assert.True(t, getLastTimeReadinessGateChecked().IsZero())
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
-	assert.Error(t, err)
-	assert.True(t, errors.IsNotFound(err))
+	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), mySQLPod, newMySQLOperatorPod()).Build()
+	engine, records = newTestEngine(t, cli, timeoutDuration)
+	engine.EvaluateRules()
+	assert.True(t, mySQLOperatorPodExists(t, cli))
+	assert.Empty(t, *records)
+
+	// Once the repair timeout has elapsed, expect the mysql-operator to get recycled
+	engine, records = newTestEngine(t, cli, 0)
+	engine.EvaluateRules()
+	assert.False(t, mySQLOperatorPodExists(t, cli))
+	assert.Len(t, *records, 1)
+	assert.Equal(t, PodsWaitingReadinessGatesRule, (*records)[0].Rule)
+	assert.Equal(t, vzapi.RemediationSucceeded, (*records)[0].Result)
 }
 
 // TestRepairICStuckDeleting tests the temporary workaround for MySQL
@@ -117,62 +97,44 @@ func TestRepairMySQLPodsWaitingReadinessGates(t *testing.T) {
 // WHEN not deleted after the expected timer expires
 // THEN recycle the mysql-operator
 func TestRepairICStuckDeleting(t *testing.T) {
-	mySQLOperatorPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      mysqloperator.ComponentName,
-			Namespace: mysqloperator.ComponentNamespace,
-			Labels: map[string]string{
-				"name": mysqloperator.ComponentName,
-			},
-		},
-	}
-
-	// Test without a deletion timestamp, the timer should not get initialized
+	// Test without a deletion timestamp, no error is expected
 	innoDBCluster := newInnoDBCluster(innoDBClusterStatusOnline)
-	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLOperatorPod, innoDBCluster).Build()
-	resetInitialTimeICUninstallChecked()
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod(), innoDBCluster).Build()
+	newTestEngine(t, cli, timeoutDuration)
 	fakeCtx := spi.NewFakeContext(cli, nil, nil, false)
 
 	err := RepairICStuckDeleting(fakeCtx)
 	assert.NoError(t, err)
-	assert.True(t, getInitialTimeICUninstallChecked().IsZero())
 
-	// Test first time calling with a deletion timestamp, the timer should get initialized
+	// Test calling with a deletion timestamp before the repair timeout, expect a retryable error
 	// and the mysql-operator pod should not get deleted.
 	innoDBCluster = newInnoDBCluster(innoDBClusterStatusOnline)
 	startTime := metav1.Now()
 	innoDBCluster.SetDeletionTimestamp(&startTime)
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLOperatorPod, innoDBCluster).Build()
+	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod(), innoDBCluster).Build()
+	newTestEngine(t, cli, timeoutDuration)
 	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
 
-	assert.True(t, getInitialTimeICUninstallChecked().IsZero())
-	err = RepairICStuckDeleting(fakeCtx)
-	assert.Error(t, err)
-	assert.False(t, getInitialTimeICUninstallChecked().IsZero())
-
-	pod := v1.Pod{}
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
-	assert.NoError(t, err, "expected the mysql-operator pod to be found")
-
-	// Call repair after the timer has started, but not expired.  Expect an error because the IC object is not deleted yet.
 	err = RepairICStuckDeleting(fakeCtx)
 	assert.Error(t, err)
+	assert.True(t, ctrlerrors.IsRetryableError(err))
+	assert.True(t, mySQLOperatorPodExists(t, cli), "expected the mysql-operator pod to be found")
 
-	// Force the timer to be expired, expect the mysql-operator pod to be deleted
-	setInitialTimeICUninstallChecked(time.Now().Add(-time.Hour * 2))
+	// Once the repair timeout has elapsed, expect the mysql-operator pod to be deleted, and a retryable
+	// error because the IC object is not deleted yet
+	_, records := newTestEngine(t, cli, 0)
 	err = RepairICStuckDeleting(fakeCtx)
-	assert.NoError(t, err)
-
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
 	assert.Error(t, err)
-	assert.True(t, errors.IsNotFound(err))
+	assert.False(t, mySQLOperatorPodExists(t, cli))
+	assert.Len(t, *records, 1)
+	assert.Equal(t, ICStuckDeletingRule, (*records)[0].Rule)
 
 	// If the IC object is already deleted, then no error should be returned
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLOperatorPod).Build()
+	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod()).Build()
+	newTestEngine(t, cli, timeoutDuration)
 	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
 	err = RepairICStuckDeleting(fakeCtx)
 	assert.NoError(t, err)
-
 }
 
 // TestRepairMySQLPodsStuckTerminating tests the temporary workaround for MySQL
@@ -181,16 +143,6 @@ func TestRepairICStuckDeleting(t *testing.T) {
 // WHEN still deleting after the expiration time period
 // THEN recycle the mysql-operator
 func TestRepairMySQLPodsStuckTerminating(t *testing.T) {
-	mySQLOperatorPod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      mysqloperator.ComponentName,
-			Namespace: mysqloperator.ComponentNamespace,
-			Labels: map[string]string{
-				"name": mysqloperator.ComponentName,
-			},
-		},
-	}
-
 	mySQLPod0 := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "mysql-0",
@@ -223,54 +175,33 @@ func TestRepairMySQLPodsStuckTerminating(t *testing.T) {
 		},
 	}
 
-	// Call with no MySQL pods being deleted, expect success
-	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLPod0, mySQLPod1).Build()
-	resetInitialTimeMySQLPodsStuckChecked()
-	fakeCtx := spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err := NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-
-	err = mysqlCheck.RepairMySQLPodStuckDeleting()
-	assert.NoError(t, err)
-	assert.True(t, getInitialTimeMySQLPodsStuckChecked().IsZero())
-
-	// Call with MySQL pods being deleted, first time expect timer to start
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLPod0, mySQLPod1, mySQLPod2).Build()
-	resetInitialTimeMySQLPodsStuckChecked()
-	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err = NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-
-	err = mysqlCheck.RepairMySQLPodStuckDeleting()
-	assert.NoError(t, err)
-	assert.False(t, getInitialTimeMySQLPodsStuckChecked().IsZero())
-
-	// Call with MySQL pods being deleted and timer expired, expect mysql-operator pod to be deleted
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLOperatorPod, mySQLPod0, mySQLPod1, mySQLPod2).Build()
-	setInitialTimeMySQLPodsStuckChecked(time.Now().Add(-time.Hour * 2))
-	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err = NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-
-	err = mysqlCheck.RepairMySQLPodStuckDeleting()
-	assert.NoError(t, err)
-	assert.True(t, getInitialTimeMySQLPodsStuckChecked().IsZero())
-
-	pod := v1.Pod{}
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
-	assert.Error(t, err)
-	assert.True(t, errors.IsNotFound(err))
-
-	// Call with no MySQL pods, expect success
-	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects().Build()
-	resetInitialTimeMySQLPodsStuckChecked()
-	fakeCtx = spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err = NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-
-	err = mysqlCheck.RepairMySQLPodStuckDeleting()
-	assert.NoError(t, err)
-	assert.True(t, getInitialTimeMySQLPodsStuckChecked().IsZero())
+	// Call with no MySQL pods being deleted, expect no repair
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod(), mySQLPod0, mySQLPod1).Build()
+	engine, records := newTestEngine(t, cli, 0)
+	engine.EvaluateRules()
+	assert.True(t, mySQLOperatorPodExists(t, cli))
+	assert.Empty(t, *records)
+
+	// Call with MySQL pods being deleted before the repair timeout, expect no repair
+	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod(), mySQLPod0, mySQLPod1, mySQLPod2).Build()
+	engine, records = newTestEngine(t, cli, timeoutDuration)
+	engine.EvaluateRules()
+	assert.True(t, mySQLOperatorPodExists(t, cli))
+	assert.Empty(t, *records)
+
+	// Call with MySQL pods being deleted after the repair timeout, expect mysql-operator pod to be deleted
+	engine, records = newTestEngine(t, cli, 0)
+	engine.EvaluateRules()
+	assert.False(t, mySQLOperatorPodExists(t, cli))
+	assert.Len(t, *records, 1)
+	assert.Equal(t, PodsStuckDeletingRule, (*records)[0].Rule)
+
+	// Call with no MySQL pods, expect no repair
+	cli = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), newMySQLOperatorPod()).Build()
+	engine, records = newTestEngine(t, cli, 0)
+	engine.EvaluateRules()
+	assert.True(t, mySQLOperatorPodExists(t, cli))
+	assert.Empty(t, *records)
 }
 
 func newInnoDBCluster(status string) *unstructured.Unstructured {
@@ -310,16 +241,52 @@ func TestRepairMySQLRouterPodsCrashLoopBackoff(t *testing.T) {
 		},
 	}
 
-	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(mySQLRouterPod).Build()
-	fakeCtx := spi.NewFakeContext(cli, nil, nil, false)
-	mysqlCheck, err := NewMySQLChecker(fakeCtx.Client(), checkPeriodDuration, timeoutDuration)
-	assert.NoError(t, err)
-	err = mysqlCheck.RepairMySQLRouterPodsCrashLoopBackoff()
-	assert.NoError(t, err)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newVerrazzano(), mySQLRouterPod).Build()
+	engine, records := newTestEngine(t, cli, timeoutDuration)
+	engine.EvaluateRules()
 
 	pod := v1.Pod{}
-	err = cli.Get(context.TODO(), types.NamespacedName{Namespace: componentNamespace, Name: routerName}, &pod)
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: componentNamespace, Name: routerName}, &pod)
 	assert.Error(t, err)
 	assert.True(t, errors.IsNotFound(err))
+	assert.Len(t, *records, 1)
+	assert.Equal(t, RouterPodsCrashLoopBackOffRule, (*records)[0].Rule)
+}
+
+func newMySQLOperatorPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mysqloperator.ComponentName,
+			Namespace: mysqloperator.ComponentNamespace,
+			Labels: map[string]string{
+				"name": mysqloperator.ComponentName,
+			},
+		},
+	}
+}
+
+func newVerrazzano() *vzapi.Verrazzano {
+	return &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+}
+
+func mySQLOperatorPodExists(t *testing.T, cli clipkg.Client) bool {
+	pod := v1.Pod{}
+	err := cli.Get(context.TODO(), types.NamespacedName{Namespace: mysqloperator.ComponentNamespace, Name: mysqloperator.ComponentName}, &pod)
+	if errors.IsNotFound(err) {
+		return false
+	}
+	assert.NoError(t, err)
+	return true
+}
 
+// newTestEngine registers the MySQL rules and returns a remediation engine recording the remediation actions in a slice
+func newTestEngine(t *testing.T, cli clipkg.Client, repairTimeout time.Duration) (*remediation.Engine, *[]vzapi.RemediationRecord) {
+	RegisterRules(repairTimeout)
+	records := &[]vzapi.RemediationRecord{}
+	recordStatus := func(vz *vzapi.Verrazzano, record vzapi.RemediationRecord) {
+		*records = append(*records, record)
+	}
+	engine, err := remediation.NewEngine(cli, record.NewFakeRecorder(10), recordStatus, checkPeriodDuration)
+	assert.NoError(t, err)
+	return engine, records
 }
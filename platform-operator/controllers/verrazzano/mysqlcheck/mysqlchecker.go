@@ -7,98 +7,77 @@ import (
 	"time"
 
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
-	"go.uber.org/zap"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/remediation"
 	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	controllerName    = "MySQLChecker"
-	channelBufferSize = 100
-)
-
-// mySQLChecker - holds global instance of MySQLChecker.  Required by MySQL workaround
-// functions that don't have access to the MySQLChecker context.
-var mySQLChecker *MySQLChecker
-
-// MySQLChecker periodically checks the state of MySQL related pods and repairs
-// any that are found to be in a stuck state (e.g. terminating, waiting for readiness gates).
-type MySQLChecker struct {
-	client        clipkg.Client
-	tickTime      time.Duration
-	RepairTimeout time.Duration
-	log           vzlog.VerrazzanoLogger
-	shutdown      chan int // The channel on which shutdown signals are sent/received
-}
+	// ICStuckDeletingRule is the name of the rule repairing an InnoDBCluster stuck deleting
+	ICStuckDeletingRule = "mysql-innodbcluster-stuck-deleting"
+	// PodsStuckDeletingRule is the name of the rule repairing MySQL pods stuck terminating
+	PodsStuckDeletingRule = "mysql-pods-stuck-deleting"
+	// PodsWaitingReadinessGatesRule is the name of the rule repairing MySQL pods waiting for readiness gates
+	PodsWaitingReadinessGatesRule = "mysql-pods-waiting-readiness-gates"
+	// RouterPodsCrashLoopBackOffRule is the name of the rule repairing mysql-router pods stuck in CrashLoopBackOff
+	RouterPodsCrashLoopBackOffRule = "mysql-router-pods-crashloopbackoff"
 
-// NewMySQLChecker - instantiate a MySQLChecker context
-func NewMySQLChecker(c clipkg.Client, tick time.Duration, timeout time.Duration) (*MySQLChecker, error) {
-	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
-		Name:           componentName,
-		Namespace:      componentNamespace,
-		ID:             controllerName,
-		Generation:     0,
-		ControllerName: controllerName,
-	})
-	if err != nil {
-		zap.S().Errorf("Failed to create resource logger for %s: %v", controllerName, err)
-		return nil, err
-	}
-
-	mySQLChecker = &MySQLChecker{
-		client:        c,
-		tickTime:      tick,
-		RepairTimeout: timeout,
-		log:           log,
-	}
-	return mySQLChecker, nil
-}
+	// mySQLOperatorRestartGroup groups the rules that are repaired by restarting the mysql-operator
+	mySQLOperatorRestartGroup = "mysql-operator-restart"
 
-// GetMySQLChecker - returns the value of mySQLChecker
-func GetMySQLChecker() *MySQLChecker {
-	return mySQLChecker
-}
+	// maxRepairAttempts is the maximum number of times the mysql-operator is restarted for a persisting problem
+	maxRepairAttempts = 5
 
-// Start starts the MySQLChecker if it is not already running.
-// It is safe to call Start multiple times, additional goroutines will not be created
-func (mc *MySQLChecker) Start() {
-	if mc.shutdown != nil {
-		// already running, so nothing to do
-		return
-	}
-	mc.shutdown = make(chan int, channelBufferSize)
+	// routerRepairBackoff is the minimum time between two deletions of mysql-router pods stuck in CrashLoopBackOff
+	routerRepairBackoff = time.Minute
+)
 
-	// goroutine updates availability every p.tickTime. If a shutdown signal is received (or channel is closed),
-	// the goroutine returns.
-	go func() {
-		var err error
-		ticker := time.NewTicker(mc.tickTime)
-		for {
-			select {
-			case <-ticker.C:
-				// timer event causes MySQL checks
-				if err = mc.RepairMySQLPodStuckDeleting(); err != nil {
-					mc.log.ErrorfThrottled("Failed to repair MySQL pods stuck terminating: %v", err)
-				}
-				if err = mc.RepairMySQLPodsWaitingReadinessGates(); err != nil {
-					mc.log.ErrorfThrottled("Failed to repair MySQL pods waiting for readiness gates: %v", err)
-				}
-				if err = mc.RepairMySQLRouterPodsCrashLoopBackoff(); err != nil {
-					mc.log.ErrorfThrottled("Failed to repair mysql-router pods stuck in CrashLoopBackoff: %v", err)
-				}
-			case <-mc.shutdown:
-				// shutdown event causes termination
-				ticker.Stop()
-				return
-			}
-		}
-	}()
+// RegisterRules registers the MySQL remediation rules. A problem repaired by restarting the mysql-operator must
+// persist for the repair timeout before it is repaired, and for the repair timeout between two repairs.
+func RegisterRules(repairTimeout time.Duration) {
+	remediation.RegisterRule(remediation.Rule{
+		Name:        ICStuckDeletingRule,
+		Component:   componentName,
+		Group:       mySQLOperatorRestartGroup,
+		Detect:      isICStuckDeleting,
+		Repair:      newMySQLOperatorRestart("InnoDBCluster stuck deleting"),
+		GracePeriod: repairTimeout,
+		MaxAttempts: maxRepairAttempts,
+		Backoff:     repairTimeout,
+		// The InnoDBCluster is checked by the MySQL component during uninstall
+		OnDemand: true,
+	})
+	remediation.RegisterRule(remediation.Rule{
+		Name:        PodsStuckDeletingRule,
+		Component:   componentName,
+		Group:       mySQLOperatorRestartGroup,
+		Detect:      isPodsStuckDeleting,
+		Repair:      newMySQLOperatorRestart("MySQL pods stuck terminating"),
+		GracePeriod: repairTimeout,
+		MaxAttempts: maxRepairAttempts,
+		Backoff:     repairTimeout,
+	})
+	remediation.RegisterRule(remediation.Rule{
+		Name:        PodsWaitingReadinessGatesRule,
+		Component:   componentName,
+		Group:       mySQLOperatorRestartGroup,
+		Detect:      isPodsWaitingForReadinessGates,
+		Repair:      newMySQLOperatorRestart("MySQL pods waiting for readiness gates"),
+		GracePeriod: repairTimeout,
+		MaxAttempts: maxRepairAttempts,
+		Backoff:     repairTimeout,
+	})
+	remediation.RegisterRule(remediation.Rule{
+		Name:      RouterPodsCrashLoopBackOffRule,
+		Component: componentName,
+		Detect:    isRouterPodsCrashLoopBackOff,
+		Repair:    deleteRouterPodsCrashLoopBackOff,
+		Backoff:   routerRepairBackoff,
+	})
 }
 
-// Pause pauses the MySQLChecker if it was running.
-// It is safe to call Pause multiple times
-func (mc *MySQLChecker) Pause() {
-	if mc.shutdown != nil {
-		close(mc.shutdown)
-		mc.shutdown = nil
+// newMySQLOperatorRestart returns a repair restarting the mysql-operator
+func newMySQLOperatorRestart(reason string) func(log vzlog.VerrazzanoLogger, client clipkg.Client) error {
+	return func(log vzlog.VerrazzanoLogger, client clipkg.Client) error {
+		return restartMySQLOperator(log, client, reason)
 	}
 }
@@ -5,27 +5,28 @@ package mysqlcheck
 
 import (
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/remediation"
 )
 
-func TestStart(t *testing.T) {
-	p := newTestMySQLCheck()
-	assert.Nil(t, p.shutdown)
-	p.Start()
-	assert.NotNil(t, p.shutdown)
-	p.Start()
-	assert.NotNil(t, p.shutdown)
-	p.Pause()
-	assert.Nil(t, p.shutdown)
-	p.Pause()
-	assert.Nil(t, p.shutdown)
-}
-
-func newTestMySQLCheck() *MySQLChecker {
-	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
-	mysqlChecker, _ := NewMySQLChecker(c, 1*time.Second, 1*time.Second)
-	return mysqlChecker
+// TestRegisterRules tests registering the MySQL remediation rules
+// GIVEN a repair timeout
+// WHEN the MySQL rules are registered
+// THEN the rules restarting the mysql-operator wait for the repair timeout and only the InnoDBCluster rule is on-demand
+func TestRegisterRules(t *testing.T) {
+	RegisterRules(timeoutDuration)
+	for _, name := range []string{ICStuckDeletingRule, PodsStuckDeletingRule, PodsWaitingReadinessGatesRule} {
+		rule, ok := remediation.GetRule(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, componentName, rule.Component)
+		assert.Equal(t, mySQLOperatorRestartGroup, rule.Group)
+		assert.Equal(t, timeoutDuration, rule.GracePeriod)
+		assert.Equal(t, maxRepairAttempts, rule.MaxAttempts)
+		assert.Equal(t, name == ICStuckDeletingRule, rule.OnDemand)
+	}
+	rule, ok := remediation.GetRule(RouterPodsCrashLoopBackOffRule)
+	assert.True(t, ok)
+	assert.Zero(t, rule.GracePeriod)
+	assert.False(t, rule.OnDemand)
 }
@@ -37,6 +37,7 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/authproxy"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentbitosoutput"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentd"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/fluentoperator"
@@ -254,12 +255,13 @@ func (r *Reconciler) ProcReadyState(vzctx vzcontext.VerrazzanoContext) (ctrl.Res
 			return newRequeueWithDelay(), err
 		}
 
-		return ctrl.Result{}, nil
+		// Restart the applications left waiting for a maintenance window by the last upgrade
+		return r.continueAppRestarts(log, actualCR)
 	}
 
-	// if an OCI DNS installation, make sure the secret required exists before proceeding
-	if actualCR.Spec.Components.DNS != nil && actualCR.Spec.Components.DNS.OCI != nil {
-		err := r.doesOCIDNSConfigSecretExist(actualCR)
+	// if a managed DNS installation, make sure the secret required by the DNS provider exists before proceeding
+	if dnsProvider := common.GetDNSProvider(actualCR); dnsProvider != nil {
+		err := r.doesDNSProviderSecretExist(dnsProvider)
 		if err != nil {
 			return newRequeueWithDelay(), err
 		}
@@ -435,11 +437,11 @@ func (r *Reconciler) ProcFailedState(vzctx vzcontext.VerrazzanoContext) (ctrl.Re
 	return ctrl.Result{}, nil
 }
 
-// doesOCIDNSConfigSecretExist returns true if the DNS secret exists
-func (r *Reconciler) doesOCIDNSConfigSecretExist(vz *installv1alpha1.Verrazzano) error {
+// doesDNSProviderSecretExist returns an error if the credentials secret of the DNS provider does not exist
+func (r *Reconciler) doesDNSProviderSecretExist(dnsProvider common.DNSProvider) error {
 	// ensure the secret exists before proceeding
 	secret := &corev1.Secret{}
-	err := r.Get(context.TODO(), types.NamespacedName{Name: vz.Spec.Components.DNS.OCI.OCIConfigSecret, Namespace: vzconst.VerrazzanoInstallNamespace}, secret)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: dnsProvider.SecretName(), Namespace: vzconst.VerrazzanoInstallNamespace}, secret)
 	if err != nil {
 		return err
 	}
@@ -1,4 +1,4 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package reconcile
@@ -131,6 +131,8 @@ func getDNSSuffix(effectiveCR *v1alpha1.Verrazzano) string {
 		dnsSuffix = vzconfig.GetWildcardDomain(effectiveCR.Spec.Components.DNS)
 	} else if effectiveCR.Spec.Components.DNS.OCI != nil {
 		dnsSuffix = effectiveCR.Spec.Components.DNS.OCI.DNSZoneName
+	} else if effectiveCR.Spec.Components.DNS.RFC2136 != nil {
+		dnsSuffix = effectiveCR.Spec.Components.DNS.RFC2136.DNSZoneName
 	} else if effectiveCR.Spec.Components.DNS.External != nil {
 		dnsSuffix = effectiveCR.Spec.Components.DNS.External.Suffix
 	}
@@ -5,9 +5,10 @@ package restart
 
 import (
 	"context"
+	"time"
+
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"strconv"
 
 	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
 	vzapp "github.com/verrazzano/verrazzano/application-operator/apis/oam/v1alpha1"
@@ -19,31 +20,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-// RestartApps restarts all the applications that have old Istio sidecars.
-// It also restarts WebLogic domains that were stopped in Istio pre-upgrade
-func RestartApps(log vzlog.VerrazzanoLogger, client clipkg.Client, generation int64) error {
-	// Generate a restart version that will not change for this Verrazzano version
-	restartVersion := "upgrade-" + strconv.Itoa(int(generation))
-
-	// Start WebLogic domains that were shutdown
-	log.Infof("Starting WebLogic domains that were stopped pre-upgrade")
-	if err := startDomainsStoppedByUpgrade(log, client, restartVersion); err != nil {
-		return err
-	}
-
-	// Rolling restart Weblogic domain pods if the Istio version skew is 2 minor versions max
-	if err := RestartDomainsIfOutdatedSidecars(log, client, restartVersion); err != nil {
-		return err
-	}
-
-	// Restart all other apps
-	log.Infof("Restarting all applications so they can get the new Envoy sidecar")
-	if err := restartAllApps(log, client, restartVersion); err != nil {
-		return err
-	}
-	return nil
-}
-
 // StopDomainsUsingOldEnvoy stops all the WebLogic domains that have the old Envoy sidecar where istio version skew is more than 2 minor versions.
 func StopDomainsUsingOldEnvoy(log vzlog.VerrazzanoLogger, client clipkg.Client) error {
 	// Get the latest Istio proxy image name from the bom
@@ -72,33 +48,6 @@ func StopDomainsUsingOldEnvoy(log vzlog.VerrazzanoLogger, client clipkg.Client)
 	return nil
 }
 
-// RestartDomainsIfOutdatedSidecars Rolling restart all the WebLogic domains that have outdated sidecars
-func RestartDomainsIfOutdatedSidecars(log vzlog.VerrazzanoLogger, client clipkg.Client, restartVersion string) error {
-	podMatcher := &WKOPodMatcher{}
-	if err := podMatcher.ReInit(); err != nil {
-		return err
-	}
-
-	// get all the app configs
-	appConfigs := oam.ApplicationConfigurationList{}
-	if err := client.List(context.TODO(), &appConfigs, &clipkg.ListOptions{}); err != nil {
-		return log.ErrorfNewErr("Failed to list appConfigs %v", err)
-	}
-
-	// Loop through the WebLogic workloads and restarts the ones that need to be restarted
-	for _, appConfig := range appConfigs.Items {
-		log.Debugf("RestartWebLogicApps: found appConfig %s", appConfig.Name)
-		for _, wl := range appConfig.Status.Workloads {
-			if wl.Reference.Kind == vzconst.VerrazzanoWebLogicWorkloadKind {
-				if err := restartDomainIfNeeded(log, client, appConfig, wl.Reference.Name, restartVersion, podMatcher); err != nil {
-					return err
-				}
-			}
-		}
-	}
-	return nil
-}
-
 // Determine if the WebLogic domain needs to be stopped, if so then stop it
 func stopDomainIfNeeded(log vzlog.VerrazzanoLogger, client clipkg.Client, appConfig oam.ApplicationConfiguration, wlName string, matcher PodMatcher) error {
 	log.Progressf("StopWebLogicApps: checking if domain for workload %s needs to be stopped", wlName)
@@ -131,38 +80,6 @@ func stopDomainIfNeeded(log vzlog.VerrazzanoLogger, client clipkg.Client, appCon
 	return stopDomain(client, appConfig.Namespace, wlName)
 }
 
-// Determine if the WebLogic domain needs to be restarted
-func restartDomainIfNeeded(log vzlog.VerrazzanoLogger, client clipkg.Client, appConfig oam.ApplicationConfiguration, wlName string, restartVersion string, podMatcher PodMatcher) error {
-	log.Progressf("RestartWebLogicApps: checking if domain for workload %s needs to be restarted", wlName)
-
-	// Get the go client so we can bypass the cache and get directly from etcd
-	goClient, err := k8sutil.GetGoClient(log)
-	if err != nil {
-		return err
-	}
-
-	// Get the domain pods for this workload
-	weblogicReq, _ := labels.NewRequirement("verrazzano.io/workload-type", selection.Equals, []string{"weblogic"})
-	compReq, _ := labels.NewRequirement("app.oam.dev/component", selection.Equals, []string{wlName})
-	appConfNameReq, _ := labels.NewRequirement("app.oam.dev/name", selection.Equals, []string{appConfig.Name})
-	selector := labels.NewSelector()
-	selector = selector.Add(*weblogicReq).Add(*compReq).Add(*appConfNameReq)
-
-	// Get the pods using the label selector
-	podList, err := goClient.CoreV1().Pods(appConfig.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
-	if err != nil {
-		return log.ErrorfNewErr("Failed to list pods for Domain %s/%s: %v", appConfig.Namespace, wlName, err)
-	}
-
-	// Check if weblogic domain pods contain out of date sidecars.
-	found := podMatcher.Matches(log, podList, "OAM Weblogic Domain", wlName)
-	if !found {
-		return nil
-	}
-
-	return restartDomain(client, appConfig.Namespace, wlName, restartVersion)
-}
-
 // Stop the WebLogic domain
 func stopDomain(client clipkg.Client, wlNamespace string, wlName string) error {
 	// Set the lifecycle annotation on the VerrazzanoWebLogicWorkload
@@ -179,24 +96,9 @@ func stopDomain(client clipkg.Client, wlNamespace string, wlName string) error {
 	return err
 }
 
-// Restart the WebLogic domain
-func restartDomain(client clipkg.Client, wlNamespace string, wlName string, restartVersion string) error {
-	var wl vzapp.VerrazzanoWebLogicWorkload
-	wl.Namespace = wlNamespace
-	wl.Name = wlName
-	_, err := controllerutil.CreateOrUpdate(context.TODO(), client, &wl, func() error {
-		if wl.ObjectMeta.Annotations == nil {
-			wl.ObjectMeta.Annotations = make(map[string]string)
-		}
-		wl.ObjectMeta.Annotations[vzconst.RestartVersionAnnotation] = restartVersion
-		return nil
-	})
-	return err
-}
-
 // startDomainsStoppedByUpgrade starts all the WebLogic domains that upgrade previously stopped
 func startDomainsStoppedByUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Client, restartVersion string) error {
-	log.Progressf("RestartApps: checking if any domains need to be started")
+	log.Progressf("Checking if any WebLogic domains stopped by the upgrade need to be started")
 
 	// get all the app configs
 	appConfigs := oam.ApplicationConfigurationList{}
@@ -206,7 +108,7 @@ func startDomainsStoppedByUpgrade(log vzlog.VerrazzanoLogger, client clipkg.Clie
 
 	// Loop through the WebLogic workloads and start the ones that were stopped
 	for _, appConfig := range appConfigs.Items {
-		log.Debugf("RestartApps: found appConfig %s", appConfig.Name)
+		log.Debugf("StartWebLogicApps: found appConfig %s", appConfig.Name)
 		for _, wl := range appConfig.Status.Workloads {
 			if wl.Reference.Kind == vzconst.VerrazzanoWebLogicWorkloadKind {
 				if err := startDomainIfNeeded(log, client, appConfig.Namespace, wl.Reference.Name, restartVersion); err != nil {
@@ -234,7 +136,7 @@ func startDomainIfNeeded(log vzlog.VerrazzanoLogger, client clipkg.Client, wlNam
 		}
 		// Set the restart version also so that when the app config is modified to use that
 		// restart version, it will the same version so WebLogic will not start twice
-		log.Debugf("RestartApps: setting restart version for workload %s to %s ...  Old version is %s", wlName,
+		log.Debugf("StartWebLogicApps: setting restart version for workload %s to %s ...  Old version is %s", wlName,
 			restartVersion, wl.ObjectMeta.Annotations[vzconst.RestartVersionAnnotation])
 		wl.ObjectMeta.Annotations[vzconst.RestartVersionAnnotation] = restartVersion
 		return nil
@@ -242,56 +144,6 @@ func startDomainIfNeeded(log vzlog.VerrazzanoLogger, client clipkg.Client, wlNam
 	return err
 }
 
-// restartAllApps restarts all the applications
-func restartAllApps(log vzlog.VerrazzanoLogger, client clipkg.Client, restartVersion string) error {
-	log.Progressf("Restarting all OAM applications that have an old Istio proxy sidecar")
-
-	// Get the latest Istio proxy image name from the bom
-	podMatcher := &AppPodMatcher{}
-	if err := podMatcher.ReInit(); err != nil {
-		return log.ErrorfNewErr("Failed to get images from BOM: %v", err)
-	}
-
-	// get the go client so we can bypass the cache and get directly from etcd
-	goClient, err := k8sutil.GetGoClient(log)
-	if err != nil {
-		return err
-	}
-
-	// get all the app configs
-	appConfigs := oam.ApplicationConfigurationList{}
-	if err := client.List(context.TODO(), &appConfigs, &clipkg.ListOptions{}); err != nil {
-		return log.ErrorfNewErr("Failed to listing appConfigs %v", err)
-	}
-
-	// check each app config to see if any of the pods have old Istio proxy images
-	for _, appConfig := range appConfigs.Items {
-		log.Oncef("Checking OAM Application %s pods for an old Istio proxy sidecar", appConfig.Name)
-
-		// Get the pods for this appconfig
-		appConfNameReq, _ := labels.NewRequirement("app.oam.dev/name", selection.Equals, []string{appConfig.Name})
-		weblogicReq, _ := labels.NewRequirement("verrazzano.io/workload-type", selection.NotEquals, []string{"weblogic"})
-		selector := labels.NewSelector()
-		selector = selector.Add(*appConfNameReq).Add(*weblogicReq)
-
-		// Get the pods using the label selector
-		podList, err := goClient.CoreV1().Pods(appConfig.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
-		if err != nil {
-			return log.ErrorfNewErr("Failed to list pods for AppConfig %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
-		}
-
-		//Check if any pods that contain no or old istio proxy container with istio injection labeled namespace
-		foundOAMPodRequireRestart := podMatcher.Matches(log, podList, "OAM Application", appConfig.Name)
-		if foundOAMPodRequireRestart {
-			err := restartOAMApp(log, appConfig, client, restartVersion)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
 // restartOAMApp sets the restart version for appconfig to recycle the pod
 func restartOAMApp(log vzlog.VerrazzanoLogger, appConfig oam.ApplicationConfiguration, client clipkg.Client, restartVersion string) error {
 	// Set the update restart version
@@ -305,6 +157,8 @@ func restartOAMApp(log vzlog.VerrazzanoLogger, appConfig oam.ApplicationConfigur
 		log.Progressf("Setting restart version for appconfig %s to %s. Previous version is %s", appConfig.Name,
 			restartVersion, ac.ObjectMeta.Annotations[vzconst.RestartVersionAnnotation])
 		ac.ObjectMeta.Annotations[vzconst.RestartVersionAnnotation] = restartVersion
+		// Record when the restart version was set, the pods created after that time have been restarted
+		ac.ObjectMeta.Annotations[restartTimeAnnotation] = getCurrentTime().UTC().Format(time.RFC3339)
 		return nil
 	})
 	if err != nil {
@@ -0,0 +1,352 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package restart
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultMaxConcurrentApplications = 1
+	weblogicWorkloadType             = "weblogic"
+	workloadTypeLabel                = "verrazzano.io/workload-type"
+	appConfigNameLabel               = "app.oam.dev/name"
+	weblogicRestartVersionLabel      = "weblogic.domainRestartVersion"
+	restartTimeAnnotation            = "verrazzano.io/restart-time"
+)
+
+// getCurrentTime returns the current time, unit tests override it to test maintenance windows
+var getCurrentTime = time.Now
+
+// appToRestart is an application that needs to be restarted
+type appToRestart struct {
+	appConfig oam.ApplicationConfiguration
+	pods      []v1.Pod
+}
+
+// RestartAppsGradually restarts the applications that have old Istio or Fluentd sidecars a few at a time.
+// Each call starts the restart of the applications that are allowed to restart, given the applications that are
+// already being restarted, the maintenance windows and the PodDisruptionBudgets, and returns the progress of the
+// restart. The returned bool is true when all the applications have been restarted and their pods are ready.
+// WebLogic domains that were stopped in Istio pre-upgrade are started on every call.
+func RestartAppsGradually(log vzlog.VerrazzanoLogger, client clipkg.Client, version string, spec *installv1alpha1.ApplicationRestartSpec) (*installv1alpha1.ApplicationRestartStatus, bool, error) {
+	// Generate a restart version that will not change for this Verrazzano version, the CR generation changes with
+	// any edit of the CR made while the applications are being restarted
+	restartVersion := "upgrade-" + version
+	status := &installv1alpha1.ApplicationRestartStatus{RestartVersion: restartVersion}
+
+	// Start WebLogic domains that were shutdown
+	if err := startDomainsStoppedByUpgrade(log, client, restartVersion); err != nil {
+		return nil, false, err
+	}
+
+	appMatcher := &AppPodMatcher{}
+	if err := appMatcher.ReInit(); err != nil {
+		return nil, false, log.ErrorfNewErr("Failed to get images from BOM: %v", err)
+	}
+	wkoMatcher := &WKOPodMatcher{}
+	if err := wkoMatcher.ReInit(); err != nil {
+		return nil, false, log.ErrorfNewErr("Failed to get images from BOM: %v", err)
+	}
+
+	// Get the go client so we can bypass the cache and get directly from etcd
+	goClient, err := k8sutil.GetGoClient(log)
+	if err != nil {
+		return nil, false, err
+	}
+
+	appConfigs := oam.ApplicationConfigurationList{}
+	if err := client.List(context.TODO(), &appConfigs, &clipkg.ListOptions{}); err != nil {
+		return nil, false, log.ErrorfNewErr("Failed to list appConfigs %v", err)
+	}
+	sort.Slice(appConfigs.Items, func(i, j int) bool {
+		return appKey(appConfigs.Items[i]) < appKey(appConfigs.Items[j])
+	})
+
+	// Find the applications being restarted and the applications that need to be restarted
+	namespaces := map[string]*v1.Namespace{}
+	var candidates []appToRestart
+	totalPods := 0
+	inProgressPods := 0
+	for _, appConfig := range appConfigs.Items {
+		ns, err := getNamespace(goClient, namespaces, appConfig.Namespace)
+		if err != nil {
+			return nil, false, log.ErrorfNewErr("Failed to get namespace %s: %v", appConfig.Namespace, err)
+		}
+		pods, err := listAppPods(goClient, appConfig)
+		if err != nil {
+			return nil, false, log.ErrorfNewErr("Failed to list pods for AppConfig %s/%s: %v", appConfig.Namespace, appConfig.Name, err)
+		}
+		totalPods += len(pods)
+
+		// An application being restarted is done once all its pods are restarted and ready. The pod matchers are
+		// not used for this, they also match the pods that have containers other than the Istio proxy.
+		if appConfig.Annotations[vzconst.RestartVersionAnnotation] == restartVersion {
+			if !allPodsRestarted(appConfig, pods, restartVersion) || !allPodsReady(pods) {
+				status.InProgress = append(status.InProgress, appKey(appConfig))
+				inProgressPods += len(pods)
+			} else {
+				status.Restarted++
+			}
+			continue
+		}
+		if !appPodsNeedRestart(log, appMatcher, wkoMatcher, appConfig, pods) {
+			continue
+		}
+		if isRestartSkipped(appConfig, ns) {
+			log.Oncef("Skipping the restart of OAM Application %s, it has opted out with the %s label", appKey(appConfig), vzconst.SkipUpgradeRestartLabel)
+			status.Skipped = append(status.Skipped, appKey(appConfig))
+			continue
+		}
+		candidates = append(candidates, appToRestart{appConfig: appConfig, pods: pods})
+	}
+
+	maxConcurrent, maxPods, err := getRestartLimits(spec, totalPods)
+	if err != nil {
+		return nil, false, log.ErrorfNewErr("Failed to get the maximum number of unavailable application pods: %v", err)
+	}
+
+	// Start restarting the applications that are allowed to restart
+	now := getCurrentTime().UTC()
+	for _, candidate := range candidates {
+		key := appKey(candidate.appConfig)
+		if !isInMaintenanceWindow(log, spec, namespaces[candidate.appConfig.Namespace], now) {
+			status.Deferred = append(status.Deferred, key)
+			continue
+		}
+		if len(status.InProgress) >= maxConcurrent {
+			status.Pending++
+			continue
+		}
+		// Always allow an application to restart when no other application is restarting, otherwise an application
+		// with more pods than the maximum would never restart
+		if maxPods != nil && len(status.InProgress) > 0 && inProgressPods+len(candidate.pods) > *maxPods {
+			status.Pending++
+			continue
+		}
+		allowed, err := disruptionsAllowed(goClient, candidate.appConfig.Namespace, candidate.pods)
+		if err != nil {
+			return nil, false, log.ErrorfNewErr("Failed to list PodDisruptionBudgets in namespace %s: %v", candidate.appConfig.Namespace, err)
+		}
+		if !allowed {
+			log.Progressf("Waiting for the PodDisruptionBudgets in namespace %s to allow the restart of OAM Application %s", candidate.appConfig.Namespace, key)
+			status.Pending++
+			continue
+		}
+		if err := restartOAMApp(log, candidate.appConfig, client, restartVersion); err != nil {
+			return nil, false, err
+		}
+		status.InProgress = append(status.InProgress, key)
+		inProgressPods += len(candidate.pods)
+	}
+
+	status.Total = status.Restarted + len(status.InProgress) + status.Pending + len(status.Deferred)
+	done := len(status.InProgress) == 0 && status.Pending == 0 && len(status.Deferred) == 0
+	if !done {
+		log.Progressf("Restarting OAM applications: %d of %d restarted, %d in progress, %d pending, %d waiting for a maintenance window",
+			status.Restarted, status.Total, len(status.InProgress), status.Pending, len(status.Deferred))
+	}
+	return status, done, nil
+}
+
+// appKey returns the namespace/name key of an application
+func appKey(appConfig oam.ApplicationConfiguration) string {
+	return fmt.Sprintf("%s/%s", appConfig.Namespace, appConfig.Name)
+}
+
+// getNamespace gets a namespace, caching it in the given map. An empty namespace is returned if it does not exist.
+func getNamespace(goClient kubernetes.Interface, namespaces map[string]*v1.Namespace, name string) (*v1.Namespace, error) {
+	if ns, ok := namespaces[name]; ok {
+		return ns, nil
+	}
+	ns, err := goClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		ns = &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	} else if err != nil {
+		return nil, err
+	}
+	namespaces[name] = ns
+	return ns, nil
+}
+
+// listAppPods lists the pods of an application, including the WebLogic domain pods
+func listAppPods(goClient kubernetes.Interface, appConfig oam.ApplicationConfiguration) ([]v1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{appConfigNameLabel: appConfig.Name})
+	podList, err := goClient.CoreV1().Pods(appConfig.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+// appPodsNeedRestart returns true if any pod of the application has an old sidecar
+func appPodsNeedRestart(log vzlog.VerrazzanoLogger, appMatcher PodMatcher, wkoMatcher PodMatcher, appConfig oam.ApplicationConfiguration, pods []v1.Pod) bool {
+	weblogicPods := &v1.PodList{}
+	otherPods := &v1.PodList{}
+	for _, pod := range pods {
+		if pod.Labels[workloadTypeLabel] == weblogicWorkloadType {
+			weblogicPods.Items = append(weblogicPods.Items, pod)
+		} else {
+			otherPods.Items = append(otherPods.Items, pod)
+		}
+	}
+	return appMatcher.Matches(log, otherPods, "OAM Application", appConfig.Name) ||
+		wkoMatcher.Matches(log, weblogicPods, "OAM WebLogic Domain", appConfig.Name)
+}
+
+// allPodsRestarted returns true if all the pods of an application were restarted with the restart version. A pod is
+// restarted if its pod template has the restart version annotation, if the WebLogic operator labeled it with the
+// restart version of the domain, or if it was created after the restart version was set on the application.
+func allPodsRestarted(appConfig oam.ApplicationConfiguration, pods []v1.Pod, restartVersion string) bool {
+	restartTime, err := time.Parse(time.RFC3339, appConfig.Annotations[restartTimeAnnotation])
+	for _, pod := range pods {
+		if pod.Annotations[vzconst.RestartVersionAnnotation] == restartVersion || pod.Labels[weblogicRestartVersionLabel] == restartVersion {
+			continue
+		}
+		if err != nil || pod.CreationTimestamp.Time.Before(restartTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// allPodsReady returns true if all the pods are ready and none of them is being deleted
+func allPodsReady(pods []v1.Pod) bool {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPodReady returns true if the pod has a Ready condition with a status of True
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isRestartSkipped returns true if the application or its namespace opted out of the restart
+func isRestartSkipped(appConfig oam.ApplicationConfiguration, ns *v1.Namespace) bool {
+	return appConfig.Labels[vzconst.SkipUpgradeRestartLabel] == "true" || ns.Labels[vzconst.SkipUpgradeRestartLabel] == "true"
+}
+
+// getRestartLimits returns the maximum number of applications that can be restarted at the same time, and the
+// maximum number of pods that can belong to the applications being restarted, nil if the pods are not limited
+func getRestartLimits(spec *installv1alpha1.ApplicationRestartSpec, totalPods int) (int, *int, error) {
+	maxConcurrent := defaultMaxConcurrentApplications
+	if spec == nil {
+		return maxConcurrent, nil, nil
+	}
+	if spec.MaxConcurrentApplications != nil && *spec.MaxConcurrentApplications > 0 {
+		maxConcurrent = *spec.MaxConcurrentApplications
+	}
+	if spec.MaxUnavailablePods == nil {
+		return maxConcurrent, nil, nil
+	}
+	maxPods, err := intstr.GetScaledValueFromIntOrPercent(spec.MaxUnavailablePods, totalPods, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	return maxConcurrent, &maxPods, nil
+}
+
+// isInMaintenanceWindow returns true if the applications in the namespace can be restarted at the given time.
+// The first maintenance window that selects the namespace applies, and the applications in namespaces that
+// are not selected by a maintenance window can be restarted at any time.
+func isInMaintenanceWindow(log vzlog.VerrazzanoLogger, spec *installv1alpha1.ApplicationRestartSpec, ns *v1.Namespace, now time.Time) bool {
+	if spec == nil {
+		return true
+	}
+	for _, window := range spec.MaintenanceWindows {
+		if window.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(window.NamespaceSelector)
+			if err != nil {
+				log.Errorf("Failed to parse the namespace selector of a maintenance window: %v", err)
+				continue
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		return isWindowOpen(log, window, now)
+	}
+	return true
+}
+
+// isWindowOpen returns true if the given UTC time is inside an occurrence of the maintenance window
+func isWindowOpen(log vzlog.VerrazzanoLogger, window installv1alpha1.MaintenanceWindow, now time.Time) bool {
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		log.Errorf("Failed to parse the start time %s of a maintenance window: %v", window.Start, err)
+		return false
+	}
+	// Check the occurrences that started on the previous days and are long enough to still be open
+	days := int(window.Duration.Duration/(24*time.Hour)) + 1
+	for i := 0; i <= days; i++ {
+		day := now.AddDate(0, 0, -i)
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if !isWindowDay(window.Days, windowStart.Weekday()) {
+			continue
+		}
+		if !now.Before(windowStart) && now.Before(windowStart.Add(window.Duration.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWindowDay returns true if a maintenance window starts on the given day of the week
+func isWindowDay(days []string, weekday time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, day := range days {
+		if strings.EqualFold(day, weekday.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// disruptionsAllowed returns false if a PodDisruptionBudget that selects any of the pods does not allow a disruption
+func disruptionsAllowed(goClient kubernetes.Interface, namespace string, pods []v1.Pod) (bool, error) {
+	pdbs, err := goClient.PolicyV1().PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) && pdb.Status.DisruptionsAllowed < 1 {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package restart
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	oamcore "github.com/crossplane/oam-kubernetes-runtime/apis/core"
+	oam "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	vzconst "github.com/verrazzano/verrazzano/pkg/constants"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testAppNamespace = "hello"
+	testVersion      = "1.6.0"
+	testRestartVer   = "upgrade-1.6.0"
+)
+
+// newTestAppConfig creates an application configuration in the test namespace
+func newTestAppConfig(name string, labels map[string]string) *oam.ApplicationConfiguration {
+	return &oam.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: testAppNamespace, Name: name, Labels: labels}}
+}
+
+// newTestAppPod creates a pod of an application with the given image and readiness
+func newTestAppPod(name string, appName string, image string, ready bool) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testAppNamespace, Name: name, Labels: map[string]string{appConfigNameLabel: appName}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "c0", Image: image}}},
+		Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}}},
+	}
+}
+
+// newRestartedTestAppPod creates a pod of an application that was restarted with the test restart version
+func newRestartedTestAppPod(name string, appName string, image string, ready bool) *v1.Pod {
+	pod := newTestAppPod(name, appName, image, ready)
+	pod.Annotations = map[string]string{vzconst.RestartVersionAnnotation: testRestartVer}
+	return pod
+}
+
+// newTestAppNamespace creates the Istio injected test namespace with the given labels
+func newTestAppNamespace(labels map[string]string) *v1.Namespace {
+	ns := initNamespace(testAppNamespace, true)
+	for k, v := range labels {
+		ns.Labels[k] = v
+	}
+	return ns
+}
+
+// newAppRestartClient creates a fake client that contains the given application configurations
+func newAppRestartClient(appConfigs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = oamcore.AddToScheme(scheme)
+	return ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(appConfigs...).Build()
+}
+
+// getCurrentProxyImage gets the Istio proxy image from the BOM
+func getCurrentProxyImage(t *testing.T) string {
+	matcher := &AppPodMatcher{}
+	assert.NoError(t, matcher.ReInit())
+	return matcher.istioProxyImage
+}
+
+// getRestartVersion gets the restart version annotation of an application configuration
+func getRestartVersion(t *testing.T, c client.Client, name string) string {
+	appConfig := oam.ApplicationConfiguration{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testAppNamespace, Name: name}, &appConfig))
+	return appConfig.Annotations[vzconst.RestartVersionAnnotation]
+}
+
+// TestRestartAppsGradually tests restarting applications one at a time
+// GIVEN two applications with old Istio sidecars and the default restart settings
+// WHEN RestartAppsGradually is called repeatedly while the restarted pods become ready
+// THEN the applications are restarted one after the other and the progress is reported
+func TestRestartAppsGradually(t *testing.T) {
+	asserts := assert.New(t)
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	newImage := getCurrentProxyImage(t)
+
+	clientSet := fake.NewSimpleClientset(newTestAppNamespace(nil),
+		newTestAppPod("app1-pod", "app1", oldIstioImage, true),
+		newTestAppPod("app2-pod", "app2", oldIstioImage, true))
+	k8sutil.SetFakeClient(clientSet)
+	defer k8sutil.ClearFakeClient()
+	c := newAppRestartClient(newTestAppConfig("app1", nil), newTestAppConfig("app2", nil))
+
+	// Only the first application is restarted
+	status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+	asserts.NoError(err)
+	asserts.False(done)
+	asserts.Equal(&installv1alpha1.ApplicationRestartStatus{RestartVersion: testRestartVer, Total: 2, Pending: 1, InProgress: []string{"hello/app1"}}, status)
+	asserts.Equal(testRestartVer, getRestartVersion(t, c, "app1"))
+	asserts.Empty(getRestartVersion(t, c, "app2"))
+
+	// The second application is not restarted while the pod of the first application is not ready
+	_, err = clientSet.CoreV1().Pods(testAppNamespace).Update(context.TODO(), newRestartedTestAppPod("app1-pod", "app1", newImage, false), metav1.UpdateOptions{})
+	asserts.NoError(err)
+	status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+	asserts.NoError(err)
+	asserts.False(done)
+	asserts.Equal([]string{"hello/app1"}, status.InProgress)
+	asserts.Empty(getRestartVersion(t, c, "app2"))
+
+	// The second application is restarted once the first application is ready
+	_, err = clientSet.CoreV1().Pods(testAppNamespace).Update(context.TODO(), newRestartedTestAppPod("app1-pod", "app1", newImage, true), metav1.UpdateOptions{})
+	asserts.NoError(err)
+	status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+	asserts.NoError(err)
+	asserts.False(done)
+	asserts.Equal(&installv1alpha1.ApplicationRestartStatus{RestartVersion: testRestartVer, Total: 2, Restarted: 1, InProgress: []string{"hello/app2"}}, status)
+	asserts.Equal(testRestartVer, getRestartVersion(t, c, "app2"))
+
+	// The restart is done once the second application is ready
+	_, err = clientSet.CoreV1().Pods(testAppNamespace).Update(context.TODO(), newRestartedTestAppPod("app2-pod", "app2", newImage, true), metav1.UpdateOptions{})
+	asserts.NoError(err)
+	status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+	asserts.NoError(err)
+	asserts.True(done)
+	asserts.Equal(&installv1alpha1.ApplicationRestartStatus{RestartVersion: testRestartVer, Total: 2, Restarted: 2}, status)
+}
+
+// TestRestartAppsGraduallyRestartedPods tests that the restart of an application is done once its pods are restarted
+// GIVEN an application with a WebLogic pod, or with a pod that has containers other than the Istio proxy
+// WHEN RestartAppsGradually is called before and after the pod is restarted
+// THEN the restart is in progress until the restarted pod is ready, and is done after that
+func TestRestartAppsGraduallyRestartedPods(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	defer k8sutil.ClearFakeClient()
+	defer func() { getCurrentTime = time.Now }()
+	restartTime := time.Date(2023, 6, 3, 23, 0, 0, 0, time.UTC)
+	getCurrentTime = func() time.Time { return restartTime }
+	newImage := getCurrentProxyImage(t)
+
+	// newPod creates a pod of the application with an application container and an Istio proxy
+	newPod := func(labels map[string]string, proxyImage string, created time.Time) *v1.Pod {
+		pod := newTestAppPod("app1-pod", "app1", "", true)
+		for k, v := range labels {
+			pod.Labels[k] = v
+		}
+		pod.CreationTimestamp = metav1.NewTime(created)
+		pod.Spec.Containers = []v1.Container{{Name: "app", Image: "hello:1.0"}, {Name: "istio-proxy", Image: proxyImage}}
+		return pod
+	}
+	weblogic := map[string]string{workloadTypeLabel: weblogicWorkloadType}
+	tests := []struct {
+		name         string
+		oldPod       *v1.Pod
+		restartedPod *v1.Pod
+	}{
+		{
+			// The WebLogic operator labels the pods of the domain with its restart version
+			name:   "WebLogicPod",
+			oldPod: newPod(weblogic, oldIstioImage, restartTime.Add(-time.Hour)),
+			restartedPod: newPod(map[string]string{workloadTypeLabel: weblogicWorkloadType, weblogicRestartVersionLabel: testRestartVer},
+				newImage, restartTime.Add(time.Minute)),
+		},
+		{
+			name:         "MultiContainerPod",
+			oldPod:       newPod(nil, oldIstioImage, restartTime.Add(-time.Hour)),
+			restartedPod: newPod(nil, newImage, restartTime.Add(time.Minute)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			clientSet := fake.NewSimpleClientset(newTestAppNamespace(nil), tt.oldPod)
+			k8sutil.SetFakeClient(clientSet)
+			c := newAppRestartClient(newTestAppConfig("app1", nil))
+
+			// The application is restarted
+			status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.False(done)
+			asserts.Equal([]string{"hello/app1"}, status.InProgress)
+
+			// The restart is in progress while the old pod is running
+			status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.False(done)
+			asserts.Equal([]string{"hello/app1"}, status.InProgress)
+
+			// The restart is in progress while the restarted pod is not ready
+			notReady := tt.restartedPod.DeepCopy()
+			notReady.Status.Conditions[0].Status = v1.ConditionFalse
+			_, err = clientSet.CoreV1().Pods(testAppNamespace).Update(context.TODO(), notReady, metav1.UpdateOptions{})
+			asserts.NoError(err)
+			status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.False(done)
+			asserts.Equal([]string{"hello/app1"}, status.InProgress)
+
+			// The restart is done once the restarted pod is ready
+			_, err = clientSet.CoreV1().Pods(testAppNamespace).Update(context.TODO(), tt.restartedPod, metav1.UpdateOptions{})
+			asserts.NoError(err)
+			status, done, err = RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.True(done)
+			asserts.Equal(&installv1alpha1.ApplicationRestartStatus{RestartVersion: testRestartVer, Total: 1, Restarted: 1}, status)
+		})
+	}
+}
+
+// TestRestartAppsGraduallyLimits tests the limits on the number of applications and pods being restarted
+// GIVEN three applications with old Istio sidecars
+// WHEN RestartAppsGradually is called with a maximum of concurrent applications or pods
+// THEN only the allowed number of applications are restarted
+func TestRestartAppsGraduallyLimits(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	defer k8sutil.ClearFakeClient()
+
+	two := 2
+	three := 3
+	percent := intstr.FromString("50%")
+	tests := []struct {
+		name       string
+		spec       *installv1alpha1.ApplicationRestartSpec
+		inProgress []string
+		pending    int
+	}{
+		{
+			name:       "MaxConcurrentApplications",
+			spec:       &installv1alpha1.ApplicationRestartSpec{MaxConcurrentApplications: &two},
+			inProgress: []string{"hello/app1", "hello/app2"},
+			pending:    1,
+		},
+		{
+			// app1 has two pods, app2 and app3 have one pod each, and at most 2 of the 4 pods can be restarting
+			name:       "MaxUnavailablePods",
+			spec:       &installv1alpha1.ApplicationRestartSpec{MaxConcurrentApplications: &three, MaxUnavailablePods: &percent},
+			inProgress: []string{"hello/app1"},
+			pending:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			k8sutil.SetFakeClient(fake.NewSimpleClientset(newTestAppNamespace(nil),
+				newTestAppPod("app1-pod1", "app1", oldIstioImage, true),
+				newTestAppPod("app1-pod2", "app1", oldIstioImage, true),
+				newTestAppPod("app2-pod", "app2", oldIstioImage, true),
+				newTestAppPod("app3-pod", "app3", oldIstioImage, true)))
+			c := newAppRestartClient(newTestAppConfig("app1", nil), newTestAppConfig("app2", nil), newTestAppConfig("app3", nil))
+
+			status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, tt.spec)
+			asserts.NoError(err)
+			asserts.False(done)
+			asserts.Equal(tt.inProgress, status.InProgress)
+			asserts.Equal(tt.pending, status.Pending)
+			asserts.Equal(3, status.Total)
+		})
+	}
+}
+
+// TestRestartAppsGraduallyOptOut tests that applications can opt out of the restart
+// GIVEN an application with the opt-out label, or in a namespace with the opt-out label
+// WHEN RestartAppsGradually is called
+// THEN the application is not restarted and is reported as skipped
+func TestRestartAppsGraduallyOptOut(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	defer k8sutil.ClearFakeClient()
+
+	optOut := map[string]string{vzconst.SkipUpgradeRestartLabel: "true"}
+	tests := []struct {
+		name            string
+		appLabels       map[string]string
+		namespaceLabels map[string]string
+	}{
+		{name: "ApplicationLabel", appLabels: optOut},
+		{name: "NamespaceLabel", namespaceLabels: optOut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			k8sutil.SetFakeClient(fake.NewSimpleClientset(newTestAppNamespace(tt.namespaceLabels),
+				newTestAppPod("app1-pod", "app1", oldIstioImage, true)))
+			c := newAppRestartClient(newTestAppConfig("app1", tt.appLabels))
+
+			status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.True(done)
+			asserts.Equal([]string{"hello/app1"}, status.Skipped)
+			asserts.Equal(0, status.Total)
+			asserts.Empty(getRestartVersion(t, c, "app1"))
+		})
+	}
+}
+
+// TestRestartAppsGraduallyPodDisruptionBudget tests that PodDisruptionBudgets are respected
+// GIVEN an application whose pods are selected by a PodDisruptionBudget
+// WHEN RestartAppsGradually is called
+// THEN the application is only restarted if the PodDisruptionBudget allows a disruption
+func TestRestartAppsGraduallyPodDisruptionBudget(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	defer k8sutil.ClearFakeClient()
+
+	tests := []struct {
+		name               string
+		disruptionsAllowed int32
+		expectRestart      bool
+	}{
+		{name: "DisruptionAllowed", disruptionsAllowed: 1, expectRestart: true},
+		{name: "DisruptionNotAllowed", disruptionsAllowed: 0, expectRestart: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			pdb := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testAppNamespace, Name: "app1-pdb"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{appConfigNameLabel: "app1"}}},
+				Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: tt.disruptionsAllowed},
+			}
+			k8sutil.SetFakeClient(fake.NewSimpleClientset(newTestAppNamespace(nil), pdb,
+				newTestAppPod("app1-pod", "app1", oldIstioImage, true)))
+			c := newAppRestartClient(newTestAppConfig("app1", nil))
+
+			status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, nil)
+			asserts.NoError(err)
+			asserts.False(done)
+			if tt.expectRestart {
+				asserts.Equal([]string{"hello/app1"}, status.InProgress)
+				asserts.Equal(testRestartVer, getRestartVersion(t, c, "app1"))
+			} else {
+				asserts.Equal(1, status.Pending)
+				asserts.Empty(getRestartVersion(t, c, "app1"))
+			}
+		})
+	}
+}
+
+// TestRestartAppsGraduallyMaintenanceWindow tests that applications are only restarted in their maintenance window
+// GIVEN an application in a namespace selected by a maintenance window
+// WHEN RestartAppsGradually is called inside and outside the window
+// THEN the application is restarted inside the window, and deferred outside of it
+func TestRestartAppsGraduallyMaintenanceWindow(t *testing.T) {
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	defer k8sutil.ClearFakeClient()
+	defer func() { getCurrentTime = time.Now }()
+
+	spec := &installv1alpha1.ApplicationRestartSpec{
+		MaintenanceWindows: []installv1alpha1.MaintenanceWindow{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Days:              []string{"Saturday"},
+			Start:             "22:00",
+			Duration:          metav1.Duration{Duration: 4 * time.Hour},
+		}},
+	}
+	tests := []struct {
+		name          string
+		now           time.Time
+		expectRestart bool
+	}{
+		// 2023-06-03 is a Saturday
+		{name: "InsideWindow", now: time.Date(2023, 6, 3, 23, 0, 0, 0, time.UTC), expectRestart: true},
+		{name: "InsideWindowNextDay", now: time.Date(2023, 6, 4, 1, 30, 0, 0, time.UTC), expectRestart: true},
+		{name: "AfterWindow", now: time.Date(2023, 6, 4, 2, 0, 0, 0, time.UTC), expectRestart: false},
+		{name: "WrongDay", now: time.Date(2023, 6, 5, 23, 0, 0, 0, time.UTC), expectRestart: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asserts := assert.New(t)
+			getCurrentTime = func() time.Time { return tt.now }
+			k8sutil.SetFakeClient(fake.NewSimpleClientset(newTestAppNamespace(map[string]string{"env": "prod"}),
+				newTestAppPod("app1-pod", "app1", oldIstioImage, true)))
+			c := newAppRestartClient(newTestAppConfig("app1", nil))
+
+			status, done, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, spec)
+			asserts.NoError(err)
+			asserts.False(done)
+			if tt.expectRestart {
+				asserts.Equal([]string{"hello/app1"}, status.InProgress)
+			} else {
+				asserts.Equal([]string{"hello/app1"}, status.Deferred)
+				asserts.Empty(getRestartVersion(t, c, "app1"))
+			}
+		})
+	}
+
+	// GIVEN a namespace that is not selected by the maintenance window
+	// WHEN RestartAppsGradually is called outside the window
+	// THEN the application is restarted
+	asserts := assert.New(t)
+	getCurrentTime = func() time.Time { return time.Date(2023, 6, 5, 23, 0, 0, 0, time.UTC) }
+	k8sutil.SetFakeClient(fake.NewSimpleClientset(newTestAppNamespace(map[string]string{"env": "dev"}),
+		newTestAppPod("app1-pod", "app1", oldIstioImage, true)))
+	c := newAppRestartClient(newTestAppConfig("app1", nil))
+	status, _, err := RestartAppsGradually(vzlog.DefaultLogger(), c, testVersion, spec)
+	asserts.NoError(err)
+	asserts.Equal([]string{"hello/app1"}, status.InProgress)
+}
@@ -97,28 +97,6 @@ func TestWebLogicStopStart(t *testing.T) {
 				return startDomainsStoppedByUpgrade(vzlog.DefaultLogger(), mock, "1")
 			},
 		},
-
-		// Test rolling restart of WebLogic because it has an old Istio image with skew max 2 minor versions.
-		{
-			name:                   "RollingRestartWebLogic",
-			image:                  "proxyv2:1.5.5",
-			expectGetAndUpdate:     true,
-			initialLifeCycleAction: "",
-			f: func(mock *mocks.MockClient) error {
-				return RestartDomainsIfOutdatedSidecars(vzlog.DefaultLogger(), mock, "1")
-			},
-		},
-
-		// Test rolling restart of WebLogic because it has an old Istio image with skew max 2 minor versions.
-		{
-			name:                   "DoNotRollingRestartWebLogic",
-			image:                  "proxyv2:1.4.5",
-			expectGetAndUpdate:     false,
-			initialLifeCycleAction: "",
-			f: func(mock *mocks.MockClient) error {
-				return RestartDomainsIfOutdatedSidecars(vzlog.DefaultLogger(), mock, "1")
-			},
-		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -158,7 +136,7 @@ func TestWebLogicStopStart(t *testing.T) {
 	}
 }
 
-// TestHelidonStopStart tests the starting and stopping of Helidon
+// TestHelidonNeedsRestart tests finding the Helidon applications to restart
 // GIVEN a AppConfig that contains Helidon workloads
 // WHEN the Helidon pods have old Istio envoy sidecar
 // THEN the pods should be restarted
@@ -168,80 +146,60 @@ func TestWebLogicStopStart(t *testing.T) {
 // THEN the pods should be restarted
 // WHEN the Helidon pods do NOT have an old istio sidecar and without istio injected namespace
 // THEN the pods should not be restarted
-func TestHelidonStopStart(t *testing.T) {
+func TestHelidonNeedsRestart(t *testing.T) {
 	asserts := assert.New(t)
 	config.SetDefaultBomFilePath(unitTestBomFile)
 
 	tests := []struct {
 		name                string
-		expectGetAndUpdate  bool
+		expectRestart       bool
 		image               string
 		isNSIstioEnabled    bool
 		isIstioLabelPresent bool
-		f                   func(mock *mocks.MockClient) error
 	}{
 		// Test restarting Helidon workload because it has an old Istio image
 		{
-			name:               "RestartHelidon",
-			expectGetAndUpdate: true,
-			image:              oldIstioImage,
-			f: func(mock *mocks.MockClient) error {
-				return restartAllApps(vzlog.DefaultLogger(), mock, "1")
-			},
+			name:          "RestartHelidon",
+			expectRestart: true,
+			image:         oldIstioImage,
 		},
 		// Test restarting Helidon workload because it doesn't have an old Istio image
 		{
-			name:               "DoNotRestartHelidon",
-			expectGetAndUpdate: false,
-			image:              "randomImage",
-			f: func(mock *mocks.MockClient) error {
-				return restartAllApps(vzlog.DefaultLogger(), mock, "1")
-			},
+			name:          "DoNotRestartHelidon",
+			expectRestart: false,
+			image:         "randomImage",
 		},
 		// Test restarting Helidon workload without old istio sidecar but with istio injected namespace
 		{
 			name:                "RestartHelidonWithIsioInjection",
-			expectGetAndUpdate:  true,
+			expectRestart:       true,
 			image:               "randomImage",
 			isIstioLabelPresent: true,
 			isNSIstioEnabled:    true,
-			f: func(mock *mocks.MockClient) error {
-				return restartAllApps(vzlog.DefaultLogger(), mock, "1")
-			},
 		},
 		// Test restarting Helidon workload without old istio sidecar and without istio injected namespace
 		{
 			name:                "DoNotRestartHelidonWithoutIstioInjection",
-			expectGetAndUpdate:  false,
+			expectRestart:       false,
 			image:               "randomImage",
 			isIstioLabelPresent: true,
 			isNSIstioEnabled:    false,
-			f: func(mock *mocks.MockClient) error {
-				return restartAllApps(vzlog.DefaultLogger(), mock, "1")
-			},
 		},
 		// Test restarting Helidon workload when namespace doesn't have an istio injection label
 		{
 			name:                "DoNotRestartHelidonWithoutIstioNSLabel",
-			expectGetAndUpdate:  false,
+			expectRestart:       false,
 			image:               "randomImage",
 			isIstioLabelPresent: false,
-			f: func(mock *mocks.MockClient) error {
-				return restartAllApps(vzlog.DefaultLogger(), mock, "1")
-			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mocker := gomock.NewController(t)
-			mock := mocks.NewMockClient(mocker)
-
 			defer config.Set(config.Get())
 			config.Set(config.OperatorConfig{VersionCheckEnabled: false})
 
 			// Setup fake client to provide workloads for restart platform testing
-			wlName := "test"
 			appConfigName := "myApp"
 			podLabels := map[string]string{"app.oam.dev/name": appConfigName}
 			podNamespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
@@ -255,26 +213,18 @@ func TestHelidonStopStart(t *testing.T) {
 				podNamespace.Labels["istio-injection"] = "disabled"
 			}
 
-			clientSet := fake.NewSimpleClientset(initFakePodWithLabels("testpod", []string{test.image}, podLabels), initFakeDeployment(), initFakeStatefulSet(), initFakeDaemonSet(), podNamespace)
+			pod := initFakePodWithLabels("testpod", []string{test.image}, podLabels)
+			clientSet := fake.NewSimpleClientset(pod, initFakeDeployment(), initFakeStatefulSet(), initFakeDaemonSet(), podNamespace)
 			k8sutil.SetFakeClient(clientSet)
 
-			conf := testAppConfigInfo{
-				namespace:     constants.VerrazzanoSystemNamespace,
-				appConfigName: appConfigName,
-				workloadKind:  vzconst.VerrazzanoHelidonWorkloadKind,
-				workloadName:  wlName,
-			}
-			expectListAppConfigs(t, mock, conf)
-			version := "1"
-			if test.expectGetAndUpdate {
-				expectGetAppConfig(t, mock, appConfigName, vzconst.RestartVersionAnnotation, version)
-				expectUpdateAppConfig(t, mock, vzconst.RestartVersionAnnotation, version)
-			}
-
-			err := test.f(mock)
+			appMatcher := &AppPodMatcher{}
+			asserts.NoError(appMatcher.ReInit())
+			wkoMatcher := &WKOPodMatcher{}
+			asserts.NoError(wkoMatcher.ReInit())
+			appConfig := oam.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: constants.VerrazzanoSystemNamespace, Name: appConfigName}}
 
 			// Validate the results
-			asserts.NoError(err)
+			asserts.Equal(test.expectRestart, appPodsNeedRestart(vzlog.DefaultLogger(), appMatcher, wkoMatcher, appConfig, []v1.Pod{*pod}))
 		})
 	}
 }
@@ -304,29 +254,6 @@ func expectListAppConfigs(_ *testing.T, mock *mocks.MockClient, config testAppCo
 		})
 }
 
-func expectGetAppConfig(_ *testing.T, mock *mocks.MockClient, appConfigName string, annotationKey string, annotationVal string) {
-	mock.EXPECT().
-		Get(gomock.Any(), types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: appConfigName}, gomock.Not(gomock.Nil()), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, nsName types.NamespacedName, appConfig *oam.ApplicationConfiguration, opts ...client.GetOption) error {
-			if len(annotationVal) > 0 {
-				appConfig.Annotations = map[string]string{}
-				appConfig.Annotations[annotationKey] = annotationVal
-			}
-			return nil
-		})
-}
-
-func expectUpdateAppConfig(t *testing.T, mock *mocks.MockClient, annotationKey string, annotationVal string) {
-	mock.EXPECT().
-		Update(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, appConfig *oam.ApplicationConfiguration, opts ...client.UpdateOption) error {
-			if len(annotationVal) > 0 {
-				assert.Equal(t, annotationVal, appConfig.Annotations[annotationKey], "Incorrect Appconfig lifecycle annotation")
-			}
-			return nil
-		}).AnyTimes()
-}
-
 func expectGetWebLogicWorkload(_ *testing.T, mock *mocks.MockClient, wlName string, lifecycleAction string) {
 	mock.EXPECT().
 		Get(gomock.Any(), types.NamespacedName{Namespace: constants.VerrazzanoSystemNamespace, Name: wlName}, gomock.Not(gomock.Nil()), gomock.Any()).
@@ -333,6 +333,33 @@ func initFakePodWithIstioInject(podName string, imageNames []string, istioInject
 	return initFakePodWithLabels(podName, imageNames, labels)
 }
 
+// TestSecretPodMatcher tests matching the pods that use a secret
+// GIVEN pods that use a secret through a volume, an environment variable, envFrom or not at all
+// WHEN the SecretPodMatcher is called
+// THEN only the pods that use the secret match
+func TestSecretPodMatcher(t *testing.T) {
+	matcher := &SecretPodMatcher{SecretName: "test-secret"}
+	assert.NoError(t, matcher.ReInit())
+
+	volumePod := initFakePod("image")
+	volumePod.Spec.Volumes = []v1.Volume{{Name: "v", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "test-secret"}}}}
+	assert.True(t, matcher.Matches(vzlog.DefaultLogger(), &v1.PodList{Items: []v1.Pod{*volumePod}}, "Deployment", "test"))
+
+	envPod := initFakePod("image")
+	envPod.Spec.InitContainers = []v1.Container{{Name: "init", Env: []v1.EnvVar{{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{
+		SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "test-secret"}, Key: "password"},
+	}}}}}
+	assert.True(t, matcher.Matches(vzlog.DefaultLogger(), &v1.PodList{Items: []v1.Pod{*envPod}}, "Deployment", "test"))
+
+	envFromPod := initFakePod("image")
+	envFromPod.Spec.Containers[0].EnvFrom = []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "test-secret"}}}}
+	assert.True(t, matcher.Matches(vzlog.DefaultLogger(), &v1.PodList{Items: []v1.Pod{*envFromPod}}, "Deployment", "test"))
+
+	otherPod := initFakePod("image")
+	otherPod.Spec.Volumes = []v1.Volume{{Name: "v", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "other-secret"}}}}
+	assert.False(t, matcher.Matches(vzlog.DefaultLogger(), &v1.PodList{Items: []v1.Pod{*otherPod}}, "Deployment", "test"))
+}
+
 // initFakePod inits a fake Pod with specified image
 func initFakePod(image string) *v1.Pod {
 	return initFakePodWithLabels("testPod", []string{image}, map[string]string{"app": "foo"})
@@ -53,6 +53,11 @@ type EnvoyOlderThanTwoVersionsPodMatcher struct {
 	istioProxyImage string
 }
 
+// SecretPodMatcher matches pods that consume a secret in their environment or volumes.
+type SecretPodMatcher struct {
+	SecretName string
+}
+
 func (o *OutdatedSidecarPodMatcher) ReInit() error {
 	if len(o.istioProxyImage) > 0 || len(o.fluentdImage) > 0 {
 		return nil
@@ -142,6 +147,45 @@ func (e *EnvoyOlderThanTwoVersionsPodMatcher) Matches(log vzlog.VerrazzanoLogger
 	return false
 }
 
+func (s *SecretPodMatcher) ReInit() error {
+	return nil
+}
+
+// Matches when a container of the pod gets its environment from the secret, or a volume of the pod is the secret
+func (s *SecretPodMatcher) Matches(log vzlog.VerrazzanoLogger, podList *v1.PodList, workloadType, workloadName string) bool {
+	for _, pod := range podList.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Secret != nil && volume.Secret.SecretName == s.SecretName {
+				log.Oncef("Restarting %s %s which has a pod with a volume for secret %s", workloadType, workloadName, s.SecretName)
+				return true
+			}
+		}
+		containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, c := range containers {
+			if containerUsesSecret(c, s.SecretName) {
+				log.Oncef("Restarting %s %s which has a pod with an environment from secret %s", workloadType, workloadName, s.SecretName)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containerUsesSecret returns true if the environment of the container comes from the secret
+func containerUsesSecret(c v1.Container, secretName string) bool {
+	for _, envFrom := range c.EnvFrom {
+		if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
+			return true
+		}
+	}
+	for _, env := range c.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *AppPodMatcher) ReInit() error {
 	images, err := getImages(istioSubcomponent, proxyv2ImageName,
 		verrazzanoSubcomponent, fluentdImageName)
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/reconcile/restart"
+	"reflect"
+	"time"
 
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/vzcr"
@@ -50,6 +52,10 @@ const (
 	vzStateEnd VerrazzanoUpgradeState = "vzStateEnd"
 )
 
+// appRestartCheckPeriod is the period at which the applications waiting for a maintenance window are checked after
+// the upgrade
+const appRestartCheckPeriod = 5 * time.Minute
+
 // VerrazzanoUpgradeState identifies the state of a Verrazzano upgrade operation
 type VerrazzanoUpgradeState string
 
@@ -132,11 +138,15 @@ func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1a
 		case vzStateRestartApps:
 			if vzcr.IsApplicationOperatorEnabled(spiCtx.EffectiveCR()) && vzcr.IsIstioEnabled(spiCtx.EffectiveCR()) {
 				log.Once("Doing Verrazzano post-upgrade application restarts if needed")
-				err := restart.RestartApps(log, r.Client, cr.Generation)
+				restartStatus, _, err := r.restartApps(log, cr, targetVersion)
 				if err != nil {
-					log.Errorf("Error running Verrazzano post-upgrade application restarts")
 					return newRequeueWithDelay(), err
 				}
+				// Wait for the restarted applications to be ready before restarting more applications. The
+				// applications waiting for a maintenance window are restarted after the upgrade, see continueAppRestarts.
+				if len(restartStatus.InProgress) > 0 || restartStatus.Pending > 0 {
+					return newRequeueWithDelay(), nil
+				}
 			}
 			tracker.vzState = vzStateUpgradeDone
 
@@ -172,6 +182,50 @@ func (r *Reconciler) reconcileUpgrade(log vzlog.VerrazzanoLogger, cr *installv1a
 	return ctrl.Result{}, nil
 }
 
+// restartApps restarts the applications that need the sidecars of the Verrazzano version a few at a time, and
+// reports the progress of the restart in the status
+func (r *Reconciler) restartApps(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano, version string) (*installv1alpha1.ApplicationRestartStatus, bool, error) {
+	restartStatus, done, err := restart.RestartAppsGradually(log, r.Client, version, cr.Spec.ApplicationRestart)
+	if err != nil {
+		log.Errorf("Error running Verrazzano post-upgrade application restarts")
+		return nil, false, err
+	}
+	// Report the progress of the restart, there is nothing to report if no application needed a restart
+	if restartStatus.Total > 0 || len(restartStatus.Skipped) > 0 {
+		if !reflect.DeepEqual(restartStatus, cr.Status.ApplicationRestart) {
+			r.StatusUpdater.Update(&vzstatus.UpdateEvent{
+				AppRestart: restartStatus,
+			})
+		}
+	}
+	return restartStatus, done, nil
+}
+
+// continueAppRestarts keeps restarting the applications that were not restarted by the last upgrade, because they
+// were waiting for a maintenance window, until all of them are restarted
+func (r *Reconciler) continueAppRestarts(log vzlog.VerrazzanoLogger, cr *installv1alpha1.Verrazzano) (ctrl.Result, error) {
+	restartStatus := cr.Status.ApplicationRestart
+	if restartStatus == nil || (len(restartStatus.Deferred) == 0 && len(restartStatus.InProgress) == 0 && restartStatus.Pending == 0) {
+		return ctrl.Result{}, nil
+	}
+	effectiveCR, err := transform.GetEffectiveCR(cr)
+	if err != nil {
+		return newRequeueWithDelay(), err
+	}
+	if !vzcr.IsApplicationOperatorEnabled(effectiveCR) || !vzcr.IsIstioEnabled(effectiveCR) {
+		return ctrl.Result{}, nil
+	}
+	_, done, err := r.restartApps(log, cr, cr.Status.Version)
+	if err != nil {
+		return newRequeueWithDelay(), err
+	}
+	if !done {
+		return ctrl.Result{RequeueAfter: appRestartCheckPeriod}, nil
+	}
+	log.Once("Restarted all the applications after the Verrazzano upgrade")
+	return ctrl.Result{}, nil
+}
+
 // resolvePendingUpgrades will delete any helm secrets with a status other than "deployed" for the given component
 func (r *Reconciler) resolvePendingUpgrades(compName string, compLog vzlog.VerrazzanoLogger) {
 	nameReq, _ := kblabels.NewRequirement("name", selection.Equals, []string{compName})
@@ -1982,3 +1982,85 @@ func createObjectMeta(namespace string, name string, finalizers []string) metav1
 		Finalizers: finalizers,
 	}
 }
+
+// TestContinueAppRestarts tests restarting the applications left waiting for a maintenance window by an upgrade
+// GIVEN a Verrazzano upgraded to 1.6.0 with an application that has a pod with several containers and a WebLogic
+// application whose restarts were deferred
+// WHEN continueAppRestarts is called once the maintenance window is open
+// THEN the applications are restarted one after the other with the restart version of the upgrade, each of them
+// once the restarted pods of the previous one are ready, and nothing is done once all the applications have been restarted
+func TestContinueAppRestarts(t *testing.T) {
+	asserts := assert.New(t)
+	config.SetDefaultBomFilePath(unitTestBomFile)
+	defer config.SetDefaultBomFilePath("")
+	config.TestProfilesDir = relativeProfilesDir
+	defer func() { config.TestProfilesDir = "" }()
+
+	// newAppPod creates a ready pod of an application with an application container and an Istio proxy
+	newAppPod := func(appName string, labels map[string]string, annotations map[string]string) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: appName + "-pod", Labels: map[string]string{"app.oam.dev/name": appName}, Annotations: annotations},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "hello:1.0"}, {Name: "istio-proxy", Image: "proxyv2:1.4.3"}}},
+			Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+		}
+		for k, v := range labels {
+			pod.Labels[k] = v
+		}
+		return pod
+	}
+	weblogic := map[string]string{"verrazzano.io/workload-type": "weblogic"}
+	clientSet := gofake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "hello", Labels: map[string]string{"istio-injection": "enabled"}}},
+		newAppPod("app1", nil, nil), newAppPod("app2", weblogic, nil))
+	k8sutil.SetFakeClient(clientSet)
+	defer k8sutil.ClearFakeClient()
+
+	vz := &vzapi.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status: vzapi.VerrazzanoStatus{
+			Version:            "1.6.0",
+			ApplicationRestart: &vzapi.ApplicationRestartStatus{RestartVersion: "upgrade-1.6.0", Total: 2, Deferred: []string{"hello/app1", "hello/app2"}},
+		},
+	}
+	scheme := newScheme()
+	_ = oamcore.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz,
+		&oamapi.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: "app1"}},
+		&oamapi.ApplicationConfiguration{ObjectMeta: metav1.ObjectMeta{Namespace: "hello", Name: "app2"}}).Build()
+	r := newVerrazzanoReconciler(c)
+	getRestartVersion := func(name string) string {
+		appConfig := &oamapi.ApplicationConfiguration{}
+		asserts.NoError(c.Get(context.TODO(), types.NamespacedName{Namespace: "hello", Name: name}, appConfig))
+		return appConfig.Annotations[vzconst.RestartVersionAnnotation]
+	}
+
+	res, err := r.continueAppRestarts(vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	asserts.Equal(appRestartCheckPeriod, res.RequeueAfter)
+	asserts.Equal("upgrade-1.6.0", getRestartVersion("app1"))
+	asserts.Empty(getRestartVersion("app2"))
+
+	// The pod of the first application is restarted with the restart version annotation of its pod template
+	_, err = clientSet.CoreV1().Pods("hello").Update(context.TODO(),
+		newAppPod("app1", nil, map[string]string{vzconst.RestartVersionAnnotation: "upgrade-1.6.0"}), metav1.UpdateOptions{})
+	asserts.NoError(err)
+	vz.Status.ApplicationRestart = &vzapi.ApplicationRestartStatus{RestartVersion: "upgrade-1.6.0", Total: 2, Pending: 1, InProgress: []string{"hello/app1"}}
+	res, err = r.continueAppRestarts(vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	asserts.Equal(appRestartCheckPeriod, res.RequeueAfter)
+	asserts.Equal("upgrade-1.6.0", getRestartVersion("app2"))
+
+	// The pod of the WebLogic application is restarted with the restart version label of the WebLogic operator
+	_, err = clientSet.CoreV1().Pods("hello").Update(context.TODO(),
+		newAppPod("app2", map[string]string{"verrazzano.io/workload-type": "weblogic", "weblogic.domainRestartVersion": "upgrade-1.6.0"}, nil), metav1.UpdateOptions{})
+	asserts.NoError(err)
+	vz.Status.ApplicationRestart = &vzapi.ApplicationRestartStatus{RestartVersion: "upgrade-1.6.0", Total: 2, Restarted: 1, InProgress: []string{"hello/app2"}}
+	res, err = r.continueAppRestarts(vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	asserts.Equal(ctrl.Result{}, res)
+
+	vz.Status.ApplicationRestart = &vzapi.ApplicationRestartStatus{RestartVersion: "upgrade-1.6.0", Total: 2, Restarted: 2}
+	res, err = r.continueAppRestarts(vzlog.DefaultLogger(), vz)
+	asserts.NoError(err)
+	asserts.Equal(ctrl.Result{}, res)
+}
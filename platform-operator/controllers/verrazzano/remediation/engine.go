@@ -0,0 +1,227 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	controllerName    = "RemediationEngine"
+	channelBufferSize = 100
+
+	// EventReasonPrefix is the prefix of the reason of the events recorded for remediation actions
+	EventReasonPrefix = "Remediation"
+)
+
+// getCurrentTime returns the current time, it is overridden by unit tests
+var getCurrentTime = time.Now
+
+// engine - holds the global instance of the Engine. Required by the on-demand rules checked by components that
+// don't have access to the Engine.
+var engine *Engine
+
+// StatusRecorder records a remediation action in the status of the Verrazzano resource
+type StatusRecorder func(vz *vzapi.Verrazzano, record vzapi.RemediationRecord)
+
+// ruleState is the state of a problem detected by a rule
+type ruleState struct {
+	detectedTime   time.Time
+	lastRepairTime time.Time
+	attempts       int
+	exhausted      bool
+}
+
+// Engine periodically evaluates the registered remediation rules, repairs the detected problems and records every
+// action taken as a Kubernetes event and in the status of the Verrazzano resource.
+type Engine struct {
+	client         clipkg.Client
+	eventRecorder  record.EventRecorder
+	statusRecorder StatusRecorder
+	tickTime       time.Duration
+	log            vzlog.VerrazzanoLogger
+	states         map[string]*ruleState
+	statesLock     sync.Mutex
+	shutdown       chan int // The channel on which shutdown signals are sent/received
+}
+
+// NewEngine - instantiate an Engine context
+func NewEngine(c clipkg.Client, eventRecorder record.EventRecorder, statusRecorder StatusRecorder, tick time.Duration) (*Engine, error) {
+	log, err := vzlog.EnsureResourceLogger(&vzlog.ResourceConfig{
+		Name:           controllerName,
+		Namespace:      "",
+		ID:             controllerName,
+		Generation:     0,
+		ControllerName: controllerName,
+	})
+	if err != nil {
+		zap.S().Errorf("Failed to create resource logger for %s: %v", controllerName, err)
+		return nil, err
+	}
+	engine = &Engine{
+		client:         c,
+		eventRecorder:  eventRecorder,
+		statusRecorder: statusRecorder,
+		tickTime:       tick,
+		log:            log,
+		states:         map[string]*ruleState{},
+	}
+	return engine, nil
+}
+
+// GetEngine - returns the value of engine
+func GetEngine() *Engine {
+	return engine
+}
+
+// Start starts the Engine if it is not already running.
+// It is safe to call Start multiple times, additional goroutines will not be created
+func (e *Engine) Start() {
+	if e.shutdown != nil {
+		// already running, so nothing to do
+		return
+	}
+	e.shutdown = make(chan int, channelBufferSize)
+
+	// goroutine evaluates the rules every e.tickTime. If a shutdown signal is received (or channel is closed),
+	// the goroutine returns.
+	go func() {
+		ticker := time.NewTicker(e.tickTime)
+		for {
+			select {
+			case <-ticker.C:
+				e.EvaluateRules()
+			case <-e.shutdown:
+				// shutdown event causes termination
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Pause pauses the Engine if it was running.
+// It is safe to call Pause multiple times
+func (e *Engine) Pause() {
+	if e.shutdown != nil {
+		close(e.shutdown)
+		e.shutdown = nil
+	}
+}
+
+// EvaluateRules evaluates the registered rules that are not on-demand
+func (e *Engine) EvaluateRules() {
+	for _, rule := range getRules() {
+		if rule.OnDemand {
+			continue
+		}
+		if _, err := e.evaluate(rule); err != nil {
+			e.log.ErrorfThrottled("Failed evaluating remediation rule %s: %v", rule.Name, err)
+		}
+	}
+}
+
+// Check evaluates a rule on demand, returning true if the problem repaired by the rule is still present
+func (e *Engine) Check(name string) (bool, error) {
+	rule, ok := GetRule(name)
+	if !ok {
+		return false, fmt.Errorf("Remediation rule %s is not registered", name)
+	}
+	return e.evaluate(rule)
+}
+
+// evaluate detects the problem repaired by a rule, and repairs it once the grace period has elapsed, within the
+// limits of the maximum attempts and backoff of the rule
+func (e *Engine) evaluate(rule Rule) (bool, error) {
+	detected, err := rule.Detect(e.log, e.client)
+	if err != nil {
+		return false, err
+	}
+
+	e.statesLock.Lock()
+	defer e.statesLock.Unlock()
+	if !detected {
+		delete(e.states, rule.Name)
+		return false, nil
+	}
+
+	now := getCurrentTime()
+	state, ok := e.states[rule.Name]
+	if !ok {
+		state = &ruleState{detectedTime: now}
+		e.states[rule.Name] = state
+		e.log.Infof("Remediation rule %s detected a problem with component %s", rule.Name, rule.Component)
+	}
+	if now.Before(state.detectedTime.Add(rule.GracePeriod)) {
+		e.log.Progressf("Waiting for the grace period of remediation rule %s to elapse", rule.Name)
+		return true, nil
+	}
+	if rule.MaxAttempts > 0 && state.attempts >= rule.MaxAttempts {
+		if !state.exhausted {
+			state.exhausted = true
+			e.record(rule, vzapi.RemediationAttemptsExhausted, state.attempts, fmt.Sprintf("The problem persists after %d repair attempts", state.attempts))
+		}
+		return true, nil
+	}
+	if !state.lastRepairTime.IsZero() && now.Before(state.lastRepairTime.Add(rule.Backoff)) {
+		return true, nil
+	}
+
+	state.attempts++
+	state.lastRepairTime = now
+	e.log.Infof("Remediation rule %s is repairing component %s, attempt %d", rule.Name, rule.Component, state.attempts)
+	if err := rule.Repair(e.log, e.client); err != nil {
+		e.record(rule, vzapi.RemediationFailed, state.attempts, err.Error())
+		return true, err
+	}
+	e.record(rule, vzapi.RemediationSucceeded, state.attempts, "The problem was repaired")
+
+	// The rules of the group have the same repair, so the repair starts their grace period over
+	if rule.Group != "" {
+		for _, r := range getRules() {
+			if r.Group == rule.Group && r.Name != rule.Name {
+				delete(e.states, r.Name)
+			}
+		}
+	}
+	return true, nil
+}
+
+// record records a remediation action as an event on the Verrazzano resource and in its status
+func (e *Engine) record(rule Rule, result vzapi.RemediationResult, attempt int, message string) {
+	vzList := &vzapi.VerrazzanoList{}
+	if err := e.client.List(context.TODO(), vzList); err != nil || len(vzList.Items) == 0 {
+		e.log.Infof("Remediation rule %s for component %s: %s, %s", rule.Name, rule.Component, result, message)
+		return
+	}
+	vz := &vzList.Items[0]
+
+	eventType := corev1.EventTypeNormal
+	if result != vzapi.RemediationSucceeded {
+		eventType = corev1.EventTypeWarning
+	}
+	if e.eventRecorder != nil {
+		e.eventRecorder.Eventf(vz, eventType, EventReasonPrefix+string(result), "Remediation rule %s for component %s, attempt %d: %s", rule.Name, rule.Component, attempt, message)
+	}
+	if e.statusRecorder != nil {
+		e.statusRecorder(vz, vzapi.RemediationRecord{
+			Attempt:   attempt,
+			Component: rule.Component,
+			Message:   message,
+			Result:    result,
+			Rule:      rule.Name,
+			Time:      getCurrentTime().UTC().Format(time.RFC3339),
+		})
+	}
+}
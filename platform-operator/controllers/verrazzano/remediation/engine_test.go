@@ -0,0 +1,227 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package remediation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testRule = "test-rule"
+
+var testTime = time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeProblem is a problem detected and repaired by a test rule
+type fakeProblem struct {
+	present   bool
+	repairs   int
+	repairErr error
+	fixes     bool
+}
+
+func (f *fakeProblem) detect(_ vzlog.VerrazzanoLogger, _ clipkg.Client) (bool, error) {
+	return f.present, nil
+}
+
+func (f *fakeProblem) repair(_ vzlog.VerrazzanoLogger, _ clipkg.Client) error {
+	f.repairs++
+	if f.repairErr != nil {
+		return f.repairErr
+	}
+	if f.fixes {
+		f.present = false
+	}
+	return nil
+}
+
+// TestGracePeriodAndBackoff tests that a problem is repaired once its grace period has elapsed, then after the backoff
+// GIVEN a rule with a grace period and a backoff detecting a persisting problem
+// WHEN the rules are evaluated over time
+// THEN the problem is repaired after the grace period, and again after the backoff, and each repair is recorded
+func TestGracePeriodAndBackoff(t *testing.T) {
+	problem := &fakeProblem{present: true}
+	registerTestRule(t, Rule{GracePeriod: 2 * time.Minute, Backoff: 5 * time.Minute}, problem)
+	engine, events, records := newTestEngine(t)
+
+	now := testTime
+	getCurrentTime = func() time.Time { return now }
+	engine.EvaluateRules()
+	assert.Equal(t, 0, problem.repairs)
+
+	now = testTime.Add(time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 0, problem.repairs)
+
+	now = testTime.Add(2 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 1, problem.repairs)
+
+	now = testTime.Add(6 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 1, problem.repairs)
+
+	now = testTime.Add(7 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 2, problem.repairs)
+
+	assert.Len(t, *records, 2)
+	assert.Equal(t, vzapi.RemediationRecord{
+		Attempt:   2,
+		Component: "test-component",
+		Message:   "The problem was repaired",
+		Result:    vzapi.RemediationSucceeded,
+		Rule:      testRule,
+		Time:      now.Format(time.RFC3339),
+	}, (*records)[1])
+	assert.Len(t, events.Events, 2)
+	assert.Contains(t, <-events.Events, "Normal RemediationSucceeded")
+}
+
+// TestMaxAttempts tests that a problem is not repaired more than the maximum attempts
+// GIVEN a rule with a maximum of two attempts and a repair that fails
+// WHEN the rules are evaluated repeatedly
+// THEN the problem is repaired twice, each failure is recorded and the exhausted attempts are recorded once
+func TestMaxAttempts(t *testing.T) {
+	problem := &fakeProblem{present: true, repairErr: fmt.Errorf("repair failed")}
+	registerTestRule(t, Rule{MaxAttempts: 2}, problem)
+	engine, events, records := newTestEngine(t)
+
+	for i := 0; i < 5; i++ {
+		engine.EvaluateRules()
+	}
+	assert.Equal(t, 2, problem.repairs)
+	assert.Len(t, *records, 3)
+	assert.Equal(t, vzapi.RemediationFailed, (*records)[0].Result)
+	assert.Equal(t, "repair failed", (*records)[0].Message)
+	assert.Equal(t, vzapi.RemediationFailed, (*records)[1].Result)
+	assert.Equal(t, vzapi.RemediationAttemptsExhausted, (*records)[2].Result)
+	assert.Len(t, events.Events, 3)
+	<-events.Events
+	<-events.Events
+	assert.Contains(t, <-events.Events, "Warning RemediationAttemptsExhausted")
+
+	// The attempts start over once the problem is gone
+	problem.present = false
+	engine.EvaluateRules()
+	problem.present = true
+	engine.EvaluateRules()
+	assert.Equal(t, 3, problem.repairs)
+}
+
+// TestGroupReset tests that a successful repair starts the grace period of the other rules of the group over
+// GIVEN two rules of the same group detecting a problem
+// WHEN one of the rules repairs the problem
+// THEN the other rule waits for its grace period again
+func TestGroupReset(t *testing.T) {
+	first := &fakeProblem{present: true, fixes: true}
+	second := &fakeProblem{present: true}
+	registerTestRule(t, Rule{Group: "test-group", GracePeriod: 2 * time.Minute}, first)
+	registerTestRule(t, Rule{Name: "other-rule", Group: "test-group", GracePeriod: 3 * time.Minute}, second)
+	engine, _, _ := newTestEngine(t)
+
+	now := testTime
+	getCurrentTime = func() time.Time { return now }
+	engine.EvaluateRules()
+	now = testTime.Add(2 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 1, first.repairs)
+
+	now = testTime.Add(4 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 0, second.repairs)
+	now = testTime.Add(5 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 1, second.repairs)
+}
+
+// TestOnDemandRule tests checking an on-demand rule
+// GIVEN an on-demand rule detecting a problem
+// WHEN the rules are evaluated and the rule is checked
+// THEN the rule is only evaluated when checked, and reports if the problem is still present
+func TestOnDemandRule(t *testing.T) {
+	problem := &fakeProblem{present: true, fixes: true}
+	registerTestRule(t, Rule{OnDemand: true, GracePeriod: time.Minute}, problem)
+	engine, _, _ := newTestEngine(t)
+
+	now := testTime
+	getCurrentTime = func() time.Time { return now }
+	engine.EvaluateRules()
+	now = testTime.Add(2 * time.Minute)
+	engine.EvaluateRules()
+	assert.Equal(t, 0, problem.repairs)
+
+	present, err := engine.Check(testRule)
+	assert.NoError(t, err)
+	assert.True(t, present)
+	now = testTime.Add(4 * time.Minute)
+	present, err = engine.Check(testRule)
+	assert.NoError(t, err)
+	assert.True(t, present)
+	assert.Equal(t, 1, problem.repairs)
+
+	present, err = engine.Check(testRule)
+	assert.NoError(t, err)
+	assert.False(t, present)
+
+	_, err = engine.Check("unknown-rule")
+	assert.Error(t, err)
+}
+
+// TestStart tests starting and pausing the engine
+func TestStart(t *testing.T) {
+	engine, _, _ := newTestEngine(t)
+	assert.Nil(t, engine.shutdown)
+	engine.Start()
+	assert.NotNil(t, engine.shutdown)
+	engine.Start()
+	assert.NotNil(t, engine.shutdown)
+	engine.Pause()
+	assert.Nil(t, engine.shutdown)
+	engine.Pause()
+	assert.Nil(t, engine.shutdown)
+}
+
+// registerTestRule registers a rule for the problem, unregistering it when the test completes
+func registerTestRule(t *testing.T, rule Rule, problem *fakeProblem) {
+	if rule.Name == "" {
+		rule.Name = testRule
+	}
+	rule.Component = "test-component"
+	rule.Detect = problem.detect
+	rule.Repair = problem.repair
+	RegisterRule(rule)
+	t.Cleanup(func() { UnregisterRule(rule.Name) })
+}
+
+// newTestEngine returns an engine recording the remediation actions with a fake event recorder and in a slice
+func newTestEngine(t *testing.T) (*Engine, *record.FakeRecorder, *[]vzapi.RemediationRecord) {
+	scheme := runtime.NewScheme()
+	_ = vzapi.AddToScheme(scheme)
+	vz := &vzapi.Verrazzano{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"}}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vz).Build()
+
+	events := record.NewFakeRecorder(10)
+	records := &[]vzapi.RemediationRecord{}
+	recordStatus := func(vz *vzapi.Verrazzano, record vzapi.RemediationRecord) {
+		*records = append(*records, record)
+	}
+	e, err := NewEngine(cli, events, recordStatus, time.Minute)
+	assert.NoError(t, err)
+	getCurrentTime = func() time.Time { return testTime }
+	t.Cleanup(func() {
+		getCurrentTime = time.Now
+		engine = nil
+	})
+	return e, events, records
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package remediation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	clipkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Rule is a remediation that repairs a known problem of a component
+type Rule struct {
+	// Name uniquely identifies the rule
+	Name string
+	// Component is the name of the component repaired by the rule
+	Component string
+	// Group is an optional name shared by rules that have the same repair. A successful repair resets the state of
+	// all the rules of the group.
+	Group string
+	// Detect returns true if the problem repaired by the rule is present
+	Detect func(log vzlog.VerrazzanoLogger, client clipkg.Client) (bool, error)
+	// Repair repairs the problem
+	Repair func(log vzlog.VerrazzanoLogger, client clipkg.Client) error
+	// GracePeriod is how long the problem must persist before it is repaired
+	GracePeriod time.Duration
+	// MaxAttempts is the maximum number of repairs while the problem persists, 0 means no limit
+	MaxAttempts int
+	// Backoff is the minimum time between two repairs of a persisting problem
+	Backoff time.Duration
+	// OnDemand rules are not evaluated periodically, only when checked by the component that owns them
+	OnDemand bool
+}
+
+var (
+	rules     = map[string]Rule{}
+	ruleNames []string
+	rulesLock sync.RWMutex
+)
+
+// RegisterRule registers a remediation rule, replacing any registered rule with the same name
+func RegisterRule(rule Rule) {
+	rulesLock.Lock()
+	defer rulesLock.Unlock()
+	if _, ok := rules[rule.Name]; !ok {
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	rules[rule.Name] = rule
+}
+
+// UnregisterRule unregisters a remediation rule
+func UnregisterRule(name string) {
+	rulesLock.Lock()
+	defer rulesLock.Unlock()
+	if _, ok := rules[name]; !ok {
+		return
+	}
+	delete(rules, name)
+	for i := range ruleNames {
+		if ruleNames[i] == name {
+			ruleNames = append(ruleNames[:i], ruleNames[i+1:]...)
+			break
+		}
+	}
+}
+
+// GetRule returns a registered remediation rule
+func GetRule(name string) (Rule, bool) {
+	rulesLock.RLock()
+	defer rulesLock.RUnlock()
+	rule, ok := rules[name]
+	return rule, ok
+}
+
+// getRules returns the registered remediation rules in registration order
+func getRules() []Rule {
+	rulesLock.RLock()
+	defer rulesLock.RUnlock()
+	var result []Rule
+	for _, name := range ruleNames {
+		result = append(result, rules[name])
+	}
+	return result
+}
@@ -57,7 +57,8 @@ func convertCertificateToClusterIssuerV1Beta1(effectiveCR *v1beta1.Verrazzano) e
 		effectiveCR.Spec.Components.ClusterIssuer = v1beta1.NewDefaultClusterIssuer()
 	}
 	clusterIssuerConfig := effectiveCR.Spec.Components.ClusterIssuer
-	if clusterIssuerConfig.IssuerConfig.CA == nil && clusterIssuerConfig.IssuerConfig.LetsEncrypt == nil {
+	if clusterIssuerConfig.IssuerConfig.CA == nil && clusterIssuerConfig.IssuerConfig.LetsEncrypt == nil &&
+		clusterIssuerConfig.IssuerConfig.ACME == nil && clusterIssuerConfig.IssuerConfig.Vault == nil {
 		clusterIssuerConfig.IssuerConfig.CA = v1beta1.NewDefaultClusterIssuer().CA
 	}
 
@@ -100,7 +101,8 @@ func convertCertificateToClusterIssuerV1Alpha1(effectiveCR *v1alpha1.Verrazzano)
 		effectiveCR.Spec.Components.ClusterIssuer = v1alpha1.NewDefaultClusterIssuer()
 	}
 	clusterIssuerConfig := effectiveCR.Spec.Components.ClusterIssuer
-	if clusterIssuerConfig.IssuerConfig.CA == nil && clusterIssuerConfig.IssuerConfig.LetsEncrypt == nil {
+	if clusterIssuerConfig.IssuerConfig.CA == nil && clusterIssuerConfig.IssuerConfig.LetsEncrypt == nil &&
+		clusterIssuerConfig.IssuerConfig.ACME == nil && clusterIssuerConfig.IssuerConfig.Vault == nil {
 		clusterIssuerConfig.IssuerConfig.CA = v1alpha1.NewDefaultClusterIssuer().CA
 	}
 
@@ -103,6 +103,19 @@ var v1alpha1Tests = []struct {
 		expectedIssuerConfig: v1alpha1.NewDefaultClusterIssuer(),
 		expectErr:            false,
 	},
+	{
+		testName:   "Vault ClusterIssuer",
+		certConfig: &v1alpha1.CertManagerComponent{Certificate: defaultCertConfigV1Alpha1},
+		issuerConfig: &v1alpha1.ClusterIssuerComponent{
+			ClusterResourceNamespace: constants.CertManagerNamespace,
+			IssuerConfig:             v1alpha1.IssuerConfig{Vault: &v1alpha1.VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"}},
+		},
+		expectedIssuerConfig: &v1alpha1.ClusterIssuerComponent{
+			ClusterResourceNamespace: constants.CertManagerNamespace,
+			IssuerConfig:             v1alpha1.IssuerConfig{Vault: &v1alpha1.VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"}},
+		},
+		expectErr: false,
+	},
 	{
 		testName:             "Empty CM Certificate nil ClusterIssuer",
 		certConfig:           &v1alpha1.CertManagerComponent{},
@@ -288,6 +301,19 @@ var v1beta1Tests = []struct {
 		expectedIssuerConfig: v1beta1.NewDefaultClusterIssuer(),
 		expectErr:            false,
 	},
+	{
+		testName:   "Vault ClusterIssuer",
+		certConfig: &v1beta1.CertManagerComponent{Certificate: defaultCertConfigV1Beta1},
+		issuerConfig: &v1beta1.ClusterIssuerComponent{
+			ClusterResourceNamespace: constants.CertManagerNamespace,
+			IssuerConfig:             v1beta1.IssuerConfig{Vault: &v1beta1.VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"}},
+		},
+		expectedIssuerConfig: &v1beta1.ClusterIssuerComponent{
+			ClusterResourceNamespace: constants.CertManagerNamespace,
+			IssuerConfig:             v1beta1.IssuerConfig{Vault: &v1beta1.VaultIssuer{Server: "http://vault:8200", Path: "pki/sign/verrazzano"}},
+		},
+		expectErr: false,
+	},
 	{
 		testName:             "Empty CM Certificate nil ClusterIssuer",
 		certConfig:           &v1beta1.CertManagerComponent{},
@@ -4,6 +4,7 @@
 package transform
 
 import (
+	"os"
 	"strings"
 
 	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
@@ -17,6 +18,56 @@ const (
 	baseProfile = "base"
 )
 
+// leveraged to replace method (unit testing)
+var getCustomProfileFunc vzprofiles.CustomProfileGetter
+
+// SetCustomProfileGetter sets the function which returns the custom profiles which are not built-in profiles
+func SetCustomProfileGetter(f vzprofiles.CustomProfileGetter) {
+	getCustomProfileFunc = f
+}
+
+// isCustomProfile returns true if the profile is a custom profile, which is merged through its chain of extended profiles
+func isCustomProfile(profile string) bool {
+	return getCustomProfileFunc != nil && profile != baseProfile && !vzprofiles.IsBuiltInProfile(profile)
+}
+
+// usesCustomProfile returns true if one of the profiles is a custom profile
+func usesCustomProfile(profiles []string) bool {
+	for _, profile := range profiles {
+		if isCustomProfile(profile) {
+			return true
+		}
+	}
+	return false
+}
+
+// getProfileData returns the v1beta1 profiles to merge for a list of profiles, the custom profiles are replaced by the
+// built-in profile at the root of their chain followed by the custom profiles of the chain
+func getProfileData(profiles []string) ([]string, error) {
+	var profileData []string
+	for _, profile := range profiles {
+		var customProfiles []*vzprofiles.CustomProfile
+		if isCustomProfile(profile) {
+			var err error
+			profile, customProfiles, err = vzprofiles.ResolveCustomProfile(profile, getCustomProfileFunc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		data, err := os.ReadFile(config.GetProfile(v1beta1.SchemeGroupVersion, profile))
+		if err != nil {
+			return nil, err
+		}
+		profileData = append(profileData, string(data))
+		for _, customProfile := range customProfiles {
+			if strings.TrimSpace(customProfile.Profile) != "" {
+				profileData = append(profileData, customProfile.Profile)
+			}
+		}
+	}
+	return profileData, nil
+}
+
 // GetEffectiveCR Creates an "effective" v1alpha1.Verrazzano CR based on the user defined resource merged with the profile definitions
 // - Effective CR == base profile + declared profiles + ActualCR (in order)
 // - last definition wins
@@ -29,6 +80,10 @@ func GetEffectiveCR(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error)
 	if len(actualCR.Spec.Profile) > 0 {
 		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
 	}
+	// Custom profiles are defined in the v1beta1 format, the effective CR is merged as a v1beta1 CR
+	if usesCustomProfile(profiles) {
+		return getEffectiveCRWithCustomProfile(actualCR)
+	}
 	var profileFiles []string
 	for _, profile := range profiles {
 		profileFiles = append(profileFiles, config.GetProfile(v1alpha1.SchemeGroupVersion, profile))
@@ -60,14 +115,28 @@ func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, e
 	if len(actualCR.Spec.Profile) > 0 {
 		profiles = append([]string{baseProfile}, strings.Split(string(actualCR.Spec.Profile), ",")...)
 	}
-	var profileFiles []string
-	for _, profile := range profiles {
-		profileFiles = append(profileFiles, config.GetProfile(v1beta1.SchemeGroupVersion, profile))
-	}
-	// Merge the profile files into an effective profile YAML string
-	effectiveCR, err := vzprofiles.MergeProfilesForV1beta1(actualCR, profileFiles...)
-	if err != nil {
-		return nil, err
+	var effectiveCR *v1beta1.Verrazzano
+	if usesCustomProfile(profiles) {
+		profileData, err := getProfileData(profiles)
+		if err != nil {
+			return nil, err
+		}
+		// Merge the built-in and custom profiles into an effective profile YAML string
+		effectiveCR, err = vzprofiles.MergeProfileDataForV1beta1(actualCR, profileData...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var profileFiles []string
+		for _, profile := range profiles {
+			profileFiles = append(profileFiles, config.GetProfile(v1beta1.SchemeGroupVersion, profile))
+		}
+		// Merge the profile files into an effective profile YAML string
+		var err error
+		effectiveCR, err = vzprofiles.MergeProfilesForV1beta1(actualCR, profileFiles...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	effectiveCR.Status = v1beta1.VerrazzanoStatus{} // Don't replicate the CR status in the effective config
 
@@ -78,3 +147,22 @@ func GetEffectiveV1beta1CR(actualCR *v1beta1.Verrazzano) (*v1beta1.Verrazzano, e
 
 	return effectiveCR, nil
 }
+
+// getEffectiveCRWithCustomProfile creates the effective v1alpha1.Verrazzano CR of a CR using a custom profile, by merging
+// the profiles with the CR converted to v1beta1
+func getEffectiveCRWithCustomProfile(actualCR *v1alpha1.Verrazzano) (*v1alpha1.Verrazzano, error) {
+	actualV1beta1CR := &v1beta1.Verrazzano{}
+	if err := actualCR.DeepCopy().ConvertTo(actualV1beta1CR); err != nil {
+		return nil, err
+	}
+	effectiveV1beta1CR, err := GetEffectiveV1beta1CR(actualV1beta1CR)
+	if err != nil {
+		return nil, err
+	}
+	effectiveCR := &v1alpha1.Verrazzano{}
+	if err := effectiveCR.ConvertFrom(effectiveV1beta1CR); err != nil {
+		return nil, err
+	}
+	effectiveCR.Status = v1alpha1.VerrazzanoStatus{}
+	return effectiveCR, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
+)
+
+const smallDevProfile = `apiVersion: install.verrazzano.io/v1beta1
+kind: Verrazzano
+spec:
+  components:
+    kiali:
+      enabled: false
+`
+
+const noMonitoringProfile = `apiVersion: install.verrazzano.io/v1beta1
+kind: Verrazzano
+spec:
+  components:
+    prometheusOperator:
+      enabled: false
+`
+
+var customProfiles = map[string]*vzprofiles.CustomProfile{
+	"small-dev":     {Name: "small-dev", Extends: "dev", Profile: smallDevProfile},
+	"no-monitoring": {Name: "no-monitoring", Extends: "small-dev", Profile: noMonitoringProfile},
+	"loop":          {Name: "loop", Extends: "loop"},
+}
+
+func getFakeCustomProfile(name string) (*vzprofiles.CustomProfile, error) {
+	return customProfiles[name], nil
+}
+
+func setupCustomProfiles(t *testing.T) {
+	config.TestProfilesDir = "../../../manifests/profiles"
+	SetCustomProfileGetter(getFakeCustomProfile)
+	t.Cleanup(func() {
+		config.TestProfilesDir = ""
+		SetCustomProfileGetter(nil)
+	})
+}
+
+// TestGetEffectiveV1beta1CRWithCustomProfile tests merging a custom profile into the effective v1beta1 CR
+// GIVEN a Verrazzano CR using a custom profile which extends another custom profile, extending the dev profile
+// WHEN the effective CR is created
+// THEN the dev profile and the custom profiles are merged, and the CR takes precedence over the profiles
+func TestGetEffectiveV1beta1CRWithCustomProfile(t *testing.T) {
+	setupCustomProfiles(t)
+	enabled := true
+	vz := &v1beta1.Verrazzano{
+		Spec: v1beta1.VerrazzanoSpec{
+			Profile: "no-monitoring",
+			Components: v1beta1.ComponentSpec{
+				Kiali: &v1beta1.KialiComponent{Enabled: &enabled},
+			},
+		},
+	}
+
+	effectiveCR, err := GetEffectiveV1beta1CR(vz)
+	assert.NoError(t, err)
+	assert.False(t, *effectiveCR.Spec.Components.PrometheusOperator.Enabled)
+	assert.True(t, *effectiveCR.Spec.Components.Kiali.Enabled)
+	// The dev profile enables a single OpenSearch node
+	assert.Len(t, effectiveCR.Spec.Components.OpenSearch.Nodes, 1)
+}
+
+// TestGetEffectiveCRWithCustomProfile tests merging a custom profile into the effective v1alpha1 CR
+// GIVEN a v1alpha1 Verrazzano CR using a custom profile
+// WHEN the effective CR is created
+// THEN the custom profile is merged
+func TestGetEffectiveCRWithCustomProfile(t *testing.T) {
+	setupCustomProfiles(t)
+	vz := &v1alpha1.Verrazzano{
+		Spec: v1alpha1.VerrazzanoSpec{Profile: "small-dev"},
+	}
+
+	effectiveCR, err := GetEffectiveCR(vz)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ProfileType("small-dev"), effectiveCR.Spec.Profile)
+	assert.False(t, *effectiveCR.Spec.Components.Kiali.Enabled)
+	assert.True(t, *effectiveCR.Spec.Components.PrometheusOperator.Enabled)
+}
+
+// TestGetEffectiveCRWithInvalidCustomProfile tests merging custom profiles which cannot be resolved
+// GIVEN a Verrazzano CR using a profile which does not exist, or a profile extending itself
+// WHEN the effective CR is created
+// THEN an error is returned
+func TestGetEffectiveCRWithInvalidCustomProfile(t *testing.T) {
+	setupCustomProfiles(t)
+
+	_, err := GetEffectiveV1beta1CR(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: "missing"}})
+	assert.ErrorContains(t, err, "The profile missing is not a built-in profile")
+
+	_, err = GetEffectiveV1beta1CR(&v1beta1.Verrazzano{Spec: v1beta1.VerrazzanoSpec{Profile: "loop"}})
+	assert.ErrorContains(t, err, "The profile loop extends itself")
+}
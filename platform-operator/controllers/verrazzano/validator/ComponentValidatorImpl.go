@@ -1,13 +1,18 @@
-// Copyright (c) 2022, Oracle and/or its affiliates.
+// Copyright (c) 2022, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package validator
 
 import (
+	"fmt"
+
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common/override"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ComponentValidatorImpl struct{}
@@ -95,3 +100,39 @@ func (c ComponentValidatorImpl) ValidateUpdateV1Beta1(old *v1beta1.Verrazzano, n
 	}
 	return errs
 }
+
+// ValidateOverridesSchemaV1Beta1 validates the install overrides of the enabled components against the values schemas
+// of their Helm charts. The keys which are not defined by the charts are returned as warnings, and the values of the
+// wrong type as errors.
+func (c ComponentValidatorImpl) ValidateOverridesSchemaV1Beta1(cli client.Client, vz *v1beta1.Verrazzano) ([]string, []error) {
+	var warnings []string
+	var errs []error
+
+	effectiveCR, err := transform.GetEffectiveV1beta1CR(vz)
+	if err != nil {
+		errs = append(errs, err)
+		return warnings, errs
+	}
+
+	for _, comp := range registry.GetComponents() {
+		if !comp.IsEnabled(effectiveCR) {
+			continue
+		}
+		overrides, ok := comp.GetOverrides(effectiveCR).([]v1beta1.Overrides)
+		if !ok || len(overrides) == 0 {
+			continue
+		}
+		overridesYAML, err := override.GetInstallOverridesYAMLUsingClient(cli, overrides, vz.Namespace)
+		if err != nil {
+			// The override sources may be created after the Verrazzano resource
+			warnings = append(warnings, fmt.Sprintf("Could not get the install overrides of the %s component: %v", comp.Name(), err))
+			continue
+		}
+		compWarnings, err := helm.ValidateOverridesSchema(comp, overridesYAML)
+		warnings = append(warnings, compWarnings...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return warnings, errs
+}
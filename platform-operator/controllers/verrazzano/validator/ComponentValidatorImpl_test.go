@@ -12,6 +12,9 @@ import (
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
 	cmcommon "github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager/common"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/common"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/helm"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/vzconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +30,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextv1json "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
 var disabled = false
@@ -502,6 +508,61 @@ func TestComponentValidatorImpl_ValidateUpdateV1Beta1(t *testing.T) {
 	}
 }
 
+// TestComponentValidatorImpl_ValidateOverridesSchemaV1Beta1 tests the ValidateOverridesSchemaV1Beta1 function
+// GIVEN a component installed from a chart with a values schema
+// WHEN its install overrides are validated
+// THEN unknown keys and missing override sources are returned as warnings, and type errors as errors
+func TestComponentValidatorImpl_ValidateOverridesSchemaV1Beta1(t *testing.T) {
+	config.TestProfilesDir = testProfilesDirectory
+	defer func() { config.TestProfilesDir = "" }()
+	registry.OverrideGetComponentsFn(func() []spi.Component {
+		return []spi.Component{
+			helm.HelmComponent{
+				ReleaseName: "keycloak",
+				ChartDir:    "../../../thirdparty/charts/keycloak",
+				GetInstallOverridesFunc: func(object runtime.Object) interface{} {
+					return object.(*vzapibeta.Verrazzano).Spec.Components.Keycloak.ValueOverrides
+				},
+			},
+		}
+	})
+	defer registry.ResetGetComponentsFn()
+
+	newVZ := func(overrides ...vzapibeta.Overrides) *vzapibeta.Verrazzano {
+		return &vzapibeta.Verrazzano{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+			Spec: vzapibeta.VerrazzanoSpec{
+				Components: vzapibeta.ComponentSpec{
+					Keycloak: &vzapibeta.KeycloakComponent{
+						InstallOverrides: vzapibeta.InstallOverrides{ValueOverrides: overrides},
+					},
+				},
+			},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).Build()
+	c := ComponentValidatorImpl{}
+
+	warnings, errs := c.ValidateOverridesSchemaV1Beta1(cli, newVZ(vzapibeta.Overrides{Values: &apiextv1json.JSON{Raw: []byte(`{"replicas": 2, "fullnameOverride": "test"}`)}}))
+	assert.Empty(t, warnings)
+	assert.Empty(t, errs)
+
+	warnings, errs = c.ValidateOverridesSchemaV1Beta1(cli, newVZ(vzapibeta.Overrides{Values: &apiextv1json.JSON{Raw: []byte(`{"replicass": 2}`)}}))
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "replicass")
+	assert.Empty(t, errs)
+
+	warnings, errs = c.ValidateOverridesSchemaV1Beta1(cli, newVZ(vzapibeta.Overrides{Values: &apiextv1json.JSON{Raw: []byte(`{"fullnameOverride": 2}`)}}))
+	assert.Empty(t, warnings)
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "fullnameOverride")
+
+	warnings, errs = c.ValidateOverridesSchemaV1Beta1(cli, newVZ(vzapibeta.Overrides{ConfigMapRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "values.yaml"}}))
+	assert.Len(t, warnings, 1)
+	assert.Empty(t, errs)
+}
+
 func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	k8scheme.AddToScheme(scheme)
@@ -80,35 +80,46 @@ type OperatorConfig struct {
 	// HealthCheckPeriodSeconds period for health check background task in seconds; a value of 0 disables health checks
 	HealthCheckPeriodSeconds int64
 
-	// MySQLCheckPeriodSeconds period for MySQL check background task in seconds; a value of 0 disables MySQL checks
-	MySQLCheckPeriodSeconds int64
+	// RemediationCheckPeriodSeconds period for the remediation background task in seconds; a value of 0 disables
+	// remediation checks
+	RemediationCheckPeriodSeconds int64
 
-	// MySQLRepairTimeoutSeconds is the amount of time the MySQL check background thread will allow to transpire between
+	// MySQLRepairTimeoutSeconds is the amount of time the MySQL remediation rules will allow to transpire between
 	// detecting a possible condition to repair, and initiating the repair logic.
 	MySQLRepairTimeoutSeconds int64
 
+	// CredentialRotationCheckPeriodSeconds period for the credential rotation background task in seconds; a value of 0
+	// disables credential rotation
+	CredentialRotationCheckPeriodSeconds int64
+
 	// DryRun Run installs in a dry-run mode
 	DryRun bool
 
 	// ExperimentalModules toggles the VPO to use the experimental modules feature
 	ExperimentalModules bool
+
+	// UpgradeManifestURLPrefix is the prefix of the platform operator manifest URLs which may be applied when the
+	// cluster agent requests an upgrade of the platform operator
+	UpgradeManifestURLPrefix string
 }
 
 // The singleton instance of the operator config
 var instance = OperatorConfig{
-	CertDir:                        "/etc/webhook/certs",
-	MetricsAddr:                    ":8080",
-	LeaderElectionEnabled:          false,
-	VersionCheckEnabled:            true,
-	RunWebhookInit:                 false,
-	RunWebhooks:                    false,
-	ResourceRequirementsValidation: false,
-	WebhookValidationEnabled:       true,
-	VerrazzanoRootDir:              rootDir,
-	HealthCheckPeriodSeconds:       60,
-	MySQLCheckPeriodSeconds:        60,
-	MySQLRepairTimeoutSeconds:      120,
-	ExperimentalModules:            false,
+	CertDir:                              "/etc/webhook/certs",
+	MetricsAddr:                          ":8080",
+	LeaderElectionEnabled:                false,
+	VersionCheckEnabled:                  true,
+	RunWebhookInit:                       false,
+	RunWebhooks:                          false,
+	ResourceRequirementsValidation:       false,
+	WebhookValidationEnabled:             true,
+	VerrazzanoRootDir:                    rootDir,
+	HealthCheckPeriodSeconds:             60,
+	RemediationCheckPeriodSeconds:        60,
+	MySQLRepairTimeoutSeconds:            120,
+	CredentialRotationCheckPeriodSeconds: 300,
+	ExperimentalModules:                  false,
+	UpgradeManifestURLPrefix:             "https://github.com/verrazzano/verrazzano/releases/download/",
 }
 
 // Set saves the operator config.  This should only be called at operator startup and during unit tests
@@ -25,8 +25,9 @@ func TestConfigDefaults(t *testing.T) {
 	asserts.False(conf.LeaderElectionEnabled, "LeaderElectionEnabled is incorrect")
 	asserts.Equal(":8080", conf.MetricsAddr, "MetricsAddr is incorrect")
 	asserts.Equal(int64(60), conf.HealthCheckPeriodSeconds, "Default health check period is correct")
-	asserts.Equal(int64(60), conf.MySQLCheckPeriodSeconds, "Default MySQL check period is correct")
+	asserts.Equal(int64(60), conf.RemediationCheckPeriodSeconds, "Default remediation check period is correct")
 	asserts.Equal(int64(120), conf.MySQLRepairTimeoutSeconds, "Default MySQL repair timeout is correct")
+	asserts.Equal(int64(300), conf.CredentialRotationCheckPeriodSeconds, "Default credential rotation check period is correct")
 	asserts.True(conf.VersionCheckEnabled, "VersionCheckEnabled is incorrect")
 	asserts.False(conf.RunWebhooks, "RunWebhooks is incorrect")
 	asserts.False(conf.ResourceRequirementsValidation, "ResourceRequirementsValidation default value is incorrect")
@@ -76,7 +77,7 @@ func TestSetConfig(t *testing.T) {
 		WebhookValidationEnabled:       false,
 		VerrazzanoRootDir:              "/root",
 		HealthCheckPeriodSeconds:       int64(0),
-		MySQLCheckPeriodSeconds:        int64(0),
+		RemediationCheckPeriodSeconds:  int64(0),
 		DryRun:                         true,
 	})
 
@@ -9,14 +9,27 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
 	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
 	"github.com/verrazzano/verrazzano/pkg/nginxutil"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
+	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/constants"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/componentdefinition"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/components"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/overrides"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/profiles"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/configmaps/upgrade"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/gatewayapi"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/secrets"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/certmanager/issuer"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/opensearch"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/registry"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/spi"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/component/thanos"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/credentials"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/healthcheck"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/mysqlcheck"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/reconcile"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/remediation"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/metricsexporter"
 	"go.uber.org/zap"
@@ -24,9 +37,11 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"os"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"strings"
 	"sync"
 	"time"
@@ -87,6 +102,9 @@ func StartPlatformOperator(vzconfig config.OperatorConfig, log *zap.SugaredLogge
 
 	metricsexporter.StartMetricsServer(log)
 
+	// The effective CR merges the custom profiles defined by ConfigMaps
+	transform.SetCustomProfileGetter(vzprofiles.NewConfigMapProfileGetter(mgr.GetClient()))
+
 	// Set up the reconciler
 	statusUpdater := healthcheck.NewStatusUpdater(mgr.GetClient())
 	healthCheck := healthcheck.NewHealthChecker(statusUpdater, mgr.GetClient(), time.Duration(vzconfig.HealthCheckPeriodSeconds)*time.Second)
@@ -123,12 +141,82 @@ func StartPlatformOperator(vzconfig config.OperatorConfig, log *zap.SugaredLogge
 		return errors.Wrap(err, "Failed to setup controller VerrazzanoConfigMaps")
 	}
 
-	// Setup MySQL checker
-	mysqlCheck, err := mysqlcheck.NewMySQLChecker(mgr.GetClient(), time.Duration(vzconfig.MySQLCheckPeriodSeconds)*time.Second, time.Duration(vzconfig.MySQLRepairTimeoutSeconds)*time.Second)
+	// Setup the custom profile ConfigMaps reconciler
+	if err = (&profiles.ProfileConfigMapsReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		StatusUpdater: statusUpdater,
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "Failed to setup controller for custom profile ConfigMaps")
+	}
+
+	// Setup the reconciler of the upgrade requests of the cluster agent
+	if err = (&upgrade.UpgradeRequestReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "Failed to setup controller for the upgrade request ConfigMap")
+	}
+
+	// Setup the remediation engine, recording the remediation actions in the Verrazzano status
+	mysqlcheck.RegisterRules(time.Duration(vzconfig.MySQLRepairTimeoutSeconds) * time.Second)
+	recordRemediation := func(vz *vzapi.Verrazzano, record vzapi.RemediationRecord) {
+		statusUpdater.Update(&healthcheck.UpdateEvent{
+			Verrazzano:   vz,
+			Remediations: []vzapi.RemediationRecord{record},
+		})
+	}
+	remediationEngine, err := remediation.NewEngine(mgr.GetClient(), mgr.GetEventRecorderFor(constants.VerrazzanoPlatformOperatorHelmName), recordRemediation, time.Duration(vzconfig.RemediationCheckPeriodSeconds)*time.Second)
 	if err != nil {
-		return errors.Wrap(err, "Failed starting MySQLChecker")
+		return errors.Wrap(err, "Failed starting RemediationEngine")
+	}
+	if vzconfig.RemediationCheckPeriodSeconds > 0 {
+		remediationEngine.Start()
+	}
+
+	// Record the OpenSearch snapshot status in the Verrazzano status
+	opensearch.SetSnapshotStatusRecorder(func(vz *vzapi.Verrazzano, status *vzapi.OpenSearchSnapshotStatus) {
+		statusUpdater.Update(&healthcheck.UpdateEvent{
+			Verrazzano: vz,
+			Snapshots:  status,
+		})
+	})
+
+	// Record the Thanos compactor status in the Verrazzano status
+	thanos.SetCompactorStatusRecorder(func(vz *vzapi.Verrazzano, status *vzapi.ThanosStatus) {
+		statusUpdater.Update(&healthcheck.UpdateEvent{
+			Verrazzano: vz,
+			Thanos:     status,
+		})
+	})
+
+	// Record the progress of long running component operations in the component status
+	spi.SetProgressRecorder(func(vz *vzapi.Verrazzano, component string, progress string) {
+		statusUpdater.Update(&healthcheck.UpdateEvent{
+			Verrazzano:        vz,
+			ComponentProgress: map[string]string{component: progress},
+		})
+	})
+
+	// The ClusterIssuer re-issues the certificates of the enabled components when the issuer changes
+	issuer.SetCertificateNamesFunc(func(ctx spi.ComponentContext) []types.NamespacedName {
+		var certNames []types.NamespacedName
+		for _, comp := range registry.GetComponents() {
+			if comp.IsEnabled(ctx.EffectiveCR()) {
+				certNames = append(certNames, comp.GetCertificateNames(ctx)...)
+			}
+		}
+		return certNames
+	})
+
+	// Setup credential rotator
+	if vzconfig.CredentialRotationCheckPeriodSeconds > 0 {
+		credentialRotator, err := credentials.NewCredentialRotator(mgr.GetClient(), statusUpdater, time.Duration(vzconfig.CredentialRotationCheckPeriodSeconds)*time.Second)
+		if err != nil {
+			return errors.Wrap(err, "Failed starting CredentialRotator")
+		}
+		credentialRotator.Start()
 	}
-	mysqlCheck.Start()
 
 	// Setup stacks reconciler
 	if err = (&components.ComponentConfigMapReconciler{
@@ -139,10 +227,45 @@ func StartPlatformOperator(vzconfig config.OperatorConfig, log *zap.SugaredLogge
 		return errors.Wrap(err, "Failed to setup controller for Verrazzano Stacks")
 	}
 
+	// Setup ComponentDefinition reconciler
+	if err = (&componentdefinition.Reconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		DryRun:        vzconfig.DryRun,
+		StatusUpdater: statusUpdater,
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "Failed to setup controller for ComponentDefinitions")
+	}
+
+	// Setup the Gateway API reconciler, rendering the Verrazzano ingresses as Gateway API resources
+	if err = (&gatewayapi.Reconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "Failed to setup controller for Gateway API")
+	}
+
 	if vzconfig.ExperimentalModules {
 		log.Infof("Experimental Modules API enabled")
 	}
 
+	// Migrate the stored Verrazzano resources to the storage version, the leader election makes sure a single
+	// operator instance runs the migration
+	apixClient, err := k8sutil.GetAPIExtV1Client()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get the apiextensions client")
+	}
+	dynamicClient, err := k8sutil.GetDynamicClient()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get the dynamic client")
+	}
+	if err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		startStorageVersionMigration(ctx, log, apixClient, dynamicClient)
+		return nil
+	})); err != nil {
+		return errors.Wrap(err, "Failed to setup the storage version migration")
+	}
+
 	// +kubebuilder:scaffold:builder
 	log.Info("Starting controller-runtime manager")
 	if err := mgr.Start(controllerruntime.SetupSignalHandler()); err != nil {
@@ -10,10 +10,13 @@ import (
 	"github.com/verrazzano/verrazzano/pkg/bom"
 	"github.com/verrazzano/verrazzano/pkg/constants"
 	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
 	moduleswebhooks "github.com/verrazzano/verrazzano/platform-operator/apis/modules/webhooks"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/webhooks"
+	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/transform"
 	"github.com/verrazzano/verrazzano/platform-operator/controllers/verrazzano/validator"
 	internalconfig "github.com/verrazzano/verrazzano/platform-operator/internal/config"
 	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/certificate"
@@ -124,6 +127,11 @@ func setupWebhooksWithManager(log *zap.SugaredLogger, mgr manager.Manager, kubeC
 	if err := (&installv1beta1.Verrazzano{}).SetupWebhookWithManager(mgr, log); err != nil {
 		return fmt.Errorf("Failed to setup install.v1beta1.Verrazzano webhook with manager: %v", err)
 	}
+	if err := (&installv1.Verrazzano{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("Failed to setup install.v1.Verrazzano webhook with manager: %v", err)
+	}
+	// The component validator merges the custom profiles defined by ConfigMaps, read them without a cache
+	transform.SetCustomProfileGetter(vzprofiles.NewConfigMapProfileGetter(mgr.GetAPIReader()))
 
 	mgr.GetWebhookServer().CertDir = config.CertDir
 
@@ -144,6 +152,11 @@ func setupWebhooksWithManager(log *zap.SugaredLogger, mgr manager.Manager, kubeC
 	mgr.GetWebhookServer().Register(webhooks.RequirementsV1beta1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1beta1{}})
 	mgr.GetWebhookServer().Register(webhooks.RequirementsV1alpha1Path, &webhook.Admission{Handler: &webhooks.RequirementsValidatorV1alpha1{}})
 
+	// register Helm values validator webhooks
+	helmValuesValidator := &webhook.Admission{Handler: &webhooks.HelmValuesValidator{Validator: validator.ComponentValidatorImpl{}}}
+	mgr.GetWebhookServer().Register(webhooks.HelmValuesV1beta1Path, helmValuesValidator)
+	mgr.GetWebhookServer().Register(webhooks.HelmValuesV1alpha1Path, helmValuesValidator)
+
 	// register MySQL install values webhooks
 	bomFile, err := bom.NewBom(internalconfig.GetDefaultBOMFilePath())
 	if err != nil {
@@ -190,6 +203,11 @@ func updateWebhookConfigurations(kubeClient *kubernetes.Clientset, log *zap.Suga
 		return fmt.Errorf("Failed to update requirements validation webhook configuration: %v", err)
 	}
 
+	log.Debug("Updating Helm values webhook configuration")
+	if err := updateValidatingWebhookConfiguration(kubeClient, webhooks.HelmValuesWebhook); err != nil {
+		return fmt.Errorf("Failed to update Helm values validation webhook configuration: %v", err)
+	}
+
 	log.Debug("Updating MySQL install values webhook configuration")
 	if err := updateValidatingWebhookConfiguration(kubeClient, webhooks.MysqlInstallValuesWebhook); err != nil {
 		return fmt.Errorf("Failed to update validation webhook configuration: %v", err)
@@ -0,0 +1,103 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operatorinit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/certificate"
+	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+const storageMigrationRetryPeriod = 30 * time.Second
+
+// startStorageVersionMigration migrates the stored Verrazzano resources to the storage version of the CRD, retrying
+// until it succeeds since the conversion webhook may not be available yet
+func startStorageVersionMigration(ctx context.Context, log *zap.SugaredLogger, apixClient apiextensionsv1client.ApiextensionsV1Interface, dynamicClient dynamic.Interface) {
+	_ = wait.PollImmediateUntil(storageMigrationRetryPeriod, func() (bool, error) {
+		if err := migrateStorageVersion(ctx, log, apixClient, dynamicClient); err != nil {
+			log.Infof("Failed to migrate the Verrazzano resources to the storage version, retrying: %v", err)
+			return false, nil
+		}
+		return true, nil
+	}, ctx.Done())
+}
+
+// migrateStorageVersion rewrites the Verrazzano resources, so that the API server stores them in the storage version
+// of the CRD, then records the storage version as the only stored version in the CRD status. This allows the
+// versions which are no longer stored to be removed from the CRD in a later release.
+func migrateStorageVersion(ctx context.Context, log *zap.SugaredLogger, apixClient apiextensionsv1client.ApiextensionsV1Interface, dynamicClient dynamic.Interface) error {
+	crd, err := apixClient.CustomResourceDefinitions().Get(ctx, certificate.CRDName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	storageVersion := getStorageVersion(crd)
+	if storageVersion == "" {
+		return fmt.Errorf("the CRD %s has no storage version", certificate.CRDName)
+	}
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		return nil
+	}
+
+	log.Infof("Migrating the Verrazzano resources stored in versions %v to the storage version %s", crd.Status.StoredVersions, storageVersion)
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: storageVersion, Resource: crd.Spec.Names.Plural}
+	vzList, err := dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range vzList.Items {
+		if err := rewriteResource(ctx, dynamicClient.Resource(gvr).Namespace(vzList.Items[i].GetNamespace()), vzList.Items[i].GetName()); err != nil {
+			return err
+		}
+	}
+
+	// The stored versions are updated with the latest CRD, otherwise the update fails with a conflict
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := apixClient.CustomResourceDefinitions().Get(ctx, certificate.CRDName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		_, err = apixClient.CustomResourceDefinitions().UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// rewriteResource updates a resource without changes, which stores it in the storage version
+func rewriteResource(ctx context.Context, resourceClient dynamic.ResourceInterface, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := resourceClient.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, err = resourceClient.Update(ctx, u, metav1.UpdateOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// getStorageVersion returns the storage version of a CRD
+func getStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
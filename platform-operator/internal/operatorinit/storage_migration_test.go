@@ -0,0 +1,83 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package operatorinit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/platform-operator/internal/k8s/certificate"
+	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	fakeapiExt "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var vzGVR = schema.GroupVersionResource{Group: "install.verrazzano.io", Version: "v1", Resource: "verrazzanos"}
+
+func newVerrazzanoCRD(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: certificate.CRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "install.verrazzano.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "verrazzanos", Kind: "Verrazzano"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v1alpha1", Served: true},
+				{Name: "v1beta1", Served: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+func newDynamicClient() *dynamicfake.FakeDynamicClient {
+	vz := &unstructured.Unstructured{}
+	vz.SetAPIVersion("install.verrazzano.io/v1")
+	vz.SetKind("Verrazzano")
+	vz.SetNamespace("default")
+	vz.SetName("verrazzano")
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{vzGVR: "VerrazzanoList"}, vz)
+}
+
+// TestMigrateStorageVersion tests migrating the Verrazzano resources to the storage version
+// GIVEN a Verrazzano CRD with resources stored in the v1beta1 and v1 versions
+// WHEN the storage version migration is run
+// THEN the Verrazzano resources are rewritten and v1 is recorded as the only stored version
+func TestMigrateStorageVersion(t *testing.T) {
+	apixClient := fakeapiExt.NewSimpleClientset(newVerrazzanoCRD("v1beta1", "v1")).ApiextensionsV1()
+	dynamicClient := newDynamicClient()
+
+	err := migrateStorageVersion(context.TODO(), zap.S(), apixClient, dynamicClient)
+	assert.NoError(t, err)
+
+	var updated []string
+	for _, action := range dynamicClient.Actions() {
+		if action.GetVerb() == "update" {
+			updated = append(updated, action.GetNamespace())
+		}
+	}
+	assert.Equal(t, []string{"default"}, updated)
+	crd, err := apixClient.CustomResourceDefinitions().Get(context.TODO(), certificate.CRDName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1"}, crd.Status.StoredVersions)
+}
+
+// TestMigrateStorageVersionMigrated tests the storage version migration of migrated Verrazzano resources
+// GIVEN a Verrazzano CRD with resources stored in the storage version only
+// WHEN the storage version migration is run
+// THEN the Verrazzano resources are not rewritten
+func TestMigrateStorageVersionMigrated(t *testing.T) {
+	apixClient := fakeapiExt.NewSimpleClientset(newVerrazzanoCRD("v1")).ApiextensionsV1()
+	dynamicClient := newDynamicClient()
+
+	err := migrateStorageVersion(context.TODO(), zap.S(), apixClient, dynamicClient)
+	assert.NoError(t, err)
+	assert.Empty(t, dynamicClient.Actions())
+}
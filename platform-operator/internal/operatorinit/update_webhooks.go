@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package operatorinit
@@ -73,7 +73,7 @@ func updateConversionWebhookConfiguration(apiextClient apiextensionsv1client.Api
 				},
 				CABundle: crt,
 			},
-			ConversionReviewVersions: []string{"v1beta1"},
+			ConversionReviewVersions: []string{"v1", "v1beta1"},
 		},
 	}
 	_, err = apiextClient.CustomResourceDefinitions().Update(context.TODO(), crd, metav1.UpdateOptions{})
@@ -1,4 +1,4 @@
-// Copyright (c) 2020, 2022, Oracle and/or its affiliates.
+// Copyright (c) 2020, 2023, Oracle and/or its affiliates.
 // Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
 
 package operatorinit
@@ -103,7 +103,7 @@ func TestUpdateConversionWebhookConfiguration(t *testing.T) {
 	asserts.Equal("/convert", *updatedCRD.Spec.Conversion.Webhook.ClientConfig.Service.Path)
 	asserts.Equal(int32(443), *updatedCRD.Spec.Conversion.Webhook.ClientConfig.Service.Port)
 	asserts.Equal(v12.WebhookConverter, updatedCRD.Spec.Conversion.Strategy)
-	asserts.Equal([]string{"v1beta1"}, updatedCRD.Spec.Conversion.Webhook.ConversionReviewVersions)
+	asserts.Equal([]string{"v1", "v1beta1"}, updatedCRD.Spec.Conversion.Webhook.ConversionReviewVersions)
 }
 
 // TestUpdateMutatingWebhookConfiguration tests that the CA Bundle is updated the specified MutatingWebhook configuration
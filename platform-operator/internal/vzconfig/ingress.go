@@ -4,6 +4,7 @@ package vzconfig
 
 import (
 	"fmt"
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
 	"github.com/verrazzano/verrazzano/pkg/nginxutil"
 	vzapi "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
@@ -65,11 +66,13 @@ func GetDNSSuffix(client client.Client, vz *vzapi.Verrazzano) (string, error) {
 		dnsSuffix = fmt.Sprintf("%s.%s", ingressIP, GetWildcardDomain(dnsConfig))
 	} else if dnsConfig.OCI != nil {
 		dnsSuffix = dnsConfig.OCI.DNSZoneName
+	} else if dnsConfig.RFC2136 != nil {
+		dnsSuffix = dnsConfig.RFC2136.DNSZoneName
 	} else if dnsConfig.External != nil {
 		dnsSuffix = dnsConfig.External.Suffix
 	}
 	if len(dnsSuffix) == 0 {
-		return "", fmt.Errorf("Invalid OCI DNS configuration, no zone name specified")
+		return "", fmt.Errorf("Invalid DNS configuration, no zone name specified")
 	}
 	return dnsSuffix, nil
 }
@@ -118,6 +121,15 @@ func GetIngressClassName(vz *vzapi.Verrazzano) string {
 	return defaultIngressClassName
 }
 
+// GetGatewayClassName gets the GatewayClass name of the generated Gateway API gateways or the default of "istio" if not specified
+func GetGatewayClassName(vz *vzapi.Verrazzano) string {
+	gatewayAPI := vz.Spec.Components.GatewayAPI
+	if gatewayAPI != nil && gatewayAPI.GatewayClassName != "" {
+		return gatewayAPI.GatewayClassName
+	}
+	return gatewayapi.DefaultGatewayClassName
+}
+
 // getVolumeClaimSpecTemplates returns the volume claim specs in v1beta1.
 func getVolumeClaimSpecTemplates(object runtime.Object) []v1beta1.VolumeClaimSpecTemplate {
 	if effectiveCR, ok := object.(*vzapi.Verrazzano); ok {
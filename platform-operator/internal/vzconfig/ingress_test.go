@@ -3,6 +3,7 @@
 package vzconfig
 
 import (
+	"github.com/verrazzano/verrazzano/pkg/gatewayapi"
 	"github.com/verrazzano/verrazzano/pkg/nginxutil"
 	"testing"
 
@@ -205,6 +206,7 @@ func TestGetDNSSuffix(t *testing.T) {
 		name              string
 		serviceType       vzapi.IngressType
 		dnsOCIZone        string
+		dnsRFC2136Zone    string
 		dnsExternalSuffix string
 		dnsWildCardSuffix string
 		lbIP              string
@@ -218,6 +220,12 @@ func TestGetDNSSuffix(t *testing.T) {
 			dnsOCIZone:  testDomain,
 			want:        testDomain,
 		},
+		{
+			name:           "lb with rfc2136 dns",
+			serviceType:    vzapi.LoadBalancer,
+			dnsRFC2136Zone: testDomain,
+			want:           testDomain,
+		},
 		{
 			name:              "lb with external dns",
 			serviceType:       vzapi.LoadBalancer,
@@ -313,6 +321,12 @@ func TestGetDNSSuffix(t *testing.T) {
 						DNSZoneName: testDomain,
 					},
 				}
+			} else if len(tt.dnsRFC2136Zone) > 0 {
+				vz.Spec.Components.DNS = &vzapi.DNSComponent{
+					RFC2136: &vzapi.RFC2136{
+						DNSZoneName: tt.dnsRFC2136Zone,
+					},
+				}
 			} else if len(tt.dnsExternalSuffix) > 0 {
 				vz.Spec.Components.DNS = &vzapi.DNSComponent{
 					External: &vzapi.External{
@@ -507,3 +521,20 @@ func TestGetIngressClassName(t *testing.T) {
 		},
 	}))
 }
+
+// TestGetGatewayClassName Tests the GetGatewayClassName utility function
+// GIVEN a call to GetGatewayClassName
+// WHEN a Verrazzano resource with a GatewayClass name specified is given
+// THEN the GatewayClass name specified in the Verrazzano resource is returned, the default otherwise
+func TestGetGatewayClassName(t *testing.T) {
+	assert.Equal(t, gatewayapi.DefaultGatewayClassName, GetGatewayClassName(&vzapi.Verrazzano{}))
+	assert.Equal(t, "foobar", GetGatewayClassName(&vzapi.Verrazzano{
+		Spec: vzapi.VerrazzanoSpec{
+			Components: vzapi.ComponentSpec{
+				GatewayAPI: &vzapi.GatewayAPIComponent{
+					GatewayClassName: "foobar",
+				},
+			},
+		},
+	}))
+}
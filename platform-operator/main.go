@@ -18,6 +18,7 @@ import (
 	clustersv1alpha1 "github.com/verrazzano/verrazzano/cluster-operator/apis/clusters/v1alpha1"
 	"github.com/verrazzano/verrazzano/pkg/helm"
 	vzlog "github.com/verrazzano/verrazzano/pkg/log"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
 	installv1alpha1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
 	installv1beta1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/validators"
@@ -42,6 +43,7 @@ func init() {
 	_ = vmov1.AddToScheme(scheme)
 	_ = installv1alpha1.AddToScheme(scheme)
 	_ = installv1beta1.AddToScheme(scheme)
+	_ = installv1.AddToScheme(scheme)
 	_ = clustersv1alpha1.AddToScheme(scheme)
 
 	_ = istioclinet.AddToScheme(scheme)
@@ -93,11 +95,17 @@ func main() {
 	flag.BoolVar(&helm.Debug, "helm-debug", helm.Debug, "Add the --debug flag to helm commands")
 	flag.Int64Var(&config.HealthCheckPeriodSeconds, "health-check-period", config.HealthCheckPeriodSeconds,
 		"Health check period seconds; set to 0 to disable health checks")
-	flag.Int64Var(&config.MySQLCheckPeriodSeconds, "mysql-check-period", config.MySQLCheckPeriodSeconds,
-		"MySQL check period seconds; set to 0 to disable MySQL checks")
+	flag.Int64Var(&config.RemediationCheckPeriodSeconds, "remediation-check-period", config.RemediationCheckPeriodSeconds,
+		"Remediation check period seconds; set to 0 to disable remediation checks")
+	flag.Int64Var(&config.RemediationCheckPeriodSeconds, "mysql-check-period", config.RemediationCheckPeriodSeconds,
+		"Deprecated, use remediation-check-period")
 	flag.Int64Var(&config.MySQLRepairTimeoutSeconds, "mysql-repair-timeout", config.MySQLRepairTimeoutSeconds,
 		"MySQL repair timeout seconds")
+	flag.Int64Var(&config.CredentialRotationCheckPeriodSeconds, "credential-rotation-check-period", config.CredentialRotationCheckPeriodSeconds,
+		"Credential rotation check period seconds; set to 0 to disable credential rotation")
 	flag.BoolVar(&config.ExperimentalModules, "experimental-modules", config.ExperimentalModules, "enable experimental modules")
+	flag.StringVar(&config.UpgradeManifestURLPrefix, "upgrade-manifest-url-prefix", config.UpgradeManifestURLPrefix,
+		"The prefix of the platform operator manifest URLs allowed in the upgrade requests of the cluster agent")
 
 	// Add the zap logger flag set to the CLI.
 	opts := kzap.Options{}
@@ -0,0 +1,68 @@
+#!/bin/bash
+#
+# Copyright (c) 2023, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+#
+# Runs a BIND DNS server in a container on the network of the KIND cluster nodes, to test RFC2136 DNS without a cloud
+# DNS service. The zone accepts dynamic updates and zone transfers signed with a generated TSIG key, and the key is
+# stored in the TSIG secret of the verrazzano-install namespace.
+#
+# Usage: install-bind-dns.sh <zone name> [<TSIG secret name>]
+#
+# The IP address of the DNS server is written to stdout, for the nameserver field of the RFC2136 DNS configuration.
+
+set -e
+
+ZONE_NAME=${1:-"verrazzano.test"}
+TSIG_SECRET_NAME=${2:-"rfc2136-tsig"}
+TSIG_KEY_NAME=${TSIG_KEY_NAME:-"externaldns-key"}
+BIND_CONTAINER_NAME=${BIND_CONTAINER_NAME:-"verrazzano-bind"}
+BIND_IMAGE=${BIND_IMAGE:-"ubuntu/bind9:9.18-22.04_beta"}
+KIND_NETWORK=${KIND_NETWORK:-"kind"}
+
+BIND_CONFIG_DIR=$(mktemp -d)
+trap 'rm -rf ${BIND_CONFIG_DIR}' EXIT
+
+TSIG_KEY=$(head -c 32 /dev/urandom | base64)
+
+cat > "${BIND_CONFIG_DIR}/named.conf" <<EOF
+options {
+  directory "/var/cache/bind";
+  recursion no;
+  allow-query { any; };
+  listen-on { any; };
+  listen-on-v6 { none; };
+};
+
+key "${TSIG_KEY_NAME}" {
+  algorithm hmac-sha256;
+  secret "${TSIG_KEY}";
+};
+
+zone "${ZONE_NAME}" {
+  type primary;
+  file "/var/lib/bind/${ZONE_NAME}.zone";
+  allow-update { key "${TSIG_KEY_NAME}"; };
+  allow-transfer { key "${TSIG_KEY_NAME}"; };
+};
+EOF
+
+cat > "${BIND_CONFIG_DIR}/${ZONE_NAME}.zone" <<EOF
+\$TTL 60
+@ IN SOA ns.${ZONE_NAME}. admin.${ZONE_NAME}. ( 1 60 60 604800 60 )
+@ IN NS ns.${ZONE_NAME}.
+ns IN A 127.0.0.1
+EOF
+
+docker rm -f "${BIND_CONTAINER_NAME}" > /dev/null 2>&1 || true
+docker run -d --name "${BIND_CONTAINER_NAME}" --network "${KIND_NETWORK}" "${BIND_IMAGE}" > /dev/null
+docker cp "${BIND_CONFIG_DIR}/named.conf" "${BIND_CONTAINER_NAME}:/etc/bind/named.conf"
+docker cp "${BIND_CONFIG_DIR}/${ZONE_NAME}.zone" "${BIND_CONTAINER_NAME}:/var/lib/bind/${ZONE_NAME}.zone"
+docker exec "${BIND_CONTAINER_NAME}" chown -R bind:bind /var/lib/bind
+docker restart "${BIND_CONTAINER_NAME}" > /dev/null
+
+kubectl create namespace verrazzano-install --dry-run=client -o yaml | kubectl apply -f - > /dev/null
+kubectl create secret generic "${TSIG_SECRET_NAME}" -n verrazzano-install --from-literal=secret="${TSIG_KEY}" \
+  --dry-run=client -o yaml | kubectl apply -f - > /dev/null
+
+docker inspect -f "{{(index .NetworkSettings.Networks \"${KIND_NETWORK}\").IPAddress}}" "${BIND_CONTAINER_NAME}"
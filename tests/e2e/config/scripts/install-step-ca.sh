@@ -0,0 +1,39 @@
+#!/bin/bash
+#
+# Copyright (c) 2023, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+#
+# Runs a step-ca server with an ACME provisioner in a container on the network of the KIND cluster nodes, to test the
+# ACME ClusterIssuer without a public ACME server.
+#
+# Usage: install-step-ca.sh
+#
+# The ACME directory URL of the server is written to stdout, for the server field of the ACME issuer configuration.
+# The server certificate is signed by the step-ca root, so the issuer configuration must skip the TLS verification.
+# The step-ca root certificate is written to STEP_CA_ROOT_FILE, create the CA bundle secret of the issuer from it.
+
+set -e
+
+STEP_CA_CONTAINER_NAME=${STEP_CA_CONTAINER_NAME:-"verrazzano-step-ca"}
+STEP_CA_IMAGE=${STEP_CA_IMAGE:-"smallstep/step-ca:0.23.2"}
+KIND_NETWORK=${KIND_NETWORK:-"kind"}
+STEP_CA_ROOT_FILE=${STEP_CA_ROOT_FILE:-"/tmp/step-ca-root.crt"}
+
+docker rm -f "${STEP_CA_CONTAINER_NAME}" > /dev/null 2>&1 || true
+docker run -d --name "${STEP_CA_CONTAINER_NAME}" --network "${KIND_NETWORK}" \
+  -e DOCKER_STEPCA_INIT_NAME="Verrazzano Test CA" \
+  -e DOCKER_STEPCA_INIT_DNS_NAMES="localhost,${STEP_CA_CONTAINER_NAME}" \
+  -e DOCKER_STEPCA_INIT_ACME=true \
+  "${STEP_CA_IMAGE}" > /dev/null
+
+for i in {1..30}; do
+  if docker exec "${STEP_CA_CONTAINER_NAME}" step ca health > /dev/null 2>&1; then
+    break
+  fi
+  sleep 2
+done
+
+docker exec "${STEP_CA_CONTAINER_NAME}" cat /home/step/certs/root_ca.crt > "${STEP_CA_ROOT_FILE}"
+
+STEP_CA_IP=$(docker inspect -f "{{(index .NetworkSettings.Networks \"${KIND_NETWORK}\").IPAddress}}" "${STEP_CA_CONTAINER_NAME}")
+echo "https://${STEP_CA_IP}:9000/acme/acme/directory"
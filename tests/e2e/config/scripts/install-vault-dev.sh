@@ -0,0 +1,57 @@
+#!/bin/bash
+#
+# Copyright (c) 2023, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+#
+# Runs a Vault server in dev mode in a container on the network of the KIND cluster nodes, to test the Vault
+# ClusterIssuer. The PKI secrets engine signs the certificates with a generated root CA, and the AppRole secret ID used by
+# cert-manager is stored in the AppRole secret of the cert-manager namespace.
+#
+# Usage: install-vault-dev.sh [<AppRole secret name>]
+#
+# The address of the Vault server and the AppRole role ID are written to stdout, separated by a space.
+
+set -e
+
+APPROLE_SECRET_NAME=${1:-"vault-approle"}
+VAULT_CONTAINER_NAME=${VAULT_CONTAINER_NAME:-"verrazzano-vault"}
+VAULT_IMAGE=${VAULT_IMAGE:-"hashicorp/vault:1.13"}
+VAULT_PKI_ROLE=${VAULT_PKI_ROLE:-"verrazzano"}
+KIND_NETWORK=${KIND_NETWORK:-"kind"}
+
+docker rm -f "${VAULT_CONTAINER_NAME}" > /dev/null 2>&1 || true
+docker run -d --name "${VAULT_CONTAINER_NAME}" --network "${KIND_NETWORK}" --cap-add IPC_LOCK \
+  -e VAULT_DEV_ROOT_TOKEN_ID=root -e VAULT_DEV_LISTEN_ADDRESS=0.0.0.0:8200 "${VAULT_IMAGE}" > /dev/null
+
+function vault_cmd() {
+  docker exec -e VAULT_ADDR=http://127.0.0.1:8200 -e VAULT_TOKEN=root "${VAULT_CONTAINER_NAME}" vault "$@"
+}
+
+for i in {1..30}; do
+  if vault_cmd status > /dev/null 2>&1; then
+    break
+  fi
+  sleep 2
+done
+
+vault_cmd secrets enable pki > /dev/null
+vault_cmd secrets tune -max-lease-ttl=8760h pki > /dev/null
+vault_cmd write pki/root/generate/internal common_name="Verrazzano Test Vault CA" ttl=8760h > /dev/null
+vault_cmd write "pki/roles/${VAULT_PKI_ROLE}" allow_any_name=true allow_ip_sans=true max_ttl=720h > /dev/null
+
+vault_cmd policy write cert-manager - > /dev/null <<EOF
+path "pki/sign/${VAULT_PKI_ROLE}" {
+  capabilities = ["create", "update"]
+}
+EOF
+vault_cmd auth enable approle > /dev/null
+vault_cmd write auth/approle/role/cert-manager token_policies=cert-manager > /dev/null
+ROLE_ID=$(vault_cmd read -field=role_id auth/approle/role/cert-manager/role-id)
+SECRET_ID=$(vault_cmd write -f -field=secret_id auth/approle/role/cert-manager/secret-id)
+
+kubectl create namespace cert-manager --dry-run=client -o yaml | kubectl apply -f - > /dev/null
+kubectl create secret generic "${APPROLE_SECRET_NAME}" -n cert-manager --from-literal=secretId="${SECRET_ID}" \
+  --dry-run=client -o yaml | kubectl apply -f - > /dev/null
+
+VAULT_IP=$(docker inspect -f "{{(index .NetworkSettings.Networks \"${KIND_NETWORK}\").IPAddress}}" "${VAULT_CONTAINER_NAME}")
+echo "http://${VAULT_IP}:8200 ${ROLE_ID}"
@@ -0,0 +1,48 @@
+#!/bin/bash
+
+# Copyright (c) 2023, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+# Configures the install config file for an ACME or Vault ClusterIssuer, for example with the servers of
+# install-step-ca.sh or install-vault-dev.sh
+#
+# Usage: process_custom_issuer_install_yaml.sh <install config> acme <ACME directory URL> [<CA bundle secret name>]
+#        process_custom_issuer_install_yaml.sh <install config> vault <Vault address> <AppRole role ID> [<AppRole secret name>]
+
+INSTALL_CONFIG_TO_EDIT=$1
+ISSUER_TYPE=$2
+ISSUER_SERVER=$3
+
+if [ -z "${ISSUER_SERVER}" ]; then
+  echo "The issuer server is required"
+  exit 1
+fi
+
+echo "Editing install config file for the ${ISSUER_TYPE} ClusterIssuer ${INSTALL_CONFIG_TO_EDIT}"
+yq -i eval ".spec.environmentName = \"${VZ_ENVIRONMENT_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.profile = \"${INSTALL_PROFILE}\"" ${INSTALL_CONFIG_TO_EDIT}
+case "${ISSUER_TYPE}" in
+  acme)
+    yq -i eval ".spec.components.clusterIssuer.acme.server = \"${ISSUER_SERVER}\"" ${INSTALL_CONFIG_TO_EDIT}
+    yq -i eval ".spec.components.clusterIssuer.acme.skipTLSVerify = true" ${INSTALL_CONFIG_TO_EDIT}
+    yq -i eval ".spec.components.clusterIssuer.acme.solver = \"http01\"" ${INSTALL_CONFIG_TO_EDIT}
+    ACME_CA_BUNDLE_SECRET=$4
+    if [ -n "${ACME_CA_BUNDLE_SECRET}" ]; then
+      yq -i eval ".spec.components.clusterIssuer.acme.caBundleSecret = \"${ACME_CA_BUNDLE_SECRET}\"" ${INSTALL_CONFIG_TO_EDIT}
+    fi
+    ;;
+  vault)
+    VAULT_ROLE_ID=$4
+    VAULT_APPROLE_SECRET=${5:-"vault-approle"}
+    yq -i eval ".spec.components.clusterIssuer.vault.server = \"${ISSUER_SERVER}\"" ${INSTALL_CONFIG_TO_EDIT}
+    yq -i eval ".spec.components.clusterIssuer.vault.path = \"pki/sign/verrazzano\"" ${INSTALL_CONFIG_TO_EDIT}
+    yq -i eval ".spec.components.clusterIssuer.vault.appRole.roleID = \"${VAULT_ROLE_ID}\"" ${INSTALL_CONFIG_TO_EDIT}
+    yq -i eval ".spec.components.clusterIssuer.vault.appRole.secretName = \"${VAULT_APPROLE_SECRET}\"" ${INSTALL_CONFIG_TO_EDIT}
+    ;;
+  *)
+    echo "Unsupported issuer type ${ISSUER_TYPE}, expected acme or vault"
+    exit 1
+    ;;
+esac
+
+cat ${INSTALL_CONFIG_TO_EDIT}
@@ -0,0 +1,27 @@
+#!/bin/bash
+
+# Copyright (c) 2023, Oracle and/or its affiliates.
+# Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+# Configures the install config file for RFC2136 DNS, for example with the BIND server of install-bind-dns.sh
+
+INSTALL_CONFIG_TO_EDIT=$1
+RFC2136_NAMESERVER=$2
+RFC2136_ZONE_NAME=${3:-"verrazzano.test"}
+RFC2136_TSIG_SECRET=${4:-"rfc2136-tsig"}
+RFC2136_TSIG_KEY_NAME=${TSIG_KEY_NAME:-"externaldns-key"}
+
+if [ -z "${RFC2136_NAMESERVER}" ]; then
+  echo "The RFC2136 nameserver is required"
+  exit 1
+fi
+
+echo "Editing install config file for RFC2136 DNS ${INSTALL_CONFIG_TO_EDIT}"
+yq -i eval ".spec.environmentName = \"${VZ_ENVIRONMENT_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.profile = \"${INSTALL_PROFILE}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.nameserver = \"${RFC2136_NAMESERVER}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.dnsZoneName = \"${RFC2136_ZONE_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigKeyName = \"${RFC2136_TSIG_KEY_NAME}\"" ${INSTALL_CONFIG_TO_EDIT}
+yq -i eval ".spec.components.dns.rfc2136.tsigSecret = \"${RFC2136_TSIG_SECRET}\"" ${INSTALL_CONFIG_TO_EDIT}
+
+cat ${INSTALL_CONFIG_TO_EDIT}
@@ -33,6 +33,9 @@ func GetDNS(cr *vzapi.Verrazzano) string {
 		if cr.Spec.Components.DNS.OCI != nil {
 			return cr.Spec.Components.DNS.OCI.DNSZoneName
 		}
+		if cr.Spec.Components.DNS.RFC2136 != nil {
+			return cr.Spec.Components.DNS.RFC2136.DNSZoneName
+		}
 		if cr.Spec.Components.DNS.External != nil {
 			return cr.Spec.Components.DNS.External.Suffix
 		}
@@ -0,0 +1,23 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package app
+
+import (
+	"github.com/spf13/cobra"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+)
+
+const (
+	CommandName = "app"
+	helpShort   = "Query Verrazzano applications"
+	helpLong    = `The command 'app' has sub-commands that query the OAM applications deployed to Verrazzano`
+)
+
+// NewCmdApp - create the app command and its sub-commands
+func NewCmdApp(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.AddCommand(NewCmdAppStatus(vzHelper))
+	return cmd
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/spf13/cobra"
+	"github.com/verrazzano/verrazzano/application-operator/controllers/apphealth"
+	"github.com/verrazzano/verrazzano/pkg/log/vzlog"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	statusCommandName = "status"
+	statusHelpShort   = "Status of a Verrazzano application"
+	statusHelpLong    = `The command 'app status' reports the health of an OAM application as a tree of its components, workloads, traits and generated resources`
+	statusHelpExample = `
+vz app status hello-helidon --namespace hello-helidon
+vz app status hello-helidon -n hello-helidon --context minikube`
+)
+
+func NewCmdAppStatus(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, statusCommandName, statusHelpShort, statusHelpLong)
+	cmd.Use = "status <name>"
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdAppStatus(cmd, args[0], vzHelper)
+	}
+	cmd.Example = statusHelpExample
+	cmd.PersistentFlags().StringP(constants.NamespaceFlag, constants.NamespaceFlagShorthand, constants.NamespaceFlagDefault, constants.NamespaceFlagHelp)
+
+	return cmd
+}
+
+// runCmdAppStatus - run the "vz app status" command
+func runCmdAppStatus(cmd *cobra.Command, name string, vzHelper helpers.VZHelper) error {
+	namespace, err := cmd.PersistentFlags().GetString(constants.NamespaceFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", constants.NamespaceFlag, err.Error())
+	}
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	appConfig := &oamv1.ApplicationConfiguration{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, appConfig); err != nil {
+		return fmt.Errorf("Failed to get the application configuration %s/%s: %s", namespace, name, err.Error())
+	}
+	root, err := apphealth.BuildGraph(context.TODO(), client, vzlog.DefaultLogger(), appConfig)
+	if err != nil {
+		return fmt.Errorf("Failed to get the resources of the application configuration %s/%s: %s", namespace, name, err.Error())
+	}
+
+	out := vzHelper.GetOutputStream()
+	fmt.Fprintf(out, "\nApplication Status\n  Name: %s\n  Namespace: %s\n  Health: %s\n\n", appConfig.Name, appConfig.Namespace, healthText(root))
+	printNode(out, root, "", "")
+	return nil
+}
+
+// printNode prints a node of the dependency graph and its children as a tree
+func printNode(out io.Writer, node *apphealth.Node, prefix string, childPrefix string) {
+	fmt.Fprintf(out, "%s%s/%s [%s]\n", prefix, node.Kind, node.Name, healthText(node))
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			printNode(out, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			printNode(out, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+// healthText returns the health of a node with the reason if it is not healthy
+func healthText(node *apphealth.Node) string {
+	if node.Healthy {
+		return "Healthy"
+	}
+	if len(node.Reason) == 0 {
+		return "Unhealthy"
+	}
+	return fmt.Sprintf("Unhealthy: %s", node.Reason)
+}
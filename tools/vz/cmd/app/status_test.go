@@ -0,0 +1,94 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package app
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	oamrt "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	oamv1 "github.com/crossplane/oam-kubernetes-runtime/apis/core/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace = "hello"
+	testAppName   = "hello-app"
+)
+
+// runAppStatus runs the app status command for the test application and returns the output
+func runAppStatus(t *testing.T, objs ...client.Object) (string, error) {
+	appConfig := &oamv1.ApplicationConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testAppName},
+		Status: oamv1.ApplicationConfigurationStatus{
+			Workloads: []oamv1.WorkloadStatus{
+				{ComponentName: "hello-component", Reference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "hello-deployment"}},
+				{ComponentName: "other-component", Reference: oamrt.TypedReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "other-deployment"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(append(objs, appConfig)...).Build()
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	cmd := NewCmdApp(rc)
+	cmd.SetArgs([]string{"status", testAppName, "--" + constants.NamespaceFlag, testNamespace})
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestAppStatusCmd tests the app status command
+// GIVEN an application with a ready Deployment and a Deployment that does not exist
+//
+//	WHEN I run the command vz app status
+//	THEN expect a tree of the application resources with the reasons the application is not healthy
+func TestAppStatusCmd(t *testing.T) {
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "hello-deployment"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	result, err := runAppStatus(t, deployment)
+	assert.NoError(t, err)
+
+	expected := `
+Application Status
+  Name: hello-app
+  Namespace: hello
+  Health: Unhealthy: Deployment other-deployment not found
+
+ApplicationConfiguration/hello-app [Unhealthy: Deployment other-deployment not found]
+├── Component/hello-component [Healthy]
+│   └── Deployment/hello-deployment [Healthy]
+└── Component/other-component [Unhealthy: Deployment other-deployment not found]
+    └── Deployment/other-deployment [Unhealthy: Deployment other-deployment not found]
+`
+	assert.Equal(t, expected, result)
+}
+
+// TestAppStatusCmdNotFound tests the app status command for an application that does not exist
+// GIVEN no application with the requested name
+//
+//	WHEN I run the command vz app status
+//	THEN expect an error
+func TestAppStatusCmdNotFound(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: new(bytes.Buffer)})
+	rc.SetClient(fake.NewClientBuilder().WithScheme(helpers.NewScheme()).Build())
+	cmd := NewCmdApp(rc)
+	cmd.SetArgs([]string{"status", "missing"})
+	assert.ErrorContains(t, cmd.Execute(), "Failed to get the application configuration default/missing")
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1alpha1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	CommandName = "convert"
+	helpShort   = "Convert Verrazzano custom resource files between API versions"
+	helpLong    = `The command 'convert' converts the Verrazzano custom resources of a file to another API version of the install.verrazzano.io API group, without connecting to a cluster.
+The deprecated fields which do not exist in the v1 API are preserved in an annotation, so that converting the resources back is lossless.`
+	helpExample = `
+# Convert a Verrazzano custom resource file to the v1 API
+vz convert -f verrazzano.yaml
+
+# Convert a Verrazzano custom resource from stdin to the v1beta1 API and write it to a file
+cat verrazzano.yaml | vz convert -f - --to-version v1beta1 -o verrazzano-v1beta1.yaml`
+
+	toVersionFlag        = "to-version"
+	toVersionFlagDefault = "v1"
+	toVersionFlagHelp    = "The API version to convert to: v1alpha1, v1beta1 or v1"
+	outputFlag           = "output"
+	outputFlagShorthand  = "o"
+	outputFlagHelp       = "Path to the file to write the converted resources to. The default is stdout."
+	filenameFlagHelp     = "Path to the file containing the Verrazzano custom resources to convert. Specifying \"-\" as the filename accepts input from stdin."
+	yamlSeparator        = "\n---\n"
+)
+
+func NewCmdConvert(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdConvert(cmd, vzHelper)
+	}
+	cmd.Example = helpExample
+	cmd.PersistentFlags().StringP(constants.FilenameFlag, constants.FilenameFlagShorthand, "", filenameFlagHelp)
+	cmd.PersistentFlags().String(toVersionFlag, toVersionFlagDefault, toVersionFlagHelp)
+	cmd.PersistentFlags().StringP(outputFlag, outputFlagShorthand, "", outputFlagHelp)
+	_ = cmd.MarkPersistentFlagRequired(constants.FilenameFlag)
+
+	return cmd
+}
+
+// runCmdConvert - run the "vz convert" command
+func runCmdConvert(cmd *cobra.Command, vzHelper helpers.VZHelper) error {
+	filename, err := cmd.PersistentFlags().GetString(constants.FilenameFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", constants.FilenameFlag, err.Error())
+	}
+	toVersion, err := cmd.PersistentFlags().GetString(toVersionFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", toVersionFlag, err.Error())
+	}
+	output, err := cmd.PersistentFlags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", outputFlag, err.Error())
+	}
+
+	var data []byte
+	if filename == "-" {
+		data, err = io.ReadAll(vzHelper.GetInputStream())
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read the Verrazzano custom resources: %s", err.Error())
+	}
+
+	var converted []string
+	for _, doc := range strings.Split(string(data), yamlSeparator) {
+		if strings.TrimSpace(doc) == "" || strings.TrimSpace(doc) == "---" {
+			continue
+		}
+		result, err := convertVerrazzano([]byte(doc), toVersion)
+		if err != nil {
+			return err
+		}
+		converted = append(converted, string(result))
+	}
+	result := strings.Join(converted, "---\n")
+
+	if output == "" {
+		_, _ = fmt.Fprint(vzHelper.GetOutputStream(), result)
+		return nil
+	}
+	if err := os.WriteFile(output, []byte(result), 0600); err != nil {
+		return fmt.Errorf("Failed to write the converted Verrazzano custom resources to %s: %s", output, err.Error())
+	}
+	return nil
+}
+
+// convertVerrazzano converts a Verrazzano custom resource to the given API version, through the v1beta1 hub version
+func convertVerrazzano(data []byte, toVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("Failed to parse the Verrazzano custom resource: %s", err.Error())
+	}
+	if typeMeta.Kind != "Verrazzano" {
+		return nil, fmt.Errorf("The resource of kind %s is not a Verrazzano custom resource", typeMeta.Kind)
+	}
+
+	hub := &v1beta1.Verrazzano{}
+	switch typeMeta.APIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		vz := &v1alpha1.Verrazzano{}
+		if err := yaml.UnmarshalStrict(data, vz); err != nil {
+			return nil, fmt.Errorf("Failed to parse the Verrazzano custom resource: %s", err.Error())
+		}
+		if err := vz.ConvertTo(hub); err != nil {
+			return nil, err
+		}
+	case v1beta1.SchemeGroupVersion.String():
+		if err := yaml.UnmarshalStrict(data, hub); err != nil {
+			return nil, fmt.Errorf("Failed to parse the Verrazzano custom resource: %s", err.Error())
+		}
+	case installv1.SchemeGroupVersion.String():
+		vz := &installv1.Verrazzano{}
+		if err := yaml.UnmarshalStrict(data, vz); err != nil {
+			return nil, fmt.Errorf("Failed to parse the Verrazzano custom resource: %s", err.Error())
+		}
+		if err := vz.ConvertTo(hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("The API version %s of the Verrazzano custom resource is not supported", typeMeta.APIVersion)
+	}
+
+	var out interface{}
+	switch toVersion {
+	case v1alpha1.SchemeGroupVersion.Version:
+		vz := &v1alpha1.Verrazzano{}
+		if err := vz.ConvertFrom(hub); err != nil {
+			return nil, err
+		}
+		vz.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: typeMeta.Kind}
+		out = vz
+	case v1beta1.SchemeGroupVersion.Version:
+		hub.TypeMeta = metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: typeMeta.Kind}
+		out = hub
+	case installv1.SchemeGroupVersion.Version:
+		vz := &installv1.Verrazzano{}
+		if err := vz.ConvertFrom(hub); err != nil {
+			return nil, err
+		}
+		vz.TypeMeta = metav1.TypeMeta{APIVersion: installv1.SchemeGroupVersion.String(), Kind: typeMeta.Kind}
+		out = vz
+	default:
+		return nil, fmt.Errorf("The API version %s is not supported, valid versions are v1alpha1, v1beta1 and v1", toVersion)
+	}
+	return marshalResource(out)
+}
+
+// marshalResource marshals a resource to YAML, without the empty metadata and status fields of the typed resource
+func marshalResource(resource interface{}) ([]byte, error) {
+	data, err := yaml.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok && metadata["creationTimestamp"] == nil {
+		delete(metadata, "creationTimestamp")
+	}
+	if status, ok := fields["status"].(map[string]interface{}); ok && len(status) == 0 {
+		delete(fields, "status")
+	}
+	return yaml.Marshal(fields)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package convert
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	installv1 "github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+)
+
+const v1beta1Verrazzano = `apiVersion: install.verrazzano.io/v1beta1
+kind: Verrazzano
+metadata:
+  name: verrazzano
+  namespace: default
+spec:
+  profile: dev
+  components:
+    certManager:
+      certificate:
+        ca:
+          clusterResourceNamespace: cert-manager
+          secretName: my-ca
+`
+
+const v1alpha1Verrazzano = `apiVersion: install.verrazzano.io/v1alpha1
+kind: Verrazzano
+metadata:
+  name: verrazzano
+spec:
+  profile: prod
+  components:
+    elasticsearch:
+      enabled: false
+`
+
+// runConvert runs the convert command with the given input and arguments, returning the output
+func runConvert(t *testing.T, input string, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: strings.NewReader(input), Out: buf, ErrOut: errBuf})
+	cmd := NewCmdConvert(rc)
+	cmd.SetArgs(append([]string{"-f", "-"}, args...))
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// TestConvertV1beta1ToV1 tests converting a v1beta1 Verrazzano resource using deprecated fields to v1 and back
+// GIVEN a v1beta1 Verrazzano resource with the deprecated cert-manager certificate
+//
+//	WHEN I run the command vz convert to v1, then back to v1beta1
+//	THEN expect the certificate to be preserved in an annotation of the v1 resource, and restored in the v1beta1 resource
+func TestConvertV1beta1ToV1(t *testing.T) {
+	result, err := runConvert(t, v1beta1Verrazzano)
+	assert.NoError(t, err)
+	vz := &installv1.Verrazzano{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(result), vz))
+	assert.Equal(t, "install.verrazzano.io/v1", vz.APIVersion)
+	assert.Equal(t, installv1.Dev, vz.Spec.Profile)
+	assert.Contains(t, vz.Annotations[installv1.CertificateAnnotation], "my-ca")
+	assert.NotContains(t, result, "status")
+	assert.NotContains(t, result, "creationTimestamp")
+
+	result, err = runConvert(t, result, "--to-version", "v1beta1")
+	assert.NoError(t, err)
+	vzBeta := &v1beta1.Verrazzano{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(result), vzBeta))
+	assert.Empty(t, vzBeta.Annotations)
+	assert.Equal(t, "my-ca", vzBeta.Spec.Components.CertManager.Certificate.CA.SecretName)
+}
+
+// TestConvertMultipleResources tests converting a file with several Verrazzano resources
+// GIVEN a file with a v1alpha1 and a v1beta1 Verrazzano resource
+//
+//	WHEN I run the command vz convert with an output file
+//	THEN expect both resources to be written to the file as v1 resources
+func TestConvertMultipleResources(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "vz.yaml")
+	result, err := runConvert(t, v1alpha1Verrazzano+"---\n"+v1beta1Verrazzano, "-o", output)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	docs := strings.Split(string(data), "---\n")
+	assert.Len(t, docs, 2)
+	vz := &installv1.Verrazzano{}
+	assert.NoError(t, yaml.UnmarshalStrict([]byte(docs[0]), vz))
+	assert.Equal(t, installv1.Prod, vz.Spec.Profile)
+	assert.False(t, *vz.Spec.Components.OpenSearch.Enabled)
+}
+
+// TestConvertInvalidVersion tests converting to an API version which does not exist
+// GIVEN a v1beta1 Verrazzano resource
+//
+//	WHEN I run the command vz convert to the v2 version
+//	THEN expect an error
+func TestConvertInvalidVersion(t *testing.T) {
+	_, err := runConvert(t, v1beta1Verrazzano, "--to-version", "v2")
+	assert.ErrorContains(t, err, "The API version v2 is not supported")
+}
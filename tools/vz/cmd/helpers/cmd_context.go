@@ -61,6 +61,11 @@ func (rc *RootCmdContext) GetKubeClient(cmd *cobra.Command) (kubernetes.Interfac
 	return kubernetes.NewForConfig(config)
 }
 
+// GetKubeConfig - return the Kubernetes client configuration, used to execute commands in pods
+func (rc *RootCmdContext) GetKubeConfig(cmd *cobra.Command) (*rest.Config, error) {
+	return getKubeConfigGivenCommand(cmd)
+}
+
 // GetDynamicClient - return a dynamic clientset for use with the go-client
 func (rc *RootCmdContext) GetDynamicClient(cmd *cobra.Command) (dynamic.Interface, error) {
 	config, err := getKubeConfigGivenCommand(cmd)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/verrazzano/verrazzano/pkg/kubectlutil"
+	vzprofiles "github.com/verrazzano/verrazzano/pkg/profiles"
 	"github.com/verrazzano/verrazzano/pkg/semver"
 	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
@@ -16,6 +17,7 @@ import (
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/constants"
 	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
 	"helm.sh/helm/v3/pkg/strvals"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +35,11 @@ const (
 	CommandName = "install"
 	helpShort   = "Install Verrazzano"
 	helpLong    = `Install the Verrazzano Platform Operator and install the Verrazzano components specified by the Verrazzano CR provided on the command line`
+
+	// profilePath is the path of the profile property set by the profile flag
+	profilePath = "spec.profile"
+
+	dryRunFlagHelp = "Validate the Verrazzano resource, including the install overrides of the components, without installing Verrazzano. The Verrazzano platform operator must be installed."
 )
 
 var helpExample = fmt.Sprintf(`
@@ -42,9 +49,15 @@ vz install
 # Install version %[1]s using a dev profile, timeout the command after 20 minutes.
 vz install --version v%[1]s --set profile=dev --timeout 20m
 
+# Install version %[1]s using the custom profile small-dev, defined by a profile ConfigMap in the verrazzano-install namespace.
+vz install --version v%[1]s --profile small-dev
+
 # Install version %[1]s using a dev profile with kiali disabled and wait for the install to complete.
 vz install --version v%[1]s --set profile=dev --set components.kiali.enabled=false
 
+# Validate a Verrazzano CR, including the install overrides of the components, with the Verrazzano platform operator already installed.
+vz install -f custom.yaml --dry-run
+
 # Install the latest version of Verrazzano using CR overlays and explicit value sets.  Output the logs in json format.
 # The overlay files can be a comma-separated list or a series of -f options.  Both formats are shown.
 vz install -f base.yaml,custom.yaml --set profile=prod --log-format json
@@ -92,6 +105,7 @@ func NewCmdInstall(vzHelper helpers.VZHelper) *cobra.Command {
 	cmd.PersistentFlags().StringSliceP(constants.FilenameFlag, constants.FilenameFlagShorthand, []string{}, constants.FilenameFlagHelp)
 	cmd.PersistentFlags().Var(&logsEnum, constants.LogFormatFlag, constants.LogFormatHelp)
 	cmd.PersistentFlags().StringArrayP(constants.SetFlag, constants.SetFlagShorthand, []string{}, constants.SetFlagHelp)
+	cmd.PersistentFlags().String(constants.ProfileFlag, "", constants.ProfileFlagHelp)
 	cmd.PersistentFlags().Bool(constants.AutoBugReportFlag, constants.AutoBugReportFlagDefault, constants.AutoBugReportFlagHelp)
 	// Private registry support
 	cmd.PersistentFlags().String(constants.ImageRegistryFlag, constants.ImageRegistryFlagDefault, constants.ImageRegistryFlagHelp)
@@ -103,9 +117,7 @@ func NewCmdInstall(vzHelper helpers.VZHelper) *cobra.Command {
 	// Add flags related to specifying the platform operator manifests as a local file or a URL
 	cmdhelpers.AddManifestsFlags(cmd)
 
-	// Dry run flag is still being discussed - keep hidden for now
-	cmd.PersistentFlags().Bool(constants.DryRunFlag, false, "Simulate an install.")
-	cmd.PersistentFlags().MarkHidden(constants.DryRunFlag)
+	cmd.PersistentFlags().Bool(constants.DryRunFlag, false, dryRunFlagHelp)
 
 	// Hide the flag for overriding the default wait timeout for the platform-operator
 	cmd.PersistentFlags().MarkHidden(constants.VPOTimeoutFlag)
@@ -163,8 +175,16 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 		return err
 	}
 
+	dryRun, err := cmd.PersistentFlags().GetBool(constants.DryRunFlag)
+	if err != nil {
+		return err
+	}
+
 	// Check to see if we have a vz resource already deployed
 	existingvz, _ := helpers.FindVerrazzanoResource(client)
+	if existingvz != nil && dryRun {
+		return fmt.Errorf("Only one install of Verrazzano is allowed")
+	}
 	if existingvz != nil {
 		// Allow install command to continue if an install is in progress and the same version is specified.
 		// For example, control-C was entered and the install command is run again.
@@ -212,6 +232,15 @@ func runCmdInstall(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper)
 			return err
 		}
 
+		// Validate the profile before installing the platform operator
+		if err := validateProfile(cmd, client); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return dryRunInstall(cmd, vzHelper, vz, client, obj)
+		}
+
 		// Delete leftover verrazzano-platform-operator deployments after an abort.
 		// This allows for the verrazzano-platform-operator validatingWebhookConfiguration to be updated with the correct caBundle.
 		err = cmdhelpers.DeleteFunc(client)
@@ -278,6 +307,27 @@ func installVerrazzano(cmd *cobra.Command, vzHelper helpers.VZHelper, vz clipkg.
 	return nil
 }
 
+// dryRunInstall validates the verrazzano install resource with the webhooks of the installed platform operator, which
+// validate the install overrides of the components against the values schemas of their Helm charts. The resource is not
+// created.
+func dryRunInstall(cmd *cobra.Command, vzHelper helpers.VZHelper, vz clipkg.Object, client clipkg.Client, obj *unstructured.Unstructured) error {
+	var errorArray = ValidateCRFunc(cmd, obj, vzHelper)
+	if len(errorArray) != 0 {
+		return fmt.Errorf("was unable to validate the given CR, the following error(s) occurred: \"%v\"", errorArray)
+	}
+
+	// The webhooks return the warnings about unknown override values, they are written to the error stream
+	err := client.Create(context.TODO(), vz, clipkg.DryRunAll)
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("The Verrazzano platform operator must be installed to validate the verrazzano install resource: %s", err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("The verrazzano install resource is not valid: %s", err.Error())
+	}
+	fmt.Fprintf(vzHelper.GetOutputStream(), fmt.Sprintf("The verrazzano install resource %s/%s is valid\n", vz.GetNamespace(), vz.GetName()))
+	return nil
+}
+
 // getVerrazzanoYAML returns the verrazzano install resource to be created
 func getVerrazzanoYAML(cmd *cobra.Command, vzHelper helpers.VZHelper, version string) (vz clipkg.Object, obj *unstructured.Unstructured, err error) {
 	// Get the list yaml filenames specified
@@ -292,6 +342,17 @@ func getVerrazzanoYAML(cmd *cobra.Command, vzHelper helpers.VZHelper, version st
 		return nil, nil, err
 	}
 
+	// The profile flag is set like the profile property of the set flags
+	if cmd.PersistentFlags().Changed(constants.ProfileFlag) {
+		if _, ok := pvs[profilePath]; ok {
+			return nil, nil, fmt.Errorf("--%s and --%s profile cannot both be specified", constants.ProfileFlag, constants.SetFlag)
+		}
+		pvs[profilePath], err = cmd.PersistentFlags().GetString(constants.ProfileFlag)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// If no yamls files were passed on the command line then create a minimal verrazzano
 	// resource.  The minimal resource is used to create a resource called verrazzano
 	// in the default namespace using the prod profile.
@@ -418,6 +479,20 @@ func validateCmd(cmd *cobra.Command) error {
 	return nil
 }
 
+// validateProfile - validate that the profile flag is a built-in profile, or a custom profile defined by a ConfigMap
+// in the verrazzano-install namespace
+func validateProfile(cmd *cobra.Command, client clipkg.Client) error {
+	if !cmd.PersistentFlags().Changed(constants.ProfileFlag) {
+		return nil
+	}
+	profile, err := cmd.PersistentFlags().GetString(constants.ProfileFlag)
+	if err != nil {
+		return err
+	}
+	_, _, err = vzprofiles.ResolveCustomProfile(profile, vzprofiles.NewConfigMapProfileGetter(client))
+	return err
+}
+
 // validateCR - validates a Custom Resource before proceeding with an install
 func ValidateCR(cmd *cobra.Command, obj *unstructured.Unstructured, vzHelper helpers.VZHelper) []error {
 	discoveryClient, err := vzHelper.GetDiscoveryClient(cmd)
@@ -19,6 +19,7 @@ import (
 	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -369,6 +370,93 @@ func TestInstallCmdSets(t *testing.T) {
 	assert.Equal(t, "test", vz.Spec.EnvironmentName)
 }
 
+// TestInstallCmdCustomProfile
+// GIVEN a CLI install command with defaults and --wait=false and --profile specified with a custom profile
+//
+//	WHEN I call cmd.Execute for install
+//	THEN the CLI install command is successful and the Verrazzano resource uses the custom profile
+func TestInstallCmdCustomProfile(t *testing.T) {
+	profileCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "small-dev",
+			Namespace: vzconstants.VerrazzanoInstallNamespace,
+			Labels:    map[string]string{vzconstants.VerrazzanoProfileLabel: "small-dev"},
+		},
+		Data: map[string]string{"extends": "dev"},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(append(testhelpers.CreateTestVPOObjects(), profileCM)...).Build()
+	cmd, _, errBuf, _ := createNewTestCommandAndBuffers(t, c)
+	cmd.PersistentFlags().Set(constants.ProfileFlag, "small-dev")
+	cmd.PersistentFlags().Set(constants.WaitFlag, "false")
+	cmdHelpers.SetDeleteFunc(cmdHelpers.FakeDeleteFunc)
+	defer cmdHelpers.SetDefaultDeleteFunc()
+
+	cmdHelpers.SetVPOIsReadyFunc(func(_ client.Client) (bool, error) { return true, nil })
+	defer cmdHelpers.SetDefaultVPOIsReadyFunc()
+
+	SetValidateCRFunc(FakeValidateCRFunc)
+	defer SetDefaultValidateCRFunc()
+
+	// Run install command
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "", errBuf.String())
+
+	// Verify the vz resource is as expected
+	vz := v1alpha1.Verrazzano{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &vz)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ProfileType("small-dev"), vz.Spec.Profile)
+}
+
+// TestInstallCmdDryRun
+// GIVEN a CLI install command with --dry-run specified
+//
+//	WHEN I call cmd.Execute for install
+//	THEN the verrazzano install resource is validated, and neither the platform operator nor the resource are created
+func TestInstallCmdDryRun(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).Build()
+	cmd, buf, errBuf, _ := createNewTestCommandAndBuffers(t, c)
+	cmd.PersistentFlags().Set(constants.DryRunFlag, "true")
+	cmd.PersistentFlags().Set(constants.SetFlag, "profile=dev")
+
+	SetValidateCRFunc(FakeValidateCRFunc)
+	defer SetDefaultValidateCRFunc()
+
+	// Run install command
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "", errBuf.String())
+	assert.Contains(t, buf.String(), "The verrazzano install resource default/verrazzano is valid")
+
+	// Verify the vz resource and the platform operator were not created
+	vz := v1alpha1.Verrazzano{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "verrazzano"}, &vz)
+	assert.True(t, errors.IsNotFound(err))
+	deployment := appsv1.Deployment{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: vzconstants.VerrazzanoInstallNamespace, Name: constants.VerrazzanoPlatformOperator}, &deployment)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+// TestInstallCmdInvalidProfile
+// GIVEN a CLI install command with --profile specified with a profile which does not exist, or also specified with --set
+//
+//	WHEN I call cmd.Execute for install
+//	THEN the CLI install command fails
+func TestInstallCmdInvalidProfile(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(testhelpers.CreateTestVPOObjects()...).Build()
+	cmd, _, _, _ := createNewTestCommandAndBuffers(t, c)
+	cmd.PersistentFlags().Set(constants.ProfileFlag, "missing")
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "The profile missing is not a built-in profile")
+
+	cmd, _, _, _ = createNewTestCommandAndBuffers(t, c)
+	cmd.PersistentFlags().Set(constants.ProfileFlag, "dev")
+	cmd.PersistentFlags().Set(constants.SetFlag, "profile=prod")
+	err = cmd.Execute()
+	assert.ErrorContains(t, err, "--profile and --set profile cannot both be specified")
+}
+
 // TestInstallCmdFilenamesAndSets
 // GIVEN a CLI install command with defaults and --wait=false and --filename and --set specified
 //
@@ -0,0 +1,133 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package restore
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	osapi "github.com/verrazzano/verrazzano/pkg/opensearch"
+	cmdhelpers "github.com/verrazzano/verrazzano/tools/vz/cmd/helpers"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+)
+
+const (
+	CommandName = "restore"
+	helpShort   = "List and restore OpenSearch snapshots"
+	helpLong    = `The command 'restore' lists the snapshots of the Verrazzano OpenSearch cluster, or restores the indices of a named snapshot.
+The snapshot repository is the one configured in the Verrazzano resource, unless the --repository flag is specified.
+Open indices cannot be restored, close or delete them first, or restore them under new names with the --rename-prefix flag.`
+	helpExample = `
+# List the snapshots of the OpenSearch cluster
+vz restore
+
+# Restore the Verrazzano indices of a snapshot under new names
+vz restore daily-2023-06-01-02-00 --rename-prefix restored-
+
+# Restore the indices of an application from a snapshot of another repository
+vz restore daily-2023-06-01-02-00 --repository my-repo --indices "verrazzano-application-hello*"`
+
+	repositoryFlag       = "repository"
+	repositoryFlagHelp   = "The snapshot repository. The default is the repository configured in the Verrazzano resource."
+	indicesFlag          = "indices"
+	indicesFlagDefault   = "verrazzano-*"
+	indicesFlagHelp      = "The pattern of the indices to restore"
+	renamePrefixFlag     = "rename-prefix"
+	renamePrefixFlagHelp = "A prefix added to the names of the restored indices"
+)
+
+func NewCmdRestore(vzHelper helpers.VZHelper) *cobra.Command {
+	cmd := cmdhelpers.NewCommand(vzHelper, CommandName, helpShort, helpLong)
+	cmd.Use = "restore [snapshot]"
+	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runCmdRestore(cmd, args, vzHelper)
+	}
+	cmd.Example = helpExample
+	cmd.PersistentFlags().String(repositoryFlag, "", repositoryFlagHelp)
+	cmd.PersistentFlags().String(indicesFlag, indicesFlagDefault, indicesFlagHelp)
+	cmd.PersistentFlags().String(renamePrefixFlag, "", renamePrefixFlagHelp)
+
+	return cmd
+}
+
+// runCmdRestore - run the "vz restore" command, listing the snapshots when no snapshot is specified
+func runCmdRestore(cmd *cobra.Command, args []string, vzHelper helpers.VZHelper) error {
+	repo, err := getRepository(cmd, vzHelper)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := vzHelper.GetKubeClient(cmd)
+	if err != nil {
+		return err
+	}
+	config, err := vzHelper.GetKubeConfig(cmd)
+	if err != nil {
+		return err
+	}
+	snapshotClient := osapi.NewClient(kubeClient, config)
+
+	if len(args) == 0 {
+		return listSnapshots(vzHelper, snapshotClient, repo)
+	}
+	indices, err := cmd.PersistentFlags().GetString(indicesFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", indicesFlag, err.Error())
+	}
+	renamePrefix, err := cmd.PersistentFlags().GetString(renamePrefixFlag)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the command line option %s: %s", renamePrefixFlag, err.Error())
+	}
+	if err := snapshotClient.RestoreSnapshot(repo, args[0], indices, renamePrefix); err != nil {
+		return fmt.Errorf("Failed to restore snapshot %s from repository %s: %s", args[0], repo, err.Error())
+	}
+	fmt.Fprintf(vzHelper.GetOutputStream(), "Started restoring the indices %s of snapshot %s from repository %s\n", indices, args[0], repo)
+	return nil
+}
+
+// listSnapshots prints the snapshots of a repository
+func listSnapshots(vzHelper helpers.VZHelper, snapshotClient *osapi.Client, repo string) error {
+	snapshots, err := snapshotClient.GetSnapshots(repo)
+	if err != nil {
+		return fmt.Errorf("Failed to list the snapshots of repository %s: %s", repo, err.Error())
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintf(vzHelper.GetOutputStream(), "No snapshots found in repository %s\n", repo)
+		return nil
+	}
+	w := tabwriter.NewWriter(vzHelper.GetOutputStream(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SNAPSHOT\tSTATE\tSTART TIME\tINDICES")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", snapshot.Snapshot, snapshot.State, snapshot.StartTime, len(snapshot.Indices))
+	}
+	return w.Flush()
+}
+
+// getRepository returns the repository specified on the command line, or the repository configured in the
+// Verrazzano resource
+func getRepository(cmd *cobra.Command, vzHelper helpers.VZHelper) (string, error) {
+	repo, err := cmd.PersistentFlags().GetString(repositoryFlag)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse the command line option %s: %s", repositoryFlag, err.Error())
+	}
+	if repo != "" {
+		return repo, nil
+	}
+	client, err := vzHelper.GetClient(cmd)
+	if err != nil {
+		return "", err
+	}
+	vz, err := helpers.FindVerrazzanoResource(client)
+	if err != nil {
+		return "", err
+	}
+	if vz.Status.OpenSearchSnapshots != nil && vz.Status.OpenSearchSnapshots.Repository != "" {
+		return vz.Status.OpenSearchSnapshots.Repository, nil
+	}
+	if opensearch := vz.Spec.Components.OpenSearch; opensearch != nil && opensearch.Snapshots != nil && opensearch.Snapshots.Repository.Name != "" {
+		return opensearch.Snapshots.Repository.Name, nil
+	}
+	return osapi.DefaultSnapshotRepository, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023, Oracle and/or its affiliates.
+// Licensed under the Universal Permissive License v 1.0 as shown at https://oss.oracle.com/licenses/upl.
+
+package restore
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/verrazzano/verrazzano/pkg/k8sutil"
+	k8sutilfake "github.com/verrazzano/verrazzano/pkg/k8sutil/fake"
+	"github.com/verrazzano/verrazzano/platform-operator/apis/verrazzano/v1beta1"
+	"github.com/verrazzano/verrazzano/tools/vz/pkg/helpers"
+	testhelpers "github.com/verrazzano/verrazzano/tools/vz/test/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// runRestore runs the restore command with the given arguments against a cluster with a Verrazzano resource using
+// the backups snapshot repository, returning the output and the OpenSearch commands executed
+func runRestore(t *testing.T, args ...string) (string, []string, error) {
+	var commands []string
+	k8sutil.NewPodExecutor = k8sutilfake.NewPodExecutor
+	k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) {
+		command := url.Query()["command"][2]
+		commands = append(commands, command)
+		if strings.Contains(command, "_restore") {
+			return "{\"accepted\":true}\n200", "", nil
+		}
+		return "{\"snapshots\":[{\"snapshot\":\"daily-1\",\"state\":\"SUCCESS\",\"start_time\":\"2023-06-01T02:00:00.000Z\",\"indices\":[\"verrazzano-system\"]}]}\n200", "", nil
+	}
+	t.Cleanup(func() {
+		k8sutil.NewPodExecutor = remotecommand.NewSPDYExecutor
+		k8sutilfake.PodExecResult = func(url *url.URL) (string, string, error) { return "", "", nil }
+	})
+
+	vz := &v1beta1.Verrazzano{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "verrazzano"},
+		Status: v1beta1.VerrazzanoStatus{
+			OpenSearchSnapshots: &v1beta1.OpenSearchSnapshotStatus{Repository: "backups"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(helpers.NewScheme()).WithObjects(vz).Build()
+	_, kubeClient := k8sutilfake.NewClientsetConfig(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "verrazzano-system", Name: "vmi-system-es-master-0", Labels: map[string]string{"app": "system-es-master"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	})
+
+	buf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	rc := testhelpers.NewFakeRootCmdContext(genericclioptions.IOStreams{In: os.Stdin, Out: buf, ErrOut: errBuf})
+	rc.SetClient(c)
+	rc.SetKubeClient(kubeClient)
+	cmd := NewCmdRestore(rc)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), commands, err
+}
+
+// TestListSnapshots tests listing the snapshots
+// GIVEN a Verrazzano resource using the backups snapshot repository
+//
+//	WHEN I run the command vz restore
+//	THEN expect the snapshots of the backups repository to be listed
+func TestListSnapshots(t *testing.T) {
+	result, commands, err := runRestore(t)
+	assert.NoError(t, err)
+	assert.Len(t, commands, 1)
+	assert.Contains(t, commands[0], "-X GET 'http://localhost:9200/_snapshot/backups/_all'")
+	expected := `SNAPSHOT  STATE    START TIME                INDICES
+daily-1   SUCCESS  2023-06-01T02:00:00.000Z  1
+`
+	assert.Equal(t, expected, result)
+}
+
+// TestRestoreSnapshot tests restoring a snapshot
+// GIVEN a snapshot of another repository
+//
+//	WHEN I run the command vz restore with the snapshot name, a repository and a rename prefix
+//	THEN expect the snapshot to be restored from the repository under new index names
+func TestRestoreSnapshot(t *testing.T) {
+	result, commands, err := runRestore(t, "daily-1", "--"+repositoryFlag, "other", "--"+renamePrefixFlag, "restored-")
+	assert.NoError(t, err)
+	assert.Len(t, commands, 1)
+	assert.Contains(t, commands[0], "-X POST 'http://localhost:9200/_snapshot/other/daily-1/_restore'")
+	assert.Contains(t, commands[0], `"indices":"verrazzano-*","rename_pattern":"(.+)","rename_replacement":"restored-$1"`)
+	assert.Equal(t, fmt.Sprintf("Started restoring the indices verrazzano-* of snapshot daily-1 from repository other\n"), result)
+}
@@ -11,9 +11,12 @@ import (
 	"testing"
 
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/analyze"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/app"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/bugreport"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/convert"
 
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/install"
+	"github.com/verrazzano/verrazzano/tools/vz/cmd/restore"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/uninstall"
 	"github.com/verrazzano/verrazzano/tools/vz/cmd/upgrade"
 
@@ -34,7 +37,7 @@ func TestNewRootCmd(t *testing.T) {
 	assert.NotNil(t, rootCmd)
 
 	// Verify the expected commands are defined
-	assert.Len(t, rootCmd.Commands(), 7)
+	assert.Len(t, rootCmd.Commands(), 10)
 	foundCount := 0
 	for _, cmd := range rootCmd.Commands() {
 		switch cmd.Name() {
@@ -52,9 +55,15 @@ func TestNewRootCmd(t *testing.T) {
 			foundCount++
 		case bugreport.CommandName:
 			foundCount++
+		case app.CommandName:
+			foundCount++
+		case restore.CommandName:
+			foundCount++
+		case convert.CommandName:
+			foundCount++
 		}
 	}
-	assert.Equal(t, 7, foundCount)
+	assert.Equal(t, 10, foundCount)
 
 	// Verify the expected global flags are defined
 	assert.NotNil(t, rootCmd.PersistentFlags().Lookup(constants.GlobalFlagKubeConfig))
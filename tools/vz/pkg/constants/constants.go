@@ -28,6 +28,8 @@ const (
 	SetFlag                  = "set"
 	SetFlagShorthand         = "s"
 	SetFlagHelp              = "Override a Verrazzano resource value (e.g. --set profile=dev).  This flag can be specified multiple times."
+	ProfileFlag              = "profile"
+	ProfileFlagHelp          = "The installation profile of Verrazzano: dev, prod, managed-cluster, none, or a custom profile defined by a ConfigMap with the label install.verrazzano.io/profile in the verrazzano-install namespace."
 	OperatorFileFlag         = "operator-file" // an alias for the manifests flag
 	OperatorFileDeprecateMsg = "Use --manifests instead"
 	ManifestsFlag            = "manifests"
@@ -55,6 +57,10 @@ const (
 	AutoBugReportFlag        = "auto-bug-report"
 	AutoBugReportFlagDefault = true
 	AutoBugReportFlagHelp    = "Automatically call vz bug-report if command fails"
+	NamespaceFlag            = "namespace"
+	NamespaceFlagShorthand   = "n"
+	NamespaceFlagDefault     = "default"
+	NamespaceFlagHelp        = "The namespace of the resource"
 	VzAnalysisReportTmpFile  = "details-*.out"
 	// DatetimeFormat - suffix to vz bug report file in yyyymmddhhmmss format
 	DatetimeFormat = "20060102150405"
@@ -77,6 +83,8 @@ const VerrazzanoMysqlInstallValuesWebhook = "verrazzano-platform-mysqlinstallove
 
 const VerrazzanoRequirementsValidatorWebhook = "verrazzano-platform-requirements-validator"
 
+const VerrazzanoHelmValuesValidatorWebhook = "verrazzano-platform-helm-values-validator"
+
 const VerrazzanoApplicationOperator = "verrazzano-application-operator"
 
 const VerrazzanoClusterOperator = "verrazzano-cluster-operator"
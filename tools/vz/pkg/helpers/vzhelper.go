@@ -34,6 +34,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -51,6 +52,7 @@ type VZHelper interface {
 	GetHTTPClient() *http.Client
 	GetDynamicClient(cmd *cobra.Command) (dynamic.Interface, error)
 	GetDiscoveryClient(cmd *cobra.Command) (discovery.DiscoveryInterface, error)
+	GetKubeConfig(cmd *cobra.Command) (*rest.Config, error)
 }
 
 type ReportCtx struct {
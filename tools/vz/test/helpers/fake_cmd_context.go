@@ -22,6 +22,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -62,6 +63,16 @@ func (rc *FakeRootCmdContext) SetClient(client client.Client) {
 	rc.client = client
 }
 
+// SetKubeClient - set the Kubernetes clientset
+func (rc *FakeRootCmdContext) SetKubeClient(kubeClient kubernetes.Interface) {
+	rc.kubeClient = kubeClient
+}
+
+// GetKubeConfig - return an empty Kubernetes client configuration for use with the fake pod executor
+func (rc *FakeRootCmdContext) GetKubeConfig(cmd *cobra.Command) (*rest.Config, error) {
+	return &rest.Config{}, nil
+}
+
 // RoundTripFunc - define the type for the Transport function
 type RoundTripFunc func(req *http.Request) *http.Response
 